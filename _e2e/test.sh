@@ -19,23 +19,183 @@ RESPONSE=$(curl -s -X 'GET' "$URI")
 echo $RESPONSE | jq -Cc
 if [ $? -ne 0 ] || [ "$(echo $RESPONSE | jq -r '.error')" != "null" ]; then exit 1; fi
 
+# 本人でなければユーザーの有無にかかわらず拒否する
 URI=$ADDR/user/$USER_ID
-RESPONSE=$(curl -s -X 'PUT' -d '{"user":{"name":"Alice","password":"12345678"}}' "$URI")
+RESPONSE=$(curl -s -X 'PUT' -d '{"user":{"name":"Alice","password":"87654321","current_password":"12345678"}}' "$URI")
+echo "\tPUT $URI"
+echo $RESPONSE | jq -Cc
+if [ "$(echo $RESPONSE | jq -r '.error.code')" != "forbidden" ]; then exit 1; fi
+
+URI=$ADDR/user/0$USER_ID$USER_ID
+RESPONSE=$(curl -s -X 'PUT' -d '{"user":{"name":"Alice","password":"87654321","current_password":"12345678"}}' "$URI")
+echo "\tPUT $URI"
+echo $RESPONSE | jq -Cc
+if [ "$(echo $RESPONSE | jq -r '.error.code')" != "forbidden" ]; then exit 1; fi
+
+URI="$ADDR/session"
+echo "\tPOST $URI"
+RESPONSE=$(curl -s -X 'POST' -d '{"session":{"name":"Bob","password":"12345678"}}' "$URI")
+echo $RESPONSE | jq -Cc
+if [ $? -ne 0 ] || [ "$(echo $RESPONSE | jq -r '.error')" != "null" ]; then exit 1; fi
+
+USER_TOKEN=$(echo $RESPONSE | jq -r '.session.token')
+
+URI=$ADDR/user/$USER_ID
+RESPONSE=$(curl -s -H "Authorization: Bearer $USER_TOKEN" -X 'PUT' -d '{"user":{"name":"Alice","password":"12345678","current_password":"wrongpassword"}}' "$URI")
+echo "\tPUT $URI"
+echo $RESPONSE | jq -Cc
+if [ "$(echo $RESPONSE | jq -r '.error')" = "null" ]; then exit 1; fi
+
+URI=$ADDR/user/$USER_ID
+RESPONSE=$(curl -s -H "Authorization: Bearer $USER_TOKEN" -X 'PUT' -d '{"user":{"name":"Alice","password":"87654321","current_password":"12345678"}}' "$URI")
 echo "\tPUT $URI"
 echo $RESPONSE | jq -Cc
 if [ $? -ne 0 ] || [ "$(echo $RESPONSE | jq -r '.error')" != "null" ]; then exit 1; fi
 
+# パスワードの変更でログアウトしたため、再びログインする
+URI="$ADDR/session"
+echo "\tPOST $URI"
+RESPONSE=$(curl -s -X 'POST' -d '{"session":{"name":"Alice","password":"87654321"}}' "$URI")
+echo $RESPONSE | jq -Cc
+if [ $? -ne 0 ] || [ "$(echo $RESPONSE | jq -r '.error')" != "null" ]; then exit 1; fi
+
+USER_TOKEN=$(echo $RESPONSE | jq -r '.session.token')
+
 URI=$ADDR/user/$USER_ID
-RESPONSE=$(curl -s -X 'DELETE' "$URI")
+RESPONSE=$(curl -s -H "Authorization: Bearer $USER_TOKEN" -X 'DELETE' "$URI")
 echo "\tDELETE $URI"
 echo $RESPONSE | jq -Cc
+if [ "$(echo $RESPONSE | jq -r '.error')" = "null" ]; then exit 1; fi
+
+URI=$ADDR/user/$USER_ID
+RESPONSE=$(curl -s -H "Authorization: Bearer $USER_TOKEN" -X 'DELETE' -d '{"user":{"current_password":"87654321"}}' "$URI")
+echo "\tDELETE $URI"
+echo $RESPONSE | jq -Cc
+if [ $? -ne 0 ] || [ "$(echo $RESPONSE | jq -r '.error')" != "null" ]; then exit 1; fi
+
+URI="$ADDR/user/$USER_ID"
+echo "\tGET $URI"
+RESPONSE=$(curl -s -X 'GET' "$URI")
+echo $RESPONSE | jq -Cc
+if [ "$(echo $RESPONSE | jq -r '.error.code')" != "not_found" ]; then exit 1; fi
+
+URI="$ADDR/user/hoge"
+echo "\tGET $URI"
+RESPONSE=$(curl -s -X 'GET' "$URI")
+echo $RESPONSE | jq -Cc
+if [ "$(echo $RESPONSE | jq -r '.error.code')" != "invalid_argument" ]; then exit 1; fi
+
+URI="$ADDR/user"
+echo "\tPOST $URI"
+RESPONSE=$(curl -s -X 'POST' -d '{"user":{"name":"Bob","password":"123"}}' "$URI")
+echo $RESPONSE | jq -Cc
+if [ "$(echo $RESPONSE | jq -r '.error.code')" != "invalid_argument" ]; then exit 1; fi
+if [ "$(echo $RESPONSE | jq -r '.error.details[0].field')" != "user.password" ]; then exit 1; fi
+if [ "$(echo $RESPONSE | jq -r '.error.details[0].rule')" != "min_length" ]; then exit 1; fi
+
+URI="$ADDR/user"
+echo "\tPOST $URI"
+RESPONSE=$(curl -s -X 'POST' -d '{"user":{"name":"Carol","password":"12345678"}}' "$URI")
+echo $RESPONSE | jq -Cc
 if [ $? -ne 0 ] || [ "$(echo $RESPONSE | jq -r '.error')" != "null" ]; then exit 1; fi
 
+echo "\tPOST $URI"
+RESPONSE=$(curl -s -X 'POST' -d '{"user":{"name":"Carol","password":"12345678"}}' "$URI")
+echo $RESPONSE | jq -Cc
+if [ "$(echo $RESPONSE | jq -r '.error.code')" != "conflict" ]; then exit 1; fi
+if [ "$(echo $RESPONSE | jq -r '.error.details[0].field')" != "user.name" ]; then exit 1; fi
+
+# 一覧はログインが必要
+URI="$ADDR/user?name_prefix=Car&limit=1"
+echo "\tGET $URI"
+RESPONSE=$(curl -s -X 'GET' "$URI")
+echo $RESPONSE | jq -Cc
+if [ "$(echo $RESPONSE | jq -r '.error.code')" != "unauthenticated" ]; then exit 1; fi
+
+URI="$ADDR/session"
+echo "\tPOST $URI"
+RESPONSE=$(curl -s -X 'POST' -d '{"session":{"name":"Carol","password":"12345678"}}' "$URI")
+echo $RESPONSE | jq -Cc
+if [ $? -ne 0 ] || [ "$(echo $RESPONSE | jq -r '.error')" != "null" ]; then exit 1; fi
+
+CAROL_TOKEN=$(echo $RESPONSE | jq -r '.session.token')
+
+URI="$ADDR/user?name_prefix=Car&limit=1"
+echo "\tGET $URI"
+RESPONSE=$(curl -s -H "Authorization: Bearer $CAROL_TOKEN" -X 'GET' "$URI")
+echo $RESPONSE | jq -Cc
+if [ $? -ne 0 ] || [ "$(echo $RESPONSE | jq -r '.error')" != "null" ]; then exit 1; fi
+if [ "$(echo $RESPONSE | jq -r '.users[0].name')" != "Carol" ]; then exit 1; fi
+if [ "$(echo $RESPONSE | jq -r '.next_cursor')" != "" ]; then exit 1; fi
+
+URI="$ADDR/user?limit=-1"
+echo "\tGET $URI"
+RESPONSE=$(curl -s -H "Authorization: Bearer $CAROL_TOKEN" -X 'GET' "$URI")
+echo $RESPONSE | jq -Cc
+if [ "$(echo $RESPONSE | jq -r '.error.code')" != "invalid_argument" ]; then exit 1; fi
+
 # 企業
 echo "[COMPANY]"
+URI="$ADDR/user"
+echo "\tPOST $URI"
+RESPONSE=$(curl -s -X 'POST' -d '{"user":{"name":"Owner","password":"12345678"}}' "$URI")
+echo $RESPONSE | jq -Cc
+if [ $? -ne 0 ] || [ "$(echo $RESPONSE | jq -r '.error')" != "null" ]; then exit 1; fi
+
+OWNER_ID=$(echo $RESPONSE | jq -r '.user.id')
+if [ $OWNER_ID = "null" ]; then exit 1; fi
+
+URI="$ADDR/session"
+echo "\tPOST $URI"
+RESPONSE=$(curl -s -X 'POST' -d '{"session":{"name":"Owner","password":"wrongpassword"}}' "$URI")
+echo $RESPONSE | jq -Cc
+if [ "$(echo $RESPONSE | jq -r '.error')" = "null" ]; then exit 1; fi
+
+URI="$ADDR/session"
+echo "\tPOST $URI"
+RESPONSE=$(curl -s -X 'POST' -d '{"session":{"name":"Owner","password":"12345678"}}' "$URI")
+echo $RESPONSE | jq -Cc
+if [ $? -ne 0 ] || [ "$(echo $RESPONSE | jq -r '.error')" != "null" ]; then exit 1; fi
+if [ "$(echo $RESPONSE | jq -r '.session.user_id')" != "$OWNER_ID" ]; then exit 1; fi
+
+OWNER_TOKEN=$(echo $RESPONSE | jq -r '.session.token')
+if [ $OWNER_TOKEN = "null" ]; then exit 1; fi
+
+URI="$ADDR/user"
+echo "\tPOST $URI"
+RESPONSE=$(curl -s -X 'POST' -d '{"user":{"name":"NewOwner","password":"12345678"}}' "$URI")
+echo $RESPONSE | jq -Cc
+if [ $? -ne 0 ] || [ "$(echo $RESPONSE | jq -r '.error')" != "null" ]; then exit 1; fi
+
+NEW_OWNER_ID=$(echo $RESPONSE | jq -r '.user.id')
+if [ $NEW_OWNER_ID = "null" ]; then exit 1; fi
+
+URI="$ADDR/session"
+echo "\tPOST $URI"
+RESPONSE=$(curl -s -X 'POST' -d '{"session":{"name":"NewOwner","password":"12345678"}}' "$URI")
+echo $RESPONSE | jq -Cc
+if [ $? -ne 0 ] || [ "$(echo $RESPONSE | jq -r '.error')" != "null" ]; then exit 1; fi
+
+NEW_OWNER_TOKEN=$(echo $RESPONSE | jq -r '.session.token')
+if [ $NEW_OWNER_TOKEN = "null" ]; then exit 1; fi
+
+URI=$ADDR/company
+echo "\tPOST $URI"
+RESPONSE=$(curl -s -H "Authorization: Bearer $OWNER_TOKEN.tampered" -X 'POST' -d "{\"company\":{\"name\":\"GREATE COMPANY\",\"owner_id\":$OWNER_ID}}" "$URI")
+echo $RESPONSE | jq -Cc
+if [ "$(echo $RESPONSE | jq -r '.error')" = "null" ]; then exit 1; fi
+
+# 呼び出し元以外を所有者として会社を作成できない
 URI=$ADDR/company
 echo "\tPOST $URI"
-RESPONSE=$(curl -s -X 'POST' -d '{"company":{"name":"GREATE COMPANY","owner_id":1}}' "$URI")
+RESPONSE=$(curl -s -H "Authorization: Bearer $OWNER_TOKEN" -X 'POST' -d "{\"company\":{\"name\":\"GREATE COMPANY\",\"owner_id\":$NEW_OWNER_ID}}" "$URI")
+echo $RESPONSE | jq -Cc
+if [ "$(echo $RESPONSE | jq -r '.error.code')" != "forbidden" ]; then exit 1; fi
+
+# owner_id を省略すると呼び出し元が所有者になる
+URI=$ADDR/company
+echo "\tPOST $URI"
+RESPONSE=$(curl -s -H "Authorization: Bearer $OWNER_TOKEN" -X 'POST' -d '{"company":{"name":"GREATE COMPANY"}}' "$URI")
 echo $RESPONSE | jq -Cc
 if [ $? -ne 0 ] || [ "$(echo $RESPONSE | jq -r '.error')" != "null" ]; then exit 1; fi
 
@@ -44,18 +204,469 @@ if [ $COMPANY_ID = "null" ]; then exit 1; fi
 
 URI=$ADDR/company/$COMPANY_ID
 echo "\tGET $URI"
+RESPONSE=$(curl -s -H "Authorization: Bearer $OWNER_TOKEN" -X 'GET' "$URI")
+echo $RESPONSE | jq -Cc
+if [ $? -ne 0 ] || [ "$(echo $RESPONSE | jq -r '.error')" != "null" ]; then exit 1; fi
+if [ "$(echo $RESPONSE | jq -r '.company.owner_id')" != "$OWNER_ID" ]; then exit 1; fi
+
+URI="$ADDR/company?name_prefix=GREATE&limit=1"
+echo "\tGET $URI"
+RESPONSE=$(curl -s -H "Authorization: Bearer $OWNER_TOKEN" -X 'GET' "$URI")
+echo $RESPONSE | jq -Cc
+if [ $? -ne 0 ] || [ "$(echo $RESPONSE | jq -r '.error')" != "null" ]; then exit 1; fi
+if [ "$(echo $RESPONSE | jq -r '.companies[0].id')" != "$COMPANY_ID" ]; then exit 1; fi
+
+# 従業員でない会社は一覧に含まれない
+URI="$ADDR/company?name_prefix=GREATE"
+echo "\tGET $URI"
+RESPONSE=$(curl -s -H "Authorization: Bearer $NEW_OWNER_TOKEN" -X 'GET' "$URI")
+echo $RESPONSE | jq -Cc
+if [ $? -ne 0 ] || [ "$(echo $RESPONSE | jq -r '.error')" != "null" ]; then exit 1; fi
+if [ "$(echo $RESPONSE | jq -r '.companies | length')" != "0" ]; then exit 1; fi
+
+URI="$ADDR/company"
+echo "\tGET $URI"
 RESPONSE=$(curl -s -X 'GET' "$URI")
 echo $RESPONSE | jq -Cc
+if [ "$(echo $RESPONSE | jq -r '.error.code')" != "unauthenticated" ]; then exit 1; fi
+
+# ユーザーIDのヘッダーでは呼び出し元を名乗れない
+URI="$ADDR/company"
+echo "\tGET $URI"
+RESPONSE=$(curl -s -H "X-User-ID: $OWNER_ID" -X 'GET' "$URI")
+echo $RESPONSE | jq -Cc
+if [ "$(echo $RESPONSE | jq -r '.error.code')" != "unauthenticated" ]; then exit 1; fi
+
+URI=$ADDR/company/$COMPANY_ID
+echo "\tPUT $URI"
+RESPONSE=$(curl -s -H "Authorization: Bearer $OWNER_TOKEN" -X 'PUT' -d "{\"company\":{\"name\":\"greate company\",\"owner_id\":$OWNER_ID}}" "$URI")
+echo $RESPONSE | jq -Cc
+if [ $? -ne 0 ] || [ "$(echo $RESPONSE | jq -r '.error')" != "null" ]; then exit 1; fi
+if [ "$(echo $RESPONSE | jq -r '.company.name')" != "greate company" ]; then exit 1; fi
+
+# 管理者の従業員でないユーザーを所有者にできない
+URI=$ADDR/company/$COMPANY_ID
+echo "\tPUT $URI"
+RESPONSE=$(curl -s -H "Authorization: Bearer $OWNER_TOKEN" -X 'PUT' -d "{\"company\":{\"name\":\"greate company\",\"owner_id\":$NEW_OWNER_ID}}" "$URI")
+echo $RESPONSE | jq -Cc
+if [ "$(echo $RESPONSE | jq -r '.error.code')" != "conflict" ]; then exit 1; fi
+
+URI=$ADDR/company/$COMPANY_ID/employee
+echo "\tPOST $URI"
+RESPONSE=$(curl -s -H "Authorization: Bearer $OWNER_TOKEN" -X 'POST' -d "{\"employee\":{\"user_id\":$NEW_OWNER_ID}}" "$URI")
+echo $RESPONSE | jq -Cc
+if [ $? -ne 0 ] || [ "$(echo $RESPONSE | jq -r '.error')" != "null" ]; then exit 1; fi
+
+EMPLOYEE_ID=$(echo $RESPONSE | jq -r '.employee.id')
+if [ $EMPLOYEE_ID = "null" ]; then exit 1; fi
+
+URI=$ADDR/company/$COMPANY_ID/employee
+echo "\tGET $URI"
+RESPONSE=$(curl -s -H "Authorization: Bearer $OWNER_TOKEN" -X 'GET' "$URI")
+echo $RESPONSE | jq -Cc
+if [ $? -ne 0 ] || [ "$(echo $RESPONSE | jq -r '.error')" != "null" ]; then exit 1; fi
+if [ "$(echo $RESPONSE | jq -r '.employees | length')" != "2" ]; then exit 1; fi
+
+OWNER_EMPLOYEE_ID=$(echo $RESPONSE | jq -r '.employees[0].id')
+
+# 会社の所有者は従業員から削除できない
+URI=$ADDR/company/$COMPANY_ID/employee/$OWNER_EMPLOYEE_ID
+echo "\tDELETE $URI"
+RESPONSE=$(curl -s -H "Authorization: Bearer $OWNER_TOKEN" -X 'DELETE' "$URI")
+echo $RESPONSE | jq -Cc
+if [ "$(echo $RESPONSE | jq -r '.error.code')" != "conflict" ]; then exit 1; fi
+
+URI=$ADDR/company/$COMPANY_ID/employee/$EMPLOYEE_ID
+echo "\tGET $URI"
+RESPONSE=$(curl -s -H "Authorization: Bearer $OWNER_TOKEN" -X 'GET' "$URI")
+echo $RESPONSE | jq -Cc
 if [ $? -ne 0 ] || [ "$(echo $RESPONSE | jq -r '.error')" != "null" ]; then exit 1; fi
+if [ "$(echo $RESPONSE | jq -r '.employee.user_id')" != "$NEW_OWNER_ID" ]; then exit 1; fi
 
+# 管理者でない従業員は自身の情報のみ確認できる
 URI=$ADDR/company/$COMPANY_ID
+echo "\tGET $URI"
+RESPONSE=$(curl -s -H "Authorization: Bearer $NEW_OWNER_TOKEN" -X 'GET' "$URI")
+echo $RESPONSE | jq -Cc
+if [ "$(echo $RESPONSE | jq -r '.error')" = "null" ]; then exit 1; fi
+
+URI=$ADDR/company/$COMPANY_ID/employee/$EMPLOYEE_ID
+echo "\tGET $URI"
+RESPONSE=$(curl -s -H "Authorization: Bearer $NEW_OWNER_TOKEN" -X 'GET' "$URI")
+echo $RESPONSE | jq -Cc
+if [ $? -ne 0 ] || [ "$(echo $RESPONSE | jq -r '.error')" != "null" ]; then exit 1; fi
+
+URI=$ADDR/company/$COMPANY_ID/employee/$EMPLOYEE_ID
+echo "\tDELETE $URI"
+RESPONSE=$(curl -s -H "Authorization: Bearer $NEW_OWNER_TOKEN" -X 'DELETE' "$URI")
+echo $RESPONSE | jq -Cc
+if [ "$(echo $RESPONSE | jq -r '.error')" = "null" ]; then exit 1; fi
+
+URI=$ADDR/company/$COMPANY_ID/employee/$EMPLOYEE_ID
+echo "\tDELETE $URI"
+RESPONSE=$(curl -s -H "Authorization: Bearer $OWNER_TOKEN" -X 'DELETE' "$URI")
+echo $RESPONSE | jq -Cc
+if [ $? -ne 0 ] || [ "$(echo $RESPONSE | jq -r '.error')" != "null" ]; then exit 1; fi
+
+URI=$ADDR/company/$COMPANY_ID/department
+echo "\tPOST $URI"
+RESPONSE=$(curl -s -H "Authorization: Bearer $OWNER_TOKEN" -X 'POST' -d '{"department":{"name":"開発部"}}' "$URI")
+echo $RESPONSE | jq -Cc
+if [ $? -ne 0 ] || [ "$(echo $RESPONSE | jq -r '.error')" != "null" ]; then exit 1; fi
+
+DEV_ID=$(echo $RESPONSE | jq -r '.department.id')
+if [ $DEV_ID = "null" ]; then exit 1; fi
+
+URI=$ADDR/company/$COMPANY_ID/department
+echo "\tPOST $URI"
+RESPONSE=$(curl -s -H "Authorization: Bearer $OWNER_TOKEN" -X 'POST' -d "{\"department\":{\"name\":\"開発一課\",\"parent_id\":$DEV_ID}}" "$URI")
+echo $RESPONSE | jq -Cc
+if [ $? -ne 0 ] || [ "$(echo $RESPONSE | jq -r '.error')" != "null" ]; then exit 1; fi
+
+TEAM_ID=$(echo $RESPONSE | jq -r '.department.id')
+if [ $TEAM_ID = "null" ]; then exit 1; fi
+
+URI=$ADDR/company/$COMPANY_ID/department/$DEV_ID/subtree
+echo "\tGET $URI"
+RESPONSE=$(curl -s -H "Authorization: Bearer $OWNER_TOKEN" -X 'GET' "$URI")
+echo $RESPONSE | jq -Cc
+if [ $? -ne 0 ] || [ "$(echo $RESPONSE | jq -r '.error')" != "null" ]; then exit 1; fi
+if [ "$(echo $RESPONSE | jq -r '.departments | length')" != "2" ]; then exit 1; fi
+
+URI=$ADDR/company/$COMPANY_ID/department/$TEAM_ID/ancestors
+echo "\tGET $URI"
+RESPONSE=$(curl -s -H "Authorization: Bearer $OWNER_TOKEN" -X 'GET' "$URI")
+echo $RESPONSE | jq -Cc
+if [ $? -ne 0 ] || [ "$(echo $RESPONSE | jq -r '.error')" != "null" ]; then exit 1; fi
+if [ "$(echo $RESPONSE | jq -r '.departments[0].id')" != "$DEV_ID" ]; then exit 1; fi
+
+# 子を持つ部署は削除できない
+URI=$ADDR/company/$COMPANY_ID/department/$DEV_ID
+echo "\tDELETE $URI"
+RESPONSE=$(curl -s -H "Authorization: Bearer $OWNER_TOKEN" -X 'DELETE' "$URI")
+echo $RESPONSE | jq -Cc
+if [ "$(echo $RESPONSE | jq -r '.error.code')" != "conflict" ]; then exit 1; fi
+
+URI=$ADDR/company/$COMPANY_ID/department/$DEV_ID/parent
+echo "\tPUT $URI"
+RESPONSE=$(curl -s -H "Authorization: Bearer $OWNER_TOKEN" -X 'PUT' -d "{\"department\":{\"parent_id\":$TEAM_ID}}" "$URI")
+echo $RESPONSE | jq -Cc
+if [ "$(echo $RESPONSE | jq -r '.error')" = "null" ]; then exit 1; fi
+
+URI=$ADDR/company/$COMPANY_ID/department/$TEAM_ID/parent
+echo "\tPUT $URI"
+RESPONSE=$(curl -s -H "Authorization: Bearer $OWNER_TOKEN" -X 'PUT' -d '{"department":{"parent_id":null}}' "$URI")
+echo $RESPONSE | jq -Cc
+if [ $? -ne 0 ] || [ "$(echo $RESPONSE | jq -r '.error')" != "null" ]; then exit 1; fi
+if [ "$(echo $RESPONSE | jq -r '.department.parent_id')" != "null" ]; then exit 1; fi
+
+URI=$ADDR/company/$COMPANY_ID/department/$TEAM_ID
+echo "\tDELETE $URI"
+RESPONSE=$(curl -s -H "Authorization: Bearer $OWNER_TOKEN" -X 'DELETE' "$URI")
+echo $RESPONSE | jq -Cc
+if [ $? -ne 0 ] || [ "$(echo $RESPONSE | jq -r '.error')" != "null" ]; then exit 1; fi
+
+URI=$ADDR/company/$COMPANY_ID/role
+echo "\tPOST $URI"
+RESPONSE=$(curl -s -H "Authorization: Bearer $OWNER_TOKEN" -X 'POST' -d '{"role":{"name":"部長"}}' "$URI")
+echo $RESPONSE | jq -Cc
+if [ $? -ne 0 ] || [ "$(echo $RESPONSE | jq -r '.error')" != "null" ]; then exit 1; fi
+ROLE_ID=$(echo $RESPONSE | jq -r '.role.id')
+
+URI=$ADDR/company/$COMPANY_ID/role
+echo "\tPOST $URI"
+RESPONSE=$(curl -s -H "Authorization: Bearer $OWNER_TOKEN" -X 'POST' -d '{"role":{"name":"部長"}}' "$URI")
+echo $RESPONSE | jq -Cc
+if [ "$(echo $RESPONSE | jq -r '.error.code')" != "conflict" ]; then exit 1; fi
+
+URI=$ADDR/company/$COMPANY_ID/role
+echo "\tGET $URI"
+RESPONSE=$(curl -s -H "Authorization: Bearer $OWNER_TOKEN" -X 'GET' "$URI")
+echo $RESPONSE | jq -Cc
+if [ $? -ne 0 ] || [ "$(echo $RESPONSE | jq -r '.error')" != "null" ]; then exit 1; fi
+if [ "$(echo $RESPONSE | jq -r '.roles | length')" != "1" ]; then exit 1; fi
+
+URI=$ADDR/company/$COMPANY_ID/role/$ROLE_ID
+echo "\tPUT $URI"
+RESPONSE=$(curl -s -H "Authorization: Bearer $OWNER_TOKEN" -X 'PUT' -d '{"role":{"name":"本部長"}}' "$URI")
+echo $RESPONSE | jq -Cc
+if [ $? -ne 0 ] || [ "$(echo $RESPONSE | jq -r '.error')" != "null" ]; then exit 1; fi
+if [ "$(echo $RESPONSE | jq -r '.role.name')" != "本部長" ]; then exit 1; fi
+
+URI=$ADDR/company/$COMPANY_ID/role/$ROLE_ID
+echo "\tDELETE $URI"
+RESPONSE=$(curl -s -H "Authorization: Bearer $OWNER_TOKEN" -X 'DELETE' "$URI")
+echo $RESPONSE | jq -Cc
+if [ $? -ne 0 ] || [ "$(echo $RESPONSE | jq -r '.error')" != "null" ]; then exit 1; fi
+
+URI=$ADDR/company/$COMPANY_ID/employee
+echo "\tGET $URI"
+RESPONSE=$(curl -s -H "Authorization: Bearer $OWNER_TOKEN" -X 'GET' "$URI")
+echo $RESPONSE | jq -Cc
+if [ $? -ne 0 ] || [ "$(echo $RESPONSE | jq -r '.error')" != "null" ]; then exit 1; fi
+ADMIN_ID=$(echo $RESPONSE | jq -r '.employees[0].id')
+
+URI=$ADDR/company/$COMPANY_ID/department
+echo "\tPOST $URI"
+RESPONSE=$(curl -s -H "Authorization: Bearer $OWNER_TOKEN" -X 'POST' -d '{"department":{"name":"営業部"}}' "$URI")
+echo $RESPONSE | jq -Cc
+if [ $? -ne 0 ] || [ "$(echo $RESPONSE | jq -r '.error')" != "null" ]; then exit 1; fi
+SALES_ID=$(echo $RESPONSE | jq -r '.department.id')
+
+URI=$ADDR/company/$COMPANY_ID/role
+echo "\tPOST $URI"
+RESPONSE=$(curl -s -H "Authorization: Bearer $OWNER_TOKEN" -X 'POST' -d '{"role":{"name":"部長"}}' "$URI")
+echo $RESPONSE | jq -Cc
+if [ $? -ne 0 ] || [ "$(echo $RESPONSE | jq -r '.error')" != "null" ]; then exit 1; fi
+ROLE_ID=$(echo $RESPONSE | jq -r '.role.id')
+
+URI=$ADDR/company/$COMPANY_ID/assignment
+echo "\tPOST $URI"
+RESPONSE=$(curl -s -H "Authorization: Bearer $OWNER_TOKEN" -X 'POST' -d "{\"assignment\":{\"employee_id\":$ADMIN_ID,\"department_id\":$SALES_ID,\"role_id\":$ROLE_ID}}" "$URI")
+echo $RESPONSE | jq -Cc
+if [ $? -ne 0 ] || [ "$(echo $RESPONSE | jq -r '.error')" != "null" ]; then exit 1; fi
+ASSIGNMENT_ID=$(echo $RESPONSE | jq -r '.assignment.id')
+
+URI=$ADDR/company/$COMPANY_ID/assignment
+echo "\tPOST $URI"
+RESPONSE=$(curl -s -H "Authorization: Bearer $OWNER_TOKEN" -X 'POST' -d "{\"assignment\":{\"employee_id\":$ADMIN_ID,\"department_id\":$DEV_ID,\"role_id\":$ROLE_ID}}" "$URI")
+echo $RESPONSE | jq -Cc
+if [ $? -ne 0 ] || [ "$(echo $RESPONSE | jq -r '.error')" != "null" ]; then exit 1; fi
+
+URI=$ADDR/company/$COMPANY_ID/employee/$ADMIN_ID/assignment
+echo "\tGET $URI"
+RESPONSE=$(curl -s -H "Authorization: Bearer $OWNER_TOKEN" -X 'GET' "$URI")
+echo $RESPONSE | jq -Cc
+if [ $? -ne 0 ] || [ "$(echo $RESPONSE | jq -r '.error')" != "null" ]; then exit 1; fi
+if [ "$(echo $RESPONSE | jq -r '.assignments | length')" != "2" ]; then exit 1; fi
+
+URI=$ADDR/company/$COMPANY_ID/department/$DEV_ID/assignment
+echo "\tGET $URI"
+RESPONSE=$(curl -s -H "Authorization: Bearer $OWNER_TOKEN" -X 'GET' "$URI")
+echo $RESPONSE | jq -Cc
+if [ $? -ne 0 ] || [ "$(echo $RESPONSE | jq -r '.error')" != "null" ]; then exit 1; fi
+if [ "$(echo $RESPONSE | jq -r '.assignments | length')" != "1" ]; then exit 1; fi
+
+# 配下の部署に配置された従業員も含める
+URI=$ADDR/company/$COMPANY_ID/department
+echo "\tPOST $URI"
+RESPONSE=$(curl -s -H "Authorization: Bearer $OWNER_TOKEN" -X 'POST' -d "{\"department\":{\"name\":\"開発二課\",\"parent_id\":$DEV_ID}}" "$URI")
+echo $RESPONSE | jq -Cc
+if [ $? -ne 0 ] || [ "$(echo $RESPONSE | jq -r '.error')" != "null" ]; then exit 1; fi
+TEAM_ID=$(echo $RESPONSE | jq -r '.department.id')
+
+URI=$ADDR/company/$COMPANY_ID/assignment
+echo "\tPOST $URI"
+RESPONSE=$(curl -s -H "Authorization: Bearer $OWNER_TOKEN" -X 'POST' -d "{\"assignment\":{\"employee_id\":$ADMIN_ID,\"department_id\":$TEAM_ID,\"role_id\":$ROLE_ID}}" "$URI")
+echo $RESPONSE | jq -Cc
+if [ $? -ne 0 ] || [ "$(echo $RESPONSE | jq -r '.error')" != "null" ]; then exit 1; fi
+
+URI=$ADDR/company/$COMPANY_ID/department/$DEV_ID/subtree/assignment
+echo "\tGET $URI"
+RESPONSE=$(curl -s -H "Authorization: Bearer $OWNER_TOKEN" -X 'GET' "$URI")
+echo $RESPONSE | jq -Cc
+if [ $? -ne 0 ] || [ "$(echo $RESPONSE | jq -r '.error')" != "null" ]; then exit 1; fi
+if [ "$(echo $RESPONSE | jq -r '.assignments | length')" != "2" ]; then exit 1; fi
+if [ "$(echo $RESPONSE | jq -r '.assignments[1].department_id')" != "$TEAM_ID" ]; then exit 1; fi
+
+URI=$ADDR/company/$COMPANY_ID/assignment/$ASSIGNMENT_ID
+echo "\tDELETE $URI"
+RESPONSE=$(curl -s -H "Authorization: Bearer $OWNER_TOKEN" -X 'DELETE' "$URI")
+echo $RESPONSE | jq -Cc
+if [ $? -ne 0 ] || [ "$(echo $RESPONSE | jq -r '.error')" != "null" ]; then exit 1; fi
+
+URI=$ADDR/company/$COMPANY_ID/employee/$ADMIN_ID/permission
+echo "\tGET $URI"
+RESPONSE=$(curl -s -H "Authorization: Bearer $OWNER_TOKEN" -X 'GET' "$URI")
+echo $RESPONSE | jq -Cc
+if [ $? -ne 0 ] || [ "$(echo $RESPONSE | jq -r '.error')" != "null" ]; then exit 1; fi
+if [ "$(echo $RESPONSE | jq -r '.permissions | length')" != "4" ]; then exit 1; fi
+
+URI=$ADDR/company/$COMPANY_ID/employee/$ADMIN_ID/permission/manage_roles
+echo "\tDELETE $URI"
+RESPONSE=$(curl -s -H "Authorization: Bearer $OWNER_TOKEN" -X 'DELETE' "$URI")
+echo $RESPONSE | jq -Cc
+if [ $? -ne 0 ] || [ "$(echo $RESPONSE | jq -r '.error')" != "null" ]; then exit 1; fi
+if [ "$(echo $RESPONSE | jq -r '.permissions | length')" != "3" ]; then exit 1; fi
+
+URI=$ADDR/company/$COMPANY_ID/employee/$ADMIN_ID/permission/manage_roles
+echo "\tPUT $URI"
+RESPONSE=$(curl -s -H "Authorization: Bearer $OWNER_TOKEN" -X 'PUT' "$URI")
+echo $RESPONSE | jq -Cc
+if [ $? -ne 0 ] || [ "$(echo $RESPONSE | jq -r '.error')" != "null" ]; then exit 1; fi
+if [ "$(echo $RESPONSE | jq -r '.permissions | length')" != "4" ]; then exit 1; fi
+
+URI=$ADDR/company/$COMPANY_ID/employee/$ADMIN_ID/permission/manage_everything
 echo "\tPUT $URI"
-RESPONSE=$(curl -s -X 'PUT' -d '{"company":{"name":"greate company","owner_id":2}}' "$URI")
+RESPONSE=$(curl -s -H "Authorization: Bearer $OWNER_TOKEN" -X 'PUT' "$URI")
+echo $RESPONSE | jq -Cc
+if [ "$(echo $RESPONSE | jq -r '.error')" = "null" ]; then exit 1; fi
+
+# API キー
+echo "[APIKEY]"
+URI=$ADDR/company/$COMPANY_ID/apikey
+echo "\tPOST $URI"
+RESPONSE=$(curl -s -H "Authorization: Bearer $OWNER_TOKEN" -X 'POST' -d '{"apikey":{"name":"batch","scopes":["manage_everything"]}}' "$URI")
+echo $RESPONSE | jq -Cc
+if [ "$(echo $RESPONSE | jq -r '.error')" = "null" ]; then exit 1; fi
+
+URI=$ADDR/company/$COMPANY_ID/apikey
+echo "\tPOST $URI"
+RESPONSE=$(curl -s -H "Authorization: Bearer $OWNER_TOKEN" -X 'POST' -d '{"apikey":{"name":"batch","scopes":["read","manage_users"]}}' "$URI")
+echo $RESPONSE | jq -Cc
+if [ $? -ne 0 ] || [ "$(echo $RESPONSE | jq -r '.error')" != "null" ]; then exit 1; fi
+
+APIKEY_ID=$(echo $RESPONSE | jq -r '.apikey.id')
+if [ $APIKEY_ID = "null" ]; then exit 1; fi
+APIKEY=$(echo $RESPONSE | jq -r '.apikey.key')
+if [ $APIKEY = "null" ]; then exit 1; fi
+
+URI=$ADDR/company/$COMPANY_ID/apikey
+echo "\tGET $URI"
+RESPONSE=$(curl -s -H "Authorization: Bearer $OWNER_TOKEN" -X 'GET' "$URI")
+echo $RESPONSE | jq -Cc
+if [ $? -ne 0 ] || [ "$(echo $RESPONSE | jq -r '.error')" != "null" ]; then exit 1; fi
+if [ "$(echo $RESPONSE | jq -r '.apikeys[0].key')" != "null" ]; then exit 1; fi
+
+URI=$ADDR/company/$COMPANY_ID
+echo "\tGET $URI"
+RESPONSE=$(curl -s -H "Authorization: ApiKey $APIKEY" -X 'GET' "$URI")
 echo $RESPONSE | jq -Cc
 if [ $? -ne 0 ] || [ "$(echo $RESPONSE | jq -r '.error')" != "null" ]; then exit 1; fi
 
+URI=$ADDR/company/$COMPANY_ID/employee
+echo "\tGET $URI"
+RESPONSE=$(curl -s -H "Authorization: ApiKey $APIKEY" -X 'GET' "$URI")
+echo $RESPONSE | jq -Cc
+if [ $? -ne 0 ] || [ "$(echo $RESPONSE | jq -r '.error')" != "null" ]; then exit 1; fi
+
+# スコープ外の操作はできない
+URI=$ADDR/company/$COMPANY_ID/role
+echo "\tPOST $URI"
+RESPONSE=$(curl -s -H "Authorization: ApiKey $APIKEY" -X 'POST' -d '{"role":{"name":"課長"}}' "$URI")
+echo $RESPONSE | jq -Cc
+if [ "$(echo $RESPONSE | jq -r '.error')" = "null" ]; then exit 1; fi
+
+# API キー自身では API キーを管理できない
+URI=$ADDR/company/$COMPANY_ID/apikey
+echo "\tGET $URI"
+RESPONSE=$(curl -s -H "Authorization: ApiKey $APIKEY" -X 'GET' "$URI")
+echo $RESPONSE | jq -Cc
+if [ "$(echo $RESPONSE | jq -r '.error')" = "null" ]; then exit 1; fi
+
+URI=$ADDR/company/$COMPANY_ID/apikey/$APIKEY_ID/rotate
+echo "\tPOST $URI"
+RESPONSE=$(curl -s -H "Authorization: Bearer $OWNER_TOKEN" -X 'POST' "$URI")
+echo $RESPONSE | jq -Cc
+if [ $? -ne 0 ] || [ "$(echo $RESPONSE | jq -r '.error')" != "null" ]; then exit 1; fi
+
+ROTATED_APIKEY=$(echo $RESPONSE | jq -r '.apikey.key')
+if [ $ROTATED_APIKEY = "null" ]; then exit 1; fi
+
+URI=$ADDR/company/$COMPANY_ID
+echo "\tGET $URI"
+RESPONSE=$(curl -s -H "Authorization: ApiKey $APIKEY" -X 'GET' "$URI")
+echo $RESPONSE | jq -Cc
+if [ "$(echo $RESPONSE | jq -r '.error')" = "null" ]; then exit 1; fi
+
+URI=$ADDR/company/$COMPANY_ID
+echo "\tGET $URI"
+RESPONSE=$(curl -s -H "Authorization: ApiKey $ROTATED_APIKEY" -X 'GET' "$URI")
+echo $RESPONSE | jq -Cc
+if [ $? -ne 0 ] || [ "$(echo $RESPONSE | jq -r '.error')" != "null" ]; then exit 1; fi
+
+URI=$ADDR/company/$COMPANY_ID/apikey/$APIKEY_ID
+echo "\tDELETE $URI"
+RESPONSE=$(curl -s -H "Authorization: Bearer $OWNER_TOKEN" -X 'DELETE' "$URI")
+echo $RESPONSE | jq -Cc
+if [ $? -ne 0 ] || [ "$(echo $RESPONSE | jq -r '.error')" != "null" ]; then exit 1; fi
+
+URI=$ADDR/company/$COMPANY_ID
+echo "\tGET $URI"
+RESPONSE=$(curl -s -H "Authorization: ApiKey $ROTATED_APIKEY" -X 'GET' "$URI")
+echo $RESPONSE | jq -Cc
+if [ "$(echo $RESPONSE | jq -r '.error')" = "null" ]; then exit 1; fi
+
 URI=$ADDR/company/$COMPANY_ID
 echo "DELETE $URI"
-RESPONSE=$(curl -s -X 'DELETE' "$URI")
+RESPONSE=$(curl -s -H "Authorization: Bearer $OWNER_TOKEN" -X 'DELETE' "$URI")
+echo $RESPONSE | jq -Cc
+if [ $? -ne 0 ] || [ "$(echo $RESPONSE | jq -r '.error')" != "null" ]; then exit 1; fi
+
+# ログアウト
+echo "[SESSION]"
+URI="$ADDR/session"
+echo "\tPOST $URI"
+RESPONSE=$(curl -s -X 'POST' -d '{"session":{"name":"Owner","password":"12345678"}}' "$URI")
+echo $RESPONSE | jq -Cc
+if [ $? -ne 0 ] || [ "$(echo $RESPONSE | jq -r '.error')" != "null" ]; then exit 1; fi
+
+SESSION_ID=$(echo $RESPONSE | jq -r '.session.id')
+if [ $SESSION_ID = "null" ]; then exit 1; fi
+
+URI="$ADDR/session"
+echo "\tDELETE $URI"
+RESPONSE=$(curl -s -H "X-Session-ID: $SESSION_ID" -X 'DELETE' "$URI")
 echo $RESPONSE | jq -Cc
 if [ $? -ne 0 ] || [ "$(echo $RESPONSE | jq -r '.error')" != "null" ]; then exit 1; fi
+
+URI="$ADDR/session"
+echo "\tDELETE $URI"
+RESPONSE=$(curl -s -H "X-Session-ID: $SESSION_ID" -X 'DELETE' "$URI")
+echo $RESPONSE | jq -Cc
+if [ "$(echo $RESPONSE | jq -r '.error')" = "null" ]; then exit 1; fi
+
+URI="$ADDR/session"
+echo "\tDELETE $URI"
+RESPONSE=$(curl -s -H "Authorization: Bearer $OWNER_TOKEN" -X 'DELETE' "$URI")
+echo $RESPONSE | jq -Cc
+if [ $? -ne 0 ] || [ "$(echo $RESPONSE | jq -r '.error')" != "null" ]; then exit 1; fi
+
+URI="$ADDR/company"
+echo "\tPOST $URI"
+RESPONSE=$(curl -s -H "Authorization: Bearer $OWNER_TOKEN" -X 'POST' -d "{\"company\":{\"name\":\"GREATE COMPANY\",\"owner_id\":$OWNER_ID}}" "$URI")
+echo $RESPONSE | jq -Cc
+if [ "$(echo $RESPONSE | jq -r '.error')" = "null" ]; then exit 1; fi
+
+# 本人以外はユーザー情報を変更できない
+URI=$ADDR/user/$OWNER_ID
+echo "\tPUT $URI"
+RESPONSE=$(curl -s -H "Authorization: Bearer $NEW_OWNER_TOKEN" -X 'PUT' -d '{"user":{"name":"Owner","password":"87654321","current_password":"12345678"}}' "$URI")
+echo $RESPONSE | jq -Cc
+if [ "$(echo $RESPONSE | jq -r '.error')" = "null" ]; then exit 1; fi
+
+# パスワードを変更すると全ての端末からログアウトする
+URI=$ADDR/user/$NEW_OWNER_ID
+echo "\tPUT $URI"
+RESPONSE=$(curl -s -H "Authorization: Bearer $NEW_OWNER_TOKEN" -X 'PUT' -d '{"user":{"name":"NewOwner","password":"87654321","current_password":"12345678"}}' "$URI")
+echo $RESPONSE | jq -Cc
+if [ $? -ne 0 ] || [ "$(echo $RESPONSE | jq -r '.error')" != "null" ]; then exit 1; fi
+
+URI="$ADDR/company"
+echo "\tPOST $URI"
+RESPONSE=$(curl -s -H "Authorization: Bearer $NEW_OWNER_TOKEN" -X 'POST' -d "{\"company\":{\"name\":\"GREATE COMPANY\",\"owner_id\":$NEW_OWNER_ID}}" "$URI")
+echo $RESPONSE | jq -Cc
+if [ "$(echo $RESPONSE | jq -r '.error')" = "null" ]; then exit 1; fi
+
+# 失効した資格情報やセッションIDが残っていても、新しいパスワードで再びログインできる
+URI="$ADDR/session"
+echo "\tPOST $URI"
+RESPONSE=$(curl -s -H "Authorization: Bearer $NEW_OWNER_TOKEN" -b "session_id=$NEW_OWNER_TOKEN" -X 'POST' -d '{"session":{"name":"NewOwner","password":"87654321"}}' "$URI")
+echo $RESPONSE | jq -Cc
+if [ $? -ne 0 ] || [ "$(echo $RESPONSE | jq -r '.error')" != "null" ]; then exit 1; fi
+
+NEW_OWNER_TOKEN=$(echo $RESPONSE | jq -r '.session.token')
+if [ $NEW_OWNER_TOKEN = "null" ]; then exit 1; fi
+
+URI="$ADDR/company"
+echo "\tGET $URI"
+RESPONSE=$(curl -s -H "Authorization: Bearer $NEW_OWNER_TOKEN" -X 'GET' "$URI")
+echo $RESPONSE | jq -Cc
+if [ $? -ne 0 ] || [ "$(echo $RESPONSE | jq -r '.error')" != "null" ]; then exit 1; fi
+
+# 失効したセッションの Cookie は破棄される
+URI="$ADDR/company"
+echo "\tGET $URI"
+HEADERS=$(curl -s -o /dev/null -D - -b "session_id=expired" -X 'GET' "$URI")
+echo "$HEADERS" | grep -i '^set-cookie: session_id=;.*max-age=0' > /dev/null
+if [ $? -ne 0 ]; then exit 1; fi
@@ -1,8 +0,0 @@
-class CreateRoles < ActiveRecord::Migration[6.1]
-  def change
-    create_table :roles do |t|
-      t.string     :name,    null: false
-      t.timestamps
-    end
-  end
-end
@@ -3,20 +3,40 @@ package main
 import (
 	"api.example.com/env"
 	"api.example.com/http-handle"
+	"api.example.com/pkg/apikey"
+	"api.example.com/pkg/assignment"
+	"api.example.com/pkg/auth"
 	"api.example.com/pkg/company"
+	"api.example.com/pkg/employee"
+	"api.example.com/pkg/organization"
+	"api.example.com/pkg/permission"
+	"api.example.com/pkg/role"
+	"api.example.com/pkg/session"
 	"api.example.com/pkg/user"
 	"api.example.com/repository"
+	"api.example.com/repository/memory"
 	"context"
 	"database/sql"
 	"fmt"
 	_ "github.com/go-sql-driver/mysql"
+	_ "github.com/mattn/go-sqlite3"
 	"log"
 	"net/http"
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
 )
 
+// サブコマンド (例: api migrate up)
+// 指定がなければサーバーを起動する
+var command = func() string {
+	if len(os.Args) > 1 {
+		return os.Args[1]
+	}
+	return ""
+}()
+
 // 起動するサーバー本体
 var srv http.Server
 
@@ -28,11 +48,51 @@ func init() {
 	srv.Addr = addr.Value()
 }
 
-// データベース
-var db *sql.DB
+// データの保存先
+var store repository.Repository
 
-// データベースの初期化
+// データの保存先の初期化
+// REPOSITORY=memory であればデータベースを使わず、停止すると全てのデータを失う
 func init() {
+	if command == "migrate" {
+		return
+	}
+
+	backend := env.Get("REPOSITORY")
+	log.Println(backend)
+
+	switch backend.Value() {
+	case "", "sql":
+		store = openRepository()
+	case "memory":
+		store = memory.New()
+	default:
+		log.Fatalf("main %s: unknown repository %q", backend.Name(), backend.Value())
+	}
+}
+
+// データベースの初期化
+// DB_DRIVER=sqlite3 であれば DB_NAME のファイルを使い、未適用のマイグレーションを適用する
+func openRepository() repository.Repository {
+	driver := env.Get("DB_DRIVER")
+	log.Println(driver)
+
+	switch driver.Value() {
+	case "", "mysql":
+		return repository.New(openMySQL())
+	case "sqlite3":
+		r, err := repository.NewSQLite(context.Background(), openSQLite())
+		if err != nil {
+			log.Fatalf("main SQLite migrate: %v", err)
+		}
+		return r
+	default:
+		log.Fatalf("main %s: unknown driver %q", driver.Name(), driver.Value())
+	}
+	return nil
+}
+
+func openMySQL() *sql.DB {
 	addr := env.Get("DB_ADDR")
 	name := env.Get("DB_NAME")
 	user := env.Get("DB_USER")
@@ -49,20 +109,138 @@ func init() {
 		addr.Value(),
 		name.Value(),
 	)
-	var err error
-	db, err = sql.Open("mysql", dsn)
+	db, err := sql.Open("mysql", dsn)
 	if err != nil {
 		log.Fatalf("main SQL Open: %v", err)
 	}
+	return db
+}
+
+func openSQLite() *sql.DB {
+	name := env.Get("DB_NAME")
+	log.Println(name)
+
+	if name.Value() == "" {
+		log.Fatalf("main %s is required", name.Name())
+	}
+
+	// 外部キーの制約を有効にし、書き込みのトランザクションは開始時にロックを待つ
+	dsn := fmt.Sprintf(
+		"file:%s?_foreign_keys=1&_busy_timeout=5000&_txlock=immediate&_journal_mode=WAL",
+		name.Value(),
+	)
+	db, err := sql.Open("sqlite3", dsn)
+	if err != nil {
+		log.Fatalf("main SQL Open: %v", err)
+	}
+	return db
+}
+
+// 資格情報の署名
+var signer session.Signer
+
+// セッションの有効期限
+var sessionTTL time.Duration
+
+// 資格情報の署名の初期化
+func init() {
+	if command == "migrate" {
+		return
+	}
+
+	key := env.GetSecure("TOKEN_KEY")
+	ttl := env.Get("TOKEN_TTL")
+	log.Println(key)
+	log.Println(ttl)
+
+	if key.Value() == "" {
+		log.Fatalf("main %s is required", key.Name())
+	}
+
+	// 既定の有効期限は 24 時間
+	d := 24 * time.Hour
+	if ttl.Value() != "" {
+		var err error
+		d, err = time.ParseDuration(ttl.Value())
+		if err != nil {
+			log.Fatalf("main %s: %v", ttl.Name(), err)
+		}
+	}
+
+	signer = session.NewSigner([]byte(key.Value()))
+	sessionTTL = d
+}
+
+// リクエストごとの期限
+var requestTimeout time.Duration
+
+// リクエストの期限の初期化
+func init() {
+	timeout := env.Get("REQUEST_TIMEOUT")
+	log.Println(timeout)
+
+	// 既定の期限は 30 秒, 0 であれば期限を設けない
+	d := 30 * time.Second
+	if timeout.Value() != "" {
+		var err error
+		d, err = time.ParseDuration(timeout.Value())
+		if err != nil {
+			log.Fatalf("main %s: %v", timeout.Name(), err)
+		}
+	}
+
+	requestTimeout = d
+}
+
+// 有効期限が切れたセッションを削除する間隔
+const cleanupInterval = time.Hour
+
+func cleanup(ctx context.Context, s session.Server) {
+	ticker := time.NewTicker(cleanupInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := s.Cleanup(ctx); err != nil {
+				log.Printf("session Cleanup: %v", err)
+			}
+		}
+	}
 }
 
 func main() {
-	defer db.Close()
-	repository := repository.New(db)
-	srv.Handler = handle.New(&handle.Services{
-		User:    user.NewServer(repository),
-		Company: company.NewServer(repository),
+	switch command {
+	case "":
+	case "migrate":
+		migrate(os.Args[2:])
+		return
+	default:
+		log.Fatalf("main: unknown command %q", command)
+	}
+
+	defer store.Close()
+	permissionServer := permission.NewServer(store)
+	sessionServer := session.NewServer(store, signer, sessionTTL)
+	handler := handle.New(&handle.Services{
+		User:         user.NewServer(store),
+		Company:      company.NewServer(store),
+		Employee:     employee.NewServer(store),
+		Organization: organization.NewServer(store),
+		Role:         role.NewServer(store),
+		Assignment:   assignment.NewServer(store),
+		Permission:   permissionServer,
+		Auth:         auth.NewServer(store, permissionServer),
+		Session:      sessionServer,
+		APIKey:       apikey.NewServer(store),
 	})
+	srv.Handler = handle.Deadline(handler, requestTimeout)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go cleanup(ctx, sessionServer)
 
 	// 異常終了しないためのおまじない
 	idleConnsClosed := make(chan struct{})
@@ -0,0 +1,80 @@
+package main
+
+import (
+	"api.example.com/env"
+	"api.example.com/repository/migration"
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+)
+
+// データベースのマイグレーション
+// api migrate [up|down|status]
+// up は未適用の全て、 down は最後の1つを対象とし、既定は up
+// DB_DRIVER=sqlite3 であれば SQLite のマイグレーションを対象とする(起動時にも up を適用する)
+func migrate(args []string) {
+	driver := env.Get("DB_DRIVER")
+	log.Println(driver)
+
+	var (
+		db  *sql.DB
+		m   *migration.Migrator
+		err error
+	)
+	switch driver.Value() {
+	case "", "mysql":
+		db = openMySQL()
+		m, err = migration.New(db)
+	case "sqlite3":
+		db = openSQLite()
+		m, err = migration.NewSQLite(db)
+	default:
+		log.Fatalf("main migrate %s: unknown driver %q", driver.Name(), driver.Value())
+	}
+	defer db.Close()
+
+	if err != nil {
+		log.Fatalf("main migrate: %v", err)
+	}
+
+	action := "up"
+	if len(args) > 0 {
+		action = args[0]
+	}
+
+	ctx := context.Background()
+	switch action {
+	case "up":
+		list, err := m.Up(ctx)
+		// 失敗しても、それまでに適用したものは表示する
+		for _, applied := range list {
+			fmt.Printf("up   %s_%s\n", applied.Version, applied.Name)
+		}
+		if err != nil {
+			log.Fatalf("main migrate up: %v", err)
+		}
+	case "down":
+		reverted, err := m.Down(ctx)
+		if err != nil {
+			log.Fatalf("main migrate down: %v", err)
+		}
+		if reverted != nil {
+			fmt.Printf("down %s_%s\n", reverted.Version, reverted.Name)
+		}
+	case "status":
+		list, err := m.Status(ctx)
+		if err != nil {
+			log.Fatalf("main migrate status: %v", err)
+		}
+		for _, s := range list {
+			state := "down"
+			if s.Applied {
+				state = "up"
+			}
+			fmt.Printf("%-4s %s_%s\n", state, s.Version, s.Name)
+		}
+	default:
+		log.Fatalf("main migrate: unknown action %q", action)
+	}
+}
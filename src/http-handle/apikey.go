@@ -0,0 +1,180 @@
+package handle
+
+import (
+	"log"
+	"net/http"
+
+	"api.example.com/http-handle/request"
+	"api.example.com/http-handle/response"
+	"api.example.com/pkg/apikey"
+	"api.example.com/pkg/auth"
+)
+
+type apiKeyHandler struct {
+	server apikey.Server
+}
+
+func newAPIKeyHandler(s apikey.Server) *apiKeyHandler {
+	return &apiKeyHandler{s}
+}
+
+func (h *apiKeyHandler) handleAPIKeys(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		h.list(w, r)
+	case http.MethodPost:
+		h.create(w, r)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func (h *apiKeyHandler) handleAPIKey(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		h.read(w, r)
+	case http.MethodDelete:
+		h.delete(w, r)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func (h *apiKeyHandler) handleRotate(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodPost:
+		h.rotate(w, r)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+// API キーを検証し、呼び出し元の API キーを context に保存する
+func (h *apiKeyHandler) identify(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token, ok, err := request.APIKey(r)
+		if err != nil {
+			log.Println(err)
+			response.Error(w, err)
+			return
+		}
+
+		if ok {
+			k, err := h.server.Authenticate(r.Context(), token)
+			if err != nil {
+				log.Println(err)
+				response.Error(w, err)
+				return
+			}
+
+			r = r.WithContext(auth.WithKey(r.Context(), k))
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (h *apiKeyHandler) create(w http.ResponseWriter, r *http.Request) {
+	k, err := request.APIKeyCreate(r)
+	if err != nil {
+		log.Println(err)
+		response.Error(w, err)
+		return
+	}
+
+	k, err = h.server.Create(r.Context(), k)
+	if err != nil {
+		log.Println(err)
+		response.Error(w, err)
+		return
+	}
+
+	err = response.APIKeyCreate(w, k)
+	if err != nil {
+		log.Println(err)
+	}
+}
+
+func (h *apiKeyHandler) list(w http.ResponseWriter, r *http.Request) {
+	companyID, err := request.APIKeyList(r)
+	if err != nil {
+		log.Println(err)
+		response.Error(w, err)
+		return
+	}
+
+	list, err := h.server.List(r.Context(), companyID)
+	if err != nil {
+		log.Println(err)
+		response.Error(w, err)
+		return
+	}
+
+	err = response.APIKeyList(w, list)
+	if err != nil {
+		log.Println(err)
+	}
+}
+
+func (h *apiKeyHandler) read(w http.ResponseWriter, r *http.Request) {
+	companyID, id, err := request.APIKeyRead(r)
+	if err != nil {
+		log.Println(err)
+		response.Error(w, err)
+		return
+	}
+
+	k, err := h.server.Read(r.Context(), companyID, id)
+	if err != nil {
+		log.Println(err)
+		response.Error(w, err)
+		return
+	}
+
+	err = response.APIKeyRead(w, k)
+	if err != nil {
+		log.Println(err)
+	}
+}
+
+func (h *apiKeyHandler) rotate(w http.ResponseWriter, r *http.Request) {
+	companyID, id, err := request.APIKeyRotate(r)
+	if err != nil {
+		log.Println(err)
+		response.Error(w, err)
+		return
+	}
+
+	k, err := h.server.Rotate(r.Context(), companyID, id)
+	if err != nil {
+		log.Println(err)
+		response.Error(w, err)
+		return
+	}
+
+	err = response.APIKeyRotate(w, k)
+	if err != nil {
+		log.Println(err)
+	}
+}
+
+func (h *apiKeyHandler) delete(w http.ResponseWriter, r *http.Request) {
+	companyID, id, err := request.APIKeyDelete(r)
+	if err != nil {
+		log.Println(err)
+		response.Error(w, err)
+		return
+	}
+
+	err = h.server.Revoke(r.Context(), companyID, id)
+	if err != nil {
+		log.Println(err)
+		response.Error(w, err)
+		return
+	}
+
+	err = response.APIKeyDelete(w)
+	if err != nil {
+		log.Println(err)
+	}
+}
@@ -0,0 +1,335 @@
+package handle
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"api.example.com/pkg/apikey"
+	"api.example.com/pkg/auth"
+	"api.example.com/pkg/permission"
+)
+
+// mock
+type apiKeyServer struct {
+	key   *apikey.Key
+	keys  []*apikey.Key
+	grant *auth.Key
+	err   error
+	// flag
+	create, read, list, rotate, revoke, authenticate bool
+}
+
+func (s *apiKeyServer) Create(context.Context, *apikey.Key) (*apikey.Key, error) {
+	if s.create {
+		return s.key, s.err
+	}
+
+	panic("invalid Create")
+}
+
+func (s *apiKeyServer) Read(context.Context, apikey.CompanyID, apikey.ID) (*apikey.Key, error) {
+	if s.read {
+		return s.key, s.err
+	}
+
+	panic("invalid Read")
+}
+
+func (s *apiKeyServer) List(context.Context, apikey.CompanyID) ([]*apikey.Key, error) {
+	if s.list {
+		return s.keys, s.err
+	}
+
+	panic("invalid List")
+}
+
+func (s *apiKeyServer) Rotate(context.Context, apikey.CompanyID, apikey.ID) (*apikey.Key, error) {
+	if s.rotate {
+		return s.key, s.err
+	}
+
+	panic("invalid Rotate")
+}
+
+func (s *apiKeyServer) Revoke(context.Context, apikey.CompanyID, apikey.ID) error {
+	if s.revoke {
+		return s.err
+	}
+
+	panic("invalid Revoke")
+}
+
+func (s *apiKeyServer) Authenticate(context.Context, apikey.Token) (*auth.Key, error) {
+	if s.authenticate {
+		return s.grant, s.err
+	}
+
+	panic("invalid Authenticate")
+}
+
+func TestAPIKeyHandler(t *testing.T) {
+	type args struct {
+		method string
+		url    string
+		body   []byte
+	}
+
+	type want struct {
+		statusCode  int
+		contentType string
+		body        []byte
+	}
+
+	type test struct {
+		name   string
+		args   args
+		server *apiKeyServer
+		want   want
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(tt.args.method, tt.args.url, bytes.NewBuffer(tt.args.body))
+			w := httptest.NewRecorder()
+
+			s := newServices()
+			s.APIKey = tt.server
+
+			New(s).ServeHTTP(w, r)
+
+			got := w.Result()
+			defer got.Body.Close()
+
+			gotBody, _ := io.ReadAll(got.Body)
+			if !reflect.DeepEqual(tt.want.body, gotBody) {
+				t.Fatalf("body want=%s, got=%s.", tt.want.body, gotBody)
+			}
+
+			gotContentType := got.Header.Get("Content-Type")
+			if tt.want.contentType != gotContentType {
+				t.Fatalf("Content-Type want=%v, got=%v.", tt.want.contentType, gotContentType)
+			}
+
+			gotStatusCode := got.StatusCode
+			if tt.want.statusCode != gotStatusCode {
+				t.Fatalf("Status-Code want=%v, got=%v.", tt.want.statusCode, gotStatusCode)
+			}
+		})
+	}
+
+	key := func(token apikey.Token) *apikey.Key {
+		return &apikey.Key{
+			ID:        3,
+			CompanyID: 1,
+			Name:      "batch",
+			Scopes:    []apikey.Scope{apikey.ScopeRead},
+			Token:     token,
+			UpdatedAt: time.Date(2022, 9, 3, 12, 34, 56, 0, time.UTC),
+		}
+	}
+
+	tests := []*test{
+		{
+			name: "create ok",
+			args: args{
+				method: http.MethodPost,
+				url:    "http://api.example.com/company/1/apikey",
+				body:   []byte(`{"apikey":{"name":"batch","scopes":["read"]}}`),
+			},
+			server: &apiKeyServer{key: key("3.secret"), create: true},
+			want: want{
+				statusCode:  200,
+				contentType: "application/json",
+				body:        []byte(`{"apikey":{"id":3,"company_id":1,"name":"batch","scopes":["read"],"key":"3.secret","updated_at":"2022-09-03T12:34:56Z"}}` + "\n"),
+			},
+		},
+		{
+			name: "create invalid request",
+			args: args{
+				method: http.MethodPost,
+				url:    "http://api.example.com/company/1/apikey",
+				body:   []byte(``),
+			},
+			server: &apiKeyServer{},
+			want: want{
+				statusCode:  400,
+				contentType: "application/json",
+				body:        []byte(`{"error":{"code":"invalid_argument","message":"invalid argument"}}` + "\n"),
+			},
+		},
+		{
+			name: "create failed",
+			args: args{
+				method: http.MethodPost,
+				url:    "http://api.example.com/company/1/apikey",
+				body:   []byte(`{"apikey":{"name":"batch","scopes":["read"]}}`),
+			},
+			server: &apiKeyServer{err: errors.New("error"), create: true},
+			want: want{
+				statusCode:  500,
+				contentType: "application/json",
+				body:        []byte(`{"error":{"code":"internal","message":"internal server error"}}` + "\n"),
+			},
+		},
+		{
+			name: "list ok",
+			args: args{
+				method: http.MethodGet,
+				url:    "http://api.example.com/company/1/apikey",
+			},
+			server: &apiKeyServer{keys: []*apikey.Key{key("")}, list: true},
+			want: want{
+				statusCode:  200,
+				contentType: "application/json",
+				body:        []byte(`{"apikeys":[{"id":3,"company_id":1,"name":"batch","scopes":["read"],"updated_at":"2022-09-03T12:34:56Z"}]}` + "\n"),
+			},
+		},
+		{
+			name: "read ok",
+			args: args{
+				method: http.MethodGet,
+				url:    "http://api.example.com/company/1/apikey/3",
+			},
+			server: &apiKeyServer{key: key(""), read: true},
+			want: want{
+				statusCode:  200,
+				contentType: "application/json",
+				body:        []byte(`{"apikey":{"id":3,"company_id":1,"name":"batch","scopes":["read"],"updated_at":"2022-09-03T12:34:56Z"}}` + "\n"),
+			},
+		},
+		{
+			name: "read failed",
+			args: args{
+				method: http.MethodGet,
+				url:    "http://api.example.com/company/1/apikey/3",
+			},
+			server: &apiKeyServer{err: errors.New("error"), read: true},
+			want: want{
+				statusCode:  500,
+				contentType: "application/json",
+				body:        []byte(`{"error":{"code":"internal","message":"internal server error"}}` + "\n"),
+			},
+		},
+		{
+			name: "rotate ok",
+			args: args{
+				method: http.MethodPost,
+				url:    "http://api.example.com/company/1/apikey/3/rotate",
+			},
+			server: &apiKeyServer{key: key("3.rotated"), rotate: true},
+			want: want{
+				statusCode:  200,
+				contentType: "application/json",
+				body:        []byte(`{"apikey":{"id":3,"company_id":1,"name":"batch","scopes":["read"],"key":"3.rotated","updated_at":"2022-09-03T12:34:56Z"}}` + "\n"),
+			},
+		},
+		{
+			name: "delete ok",
+			args: args{
+				method: http.MethodDelete,
+				url:    "http://api.example.com/company/1/apikey/3",
+			},
+			server: &apiKeyServer{revoke: true},
+			want: want{
+				statusCode:  200,
+				contentType: "application/json",
+				body:        []byte(`{"apikey":{}}` + "\n"),
+			},
+		},
+		{
+			name: "delete failed",
+			args: args{
+				method: http.MethodDelete,
+				url:    "http://api.example.com/company/1/apikey/3",
+			},
+			server: &apiKeyServer{err: errors.New("error"), revoke: true},
+			want: want{
+				statusCode:  500,
+				contentType: "application/json",
+				body:        []byte(`{"error":{"code":"internal","message":"internal server error"}}` + "\n"),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+}
+
+func TestAPIKeyHandler_identify(t *testing.T) {
+	type test struct {
+		name       string
+		header     string
+		server     *apiKeyServer
+		statusCode int
+		wantKey    *auth.Key
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "http://api.example.com/", nil)
+			if tt.header != "" {
+				r.Header.Set("Authorization", tt.header)
+			}
+			w := httptest.NewRecorder()
+
+			var gotKey *auth.Key
+			next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				gotKey, _ = auth.KeyFrom(r.Context())
+			})
+			newAPIKeyHandler(tt.server).identify(next).ServeHTTP(w, r)
+
+			if tt.statusCode != w.Code {
+				t.Fatalf("Status-Code want=%v, got=%v.", tt.statusCode, w.Code)
+			}
+
+			if !reflect.DeepEqual(tt.wantKey, gotKey) {
+				t.Fatalf("key want=%v, got=%v.", tt.wantKey, gotKey)
+			}
+		})
+	}
+
+	grant := &auth.Key{CompanyID: 1, Permissions: []permission.Permission{permission.ManageEmployees}}
+
+	tests := []*test{
+		{
+			name:       "ok",
+			header:     "ApiKey 3.secret",
+			server:     &apiKeyServer{grant: grant, authenticate: true},
+			statusCode: http.StatusOK,
+			wantKey:    grant,
+		},
+		{
+			name:       "no header",
+			header:     "",
+			server:     &apiKeyServer{},
+			statusCode: http.StatusOK,
+			wantKey:    nil,
+		},
+		{
+			name:       "bearer",
+			header:     "Bearer token",
+			server:     &apiKeyServer{},
+			statusCode: http.StatusOK,
+			wantKey:    nil,
+		},
+		{
+			name:       "revoked",
+			header:     "ApiKey 3.secret",
+			server:     &apiKeyServer{err: apikey.ErrInvalidKey, authenticate: true},
+			statusCode: http.StatusUnauthorized,
+			wantKey:    nil,
+		},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+}
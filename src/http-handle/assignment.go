@@ -0,0 +1,192 @@
+package handle
+
+import (
+	"log"
+	"net/http"
+
+	"api.example.com/http-handle/request"
+	"api.example.com/http-handle/response"
+	"api.example.com/pkg/assignment"
+)
+
+type assignmentHandler struct {
+	server assignment.Server
+}
+
+func newAssignmentHandler(s assignment.Server) *assignmentHandler {
+	return &assignmentHandler{s}
+}
+
+func (h *assignmentHandler) handleAssignments(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodPost:
+		h.create(w, r)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func (h *assignmentHandler) handleAssignment(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		h.read(w, r)
+	case http.MethodDelete:
+		h.delete(w, r)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func (h *assignmentHandler) handleEmployee(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		h.listByEmployee(w, r)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func (h *assignmentHandler) handleDepartment(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		h.listByDepartment(w, r)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func (h *assignmentHandler) handleSubtree(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		h.listBySubtree(w, r)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func (h *assignmentHandler) create(w http.ResponseWriter, r *http.Request) {
+	assignment, err := request.AssignmentCreate(r)
+	if err != nil {
+		log.Println(err)
+		response.Error(w, err)
+		return
+	}
+
+	assignment, err = h.server.Create(r.Context(), assignment)
+	if err != nil {
+		log.Println(err)
+		response.Error(w, err)
+		return
+	}
+
+	err = response.AssignmentCreate(w, assignment)
+	if err != nil {
+		log.Println(err)
+	}
+}
+
+func (h *assignmentHandler) read(w http.ResponseWriter, r *http.Request) {
+	companyID, assignmentID, err := request.AssignmentRead(r)
+	if err != nil {
+		log.Println(err)
+		response.Error(w, err)
+		return
+	}
+
+	assignment, err := h.server.Read(r.Context(), companyID, assignmentID)
+	if err != nil {
+		log.Println(err)
+		response.Error(w, err)
+		return
+	}
+
+	err = response.AssignmentRead(w, assignment)
+	if err != nil {
+		log.Println(err)
+	}
+}
+
+func (h *assignmentHandler) listByEmployee(w http.ResponseWriter, r *http.Request) {
+	companyID, employeeID, err := request.AssignmentListByEmployee(r)
+	if err != nil {
+		log.Println(err)
+		response.Error(w, err)
+		return
+	}
+
+	list, err := h.server.ListByEmployee(r.Context(), companyID, employeeID)
+	if err != nil {
+		log.Println(err)
+		response.Error(w, err)
+		return
+	}
+
+	err = response.AssignmentList(w, list)
+	if err != nil {
+		log.Println(err)
+	}
+}
+
+func (h *assignmentHandler) listByDepartment(w http.ResponseWriter, r *http.Request) {
+	companyID, departmentID, err := request.AssignmentListByDepartment(r)
+	if err != nil {
+		log.Println(err)
+		response.Error(w, err)
+		return
+	}
+
+	list, err := h.server.ListByDepartment(r.Context(), companyID, departmentID)
+	if err != nil {
+		log.Println(err)
+		response.Error(w, err)
+		return
+	}
+
+	err = response.AssignmentList(w, list)
+	if err != nil {
+		log.Println(err)
+	}
+}
+
+// 部署と配下の全ての部署の配置
+func (h *assignmentHandler) listBySubtree(w http.ResponseWriter, r *http.Request) {
+	companyID, departmentID, err := request.AssignmentListByDepartment(r)
+	if err != nil {
+		log.Println(err)
+		response.Error(w, err)
+		return
+	}
+
+	list, err := h.server.ListBySubtree(r.Context(), companyID, departmentID)
+	if err != nil {
+		log.Println(err)
+		response.Error(w, err)
+		return
+	}
+
+	err = response.AssignmentList(w, list)
+	if err != nil {
+		log.Println(err)
+	}
+}
+
+func (h *assignmentHandler) delete(w http.ResponseWriter, r *http.Request) {
+	companyID, assignmentID, err := request.AssignmentDelete(r)
+	if err != nil {
+		log.Println(err)
+		response.Error(w, err)
+		return
+	}
+
+	err = h.server.Delete(r.Context(), companyID, assignmentID)
+	if err != nil {
+		log.Println(err)
+		response.Error(w, err)
+		return
+	}
+
+	err = response.AssignmentDelete(w)
+	if err != nil {
+		log.Println(err)
+	}
+}
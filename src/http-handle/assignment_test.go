@@ -0,0 +1,319 @@
+package handle
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"api.example.com/pkg/assignment"
+)
+
+// mock
+type assignmentServer struct {
+	assignment  *assignment.Assignment
+	assignments []*assignment.Assignment
+	err         error
+	// flag
+	create           bool
+	read             bool
+	listByEmployee   bool
+	listByDepartment bool
+	listBySubtree    bool
+	delete           bool
+}
+
+func (s *assignmentServer) Create(context.Context, *assignment.Assignment) (*assignment.Assignment, error) {
+	if s.create {
+		return s.assignment, s.err
+	}
+
+	panic("invalid Create")
+}
+
+func (s *assignmentServer) Read(context.Context, assignment.CompanyID, assignment.ID) (*assignment.Assignment, error) {
+	if s.read {
+		return s.assignment, s.err
+	}
+
+	panic("invalid Read")
+}
+
+func (s *assignmentServer) ListByEmployee(context.Context, assignment.CompanyID, assignment.EmployeeID) ([]*assignment.Assignment, error) {
+	if s.listByEmployee {
+		return s.assignments, s.err
+	}
+
+	panic("invalid ListByEmployee")
+}
+
+func (s *assignmentServer) ListByDepartment(context.Context, assignment.CompanyID, assignment.DepartmentID) ([]*assignment.Assignment, error) {
+	if s.listByDepartment {
+		return s.assignments, s.err
+	}
+
+	panic("invalid ListByDepartment")
+}
+
+func (s *assignmentServer) ListBySubtree(context.Context, assignment.CompanyID, assignment.DepartmentID) ([]*assignment.Assignment, error) {
+	if s.listBySubtree {
+		return s.assignments, s.err
+	}
+
+	panic("invalid ListBySubtree")
+}
+
+func (s *assignmentServer) Delete(context.Context, assignment.CompanyID, assignment.ID) error {
+	if s.delete {
+		return s.err
+	}
+
+	panic("invalid Delete")
+}
+
+func TestAssignmentHandler(t *testing.T) {
+	type args struct {
+		method string
+		url    string
+		body   []byte
+	}
+
+	type want struct {
+		statusCode  int
+		contentType string
+		body        []byte
+	}
+
+	type test struct {
+		name   string
+		args   args
+		server *assignmentServer
+		want   want
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(tt.args.method, tt.args.url, bytes.NewBuffer(tt.args.body))
+			w := httptest.NewRecorder()
+
+			s := newServices()
+			s.Assignment = tt.server
+
+			New(s).ServeHTTP(w, r)
+
+			got := w.Result()
+			defer got.Body.Close()
+
+			gotBody, _ := io.ReadAll(got.Body)
+			if !reflect.DeepEqual(tt.want.body, gotBody) {
+				t.Fatalf("body want=%s, got=%s.", tt.want.body, gotBody)
+			}
+
+			gotContentType := got.Header.Get("Content-Type")
+			if tt.want.contentType != gotContentType {
+				t.Fatalf("Content-Type want=%v, got=%v.", tt.want.contentType, gotContentType)
+			}
+
+			gotStatusCode := got.StatusCode
+			if tt.want.statusCode != gotStatusCode {
+				t.Fatalf("Status-Code want=%v, got=%v.", tt.want.statusCode, gotStatusCode)
+			}
+		})
+	}
+
+	a := &assignment.Assignment{
+		ID:           5,
+		CompanyID:    1,
+		EmployeeID:   2,
+		DepartmentID: 3,
+		RoleID:       4,
+		UpdatedAt:    time.Date(2022, 9, 3, 12, 34, 56, 0, time.UTC),
+	}
+	value := `{"id":5,"company_id":1,"employee_id":2,"department_id":3,"role_id":4,"updated_at":"2022-09-03T12:34:56Z"}`
+	body := `{"assignment":` + value + `}` + "\n"
+	list := `{"assignments":[` + value + `]}` + "\n"
+
+	tests := []*test{
+		{
+			name: "create ok",
+			args: args{
+				method: http.MethodPost,
+				url:    "http://api.example.com/company/1/assignment",
+				body:   []byte(`{"assignment":{"employee_id":2,"department_id":3,"role_id":4}}`),
+			},
+			server: &assignmentServer{assignment: a, create: true},
+			want: want{
+				statusCode:  200,
+				contentType: "application/json",
+				body:        []byte(body),
+			},
+		},
+		{
+			name: "create invalid request",
+			args: args{
+				method: http.MethodPost,
+				url:    "http://api.example.com/company/1/assignment",
+				body:   []byte(``),
+			},
+			server: &assignmentServer{},
+			want: want{
+				statusCode:  400,
+				contentType: "application/json",
+				body:        []byte(`{"error":{"code":"invalid_argument","message":"invalid argument"}}` + "\n"),
+			},
+		},
+		{
+			name: "create failed",
+			args: args{
+				method: http.MethodPost,
+				url:    "http://api.example.com/company/1/assignment",
+				body:   []byte(`{"assignment":{"employee_id":2,"department_id":3,"role_id":4}}`),
+			},
+			server: &assignmentServer{err: errors.New("error"), create: true},
+			want: want{
+				statusCode:  500,
+				contentType: "application/json",
+				body:        []byte(`{"error":{"code":"internal","message":"internal server error"}}` + "\n"),
+			},
+		},
+		{
+			name: "read ok",
+			args: args{
+				method: http.MethodGet,
+				url:    "http://api.example.com/company/1/assignment/5",
+			},
+			server: &assignmentServer{assignment: a, read: true},
+			want: want{
+				statusCode:  200,
+				contentType: "application/json",
+				body:        []byte(body),
+			},
+		},
+		{
+			name: "read failed",
+			args: args{
+				method: http.MethodGet,
+				url:    "http://api.example.com/company/1/assignment/5",
+			},
+			server: &assignmentServer{err: errors.New("error"), read: true},
+			want: want{
+				statusCode:  500,
+				contentType: "application/json",
+				body:        []byte(`{"error":{"code":"internal","message":"internal server error"}}` + "\n"),
+			},
+		},
+		{
+			name: "list by employee ok",
+			args: args{
+				method: http.MethodGet,
+				url:    "http://api.example.com/company/1/employee/2/assignment",
+			},
+			server: &assignmentServer{assignments: []*assignment.Assignment{a}, listByEmployee: true},
+			want: want{
+				statusCode:  200,
+				contentType: "application/json",
+				body:        []byte(list),
+			},
+		},
+		{
+			name: "list by employee failed",
+			args: args{
+				method: http.MethodGet,
+				url:    "http://api.example.com/company/1/employee/2/assignment",
+			},
+			server: &assignmentServer{err: errors.New("error"), listByEmployee: true},
+			want: want{
+				statusCode:  500,
+				contentType: "application/json",
+				body:        []byte(`{"error":{"code":"internal","message":"internal server error"}}` + "\n"),
+			},
+		},
+		{
+			name: "list by department ok",
+			args: args{
+				method: http.MethodGet,
+				url:    "http://api.example.com/company/1/department/3/assignment",
+			},
+			server: &assignmentServer{assignments: []*assignment.Assignment{a}, listByDepartment: true},
+			want: want{
+				statusCode:  200,
+				contentType: "application/json",
+				body:        []byte(list),
+			},
+		},
+		{
+			name: "list by department failed",
+			args: args{
+				method: http.MethodGet,
+				url:    "http://api.example.com/company/1/department/3/assignment",
+			},
+			server: &assignmentServer{err: errors.New("error"), listByDepartment: true},
+			want: want{
+				statusCode:  500,
+				contentType: "application/json",
+				body:        []byte(`{"error":{"code":"internal","message":"internal server error"}}` + "\n"),
+			},
+		},
+		{
+			name: "list by subtree ok",
+			args: args{
+				method: http.MethodGet,
+				url:    "http://api.example.com/company/1/department/3/subtree/assignment",
+			},
+			server: &assignmentServer{assignments: []*assignment.Assignment{a}, listBySubtree: true},
+			want: want{
+				statusCode:  200,
+				contentType: "application/json",
+				body:        []byte(list),
+			},
+		},
+		{
+			name: "list by subtree invalid request",
+			args: args{
+				method: http.MethodGet,
+				url:    "http://api.example.com/company/1/department/hoge/subtree/assignment",
+			},
+			server: &assignmentServer{},
+			want: want{
+				statusCode:  400,
+				contentType: "application/json",
+				body:        []byte(`{"error":{"code":"invalid_argument","message":"invalid argument"}}` + "\n"),
+			},
+		},
+		{
+			name: "delete ok",
+			args: args{
+				method: http.MethodDelete,
+				url:    "http://api.example.com/company/1/assignment/5",
+			},
+			server: &assignmentServer{delete: true},
+			want: want{
+				statusCode:  200,
+				contentType: "application/json",
+				body:        []byte(`{"assignment":{}}` + "\n"),
+			},
+		},
+		{
+			name: "delete failed",
+			args: args{
+				method: http.MethodDelete,
+				url:    "http://api.example.com/company/1/assignment/5",
+			},
+			server: &assignmentServer{err: errors.New("error"), delete: true},
+			want: want{
+				statusCode:  500,
+				contentType: "application/json",
+				body:        []byte(`{"error":{"code":"internal","message":"internal server error"}}` + "\n"),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+}
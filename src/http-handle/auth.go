@@ -0,0 +1,88 @@
+package handle
+
+import (
+	"log"
+	"net/http"
+
+	"api.example.com/http-handle/request"
+	"api.example.com/http-handle/response"
+	"api.example.com/pkg/auth"
+	"api.example.com/pkg/permission"
+)
+
+type authHandler struct {
+	server auth.Server
+}
+
+func newAuthHandler(s auth.Server) *authHandler {
+	return &authHandler{s}
+}
+
+// 呼び出し元のユーザーが識別できること
+func (h *authHandler) authenticated(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		_, err := h.server.Caller(r.Context())
+		if err != nil {
+			log.Println(err)
+			response.Error(w, err)
+			return
+		}
+
+		next(w, r)
+	}
+}
+
+// 会社の管理者であること
+// 参照以外は p の権限も必要
+func (h *authHandler) administrator(p permission.Permission, next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		companyID, err := request.AuthCompany(r)
+		if err != nil {
+			log.Println(err)
+			response.Error(w, err)
+			return
+		}
+
+		required := p
+		if r.Method == http.MethodGet {
+			required = ""
+		}
+
+		err = h.server.Administrator(r.Context(), companyID, required)
+		if err != nil {
+			log.Println(err)
+			response.Error(w, err)
+			return
+		}
+
+		next(w, r)
+	}
+}
+
+// 参照は従業員本人にも許可し、それ以外は administrator と同じ
+func (h *authHandler) self(p permission.Permission, next http.HandlerFunc) http.HandlerFunc {
+	admin := h.administrator(p, next)
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			admin(w, r)
+			return
+		}
+
+		companyID, employeeID, err := request.AuthEmployee(r)
+		if err != nil {
+			log.Println(err)
+			response.Error(w, err)
+			return
+		}
+
+		err = h.server.Self(r.Context(), companyID, employeeID)
+		if err != nil {
+			log.Println(err)
+			response.Error(w, err)
+			return
+		}
+
+		next(w, r)
+	}
+}
@@ -0,0 +1,156 @@
+package handle
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"api.example.com/pkg/auth"
+	"api.example.com/pkg/company"
+	"api.example.com/pkg/employee"
+	"api.example.com/pkg/permission"
+)
+
+// mock
+// err が nil なら全て許可する
+type authServer struct {
+	err error
+	// 呼び出された認可と権限
+	called     string
+	permission permission.Permission
+}
+
+func (s *authServer) Caller(ctx context.Context) (auth.UserID, error) {
+	s.called = "caller"
+	id, _ := auth.CallerFrom(ctx)
+	return id, s.err
+}
+
+func (s *authServer) Administrator(ctx context.Context, companyID auth.CompanyID, p permission.Permission) error {
+	s.called = "administrator"
+	s.permission = p
+	return s.err
+}
+
+func (s *authServer) Self(ctx context.Context, companyID auth.CompanyID, employeeID auth.EmployeeID) error {
+	s.called = "self"
+	return s.err
+}
+
+func TestAuthHandler(t *testing.T) {
+	type want struct {
+		statusCode int
+		called     string
+		permission permission.Permission
+	}
+
+	type test struct {
+		name   string
+		method string
+		url    string
+		err    error
+		want   want
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(tt.method, tt.url, nil)
+			w := httptest.NewRecorder()
+
+			a := &authServer{err: tt.err}
+			s := newServices()
+			s.Auth = a
+			s.Company = &companyServer{company: &company.Company{ID: 1}, read: true, t: t}
+			s.Employee = &employeeServer{employee: &employee.Employee{ID: 3, CompanyID: 1}, read: true}
+
+			New(s).ServeHTTP(w, r)
+
+			got := w.Result()
+			defer got.Body.Close()
+			io.ReadAll(got.Body)
+
+			gotWant := want{statusCode: got.StatusCode, called: a.called, permission: a.permission}
+			if !reflect.DeepEqual(tt.want, gotWant) {
+				t.Fatalf("want=%v, got=%v.", tt.want, gotWant)
+			}
+		})
+	}
+
+	tests := []*test{
+		{
+			name:   "administrator read",
+			method: http.MethodGet,
+			url:    "http://api.example.com/company/1",
+			want:   want{statusCode: http.StatusOK, called: "administrator", permission: ""},
+		},
+		{
+			name:   "administrator update",
+			method: http.MethodPut,
+			url:    "http://api.example.com/company/1",
+			err:    auth.ErrForbidden,
+			want:   want{statusCode: http.StatusForbidden, called: "administrator", permission: permission.ManageCompany},
+		},
+		{
+			name:   "not administrator",
+			method: http.MethodGet,
+			url:    "http://api.example.com/company/1",
+			err:    auth.ErrForbidden,
+			want:   want{statusCode: http.StatusForbidden, called: "administrator", permission: ""},
+		},
+		{
+			name:   "authenticated list",
+			method: http.MethodGet,
+			url:    "http://api.example.com/company",
+			err:    auth.ErrUnauthenticated,
+			want:   want{statusCode: http.StatusUnauthorized, called: "caller", permission: ""},
+		},
+		{
+			name:   "authenticated create",
+			method: http.MethodPost,
+			url:    "http://api.example.com/company",
+			err:    auth.ErrForbidden,
+			want:   want{statusCode: http.StatusForbidden, called: "caller", permission: ""},
+		},
+		{
+			name:   "authenticated user list",
+			method: http.MethodGet,
+			url:    "http://api.example.com/user",
+			err:    auth.ErrUnauthenticated,
+			want:   want{statusCode: http.StatusUnauthorized, called: "caller", permission: ""},
+		},
+		{
+			name:   "user create without login",
+			method: http.MethodPost,
+			url:    "http://api.example.com/user",
+			err:    auth.ErrUnauthenticated,
+			want:   want{statusCode: http.StatusBadRequest, called: "", permission: ""},
+		},
+		{
+			name:   "self read",
+			method: http.MethodGet,
+			url:    "http://api.example.com/company/1/employee/3",
+			want:   want{statusCode: http.StatusOK, called: "self", permission: ""},
+		},
+		{
+			name:   "self delete",
+			method: http.MethodDelete,
+			url:    "http://api.example.com/company/1/employee/3",
+			err:    auth.ErrForbidden,
+			want:   want{statusCode: http.StatusForbidden, called: "administrator", permission: permission.ManageEmployees},
+		},
+		{
+			name:   "unauthenticated",
+			method: http.MethodGet,
+			url:    "http://api.example.com/company/1",
+			err:    auth.ErrUnauthenticated,
+			want:   want{statusCode: http.StatusUnauthorized, called: "administrator", permission: ""},
+		},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+}
@@ -6,6 +6,7 @@ import (
 
 	"api.example.com/http-handle/request"
 	"api.example.com/http-handle/response"
+	"api.example.com/pkg/auth"
 	"api.example.com/pkg/company"
 )
 
@@ -17,12 +18,17 @@ func newCompanyHandler(s company.Server) *companyHandler {
 	return &companyHandler{s}
 }
 
-func (h *companyHandler) handleCompanies(w http.ResponseWriter, r *http.Request) {
-	switch r.Method {
-	case http.MethodPost:
-		h.create(w, r)
-	default:
-		http.NotFound(w, r)
+// 一覧と登録はそれぞれの認可を経た list と create に振り分ける
+func (h *companyHandler) handleCompanies(list, create http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			list(w, r)
+		case http.MethodPost:
+			create(w, r)
+		default:
+			http.NotFound(w, r)
+		}
 	}
 }
 
@@ -30,6 +36,10 @@ func (h *companyHandler) handleCompany(w http.ResponseWriter, r *http.Request) {
 	switch r.Method {
 	case http.MethodGet:
 		h.read(w, r)
+	case http.MethodPut:
+		h.update(w, r)
+	case http.MethodDelete:
+		h.delete(w, r)
 	default:
 		http.NotFound(w, r)
 	}
@@ -43,7 +53,8 @@ func (h *companyHandler) create(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	company, err = h.server.Create(company)
+	caller, _ := auth.CallerFrom(r.Context())
+	company, err = h.server.Create(r.Context(), company, caller)
 	if err != nil {
 		log.Println(err)
 		response.Error(w, err)
@@ -52,6 +63,7 @@ func (h *companyHandler) create(w http.ResponseWriter, r *http.Request) {
 
 	err = response.WriteCompany(w, company)
 	if err != nil {
+		log.Println(err)
 	}
 }
 
@@ -64,7 +76,7 @@ func (h *companyHandler) read(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	company, err := h.server.Read(companyId)
+	company, err := h.server.Read(r.Context(), companyId)
 	if err != nil {
 		log.Println(err)
 		response.Error(w, err)
@@ -76,3 +88,67 @@ func (h *companyHandler) read(w http.ResponseWriter, r *http.Request) {
 		log.Println(err)
 	}
 }
+
+func (h *companyHandler) list(w http.ResponseWriter, r *http.Request) {
+	query, err := request.CompanyList(r)
+	if err != nil {
+		log.Println(err)
+		response.Error(w, err)
+		return
+	}
+
+	caller, _ := auth.CallerFrom(r.Context())
+	page, err := h.server.List(r.Context(), query, caller)
+	if err != nil {
+		log.Println(err)
+		response.Error(w, err)
+		return
+	}
+
+	err = response.CompanyList(w, page)
+	if err != nil {
+		log.Println(err)
+	}
+}
+
+func (h *companyHandler) update(w http.ResponseWriter, r *http.Request) {
+	company, err := request.CompanyUpdate(r)
+	if err != nil {
+		log.Println(err)
+		response.Error(w, err)
+		return
+	}
+
+	company, err = h.server.Update(r.Context(), company)
+	if err != nil {
+		log.Println(err)
+		response.Error(w, err)
+		return
+	}
+
+	err = response.CompanyUpdate(w, company)
+	if err != nil {
+		log.Println(err)
+	}
+}
+
+func (h *companyHandler) delete(w http.ResponseWriter, r *http.Request) {
+	companyID, err := request.CompanyDelete(r)
+	if err != nil {
+		log.Println(err)
+		response.Error(w, err)
+		return
+	}
+
+	err = h.server.Delete(r.Context(), companyID)
+	if err != nil {
+		log.Println(err)
+		response.Error(w, err)
+		return
+	}
+
+	err = response.CompanyDelete(w)
+	if err != nil {
+		log.Println(err)
+	}
+}
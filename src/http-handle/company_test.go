@@ -2,6 +2,7 @@ package handle
 
 import (
 	"bytes"
+	"context"
 	"errors"
 	"io"
 	"net/http"
@@ -11,15 +12,24 @@ import (
 	"time"
 
 	"api.example.com/pkg/company"
+	"api.example.com/pkg/page"
+	"api.example.com/pkg/session"
 )
 
 // mock
 type companyServer struct {
 	company *company.Company
+	page    *company.Page
 	err     error
 	// flag
 	create bool
 	read   bool
+	list   bool
+	update bool
+	delete bool
+	// List に渡された条件
+	query  company.ListQuery
+	caller company.OwnerID
 	// test
 	t *testing.T
 }
@@ -27,7 +37,7 @@ type companyServer struct {
 // mock
 type makeServer func(t *testing.T) company.Server
 
-func (s *companyServer) Create(*company.Company) (*company.Company, error) {
+func (s *companyServer) Create(context.Context, *company.Company, company.OwnerID) (*company.Company, error) {
 	s.t.Helper()
 
 	if s.create {
@@ -37,7 +47,7 @@ func (s *companyServer) Create(*company.Company) (*company.Company, error) {
 	panic("invalid Create")
 }
 
-func (s *companyServer) Read(company.ID) (*company.Company, error) {
+func (s *companyServer) Read(context.Context, company.ID) (*company.Company, error) {
 	// s.t.Helper()
 
 	if s.read {
@@ -47,6 +57,31 @@ func (s *companyServer) Read(company.ID) (*company.Company, error) {
 	panic("invalid Read")
 }
 
+func (s *companyServer) List(ctx context.Context, q company.ListQuery, caller company.OwnerID) (*company.Page, error) {
+	if s.list {
+		s.query, s.caller = q, caller
+		return s.page, s.err
+	}
+
+	panic("invalid List")
+}
+
+func (s *companyServer) Update(context.Context, *company.Company) (*company.Company, error) {
+	if s.update {
+		return s.company, s.err
+	}
+
+	panic("invalid Update")
+}
+
+func (s *companyServer) Delete(context.Context, company.ID) error {
+	if s.delete {
+		return s.err
+	}
+
+	panic("invalid Delete")
+}
+
 func TestCompanyHanlder_create(t *testing.T) {
 	type args struct {
 		url  string
@@ -134,9 +169,9 @@ func TestCompanyHanlder_create(t *testing.T) {
 				}
 			},
 			want: want{
-				statusCode:  500,
+				statusCode:  400,
 				contentType: "application/json",
-				body:        []byte(`{"error":{}}` + "\n"),
+				body:        []byte(`{"error":{"code":"invalid_argument","message":"invalid argument"}}` + "\n"),
 			},
 		},
 		{
@@ -155,7 +190,7 @@ func TestCompanyHanlder_create(t *testing.T) {
 			want: want{
 				statusCode:  500,
 				contentType: "application/json",
-				body:        []byte(`{"error":{}}` + "\n"),
+				body:        []byte(`{"error":{"code":"internal","message":"internal server error"}}` + "\n"),
 			},
 		},
 	}
@@ -250,9 +285,9 @@ func TestCompanyHandler_read(t *testing.T) {
 				read: true,
 			},
 			want: want{
-				statusCode:  http.StatusInternalServerError,
+				statusCode:  http.StatusBadRequest,
 				contentType: "application/json",
-				body:        []byte(`{"error":{}}` + "\n"),
+				body:        []byte(`{"error":{"code":"invalid_argument","message":"invalid argument"}}` + "\n"),
 			},
 		},
 		{
@@ -268,7 +303,315 @@ func TestCompanyHandler_read(t *testing.T) {
 			want: want{
 				statusCode:  http.StatusInternalServerError,
 				contentType: "application/json",
-				body:        []byte(`{"error":{}}` + "\n"),
+				body:        []byte(`{"error":{"code":"internal","message":"internal server error"}}` + "\n"),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+}
+
+func TestCompanyHandler_list(t *testing.T) {
+	type want struct {
+		statusCode int
+		body       []byte
+		query      company.ListQuery
+		// 呼び出し元の会社に絞り込む
+		caller company.OwnerID
+	}
+
+	type test struct {
+		testcase string
+		url      string
+		server   *companyServer
+		want     want
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.testcase, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, tt.url, nil)
+			r.Header.Set("Authorization", "Bearer token")
+			w := httptest.NewRecorder()
+
+			s := newServices()
+			s.Company = tt.server
+			s.Session = &sessionServer{session: &session.Session{UserID: 7}, authenticate: true}
+			New(s).ServeHTTP(w, r)
+
+			got := w.Result()
+			defer got.Body.Close()
+
+			gotBody, _ := io.ReadAll(got.Body)
+			if !reflect.DeepEqual(tt.want.body, gotBody) {
+				t.Fatalf("want=%s, got=%s.", tt.want.body, gotBody)
+			}
+
+			if tt.want.statusCode != got.StatusCode {
+				t.Fatalf("want=%v, got=%v.", tt.want.statusCode, got.StatusCode)
+			}
+
+			if tt.want.query != tt.server.query {
+				t.Fatalf("want=%v, got=%v.", tt.want.query, tt.server.query)
+			}
+
+			if tt.want.caller != tt.server.caller {
+				t.Fatalf("want=%v, got=%v.", tt.want.caller, tt.server.caller)
+			}
+		})
+	}
+
+	tests := []*test{
+		{
+			testcase: "ok",
+			url:      "http://api.example.com/company?name_prefix=test&cursor=MQ&limit=1",
+			server: &companyServer{
+				page: &company.Page{
+					Companies: []*company.Company{
+						{
+							ID:        2,
+							Name:      "testCompany",
+							OwnerID:   1,
+							UpdatedAt: time.Date(2022, 9, 3, 12, 34, 56, 0, time.UTC),
+						},
+					},
+					Next: "Mg",
+				},
+				list: true,
+			},
+			want: want{
+				statusCode: http.StatusOK,
+				body:       []byte(`{"companies":[{"id":2,"name":"testCompany","owner_id":1,"updated_at":"2022-09-03T12:34:56Z"}],"next_cursor":"Mg"}` + "\n"),
+				query:      company.ListQuery{NamePrefix: "test", Query: page.Query{Cursor: "MQ", Limit: 1}},
+				caller:     7,
+			},
+		},
+		{
+			testcase: "last page",
+			url:      "http://api.example.com/company",
+			server: &companyServer{
+				page: &company.Page{},
+				list: true,
+			},
+			want: want{
+				statusCode: http.StatusOK,
+				body:       []byte(`{"companies":[],"next_cursor":""}` + "\n"),
+				caller:     7,
+			},
+		},
+		{
+			testcase: "invalid limit",
+			url:      "http://api.example.com/company?limit=xxx",
+			server:   &companyServer{},
+			want: want{
+				statusCode: http.StatusBadRequest,
+				body:       []byte(`{"error":{"code":"invalid_argument","message":"invalid argument"}}` + "\n"),
+			},
+		},
+		{
+			testcase: "failed server-list",
+			url:      "http://api.example.com/company",
+			server: &companyServer{
+				err:  errors.New("internal server error"),
+				list: true,
+			},
+			want: want{
+				statusCode: http.StatusInternalServerError,
+				body:       []byte(`{"error":{"code":"internal","message":"internal server error"}}` + "\n"),
+				caller:     7,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+}
+
+func TestCompanyHandler_update(t *testing.T) {
+	type args struct {
+		url  string
+		body []byte
+	}
+
+	type want struct {
+		statusCode  int
+		contentType string
+		body        []byte
+	}
+
+	type test struct {
+		testcase string
+		args
+		server company.Server
+		want   want
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.testcase, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodPut, tt.url, bytes.NewBuffer(tt.args.body))
+			w := httptest.NewRecorder()
+
+			s := newServices()
+			s.Company = tt.server
+			New(s).ServeHTTP(w, r)
+
+			got := w.Result()
+			defer got.Body.Close()
+
+			gotBody, _ := io.ReadAll(got.Body)
+			if !reflect.DeepEqual(tt.want.body, gotBody) {
+				t.Fatalf("want=%s, got=%s.", tt.want.body, gotBody)
+			}
+
+			gotContentType := got.Header.Get("Content-Type")
+			if tt.want.contentType != gotContentType {
+				t.Fatalf("want=%v, got-%v.", tt.want.contentType, gotContentType)
+			}
+
+			gotStatusCode := got.StatusCode
+			if tt.want.statusCode != gotStatusCode {
+				t.Fatalf("want=%v, got=%v.", tt.want.statusCode, gotStatusCode)
+			}
+		})
+	}
+
+	tests := []*test{
+		{
+			testcase: "ok",
+			args: args{
+				url:  "http://api.example.com/company/1",
+				body: []byte(`{"company":{"name":"greate company","owner_id":2}}`),
+			},
+			server: &companyServer{
+				company: &company.Company{
+					ID:        1,
+					Name:      "greate company",
+					OwnerID:   2,
+					UpdatedAt: time.Date(2022, 9, 3, 12, 34, 56, 0, time.UTC),
+				},
+				update: true,
+			},
+			want: want{
+				statusCode:  http.StatusOK,
+				contentType: "application/json",
+				body:        []byte(`{"company":{"id":1,"name":"greate company","owner_id":2,"updated_at":"2022-09-03T12:34:56Z"}}` + "\n"),
+			},
+		},
+		{
+			testcase: "invalid company_id",
+			args: args{
+				url:  "http://api.example.com/company/xxx",
+				body: []byte(`{"company":{"name":"greate company","owner_id":2}}`),
+			},
+			server: &companyServer{},
+			want: want{
+				statusCode:  http.StatusBadRequest,
+				contentType: "application/json",
+				body:        []byte(`{"error":{"code":"invalid_argument","message":"invalid argument"}}` + "\n"),
+			},
+		},
+		{
+			testcase: "failed server-update",
+			args: args{
+				url:  "http://api.example.com/company/1",
+				body: []byte(`{"company":{"name":"greate company","owner_id":2}}`),
+			},
+			server: &companyServer{
+				err:    errors.New("internal server error"),
+				update: true,
+			},
+			want: want{
+				statusCode:  http.StatusInternalServerError,
+				contentType: "application/json",
+				body:        []byte(`{"error":{"code":"internal","message":"internal server error"}}` + "\n"),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+}
+
+func TestCompanyHandler_delete(t *testing.T) {
+	type want struct {
+		statusCode  int
+		contentType string
+		body        []byte
+	}
+
+	type test struct {
+		testcase string
+		url      string
+		server   company.Server
+		want     want
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.testcase, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodDelete, tt.url, nil)
+			w := httptest.NewRecorder()
+
+			s := newServices()
+			s.Company = tt.server
+			New(s).ServeHTTP(w, r)
+
+			got := w.Result()
+			defer got.Body.Close()
+
+			gotBody, _ := io.ReadAll(got.Body)
+			if !reflect.DeepEqual(tt.want.body, gotBody) {
+				t.Fatalf("want=%s, got=%s.", tt.want.body, gotBody)
+			}
+
+			gotContentType := got.Header.Get("Content-Type")
+			if tt.want.contentType != gotContentType {
+				t.Fatalf("want=%v, got-%v.", tt.want.contentType, gotContentType)
+			}
+
+			gotStatusCode := got.StatusCode
+			if tt.want.statusCode != gotStatusCode {
+				t.Fatalf("want=%v, got=%v.", tt.want.statusCode, gotStatusCode)
+			}
+		})
+	}
+
+	tests := []*test{
+		{
+			testcase: "ok",
+			url:      "http://api.example.com/company/1",
+			server: &companyServer{
+				delete: true,
+			},
+			want: want{
+				statusCode:  http.StatusOK,
+				contentType: "application/json",
+				body:        []byte(`{"company":{}}` + "\n"),
+			},
+		},
+		{
+			testcase: "invalid company_id",
+			url:      "http://api.example.com/company/xxx",
+			server:   &companyServer{},
+			want: want{
+				statusCode:  http.StatusBadRequest,
+				contentType: "application/json",
+				body:        []byte(`{"error":{"code":"invalid_argument","message":"invalid argument"}}` + "\n"),
+			},
+		},
+		{
+			testcase: "failed server-delete",
+			url:      "http://api.example.com/company/1",
+			server: &companyServer{
+				err:    errors.New("internal server error"),
+				delete: true,
+			},
+			want: want{
+				statusCode:  http.StatusInternalServerError,
+				contentType: "application/json",
+				body:        []byte(`{"error":{"code":"internal","message":"internal server error"}}` + "\n"),
 			},
 		},
 	}
@@ -0,0 +1,23 @@
+package handle
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+// リクエストごとの期限
+// 期限を過ぎるか接続が切れると context が取り消され、実行中の SQL も中断される
+// d が 0 以下であれば期限を設けない
+func Deadline(h http.Handler, d time.Duration) http.Handler {
+	if d <= 0 {
+		return h
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx, cancel := context.WithTimeout(r.Context(), d)
+		defer cancel()
+
+		h.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
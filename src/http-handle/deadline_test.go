@@ -0,0 +1,51 @@
+package handle
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestDeadline(t *testing.T) {
+	type test struct {
+		name         string
+		timeout      time.Duration
+		wantDeadline bool
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.name, func(t *testing.T) {
+			var got bool
+			h := Deadline(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				var deadline time.Time
+				deadline, got = r.Context().Deadline()
+				if got && time.Until(deadline) > tt.timeout {
+					t.Fatalf("want<=%v, got=%v.", tt.timeout, time.Until(deadline))
+				}
+			}), tt.timeout)
+
+			h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/user/1", nil))
+			if tt.wantDeadline != got {
+				t.Fatalf("want=%v, got=%v.", tt.wantDeadline, got)
+			}
+		})
+	}
+
+	tests := []*test{
+		{
+			name:         "with deadline",
+			timeout:      time.Second,
+			wantDeadline: true,
+		},
+		{
+			name:         "without deadline",
+			timeout:      0,
+			wantDeadline: false,
+		},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+}
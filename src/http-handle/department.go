@@ -0,0 +1,231 @@
+package handle
+
+import (
+	"context"
+	"log"
+	"net/http"
+
+	"api.example.com/http-handle/request"
+	"api.example.com/http-handle/response"
+	"api.example.com/pkg/organization"
+)
+
+type departmentHandler struct {
+	server organization.Server
+}
+
+func newDepartmentHandler(s organization.Server) *departmentHandler {
+	return &departmentHandler{s}
+}
+
+func (h *departmentHandler) handleDepartments(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		h.list(w, r)
+	case http.MethodPost:
+		h.create(w, r)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func (h *departmentHandler) handleDepartment(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		h.read(w, r)
+	case http.MethodPut:
+		h.rename(w, r)
+	case http.MethodDelete:
+		h.delete(w, r)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func (h *departmentHandler) handleParent(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodPut:
+		h.move(w, r)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func (h *departmentHandler) handleChildren(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		h.tree(w, r, h.server.Children)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func (h *departmentHandler) handleAncestors(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		h.tree(w, r, h.server.Ancestors)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func (h *departmentHandler) handleSubtree(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		h.tree(w, r, h.server.Subtree)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func (h *departmentHandler) create(w http.ResponseWriter, r *http.Request) {
+	department, err := request.DepartmentCreate(r)
+	if err != nil {
+		log.Println(err)
+		response.Error(w, err)
+		return
+	}
+
+	department, err = h.server.Create(r.Context(), department)
+	if err != nil {
+		log.Println(err)
+		response.Error(w, err)
+		return
+	}
+
+	err = response.DepartmentCreate(w, department)
+	if err != nil {
+		log.Println(err)
+	}
+}
+
+func (h *departmentHandler) list(w http.ResponseWriter, r *http.Request) {
+	companyID, err := request.DepartmentList(r)
+	if err != nil {
+		log.Println(err)
+		response.Error(w, err)
+		return
+	}
+
+	list, err := h.server.Children(r.Context(), companyID, organization.Root)
+	if err != nil {
+		log.Println(err)
+		response.Error(w, err)
+		return
+	}
+
+	err = response.DepartmentList(w, list)
+	if err != nil {
+		log.Println(err)
+	}
+}
+
+func (h *departmentHandler) read(w http.ResponseWriter, r *http.Request) {
+	companyID, departmentID, err := request.DepartmentRead(r)
+	if err != nil {
+		log.Println(err)
+		response.Error(w, err)
+		return
+	}
+
+	department, err := h.server.Read(r.Context(), companyID, departmentID)
+	if err != nil {
+		log.Println(err)
+		response.Error(w, err)
+		return
+	}
+
+	err = response.DepartmentRead(w, department)
+	if err != nil {
+		log.Println(err)
+	}
+}
+
+func (h *departmentHandler) rename(w http.ResponseWriter, r *http.Request) {
+	companyID, departmentID, name, err := request.DepartmentRename(r)
+	if err != nil {
+		log.Println(err)
+		response.Error(w, err)
+		return
+	}
+
+	department, err := h.server.Rename(r.Context(), companyID, departmentID, name)
+	if err != nil {
+		log.Println(err)
+		response.Error(w, err)
+		return
+	}
+
+	err = response.DepartmentUpdate(w, department)
+	if err != nil {
+		log.Println(err)
+	}
+}
+
+func (h *departmentHandler) move(w http.ResponseWriter, r *http.Request) {
+	companyID, departmentID, parentID, err := request.DepartmentMove(r)
+	if err != nil {
+		log.Println(err)
+		response.Error(w, err)
+		return
+	}
+
+	department, err := h.server.Move(r.Context(), companyID, departmentID, parentID)
+	if err != nil {
+		log.Println(err)
+		response.Error(w, err)
+		return
+	}
+
+	err = response.DepartmentUpdate(w, department)
+	if err != nil {
+		log.Println(err)
+	}
+}
+
+func (h *departmentHandler) delete(w http.ResponseWriter, r *http.Request) {
+	companyID, departmentID, err := request.DepartmentDelete(r)
+	if err != nil {
+		log.Println(err)
+		response.Error(w, err)
+		return
+	}
+
+	err = h.server.Delete(r.Context(), companyID, departmentID)
+	if err != nil {
+		log.Println(err)
+		response.Error(w, err)
+		return
+	}
+
+	err = response.DepartmentDelete(w)
+	if err != nil {
+		log.Println(err)
+	}
+}
+
+// children, ancestors, subtree
+func (h *departmentHandler) tree(
+	w http.ResponseWriter,
+	r *http.Request,
+	find func(context.Context, organization.CompanyID, organization.ID) ([]*organization.Department, error),
+) {
+	companyID, departmentID, err := request.DepartmentTree(r)
+	if err != nil {
+		log.Println(err)
+		response.Error(w, err)
+		return
+	}
+
+	list, err := find(r.Context(), companyID, departmentID)
+	if err != nil {
+		log.Println(err)
+		response.Error(w, err)
+		return
+	}
+
+	err = response.DepartmentList(w, list)
+	if err != nil {
+		log.Println(err)
+	}
+}
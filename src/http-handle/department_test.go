@@ -0,0 +1,338 @@
+package handle
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"api.example.com/pkg/organization"
+)
+
+// mock
+type departmentServer struct {
+	department  *organization.Department
+	departments []*organization.Department
+	err         error
+	// flag
+	create, read, rename, move, delete bool
+	children, ancestors, subtree       bool
+}
+
+func (s *departmentServer) Create(context.Context, *organization.Department) (*organization.Department, error) {
+	if s.create {
+		return s.department, s.err
+	}
+
+	panic("invalid Create")
+}
+
+func (s *departmentServer) Read(context.Context, organization.CompanyID, organization.ID) (*organization.Department, error) {
+	if s.read {
+		return s.department, s.err
+	}
+
+	panic("invalid Read")
+}
+
+func (s *departmentServer) Rename(context.Context, organization.CompanyID, organization.ID, organization.Name) (*organization.Department, error) {
+	if s.rename {
+		return s.department, s.err
+	}
+
+	panic("invalid Rename")
+}
+
+func (s *departmentServer) Move(context.Context, organization.CompanyID, organization.ID, organization.ID) (*organization.Department, error) {
+	if s.move {
+		return s.department, s.err
+	}
+
+	panic("invalid Move")
+}
+
+func (s *departmentServer) Delete(context.Context, organization.CompanyID, organization.ID) error {
+	if s.delete {
+		return s.err
+	}
+
+	panic("invalid Delete")
+}
+
+func (s *departmentServer) Children(context.Context, organization.CompanyID, organization.ID) ([]*organization.Department, error) {
+	if s.children {
+		return s.departments, s.err
+	}
+
+	panic("invalid Children")
+}
+
+func (s *departmentServer) Ancestors(context.Context, organization.CompanyID, organization.ID) ([]*organization.Department, error) {
+	if s.ancestors {
+		return s.departments, s.err
+	}
+
+	panic("invalid Ancestors")
+}
+
+func (s *departmentServer) Subtree(context.Context, organization.CompanyID, organization.ID) ([]*organization.Department, error) {
+	if s.subtree {
+		return s.departments, s.err
+	}
+
+	panic("invalid Subtree")
+}
+
+func TestDepartmentHandler(t *testing.T) {
+	type args struct {
+		method string
+		url    string
+		body   []byte
+	}
+
+	type want struct {
+		statusCode  int
+		contentType string
+		body        []byte
+	}
+
+	type test struct {
+		name   string
+		args   args
+		server *departmentServer
+		want   want
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(tt.args.method, tt.args.url, bytes.NewBuffer(tt.args.body))
+			w := httptest.NewRecorder()
+
+			s := newServices()
+			s.Organization = tt.server
+
+			New(s).ServeHTTP(w, r)
+
+			got := w.Result()
+			defer got.Body.Close()
+
+			gotBody, _ := io.ReadAll(got.Body)
+			if !reflect.DeepEqual(tt.want.body, gotBody) {
+				t.Fatalf("body want=%s, got=%s.", tt.want.body, gotBody)
+			}
+
+			gotContentType := got.Header.Get("Content-Type")
+			if tt.want.contentType != gotContentType {
+				t.Fatalf("Content-Type want=%v, got=%v.", tt.want.contentType, gotContentType)
+			}
+
+			gotStatusCode := got.StatusCode
+			if tt.want.statusCode != gotStatusCode {
+				t.Fatalf("Status-Code want=%v, got=%v.", tt.want.statusCode, gotStatusCode)
+			}
+		})
+	}
+
+	root := &organization.Department{
+		ID:        2,
+		CompanyID: 1,
+		ParentID:  organization.Root,
+		Name:      "開発部",
+		UpdatedAt: time.Date(2022, 9, 3, 12, 34, 56, 0, time.UTC),
+	}
+	child := &organization.Department{
+		ID:        3,
+		CompanyID: 1,
+		ParentID:  2,
+		Name:      "開発一課",
+		UpdatedAt: time.Date(2022, 9, 3, 12, 34, 56, 0, time.UTC),
+	}
+	rootBody := `{"id":2,"company_id":1,"parent_id":null,"name":"開発部","updated_at":"2022-09-03T12:34:56Z"}`
+	childBody := `{"id":3,"company_id":1,"parent_id":2,"name":"開発一課","updated_at":"2022-09-03T12:34:56Z"}`
+
+	tests := []*test{
+		{
+			name: "create",
+			args: args{
+				method: http.MethodPost,
+				url:    "http://api.example.com/company/1/department",
+				body:   []byte(`{"department":{"name":"開発一課","parent_id":2}}`),
+			},
+			server: &departmentServer{department: child, create: true},
+			want: want{
+				statusCode:  200,
+				contentType: "application/json",
+				body:        []byte(`{"department":` + childBody + `}` + "\n"),
+			},
+		},
+		{
+			name: "create invalid request",
+			args: args{
+				method: http.MethodPost,
+				url:    "http://api.example.com/company/1/department",
+				body:   []byte(``),
+			},
+			server: &departmentServer{},
+			want: want{
+				statusCode:  400,
+				contentType: "application/json",
+				body:        []byte(`{"error":{"code":"invalid_argument","message":"invalid argument"}}` + "\n"),
+			},
+		},
+		{
+			name: "list",
+			args: args{
+				method: http.MethodGet,
+				url:    "http://api.example.com/company/1/department",
+			},
+			server: &departmentServer{departments: []*organization.Department{root}, children: true},
+			want: want{
+				statusCode:  200,
+				contentType: "application/json",
+				body:        []byte(`{"departments":[` + rootBody + `]}` + "\n"),
+			},
+		},
+		{
+			name: "read",
+			args: args{
+				method: http.MethodGet,
+				url:    "http://api.example.com/company/1/department/2",
+			},
+			server: &departmentServer{department: root, read: true},
+			want: want{
+				statusCode:  200,
+				contentType: "application/json",
+				body:        []byte(`{"department":` + rootBody + `}` + "\n"),
+			},
+		},
+		{
+			name: "rename",
+			args: args{
+				method: http.MethodPut,
+				url:    "http://api.example.com/company/1/department/3",
+				body:   []byte(`{"department":{"name":"開発一課"}}`),
+			},
+			server: &departmentServer{department: child, rename: true},
+			want: want{
+				statusCode:  200,
+				contentType: "application/json",
+				body:        []byte(`{"department":` + childBody + `}` + "\n"),
+			},
+		},
+		{
+			name: "move",
+			args: args{
+				method: http.MethodPut,
+				url:    "http://api.example.com/company/1/department/3/parent",
+				body:   []byte(`{"department":{"parent_id":2}}`),
+			},
+			server: &departmentServer{department: child, move: true},
+			want: want{
+				statusCode:  200,
+				contentType: "application/json",
+				body:        []byte(`{"department":` + childBody + `}` + "\n"),
+			},
+		},
+		{
+			name: "move cycle",
+			args: args{
+				method: http.MethodPut,
+				url:    "http://api.example.com/company/1/department/2/parent",
+				body:   []byte(`{"department":{"parent_id":3}}`),
+			},
+			server: &departmentServer{err: organization.ErrCycle, move: true},
+			want: want{
+				statusCode:  400,
+				contentType: "application/json",
+				body:        []byte(`{"error":{"code":"invalid_argument","message":"department cycle"}}` + "\n"),
+			},
+		},
+		{
+			name: "delete",
+			args: args{
+				method: http.MethodDelete,
+				url:    "http://api.example.com/company/1/department/3",
+			},
+			server: &departmentServer{delete: true},
+			want: want{
+				statusCode:  200,
+				contentType: "application/json",
+				body:        []byte(`{"department":{}}` + "\n"),
+			},
+		},
+		{
+			name: "delete failed",
+			args: args{
+				method: http.MethodDelete,
+				url:    "http://api.example.com/company/1/department/2",
+			},
+			server: &departmentServer{err: errors.New("has children"), delete: true},
+			want: want{
+				statusCode:  500,
+				contentType: "application/json",
+				body:        []byte(`{"error":{"code":"internal","message":"internal server error"}}` + "\n"),
+			},
+		},
+		{
+			name: "children",
+			args: args{
+				method: http.MethodGet,
+				url:    "http://api.example.com/company/1/department/2/children",
+			},
+			server: &departmentServer{departments: []*organization.Department{child}, children: true},
+			want: want{
+				statusCode:  200,
+				contentType: "application/json",
+				body:        []byte(`{"departments":[` + childBody + `]}` + "\n"),
+			},
+		},
+		{
+			name: "ancestors",
+			args: args{
+				method: http.MethodGet,
+				url:    "http://api.example.com/company/1/department/3/ancestors",
+			},
+			server: &departmentServer{departments: []*organization.Department{root}, ancestors: true},
+			want: want{
+				statusCode:  200,
+				contentType: "application/json",
+				body:        []byte(`{"departments":[` + rootBody + `]}` + "\n"),
+			},
+		},
+		{
+			name: "subtree",
+			args: args{
+				method: http.MethodGet,
+				url:    "http://api.example.com/company/1/department/2/subtree",
+			},
+			server: &departmentServer{departments: []*organization.Department{root, child}, subtree: true},
+			want: want{
+				statusCode:  200,
+				contentType: "application/json",
+				body:        []byte(`{"departments":[` + rootBody + `,` + childBody + `]}` + "\n"),
+			},
+		},
+		{
+			name: "subtree failed",
+			args: args{
+				method: http.MethodGet,
+				url:    "http://api.example.com/company/1/department/2/subtree",
+			},
+			server: &departmentServer{err: errors.New("not found"), subtree: true},
+			want: want{
+				statusCode:  500,
+				contentType: "application/json",
+				body:        []byte(`{"error":{"code":"internal","message":"internal server error"}}` + "\n"),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+}
@@ -0,0 +1,124 @@
+package handle
+
+import (
+	"log"
+	"net/http"
+
+	"api.example.com/http-handle/request"
+	"api.example.com/http-handle/response"
+	"api.example.com/pkg/employee"
+)
+
+type employeeHandler struct {
+	server employee.Server
+}
+
+func newEmployeeHandler(s employee.Server) *employeeHandler {
+	return &employeeHandler{s}
+}
+
+func (h *employeeHandler) handleEmployees(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		h.list(w, r)
+	case http.MethodPost:
+		h.create(w, r)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func (h *employeeHandler) handleEmployee(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		h.read(w, r)
+	case http.MethodDelete:
+		h.delete(w, r)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func (h *employeeHandler) create(w http.ResponseWriter, r *http.Request) {
+	employee, err := request.EmployeeCreate(r)
+	if err != nil {
+		log.Println(err)
+		response.Error(w, err)
+		return
+	}
+
+	employee, err = h.server.Create(r.Context(), employee)
+	if err != nil {
+		log.Println(err)
+		response.Error(w, err)
+		return
+	}
+
+	err = response.EmployeeCreate(w, employee)
+	if err != nil {
+		log.Println(err)
+	}
+}
+
+func (h *employeeHandler) list(w http.ResponseWriter, r *http.Request) {
+	companyID, err := request.EmployeeList(r)
+	if err != nil {
+		log.Println(err)
+		response.Error(w, err)
+		return
+	}
+
+	list, err := h.server.List(r.Context(), companyID)
+	if err != nil {
+		log.Println(err)
+		response.Error(w, err)
+		return
+	}
+
+	err = response.EmployeeList(w, list)
+	if err != nil {
+		log.Println(err)
+	}
+}
+
+func (h *employeeHandler) read(w http.ResponseWriter, r *http.Request) {
+	companyID, employeeID, err := request.EmployeeRead(r)
+	if err != nil {
+		log.Println(err)
+		response.Error(w, err)
+		return
+	}
+
+	employee, err := h.server.Read(r.Context(), companyID, employeeID)
+	if err != nil {
+		log.Println(err)
+		response.Error(w, err)
+		return
+	}
+
+	err = response.EmployeeRead(w, employee)
+	if err != nil {
+		log.Println(err)
+	}
+}
+
+func (h *employeeHandler) delete(w http.ResponseWriter, r *http.Request) {
+	companyID, employeeID, err := request.EmployeeDelete(r)
+	if err != nil {
+		log.Println(err)
+		response.Error(w, err)
+		return
+	}
+
+	err = h.server.Delete(r.Context(), companyID, employeeID)
+	if err != nil {
+		log.Println(err)
+		response.Error(w, err)
+		return
+	}
+
+	err = response.EmployeeDelete(w)
+	if err != nil {
+		log.Println(err)
+	}
+}
@@ -0,0 +1,246 @@
+package handle
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"api.example.com/pkg/employee"
+)
+
+// mock
+type employeeServer struct {
+	employee  *employee.Employee
+	employees []*employee.Employee
+	err       error
+	// flag
+	create bool
+	read   bool
+	list   bool
+	delete bool
+}
+
+func (s *employeeServer) Create(context.Context, *employee.Employee) (*employee.Employee, error) {
+	if s.create {
+		return s.employee, s.err
+	}
+
+	panic("invalid Create")
+}
+
+func (s *employeeServer) Read(context.Context, employee.CompanyID, employee.ID) (*employee.Employee, error) {
+	if s.read {
+		return s.employee, s.err
+	}
+
+	panic("invalid Read")
+}
+
+func (s *employeeServer) List(context.Context, employee.CompanyID) ([]*employee.Employee, error) {
+	if s.list {
+		return s.employees, s.err
+	}
+
+	panic("invalid List")
+}
+
+func (s *employeeServer) Delete(context.Context, employee.CompanyID, employee.ID) error {
+	if s.delete {
+		return s.err
+	}
+
+	panic("invalid Delete")
+}
+
+func TestEmployeeHandler(t *testing.T) {
+	type args struct {
+		method string
+		url    string
+		body   []byte
+	}
+
+	type want struct {
+		statusCode  int
+		contentType string
+		body        []byte
+	}
+
+	type test struct {
+		name   string
+		args   args
+		server *employeeServer
+		want   want
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(tt.args.method, tt.args.url, bytes.NewBuffer(tt.args.body))
+			w := httptest.NewRecorder()
+
+			s := newServices()
+			s.Employee = tt.server
+
+			New(s).ServeHTTP(w, r)
+
+			got := w.Result()
+			defer got.Body.Close()
+
+			gotBody, _ := io.ReadAll(got.Body)
+			if !reflect.DeepEqual(tt.want.body, gotBody) {
+				t.Fatalf("body want=%s, got=%s.", tt.want.body, gotBody)
+			}
+
+			gotContentType := got.Header.Get("Content-Type")
+			if tt.want.contentType != gotContentType {
+				t.Fatalf("Content-Type want=%v, got=%v.", tt.want.contentType, gotContentType)
+			}
+
+			gotStatusCode := got.StatusCode
+			if tt.want.statusCode != gotStatusCode {
+				t.Fatalf("Status-Code want=%v, got=%v.", tt.want.statusCode, gotStatusCode)
+			}
+		})
+	}
+
+	e := &employee.Employee{
+		ID:            3,
+		CompanyID:     1,
+		UserID:        2,
+		Administrator: false,
+		UpdatedAt:     time.Date(2022, 9, 3, 12, 34, 56, 0, time.UTC),
+	}
+	body := `{"employee":{"id":3,"company_id":1,"user_id":2,"administrator":false,"updated_at":"2022-09-03T12:34:56Z"}}` + "\n"
+
+	tests := []*test{
+		{
+			name: "create ok",
+			args: args{
+				method: http.MethodPost,
+				url:    "http://api.example.com/company/1/employee",
+				body:   []byte(`{"employee":{"user_id":2}}`),
+			},
+			server: &employeeServer{employee: e, create: true},
+			want: want{
+				statusCode:  200,
+				contentType: "application/json",
+				body:        []byte(body),
+			},
+		},
+		{
+			name: "create invalid request",
+			args: args{
+				method: http.MethodPost,
+				url:    "http://api.example.com/company/1/employee",
+				body:   []byte(``),
+			},
+			server: &employeeServer{},
+			want: want{
+				statusCode:  400,
+				contentType: "application/json",
+				body:        []byte(`{"error":{"code":"invalid_argument","message":"invalid argument"}}` + "\n"),
+			},
+		},
+		{
+			name: "create failed",
+			args: args{
+				method: http.MethodPost,
+				url:    "http://api.example.com/company/1/employee",
+				body:   []byte(`{"employee":{"user_id":2}}`),
+			},
+			server: &employeeServer{err: errors.New("error"), create: true},
+			want: want{
+				statusCode:  500,
+				contentType: "application/json",
+				body:        []byte(`{"error":{"code":"internal","message":"internal server error"}}` + "\n"),
+			},
+		},
+		{
+			name: "list ok",
+			args: args{
+				method: http.MethodGet,
+				url:    "http://api.example.com/company/1/employee",
+			},
+			server: &employeeServer{employees: []*employee.Employee{e}, list: true},
+			want: want{
+				statusCode:  200,
+				contentType: "application/json",
+				body:        []byte(`{"employees":[{"id":3,"company_id":1,"user_id":2,"administrator":false,"updated_at":"2022-09-03T12:34:56Z"}]}` + "\n"),
+			},
+		},
+		{
+			name: "list failed",
+			args: args{
+				method: http.MethodGet,
+				url:    "http://api.example.com/company/1/employee",
+			},
+			server: &employeeServer{err: errors.New("error"), list: true},
+			want: want{
+				statusCode:  500,
+				contentType: "application/json",
+				body:        []byte(`{"error":{"code":"internal","message":"internal server error"}}` + "\n"),
+			},
+		},
+		{
+			name: "read ok",
+			args: args{
+				method: http.MethodGet,
+				url:    "http://api.example.com/company/1/employee/3",
+			},
+			server: &employeeServer{employee: e, read: true},
+			want: want{
+				statusCode:  200,
+				contentType: "application/json",
+				body:        []byte(body),
+			},
+		},
+		{
+			name: "read failed",
+			args: args{
+				method: http.MethodGet,
+				url:    "http://api.example.com/company/1/employee/3",
+			},
+			server: &employeeServer{err: errors.New("error"), read: true},
+			want: want{
+				statusCode:  500,
+				contentType: "application/json",
+				body:        []byte(`{"error":{"code":"internal","message":"internal server error"}}` + "\n"),
+			},
+		},
+		{
+			name: "delete ok",
+			args: args{
+				method: http.MethodDelete,
+				url:    "http://api.example.com/company/1/employee/3",
+			},
+			server: &employeeServer{delete: true},
+			want: want{
+				statusCode:  200,
+				contentType: "application/json",
+				body:        []byte(`{"employee":{}}` + "\n"),
+			},
+		},
+		{
+			name: "delete failed",
+			args: args{
+				method: http.MethodDelete,
+				url:    "http://api.example.com/company/1/employee/3",
+			},
+			server: &employeeServer{err: errors.New("error"), delete: true},
+			want: want{
+				statusCode:  500,
+				contentType: "application/json",
+				body:        []byte(`{"error":{"code":"internal","message":"internal server error"}}` + "\n"),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+}
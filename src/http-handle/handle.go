@@ -3,28 +3,98 @@ package handle
 import (
 	"net/http"
 
+	"api.example.com/pkg/apikey"
+	"api.example.com/pkg/assignment"
+	"api.example.com/pkg/auth"
 	"api.example.com/pkg/company"
+	"api.example.com/pkg/employee"
+	"api.example.com/pkg/organization"
+	"api.example.com/pkg/permission"
+	"api.example.com/pkg/role"
+	"api.example.com/pkg/session"
 	"api.example.com/pkg/user"
 	"github.com/gorilla/mux"
 )
 
 type Services struct {
-	User    user.Server
-	Company company.Server
+	User         user.Server
+	Company      company.Server
+	Employee     employee.Server
+	Organization organization.Server
+	Role         role.Server
+	Assignment   assignment.Server
+	Permission   permission.Server
+	Auth         auth.Server
+	Session      session.Server
+	APIKey       apikey.Server
 }
 
 func New(s *Services) http.Handler {
 	mux := mux.NewRouter()
+	sessions := newSessionHandler(s.Session)
+	mux.Use(sessions.identify)
+	keys := newAPIKeyHandler(s.APIKey)
+	mux.Use(keys.identify)
+
+	guard := newAuthHandler(s.Auth)
+
+	// 会社の情報は管理者のみが操作でき、管理者でない従業員は自身の従業員情報のみ参照できる
+	admin, self := guard.administrator, guard.self
 
 	func(user *userHandler) {
-		mux.HandleFunc("/user", user.handleUsers)
+		// 一覧はログインしたユーザーのみが参照でき、登録はログインせずに行える
+		mux.HandleFunc("/user", user.handleUsers(guard.authenticated(user.list), user.create))
 		mux.HandleFunc("/user/{user_id}", user.handleUser)
 	}(newUserHandler(s.User))
 
+	func(session *sessionHandler) {
+		mux.HandleFunc("/session", session.handleSessions)
+	}(sessions)
+
 	func(company *companyHandler) {
-		mux.HandleFunc("/company", company.handleCompanies)
-		mux.HandleFunc("/company/{company_id}", company.handleCompany)
+		// 一覧は呼び出し元が従業員である会社に限り、登録は呼び出し元を所有者とする
+		mux.HandleFunc("/company", company.handleCompanies(guard.authenticated(company.list), guard.authenticated(company.create)))
+		mux.HandleFunc("/company/{company_id}", admin(permission.ManageCompany, company.handleCompany))
 	}(newCompanyHandler(s.Company))
 
+	func(employee *employeeHandler) {
+		mux.HandleFunc("/company/{company_id}/employee", admin(permission.ManageEmployees, employee.handleEmployees))
+		mux.HandleFunc("/company/{company_id}/employee/{employee_id}", self(permission.ManageEmployees, employee.handleEmployee))
+	}(newEmployeeHandler(s.Employee))
+
+	func(department *departmentHandler) {
+		mux.HandleFunc("/company/{company_id}/department", admin(permission.ManageDepartments, department.handleDepartments))
+		mux.HandleFunc("/company/{company_id}/department/{department_id}", admin(permission.ManageDepartments, department.handleDepartment))
+		mux.HandleFunc("/company/{company_id}/department/{department_id}/parent", admin(permission.ManageDepartments, department.handleParent))
+		mux.HandleFunc("/company/{company_id}/department/{department_id}/children", admin(permission.ManageDepartments, department.handleChildren))
+		mux.HandleFunc("/company/{company_id}/department/{department_id}/ancestors", admin(permission.ManageDepartments, department.handleAncestors))
+		mux.HandleFunc("/company/{company_id}/department/{department_id}/subtree", admin(permission.ManageDepartments, department.handleSubtree))
+	}(newDepartmentHandler(s.Organization))
+
+	func(role *roleHandler) {
+		mux.HandleFunc("/company/{company_id}/role", admin(permission.ManageRoles, role.handleRoles))
+		mux.HandleFunc("/company/{company_id}/role/{role_id}", admin(permission.ManageRoles, role.handleRole))
+	}(newRoleHandler(s.Role))
+
+	func(assignment *assignmentHandler) {
+		mux.HandleFunc("/company/{company_id}/assignment", admin(permission.ManageEmployees, assignment.handleAssignments))
+		mux.HandleFunc("/company/{company_id}/assignment/{assignment_id}", admin(permission.ManageEmployees, assignment.handleAssignment))
+		mux.HandleFunc("/company/{company_id}/employee/{employee_id}/assignment", self(permission.ManageEmployees, assignment.handleEmployee))
+		mux.HandleFunc("/company/{company_id}/department/{department_id}/assignment", admin(permission.ManageEmployees, assignment.handleDepartment))
+		mux.HandleFunc("/company/{company_id}/department/{department_id}/subtree/assignment", admin(permission.ManageEmployees, assignment.handleSubtree))
+	}(newAssignmentHandler(s.Assignment))
+
+	func(grant *permissionHandler) {
+		mux.HandleFunc("/company/{company_id}/employee/{employee_id}/permission", admin(permission.ManageCompany, grant.handlePermissions))
+		mux.HandleFunc("/company/{company_id}/employee/{employee_id}/permission/{permission}", admin(permission.ManageCompany, grant.handlePermission))
+	}(newPermissionHandler(s.Permission))
+
+	// API キーの管理はユーザーのみが行え、API キー自身では操作できない
+	func(key *apiKeyHandler) {
+		mux.HandleFunc("/company/{company_id}/apikey", guard.authenticated(admin(permission.ManageCompany, key.handleAPIKeys)))
+		mux.HandleFunc("/company/{company_id}/apikey/{apikey_id}", guard.authenticated(admin(permission.ManageCompany, key.handleAPIKey)))
+		mux.HandleFunc("/company/{company_id}/apikey/{apikey_id}/rotate", guard.authenticated(admin(permission.ManageCompany, key.handleRotate)))
+	}(keys)
+
 	return mux
 }
@@ -3,7 +3,15 @@ package handle
 // helper method
 func newServices() *Services {
 	return &Services{
-		User:    &userServer{},
-		Company: &companyServer{},
+		User:         &userServer{},
+		Company:      &companyServer{},
+		Employee:     &employeeServer{},
+		Organization: &departmentServer{},
+		Role:         &roleServer{},
+		Assignment:   &assignmentServer{},
+		Permission:   &permissionServer{},
+		Auth:         &authServer{},
+		Session:      &sessionServer{},
+		APIKey:       &apiKeyServer{},
 	}
 }
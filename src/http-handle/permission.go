@@ -0,0 +1,101 @@
+package handle
+
+import (
+	"log"
+	"net/http"
+
+	"api.example.com/http-handle/request"
+	"api.example.com/http-handle/response"
+	"api.example.com/pkg/permission"
+)
+
+type permissionHandler struct {
+	server permission.Server
+}
+
+func newPermissionHandler(s permission.Server) *permissionHandler {
+	return &permissionHandler{s}
+}
+
+func (h *permissionHandler) handlePermissions(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		h.list(w, r)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func (h *permissionHandler) handlePermission(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodPut:
+		h.grant(w, r)
+	case http.MethodDelete:
+		h.revoke(w, r)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func (h *permissionHandler) list(w http.ResponseWriter, r *http.Request) {
+	companyID, employeeID, err := request.PermissionList(r)
+	if err != nil {
+		log.Println(err)
+		response.Error(w, err)
+		return
+	}
+
+	list, err := h.server.List(r.Context(), companyID, employeeID)
+	if err != nil {
+		log.Println(err)
+		response.Error(w, err)
+		return
+	}
+
+	err = response.PermissionList(w, list)
+	if err != nil {
+		log.Println(err)
+	}
+}
+
+func (h *permissionHandler) grant(w http.ResponseWriter, r *http.Request) {
+	companyID, employeeID, p, err := request.PermissionGrant(r)
+	if err != nil {
+		log.Println(err)
+		response.Error(w, err)
+		return
+	}
+
+	list, err := h.server.Grant(r.Context(), companyID, employeeID, p)
+	if err != nil {
+		log.Println(err)
+		response.Error(w, err)
+		return
+	}
+
+	err = response.PermissionGrant(w, list)
+	if err != nil {
+		log.Println(err)
+	}
+}
+
+func (h *permissionHandler) revoke(w http.ResponseWriter, r *http.Request) {
+	companyID, employeeID, p, err := request.PermissionRevoke(r)
+	if err != nil {
+		log.Println(err)
+		response.Error(w, err)
+		return
+	}
+
+	list, err := h.server.Revoke(r.Context(), companyID, employeeID, p)
+	if err != nil {
+		log.Println(err)
+		response.Error(w, err)
+		return
+	}
+
+	err = response.PermissionRevoke(w, list)
+	if err != nil {
+		log.Println(err)
+	}
+}
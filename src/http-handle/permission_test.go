@@ -0,0 +1,194 @@
+package handle
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"api.example.com/pkg/permission"
+)
+
+// mock
+type permissionServer struct {
+	permissions []permission.Permission
+	err         error
+	// flag
+	check  bool
+	list   bool
+	grant  bool
+	revoke bool
+}
+
+func (s *permissionServer) Check(context.Context, permission.CompanyID, permission.EmployeeID, permission.Permission) error {
+	if s.check {
+		return s.err
+	}
+
+	panic("invalid Check")
+}
+
+func (s *permissionServer) List(context.Context, permission.CompanyID, permission.EmployeeID) ([]permission.Permission, error) {
+	if s.list {
+		return s.permissions, s.err
+	}
+
+	panic("invalid List")
+}
+
+func (s *permissionServer) Grant(context.Context, permission.CompanyID, permission.EmployeeID, permission.Permission) ([]permission.Permission, error) {
+	if s.grant {
+		return s.permissions, s.err
+	}
+
+	panic("invalid Grant")
+}
+
+func (s *permissionServer) Revoke(context.Context, permission.CompanyID, permission.EmployeeID, permission.Permission) ([]permission.Permission, error) {
+	if s.revoke {
+		return s.permissions, s.err
+	}
+
+	panic("invalid Revoke")
+}
+
+func TestPermissionHandler(t *testing.T) {
+	type args struct {
+		method string
+		url    string
+	}
+
+	type want struct {
+		statusCode  int
+		contentType string
+		body        []byte
+	}
+
+	type test struct {
+		name   string
+		args   args
+		server *permissionServer
+		want   want
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(tt.args.method, tt.args.url, nil)
+			w := httptest.NewRecorder()
+
+			s := newServices()
+			s.Permission = tt.server
+
+			New(s).ServeHTTP(w, r)
+
+			got := w.Result()
+			defer got.Body.Close()
+
+			gotBody, _ := io.ReadAll(got.Body)
+			if !reflect.DeepEqual(tt.want.body, gotBody) {
+				t.Fatalf("body want=%s, got=%s.", tt.want.body, gotBody)
+			}
+
+			gotContentType := got.Header.Get("Content-Type")
+			if tt.want.contentType != gotContentType {
+				t.Fatalf("Content-Type want=%v, got=%v.", tt.want.contentType, gotContentType)
+			}
+
+			gotStatusCode := got.StatusCode
+			if tt.want.statusCode != gotStatusCode {
+				t.Fatalf("Status-Code want=%v, got=%v.", tt.want.statusCode, gotStatusCode)
+			}
+		})
+	}
+
+	list := []permission.Permission{permission.ManageEmployees, permission.ManageRoles}
+	body := `{"permissions":["manage_employees","manage_roles"]}` + "\n"
+
+	tests := []*test{
+		{
+			name: "list ok",
+			args: args{
+				method: http.MethodGet,
+				url:    "http://api.example.com/company/1/employee/2/permission",
+			},
+			server: &permissionServer{permissions: list, list: true},
+			want: want{
+				statusCode:  200,
+				contentType: "application/json",
+				body:        []byte(body),
+			},
+		},
+		{
+			name: "list failed",
+			args: args{
+				method: http.MethodGet,
+				url:    "http://api.example.com/company/1/employee/2/permission",
+			},
+			server: &permissionServer{err: errors.New("error"), list: true},
+			want: want{
+				statusCode:  500,
+				contentType: "application/json",
+				body:        []byte(`{"error":{"code":"internal","message":"internal server error"}}` + "\n"),
+			},
+		},
+		{
+			name: "grant ok",
+			args: args{
+				method: http.MethodPut,
+				url:    "http://api.example.com/company/1/employee/2/permission/manage_roles",
+			},
+			server: &permissionServer{permissions: list, grant: true},
+			want: want{
+				statusCode:  200,
+				contentType: "application/json",
+				body:        []byte(body),
+			},
+		},
+		{
+			name: "grant failed",
+			args: args{
+				method: http.MethodPut,
+				url:    "http://api.example.com/company/1/employee/2/permission/manage_roles",
+			},
+			server: &permissionServer{err: errors.New("error"), grant: true},
+			want: want{
+				statusCode:  500,
+				contentType: "application/json",
+				body:        []byte(`{"error":{"code":"internal","message":"internal server error"}}` + "\n"),
+			},
+		},
+		{
+			name: "revoke ok",
+			args: args{
+				method: http.MethodDelete,
+				url:    "http://api.example.com/company/1/employee/2/permission/manage_company",
+			},
+			server: &permissionServer{permissions: list, revoke: true},
+			want: want{
+				statusCode:  200,
+				contentType: "application/json",
+				body:        []byte(body),
+			},
+		},
+		{
+			name: "revoke failed",
+			args: args{
+				method: http.MethodDelete,
+				url:    "http://api.example.com/company/1/employee/2/permission/manage_company",
+			},
+			server: &permissionServer{err: errors.New("error"), revoke: true},
+			want: want{
+				statusCode:  500,
+				contentType: "application/json",
+				body:        []byte(`{"error":{"code":"internal","message":"internal server error"}}` + "\n"),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+}
@@ -0,0 +1,92 @@
+package request
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"api.example.com/pkg/apikey"
+	"github.com/gorilla/mux"
+)
+
+func parseAPIKeyBody(r *http.Request) (apikey.Name, []apikey.Scope, error) {
+	defer r.Body.Close()
+
+	body := struct {
+		APIKey struct {
+			Name   apikey.Name    `json:"name"`
+			Scopes []apikey.Scope `json:"scopes"`
+		} `json:"apikey"`
+	}{}
+	err := json.NewDecoder(r.Body).Decode(&body)
+	if err != nil {
+		return "", nil, invalid(err)
+	}
+
+	return body.APIKey.Name, body.APIKey.Scopes, nil
+}
+
+func parseAPIKeyPath(r *http.Request) (apikey.CompanyID, apikey.ID, error) {
+	companyID, err := parseCompanyPath(r)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	id, err := strconv.Atoi(mux.Vars(r)["apikey_id"])
+	if err != nil {
+		return 0, 0, invalid(err)
+	}
+
+	return companyID, apikey.ID(id), nil
+}
+
+func APIKeyCreate(req *http.Request) (*apikey.Key, error) {
+	companyID, err := parseCompanyPath(req)
+	if err != nil {
+		return nil, fmt.Errorf("http-handle/request.APIKeyCreate: %w", err)
+	}
+
+	name, scopes, err := parseAPIKeyBody(req)
+	if err != nil {
+		return nil, fmt.Errorf("http-handle/request.APIKeyCreate: %w", err)
+	}
+
+	return apikey.New(companyID, name, scopes), nil
+}
+
+func APIKeyList(req *http.Request) (apikey.CompanyID, error) {
+	companyID, err := parseCompanyPath(req)
+	if err != nil {
+		return 0, fmt.Errorf("http-handle/request.APIKeyList: %w", err)
+	}
+
+	return companyID, nil
+}
+
+func APIKeyRead(req *http.Request) (apikey.CompanyID, apikey.ID, error) {
+	companyID, id, err := parseAPIKeyPath(req)
+	if err != nil {
+		return 0, 0, fmt.Errorf("http-handle/request.APIKeyRead: %w", err)
+	}
+
+	return companyID, id, nil
+}
+
+func APIKeyRotate(req *http.Request) (apikey.CompanyID, apikey.ID, error) {
+	companyID, id, err := parseAPIKeyPath(req)
+	if err != nil {
+		return 0, 0, fmt.Errorf("http-handle/request.APIKeyRotate: %w", err)
+	}
+
+	return companyID, id, nil
+}
+
+func APIKeyDelete(req *http.Request) (apikey.CompanyID, apikey.ID, error) {
+	companyID, id, err := parseAPIKeyPath(req)
+	if err != nil {
+		return 0, 0, fmt.Errorf("http-handle/request.APIKeyDelete: %w", err)
+	}
+
+	return companyID, id, nil
+}
@@ -0,0 +1,229 @@
+package request
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"api.example.com/pkg/apikey"
+	"github.com/gorilla/mux"
+)
+
+func TestAPIKeyCreate(t *testing.T) {
+	type test struct {
+		name    string
+		url     string
+		body    []byte
+		want    *apikey.Key
+		wantErr bool
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodPost, tt.url, bytes.NewBuffer(tt.body))
+
+			var (
+				got *apikey.Key
+				err error
+			)
+
+			router := mux.NewRouter()
+			router.HandleFunc("/company/{company_id}/apikey", func(w http.ResponseWriter, r *http.Request) {
+				got, err = APIKeyCreate(r)
+			})
+			router.ServeHTTP(w, r)
+
+			if tt.wantErr != (err != nil) {
+				t.Fatalf("want-error=%v, error=%v.", tt.wantErr, err)
+			}
+
+			if !reflect.DeepEqual(tt.want, got) {
+				t.Fatalf("want=%v, got=%v.", tt.want, got)
+			}
+		})
+	}
+
+	tests := []*test{
+		{
+			name: "ok",
+			url:  "http://api.example.com/company/1/apikey",
+			body: []byte(`{
+  "apikey": {
+    "name": "batch",
+    "scopes": ["read", "manage_users"]
+  }
+}`),
+			want:    apikey.New(1, "batch", []apikey.Scope{apikey.ScopeRead, apikey.ScopeManageUsers}),
+			wantErr: false,
+		},
+		{
+			name:    "invalid company_id",
+			url:     "http://api.example.com/company/hoge/apikey",
+			body:    []byte(`{"apikey":{"name":"batch","scopes":["read"]}}`),
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "invalid request",
+			url:     "http://api.example.com/company/1/apikey",
+			body:    []byte{},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+}
+
+func TestAPIKeyList(t *testing.T) {
+	type test struct {
+		name    string
+		url     string
+		want    apikey.CompanyID
+		wantErr bool
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodGet, tt.url, nil)
+
+			var (
+				got apikey.CompanyID
+				err error
+			)
+
+			router := mux.NewRouter()
+			router.HandleFunc("/company/{company_id}/apikey", func(w http.ResponseWriter, r *http.Request) {
+				got, err = APIKeyList(r)
+			})
+			router.ServeHTTP(w, r)
+
+			if tt.wantErr != (err != nil) {
+				t.Fatalf("want-err=%v, err=%v.", tt.wantErr, err)
+			}
+
+			if !reflect.DeepEqual(tt.want, got) {
+				t.Fatalf("want=%v, got%v.", tt.want, got)
+			}
+		})
+	}
+
+	tests := []*test{
+		{
+			name:    "OK",
+			url:     "http://api.example.com/company/1/apikey",
+			want:    1,
+			wantErr: false,
+		},
+		{
+			name:    "failed request",
+			url:     "http://api.example.com/company/hoge/apikey",
+			want:    0,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+}
+
+func TestAPIKeyPath(t *testing.T) {
+	type want struct {
+		companyID apikey.CompanyID
+		id        apikey.ID
+	}
+
+	type test struct {
+		name    string
+		parse   func(*http.Request) (apikey.CompanyID, apikey.ID, error)
+		path    string
+		url     string
+		want    want
+		wantErr bool
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodGet, tt.url, nil)
+
+			var (
+				got want
+				err error
+			)
+
+			router := mux.NewRouter()
+			router.HandleFunc(tt.path, func(w http.ResponseWriter, r *http.Request) {
+				got.companyID, got.id, err = tt.parse(r)
+			})
+			router.ServeHTTP(w, r)
+
+			if tt.wantErr != (err != nil) {
+				t.Fatalf("want-err=%v, err=%v.", tt.wantErr, err)
+			}
+
+			if !reflect.DeepEqual(tt.want, got) {
+				t.Fatalf("want=%v, got%v.", tt.want, got)
+			}
+		})
+	}
+
+	const (
+		keyPath    = "/company/{company_id}/apikey/{apikey_id}"
+		rotatePath = "/company/{company_id}/apikey/{apikey_id}/rotate"
+	)
+
+	tests := []*test{
+		{
+			name:    "read",
+			parse:   APIKeyRead,
+			path:    keyPath,
+			url:     "http://api.example.com/company/1/apikey/3",
+			want:    want{companyID: 1, id: 3},
+			wantErr: false,
+		},
+		{
+			name:    "rotate",
+			parse:   APIKeyRotate,
+			path:    rotatePath,
+			url:     "http://api.example.com/company/1/apikey/3/rotate",
+			want:    want{companyID: 1, id: 3},
+			wantErr: false,
+		},
+		{
+			name:    "delete",
+			parse:   APIKeyDelete,
+			path:    keyPath,
+			url:     "http://api.example.com/company/1/apikey/3",
+			want:    want{companyID: 1, id: 3},
+			wantErr: false,
+		},
+		{
+			name:    "invalid company_id",
+			parse:   APIKeyRead,
+			path:    keyPath,
+			url:     "http://api.example.com/company/hoge/apikey/3",
+			want:    want{},
+			wantErr: true,
+		},
+		{
+			name:    "invalid apikey_id",
+			parse:   APIKeyDelete,
+			path:    keyPath,
+			url:     "http://api.example.com/company/1/apikey/hoge",
+			want:    want{},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+}
@@ -0,0 +1,94 @@
+package request
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"api.example.com/pkg/assignment"
+	"github.com/gorilla/mux"
+)
+
+func parseAssignmentBody(r *http.Request) (*assignment.Assignment, error) {
+	defer r.Body.Close()
+
+	body := struct {
+		Assignment struct {
+			EmployeeID   assignment.EmployeeID   `json:"employee_id"`
+			DepartmentID assignment.DepartmentID `json:"department_id"`
+			RoleID       assignment.RoleID       `json:"role_id"`
+		} `json:"assignment"`
+	}{}
+	err := json.NewDecoder(r.Body).Decode(&body)
+	if err != nil {
+		return nil, invalid(err)
+	}
+
+	return assignment.New(0, body.Assignment.EmployeeID, body.Assignment.DepartmentID, body.Assignment.RoleID), nil
+}
+
+func parseAssignmentPath(r *http.Request) (assignment.CompanyID, assignment.ID, error) {
+	companyID, err := parseCompanyPath(r)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	id, err := strconv.Atoi(mux.Vars(r)["assignment_id"])
+	if err != nil {
+		return 0, 0, invalid(err)
+	}
+
+	return companyID, assignment.ID(id), nil
+}
+
+func AssignmentCreate(req *http.Request) (*assignment.Assignment, error) {
+	companyID, err := parseCompanyPath(req)
+	if err != nil {
+		return nil, fmt.Errorf("http-handle/request.AssignmentCreate: %w", err)
+	}
+
+	a, err := parseAssignmentBody(req)
+	if err != nil {
+		return nil, fmt.Errorf("http-handle/request.AssignmentCreate: %w", err)
+	}
+
+	a.CompanyID = companyID
+	return a, nil
+}
+
+func AssignmentRead(req *http.Request) (assignment.CompanyID, assignment.ID, error) {
+	companyID, id, err := parseAssignmentPath(req)
+	if err != nil {
+		return 0, 0, fmt.Errorf("http-handle/request.AssignmentRead: %w", err)
+	}
+
+	return companyID, id, nil
+}
+
+func AssignmentListByEmployee(req *http.Request) (assignment.CompanyID, assignment.EmployeeID, error) {
+	companyID, employeeID, err := parseEmployeePath(req)
+	if err != nil {
+		return 0, 0, fmt.Errorf("http-handle/request.AssignmentListByEmployee: %w", err)
+	}
+
+	return companyID, employeeID, nil
+}
+
+func AssignmentListByDepartment(req *http.Request) (assignment.CompanyID, assignment.DepartmentID, error) {
+	companyID, departmentID, err := parseDepartmentPath(req)
+	if err != nil {
+		return 0, 0, fmt.Errorf("http-handle/request.AssignmentListByDepartment: %w", err)
+	}
+
+	return companyID, departmentID, nil
+}
+
+func AssignmentDelete(req *http.Request) (assignment.CompanyID, assignment.ID, error) {
+	companyID, id, err := parseAssignmentPath(req)
+	if err != nil {
+		return 0, 0, fmt.Errorf("http-handle/request.AssignmentDelete: %w", err)
+	}
+
+	return companyID, id, nil
+}
@@ -0,0 +1,324 @@
+package request
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"api.example.com/pkg/assignment"
+	"github.com/gorilla/mux"
+)
+
+func TestAssignmentCreate(t *testing.T) {
+	type test struct {
+		name    string
+		url     string
+		body    []byte
+		want    *assignment.Assignment
+		wantErr bool
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodPost, tt.url, bytes.NewBuffer(tt.body))
+
+			var (
+				got *assignment.Assignment
+				err error
+			)
+
+			router := mux.NewRouter()
+			router.HandleFunc("/company/{company_id}/assignment", func(w http.ResponseWriter, r *http.Request) {
+				got, err = AssignmentCreate(r)
+			})
+			router.ServeHTTP(w, r)
+
+			if tt.wantErr != (err != nil) {
+				t.Fatalf("want-error=%v, error=%v.", tt.wantErr, err)
+			}
+
+			if !reflect.DeepEqual(tt.want, got) {
+				t.Fatalf("want=%v, got=%v.", tt.want, got)
+			}
+		})
+	}
+
+	tests := []*test{
+		{
+			name: "ok",
+			url:  "http://api.example.com/company/1/assignment",
+			body: []byte(`{
+  "assignment": {
+    "employee_id": 2,
+    "department_id": 3,
+    "role_id": 4
+  }
+}`),
+			want:    assignment.New(1, 2, 3, 4),
+			wantErr: false,
+		},
+		{
+			name:    "invalid company_id",
+			url:     "http://api.example.com/company/hoge/assignment",
+			body:    []byte(`{"assignment":{"employee_id":2,"department_id":3,"role_id":4}}`),
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "invalid request",
+			url:     "http://api.example.com/company/1/assignment",
+			body:    []byte{},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+}
+
+func TestAssignmentRead(t *testing.T) {
+	type want struct {
+		companyID assignment.CompanyID
+		id        assignment.ID
+	}
+
+	type test struct {
+		name    string
+		url     string
+		want    want
+		wantErr bool
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodGet, tt.url, nil)
+
+			var (
+				got want
+				err error
+			)
+
+			router := mux.NewRouter()
+			router.HandleFunc("/company/{company_id}/assignment/{assignment_id}", func(w http.ResponseWriter, r *http.Request) {
+				got.companyID, got.id, err = AssignmentRead(r)
+			})
+			router.ServeHTTP(w, r)
+
+			if tt.wantErr != (err != nil) {
+				t.Fatalf("want-err=%v, err=%v.", tt.wantErr, err)
+			}
+
+			if !reflect.DeepEqual(tt.want, got) {
+				t.Fatalf("want=%v, got%v.", tt.want, got)
+			}
+		})
+	}
+
+	tests := []*test{
+		{
+			name:    "OK",
+			url:     "http://api.example.com/company/1/assignment/5",
+			want:    want{companyID: 1, id: 5},
+			wantErr: false,
+		},
+		{
+			name:    "invalid company_id",
+			url:     "http://api.example.com/company/hoge/assignment/5",
+			want:    want{},
+			wantErr: true,
+		},
+		{
+			name:    "invalid assignment_id",
+			url:     "http://api.example.com/company/1/assignment/hoge",
+			want:    want{},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+}
+
+func TestAssignmentListByEmployee(t *testing.T) {
+	type want struct {
+		companyID  assignment.CompanyID
+		employeeID assignment.EmployeeID
+	}
+
+	type test struct {
+		name    string
+		url     string
+		want    want
+		wantErr bool
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodGet, tt.url, nil)
+
+			var (
+				got want
+				err error
+			)
+
+			router := mux.NewRouter()
+			router.HandleFunc("/company/{company_id}/employee/{employee_id}/assignment", func(w http.ResponseWriter, r *http.Request) {
+				got.companyID, got.employeeID, err = AssignmentListByEmployee(r)
+			})
+			router.ServeHTTP(w, r)
+
+			if tt.wantErr != (err != nil) {
+				t.Fatalf("want-err=%v, err=%v.", tt.wantErr, err)
+			}
+
+			if !reflect.DeepEqual(tt.want, got) {
+				t.Fatalf("want=%v, got%v.", tt.want, got)
+			}
+		})
+	}
+
+	tests := []*test{
+		{
+			name:    "OK",
+			url:     "http://api.example.com/company/1/employee/2/assignment",
+			want:    want{companyID: 1, employeeID: 2},
+			wantErr: false,
+		},
+		{
+			name:    "failed request",
+			url:     "http://api.example.com/company/1/employee/hoge/assignment",
+			want:    want{},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+}
+
+func TestAssignmentListByDepartment(t *testing.T) {
+	type want struct {
+		companyID    assignment.CompanyID
+		departmentID assignment.DepartmentID
+	}
+
+	type test struct {
+		name    string
+		url     string
+		want    want
+		wantErr bool
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodGet, tt.url, nil)
+
+			var (
+				got want
+				err error
+			)
+
+			router := mux.NewRouter()
+			router.HandleFunc("/company/{company_id}/department/{department_id}/assignment", func(w http.ResponseWriter, r *http.Request) {
+				got.companyID, got.departmentID, err = AssignmentListByDepartment(r)
+			})
+			router.ServeHTTP(w, r)
+
+			if tt.wantErr != (err != nil) {
+				t.Fatalf("want-err=%v, err=%v.", tt.wantErr, err)
+			}
+
+			if !reflect.DeepEqual(tt.want, got) {
+				t.Fatalf("want=%v, got%v.", tt.want, got)
+			}
+		})
+	}
+
+	tests := []*test{
+		{
+			name:    "OK",
+			url:     "http://api.example.com/company/1/department/3/assignment",
+			want:    want{companyID: 1, departmentID: 3},
+			wantErr: false,
+		},
+		{
+			name:    "failed request",
+			url:     "http://api.example.com/company/1/department/hoge/assignment",
+			want:    want{},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+}
+
+func TestAssignmentDelete(t *testing.T) {
+	type want struct {
+		companyID assignment.CompanyID
+		id        assignment.ID
+	}
+
+	type test struct {
+		name    string
+		url     string
+		want    want
+		wantErr bool
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodDelete, tt.url, nil)
+
+			var (
+				got want
+				err error
+			)
+
+			router := mux.NewRouter()
+			router.HandleFunc("/company/{company_id}/assignment/{assignment_id}", func(w http.ResponseWriter, r *http.Request) {
+				got.companyID, got.id, err = AssignmentDelete(r)
+			})
+			router.ServeHTTP(w, r)
+
+			if tt.wantErr != (err != nil) {
+				t.Fatalf("want-err=%v, err=%v.", tt.wantErr, err)
+			}
+
+			if !reflect.DeepEqual(tt.want, got) {
+				t.Fatalf("want=%v, got%v.", tt.want, got)
+			}
+		})
+	}
+
+	tests := []*test{
+		{
+			name:    "OK",
+			url:     "http://api.example.com/company/1/assignment/5",
+			want:    want{companyID: 1, id: 5},
+			wantErr: false,
+		},
+		{
+			name:    "failed request",
+			url:     "http://api.example.com/company/1/assignment/hoge",
+			want:    want{},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+}
@@ -0,0 +1,86 @@
+package request
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"api.example.com/pkg/apikey"
+	"api.example.com/pkg/auth"
+	"api.example.com/pkg/session"
+)
+
+// Authorization: Bearer {token}
+const bearerPrefix = "Bearer "
+
+// Authorization: ApiKey {key}
+const apiKeyPrefix = "ApiKey "
+
+// ヘッダーがないか、API キーであれば ok=false
+// それ以外で Bearer でなければ auth.ErrUnauthenticated
+func Token(req *http.Request) (token session.Token, ok bool, err error) {
+	value := req.Header.Get("Authorization")
+	if value == "" || strings.HasPrefix(value, apiKeyPrefix) {
+		return "", false, nil
+	}
+
+	if !strings.HasPrefix(value, bearerPrefix) || len(value) == len(bearerPrefix) {
+		return "", false, fmt.Errorf("http-handle/request.Token: %w: invalid authorization scheme", auth.ErrUnauthenticated)
+	}
+
+	return session.Token(strings.TrimPrefix(value, bearerPrefix)), true, nil
+}
+
+// ApiKey 以外のヘッダーは ok=false
+func APIKey(req *http.Request) (key apikey.Token, ok bool, err error) {
+	value := req.Header.Get("Authorization")
+	if !strings.HasPrefix(value, apiKeyPrefix) {
+		return "", false, nil
+	}
+
+	if len(value) == len(apiKeyPrefix) {
+		return "", false, fmt.Errorf("http-handle/request.APIKey: %w: empty api key", auth.ErrUnauthenticated)
+	}
+
+	return apikey.Token(strings.TrimPrefix(value, apiKeyPrefix)), true, nil
+}
+
+// 認可の対象となる会社
+func AuthCompany(req *http.Request) (auth.CompanyID, error) {
+	companyID, err := parseCompanyPath(req)
+	if err != nil {
+		return 0, fmt.Errorf("http-handle/request.AuthCompany: %w", err)
+	}
+
+	return companyID, nil
+}
+
+// 認可の対象となる従業員
+func AuthEmployee(req *http.Request) (auth.CompanyID, auth.EmployeeID, error) {
+	companyID, employeeID, err := parseEmployeePath(req)
+	if err != nil {
+		return 0, 0, fmt.Errorf("http-handle/request.AuthEmployee: %w", err)
+	}
+
+	return companyID, employeeID, nil
+}
+
+// ブラウザ向けのセッションID
+const (
+	sessionCookie = "session_id"
+	sessionHeader = "X-Session-ID"
+)
+
+// Cookie を優先し、なければ X-Session-ID ヘッダー
+// どちらもなければ ok=false
+func SessionID(req *http.Request) (id session.ID, ok bool) {
+	if c, err := req.Cookie(sessionCookie); err == nil && c.Value != "" {
+		return session.ID(c.Value), true
+	}
+
+	if value := req.Header.Get(sessionHeader); value != "" {
+		return session.ID(value), true
+	}
+
+	return "", false
+}
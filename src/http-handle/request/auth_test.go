@@ -0,0 +1,272 @@
+package request
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"api.example.com/pkg/apikey"
+	"api.example.com/pkg/auth"
+	"api.example.com/pkg/session"
+	"github.com/gorilla/mux"
+)
+
+func TestToken(t *testing.T) {
+	type want struct {
+		token session.Token
+		ok    bool
+	}
+
+	type test struct {
+		name    string
+		header  string
+		want    want
+		wantErr error
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "http://api.example.com/company/1", nil)
+			if tt.header != "" {
+				r.Header.Set("Authorization", tt.header)
+			}
+
+			var (
+				got want
+				err error
+			)
+			got.token, got.ok, err = Token(r)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("want-err=%v, err=%v.", tt.wantErr, err)
+			}
+
+			if !reflect.DeepEqual(tt.want, got) {
+				t.Fatalf("want=%v, got%v.", tt.want, got)
+			}
+		})
+	}
+
+	tests := []*test{
+		{
+			name:    "OK",
+			header:  "Bearer header.payload.signature",
+			want:    want{token: "header.payload.signature", ok: true},
+			wantErr: nil,
+		},
+		{
+			name:    "no header",
+			header:  "",
+			want:    want{},
+			wantErr: nil,
+		},
+		{
+			name:    "other scheme",
+			header:  "Basic Ym9iOnBhc3N3b3Jk",
+			want:    want{},
+			wantErr: auth.ErrUnauthenticated,
+		},
+		{
+			name:    "empty token",
+			header:  "Bearer ",
+			want:    want{},
+			wantErr: auth.ErrUnauthenticated,
+		},
+		{
+			name:    "api key",
+			header:  "ApiKey 1.secret",
+			want:    want{},
+			wantErr: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+}
+
+func TestAPIKey(t *testing.T) {
+	type want struct {
+		key apikey.Token
+		ok  bool
+	}
+
+	type test struct {
+		name    string
+		header  string
+		want    want
+		wantErr error
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "http://api.example.com/company/1", nil)
+			if tt.header != "" {
+				r.Header.Set("Authorization", tt.header)
+			}
+
+			var (
+				got want
+				err error
+			)
+			got.key, got.ok, err = APIKey(r)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("want-err=%v, err=%v.", tt.wantErr, err)
+			}
+
+			if !reflect.DeepEqual(tt.want, got) {
+				t.Fatalf("want=%v, got%v.", tt.want, got)
+			}
+		})
+	}
+
+	tests := []*test{
+		{
+			name:    "OK",
+			header:  "ApiKey 1.secret",
+			want:    want{key: "1.secret", ok: true},
+			wantErr: nil,
+		},
+		{
+			name:    "no header",
+			header:  "",
+			want:    want{},
+			wantErr: nil,
+		},
+		{
+			name:    "bearer",
+			header:  "Bearer header.payload.signature",
+			want:    want{},
+			wantErr: nil,
+		},
+		{
+			name:    "empty key",
+			header:  "ApiKey ",
+			want:    want{},
+			wantErr: auth.ErrUnauthenticated,
+		},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+}
+
+func TestAuthEmployee(t *testing.T) {
+	type want struct {
+		companyID auth.CompanyID
+		id        auth.EmployeeID
+	}
+
+	type test struct {
+		name    string
+		url     string
+		want    want
+		wantErr bool
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodGet, tt.url, nil)
+
+			var (
+				got want
+				err error
+			)
+
+			router := mux.NewRouter()
+			router.HandleFunc("/company/{company_id}/employee/{employee_id}", func(w http.ResponseWriter, r *http.Request) {
+				got.companyID, got.id, err = AuthEmployee(r)
+			})
+			router.ServeHTTP(w, r)
+
+			if tt.wantErr != (err != nil) {
+				t.Fatalf("want-err=%v, err=%v.", tt.wantErr, err)
+			}
+
+			if !reflect.DeepEqual(tt.want, got) {
+				t.Fatalf("want=%v, got%v.", tt.want, got)
+			}
+		})
+	}
+
+	tests := []*test{
+		{
+			name:    "OK",
+			url:     "http://api.example.com/company/1/employee/3",
+			want:    want{companyID: 1, id: 3},
+			wantErr: false,
+		},
+		{
+			name:    "invalid employee_id",
+			url:     "http://api.example.com/company/1/employee/hoge",
+			want:    want{},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+}
+
+func TestSessionID(t *testing.T) {
+	type want struct {
+		id session.ID
+		ok bool
+	}
+
+	type test struct {
+		name   string
+		cookie string
+		header string
+		want   want
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "http://api.example.com/company/1", nil)
+			if tt.cookie != "" {
+				r.AddCookie(&http.Cookie{Name: "session_id", Value: tt.cookie})
+			}
+			if tt.header != "" {
+				r.Header.Set("X-Session-ID", tt.header)
+			}
+
+			var got want
+			got.id, got.ok = SessionID(r)
+			if !reflect.DeepEqual(tt.want, got) {
+				t.Fatalf("want=%v, got%v.", tt.want, got)
+			}
+		})
+	}
+
+	tests := []*test{
+		{
+			name:   "cookie",
+			cookie: "cookie-id",
+			want:   want{id: "cookie-id", ok: true},
+		},
+		{
+			name:   "header",
+			header: "header-id",
+			want:   want{id: "header-id", ok: true},
+		},
+		{
+			name:   "cookie first",
+			cookie: "cookie-id",
+			header: "header-id",
+			want:   want{id: "cookie-id", ok: true},
+		},
+		{
+			name: "none",
+			want: want{},
+		},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+}
@@ -10,7 +10,7 @@ import (
 	"github.com/gorilla/mux"
 )
 
-func NewCompanyCreate(r *http.Request) (*company.Company, error) {
+func parseCompanyBody(r *http.Request) (*company.Company, error) {
 	defer r.Body.Close()
 
 	body := struct {
@@ -21,18 +21,27 @@ func NewCompanyCreate(r *http.Request) (*company.Company, error) {
 	}{}
 	err := json.NewDecoder(r.Body).Decode(&body)
 	if err != nil {
-		return nil, err
+		return nil, invalid(err)
 	}
 
 	return company.New(body.Company.Name, body.Company.OwnerID), nil
 }
 
+func NewCompanyCreate(r *http.Request) (*company.Company, error) {
+	c, err := parseCompanyBody(r)
+	if err != nil {
+		return nil, fmt.Errorf("http-handle/request.NewCompanyCreate: %w", err)
+	}
+
+	return c, nil
+}
+
 func parseCompanyPath(r *http.Request) (company.ID, error) {
 	vars := mux.Vars(r)
 
 	id, err := strconv.Atoi(vars["company_id"])
 	if err != nil {
-		return 0, err
+		return 0, invalid(err)
 	}
 
 	return company.ID(id), nil
@@ -46,3 +55,40 @@ func CompanyRead(req *http.Request) (company.ID, error) {
 
 	return id, nil
 }
+
+// 名前の前方一致 (?name_prefix=...) と読み込み位置
+func CompanyList(req *http.Request) (company.ListQuery, error) {
+	q, err := parsePage(req)
+	if err != nil {
+		return company.ListQuery{}, fmt.Errorf("http-handle/request.CompanyList: %w", err)
+	}
+
+	return company.ListQuery{
+		NamePrefix: company.Name(req.URL.Query().Get("name_prefix")),
+		Query:      q,
+	}, nil
+}
+
+func CompanyUpdate(req *http.Request) (*company.Company, error) {
+	id, err := parseCompanyPath(req)
+	if err != nil {
+		return nil, fmt.Errorf("http-handle/request.CompanyUpdate: %w", err)
+	}
+
+	c, err := parseCompanyBody(req)
+	if err != nil {
+		return nil, fmt.Errorf("http-handle/request.CompanyUpdate: %w", err)
+	}
+
+	c.ID = id
+	return c, nil
+}
+
+func CompanyDelete(req *http.Request) (company.ID, error) {
+	id, err := parseCompanyPath(req)
+	if err != nil {
+		return 0, fmt.Errorf("http-handle/request.CompanyDelete: %w", err)
+	}
+
+	return id, nil
+}
@@ -8,6 +8,7 @@ import (
 	"testing"
 
 	"api.example.com/pkg/company"
+	"api.example.com/pkg/page"
 	"github.com/gorilla/mux"
 )
 
@@ -115,3 +116,171 @@ func TestCompanyRead(t *testing.T) {
 		do(tt)
 	}
 }
+
+func TestCompanyList(t *testing.T) {
+	type test struct {
+		name    string
+		url     string
+		want    company.ListQuery
+		wantErr bool
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, tt.url, nil)
+
+			got, err := CompanyList(r)
+			if tt.wantErr != (err != nil) {
+				t.Fatalf("want-error=%v, error=%v.", tt.wantErr, err)
+			}
+
+			if !reflect.DeepEqual(tt.want, got) {
+				t.Fatalf("want=%v, got=%v.", tt.want, got)
+			}
+		})
+	}
+
+	tests := []*test{
+		{
+			name: "ok",
+			url:  "http://api.example.com/company?name_prefix=GREATE&cursor=Mg&limit=10",
+			want: company.ListQuery{NamePrefix: "GREATE", Query: page.Query{Cursor: "Mg", Limit: 10}},
+		},
+		{
+			name: "default",
+			url:  "http://api.example.com/company",
+			want: company.ListQuery{},
+		},
+		{
+			name:    "invalid limit",
+			url:     "http://api.example.com/company?limit=ten",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+}
+
+func TestCompanyUpdate(t *testing.T) {
+	type test struct {
+		name    string
+		url     string
+		body    []byte
+		want    *company.Company
+		wantErr bool
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodPut, tt.url, bytes.NewBuffer(tt.body))
+
+			var (
+				got *company.Company
+				err error
+			)
+
+			router := mux.NewRouter()
+			router.HandleFunc("/company/{company_id}", func(w http.ResponseWriter, r *http.Request) {
+				got, err = CompanyUpdate(r)
+			})
+			router.ServeHTTP(w, r)
+
+			if tt.wantErr != (err != nil) {
+				t.Fatalf("want-err=%v, err=%v.", tt.wantErr, err)
+			}
+
+			if !reflect.DeepEqual(tt.want, got) {
+				t.Fatalf("want=%v, got=%v.", tt.want, got)
+			}
+		})
+	}
+
+	tests := []*test{
+		{
+			name: "ok",
+			url:  "http://api.example.com/company/1",
+			body: []byte(`{"company":{"name":"greate company","owner_id":2}}`),
+			want: &company.Company{
+				ID:      1,
+				Name:    "greate company",
+				OwnerID: 2,
+			},
+			wantErr: false,
+		},
+		{
+			name:    "empty body",
+			url:     "http://api.example.com/company/1",
+			body:    []byte{},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "invalid company_id",
+			url:     "http://api.example.com/company/hoge",
+			body:    []byte(`{"company":{"name":"greate company","owner_id":2}}`),
+			want:    nil,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+}
+
+func TestCompanyDelete(t *testing.T) {
+	type test struct {
+		name    string
+		url     string
+		want    company.ID
+		wantErr bool
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodDelete, tt.url, nil)
+
+			var (
+				got company.ID
+				err error
+			)
+
+			router := mux.NewRouter()
+			router.HandleFunc("/company/{company_id}", func(w http.ResponseWriter, r *http.Request) {
+				got, err = CompanyDelete(r)
+			})
+			router.ServeHTTP(w, r)
+
+			if tt.wantErr != (err != nil) {
+				t.Fatalf("want-err=%v, err=%v.", tt.wantErr, err)
+			}
+
+			if tt.want != got {
+				t.Fatalf("want=%v, got=%v.", tt.want, got)
+			}
+		})
+	}
+
+	tests := []*test{
+		{
+			name:    "ok",
+			url:     "http://api.example.com/company/2",
+			want:    2,
+			wantErr: false,
+		},
+		{
+			name:    "invalid company_id",
+			url:     "http://api.example.com/company/hoge",
+			want:    0,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+}
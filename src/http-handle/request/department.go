@@ -0,0 +1,134 @@
+package request
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"api.example.com/pkg/organization"
+	"github.com/gorilla/mux"
+)
+
+type departmentBody struct {
+	Department struct {
+		// null または省略時は最上位の部署
+		ParentID *organization.ID  `json:"parent_id"`
+		Name     organization.Name `json:"name"`
+	} `json:"department"`
+}
+
+func parseDepartmentBody(r *http.Request) (*departmentBody, error) {
+	defer r.Body.Close()
+
+	body := &departmentBody{}
+	err := json.NewDecoder(r.Body).Decode(body)
+	if err != nil {
+		return nil, invalid(err)
+	}
+
+	return body, nil
+}
+
+func (b *departmentBody) parentID() organization.ID {
+	if b.Department.ParentID == nil {
+		return organization.Root
+	}
+
+	return *b.Department.ParentID
+}
+
+func parseDepartmentPath(r *http.Request) (organization.CompanyID, organization.ID, error) {
+	companyID, err := parseCompanyPath(r)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	id, err := strconv.Atoi(mux.Vars(r)["department_id"])
+	if err != nil {
+		return 0, 0, invalid(err)
+	}
+
+	return companyID, organization.ID(id), nil
+}
+
+func DepartmentCreate(req *http.Request) (*organization.Department, error) {
+	companyID, err := parseCompanyPath(req)
+	if err != nil {
+		return nil, fmt.Errorf("http-handle/request.DepartmentCreate: %w", err)
+	}
+
+	body, err := parseDepartmentBody(req)
+	if err != nil {
+		return nil, fmt.Errorf("http-handle/request.DepartmentCreate: %w", err)
+	}
+
+	return organization.New(companyID, body.parentID(), body.Department.Name), nil
+}
+
+// 最上位の部署の一覧
+func DepartmentList(req *http.Request) (organization.CompanyID, error) {
+	companyID, err := parseCompanyPath(req)
+	if err != nil {
+		return 0, fmt.Errorf("http-handle/request.DepartmentList: %w", err)
+	}
+
+	return companyID, nil
+}
+
+func DepartmentRead(req *http.Request) (organization.CompanyID, organization.ID, error) {
+	companyID, id, err := parseDepartmentPath(req)
+	if err != nil {
+		return 0, 0, fmt.Errorf("http-handle/request.DepartmentRead: %w", err)
+	}
+
+	return companyID, id, nil
+}
+
+func DepartmentRename(req *http.Request) (organization.CompanyID, organization.ID, organization.Name, error) {
+	companyID, id, err := parseDepartmentPath(req)
+	if err != nil {
+		return 0, 0, "", fmt.Errorf("http-handle/request.DepartmentRename: %w", err)
+	}
+
+	body, err := parseDepartmentBody(req)
+	if err != nil {
+		return 0, 0, "", fmt.Errorf("http-handle/request.DepartmentRename: %w", err)
+	}
+
+	return companyID, id, body.Department.Name, nil
+}
+
+// 移動先の親部署
+func DepartmentMove(req *http.Request) (organization.CompanyID, organization.ID, organization.ID, error) {
+	companyID, id, err := parseDepartmentPath(req)
+	if err != nil {
+		return 0, 0, 0, fmt.Errorf("http-handle/request.DepartmentMove: %w", err)
+	}
+
+	body, err := parseDepartmentBody(req)
+	if err != nil {
+		return 0, 0, 0, fmt.Errorf("http-handle/request.DepartmentMove: %w", err)
+	}
+
+	return companyID, id, body.parentID(), nil
+}
+
+func DepartmentDelete(req *http.Request) (organization.CompanyID, organization.ID, error) {
+	companyID, id, err := parseDepartmentPath(req)
+	if err != nil {
+		return 0, 0, fmt.Errorf("http-handle/request.DepartmentDelete: %w", err)
+	}
+
+	return companyID, id, nil
+}
+
+// children, ancestors, subtree
+func DepartmentTree(req *http.Request) (organization.CompanyID, organization.ID, error) {
+	companyID, id, err := parseDepartmentPath(req)
+	if err != nil {
+		return 0, 0, fmt.Errorf("http-handle/request.DepartmentTree: %w", err)
+	}
+
+	return companyID, id, nil
+}
@@ -0,0 +1,275 @@
+package request
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"api.example.com/pkg/organization"
+	"github.com/gorilla/mux"
+)
+
+func TestDepartmentCreate(t *testing.T) {
+	type test struct {
+		name    string
+		url     string
+		body    []byte
+		want    *organization.Department
+		wantErr bool
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodPost, tt.url, bytes.NewBuffer(tt.body))
+
+			var (
+				got *organization.Department
+				err error
+			)
+
+			router := mux.NewRouter()
+			router.HandleFunc("/company/{company_id}/department", func(w http.ResponseWriter, r *http.Request) {
+				got, err = DepartmentCreate(r)
+			})
+			router.ServeHTTP(w, r)
+
+			if tt.wantErr != (err != nil) {
+				t.Fatalf("want-error=%v, error=%v.", tt.wantErr, err)
+			}
+
+			if !reflect.DeepEqual(tt.want, got) {
+				t.Fatalf("want=%v, got=%v.", tt.want, got)
+			}
+		})
+	}
+
+	tests := []*test{
+		{
+			name:    "ok",
+			url:     "http://api.example.com/company/1/department",
+			body:    []byte(`{"department":{"name":"開発一課","parent_id":2}}`),
+			want:    organization.New(1, 2, "開発一課"),
+			wantErr: false,
+		},
+		{
+			name:    "root",
+			url:     "http://api.example.com/company/1/department",
+			body:    []byte(`{"department":{"name":"開発部"}}`),
+			want:    organization.New(1, organization.Root, "開発部"),
+			wantErr: false,
+		},
+		{
+			name:    "null parent",
+			url:     "http://api.example.com/company/1/department",
+			body:    []byte(`{"department":{"name":"開発部","parent_id":null}}`),
+			want:    organization.New(1, organization.Root, "開発部"),
+			wantErr: false,
+		},
+		{
+			name:    "invalid request",
+			url:     "http://api.example.com/company/1/department",
+			body:    []byte{},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+}
+
+func TestDepartmentMove(t *testing.T) {
+	type want struct {
+		companyID organization.CompanyID
+		id        organization.ID
+		parentID  organization.ID
+	}
+
+	type test struct {
+		name    string
+		url     string
+		body    []byte
+		want    want
+		wantErr bool
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodPut, tt.url, bytes.NewBuffer(tt.body))
+
+			var (
+				got want
+				err error
+			)
+
+			router := mux.NewRouter()
+			router.HandleFunc("/company/{company_id}/department/{department_id}/parent", func(w http.ResponseWriter, r *http.Request) {
+				got.companyID, got.id, got.parentID, err = DepartmentMove(r)
+			})
+			router.ServeHTTP(w, r)
+
+			if tt.wantErr != (err != nil) {
+				t.Fatalf("want-error=%v, error=%v.", tt.wantErr, err)
+			}
+
+			if !reflect.DeepEqual(tt.want, got) {
+				t.Fatalf("want=%v, got=%v.", tt.want, got)
+			}
+		})
+	}
+
+	tests := []*test{
+		{
+			name:    "ok",
+			url:     "http://api.example.com/company/1/department/3/parent",
+			body:    []byte(`{"department":{"parent_id":2}}`),
+			want:    want{companyID: 1, id: 3, parentID: 2},
+			wantErr: false,
+		},
+		{
+			name:    "to root",
+			url:     "http://api.example.com/company/1/department/3/parent",
+			body:    []byte(`{"department":{"parent_id":null}}`),
+			want:    want{companyID: 1, id: 3, parentID: organization.Root},
+			wantErr: false,
+		},
+		{
+			name:    "invalid department_id",
+			url:     "http://api.example.com/company/1/department/hoge/parent",
+			body:    []byte(`{"department":{"parent_id":2}}`),
+			want:    want{},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+}
+
+func TestDepartmentRename(t *testing.T) {
+	type want struct {
+		companyID organization.CompanyID
+		id        organization.ID
+		name      organization.Name
+	}
+
+	type test struct {
+		name    string
+		url     string
+		body    []byte
+		want    want
+		wantErr bool
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodPut, tt.url, bytes.NewBuffer(tt.body))
+
+			var (
+				got want
+				err error
+			)
+
+			router := mux.NewRouter()
+			router.HandleFunc("/company/{company_id}/department/{department_id}", func(w http.ResponseWriter, r *http.Request) {
+				got.companyID, got.id, got.name, err = DepartmentRename(r)
+			})
+			router.ServeHTTP(w, r)
+
+			if tt.wantErr != (err != nil) {
+				t.Fatalf("want-error=%v, error=%v.", tt.wantErr, err)
+			}
+
+			if !reflect.DeepEqual(tt.want, got) {
+				t.Fatalf("want=%v, got=%v.", tt.want, got)
+			}
+		})
+	}
+
+	tests := []*test{
+		{
+			name:    "ok",
+			url:     "http://api.example.com/company/1/department/3",
+			body:    []byte(`{"department":{"name":"開発本部"}}`),
+			want:    want{companyID: 1, id: 3, name: "開発本部"},
+			wantErr: false,
+		},
+		{
+			name:    "invalid request",
+			url:     "http://api.example.com/company/1/department/3",
+			body:    []byte{},
+			want:    want{},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+}
+
+func TestDepartmentTree(t *testing.T) {
+	type want struct {
+		companyID organization.CompanyID
+		id        organization.ID
+	}
+
+	type test struct {
+		name    string
+		url     string
+		want    want
+		wantErr bool
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodGet, tt.url, nil)
+
+			var (
+				got want
+				err error
+			)
+
+			router := mux.NewRouter()
+			router.HandleFunc("/company/{company_id}/department/{department_id}/subtree", func(w http.ResponseWriter, r *http.Request) {
+				got.companyID, got.id, err = DepartmentTree(r)
+			})
+			router.ServeHTTP(w, r)
+
+			if tt.wantErr != (err != nil) {
+				t.Fatalf("want-error=%v, error=%v.", tt.wantErr, err)
+			}
+
+			if !reflect.DeepEqual(tt.want, got) {
+				t.Fatalf("want=%v, got=%v.", tt.want, got)
+			}
+		})
+	}
+
+	tests := []*test{
+		{
+			name:    "ok",
+			url:     "http://api.example.com/company/1/department/2/subtree",
+			want:    want{companyID: 1, id: 2},
+			wantErr: false,
+		},
+		{
+			name:    "invalid company_id",
+			url:     "http://api.example.com/company/hoge/department/2/subtree",
+			want:    want{},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+}
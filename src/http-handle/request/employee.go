@@ -0,0 +1,84 @@
+package request
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"api.example.com/pkg/employee"
+	"github.com/gorilla/mux"
+)
+
+func parseEmployeeBody(r *http.Request) (*employee.Employee, error) {
+	defer r.Body.Close()
+
+	body := struct {
+		Employee struct {
+			UserID        employee.UserID `json:"user_id"`
+			Administrator bool            `json:"administrator"`
+		} `json:"employee"`
+	}{}
+	err := json.NewDecoder(r.Body).Decode(&body)
+	if err != nil {
+		return nil, invalid(err)
+	}
+
+	return employee.New(0, body.Employee.UserID, body.Employee.Administrator), nil
+}
+
+func parseEmployeePath(r *http.Request) (employee.CompanyID, employee.ID, error) {
+	companyID, err := parseCompanyPath(r)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	id, err := strconv.Atoi(mux.Vars(r)["employee_id"])
+	if err != nil {
+		return 0, 0, invalid(err)
+	}
+
+	return companyID, employee.ID(id), nil
+}
+
+func EmployeeCreate(req *http.Request) (*employee.Employee, error) {
+	companyID, err := parseCompanyPath(req)
+	if err != nil {
+		return nil, fmt.Errorf("http-handle/request.EmployeeCreate: %w", err)
+	}
+
+	e, err := parseEmployeeBody(req)
+	if err != nil {
+		return nil, fmt.Errorf("http-handle/request.EmployeeCreate: %w", err)
+	}
+
+	e.CompanyID = companyID
+	return e, nil
+}
+
+func EmployeeList(req *http.Request) (employee.CompanyID, error) {
+	companyID, err := parseCompanyPath(req)
+	if err != nil {
+		return 0, fmt.Errorf("http-handle/request.EmployeeList: %w", err)
+	}
+
+	return companyID, nil
+}
+
+func EmployeeRead(req *http.Request) (employee.CompanyID, employee.ID, error) {
+	companyID, id, err := parseEmployeePath(req)
+	if err != nil {
+		return 0, 0, fmt.Errorf("http-handle/request.EmployeeRead: %w", err)
+	}
+
+	return companyID, id, nil
+}
+
+func EmployeeDelete(req *http.Request) (employee.CompanyID, employee.ID, error) {
+	companyID, id, err := parseEmployeePath(req)
+	if err != nil {
+		return 0, 0, fmt.Errorf("http-handle/request.EmployeeDelete: %w", err)
+	}
+
+	return companyID, id, nil
+}
@@ -0,0 +1,259 @@
+package request
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"api.example.com/pkg/employee"
+	"github.com/gorilla/mux"
+)
+
+func TestEmployeeCreate(t *testing.T) {
+	type test struct {
+		name    string
+		url     string
+		body    []byte
+		want    *employee.Employee
+		wantErr bool
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodPost, tt.url, bytes.NewBuffer(tt.body))
+
+			var (
+				got *employee.Employee
+				err error
+			)
+
+			router := mux.NewRouter()
+			router.HandleFunc("/company/{company_id}/employee", func(w http.ResponseWriter, r *http.Request) {
+				got, err = EmployeeCreate(r)
+			})
+			router.ServeHTTP(w, r)
+
+			if tt.wantErr != (err != nil) {
+				t.Fatalf("want-error=%v, error=%v.", tt.wantErr, err)
+			}
+
+			if !reflect.DeepEqual(tt.want, got) {
+				t.Fatalf("want=%v, got=%v.", tt.want, got)
+			}
+		})
+	}
+
+	tests := []*test{
+		{
+			name: "ok",
+			url:  "http://api.example.com/company/1/employee",
+			body: []byte(`{
+  "employee": {
+    "user_id": 2,
+    "administrator": true
+  }
+}`),
+			want:    employee.New(1, 2, true),
+			wantErr: false,
+		},
+		{
+			name:    "invalid company_id",
+			url:     "http://api.example.com/company/hoge/employee",
+			body:    []byte(`{"employee":{"user_id":2}}`),
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "invalid request",
+			url:     "http://api.example.com/company/1/employee",
+			body:    []byte{},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+}
+
+func TestEmployeeList(t *testing.T) {
+	type test struct {
+		name    string
+		url     string
+		want    employee.CompanyID
+		wantErr bool
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodGet, tt.url, nil)
+
+			var (
+				got employee.CompanyID
+				err error
+			)
+
+			router := mux.NewRouter()
+			router.HandleFunc("/company/{company_id}/employee", func(w http.ResponseWriter, r *http.Request) {
+				got, err = EmployeeList(r)
+			})
+			router.ServeHTTP(w, r)
+
+			if tt.wantErr != (err != nil) {
+				t.Fatalf("want-err=%v, err=%v.", tt.wantErr, err)
+			}
+
+			if !reflect.DeepEqual(tt.want, got) {
+				t.Fatalf("want=%v, got%v.", tt.want, got)
+			}
+		})
+	}
+
+	tests := []*test{
+		{
+			name:    "OK",
+			url:     "http://api.example.com/company/1/employee",
+			want:    1,
+			wantErr: false,
+		},
+		{
+			name:    "failed request",
+			url:     "http://api.example.com/company/hoge/employee",
+			want:    0,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+}
+
+func TestEmployeeRead(t *testing.T) {
+	type want struct {
+		companyID employee.CompanyID
+		id        employee.ID
+	}
+
+	type test struct {
+		name    string
+		url     string
+		want    want
+		wantErr bool
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodGet, tt.url, nil)
+
+			var (
+				got want
+				err error
+			)
+
+			router := mux.NewRouter()
+			router.HandleFunc("/company/{company_id}/employee/{employee_id}", func(w http.ResponseWriter, r *http.Request) {
+				got.companyID, got.id, err = EmployeeRead(r)
+			})
+			router.ServeHTTP(w, r)
+
+			if tt.wantErr != (err != nil) {
+				t.Fatalf("want-err=%v, err=%v.", tt.wantErr, err)
+			}
+
+			if !reflect.DeepEqual(tt.want, got) {
+				t.Fatalf("want=%v, got%v.", tt.want, got)
+			}
+		})
+	}
+
+	tests := []*test{
+		{
+			name:    "OK",
+			url:     "http://api.example.com/company/1/employee/3",
+			want:    want{companyID: 1, id: 3},
+			wantErr: false,
+		},
+		{
+			name:    "invalid company_id",
+			url:     "http://api.example.com/company/hoge/employee/3",
+			want:    want{},
+			wantErr: true,
+		},
+		{
+			name:    "invalid employee_id",
+			url:     "http://api.example.com/company/1/employee/hoge",
+			want:    want{},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+}
+
+func TestEmployeeDelete(t *testing.T) {
+	type want struct {
+		companyID employee.CompanyID
+		id        employee.ID
+	}
+
+	type test struct {
+		name    string
+		url     string
+		want    want
+		wantErr bool
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodDelete, tt.url, nil)
+
+			var (
+				got want
+				err error
+			)
+
+			router := mux.NewRouter()
+			router.HandleFunc("/company/{company_id}/employee/{employee_id}", func(w http.ResponseWriter, r *http.Request) {
+				got.companyID, got.id, err = EmployeeDelete(r)
+			})
+			router.ServeHTTP(w, r)
+
+			if tt.wantErr != (err != nil) {
+				t.Fatalf("want-err=%v, err=%v.", tt.wantErr, err)
+			}
+
+			if !reflect.DeepEqual(tt.want, got) {
+				t.Fatalf("want=%v, got%v.", tt.want, got)
+			}
+		})
+	}
+
+	tests := []*test{
+		{
+			name:    "OK",
+			url:     "http://api.example.com/company/1/employee/3",
+			want:    want{companyID: 1, id: 3},
+			wantErr: false,
+		},
+		{
+			name:    "failed request",
+			url:     "http://api.example.com/company/1/employee/hoge",
+			want:    want{},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+}
@@ -0,0 +1,45 @@
+package request
+
+import (
+	"fmt"
+	"net/http"
+
+	"api.example.com/pkg/permission"
+	"github.com/gorilla/mux"
+)
+
+func parsePermissionPath(r *http.Request) (permission.CompanyID, permission.EmployeeID, permission.Permission, error) {
+	companyID, employeeID, err := parseEmployeePath(r)
+	if err != nil {
+		return 0, 0, "", err
+	}
+
+	return companyID, employeeID, permission.Permission(mux.Vars(r)["permission"]), nil
+}
+
+func PermissionList(req *http.Request) (permission.CompanyID, permission.EmployeeID, error) {
+	companyID, employeeID, err := parseEmployeePath(req)
+	if err != nil {
+		return 0, 0, fmt.Errorf("http-handle/request.PermissionList: %w", err)
+	}
+
+	return companyID, employeeID, nil
+}
+
+func PermissionGrant(req *http.Request) (permission.CompanyID, permission.EmployeeID, permission.Permission, error) {
+	companyID, employeeID, p, err := parsePermissionPath(req)
+	if err != nil {
+		return 0, 0, "", fmt.Errorf("http-handle/request.PermissionGrant: %w", err)
+	}
+
+	return companyID, employeeID, p, nil
+}
+
+func PermissionRevoke(req *http.Request) (permission.CompanyID, permission.EmployeeID, permission.Permission, error) {
+	companyID, employeeID, p, err := parsePermissionPath(req)
+	if err != nil {
+		return 0, 0, "", fmt.Errorf("http-handle/request.PermissionRevoke: %w", err)
+	}
+
+	return companyID, employeeID, p, nil
+}
@@ -0,0 +1,190 @@
+package request
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"api.example.com/pkg/permission"
+	"github.com/gorilla/mux"
+)
+
+func TestPermissionList(t *testing.T) {
+	type want struct {
+		companyID  permission.CompanyID
+		employeeID permission.EmployeeID
+	}
+
+	type test struct {
+		name    string
+		url     string
+		want    want
+		wantErr bool
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodGet, tt.url, nil)
+
+			var (
+				got want
+				err error
+			)
+
+			router := mux.NewRouter()
+			router.HandleFunc("/company/{company_id}/employee/{employee_id}/permission", func(w http.ResponseWriter, r *http.Request) {
+				got.companyID, got.employeeID, err = PermissionList(r)
+			})
+			router.ServeHTTP(w, r)
+
+			if tt.wantErr != (err != nil) {
+				t.Fatalf("want-err=%v, err=%v.", tt.wantErr, err)
+			}
+
+			if !reflect.DeepEqual(tt.want, got) {
+				t.Fatalf("want=%v, got%v.", tt.want, got)
+			}
+		})
+	}
+
+	tests := []*test{
+		{
+			name:    "OK",
+			url:     "http://api.example.com/company/1/employee/2/permission",
+			want:    want{companyID: 1, employeeID: 2},
+			wantErr: false,
+		},
+		{
+			name:    "failed request",
+			url:     "http://api.example.com/company/1/employee/hoge/permission",
+			want:    want{},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+}
+
+func TestPermissionGrant(t *testing.T) {
+	type want struct {
+		companyID  permission.CompanyID
+		employeeID permission.EmployeeID
+		permission permission.Permission
+	}
+
+	type test struct {
+		name    string
+		url     string
+		want    want
+		wantErr bool
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodPut, tt.url, nil)
+
+			var (
+				got want
+				err error
+			)
+
+			router := mux.NewRouter()
+			router.HandleFunc("/company/{company_id}/employee/{employee_id}/permission/{permission}", func(w http.ResponseWriter, r *http.Request) {
+				got.companyID, got.employeeID, got.permission, err = PermissionGrant(r)
+			})
+			router.ServeHTTP(w, r)
+
+			if tt.wantErr != (err != nil) {
+				t.Fatalf("want-err=%v, err=%v.", tt.wantErr, err)
+			}
+
+			if !reflect.DeepEqual(tt.want, got) {
+				t.Fatalf("want=%v, got%v.", tt.want, got)
+			}
+		})
+	}
+
+	tests := []*test{
+		{
+			name:    "OK",
+			url:     "http://api.example.com/company/1/employee/2/permission/manage_roles",
+			want:    want{companyID: 1, employeeID: 2, permission: permission.ManageRoles},
+			wantErr: false,
+		},
+		{
+			name:    "failed request",
+			url:     "http://api.example.com/company/hoge/employee/2/permission/manage_roles",
+			want:    want{},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+}
+
+func TestPermissionRevoke(t *testing.T) {
+	type want struct {
+		companyID  permission.CompanyID
+		employeeID permission.EmployeeID
+		permission permission.Permission
+	}
+
+	type test struct {
+		name    string
+		url     string
+		want    want
+		wantErr bool
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodDelete, tt.url, nil)
+
+			var (
+				got want
+				err error
+			)
+
+			router := mux.NewRouter()
+			router.HandleFunc("/company/{company_id}/employee/{employee_id}/permission/{permission}", func(w http.ResponseWriter, r *http.Request) {
+				got.companyID, got.employeeID, got.permission, err = PermissionRevoke(r)
+			})
+			router.ServeHTTP(w, r)
+
+			if tt.wantErr != (err != nil) {
+				t.Fatalf("want-err=%v, err=%v.", tt.wantErr, err)
+			}
+
+			if !reflect.DeepEqual(tt.want, got) {
+				t.Fatalf("want=%v, got%v.", tt.want, got)
+			}
+		})
+	}
+
+	tests := []*test{
+		{
+			name:    "OK",
+			url:     "http://api.example.com/company/1/employee/2/permission/manage_company",
+			want:    want{companyID: 1, employeeID: 2, permission: permission.ManageCompany},
+			wantErr: false,
+		},
+		{
+			name:    "failed request",
+			url:     "http://api.example.com/company/1/employee/hoge/permission/manage_company",
+			want:    want{},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+}
@@ -0,0 +1,35 @@
+package request
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"api.example.com/pkg/failure"
+	"api.example.com/pkg/page"
+)
+
+// 読み取れないリクエストは failure.ErrInvalidArgument
+func invalid(err error) error {
+	return fmt.Errorf("%w: %v", failure.ErrInvalidArgument, err)
+}
+
+// 一覧の読み込み位置と件数 (?cursor=...&limit=...)
+// 省略した項目はゼロ値
+func parsePage(r *http.Request) (page.Query, error) {
+	values := r.URL.Query()
+
+	limit := 0
+	if v := values.Get("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return page.Query{}, invalid(err)
+		}
+		limit = n
+	}
+
+	return page.Query{
+		Cursor: page.Cursor(values.Get("cursor")),
+		Limit:  limit,
+	}, nil
+}
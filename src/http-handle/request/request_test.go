@@ -0,0 +1,60 @@
+package request
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"api.example.com/pkg/failure"
+	"github.com/gorilla/mux"
+)
+
+func TestInvalid(t *testing.T) {
+	type test struct {
+		name  string
+		url   string
+		parse func(*http.Request) error
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodPost, tt.url, nil)
+
+			var err error
+			router := mux.NewRouter()
+			router.HandleFunc("/company/{company_id}/role", func(w http.ResponseWriter, r *http.Request) {
+				err = tt.parse(r)
+			})
+			router.ServeHTTP(w, r)
+
+			if !errors.Is(err, failure.ErrInvalidArgument) {
+				t.Fatalf("want=%v, got=%v.", failure.ErrInvalidArgument, err)
+			}
+		})
+	}
+
+	tests := []*test{
+		{
+			name: "invalid path",
+			url:  "http://api.example.com/company/hoge/role",
+			parse: func(r *http.Request) error {
+				_, err := RoleList(r)
+				return err
+			},
+		},
+		{
+			name: "invalid body",
+			url:  "http://api.example.com/company/1/role",
+			parse: func(r *http.Request) error {
+				_, err := RoleCreate(r)
+				return err
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+}
@@ -0,0 +1,99 @@
+package request
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"api.example.com/pkg/role"
+	"github.com/gorilla/mux"
+)
+
+func parseRoleBody(r *http.Request) (*role.Role, error) {
+	defer r.Body.Close()
+
+	body := struct {
+		Role struct {
+			Name role.Name `json:"name"`
+		} `json:"role"`
+	}{}
+	err := json.NewDecoder(r.Body).Decode(&body)
+	if err != nil {
+		return nil, invalid(err)
+	}
+
+	return role.New(0, body.Role.Name), nil
+}
+
+func parseRolePath(r *http.Request) (role.CompanyID, role.ID, error) {
+	companyID, err := parseCompanyPath(r)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	id, err := strconv.Atoi(mux.Vars(r)["role_id"])
+	if err != nil {
+		return 0, 0, invalid(err)
+	}
+
+	return companyID, role.ID(id), nil
+}
+
+func RoleCreate(req *http.Request) (*role.Role, error) {
+	companyID, err := parseCompanyPath(req)
+	if err != nil {
+		return nil, fmt.Errorf("http-handle/request.RoleCreate: %w", err)
+	}
+
+	r, err := parseRoleBody(req)
+	if err != nil {
+		return nil, fmt.Errorf("http-handle/request.RoleCreate: %w", err)
+	}
+
+	r.CompanyID = companyID
+	return r, nil
+}
+
+func RoleList(req *http.Request) (role.CompanyID, error) {
+	companyID, err := parseCompanyPath(req)
+	if err != nil {
+		return 0, fmt.Errorf("http-handle/request.RoleList: %w", err)
+	}
+
+	return companyID, nil
+}
+
+func RoleRead(req *http.Request) (role.CompanyID, role.ID, error) {
+	companyID, id, err := parseRolePath(req)
+	if err != nil {
+		return 0, 0, fmt.Errorf("http-handle/request.RoleRead: %w", err)
+	}
+
+	return companyID, id, nil
+}
+
+func RoleUpdate(req *http.Request) (*role.Role, error) {
+	companyID, id, err := parseRolePath(req)
+	if err != nil {
+		return nil, fmt.Errorf("http-handle/request.RoleUpdate: %w", err)
+	}
+
+	r, err := parseRoleBody(req)
+	if err != nil {
+		return nil, fmt.Errorf("http-handle/request.RoleUpdate: %w", err)
+	}
+
+	r.ID = id
+	r.CompanyID = companyID
+	return r, nil
+}
+
+func RoleDelete(req *http.Request) (role.CompanyID, role.ID, error) {
+	companyID, id, err := parseRolePath(req)
+	if err != nil {
+		return 0, 0, fmt.Errorf("http-handle/request.RoleDelete: %w", err)
+	}
+
+	return companyID, id, nil
+}
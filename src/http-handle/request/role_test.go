@@ -0,0 +1,322 @@
+package request
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"api.example.com/pkg/role"
+	"github.com/gorilla/mux"
+)
+
+func TestRoleCreate(t *testing.T) {
+	type test struct {
+		name    string
+		url     string
+		body    []byte
+		want    *role.Role
+		wantErr bool
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodPost, tt.url, bytes.NewBuffer(tt.body))
+
+			var (
+				got *role.Role
+				err error
+			)
+
+			router := mux.NewRouter()
+			router.HandleFunc("/company/{company_id}/role", func(w http.ResponseWriter, r *http.Request) {
+				got, err = RoleCreate(r)
+			})
+			router.ServeHTTP(w, r)
+
+			if tt.wantErr != (err != nil) {
+				t.Fatalf("want-error=%v, error=%v.", tt.wantErr, err)
+			}
+
+			if !reflect.DeepEqual(tt.want, got) {
+				t.Fatalf("want=%v, got=%v.", tt.want, got)
+			}
+		})
+	}
+
+	tests := []*test{
+		{
+			name: "ok",
+			url:  "http://api.example.com/company/1/role",
+			body: []byte(`{
+  "role": {
+    "name": "部長"
+  }
+}`),
+			want:    role.New(1, "部長"),
+			wantErr: false,
+		},
+		{
+			name:    "invalid company_id",
+			url:     "http://api.example.com/company/hoge/role",
+			body:    []byte(`{"role":{"name":"部長"}}`),
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "invalid request",
+			url:     "http://api.example.com/company/1/role",
+			body:    []byte{},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+}
+
+func TestRoleList(t *testing.T) {
+	type test struct {
+		name    string
+		url     string
+		want    role.CompanyID
+		wantErr bool
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodGet, tt.url, nil)
+
+			var (
+				got role.CompanyID
+				err error
+			)
+
+			router := mux.NewRouter()
+			router.HandleFunc("/company/{company_id}/role", func(w http.ResponseWriter, r *http.Request) {
+				got, err = RoleList(r)
+			})
+			router.ServeHTTP(w, r)
+
+			if tt.wantErr != (err != nil) {
+				t.Fatalf("want-err=%v, err=%v.", tt.wantErr, err)
+			}
+
+			if !reflect.DeepEqual(tt.want, got) {
+				t.Fatalf("want=%v, got%v.", tt.want, got)
+			}
+		})
+	}
+
+	tests := []*test{
+		{
+			name:    "OK",
+			url:     "http://api.example.com/company/1/role",
+			want:    1,
+			wantErr: false,
+		},
+		{
+			name:    "failed request",
+			url:     "http://api.example.com/company/hoge/role",
+			want:    0,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+}
+
+func TestRoleRead(t *testing.T) {
+	type want struct {
+		companyID role.CompanyID
+		id        role.ID
+	}
+
+	type test struct {
+		name    string
+		url     string
+		want    want
+		wantErr bool
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodGet, tt.url, nil)
+
+			var (
+				got want
+				err error
+			)
+
+			router := mux.NewRouter()
+			router.HandleFunc("/company/{company_id}/role/{role_id}", func(w http.ResponseWriter, r *http.Request) {
+				got.companyID, got.id, err = RoleRead(r)
+			})
+			router.ServeHTTP(w, r)
+
+			if tt.wantErr != (err != nil) {
+				t.Fatalf("want-err=%v, err=%v.", tt.wantErr, err)
+			}
+
+			if !reflect.DeepEqual(tt.want, got) {
+				t.Fatalf("want=%v, got%v.", tt.want, got)
+			}
+		})
+	}
+
+	tests := []*test{
+		{
+			name:    "OK",
+			url:     "http://api.example.com/company/1/role/3",
+			want:    want{companyID: 1, id: 3},
+			wantErr: false,
+		},
+		{
+			name:    "invalid company_id",
+			url:     "http://api.example.com/company/hoge/role/3",
+			want:    want{},
+			wantErr: true,
+		},
+		{
+			name:    "invalid role_id",
+			url:     "http://api.example.com/company/1/role/hoge",
+			want:    want{},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+}
+
+func TestRoleUpdate(t *testing.T) {
+	type test struct {
+		name    string
+		url     string
+		body    []byte
+		want    *role.Role
+		wantErr bool
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodPut, tt.url, bytes.NewBuffer(tt.body))
+
+			var (
+				got *role.Role
+				err error
+			)
+
+			router := mux.NewRouter()
+			router.HandleFunc("/company/{company_id}/role/{role_id}", func(w http.ResponseWriter, r *http.Request) {
+				got, err = RoleUpdate(r)
+			})
+			router.ServeHTTP(w, r)
+
+			if tt.wantErr != (err != nil) {
+				t.Fatalf("want-error=%v, error=%v.", tt.wantErr, err)
+			}
+
+			if !reflect.DeepEqual(tt.want, got) {
+				t.Fatalf("want=%v, got=%v.", tt.want, got)
+			}
+		})
+	}
+
+	tests := []*test{
+		{
+			name:    "ok",
+			url:     "http://api.example.com/company/1/role/3",
+			body:    []byte(`{"role":{"name":"課長"}}`),
+			want:    &role.Role{ID: 3, CompanyID: 1, Name: "課長"},
+			wantErr: false,
+		},
+		{
+			name:    "invalid role_id",
+			url:     "http://api.example.com/company/1/role/hoge",
+			body:    []byte(`{"role":{"name":"課長"}}`),
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "invalid request",
+			url:     "http://api.example.com/company/1/role/3",
+			body:    []byte{},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+}
+
+func TestRoleDelete(t *testing.T) {
+	type want struct {
+		companyID role.CompanyID
+		id        role.ID
+	}
+
+	type test struct {
+		name    string
+		url     string
+		want    want
+		wantErr bool
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodDelete, tt.url, nil)
+
+			var (
+				got want
+				err error
+			)
+
+			router := mux.NewRouter()
+			router.HandleFunc("/company/{company_id}/role/{role_id}", func(w http.ResponseWriter, r *http.Request) {
+				got.companyID, got.id, err = RoleDelete(r)
+			})
+			router.ServeHTTP(w, r)
+
+			if tt.wantErr != (err != nil) {
+				t.Fatalf("want-err=%v, err=%v.", tt.wantErr, err)
+			}
+
+			if !reflect.DeepEqual(tt.want, got) {
+				t.Fatalf("want=%v, got%v.", tt.want, got)
+			}
+		})
+	}
+
+	tests := []*test{
+		{
+			name:    "OK",
+			url:     "http://api.example.com/company/1/role/3",
+			want:    want{companyID: 1, id: 3},
+			wantErr: false,
+		},
+		{
+			name:    "failed request",
+			url:     "http://api.example.com/company/1/role/hoge",
+			want:    want{},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+}
@@ -0,0 +1,26 @@
+package request
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"api.example.com/pkg/user"
+)
+
+func SessionCreate(req *http.Request) (user.Name, user.PlainPassword, error) {
+	defer req.Body.Close()
+
+	body := struct {
+		Session struct {
+			Name     user.Name          `json:"name"`
+			Password user.PlainPassword `json:"password"`
+		} `json:"session"`
+	}{}
+	err := json.NewDecoder(req.Body).Decode(&body)
+	if err != nil {
+		return "", "", fmt.Errorf("http-handle/request.SessionCreate: %w", invalid(err))
+	}
+
+	return body.Session.Name, body.Session.Password, nil
+}
@@ -0,0 +1,63 @@
+package request
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"api.example.com/pkg/user"
+)
+
+func TestSessionCreate(t *testing.T) {
+	type want struct {
+		name     user.Name
+		password user.PlainPassword
+	}
+
+	type test struct {
+		name    string
+		body    []byte
+		want    want
+		wantErr bool
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodPost, "http://api.example.com/session", bytes.NewBuffer(tt.body))
+
+			var (
+				got want
+				err error
+			)
+			got.name, got.password, err = SessionCreate(r)
+			if tt.wantErr != (err != nil) {
+				t.Fatalf("want-err=%v, err=%v.", tt.wantErr, err)
+			}
+
+			if !reflect.DeepEqual(tt.want, got) {
+				t.Fatalf("want=%v, got%v.", tt.want, got)
+			}
+		})
+	}
+
+	tests := []*test{
+		{
+			name:    "OK",
+			body:    []byte(`{"session":{"name":"Bob","password":"password"}}`),
+			want:    want{name: "Bob", password: "password"},
+			wantErr: false,
+		},
+		{
+			name:    "invalid request",
+			body:    []byte{},
+			want:    want{},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+}
@@ -4,36 +4,61 @@ import (
 	"api.example.com/pkg/user"
 	"api.example.com/pkg/user/password"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"github.com/gorilla/mux"
+	"io"
 	"net/http"
 	"strconv"
 )
 
-func parseUserBody(r *http.Request) (*user.User, error) {
+// 本人確認のための現在のパスワードも返す
+func parseUserBody(r *http.Request) (*user.User, user.PlainPassword, error) {
 	defer r.Body.Close()
 
 	body := struct {
 		User struct {
-			Name     user.Name `json:"name"`
-			Password string    `json:"password"`
+			Name            user.Name          `json:"name"`
+			Password        string             `json:"password"`
+			CurrentPassword user.PlainPassword `json:"current_password"`
 		} `json:"user"`
 	}{}
 
 	err := json.NewDecoder(r.Body).Decode(&body)
 	if err != nil {
-		return nil, err
+		return nil, "", invalid(err)
 	}
 
 	password, err := password.New(body.User.Password)
 	if err != nil {
-		return nil, err
+		return nil, "", invalid(err)
 	}
 
 	return user.New(
 		body.User.Name,
 		password,
-	), nil
+	), body.User.CurrentPassword, nil
+}
+
+// 削除では本文を省略できる
+func parseCurrentPassword(r *http.Request) (user.PlainPassword, error) {
+	defer r.Body.Close()
+
+	body := struct {
+		User struct {
+			CurrentPassword user.PlainPassword `json:"current_password"`
+		} `json:"user"`
+	}{}
+
+	err := json.NewDecoder(r.Body).Decode(&body)
+	if errors.Is(err, io.EOF) {
+		return "", nil
+	}
+	if err != nil {
+		return "", invalid(err)
+	}
+
+	return body.User.CurrentPassword, nil
 }
 
 func parseUserPath(r *http.Request) (user.ID, error) {
@@ -41,14 +66,14 @@ func parseUserPath(r *http.Request) (user.ID, error) {
 
 	id, err := strconv.Atoi(vars["user_id"])
 	if err != nil {
-		return 0, err
+		return 0, invalid(err)
 	}
 
 	return user.ID(id), nil
 }
 
 func UserCreate(req *http.Request) (*user.User, error) {
-	user, err := parseUserBody(req)
+	user, _, err := parseUserBody(req)
 	if err != nil {
 		return nil, fmt.Errorf("http-handle/request.UserCreate: %w", err)
 	}
@@ -63,25 +88,44 @@ func UserRead(req *http.Request) (user.ID, error) {
 	return id, nil
 }
 
-func UserUpdate(req *http.Request) (*user.User, error) {
+// 名前の前方一致 (?name_prefix=...) と読み込み位置
+func UserList(req *http.Request) (user.ListQuery, error) {
+	q, err := parsePage(req)
+	if err != nil {
+		return user.ListQuery{}, fmt.Errorf("http-handle/request.UserList: %w", err)
+	}
+
+	return user.ListQuery{
+		NamePrefix: user.Name(req.URL.Query().Get("name_prefix")),
+		Query:      q,
+	}, nil
+}
+
+func UserUpdate(req *http.Request) (*user.User, user.PlainPassword, error) {
 	id, err := parseUserPath(req)
 	if err != nil {
-		return nil, fmt.Errorf("http-handle/request.UserUpdate: %w", err)
+		return nil, "", fmt.Errorf("http-handle/request.UserUpdate: %w", err)
 	}
 
-	user, err := parseUserBody(req)
+	user, current, err := parseUserBody(req)
 	if err != nil {
-		return nil, fmt.Errorf("http-handle/request.UserUpdate: %w", err)
+		return nil, "", fmt.Errorf("http-handle/request.UserUpdate: %w", err)
 	}
 
 	user.ID = id
-	return user, nil
+	return user, current, nil
 }
 
-func UserDelete(req *http.Request) (user.ID, error) {
+func UserDelete(req *http.Request) (user.ID, user.PlainPassword, error) {
 	id, err := parseUserPath(req)
 	if err != nil {
-		return 0, fmt.Errorf("http-handle/request.UserDelete: %w", err)
+		return 0, "", fmt.Errorf("http-handle/request.UserDelete: %w", err)
 	}
-	return id, nil
+
+	current, err := parseCurrentPassword(req)
+	if err != nil {
+		return 0, "", fmt.Errorf("http-handle/request.UserDelete: %w", err)
+	}
+
+	return id, current, nil
 }
@@ -1,6 +1,7 @@
 package request
 
 import (
+	"api.example.com/pkg/page"
 	"api.example.com/pkg/user"
 	"bytes"
 	"github.com/gorilla/mux"
@@ -115,14 +116,61 @@ func TestUserRead(t *testing.T) {
 	}
 }
 
+func TestUserList(t *testing.T) {
+	type test struct {
+		name    string
+		url     string
+		want    user.ListQuery
+		wantErr bool
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, tt.url, nil)
+
+			got, err := UserList(r)
+			if tt.wantErr != (err != nil) {
+				t.Fatalf("want-error=%v, error=%v.", tt.wantErr, err)
+			}
+
+			if !reflect.DeepEqual(tt.want, got) {
+				t.Fatalf("want=%v, got=%v.", tt.want, got)
+			}
+		})
+	}
+
+	tests := []*test{
+		{
+			name: "ok",
+			url:  "http://api.example.com/user?name_prefix=bo&cursor=Mg&limit=10",
+			want: user.ListQuery{NamePrefix: "bo", Query: page.Query{Cursor: "Mg", Limit: 10}},
+		},
+		{
+			name: "default",
+			url:  "http://api.example.com/user",
+			want: user.ListQuery{},
+		},
+		{
+			name:    "invalid limit",
+			url:     "http://api.example.com/user?limit=ten",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+}
+
 func TestUserUpdate(t *testing.T) {
 	type test struct {
-		testcase string
-		url      string
-		body     []byte
-		password string
-		want     *user.User
-		wantErr  bool
+		testcase    string
+		url         string
+		body        []byte
+		password    string
+		want        *user.User
+		wantCurrent user.PlainPassword
+		wantErr     bool
 	}
 
 	do := func(tt test) {
@@ -131,12 +179,13 @@ func TestUserUpdate(t *testing.T) {
 			r := httptest.NewRequest("PUT", tt.url, bytes.NewBuffer(tt.body))
 
 			var (
-				got *user.User
-				err error
+				got     *user.User
+				current user.PlainPassword
+				err     error
 			)
 			router := mux.NewRouter()
 			router.HandleFunc("/user/{user_id}", func(w http.ResponseWriter, r *http.Request) {
-				got, err = UserUpdate(r)
+				got, current, err = UserUpdate(r)
 			})
 			router.ServeHTTP(w, r)
 
@@ -158,11 +207,28 @@ func TestUserUpdate(t *testing.T) {
 			if !reflect.DeepEqual(tt.want, got) {
 				t.Fatalf("want=%v, got=%v.", tt.want, got)
 			}
+
+			if tt.wantCurrent != current {
+				t.Fatalf("current_password want=%v, got=%v.", tt.wantCurrent, current)
+			}
 		})
 	}
 
 	tests := []test{
 		{
+			url:      "http://api.example.com/user/1",
+			body:     []byte(`{"user":{"name":"Bob","password":"qwerty","current_password":"password"}}`),
+			password: "qwerty",
+			want: &user.User{
+				ID:       1,
+				Name:     "Bob",
+				Password: nil,
+			},
+			wantCurrent: "password",
+			wantErr:     false,
+		},
+		{
+			testcase: "without current_password",
 			url:      "http://api.example.com/user/1",
 			body:     []byte(`{"user":{"name":"Bob","password":"qwerty"}}`),
 			password: "qwerty",
@@ -171,7 +237,8 @@ func TestUserUpdate(t *testing.T) {
 				Name:     "Bob",
 				Password: nil,
 			},
-			wantErr: false,
+			wantCurrent: "",
+			wantErr:     false,
 		},
 		{
 			testcase: "empty body",
@@ -196,10 +263,12 @@ func TestUserUpdate(t *testing.T) {
 
 func TestUserDelete(t *testing.T) {
 	type test struct {
-		testcase string
-		url      string
-		want     user.ID
-		wantErr  bool
+		testcase    string
+		url         string
+		body        []byte
+		want        user.ID
+		wantCurrent user.PlainPassword
+		wantErr     bool
 	}
 
 	do := func(tt test) {
@@ -207,15 +276,16 @@ func TestUserDelete(t *testing.T) {
 
 		t.Run(tt.testcase, func(t *testing.T) {
 			w := httptest.NewRecorder()
-			r := httptest.NewRequest("GET", tt.url, nil)
+			r := httptest.NewRequest("DELETE", tt.url, bytes.NewBuffer(tt.body))
 
 			var (
-				got user.ID
-				err error
+				got     user.ID
+				current user.PlainPassword
+				err     error
 			)
 			router := mux.NewRouter()
 			router.HandleFunc("/user/{user_id}", func(w http.ResponseWriter, r *http.Request) {
-				got, err = UserDelete(r)
+				got, current, err = UserDelete(r)
 			})
 			router.ServeHTTP(w, r)
 
@@ -226,14 +296,33 @@ func TestUserDelete(t *testing.T) {
 			if tt.want != got {
 				t.Fatalf("want=%v, got=%v.", tt.want, got)
 			}
+
+			if tt.wantCurrent != current {
+				t.Fatalf("current_password want=%v, got=%v.", tt.wantCurrent, current)
+			}
 		})
 	}
 
 	tests := []test{
 		{
-			url:     "http://api.example.com/user/2",
-			want:    2,
-			wantErr: false,
+			url:         "http://api.example.com/user/2",
+			body:        []byte(`{"user":{"current_password":"password"}}`),
+			want:        2,
+			wantCurrent: "password",
+			wantErr:     false,
+		},
+		{
+			testcase: "empty body",
+			url:      "http://api.example.com/user/2",
+			want:     2,
+			wantErr:  false,
+		},
+		{
+			testcase: "invalid body",
+			url:      "http://api.example.com/user/2",
+			body:     []byte(`{"user":`),
+			want:     0,
+			wantErr:  true,
 		},
 		{
 			url:     "http://api.example.com/user/xxx",
@@ -0,0 +1,102 @@
+package response
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"api.example.com/pkg/apikey"
+)
+
+type apiKeyValue struct {
+	ID        apikey.ID        `json:"id"`
+	CompanyID apikey.CompanyID `json:"company_id"`
+	Name      apikey.Name      `json:"name"`
+	Scopes    []apikey.Scope   `json:"scopes"`
+	// 作成と再発行の直後のみ返す
+	Key       apikey.Token `json:"key,omitempty"`
+	UpdatedAt time.Time    `json:"updated_at"`
+}
+
+func newAPIKeyValue(k *apikey.Key) apiKeyValue {
+	return apiKeyValue{
+		ID:        k.ID,
+		CompanyID: k.CompanyID,
+		Name:      k.Name,
+		Scopes:    k.Scopes,
+		Key:       k.Token,
+		UpdatedAt: k.UpdatedAt,
+	}
+}
+
+func writeAPIKey(w http.ResponseWriter, k *apikey.Key) error {
+	body := struct {
+		APIKey apiKeyValue `json:"apikey"`
+	}{
+		APIKey: newAPIKeyValue(k),
+	}
+
+	writeHeader(w)
+	return json.NewEncoder(w).Encode(&body)
+}
+
+func APIKeyCreate(w http.ResponseWriter, k *apikey.Key) error {
+	err := writeAPIKey(w, k)
+	if err != nil {
+		return fmt.Errorf("http-handle/response.APIKeyCreate: %w", err)
+	}
+
+	return nil
+}
+
+func APIKeyRead(w http.ResponseWriter, k *apikey.Key) error {
+	err := writeAPIKey(w, k)
+	if err != nil {
+		return fmt.Errorf("http-handle/response.APIKeyRead: %w", err)
+	}
+
+	return nil
+}
+
+func APIKeyList(w http.ResponseWriter, list []*apikey.Key) error {
+	body := struct {
+		APIKeys []apiKeyValue `json:"apikeys"`
+	}{
+		APIKeys: make([]apiKeyValue, 0, len(list)),
+	}
+	for _, k := range list {
+		body.APIKeys = append(body.APIKeys, newAPIKeyValue(k))
+	}
+
+	writeHeader(w)
+	err := json.NewEncoder(w).Encode(&body)
+	if err != nil {
+		return fmt.Errorf("http-handle/response.APIKeyList: %w", err)
+	}
+
+	return nil
+}
+
+func APIKeyRotate(w http.ResponseWriter, k *apikey.Key) error {
+	err := writeAPIKey(w, k)
+	if err != nil {
+		return fmt.Errorf("http-handle/response.APIKeyRotate: %w", err)
+	}
+
+	return nil
+}
+
+func APIKeyDelete(w http.ResponseWriter) error {
+	body := struct {
+		APIKey struct{} `json:"apikey"`
+	}{}
+
+	writeHeader(w)
+	err := json.NewEncoder(w).Encode(&body)
+	if err != nil {
+		return fmt.Errorf("http-handle/response.APIKeyDelete: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,139 @@
+package response
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"api.example.com/pkg/apikey"
+)
+
+func TestAPIKey(t *testing.T) {
+	type want struct {
+		statusCode  int
+		contentType string
+		body        []byte
+	}
+
+	type test struct {
+		name    string
+		write   func(http.ResponseWriter) error
+		want    want
+		wantErr bool
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			err := tt.write(w)
+			if tt.wantErr != (err != nil) {
+				t.Fatalf("want-error=%v, error=%v.", tt.wantErr, err)
+			}
+
+			got := w.Result()
+			defer got.Body.Close()
+
+			gotBody, _ := io.ReadAll(got.Body)
+			if !reflect.DeepEqual(tt.want.body, gotBody) {
+				t.Fatalf("body want=%s, got=%s.", tt.want.body, gotBody)
+			}
+
+			gotContentType := got.Header.Get("Content-Type")
+			if tt.want.contentType != gotContentType {
+				t.Fatalf("Content-Type want=%v, got=%v.", tt.want.contentType, gotContentType)
+			}
+
+			gotStatusCode := got.StatusCode
+			if tt.want.statusCode != gotStatusCode {
+				t.Fatalf("Status-Code want=%v, got=%v.", tt.want.statusCode, gotStatusCode)
+			}
+		})
+	}
+
+	key := func(token apikey.Token) *apikey.Key {
+		return &apikey.Key{
+			ID:        3,
+			CompanyID: 1,
+			Name:      "batch",
+			Scopes:    []apikey.Scope{apikey.ScopeRead},
+			Token:     token,
+			UpdatedAt: time.Date(2022, 9, 3, 12, 34, 56, 0, time.UTC),
+		}
+	}
+
+	tests := []*test{
+		{
+			name: "create",
+			write: func(w http.ResponseWriter) error {
+				return APIKeyCreate(w, key("3.secret"))
+			},
+			want: want{
+				statusCode:  200,
+				contentType: "application/json",
+				body:        []byte(`{"apikey":{"id":3,"company_id":1,"name":"batch","scopes":["read"],"key":"3.secret","updated_at":"2022-09-03T12:34:56Z"}}` + "\n"),
+			},
+		},
+		{
+			name: "read",
+			write: func(w http.ResponseWriter) error {
+				return APIKeyRead(w, key(""))
+			},
+			want: want{
+				statusCode:  200,
+				contentType: "application/json",
+				body:        []byte(`{"apikey":{"id":3,"company_id":1,"name":"batch","scopes":["read"],"updated_at":"2022-09-03T12:34:56Z"}}` + "\n"),
+			},
+		},
+		{
+			name: "rotate",
+			write: func(w http.ResponseWriter) error {
+				return APIKeyRotate(w, key("3.rotated"))
+			},
+			want: want{
+				statusCode:  200,
+				contentType: "application/json",
+				body:        []byte(`{"apikey":{"id":3,"company_id":1,"name":"batch","scopes":["read"],"key":"3.rotated","updated_at":"2022-09-03T12:34:56Z"}}` + "\n"),
+			},
+		},
+		{
+			name: "list",
+			write: func(w http.ResponseWriter) error {
+				return APIKeyList(w, []*apikey.Key{key("")})
+			},
+			want: want{
+				statusCode:  200,
+				contentType: "application/json",
+				body:        []byte(`{"apikeys":[{"id":3,"company_id":1,"name":"batch","scopes":["read"],"updated_at":"2022-09-03T12:34:56Z"}]}` + "\n"),
+			},
+		},
+		{
+			name: "empty list",
+			write: func(w http.ResponseWriter) error {
+				return APIKeyList(w, nil)
+			},
+			want: want{
+				statusCode:  200,
+				contentType: "application/json",
+				body:        []byte(`{"apikeys":[]}` + "\n"),
+			},
+		},
+		{
+			name: "delete",
+			write: func(w http.ResponseWriter) error {
+				return APIKeyDelete(w)
+			},
+			want: want{
+				statusCode:  200,
+				contentType: "application/json",
+				body:        []byte(`{"apikey":{}}` + "\n"),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+}
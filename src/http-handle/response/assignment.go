@@ -0,0 +1,92 @@
+package response
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"api.example.com/pkg/assignment"
+)
+
+type assignmentValue struct {
+	ID           assignment.ID           `json:"id"`
+	CompanyID    assignment.CompanyID    `json:"company_id"`
+	EmployeeID   assignment.EmployeeID   `json:"employee_id"`
+	DepartmentID assignment.DepartmentID `json:"department_id"`
+	RoleID       assignment.RoleID       `json:"role_id"`
+	UpdatedAt    time.Time               `json:"updated_at"`
+}
+
+func newAssignmentValue(a *assignment.Assignment) assignmentValue {
+	return assignmentValue{
+		ID:           a.ID,
+		CompanyID:    a.CompanyID,
+		EmployeeID:   a.EmployeeID,
+		DepartmentID: a.DepartmentID,
+		RoleID:       a.RoleID,
+		UpdatedAt:    a.UpdatedAt,
+	}
+}
+
+func writeAssignment(w http.ResponseWriter, a *assignment.Assignment) error {
+	body := struct {
+		Assignment assignmentValue `json:"assignment"`
+	}{
+		Assignment: newAssignmentValue(a),
+	}
+
+	writeHeader(w)
+	return json.NewEncoder(w).Encode(&body)
+}
+
+func AssignmentCreate(w http.ResponseWriter, a *assignment.Assignment) error {
+	err := writeAssignment(w, a)
+	if err != nil {
+		return fmt.Errorf("http-handle/response.AssignmentCreate: %w", err)
+	}
+
+	return nil
+}
+
+func AssignmentRead(w http.ResponseWriter, a *assignment.Assignment) error {
+	err := writeAssignment(w, a)
+	if err != nil {
+		return fmt.Errorf("http-handle/response.AssignmentRead: %w", err)
+	}
+
+	return nil
+}
+
+func AssignmentList(w http.ResponseWriter, list []*assignment.Assignment) error {
+	body := struct {
+		Assignments []assignmentValue `json:"assignments"`
+	}{
+		Assignments: make([]assignmentValue, 0, len(list)),
+	}
+	for _, a := range list {
+		body.Assignments = append(body.Assignments, newAssignmentValue(a))
+	}
+
+	writeHeader(w)
+	err := json.NewEncoder(w).Encode(&body)
+	if err != nil {
+		return fmt.Errorf("http-handle/response.AssignmentList: %w", err)
+	}
+
+	return nil
+}
+
+func AssignmentDelete(w http.ResponseWriter) error {
+	body := struct {
+		Assignment struct{} `json:"assignment"`
+	}{}
+
+	writeHeader(w)
+	err := json.NewEncoder(w).Encode(&body)
+	if err != nil {
+		return fmt.Errorf("http-handle/response.AssignmentDelete: %w", err)
+	}
+
+	return nil
+}
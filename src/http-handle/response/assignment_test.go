@@ -0,0 +1,182 @@
+package response
+
+import (
+	"io"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"api.example.com/pkg/assignment"
+)
+
+func TestAssignmentRead(t *testing.T) {
+	type want struct {
+		statusCode  int
+		contentType string
+		body        []byte
+	}
+
+	type test struct {
+		name       string
+		assignment *assignment.Assignment
+		want       want
+		wantErr    bool
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			err := AssignmentRead(w, tt.assignment)
+			if tt.wantErr != (err != nil) {
+				t.Fatalf("want-error=%v, error=%v.", tt.wantErr, err)
+			}
+
+			got := w.Result()
+			defer got.Body.Close()
+
+			gotBody, _ := io.ReadAll(got.Body)
+			if !reflect.DeepEqual(tt.want.body, gotBody) {
+				t.Fatalf("body want=%s, got=%s.", tt.want.body, gotBody)
+			}
+
+			gotContentType := got.Header.Get("Content-Type")
+			if tt.want.contentType != gotContentType {
+				t.Fatalf("Content-Type want=%v, got=%v.", tt.want.contentType, gotContentType)
+			}
+
+			gotStatusCode := got.StatusCode
+			if tt.want.statusCode != gotStatusCode {
+				t.Fatalf("Status-Code want=%v, got=%v.", tt.want.statusCode, gotStatusCode)
+			}
+		})
+	}
+
+	tests := []*test{
+		{
+			name: "ok",
+			assignment: &assignment.Assignment{
+				ID:           5,
+				CompanyID:    1,
+				EmployeeID:   2,
+				DepartmentID: 3,
+				RoleID:       4,
+				UpdatedAt:    time.Date(2022, 9, 3, 12, 34, 56, 0, time.UTC),
+			},
+			want: want{
+				statusCode:  200,
+				contentType: "application/json",
+				body:        []byte(`{"assignment":{"id":5,"company_id":1,"employee_id":2,"department_id":3,"role_id":4,"updated_at":"2022-09-03T12:34:56Z"}}` + "\n"),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+}
+
+func TestAssignmentList(t *testing.T) {
+	type want struct {
+		statusCode  int
+		contentType string
+		body        []byte
+	}
+
+	type test struct {
+		name    string
+		list    []*assignment.Assignment
+		want    want
+		wantErr bool
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			err := AssignmentList(w, tt.list)
+			if tt.wantErr != (err != nil) {
+				t.Fatalf("want-error=%v, error=%v.", tt.wantErr, err)
+			}
+
+			got := w.Result()
+			defer got.Body.Close()
+
+			gotBody, _ := io.ReadAll(got.Body)
+			if !reflect.DeepEqual(tt.want.body, gotBody) {
+				t.Fatalf("body want=%s, got=%s.", tt.want.body, gotBody)
+			}
+
+			gotContentType := got.Header.Get("Content-Type")
+			if tt.want.contentType != gotContentType {
+				t.Fatalf("Content-Type want=%v, got=%v.", tt.want.contentType, gotContentType)
+			}
+
+			gotStatusCode := got.StatusCode
+			if tt.want.statusCode != gotStatusCode {
+				t.Fatalf("Status-Code want=%v, got=%v.", tt.want.statusCode, gotStatusCode)
+			}
+		})
+	}
+
+	tests := []*test{
+		{
+			name: "ok",
+			list: []*assignment.Assignment{
+				{
+					ID:           5,
+					CompanyID:    1,
+					EmployeeID:   2,
+					DepartmentID: 3,
+					RoleID:       4,
+					UpdatedAt:    time.Date(2022, 9, 3, 12, 34, 56, 0, time.UTC),
+				},
+				{
+					ID:           6,
+					CompanyID:    1,
+					EmployeeID:   2,
+					DepartmentID: 7,
+					RoleID:       4,
+					UpdatedAt:    time.Date(2022, 9, 4, 12, 34, 56, 0, time.UTC),
+				},
+			},
+			want: want{
+				statusCode:  200,
+				contentType: "application/json",
+				body: []byte(`{"assignments":[` +
+					`{"id":5,"company_id":1,"employee_id":2,"department_id":3,"role_id":4,"updated_at":"2022-09-03T12:34:56Z"},` +
+					`{"id":6,"company_id":1,"employee_id":2,"department_id":7,"role_id":4,"updated_at":"2022-09-04T12:34:56Z"}` +
+					`]}` + "\n"),
+			},
+		},
+		{
+			name: "empty",
+			list: []*assignment.Assignment{},
+			want: want{
+				statusCode:  200,
+				contentType: "application/json",
+				body:        []byte(`{"assignments":[]}` + "\n"),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+}
+
+func TestAssignmentDelete(t *testing.T) {
+	w := httptest.NewRecorder()
+	err := AssignmentDelete(w)
+	if err != nil {
+		t.Fatalf("error=%v.", err)
+	}
+
+	got := w.Result()
+	defer got.Body.Close()
+
+	gotBody, _ := io.ReadAll(got.Body)
+	want := []byte(`{"assignment":{}}` + "\n")
+	if !reflect.DeepEqual(want, gotBody) {
+		t.Fatalf("body want=%s, got=%s.", want, gotBody)
+	}
+}
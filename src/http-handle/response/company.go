@@ -8,25 +8,30 @@ import (
 
 	"api.example.com/pkg/company"
 	companies "api.example.com/pkg/company"
+	"api.example.com/pkg/page"
 )
 
-func WriteCompany(w http.ResponseWriter, company *companies.Company) error {
-	type value struct {
-		ID        companies.ID      `json:"id"`
-		Name      companies.Name    `json:"name"`
-		OwnerID   companies.OwnerID `json:"owner_id"`
-		UpdatedAt time.Time         `json:"updated_at"`
+type companyValue struct {
+	ID        companies.ID      `json:"id"`
+	Name      companies.Name    `json:"name"`
+	OwnerID   companies.OwnerID `json:"owner_id"`
+	UpdatedAt time.Time         `json:"updated_at"`
+}
+
+func newCompanyValue(c *companies.Company) companyValue {
+	return companyValue{
+		ID:        c.ID,
+		Name:      c.Name,
+		OwnerID:   c.OwnerID,
+		UpdatedAt: c.UpdatedAt,
 	}
+}
 
+func WriteCompany(w http.ResponseWriter, company *companies.Company) error {
 	body := struct {
-		Company value `json:"company"`
+		Company companyValue `json:"company"`
 	}{
-		Company: value{
-			ID:        company.ID,
-			Name:      company.Name,
-			OwnerID:   company.OwnerID,
-			UpdatedAt: company.UpdatedAt,
-		},
+		Company: newCompanyValue(company),
 	}
 
 	writeHeader(w)
@@ -41,3 +46,48 @@ func CompanyRead(w http.ResponseWriter, c *company.Company) error {
 
 	return nil
 }
+
+// 最後のページでは next_cursor が空
+func CompanyList(w http.ResponseWriter, p *company.Page) error {
+	body := struct {
+		Companies  []companyValue `json:"companies"`
+		NextCursor page.Cursor    `json:"next_cursor"`
+	}{
+		Companies:  make([]companyValue, 0, len(p.Companies)),
+		NextCursor: p.Next,
+	}
+	for _, c := range p.Companies {
+		body.Companies = append(body.Companies, newCompanyValue(c))
+	}
+
+	writeHeader(w)
+	err := json.NewEncoder(w).Encode(&body)
+	if err != nil {
+		return fmt.Errorf("http-handle/response.CompanyList: %w", err)
+	}
+
+	return nil
+}
+
+func CompanyUpdate(w http.ResponseWriter, c *company.Company) error {
+	err := WriteCompany(w, c)
+	if err != nil {
+		return fmt.Errorf("http-handle/response.CompanyUpdate: %w", err)
+	}
+
+	return nil
+}
+
+func CompanyDelete(w http.ResponseWriter) error {
+	body := struct {
+		Company struct{} `json:"company"`
+	}{}
+
+	writeHeader(w)
+	err := json.NewEncoder(w).Encode(&body)
+	if err != nil {
+		return fmt.Errorf("http-handle/response.CompanyDelete: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,105 @@
+package response
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"api.example.com/pkg/organization"
+)
+
+type departmentValue struct {
+	ID        organization.ID        `json:"id"`
+	CompanyID organization.CompanyID `json:"company_id"`
+	// 最上位の部署は null
+	ParentID  *organization.ID  `json:"parent_id"`
+	Name      organization.Name `json:"name"`
+	UpdatedAt time.Time         `json:"updated_at"`
+}
+
+func newDepartmentValue(d *organization.Department) departmentValue {
+	v := departmentValue{
+		ID:        d.ID,
+		CompanyID: d.CompanyID,
+		Name:      d.Name,
+		UpdatedAt: d.UpdatedAt,
+	}
+	if d.ParentID != organization.Root {
+		parentID := d.ParentID
+		v.ParentID = &parentID
+	}
+	return v
+}
+
+func writeDepartment(w http.ResponseWriter, d *organization.Department) error {
+	body := struct {
+		Department departmentValue `json:"department"`
+	}{
+		Department: newDepartmentValue(d),
+	}
+
+	writeHeader(w)
+	return json.NewEncoder(w).Encode(&body)
+}
+
+func DepartmentCreate(w http.ResponseWriter, d *organization.Department) error {
+	err := writeDepartment(w, d)
+	if err != nil {
+		return fmt.Errorf("http-handle/response.DepartmentCreate: %w", err)
+	}
+
+	return nil
+}
+
+func DepartmentRead(w http.ResponseWriter, d *organization.Department) error {
+	err := writeDepartment(w, d)
+	if err != nil {
+		return fmt.Errorf("http-handle/response.DepartmentRead: %w", err)
+	}
+
+	return nil
+}
+
+// rename, move
+func DepartmentUpdate(w http.ResponseWriter, d *organization.Department) error {
+	err := writeDepartment(w, d)
+	if err != nil {
+		return fmt.Errorf("http-handle/response.DepartmentUpdate: %w", err)
+	}
+
+	return nil
+}
+
+func DepartmentList(w http.ResponseWriter, list []*organization.Department) error {
+	body := struct {
+		Departments []departmentValue `json:"departments"`
+	}{
+		Departments: make([]departmentValue, 0, len(list)),
+	}
+	for _, d := range list {
+		body.Departments = append(body.Departments, newDepartmentValue(d))
+	}
+
+	writeHeader(w)
+	err := json.NewEncoder(w).Encode(&body)
+	if err != nil {
+		return fmt.Errorf("http-handle/response.DepartmentList: %w", err)
+	}
+
+	return nil
+}
+
+func DepartmentDelete(w http.ResponseWriter) error {
+	body := struct {
+		Department struct{} `json:"department"`
+	}{}
+
+	writeHeader(w)
+	err := json.NewEncoder(w).Encode(&body)
+	if err != nil {
+		return fmt.Errorf("http-handle/response.DepartmentDelete: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,119 @@
+package response
+
+import (
+	"io"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"api.example.com/pkg/organization"
+)
+
+func TestDepartmentRead(t *testing.T) {
+	type test struct {
+		name       string
+		department *organization.Department
+		want       []byte
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			err := DepartmentRead(w, tt.department)
+			if err != nil {
+				t.Fatalf("error=%v.", err)
+			}
+
+			got := w.Result()
+			defer got.Body.Close()
+
+			gotBody, _ := io.ReadAll(got.Body)
+			if !reflect.DeepEqual(tt.want, gotBody) {
+				t.Fatalf("body want=%s, got=%s.", tt.want, gotBody)
+			}
+
+			if got.Header.Get("Content-Type") != "application/json" {
+				t.Fatalf("Content-Type got=%v.", got.Header.Get("Content-Type"))
+			}
+		})
+	}
+
+	tests := []*test{
+		{
+			name: "root",
+			department: &organization.Department{
+				ID:        2,
+				CompanyID: 1,
+				ParentID:  organization.Root,
+				Name:      "開発部",
+				UpdatedAt: time.Date(2022, 9, 3, 12, 34, 56, 0, time.UTC),
+			},
+			want: []byte(`{"department":{"id":2,"company_id":1,"parent_id":null,"name":"開発部","updated_at":"2022-09-03T12:34:56Z"}}` + "\n"),
+		},
+		{
+			name: "child",
+			department: &organization.Department{
+				ID:        3,
+				CompanyID: 1,
+				ParentID:  2,
+				Name:      "開発一課",
+				UpdatedAt: time.Date(2022, 9, 3, 12, 34, 56, 0, time.UTC),
+			},
+			want: []byte(`{"department":{"id":3,"company_id":1,"parent_id":2,"name":"開発一課","updated_at":"2022-09-03T12:34:56Z"}}` + "\n"),
+		},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+}
+
+func TestDepartmentList(t *testing.T) {
+	type test struct {
+		name string
+		list []*organization.Department
+		want []byte
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			err := DepartmentList(w, tt.list)
+			if err != nil {
+				t.Fatalf("error=%v.", err)
+			}
+
+			got := w.Result()
+			defer got.Body.Close()
+
+			gotBody, _ := io.ReadAll(got.Body)
+			if !reflect.DeepEqual(tt.want, gotBody) {
+				t.Fatalf("body want=%s, got=%s.", tt.want, gotBody)
+			}
+		})
+	}
+
+	tests := []*test{
+		{
+			name: "ok",
+			list: []*organization.Department{
+				{ID: 2, CompanyID: 1, Name: "開発部", UpdatedAt: time.Date(2022, 9, 3, 12, 34, 56, 0, time.UTC)},
+				{ID: 3, CompanyID: 1, ParentID: 2, Name: "開発一課", UpdatedAt: time.Date(2022, 9, 3, 12, 34, 56, 0, time.UTC)},
+			},
+			want: []byte(`{"departments":[` +
+				`{"id":2,"company_id":1,"parent_id":null,"name":"開発部","updated_at":"2022-09-03T12:34:56Z"},` +
+				`{"id":3,"company_id":1,"parent_id":2,"name":"開発一課","updated_at":"2022-09-03T12:34:56Z"}` +
+				`]}` + "\n"),
+		},
+		{
+			name: "empty",
+			list: []*organization.Department{},
+			want: []byte(`{"departments":[]}` + "\n"),
+		},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+}
@@ -0,0 +1,90 @@
+package response
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"api.example.com/pkg/employee"
+)
+
+type employeeValue struct {
+	ID            employee.ID        `json:"id"`
+	CompanyID     employee.CompanyID `json:"company_id"`
+	UserID        employee.UserID    `json:"user_id"`
+	Administrator bool               `json:"administrator"`
+	UpdatedAt     time.Time          `json:"updated_at"`
+}
+
+func newEmployeeValue(e *employee.Employee) employeeValue {
+	return employeeValue{
+		ID:            e.ID,
+		CompanyID:     e.CompanyID,
+		UserID:        e.UserID,
+		Administrator: e.Administrator,
+		UpdatedAt:     e.UpdatedAt,
+	}
+}
+
+func writeEmployee(w http.ResponseWriter, e *employee.Employee) error {
+	body := struct {
+		Employee employeeValue `json:"employee"`
+	}{
+		Employee: newEmployeeValue(e),
+	}
+
+	writeHeader(w)
+	return json.NewEncoder(w).Encode(&body)
+}
+
+func EmployeeCreate(w http.ResponseWriter, e *employee.Employee) error {
+	err := writeEmployee(w, e)
+	if err != nil {
+		return fmt.Errorf("http-handle/response.EmployeeCreate: %w", err)
+	}
+
+	return nil
+}
+
+func EmployeeRead(w http.ResponseWriter, e *employee.Employee) error {
+	err := writeEmployee(w, e)
+	if err != nil {
+		return fmt.Errorf("http-handle/response.EmployeeRead: %w", err)
+	}
+
+	return nil
+}
+
+func EmployeeList(w http.ResponseWriter, list []*employee.Employee) error {
+	body := struct {
+		Employees []employeeValue `json:"employees"`
+	}{
+		Employees: make([]employeeValue, 0, len(list)),
+	}
+	for _, e := range list {
+		body.Employees = append(body.Employees, newEmployeeValue(e))
+	}
+
+	writeHeader(w)
+	err := json.NewEncoder(w).Encode(&body)
+	if err != nil {
+		return fmt.Errorf("http-handle/response.EmployeeList: %w", err)
+	}
+
+	return nil
+}
+
+func EmployeeDelete(w http.ResponseWriter) error {
+	body := struct {
+		Employee struct{} `json:"employee"`
+	}{}
+
+	writeHeader(w)
+	err := json.NewEncoder(w).Encode(&body)
+	if err != nil {
+		return fmt.Errorf("http-handle/response.EmployeeDelete: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,179 @@
+package response
+
+import (
+	"io"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"api.example.com/pkg/employee"
+)
+
+func TestEmployeeRead(t *testing.T) {
+	type want struct {
+		statusCode  int
+		contentType string
+		body        []byte
+	}
+
+	type test struct {
+		name     string
+		employee *employee.Employee
+		want     want
+		wantErr  bool
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			err := EmployeeRead(w, tt.employee)
+			if tt.wantErr != (err != nil) {
+				t.Fatalf("want-error=%v, error=%v.", tt.wantErr, err)
+			}
+
+			got := w.Result()
+			defer got.Body.Close()
+
+			gotBody, _ := io.ReadAll(got.Body)
+			if !reflect.DeepEqual(tt.want.body, gotBody) {
+				t.Fatalf("body want=%s, got=%s.", tt.want.body, gotBody)
+			}
+
+			gotContentType := got.Header.Get("Content-Type")
+			if tt.want.contentType != gotContentType {
+				t.Fatalf("Content-Type want=%v, got=%v.", tt.want.contentType, gotContentType)
+			}
+
+			gotStatusCode := got.StatusCode
+			if tt.want.statusCode != gotStatusCode {
+				t.Fatalf("Status-Code want=%v, got=%v.", tt.want.statusCode, gotStatusCode)
+			}
+		})
+	}
+
+	tests := []*test{
+		{
+			name: "ok",
+			employee: &employee.Employee{
+				ID:            3,
+				CompanyID:     1,
+				UserID:        2,
+				Administrator: true,
+				UpdatedAt:     time.Date(2022, 9, 3, 12, 34, 56, 0, time.UTC),
+			},
+			want: want{
+				statusCode:  200,
+				contentType: "application/json",
+				body:        []byte(`{"employee":{"id":3,"company_id":1,"user_id":2,"administrator":true,"updated_at":"2022-09-03T12:34:56Z"}}` + "\n"),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+}
+
+func TestEmployeeList(t *testing.T) {
+	type want struct {
+		statusCode  int
+		contentType string
+		body        []byte
+	}
+
+	type test struct {
+		name    string
+		list    []*employee.Employee
+		want    want
+		wantErr bool
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			err := EmployeeList(w, tt.list)
+			if tt.wantErr != (err != nil) {
+				t.Fatalf("want-error=%v, error=%v.", tt.wantErr, err)
+			}
+
+			got := w.Result()
+			defer got.Body.Close()
+
+			gotBody, _ := io.ReadAll(got.Body)
+			if !reflect.DeepEqual(tt.want.body, gotBody) {
+				t.Fatalf("body want=%s, got=%s.", tt.want.body, gotBody)
+			}
+
+			gotContentType := got.Header.Get("Content-Type")
+			if tt.want.contentType != gotContentType {
+				t.Fatalf("Content-Type want=%v, got=%v.", tt.want.contentType, gotContentType)
+			}
+
+			gotStatusCode := got.StatusCode
+			if tt.want.statusCode != gotStatusCode {
+				t.Fatalf("Status-Code want=%v, got=%v.", tt.want.statusCode, gotStatusCode)
+			}
+		})
+	}
+
+	tests := []*test{
+		{
+			name: "ok",
+			list: []*employee.Employee{
+				{
+					ID:            3,
+					CompanyID:     1,
+					UserID:        2,
+					Administrator: true,
+					UpdatedAt:     time.Date(2022, 9, 3, 12, 34, 56, 0, time.UTC),
+				},
+				{
+					ID:            4,
+					CompanyID:     1,
+					UserID:        5,
+					Administrator: false,
+					UpdatedAt:     time.Date(2022, 9, 4, 12, 34, 56, 0, time.UTC),
+				},
+			},
+			want: want{
+				statusCode:  200,
+				contentType: "application/json",
+				body: []byte(`{"employees":[` +
+					`{"id":3,"company_id":1,"user_id":2,"administrator":true,"updated_at":"2022-09-03T12:34:56Z"},` +
+					`{"id":4,"company_id":1,"user_id":5,"administrator":false,"updated_at":"2022-09-04T12:34:56Z"}` +
+					`]}` + "\n"),
+			},
+		},
+		{
+			name: "empty",
+			list: []*employee.Employee{},
+			want: want{
+				statusCode:  200,
+				contentType: "application/json",
+				body:        []byte(`{"employees":[]}` + "\n"),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+}
+
+func TestEmployeeDelete(t *testing.T) {
+	w := httptest.NewRecorder()
+	err := EmployeeDelete(w)
+	if err != nil {
+		t.Fatalf("error=%v.", err)
+	}
+
+	got := w.Result()
+	defer got.Body.Close()
+
+	gotBody, _ := io.ReadAll(got.Body)
+	want := []byte(`{"employee":{}}` + "\n")
+	if !reflect.DeepEqual(want, gotBody) {
+		t.Fatalf("body want=%s, got=%s.", want, gotBody)
+	}
+}
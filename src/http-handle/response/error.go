@@ -1,26 +1,96 @@
 package response
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
+
+	"api.example.com/pkg/failure"
 )
 
 func writeHeader(w http.ResponseWriter) {
 	w.Header().Set("Content-Type", "application/json")
 }
 
+// エラーの種類ごとのステータスコードと、呼び出し元が判定に使うコード
+func statusCode(err error) (int, string) {
+	// リクエストの期限切れ
+	if errors.Is(err, context.DeadlineExceeded) {
+		return http.StatusGatewayTimeout, "deadline_exceeded"
+	}
+
+	switch failure.Kind(err) {
+	case failure.ErrInvalidArgument:
+		return http.StatusBadRequest, "invalid_argument"
+	case failure.ErrNotFound:
+		return http.StatusNotFound, "not_found"
+	case failure.ErrConflict:
+		return http.StatusConflict, "conflict"
+	case failure.ErrUnauthenticated:
+		return http.StatusUnauthorized, "unauthenticated"
+	case failure.ErrForbidden:
+		return http.StatusForbidden, "forbidden"
+	default:
+		return http.StatusInternalServerError, "internal"
+	}
+}
+
+// 入力値や制約の違反 (どの項目を誤ったかを呼び出し元が判定する)
+type detail struct {
+	Field string       `json:"field"`
+	Rule  failure.Rule `json:"rule"`
+	// 長さや値の規則のみ
+	Limit  *int `json:"limit,omitempty"`
+	Actual *int `json:"actual,omitempty"`
+}
+
+func details(err error) []detail {
+	var ds []detail
+	for _, v := range failure.Violations(err) {
+		d := detail{
+			Field: v.Field,
+			Rule:  v.Rule,
+		}
+		if v.Rule.Bounded() {
+			limit, actual := v.Limit, v.Actual
+			d.Limit, d.Actual = &limit, &actual
+		}
+		ds = append(ds, d)
+	}
+	return ds
+}
+
+// 内部のエラーの詳細は返さない
 func Error(w http.ResponseWriter, err error) error {
-	type Error struct{}
+	type Error struct {
+		Code    string   `json:"code"`
+		Message string   `json:"message"`
+		Details []detail `json:"details,omitempty"`
+	}
+
+	status, code := statusCode(err)
+	message := failure.Message(err)
+	if status == http.StatusGatewayTimeout {
+		message = "request deadline exceeded"
+	}
+	if message == "" {
+		message = "internal server error"
+	}
 
 	res := struct {
 		Error Error `json:"error"`
 	}{
-		Error: Error{},
+		Error: Error{
+			Code:    code,
+			Message: message,
+			Details: details(err),
+		},
 	}
 
 	writeHeader(w)
-	w.WriteHeader(http.StatusInternalServerError) // TODO
+	w.WriteHeader(status)
 	err = json.NewEncoder(w).Encode(&res)
 	if err != nil {
 		return fmt.Errorf("http-handle/response.Error: %w", err)
@@ -1,12 +1,18 @@
 package response
 
 import (
+	"context"
 	"fmt"
 	"io"
 	"net/http"
 	"net/http/httptest"
 	"reflect"
 	"testing"
+
+	"api.example.com/pkg/auth"
+	"api.example.com/pkg/failure"
+	"api.example.com/pkg/role"
+	"api.example.com/pkg/user"
 )
 
 func TestError(t *testing.T) {
@@ -58,7 +64,116 @@ func TestError(t *testing.T) {
 			want: want{
 				statusCode:  http.StatusInternalServerError,
 				contentType: "application/json",
-				body:        []byte(`{"error":{}}` + "\n"),
+				body:        []byte(`{"error":{"code":"internal","message":"internal server error"}}` + "\n"),
+			},
+		},
+		{
+			testcase: "invalid argument",
+			err:      fmt.Errorf("error test: %w", failure.New(failure.ErrInvalidArgument, "invalid user_id")),
+			wantErr:  false,
+			want: want{
+				statusCode:  http.StatusBadRequest,
+				contentType: "application/json",
+				body:        []byte(`{"error":{"code":"invalid_argument","message":"invalid user_id"}}` + "\n"),
+			},
+		},
+		{
+			testcase: "invalid argument with details",
+			err: fmt.Errorf("error test: %w", failure.Invalid("invalid user", []failure.Violation{
+				{Field: "user.name", Rule: failure.RuleMaxLength, Limit: 255, Actual: 256},
+				{Field: "user.password", Rule: failure.RuleMinLength, Limit: 8, Actual: 3},
+			})),
+			wantErr: false,
+			want: want{
+				statusCode:  http.StatusBadRequest,
+				contentType: "application/json",
+				body: []byte(`{"error":{"code":"invalid_argument","message":"invalid user","details":[` +
+					`{"field":"user.name","rule":"max_length","limit":255,"actual":256},` +
+					`{"field":"user.password","rule":"min_length","limit":8,"actual":3}]}}` + "\n"),
+			},
+		},
+		{
+			testcase: "not found",
+			err:      fmt.Errorf("error test: %w: %v", failure.ErrNotFound, "sql: no rows in result set"),
+			wantErr:  false,
+			want: want{
+				statusCode:  http.StatusNotFound,
+				contentType: "application/json",
+				body:        []byte(`{"error":{"code":"not_found","message":"not found"}}` + "\n"),
+			},
+		},
+		{
+			testcase: "conflict",
+			err:      fmt.Errorf("error test: %w", role.ErrDuplicateName),
+			wantErr:  false,
+			want: want{
+				statusCode:  http.StatusConflict,
+				contentType: "application/json",
+				body:        []byte(`{"error":{"code":"conflict","message":"role name already exists"}}` + "\n"),
+			},
+		},
+		{
+			testcase: "conflict with details",
+			err:      fmt.Errorf("error test: %w", failure.Conflict("user.name already exists", "user.name", failure.RuleUnique)),
+			wantErr:  false,
+			want: want{
+				statusCode:  http.StatusConflict,
+				contentType: "application/json",
+				body: []byte(`{"error":{"code":"conflict","message":"user.name already exists","details":[` +
+					`{"field":"user.name","rule":"unique"}]}}` + "\n"),
+			},
+		},
+		{
+			testcase: "min length with actual 0",
+			err: fmt.Errorf("error test: %w", failure.Invalid("invalid user", []failure.Violation{
+				{Field: "user.name", Rule: failure.RuleMinLength, Limit: 1, Actual: 0},
+			})),
+			wantErr: false,
+			want: want{
+				statusCode:  http.StatusBadRequest,
+				contentType: "application/json",
+				body: []byte(`{"error":{"code":"invalid_argument","message":"invalid user","details":[` +
+					`{"field":"user.name","rule":"min_length","limit":1,"actual":0}]}}` + "\n"),
+			},
+		},
+		{
+			testcase: "deadline exceeded",
+			err:      fmt.Errorf("error test: %w", context.DeadlineExceeded),
+			wantErr:  false,
+			want: want{
+				statusCode:  http.StatusGatewayTimeout,
+				contentType: "application/json",
+				body:        []byte(`{"error":{"code":"deadline_exceeded","message":"request deadline exceeded"}}` + "\n"),
+			},
+		},
+		{
+			testcase: "unauthenticated",
+			err:      fmt.Errorf("error test: %w", auth.ErrUnauthenticated),
+			wantErr:  false,
+			want: want{
+				statusCode:  http.StatusUnauthorized,
+				contentType: "application/json",
+				body:        []byte(`{"error":{"code":"unauthenticated","message":"unauthenticated"}}` + "\n"),
+			},
+		},
+		{
+			testcase: "forbidden",
+			err:      fmt.Errorf("error test: %w", auth.ErrForbidden),
+			wantErr:  false,
+			want: want{
+				statusCode:  http.StatusForbidden,
+				contentType: "application/json",
+				body:        []byte(`{"error":{"code":"forbidden","message":"permission denied"}}` + "\n"),
+			},
+		},
+		{
+			testcase: "not the account owner",
+			err:      fmt.Errorf("error test: %w", user.ErrForbidden),
+			wantErr:  false,
+			want: want{
+				statusCode:  http.StatusForbidden,
+				contentType: "application/json",
+				body:        []byte(`{"error":{"code":"forbidden","message":"forbidden: not the account owner"}}` + "\n"),
 			},
 		},
 	}
@@ -0,0 +1,48 @@
+package response
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"api.example.com/pkg/permission"
+)
+
+func writePermissions(w http.ResponseWriter, list []permission.Permission) error {
+	body := struct {
+		Permissions []permission.Permission `json:"permissions"`
+	}{
+		Permissions: make([]permission.Permission, 0, len(list)),
+	}
+	body.Permissions = append(body.Permissions, list...)
+
+	writeHeader(w)
+	return json.NewEncoder(w).Encode(&body)
+}
+
+func PermissionList(w http.ResponseWriter, list []permission.Permission) error {
+	err := writePermissions(w, list)
+	if err != nil {
+		return fmt.Errorf("http-handle/response.PermissionList: %w", err)
+	}
+
+	return nil
+}
+
+func PermissionGrant(w http.ResponseWriter, list []permission.Permission) error {
+	err := writePermissions(w, list)
+	if err != nil {
+		return fmt.Errorf("http-handle/response.PermissionGrant: %w", err)
+	}
+
+	return nil
+}
+
+func PermissionRevoke(w http.ResponseWriter, list []permission.Permission) error {
+	err := writePermissions(w, list)
+	if err != nil {
+		return fmt.Errorf("http-handle/response.PermissionRevoke: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,97 @@
+package response
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"api.example.com/pkg/role"
+)
+
+type roleValue struct {
+	ID        role.ID        `json:"id"`
+	CompanyID role.CompanyID `json:"company_id"`
+	Name      role.Name      `json:"name"`
+	UpdatedAt time.Time      `json:"updated_at"`
+}
+
+func newRoleValue(r *role.Role) roleValue {
+	return roleValue{
+		ID:        r.ID,
+		CompanyID: r.CompanyID,
+		Name:      r.Name,
+		UpdatedAt: r.UpdatedAt,
+	}
+}
+
+func writeRole(w http.ResponseWriter, r *role.Role) error {
+	body := struct {
+		Role roleValue `json:"role"`
+	}{
+		Role: newRoleValue(r),
+	}
+
+	writeHeader(w)
+	return json.NewEncoder(w).Encode(&body)
+}
+
+func RoleCreate(w http.ResponseWriter, r *role.Role) error {
+	err := writeRole(w, r)
+	if err != nil {
+		return fmt.Errorf("http-handle/response.RoleCreate: %w", err)
+	}
+
+	return nil
+}
+
+func RoleRead(w http.ResponseWriter, r *role.Role) error {
+	err := writeRole(w, r)
+	if err != nil {
+		return fmt.Errorf("http-handle/response.RoleRead: %w", err)
+	}
+
+	return nil
+}
+
+func RoleList(w http.ResponseWriter, list []*role.Role) error {
+	body := struct {
+		Roles []roleValue `json:"roles"`
+	}{
+		Roles: make([]roleValue, 0, len(list)),
+	}
+	for _, r := range list {
+		body.Roles = append(body.Roles, newRoleValue(r))
+	}
+
+	writeHeader(w)
+	err := json.NewEncoder(w).Encode(&body)
+	if err != nil {
+		return fmt.Errorf("http-handle/response.RoleList: %w", err)
+	}
+
+	return nil
+}
+
+func RoleUpdate(w http.ResponseWriter, r *role.Role) error {
+	err := writeRole(w, r)
+	if err != nil {
+		return fmt.Errorf("http-handle/response.RoleUpdate: %w", err)
+	}
+
+	return nil
+}
+
+func RoleDelete(w http.ResponseWriter) error {
+	body := struct {
+		Role struct{} `json:"role"`
+	}{}
+
+	writeHeader(w)
+	err := json.NewEncoder(w).Encode(&body)
+	if err != nil {
+		return fmt.Errorf("http-handle/response.RoleDelete: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,176 @@
+package response
+
+import (
+	"io"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"api.example.com/pkg/role"
+)
+
+func TestRoleRead(t *testing.T) {
+	type want struct {
+		statusCode  int
+		contentType string
+		body        []byte
+	}
+
+	type test struct {
+		name    string
+		role    *role.Role
+		want    want
+		wantErr bool
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			err := RoleRead(w, tt.role)
+			if tt.wantErr != (err != nil) {
+				t.Fatalf("want-error=%v, error=%v.", tt.wantErr, err)
+			}
+
+			got := w.Result()
+			defer got.Body.Close()
+
+			gotBody, _ := io.ReadAll(got.Body)
+			if !reflect.DeepEqual(tt.want.body, gotBody) {
+				t.Fatalf("body want=%s, got=%s.", tt.want.body, gotBody)
+			}
+
+			gotContentType := got.Header.Get("Content-Type")
+			if tt.want.contentType != gotContentType {
+				t.Fatalf("Content-Type want=%v, got=%v.", tt.want.contentType, gotContentType)
+			}
+
+			gotStatusCode := got.StatusCode
+			if tt.want.statusCode != gotStatusCode {
+				t.Fatalf("Status-Code want=%v, got=%v.", tt.want.statusCode, gotStatusCode)
+			}
+		})
+	}
+
+	tests := []*test{
+		{
+			name: "ok",
+			role: &role.Role{
+				ID:        3,
+				CompanyID: 1,
+				Name:      "部長",
+				UpdatedAt: time.Date(2022, 9, 3, 12, 34, 56, 0, time.UTC),
+			},
+			want: want{
+				statusCode:  200,
+				contentType: "application/json",
+				body:        []byte(`{"role":{"id":3,"company_id":1,"name":"部長","updated_at":"2022-09-03T12:34:56Z"}}` + "\n"),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+}
+
+func TestRoleList(t *testing.T) {
+	type want struct {
+		statusCode  int
+		contentType string
+		body        []byte
+	}
+
+	type test struct {
+		name    string
+		list    []*role.Role
+		want    want
+		wantErr bool
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			err := RoleList(w, tt.list)
+			if tt.wantErr != (err != nil) {
+				t.Fatalf("want-error=%v, error=%v.", tt.wantErr, err)
+			}
+
+			got := w.Result()
+			defer got.Body.Close()
+
+			gotBody, _ := io.ReadAll(got.Body)
+			if !reflect.DeepEqual(tt.want.body, gotBody) {
+				t.Fatalf("body want=%s, got=%s.", tt.want.body, gotBody)
+			}
+
+			gotContentType := got.Header.Get("Content-Type")
+			if tt.want.contentType != gotContentType {
+				t.Fatalf("Content-Type want=%v, got=%v.", tt.want.contentType, gotContentType)
+			}
+
+			gotStatusCode := got.StatusCode
+			if tt.want.statusCode != gotStatusCode {
+				t.Fatalf("Status-Code want=%v, got=%v.", tt.want.statusCode, gotStatusCode)
+			}
+		})
+	}
+
+	tests := []*test{
+		{
+			name: "ok",
+			list: []*role.Role{
+				{
+					ID:        3,
+					CompanyID: 1,
+					Name:      "部長",
+					UpdatedAt: time.Date(2022, 9, 3, 12, 34, 56, 0, time.UTC),
+				},
+				{
+					ID:        4,
+					CompanyID: 1,
+					Name:      "課長",
+					UpdatedAt: time.Date(2022, 9, 4, 12, 34, 56, 0, time.UTC),
+				},
+			},
+			want: want{
+				statusCode:  200,
+				contentType: "application/json",
+				body: []byte(`{"roles":[` +
+					`{"id":3,"company_id":1,"name":"部長","updated_at":"2022-09-03T12:34:56Z"},` +
+					`{"id":4,"company_id":1,"name":"課長","updated_at":"2022-09-04T12:34:56Z"}` +
+					`]}` + "\n"),
+			},
+		},
+		{
+			name: "empty",
+			list: []*role.Role{},
+			want: want{
+				statusCode:  200,
+				contentType: "application/json",
+				body:        []byte(`{"roles":[]}` + "\n"),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+}
+
+func TestRoleDelete(t *testing.T) {
+	w := httptest.NewRecorder()
+	err := RoleDelete(w)
+	if err != nil {
+		t.Fatalf("error=%v.", err)
+	}
+
+	got := w.Result()
+	defer got.Body.Close()
+
+	gotBody, _ := io.ReadAll(got.Body)
+	want := []byte(`{"role":{}}` + "\n")
+	if !reflect.DeepEqual(want, gotBody) {
+		t.Fatalf("body want=%s, got=%s.", want, gotBody)
+	}
+}
@@ -0,0 +1,78 @@
+package response
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"api.example.com/pkg/session"
+)
+
+// ブラウザ向けにセッションIDを保存する Cookie
+const sessionCookie = "session_id"
+
+func SessionCreate(w http.ResponseWriter, s *session.Session) error {
+	type Session struct {
+		ID        session.ID     `json:"id"`
+		UserID    session.UserID `json:"user_id"`
+		Token     session.Token  `json:"token"`
+		ExpiresAt time.Time      `json:"expires_at"`
+	}
+
+	body := struct {
+		Session Session `json:"session"`
+	}{
+		Session: Session{
+			ID:        s.ID,
+			UserID:    s.UserID,
+			Token:     s.Token,
+			ExpiresAt: s.ExpiresAt,
+		},
+	}
+
+	http.SetCookie(w, &http.Cookie{
+		Name:     sessionCookie,
+		Value:    string(s.ID),
+		Path:     "/",
+		Expires:  s.ExpiresAt,
+		HttpOnly: true,
+		SameSite: http.SameSiteLaxMode,
+	})
+	writeHeader(w)
+	err := json.NewEncoder(w).Encode(&body)
+	if err != nil {
+		return fmt.Errorf("http-handle/response.SessionCreate: %w", err)
+	}
+
+	return nil
+}
+
+func SessionDelete(w http.ResponseWriter) error {
+	body := struct {
+		Session struct{} `json:"session"`
+	}{}
+
+	// Cookie も破棄する
+	SessionExpire(w)
+	writeHeader(w)
+	err := json.NewEncoder(w).Encode(&body)
+	if err != nil {
+		return fmt.Errorf("http-handle/response.SessionDelete: %w", err)
+	}
+
+	return nil
+}
+
+// セッションIDの Cookie を破棄させる
+// 本文は書き込まないため、他のレスポンスの前に呼び出すこと
+func SessionExpire(w http.ResponseWriter) {
+	http.SetCookie(w, &http.Cookie{
+		Name:     sessionCookie,
+		Value:    "",
+		Path:     "/",
+		MaxAge:   -1,
+		HttpOnly: true,
+		SameSite: http.SameSiteLaxMode,
+	})
+}
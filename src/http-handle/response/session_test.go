@@ -0,0 +1,148 @@
+package response
+
+import (
+	"io"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"api.example.com/pkg/session"
+)
+
+func TestSessionCreate(t *testing.T) {
+	type want struct {
+		statusCode  int
+		contentType string
+		body        []byte
+		cookie      string
+	}
+
+	type test struct {
+		name    string
+		session *session.Session
+		want    want
+		wantErr bool
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			err := SessionCreate(w, tt.session)
+			if tt.wantErr != (err != nil) {
+				t.Fatalf("want-error=%v, error=%v.", tt.wantErr, err)
+			}
+
+			got := w.Result()
+			defer got.Body.Close()
+
+			gotBody, _ := io.ReadAll(got.Body)
+			if !reflect.DeepEqual(tt.want.body, gotBody) {
+				t.Fatalf("body want=%s, got=%s.", tt.want.body, gotBody)
+			}
+
+			gotContentType := got.Header.Get("Content-Type")
+			if tt.want.contentType != gotContentType {
+				t.Fatalf("Content-Type want=%v, got=%v.", tt.want.contentType, gotContentType)
+			}
+
+			gotCookie := got.Header.Get("Set-Cookie")
+			if tt.want.cookie != gotCookie {
+				t.Fatalf("Set-Cookie want=%v, got=%v.", tt.want.cookie, gotCookie)
+			}
+
+			gotStatusCode := got.StatusCode
+			if tt.want.statusCode != gotStatusCode {
+				t.Fatalf("Status-Code want=%v, got=%v.", tt.want.statusCode, gotStatusCode)
+			}
+		})
+	}
+
+	tests := []*test{
+		{
+			name: "ok",
+			session: &session.Session{
+				ID:        "sid",
+				UserID:    1,
+				Token:     "header.payload.signature",
+				ExpiresAt: time.Date(2022, 9, 3, 12, 34, 56, 0, time.UTC),
+			},
+			want: want{
+				statusCode:  200,
+				contentType: "application/json",
+				body:        []byte(`{"session":{"id":"sid","user_id":1,"token":"header.payload.signature","expires_at":"2022-09-03T12:34:56Z"}}` + "\n"),
+				cookie:      "session_id=sid; Path=/; Expires=Sat, 03 Sep 2022 12:34:56 GMT; HttpOnly; SameSite=Lax",
+			},
+			wantErr: false,
+		},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+}
+
+func TestSessionDelete(t *testing.T) {
+	type want struct {
+		statusCode  int
+		contentType string
+		body        []byte
+		cookie      string
+	}
+
+	type test struct {
+		name    string
+		want    want
+		wantErr bool
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			err := SessionDelete(w)
+			if tt.wantErr != (err != nil) {
+				t.Fatalf("want-error=%v, error=%v.", tt.wantErr, err)
+			}
+
+			got := w.Result()
+			defer got.Body.Close()
+
+			gotBody, _ := io.ReadAll(got.Body)
+			if !reflect.DeepEqual(tt.want.body, gotBody) {
+				t.Fatalf("body want=%s, got=%s.", tt.want.body, gotBody)
+			}
+
+			gotContentType := got.Header.Get("Content-Type")
+			if tt.want.contentType != gotContentType {
+				t.Fatalf("Content-Type want=%v, got=%v.", tt.want.contentType, gotContentType)
+			}
+
+			gotCookie := got.Header.Get("Set-Cookie")
+			if tt.want.cookie != gotCookie {
+				t.Fatalf("Set-Cookie want=%v, got=%v.", tt.want.cookie, gotCookie)
+			}
+
+			gotStatusCode := got.StatusCode
+			if tt.want.statusCode != gotStatusCode {
+				t.Fatalf("Status-Code want=%v, got=%v.", tt.want.statusCode, gotStatusCode)
+			}
+		})
+	}
+
+	tests := []*test{
+		{
+			name: "ok",
+			want: want{
+				statusCode:  200,
+				contentType: "application/json",
+				body:        []byte(`{"session":{}}` + "\n"),
+				cookie:      "session_id=; Path=/; Max-Age=0; HttpOnly; SameSite=Lax",
+			},
+			wantErr: false,
+		},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+}
@@ -1,28 +1,34 @@
 package response
 
 import (
+	"api.example.com/pkg/page"
 	"api.example.com/pkg/user"
 	"encoding/json"
 	"fmt"
 	"net/http"
 )
 
-// user response
-func writeUser(w http.ResponseWriter, u *user.User) error {
-	type User struct {
-		ID       user.ID   `json:"id"`
-		Name     user.Name `json:"name"`
-		Password string    `json:"password"`
+type userValue struct {
+	ID       user.ID   `json:"id"`
+	Name     user.Name `json:"name"`
+	Password string    `json:"password"`
+}
+
+// パスワードは返さない
+func newUserValue(u *user.User) userValue {
+	return userValue{
+		ID:       u.ID,
+		Name:     u.Name,
+		Password: "*****",
 	}
+}
 
+// user response
+func writeUser(w http.ResponseWriter, u *user.User) error {
 	body := struct {
-		User `json:"user"`
+		User userValue `json:"user"`
 	}{
-		User: User{
-			ID:       u.ID,
-			Name:     u.Name,
-			Password: "*****",
-		},
+		User: newUserValue(u),
 	}
 
 	writeHeader(w)
@@ -47,6 +53,28 @@ func UserRead(w http.ResponseWriter, u *user.User) error {
 	return nil
 }
 
+// 最後のページでは next_cursor が空
+func UserList(w http.ResponseWriter, p *user.Page) error {
+	body := struct {
+		Users      []userValue `json:"users"`
+		NextCursor page.Cursor `json:"next_cursor"`
+	}{
+		Users:      make([]userValue, 0, len(p.Users)),
+		NextCursor: p.Next,
+	}
+	for _, u := range p.Users {
+		body.Users = append(body.Users, newUserValue(u))
+	}
+
+	writeHeader(w)
+	err := json.NewEncoder(w).Encode(&body)
+	if err != nil {
+		return fmt.Errorf("http-handle/reponse.UserList: %w", err)
+	}
+
+	return nil
+}
+
 func UserUpdate(w http.ResponseWriter, u *user.User) error {
 	err := writeUser(w, u)
 	if err != nil {
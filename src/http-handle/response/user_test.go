@@ -177,6 +177,80 @@ func TestUserRead(t *testing.T) {
 	}
 }
 
+func TestUserList(t *testing.T) {
+	type want struct {
+		statusCode  int
+		contentType string
+		body        []byte
+	}
+
+	type test struct {
+		name    string
+		page    *user.Page
+		want    want
+		wantErr bool
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			err := UserList(w, tt.page)
+			if tt.wantErr != (err != nil) {
+				t.Fatalf("want-error=%v, error=%v.", tt.wantErr, err)
+			}
+
+			got := w.Result()
+			defer got.Body.Close()
+
+			gotBody, _ := io.ReadAll(got.Body)
+			if !reflect.DeepEqual(tt.want.body, gotBody) {
+				t.Fatalf("body want=%s, got=%s.", tt.want.body, gotBody)
+			}
+
+			gotContentType := got.Header.Get("Content-Type")
+			if tt.want.contentType != gotContentType {
+				t.Fatalf("Content-Type want=%v, got=%v.", tt.want.contentType, gotContentType)
+			}
+
+			gotStatusCode := got.StatusCode
+			if tt.want.statusCode != gotStatusCode {
+				t.Fatalf("Status-Code want=%v, got=%v.", tt.want.statusCode, gotStatusCode)
+			}
+		})
+	}
+
+	tests := []*test{
+		{
+			name: "ok",
+			page: &user.Page{
+				Users: []*user.User{
+					{ID: 1, Name: "bob", Password: mockPassword("qwerty")},
+					{ID: 2, Name: "bobby", Password: mockPassword("qwerty")},
+				},
+				Next: "Mg",
+			},
+			want: want{
+				statusCode:  http.StatusOK,
+				contentType: "application/json",
+				body:        []byte(`{"users":[{"id":1,"name":"bob","password":"*****"},{"id":2,"name":"bobby","password":"*****"}],"next_cursor":"Mg"}` + "\n"),
+			},
+		},
+		{
+			name: "empty",
+			page: &user.Page{},
+			want: want{
+				statusCode:  http.StatusOK,
+				contentType: "application/json",
+				body:        []byte(`{"users":[],"next_cursor":""}` + "\n"),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+}
+
 func TestUserUpdate(t *testing.T) {
 	type want struct {
 		statusCode  int
@@ -0,0 +1,147 @@
+package handle
+
+import (
+	"log"
+	"net/http"
+
+	"api.example.com/http-handle/request"
+	"api.example.com/http-handle/response"
+	"api.example.com/pkg/role"
+)
+
+type roleHandler struct {
+	server role.Server
+}
+
+func newRoleHandler(s role.Server) *roleHandler {
+	return &roleHandler{s}
+}
+
+func (h *roleHandler) handleRoles(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		h.list(w, r)
+	case http.MethodPost:
+		h.create(w, r)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func (h *roleHandler) handleRole(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		h.read(w, r)
+	case http.MethodPut:
+		h.update(w, r)
+	case http.MethodDelete:
+		h.delete(w, r)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func (h *roleHandler) create(w http.ResponseWriter, r *http.Request) {
+	role, err := request.RoleCreate(r)
+	if err != nil {
+		log.Println(err)
+		response.Error(w, err)
+		return
+	}
+
+	role, err = h.server.Create(r.Context(), role)
+	if err != nil {
+		log.Println(err)
+		response.Error(w, err)
+		return
+	}
+
+	err = response.RoleCreate(w, role)
+	if err != nil {
+		log.Println(err)
+	}
+}
+
+func (h *roleHandler) list(w http.ResponseWriter, r *http.Request) {
+	companyID, err := request.RoleList(r)
+	if err != nil {
+		log.Println(err)
+		response.Error(w, err)
+		return
+	}
+
+	list, err := h.server.List(r.Context(), companyID)
+	if err != nil {
+		log.Println(err)
+		response.Error(w, err)
+		return
+	}
+
+	err = response.RoleList(w, list)
+	if err != nil {
+		log.Println(err)
+	}
+}
+
+func (h *roleHandler) read(w http.ResponseWriter, r *http.Request) {
+	companyID, roleID, err := request.RoleRead(r)
+	if err != nil {
+		log.Println(err)
+		response.Error(w, err)
+		return
+	}
+
+	role, err := h.server.Read(r.Context(), companyID, roleID)
+	if err != nil {
+		log.Println(err)
+		response.Error(w, err)
+		return
+	}
+
+	err = response.RoleRead(w, role)
+	if err != nil {
+		log.Println(err)
+	}
+}
+
+func (h *roleHandler) update(w http.ResponseWriter, r *http.Request) {
+	role, err := request.RoleUpdate(r)
+	if err != nil {
+		log.Println(err)
+		response.Error(w, err)
+		return
+	}
+
+	role, err = h.server.Update(r.Context(), role)
+	if err != nil {
+		log.Println(err)
+		response.Error(w, err)
+		return
+	}
+
+	err = response.RoleUpdate(w, role)
+	if err != nil {
+		log.Println(err)
+	}
+}
+
+func (h *roleHandler) delete(w http.ResponseWriter, r *http.Request) {
+	companyID, roleID, err := request.RoleDelete(r)
+	if err != nil {
+		log.Println(err)
+		response.Error(w, err)
+		return
+	}
+
+	err = h.server.Delete(r.Context(), companyID, roleID)
+	if err != nil {
+		log.Println(err)
+		response.Error(w, err)
+		return
+	}
+
+	err = response.RoleDelete(w)
+	if err != nil {
+		log.Println(err)
+	}
+}
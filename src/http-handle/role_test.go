@@ -0,0 +1,311 @@
+package handle
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"api.example.com/pkg/failure"
+	"api.example.com/pkg/role"
+)
+
+// mock
+type roleServer struct {
+	role  *role.Role
+	roles []*role.Role
+	err   error
+	// flag
+	create bool
+	read   bool
+	list   bool
+	update bool
+	delete bool
+}
+
+func (s *roleServer) Create(context.Context, *role.Role) (*role.Role, error) {
+	if s.create {
+		return s.role, s.err
+	}
+
+	panic("invalid Create")
+}
+
+func (s *roleServer) Read(context.Context, role.CompanyID, role.ID) (*role.Role, error) {
+	if s.read {
+		return s.role, s.err
+	}
+
+	panic("invalid Read")
+}
+
+func (s *roleServer) List(context.Context, role.CompanyID) ([]*role.Role, error) {
+	if s.list {
+		return s.roles, s.err
+	}
+
+	panic("invalid List")
+}
+
+func (s *roleServer) Update(context.Context, *role.Role) (*role.Role, error) {
+	if s.update {
+		return s.role, s.err
+	}
+
+	panic("invalid Update")
+}
+
+func (s *roleServer) Delete(context.Context, role.CompanyID, role.ID) error {
+	if s.delete {
+		return s.err
+	}
+
+	panic("invalid Delete")
+}
+
+func TestRoleHandler(t *testing.T) {
+	type args struct {
+		method string
+		url    string
+		body   []byte
+	}
+
+	type want struct {
+		statusCode  int
+		contentType string
+		body        []byte
+	}
+
+	type test struct {
+		name   string
+		args   args
+		server *roleServer
+		want   want
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(tt.args.method, tt.args.url, bytes.NewBuffer(tt.args.body))
+			w := httptest.NewRecorder()
+
+			s := newServices()
+			s.Role = tt.server
+
+			New(s).ServeHTTP(w, r)
+
+			got := w.Result()
+			defer got.Body.Close()
+
+			gotBody, _ := io.ReadAll(got.Body)
+			if !reflect.DeepEqual(tt.want.body, gotBody) {
+				t.Fatalf("body want=%s, got=%s.", tt.want.body, gotBody)
+			}
+
+			gotContentType := got.Header.Get("Content-Type")
+			if tt.want.contentType != gotContentType {
+				t.Fatalf("Content-Type want=%v, got=%v.", tt.want.contentType, gotContentType)
+			}
+
+			gotStatusCode := got.StatusCode
+			if tt.want.statusCode != gotStatusCode {
+				t.Fatalf("Status-Code want=%v, got=%v.", tt.want.statusCode, gotStatusCode)
+			}
+		})
+	}
+
+	e := &role.Role{
+		ID:        3,
+		CompanyID: 1,
+		Name:      "部長",
+		UpdatedAt: time.Date(2022, 9, 3, 12, 34, 56, 0, time.UTC),
+	}
+	body := `{"role":{"id":3,"company_id":1,"name":"部長","updated_at":"2022-09-03T12:34:56Z"}}` + "\n"
+
+	tests := []*test{
+		{
+			name: "create ok",
+			args: args{
+				method: http.MethodPost,
+				url:    "http://api.example.com/company/1/role",
+				body:   []byte(`{"role":{"name":"部長"}}`),
+			},
+			server: &roleServer{role: e, create: true},
+			want: want{
+				statusCode:  200,
+				contentType: "application/json",
+				body:        []byte(body),
+			},
+		},
+		{
+			name: "create invalid request",
+			args: args{
+				method: http.MethodPost,
+				url:    "http://api.example.com/company/1/role",
+				body:   []byte(``),
+			},
+			server: &roleServer{},
+			want: want{
+				statusCode:  400,
+				contentType: "application/json",
+				body:        []byte(`{"error":{"code":"invalid_argument","message":"invalid argument"}}` + "\n"),
+			},
+		},
+		{
+			name: "create failed",
+			args: args{
+				method: http.MethodPost,
+				url:    "http://api.example.com/company/1/role",
+				body:   []byte(`{"role":{"name":"部長"}}`),
+			},
+			server: &roleServer{err: errors.New("error"), create: true},
+			want: want{
+				statusCode:  500,
+				contentType: "application/json",
+				body:        []byte(`{"error":{"code":"internal","message":"internal server error"}}` + "\n"),
+			},
+		},
+		{
+			name: "create duplicate name",
+			args: args{
+				method: http.MethodPost,
+				url:    "http://api.example.com/company/1/role",
+				body:   []byte(`{"role":{"name":"部長"}}`),
+			},
+			server: &roleServer{err: fmt.Errorf("test: %w", role.ErrDuplicateName), create: true},
+			want: want{
+				statusCode:  409,
+				contentType: "application/json",
+				body:        []byte(`{"error":{"code":"conflict","message":"role name already exists"}}` + "\n"),
+			},
+		},
+		{
+			name: "list ok",
+			args: args{
+				method: http.MethodGet,
+				url:    "http://api.example.com/company/1/role",
+			},
+			server: &roleServer{roles: []*role.Role{e}, list: true},
+			want: want{
+				statusCode:  200,
+				contentType: "application/json",
+				body:        []byte(`{"roles":[{"id":3,"company_id":1,"name":"部長","updated_at":"2022-09-03T12:34:56Z"}]}` + "\n"),
+			},
+		},
+		{
+			name: "list failed",
+			args: args{
+				method: http.MethodGet,
+				url:    "http://api.example.com/company/1/role",
+			},
+			server: &roleServer{err: errors.New("error"), list: true},
+			want: want{
+				statusCode:  500,
+				contentType: "application/json",
+				body:        []byte(`{"error":{"code":"internal","message":"internal server error"}}` + "\n"),
+			},
+		},
+		{
+			name: "read ok",
+			args: args{
+				method: http.MethodGet,
+				url:    "http://api.example.com/company/1/role/3",
+			},
+			server: &roleServer{role: e, read: true},
+			want: want{
+				statusCode:  200,
+				contentType: "application/json",
+				body:        []byte(body),
+			},
+		},
+		{
+			name: "read failed",
+			args: args{
+				method: http.MethodGet,
+				url:    "http://api.example.com/company/1/role/3",
+			},
+			server: &roleServer{err: errors.New("error"), read: true},
+			want: want{
+				statusCode:  500,
+				contentType: "application/json",
+				body:        []byte(`{"error":{"code":"internal","message":"internal server error"}}` + "\n"),
+			},
+		},
+		{
+			name: "read not found",
+			args: args{
+				method: http.MethodGet,
+				url:    "http://api.example.com/company/1/role/3",
+			},
+			server: &roleServer{err: fmt.Errorf("test: %w", failure.ErrNotFound), read: true},
+			want: want{
+				statusCode:  404,
+				contentType: "application/json",
+				body:        []byte(`{"error":{"code":"not_found","message":"not found"}}` + "\n"),
+			},
+		},
+		{
+			name: "update ok",
+			args: args{
+				method: http.MethodPut,
+				url:    "http://api.example.com/company/1/role/3",
+				body:   []byte(`{"role":{"name":"部長"}}`),
+			},
+			server: &roleServer{role: e, update: true},
+			want: want{
+				statusCode:  200,
+				contentType: "application/json",
+				body:        []byte(body),
+			},
+		},
+		{
+			name: "update failed",
+			args: args{
+				method: http.MethodPut,
+				url:    "http://api.example.com/company/1/role/3",
+				body:   []byte(`{"role":{"name":"部長"}}`),
+			},
+			server: &roleServer{err: errors.New("error"), update: true},
+			want: want{
+				statusCode:  500,
+				contentType: "application/json",
+				body:        []byte(`{"error":{"code":"internal","message":"internal server error"}}` + "\n"),
+			},
+		},
+		{
+			name: "delete ok",
+			args: args{
+				method: http.MethodDelete,
+				url:    "http://api.example.com/company/1/role/3",
+			},
+			server: &roleServer{delete: true},
+			want: want{
+				statusCode:  200,
+				contentType: "application/json",
+				body:        []byte(`{"role":{}}` + "\n"),
+			},
+		},
+		{
+			name: "delete failed",
+			args: args{
+				method: http.MethodDelete,
+				url:    "http://api.example.com/company/1/role/3",
+			},
+			server: &roleServer{err: errors.New("error"), delete: true},
+			want: want{
+				statusCode:  500,
+				contentType: "application/json",
+				body:        []byte(`{"error":{"code":"internal","message":"internal server error"}}` + "\n"),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+}
@@ -0,0 +1,128 @@
+package handle
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+
+	"api.example.com/http-handle/request"
+	"api.example.com/http-handle/response"
+	"api.example.com/pkg/auth"
+	"api.example.com/pkg/session"
+)
+
+type sessionHandler struct {
+	server session.Server
+}
+
+func newSessionHandler(s session.Server) *sessionHandler {
+	return &sessionHandler{s}
+}
+
+func (h *sessionHandler) handleSessions(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodPost:
+		h.create(w, r)
+	case http.MethodDelete:
+		h.delete(w, r)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+// 資格情報またはセッションIDを検証し、呼び出し元のセッションとユーザーを context に保存する
+// ログアウト済みや期限切れのセッションは匿名として扱い、認証が必要かは各経路で判断する
+func (h *sessionHandler) identify(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s, err := h.session(r)
+		if stale(err) {
+			log.Println(err)
+			response.SessionExpire(w)
+			s, err = nil, nil
+		}
+
+		if err != nil {
+			log.Println(err)
+			response.Error(w, err)
+			return
+		}
+
+		if s != nil {
+			ctx := session.NewContext(r.Context(), s)
+			r = r.WithContext(auth.WithCaller(ctx, s.UserID))
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// 失効したセッションを示す資格情報やセッションIDであるか
+func stale(err error) bool {
+	return errors.Is(err, session.ErrInvalidSession) ||
+		errors.Is(err, session.ErrInvalidToken) ||
+		errors.Is(err, session.ErrExpiredToken)
+}
+
+// 資格情報を優先する
+// どちらもなければ nil
+func (h *sessionHandler) session(r *http.Request) (*session.Session, error) {
+	token, ok, err := request.Token(r)
+	if err != nil {
+		return nil, err
+	}
+
+	if ok {
+		return h.server.Authenticate(r.Context(), token)
+	}
+
+	if id, ok := request.SessionID(r); ok {
+		return h.server.Resume(r.Context(), id)
+	}
+
+	return nil, nil
+}
+
+func (h *sessionHandler) create(w http.ResponseWriter, r *http.Request) {
+	name, password, err := request.SessionCreate(r)
+	if err != nil {
+		log.Println(err)
+		response.Error(w, err)
+		return
+	}
+
+	s, err := h.server.Login(r.Context(), name, password)
+	if err != nil {
+		log.Println(err)
+		response.Error(w, err)
+		return
+	}
+
+	err = response.SessionCreate(w, s)
+	if err != nil {
+		log.Println(err)
+	}
+}
+
+// 呼び出し元のセッションのみログアウトできる
+func (h *sessionHandler) delete(w http.ResponseWriter, r *http.Request) {
+	s, ok := session.FromContext(r.Context())
+	if !ok {
+		err := fmt.Errorf("http-handle.sessionHandler.delete: %w", auth.ErrUnauthenticated)
+		log.Println(err)
+		response.Error(w, err)
+		return
+	}
+
+	err := h.server.Logout(r.Context(), s.ID)
+	if err != nil {
+		log.Println(err)
+		response.Error(w, err)
+		return
+	}
+
+	err = response.SessionDelete(w)
+	if err != nil {
+		log.Println(err)
+	}
+}
@@ -0,0 +1,327 @@
+package handle
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"api.example.com/pkg/auth"
+	"api.example.com/pkg/session"
+	"api.example.com/pkg/user"
+)
+
+// mock
+type sessionServer struct {
+	session *session.Session
+	err     error
+	// flag
+	login, authenticate, resume, logout, cleanup bool
+}
+
+func (s *sessionServer) Login(context.Context, user.Name, user.PlainPassword) (*session.Session, error) {
+	if s.login {
+		return s.session, s.err
+	}
+
+	panic("invalid Login")
+}
+
+func (s *sessionServer) Authenticate(context.Context, session.Token) (*session.Session, error) {
+	if s.authenticate {
+		return s.session, s.err
+	}
+
+	panic("invalid Authenticate")
+}
+
+func (s *sessionServer) Resume(context.Context, session.ID) (*session.Session, error) {
+	if s.resume {
+		return s.session, s.err
+	}
+
+	panic("invalid Resume")
+}
+
+func (s *sessionServer) Logout(context.Context, session.ID) error {
+	if s.logout {
+		return s.err
+	}
+
+	panic("invalid Logout")
+}
+
+func (s *sessionServer) Cleanup(context.Context) error {
+	if s.cleanup {
+		return s.err
+	}
+
+	panic("invalid Cleanup")
+}
+
+func TestSessionHandler(t *testing.T) {
+	type args struct {
+		method string
+		url    string
+		header string
+		body   []byte
+	}
+
+	type want struct {
+		statusCode  int
+		contentType string
+		body        []byte
+	}
+
+	type test struct {
+		name   string
+		args   args
+		server *sessionServer
+		want   want
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(tt.args.method, tt.args.url, bytes.NewBuffer(tt.args.body))
+			if tt.args.header != "" {
+				r.Header.Set("Authorization", tt.args.header)
+			}
+			w := httptest.NewRecorder()
+
+			s := newServices()
+			s.Session = tt.server
+
+			New(s).ServeHTTP(w, r)
+
+			got := w.Result()
+			defer got.Body.Close()
+
+			gotBody, _ := io.ReadAll(got.Body)
+			if !reflect.DeepEqual(tt.want.body, gotBody) {
+				t.Fatalf("body want=%s, got=%s.", tt.want.body, gotBody)
+			}
+
+			gotContentType := got.Header.Get("Content-Type")
+			if tt.want.contentType != gotContentType {
+				t.Fatalf("Content-Type want=%v, got=%v.", tt.want.contentType, gotContentType)
+			}
+
+			gotStatusCode := got.StatusCode
+			if tt.want.statusCode != gotStatusCode {
+				t.Fatalf("Status-Code want=%v, got=%v.", tt.want.statusCode, gotStatusCode)
+			}
+		})
+	}
+
+	tests := []*test{
+		{
+			name: "create ok",
+			args: args{
+				method: http.MethodPost,
+				url:    "http://api.example.com/session",
+				body:   []byte(`{"session":{"name":"Bob","password":"password"}}`),
+			},
+			server: &sessionServer{session: &session.Session{ID: "sid", UserID: 1, Token: "token", ExpiresAt: time.Date(2022, 9, 3, 12, 34, 56, 0, time.UTC)}, login: true},
+			want: want{
+				statusCode:  200,
+				contentType: "application/json",
+				body:        []byte(`{"session":{"id":"sid","user_id":1,"token":"token","expires_at":"2022-09-03T12:34:56Z"}}` + "\n"),
+			},
+		},
+		{
+			name: "create invalid request",
+			args: args{
+				method: http.MethodPost,
+				url:    "http://api.example.com/session",
+				body:   []byte(``),
+			},
+			server: &sessionServer{},
+			want: want{
+				statusCode:  400,
+				contentType: "application/json",
+				body:        []byte(`{"error":{"code":"invalid_argument","message":"invalid argument"}}` + "\n"),
+			},
+		},
+		{
+			name: "create wrong password",
+			args: args{
+				method: http.MethodPost,
+				url:    "http://api.example.com/session",
+				body:   []byte(`{"session":{"name":"Bob","password":"qwerty"}}`),
+			},
+			server: &sessionServer{err: session.ErrInvalidCredentials, login: true},
+			want: want{
+				statusCode:  401,
+				contentType: "application/json",
+				body:        []byte(`{"error":{"code":"unauthenticated","message":"invalid name or password"}}` + "\n"),
+			},
+		},
+		{
+			name: "create failed",
+			args: args{
+				method: http.MethodPost,
+				url:    "http://api.example.com/session",
+				body:   []byte(`{"session":{"name":"Bob","password":"password"}}`),
+			},
+			server: &sessionServer{err: errors.New("error"), login: true},
+			want: want{
+				statusCode:  500,
+				contentType: "application/json",
+				body:        []byte(`{"error":{"code":"internal","message":"internal server error"}}` + "\n"),
+			},
+		},
+		{
+			name: "delete ok",
+			args: args{
+				method: http.MethodDelete,
+				url:    "http://api.example.com/session",
+				header: "Bearer token",
+			},
+			server: &sessionServer{session: &session.Session{ID: "sid", UserID: 1}, authenticate: true, logout: true},
+			want: want{
+				statusCode:  200,
+				contentType: "application/json",
+				body:        []byte(`{"session":{}}` + "\n"),
+			},
+		},
+		{
+			name: "delete without session",
+			args: args{
+				method: http.MethodDelete,
+				url:    "http://api.example.com/session",
+			},
+			server: &sessionServer{},
+			want: want{
+				statusCode:  401,
+				contentType: "application/json",
+				body:        []byte(`{"error":{"code":"unauthenticated","message":"unauthenticated"}}` + "\n"),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+}
+
+func TestSessionHandler_identify(t *testing.T) {
+	type test struct {
+		name   string
+		header string
+		cookie string
+		// 呼び出し元を名乗るだけのヘッダー
+		userID     string
+		server     *sessionServer
+		statusCode int
+		wantCaller session.UserID
+		// Cookie を破棄させるか
+		wantExpire bool
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "http://api.example.com/", nil)
+			if tt.header != "" {
+				r.Header.Set("Authorization", tt.header)
+			}
+			if tt.cookie != "" {
+				r.AddCookie(&http.Cookie{Name: "session_id", Value: tt.cookie})
+			}
+			if tt.userID != "" {
+				r.Header.Set("X-User-ID", tt.userID)
+			}
+			w := httptest.NewRecorder()
+
+			var gotCaller session.UserID
+			next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				gotCaller, _ = auth.CallerFrom(r.Context())
+			})
+			newSessionHandler(tt.server).identify(next).ServeHTTP(w, r)
+
+			if tt.statusCode != w.Code {
+				t.Fatalf("Status-Code want=%v, got=%v.", tt.statusCode, w.Code)
+			}
+
+			if tt.wantCaller != gotCaller {
+				t.Fatalf("caller want=%v, got=%v.", tt.wantCaller, gotCaller)
+			}
+
+			gotExpire := w.Header().Get("Set-Cookie") == "session_id=; Path=/; Max-Age=0; HttpOnly; SameSite=Lax"
+			if tt.wantExpire != gotExpire {
+				t.Fatalf("expire want=%v, got=%v.", tt.wantExpire, w.Header().Get("Set-Cookie"))
+			}
+		})
+	}
+
+	tests := []*test{
+		{
+			name:       "ok",
+			header:     "Bearer token",
+			server:     &sessionServer{session: &session.Session{ID: "sid", UserID: 2}, authenticate: true},
+			statusCode: http.StatusOK,
+			wantCaller: 2,
+		},
+		{
+			name:       "cookie",
+			cookie:     "sid",
+			server:     &sessionServer{session: &session.Session{ID: "sid", UserID: 3}, resume: true},
+			statusCode: http.StatusOK,
+			wantCaller: 3,
+		},
+		{
+			// 失効したセッションは匿名として扱う
+			name:       "logged out",
+			cookie:     "sid",
+			server:     &sessionServer{err: session.ErrInvalidSession, resume: true},
+			statusCode: http.StatusOK,
+			wantCaller: 0,
+			wantExpire: true,
+		},
+		{
+			name:       "failed resume",
+			cookie:     "sid",
+			server:     &sessionServer{err: errors.New("test error"), resume: true},
+			statusCode: http.StatusInternalServerError,
+			wantCaller: 0,
+		},
+		{
+			name:       "no header",
+			header:     "",
+			server:     &sessionServer{},
+			statusCode: http.StatusOK,
+			wantCaller: 0,
+		},
+		{
+			// 資格情報のないヘッダーでは呼び出し元を識別しない
+			name:       "user id header",
+			userID:     "1",
+			server:     &sessionServer{},
+			statusCode: http.StatusOK,
+			wantCaller: 0,
+		},
+		{
+			name:       "invalid scheme",
+			header:     "Basic Ym9iOnBhc3N3b3Jk",
+			server:     &sessionServer{},
+			statusCode: http.StatusUnauthorized,
+			wantCaller: 0,
+		},
+		{
+			name:       "invalid token",
+			header:     "Bearer token",
+			server:     &sessionServer{err: session.ErrInvalidToken, authenticate: true},
+			statusCode: http.StatusOK,
+			wantCaller: 0,
+			wantExpire: true,
+		},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+}
@@ -3,6 +3,7 @@ package handle
 import (
 	"api.example.com/http-handle/request"
 	"api.example.com/http-handle/response"
+	"api.example.com/pkg/auth"
 	"api.example.com/pkg/user"
 	"log"
 	"net/http"
@@ -16,12 +17,16 @@ func newUserHandler(s user.Server) *userHandler {
 	return &userHandler{s}
 }
 
-func (h *userHandler) handleUsers(w http.ResponseWriter, r *http.Request) {
-	switch r.Method {
-	case http.MethodPost:
-		h.create(w, r)
-	default:
-		http.NotFound(w, r)
+func (h *userHandler) handleUsers(list, create http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			list(w, r)
+		case http.MethodPost:
+			create(w, r)
+		default:
+			http.NotFound(w, r)
+		}
 	}
 }
 
@@ -46,7 +51,7 @@ func (h *userHandler) create(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	user, err = h.server.Create(user)
+	user, err = h.server.Create(r.Context(), user)
 	if err != nil {
 		log.Println(err)
 		response.Error(w, err)
@@ -67,7 +72,7 @@ func (h *userHandler) read(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	user, err := h.server.Read(userID)
+	user, err := h.server.Read(r.Context(), userID)
 	if err != nil {
 		log.Println(err)
 		response.Error(w, err)
@@ -80,36 +85,63 @@ func (h *userHandler) read(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// 呼び出し元と現在のパスワードによる本人確認
+func credential(r *http.Request, current user.PlainPassword) user.Credential {
+	caller, _ := auth.CallerFrom(r.Context())
+	return user.NewCredential(caller, current)
+}
+
+func (h *userHandler) list(w http.ResponseWriter, r *http.Request) {
+	query, err := request.UserList(r)
+	if err != nil {
+		log.Println(err)
+		response.Error(w, err)
+		return
+	}
+
+	page, err := h.server.List(r.Context(), query)
+	if err != nil {
+		log.Println(err)
+		response.Error(w, err)
+		return
+	}
+
+	err = response.UserList(w, page)
+	if err != nil {
+		log.Println(err)
+	}
+}
+
 func (h *userHandler) update(w http.ResponseWriter, r *http.Request) {
-	user, err := request.UserUpdate(r)
+	u, current, err := request.UserUpdate(r)
 	if err != nil {
 		log.Println(err)
 		response.Error(w, err)
 		return
 	}
 
-	user, err = h.server.Update(user)
+	u, err = h.server.Update(r.Context(), u, credential(r, current))
 	if err != nil {
 		log.Println(err)
 		response.Error(w, err)
 		return
 	}
 
-	err = response.UserUpdate(w, user)
+	err = response.UserUpdate(w, u)
 	if err != nil {
 		log.Println(err)
 	}
 }
 
 func (h *userHandler) delete(w http.ResponseWriter, r *http.Request) {
-	userID, err := request.UserDelete(r)
+	userID, current, err := request.UserDelete(r)
 	if err != nil {
 		log.Println(err)
 		response.Error(w, err)
 		return
 	}
 
-	err = h.server.Delete(userID)
+	err = h.server.Delete(r.Context(), userID, credential(r, current))
 	if err != nil {
 		log.Println(err)
 		response.Error(w, err)
@@ -1,9 +1,11 @@
 package handle
 
 import (
+	"api.example.com/pkg/page"
 	"api.example.com/pkg/user"
 	"api.example.com/pkg/user/password"
 	"bytes"
+	"context"
 	"errors"
 	"io"
 	"net/http"
@@ -15,12 +17,15 @@ import (
 // mock
 type userServer struct {
 	user *user.User
+	page *user.Page
 	err  error
 	// flags
-	create, read, update, delete bool
+	create, read, list, update, delete bool
+	// List に渡された条件
+	query user.ListQuery
 }
 
-func (s *userServer) Create(*user.User) (*user.User, error) {
+func (s *userServer) Create(context.Context, *user.User) (*user.User, error) {
 	if s.create {
 		return s.user, s.err
 	}
@@ -28,7 +33,7 @@ func (s *userServer) Create(*user.User) (*user.User, error) {
 	panic("invalid Create")
 }
 
-func (s *userServer) Read(user.ID) (*user.User, error) {
+func (s *userServer) Read(context.Context, user.ID) (*user.User, error) {
 	if s.read {
 		return s.user, s.err
 	}
@@ -36,7 +41,16 @@ func (s *userServer) Read(user.ID) (*user.User, error) {
 	panic("invalid Read")
 }
 
-func (s *userServer) Update(*user.User) (*user.User, error) {
+func (s *userServer) List(ctx context.Context, q user.ListQuery) (*user.Page, error) {
+	if s.list {
+		s.query = q
+		return s.page, s.err
+	}
+
+	panic("invalid List")
+}
+
+func (s *userServer) Update(context.Context, *user.User, user.Credential) (*user.User, error) {
 	if s.update {
 		return s.user, s.err
 	}
@@ -44,7 +58,7 @@ func (s *userServer) Update(*user.User) (*user.User, error) {
 	panic("invalid Update")
 }
 
-func (s *userServer) Delete(user.ID) error {
+func (s *userServer) Delete(context.Context, user.ID, user.Credential) error {
 	if s.delete {
 		return s.err
 	}
@@ -136,9 +150,9 @@ func TestUserHandler_create(t *testing.T) {
 				create: true,
 			},
 			want: want{
-				statusCode:  http.StatusInternalServerError,
+				statusCode:  http.StatusBadRequest,
 				contentType: "application/json",
-				body:        []byte(`{"error":{}}` + "\n"),
+				body:        []byte(`{"error":{"code":"invalid_argument","message":"invalid argument"}}` + "\n"),
 			},
 		},
 		{
@@ -154,7 +168,7 @@ func TestUserHandler_create(t *testing.T) {
 			want: want{
 				statusCode:  http.StatusInternalServerError,
 				contentType: "application/json",
-				body:        []byte(`{"error":{}}` + "\n"),
+				body:        []byte(`{"error":{"code":"internal","message":"internal server error"}}` + "\n"),
 			},
 		},
 	}
@@ -247,9 +261,9 @@ func TestUserHandler_read(t *testing.T) {
 				read: true,
 			},
 			want: want{
-				statusCode:  http.StatusInternalServerError,
+				statusCode:  http.StatusBadRequest,
 				contentType: "application/json",
-				body:        []byte(`{"error":{}}` + "\n"),
+				body:        []byte(`{"error":{"code":"invalid_argument","message":"invalid argument"}}` + "\n"),
 			},
 		},
 		{
@@ -265,7 +279,108 @@ func TestUserHandler_read(t *testing.T) {
 			want: want{
 				statusCode:  http.StatusInternalServerError,
 				contentType: "application/json",
-				body:        []byte(`{"error":{}}` + "\n"),
+				body:        []byte(`{"error":{"code":"internal","message":"internal server error"}}` + "\n"),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+}
+
+func TestUserHandler_list(t *testing.T) {
+	type want struct {
+		statusCode int
+		body       []byte
+		query      user.ListQuery
+	}
+
+	type test struct {
+		testcase string
+		url      string
+		server   *userServer
+		want     want
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.testcase, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, tt.url, nil)
+			w := httptest.NewRecorder()
+
+			s := newServices()
+			s.User = tt.server
+			New(s).ServeHTTP(w, r)
+
+			got := w.Result()
+			defer got.Body.Close()
+
+			gotBody, _ := io.ReadAll(got.Body)
+			if !reflect.DeepEqual(tt.want.body, gotBody) {
+				t.Fatalf("want=%s, got=%s.", tt.want.body, gotBody)
+			}
+
+			if tt.want.statusCode != got.StatusCode {
+				t.Fatalf("want=%v, got=%v.", tt.want.statusCode, got.StatusCode)
+			}
+
+			if tt.want.query != tt.server.query {
+				t.Fatalf("want=%v, got=%v.", tt.want.query, tt.server.query)
+			}
+		})
+	}
+
+	tests := []*test{
+		{
+			testcase: "ok",
+			url:      "/user?name_prefix=bo&cursor=Mg&limit=2",
+			server: &userServer{
+				page: &user.Page{
+					Users: []*user.User{
+						{ID: 3, Name: "bob", Password: password.FromHash([]byte("qwerty"))},
+						{ID: 4, Name: "bobby", Password: password.FromHash([]byte("qwerty"))},
+					},
+					Next: "NA",
+				},
+				list: true,
+			},
+			want: want{
+				statusCode: http.StatusOK,
+				body:       []byte(`{"users":[{"id":3,"name":"bob","password":"*****"},{"id":4,"name":"bobby","password":"*****"}],"next_cursor":"NA"}` + "\n"),
+				query:      user.ListQuery{NamePrefix: "bo", Query: page.Query{Cursor: "Mg", Limit: 2}},
+			},
+		},
+		{
+			testcase: "last page",
+			url:      "/user",
+			server: &userServer{
+				page: &user.Page{},
+				list: true,
+			},
+			want: want{
+				statusCode: http.StatusOK,
+				body:       []byte(`{"users":[],"next_cursor":""}` + "\n"),
+			},
+		},
+		{
+			testcase: "invalid limit",
+			url:      "/user?limit=xxx",
+			server:   &userServer{},
+			want: want{
+				statusCode: http.StatusBadRequest,
+				body:       []byte(`{"error":{"code":"invalid_argument","message":"invalid argument"}}` + "\n"),
+			},
+		},
+		{
+			testcase: "failed server-list",
+			url:      "/user",
+			server: &userServer{
+				err:  errors.New("internal server error"),
+				list: true,
+			},
+			want: want{
+				statusCode: http.StatusInternalServerError,
+				body:       []byte(`{"error":{"code":"internal","message":"internal server error"}}` + "\n"),
 			},
 		},
 	}
@@ -327,7 +442,7 @@ func TestUserHandler_update(t *testing.T) {
 		{
 			args: args{
 				url:  "/user/1",
-				body: []byte(`{"user":{"name":"bob","password":"*****"}}`),
+				body: []byte(`{"user":{"name":"bob","password":"*****","current_password":"qwerty"}}`),
 			},
 			server: &userServer{
 				user: &user.User{
@@ -358,9 +473,25 @@ func TestUserHandler_update(t *testing.T) {
 				update: true,
 			},
 			want: want{
-				statusCode:  http.StatusInternalServerError,
+				statusCode:  http.StatusBadRequest,
 				contentType: "application/json",
-				body:        []byte(`{"error":{}}` + "\n"),
+				body:        []byte(`{"error":{"code":"invalid_argument","message":"invalid argument"}}` + "\n"),
+			},
+		},
+		{
+			testcase: "not the account owner",
+			args: args{
+				url:  "/user/1",
+				body: []byte(`{"user":{"name":"bob","password":"*****","current_password":"wrong"}}`),
+			},
+			server: &userServer{
+				err:    user.ErrForbidden,
+				update: true,
+			},
+			want: want{
+				statusCode:  http.StatusForbidden,
+				contentType: "application/json",
+				body:        []byte(`{"error":{"code":"forbidden","message":"forbidden: not the account owner"}}` + "\n"),
 			},
 		},
 		{
@@ -376,7 +507,7 @@ func TestUserHandler_update(t *testing.T) {
 			want: want{
 				statusCode:  http.StatusInternalServerError,
 				contentType: "application/json",
-				body:        []byte(`{"error":{}}` + "\n"),
+				body:        []byte(`{"error":{"code":"internal","message":"internal server error"}}` + "\n"),
 			},
 		},
 	}
@@ -461,9 +592,25 @@ func TestUserHandler_delete(t *testing.T) {
 				delete: true,
 			},
 			want: want{
-				statusCode:  http.StatusInternalServerError,
+				statusCode:  http.StatusBadRequest,
+				contentType: "application/json",
+				body:        []byte(`{"error":{"code":"invalid_argument","message":"invalid argument"}}` + "\n"),
+			},
+		},
+		{
+			testcase: "not the account owner",
+			args: args{
+				url:  "/user/1",
+				body: []byte(`{"user":{"current_password":"wrong"}}`),
+			},
+			server: &userServer{
+				err:    user.ErrForbidden,
+				delete: true,
+			},
+			want: want{
+				statusCode:  http.StatusForbidden,
 				contentType: "application/json",
-				body:        []byte(`{"error":{}}` + "\n"),
+				body:        []byte(`{"error":{"code":"forbidden","message":"forbidden: not the account owner"}}` + "\n"),
 			},
 		},
 		{
@@ -479,7 +626,7 @@ func TestUserHandler_delete(t *testing.T) {
 			want: want{
 				statusCode:  http.StatusInternalServerError,
 				contentType: "application/json",
-				body:        []byte(`{"error":{}}` + "\n"),
+				body:        []byte(`{"error":{"code":"internal","message":"internal server error"}}` + "\n"),
 			},
 		},
 	}
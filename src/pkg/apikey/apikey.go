@@ -0,0 +1,172 @@
+package apikey
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"api.example.com/pkg/auth"
+	"api.example.com/pkg/company"
+	"api.example.com/pkg/failure"
+	"api.example.com/pkg/permission"
+	"api.example.com/pkg/user"
+)
+
+// API キーの ID
+type ID int
+
+func (id ID) Valid() bool {
+	return id > 0
+}
+
+// API キーを発行した会社
+type CompanyID = company.ID
+
+// 用途を示す名前
+type Name string
+
+// 1 ≤ name.length ≤ 255
+func (n Name) valid() bool {
+	l := len(n)
+	return l > 0 && l < 256
+}
+
+// API キーに許可する操作の範囲
+// どのスコープでも会社の情報は参照できる
+type Scope string
+
+const (
+	// 会社の情報の参照のみ
+	ScopeRead Scope = "read"
+	// 従業員の登録、削除、配置
+	ScopeManageUsers Scope = "manage_users"
+	// 会社情報、部署、肩書きの変更と権限の付与、剥奪
+	ScopeManageCompany Scope = "manage_company"
+)
+
+// 全てのスコープ
+var Scopes = []Scope{
+	ScopeRead,
+	ScopeManageUsers,
+	ScopeManageCompany,
+}
+
+func (s Scope) Valid() bool {
+	for _, v := range Scopes {
+		if s == v {
+			return true
+		}
+	}
+	return false
+}
+
+// スコープで許可される管理者の操作権限
+func (s Scope) permissions() []permission.Permission {
+	switch s {
+	case ScopeManageUsers:
+		return []permission.Permission{permission.ManageEmployees}
+	case ScopeManageCompany:
+		return []permission.Permission{permission.ManageCompany, permission.ManageDepartments, permission.ManageRoles}
+	default:
+		return nil
+	}
+}
+
+// 1つ以上の有効なスコープを重複なく持つこと
+func validScopes(list []Scope) bool {
+	if len(list) == 0 {
+		return false
+	}
+
+	seen := map[Scope]bool{}
+	for _, s := range list {
+		if !s.Valid() || seen[s] {
+			return false
+		}
+		seen[s] = true
+	}
+	return true
+}
+
+// クライアントに渡す API キー ("{id}.{secret}")
+// 作成と再発行の直後にのみ得られる
+type Token string
+
+func newToken(id ID, secret string) Token {
+	return Token(fmt.Sprintf("%d.%s", id, secret))
+}
+
+func (t Token) parse() (ID, string, bool) {
+	s := strings.SplitN(string(t), ".", 2)
+	if len(s) != 2 || s[1] == "" {
+		return 0, "", false
+	}
+
+	id, err := strconv.Atoi(s[0])
+	if err != nil || !ID(id).Valid() {
+		return 0, "", false
+	}
+
+	return ID(id), s[1], true
+}
+
+// 推測できない秘密の値
+// bcrypt が扱える 72 バイトに収める
+func newSecret() (string, error) {
+	b := make([]byte, 32)
+	_, err := rand.Read(b)
+	if err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+var (
+	// API キーが存在しない(失効済み)か、秘密の値が一致しない
+	ErrInvalidKey = failure.New(auth.ErrUnauthenticated, "invalid api key")
+	// スコープが空、不明、または重複している
+	ErrInvalidScope = failure.New(failure.ErrInvalidArgument, "invalid api key scope")
+)
+
+// 会社が発行した API キー
+type Key struct {
+	ID        ID
+	CompanyID CompanyID
+	Name      Name
+	Scopes    []Scope
+	// 秘密の値のハッシュ
+	// パスワードと同じく平文は保存しない
+	Secret user.Password
+	// 作成と再発行の直後のみ設定する
+	Token     Token
+	UpdatedAt time.Time
+}
+
+func New(companyID CompanyID, name Name, scopes []Scope) *Key {
+	return &Key{
+		CompanyID: companyID,
+		Name:      name,
+		Scopes:    scopes,
+	}
+}
+
+func (k *Key) validCreate() bool {
+	return k.CompanyID.Valid() && k.Name.valid()
+}
+
+// 呼び出し元としての API キー
+func (k *Key) grant() *auth.Key {
+	list := []permission.Permission{}
+	for _, s := range k.Scopes {
+		list = append(list, s.permissions()...)
+	}
+
+	return &auth.Key{
+		CompanyID:   k.CompanyID,
+		Permissions: list,
+	}
+}
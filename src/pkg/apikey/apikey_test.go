@@ -0,0 +1,225 @@
+package apikey
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"api.example.com/pkg/auth"
+	"api.example.com/pkg/permission"
+)
+
+func TestNew(t *testing.T) {
+	got := New(1, "batch", []Scope{ScopeRead})
+	want := &Key{CompanyID: 1, Name: "batch", Scopes: []Scope{ScopeRead}}
+	if !reflect.DeepEqual(want, got) {
+		t.Fatalf("want=%v, got=%v.", want, got)
+	}
+}
+
+func TestKey_validCreate(t *testing.T) {
+	type test struct {
+		name string
+		key  *Key
+		want bool
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.key.validCreate()
+			if tt.want != got {
+				t.Fatalf("want=%v, got=%v.", tt.want, got)
+			}
+		})
+	}
+
+	tests := []*test{
+		{
+			name: "ok",
+			key:  New(1, "batch", []Scope{ScopeRead}),
+			want: true,
+		},
+		{
+			name: "invalid company_id",
+			key:  New(0, "batch", []Scope{ScopeRead}),
+			want: false,
+		},
+		{
+			name: "empty name",
+			key:  New(1, "", []Scope{ScopeRead}),
+			want: false,
+		},
+		{
+			name: "too long name",
+			key:  New(1, Name(strings.Repeat("a", 256)), []Scope{ScopeRead}),
+			want: false,
+		},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+}
+
+func TestValidScopes(t *testing.T) {
+	type test struct {
+		name   string
+		scopes []Scope
+		want   bool
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.name, func(t *testing.T) {
+			got := validScopes(tt.scopes)
+			if tt.want != got {
+				t.Fatalf("want=%v, got=%v.", tt.want, got)
+			}
+		})
+	}
+
+	tests := []*test{
+		{
+			name:   "ok",
+			scopes: []Scope{ScopeRead, ScopeManageUsers, ScopeManageCompany},
+			want:   true,
+		},
+		{
+			name:   "empty",
+			scopes: []Scope{},
+			want:   false,
+		},
+		{
+			name:   "unknown",
+			scopes: []Scope{"manage_everything"},
+			want:   false,
+		},
+		{
+			name:   "duplicate",
+			scopes: []Scope{ScopeRead, ScopeRead},
+			want:   false,
+		},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+}
+
+func TestToken_parse(t *testing.T) {
+	type want struct {
+		id     ID
+		secret string
+		ok     bool
+	}
+
+	type test struct {
+		name  string
+		token Token
+		want  want
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.name, func(t *testing.T) {
+			var got want
+			got.id, got.secret, got.ok = tt.token.parse()
+			if !reflect.DeepEqual(tt.want, got) {
+				t.Fatalf("want=%v, got=%v.", tt.want, got)
+			}
+		})
+	}
+
+	tests := []*test{
+		{
+			name:  "ok",
+			token: newToken(12, "secret.with.dots"),
+			want:  want{id: 12, secret: "secret.with.dots", ok: true},
+		},
+		{
+			name:  "no secret",
+			token: "12.",
+			want:  want{},
+		},
+		{
+			name:  "no separator",
+			token: "12",
+			want:  want{},
+		},
+		{
+			name:  "invalid id",
+			token: "0.secret",
+			want:  want{},
+		},
+		{
+			name:  "not a number",
+			token: "abc.secret",
+			want:  want{},
+		},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+}
+
+func TestNewSecret(t *testing.T) {
+	a, err := newSecret()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	b, err := newSecret()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if a == b {
+		t.Fatalf("secret must be random: %v", a)
+	}
+
+	// bcrypt は 72 バイトまで
+	if len(a) > 72 {
+		t.Fatalf("too long secret: %d", len(a))
+	}
+}
+
+func TestKey_grant(t *testing.T) {
+	type test struct {
+		name string
+		key  *Key
+		want *auth.Key
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.key.grant()
+			if !reflect.DeepEqual(tt.want, got) {
+				t.Fatalf("want=%v, got=%v.", tt.want, got)
+			}
+		})
+	}
+
+	tests := []*test{
+		{
+			name: "read",
+			key:  New(1, "batch", []Scope{ScopeRead}),
+			want: &auth.Key{CompanyID: 1, Permissions: []permission.Permission{}},
+		},
+		{
+			name: "manage users",
+			key:  New(1, "batch", []Scope{ScopeRead, ScopeManageUsers}),
+			want: &auth.Key{CompanyID: 1, Permissions: []permission.Permission{permission.ManageEmployees}},
+		},
+		{
+			name: "manage company",
+			key:  New(1, "batch", []Scope{ScopeManageCompany}),
+			want: &auth.Key{
+				CompanyID:   1,
+				Permissions: []permission.Permission{permission.ManageCompany, permission.ManageDepartments, permission.ManageRoles},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+}
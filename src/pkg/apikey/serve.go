@@ -0,0 +1,152 @@
+package apikey
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"api.example.com/pkg/auth"
+	"api.example.com/pkg/failure"
+	"api.example.com/pkg/user"
+	"api.example.com/pkg/user/password"
+)
+
+type Repository interface {
+	APIKeyCreate(context.Context, *Key) (*Key, error)
+	APIKeyRead(context.Context, CompanyID, ID) (*Key, error)
+	// 会社を問わない
+	APIKeyReadByID(context.Context, ID) (*Key, error)
+	APIKeyList(context.Context, CompanyID) ([]*Key, error)
+	// 秘密の値のみ更新する
+	APIKeyUpdate(context.Context, *Key) (*Key, error)
+	APIKeyDelete(context.Context, CompanyID, ID) error
+}
+
+type Server interface {
+	// 作成した API キーの Token を返す
+	Create(context.Context, *Key) (*Key, error)
+	Read(context.Context, CompanyID, ID) (*Key, error)
+	List(context.Context, CompanyID) ([]*Key, error)
+	// 秘密の値を作り直し、新しい Token を返す
+	// 以前の Token は使用できなくなる
+	Rotate(context.Context, CompanyID, ID) (*Key, error)
+	// 失効(削除)
+	Revoke(context.Context, CompanyID, ID) error
+	// Token が示す API キーの呼び出し元
+	Authenticate(context.Context, Token) (*auth.Key, error)
+}
+
+// impl Server
+type server struct {
+	repository Repository
+}
+
+func NewServer(repo Repository) Server {
+	return &server{repo}
+}
+
+// 秘密の値とそのハッシュ
+func hashSecret() (string, user.Password, error) {
+	secret, err := newSecret()
+	if err != nil {
+		return "", nil, err
+	}
+
+	hash, err := password.New(secret)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return secret, hash, nil
+}
+
+func (s *server) Create(ctx context.Context, k *Key) (*Key, error) {
+	if !k.validCreate() {
+		return nil, fmt.Errorf("pkg/apikey.Create: %w", failure.New(failure.ErrInvalidArgument, "invalid api key"))
+	}
+
+	if !validScopes(k.Scopes) {
+		return nil, fmt.Errorf("pkg/apikey.Create: %w", ErrInvalidScope)
+	}
+
+	secret, hash, err := hashSecret()
+	if err != nil {
+		return nil, fmt.Errorf("pkg/apikey.Create: %w", err)
+	}
+
+	k.Secret = hash
+	created, err := s.repository.APIKeyCreate(ctx, k)
+	if err != nil {
+		return nil, fmt.Errorf("pkg/apikey.Create: %w", err)
+	}
+
+	created.Token = newToken(created.ID, secret)
+	return created, nil
+}
+
+func (s *server) Read(ctx context.Context, companyID CompanyID, id ID) (*Key, error) {
+	if !companyID.Valid() || !id.Valid() {
+		return nil, fmt.Errorf("pkg/apikey.Read: %w", failure.New(failure.ErrInvalidArgument, "invalid id"))
+	}
+
+	return s.repository.APIKeyRead(ctx, companyID, id)
+}
+
+func (s *server) List(ctx context.Context, companyID CompanyID) ([]*Key, error) {
+	if !companyID.Valid() {
+		return nil, fmt.Errorf("pkg/apikey.List: %w", failure.New(failure.ErrInvalidArgument, "invalid company_id"))
+	}
+
+	return s.repository.APIKeyList(ctx, companyID)
+}
+
+func (s *server) Rotate(ctx context.Context, companyID CompanyID, id ID) (*Key, error) {
+	k, err := s.Read(ctx, companyID, id)
+	if err != nil {
+		return nil, fmt.Errorf("pkg/apikey.Rotate: %w", err)
+	}
+
+	secret, hash, err := hashSecret()
+	if err != nil {
+		return nil, fmt.Errorf("pkg/apikey.Rotate: %w", err)
+	}
+
+	k.Secret = hash
+	updated, err := s.repository.APIKeyUpdate(ctx, k)
+	if err != nil {
+		return nil, fmt.Errorf("pkg/apikey.Rotate: %w", err)
+	}
+
+	updated.Token = newToken(updated.ID, secret)
+	return updated, nil
+}
+
+func (s *server) Revoke(ctx context.Context, companyID CompanyID, id ID) error {
+	if !companyID.Valid() || !id.Valid() {
+		return fmt.Errorf("pkg/apikey.Revoke: %w", failure.New(failure.ErrInvalidArgument, "invalid id"))
+	}
+
+	return s.repository.APIKeyDelete(ctx, companyID, id)
+}
+
+func (s *server) Authenticate(ctx context.Context, token Token) (*auth.Key, error) {
+	id, secret, ok := token.parse()
+	if !ok {
+		return nil, fmt.Errorf("pkg/apikey.Authenticate: %w", ErrInvalidKey)
+	}
+
+	// 障害によるエラーは失効済みの API キーと区別する
+	k, err := s.repository.APIKeyReadByID(ctx, id)
+	if errors.Is(err, failure.ErrNotFound) {
+		return nil, fmt.Errorf("pkg/apikey.Authenticate: %w: %v", ErrInvalidKey, err)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("pkg/apikey.Authenticate: %w", err)
+	}
+
+	if !k.Secret.Verify(secret) {
+		return nil, fmt.Errorf("pkg/apikey.Authenticate: %w", ErrInvalidKey)
+	}
+
+	return k.grant(), nil
+}
@@ -0,0 +1,463 @@
+package apikey
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"api.example.com/pkg/auth"
+	"api.example.com/pkg/failure"
+	"api.example.com/pkg/permission"
+	"api.example.com/pkg/user/password"
+)
+
+// mock
+type makeRepository func(t *testing.T) Repository
+
+type repository struct {
+	key  *Key
+	keys []*Key
+	err  error
+	// 作成、更新に渡された API キー
+	saved *Key
+	// flag
+	create, read, readByID, list, update, delete bool
+	// test
+	t *testing.T
+}
+
+func (r *repository) APIKeyCreate(_ context.Context, k *Key) (*Key, error) {
+	r.t.Helper()
+
+	if r.create {
+		r.saved = k
+		return r.key, r.err
+	}
+	r.t.Fatal("invalid APIKeyCreate")
+	panic("invalid APIKeyCreate")
+}
+
+func (r *repository) APIKeyRead(context.Context, CompanyID, ID) (*Key, error) {
+	r.t.Helper()
+
+	if r.read {
+		return r.key, r.err
+	}
+	r.t.Fatal("invalid APIKeyRead")
+	panic("invalid APIKeyRead")
+}
+
+func (r *repository) APIKeyReadByID(context.Context, ID) (*Key, error) {
+	r.t.Helper()
+
+	if r.readByID {
+		return r.key, r.err
+	}
+	r.t.Fatal("invalid APIKeyReadByID")
+	panic("invalid APIKeyReadByID")
+}
+
+func (r *repository) APIKeyList(context.Context, CompanyID) ([]*Key, error) {
+	r.t.Helper()
+
+	if r.list {
+		return r.keys, r.err
+	}
+	r.t.Fatal("invalid APIKeyList")
+	panic("invalid APIKeyList")
+}
+
+func (r *repository) APIKeyUpdate(_ context.Context, k *Key) (*Key, error) {
+	r.t.Helper()
+
+	if r.update {
+		r.saved = k
+		return k, r.err
+	}
+	r.t.Fatal("invalid APIKeyUpdate")
+	panic("invalid APIKeyUpdate")
+}
+
+func (r *repository) APIKeyDelete(context.Context, CompanyID, ID) error {
+	r.t.Helper()
+
+	if r.delete {
+		return r.err
+	}
+	r.t.Fatal("invalid APIKeyDelete")
+	panic("invalid APIKeyDelete")
+}
+
+// 発行した Token の秘密の値が保存したハッシュと一致すること
+func testToken(t *testing.T, id ID, saved *Key, got *Key) {
+	t.Helper()
+
+	gotID, secret, ok := got.Token.parse()
+	if !ok || gotID != id {
+		t.Fatalf("invalid token=%v.", got.Token)
+	}
+
+	if saved == nil || !saved.Secret.Verify(secret) {
+		t.Fatalf("secret is not hashed: token=%v.", got.Token)
+	}
+}
+
+func TestServer_Create(t *testing.T) {
+	type test struct {
+		name    string
+		key     *Key
+		repo    *repository
+		wantErr bool
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.repo.t = t
+			got, err := NewServer(tt.repo).Create(context.Background(), tt.key)
+			if tt.wantErr != (err != nil) {
+				t.Fatalf("want-error=%v, error=%v.", tt.wantErr, err)
+			}
+
+			if tt.wantErr {
+				return
+			}
+
+			testToken(t, 2, tt.repo.saved, got)
+		})
+	}
+
+	tests := []*test{
+		{
+			name: "ok",
+			key:  New(1, "batch", []Scope{ScopeRead}),
+			repo: &repository{
+				key:    &Key{ID: 2, CompanyID: 1, Name: "batch", Scopes: []Scope{ScopeRead}},
+				create: true,
+			},
+			wantErr: false,
+		},
+		{
+			name:    "invalid",
+			key:     New(1, "", []Scope{ScopeRead}),
+			repo:    &repository{},
+			wantErr: true,
+		},
+		{
+			name:    "invalid scope",
+			key:     New(1, "batch", []Scope{"manage_everything"}),
+			repo:    &repository{},
+			wantErr: true,
+		},
+		{
+			name: "failed create",
+			key:  New(1, "batch", []Scope{ScopeRead}),
+			repo: &repository{
+				err:    errors.New("test error"),
+				create: true,
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+}
+
+func TestServer_Read(t *testing.T) {
+	type test struct {
+		name           string
+		companyID      CompanyID
+		id             ID
+		makeRepository makeRepository
+		want           *Key
+		wantErr        bool
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewServer(tt.makeRepository(t)).Read(context.Background(), tt.companyID, tt.id)
+			if tt.wantErr != (err != nil) {
+				t.Fatalf("want-error=%v, error=%v.", tt.wantErr, err)
+			}
+
+			if !reflect.DeepEqual(tt.want, got) {
+				t.Fatalf("want=%v, got=%v.", tt.want, got)
+			}
+		})
+	}
+
+	updatedAt := time.Date(2022, 9, 3, 12, 34, 56, 0, time.UTC)
+
+	tests := []*test{
+		{
+			name:      "ok",
+			companyID: 1,
+			id:        2,
+			makeRepository: func(t *testing.T) Repository {
+				return &repository{
+					key:  &Key{ID: 2, CompanyID: 1, Name: "batch", Scopes: []Scope{ScopeRead}, UpdatedAt: updatedAt},
+					read: true,
+					t:    t,
+				}
+			},
+			want:    &Key{ID: 2, CompanyID: 1, Name: "batch", Scopes: []Scope{ScopeRead}, UpdatedAt: updatedAt},
+			wantErr: false,
+		},
+		{
+			name:      "invalid id",
+			companyID: 1,
+			id:        0,
+			makeRepository: func(t *testing.T) Repository {
+				return &repository{t: t}
+			},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+}
+
+func TestServer_List(t *testing.T) {
+	type test struct {
+		name           string
+		companyID      CompanyID
+		makeRepository makeRepository
+		want           []*Key
+		wantErr        bool
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewServer(tt.makeRepository(t)).List(context.Background(), tt.companyID)
+			if tt.wantErr != (err != nil) {
+				t.Fatalf("want-error=%v, error=%v.", tt.wantErr, err)
+			}
+
+			if !reflect.DeepEqual(tt.want, got) {
+				t.Fatalf("want=%v, got=%v.", tt.want, got)
+			}
+		})
+	}
+
+	tests := []*test{
+		{
+			name:      "ok",
+			companyID: 1,
+			makeRepository: func(t *testing.T) Repository {
+				return &repository{
+					keys: []*Key{{ID: 2, CompanyID: 1, Name: "batch", Scopes: []Scope{ScopeRead}}},
+					list: true,
+					t:    t,
+				}
+			},
+			want:    []*Key{{ID: 2, CompanyID: 1, Name: "batch", Scopes: []Scope{ScopeRead}}},
+			wantErr: false,
+		},
+		{
+			name:      "invalid company_id",
+			companyID: 0,
+			makeRepository: func(t *testing.T) Repository {
+				return &repository{t: t}
+			},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+}
+
+func TestServer_Rotate(t *testing.T) {
+	type test struct {
+		name    string
+		id      ID
+		repo    *repository
+		wantErr bool
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.repo.t = t
+			got, err := NewServer(tt.repo).Rotate(context.Background(), 1, tt.id)
+			if tt.wantErr != (err != nil) {
+				t.Fatalf("want-error=%v, error=%v.", tt.wantErr, err)
+			}
+
+			if tt.wantErr {
+				return
+			}
+
+			testToken(t, tt.id, tt.repo.saved, got)
+		})
+	}
+
+	old, err := password.New("old-secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []*test{
+		{
+			name: "ok",
+			id:   2,
+			repo: &repository{
+				key:    &Key{ID: 2, CompanyID: 1, Name: "batch", Scopes: []Scope{ScopeRead}, Secret: old},
+				read:   true,
+				update: true,
+			},
+			wantErr: false,
+		},
+		{
+			name: "not found",
+			id:   2,
+			repo: &repository{
+				err:  errors.New("test error"),
+				read: true,
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+}
+
+func TestServer_Revoke(t *testing.T) {
+	type test struct {
+		name           string
+		id             ID
+		makeRepository makeRepository
+		wantErr        bool
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.name, func(t *testing.T) {
+			err := NewServer(tt.makeRepository(t)).Revoke(context.Background(), 1, tt.id)
+			if tt.wantErr != (err != nil) {
+				t.Fatalf("want-error=%v, error=%v.", tt.wantErr, err)
+			}
+		})
+	}
+
+	tests := []*test{
+		{
+			name: "ok",
+			id:   2,
+			makeRepository: func(t *testing.T) Repository {
+				return &repository{delete: true, t: t}
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid id",
+			id:   0,
+			makeRepository: func(t *testing.T) Repository {
+				return &repository{t: t}
+			},
+			wantErr: true,
+		},
+		{
+			name: "failed delete",
+			id:   2,
+			makeRepository: func(t *testing.T) Repository {
+				return &repository{err: errors.New("test error"), delete: true, t: t}
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+}
+
+func TestServer_Authenticate(t *testing.T) {
+	type test struct {
+		name           string
+		token          Token
+		makeRepository makeRepository
+		want           *auth.Key
+		wantErr        error
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewServer(tt.makeRepository(t)).Authenticate(context.Background(), tt.token)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("want-error=%v, error=%v.", tt.wantErr, err)
+			}
+
+			if !reflect.DeepEqual(tt.want, got) {
+				t.Fatalf("want=%v, got=%v.", tt.want, got)
+			}
+		})
+	}
+
+	hash, err := password.New("secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+	key := &Key{ID: 2, CompanyID: 1, Name: "batch", Scopes: []Scope{ScopeManageUsers}, Secret: hash}
+
+	errRead := errors.New("failed read")
+	tests := []*test{
+		{
+			name:  "ok",
+			token: "2.secret",
+			makeRepository: func(t *testing.T) Repository {
+				return &repository{key: key, readByID: true, t: t}
+			},
+			want:    &auth.Key{CompanyID: 1, Permissions: []permission.Permission{permission.ManageEmployees}},
+			wantErr: nil,
+		},
+		{
+			name:  "wrong secret",
+			token: "2.wrong",
+			makeRepository: func(t *testing.T) Repository {
+				return &repository{key: key, readByID: true, t: t}
+			},
+			want:    nil,
+			wantErr: auth.ErrUnauthenticated,
+		},
+		{
+			name:  "revoked",
+			token: "2.secret",
+			makeRepository: func(t *testing.T) Repository {
+				return &repository{err: failure.ErrNotFound, readByID: true, t: t}
+			},
+			want:    nil,
+			wantErr: ErrInvalidKey,
+		},
+		{
+			name:  "failed read",
+			token: "2.secret",
+			makeRepository: func(t *testing.T) Repository {
+				return &repository{err: errRead, readByID: true, t: t}
+			},
+			want:    nil,
+			wantErr: errRead,
+		},
+		{
+			name:  "malformed",
+			token: "malformed",
+			makeRepository: func(t *testing.T) Repository {
+				return &repository{t: t}
+			},
+			want:    nil,
+			wantErr: ErrInvalidKey,
+		},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+}
@@ -0,0 +1,59 @@
+package assignment
+
+import (
+	"time"
+
+	"api.example.com/pkg/company"
+	"api.example.com/pkg/employee"
+	"api.example.com/pkg/failure"
+	"api.example.com/pkg/organization"
+	"api.example.com/pkg/role"
+)
+
+// 従業員の配置(部署と肩書きの組)の ID
+type ID int
+
+func (id ID) Valid() bool {
+	return id > 0
+}
+
+// 配置を持つ会社
+type CompanyID = company.ID
+
+// 配置される従業員
+// 1人の従業員は複数の配置を兼任することができる
+type EmployeeID = employee.ID
+
+// 配置先の部署
+type DepartmentID = organization.ID
+
+// 配置先での肩書き
+type RoleID = role.ID
+
+// 同じ従業員に同じ部署と肩書きの組は配置できない
+var ErrDuplicate = failure.New(failure.ErrConflict, "assignment already exists")
+
+// 従業員の配置
+// 「社員Aは営業部長と開発部長を兼任する」は
+// 社員Aに (営業部, 部長) と (開発部, 部長) の2つの配置を持たせる
+type Assignment struct {
+	ID           ID
+	CompanyID    CompanyID
+	EmployeeID   EmployeeID
+	DepartmentID DepartmentID
+	RoleID       RoleID
+	UpdatedAt    time.Time
+}
+
+func New(companyID CompanyID, employeeID EmployeeID, departmentID DepartmentID, roleID RoleID) *Assignment {
+	return &Assignment{
+		CompanyID:    companyID,
+		EmployeeID:   employeeID,
+		DepartmentID: departmentID,
+		RoleID:       roleID,
+	}
+}
+
+func (a *Assignment) validCreate() bool {
+	return a.CompanyID.Valid() && a.EmployeeID.Valid() && a.DepartmentID.Valid() && a.RoleID.Valid()
+}
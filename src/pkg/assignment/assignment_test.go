@@ -0,0 +1,63 @@
+package assignment
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNew(t *testing.T) {
+	got := New(1, 2, 3, 4)
+	want := &Assignment{CompanyID: 1, EmployeeID: 2, DepartmentID: 3, RoleID: 4}
+	if !reflect.DeepEqual(want, got) {
+		t.Fatalf("want=%v, got=%v.", want, got)
+	}
+}
+
+func TestAssignment_validCreate(t *testing.T) {
+	type test struct {
+		name       string
+		assignment *Assignment
+		want       bool
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.assignment.validCreate()
+			if tt.want != got {
+				t.Fatalf("want=%v, got=%v.", tt.want, got)
+			}
+		})
+	}
+
+	tests := []*test{
+		{
+			name:       "ok",
+			assignment: New(1, 2, 3, 4),
+			want:       true,
+		},
+		{
+			name:       "invalid company_id",
+			assignment: New(0, 2, 3, 4),
+			want:       false,
+		},
+		{
+			name:       "invalid employee_id",
+			assignment: New(1, 0, 3, 4),
+			want:       false,
+		},
+		{
+			name:       "invalid department_id",
+			assignment: New(1, 2, 0, 4),
+			want:       false,
+		},
+		{
+			name:       "invalid role_id",
+			assignment: New(1, 2, 3, 0),
+			want:       false,
+		},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+}
@@ -0,0 +1,85 @@
+package assignment
+
+import (
+	"context"
+	"fmt"
+
+	"api.example.com/pkg/failure"
+)
+
+// 従業員、部署、肩書きが同じ会社に実在することは Repository で確認する
+type Repository interface {
+	AssignmentCreate(context.Context, *Assignment) (*Assignment, error)
+	AssignmentRead(context.Context, CompanyID, ID) (*Assignment, error)
+	AssignmentListByEmployee(context.Context, CompanyID, EmployeeID) ([]*Assignment, error)
+	AssignmentListByDepartment(context.Context, CompanyID, DepartmentID) ([]*Assignment, error)
+	AssignmentListBySubtree(context.Context, CompanyID, DepartmentID) ([]*Assignment, error)
+	AssignmentDelete(context.Context, CompanyID, ID) error
+}
+
+type Server interface {
+	Create(context.Context, *Assignment) (*Assignment, error)
+	Read(context.Context, CompanyID, ID) (*Assignment, error)
+	ListByEmployee(context.Context, CompanyID, EmployeeID) ([]*Assignment, error)
+	ListByDepartment(context.Context, CompanyID, DepartmentID) ([]*Assignment, error)
+	// 部署と配下の全ての部署の配置
+	ListBySubtree(context.Context, CompanyID, DepartmentID) ([]*Assignment, error)
+	Delete(context.Context, CompanyID, ID) error
+}
+
+// impl Server
+type server struct {
+	repository Repository
+}
+
+func NewServer(repo Repository) Server {
+	return &server{repo}
+}
+
+func (s *server) Create(ctx context.Context, a *Assignment) (*Assignment, error) {
+	if ok := a.validCreate(); !ok {
+		return nil, fmt.Errorf("pkg/assignment.Create: %w", failure.New(failure.ErrInvalidArgument, "invalid assignment"))
+	}
+
+	return s.repository.AssignmentCreate(ctx, a)
+}
+
+func (s *server) Read(ctx context.Context, companyID CompanyID, id ID) (*Assignment, error) {
+	if ok := companyID.Valid() && id.Valid(); !ok {
+		return nil, fmt.Errorf("pkg/assignment.Read: %w", failure.New(failure.ErrInvalidArgument, "invalid company_id or assignment_id"))
+	}
+
+	return s.repository.AssignmentRead(ctx, companyID, id)
+}
+
+func (s *server) ListByEmployee(ctx context.Context, companyID CompanyID, employeeID EmployeeID) ([]*Assignment, error) {
+	if ok := companyID.Valid() && employeeID.Valid(); !ok {
+		return nil, fmt.Errorf("pkg/assignment.ListByEmployee: %w", failure.New(failure.ErrInvalidArgument, "invalid company_id or employee_id"))
+	}
+
+	return s.repository.AssignmentListByEmployee(ctx, companyID, employeeID)
+}
+
+func (s *server) ListByDepartment(ctx context.Context, companyID CompanyID, departmentID DepartmentID) ([]*Assignment, error) {
+	if ok := companyID.Valid() && departmentID.Valid(); !ok {
+		return nil, fmt.Errorf("pkg/assignment.ListByDepartment: %w", failure.New(failure.ErrInvalidArgument, "invalid company_id or department_id"))
+	}
+
+	return s.repository.AssignmentListByDepartment(ctx, companyID, departmentID)
+}
+
+func (s *server) ListBySubtree(ctx context.Context, companyID CompanyID, departmentID DepartmentID) ([]*Assignment, error) {
+	if ok := companyID.Valid() && departmentID.Valid(); !ok {
+		return nil, fmt.Errorf("pkg/assignment.ListBySubtree: %w", failure.New(failure.ErrInvalidArgument, "invalid company_id or department_id"))
+	}
+
+	return s.repository.AssignmentListBySubtree(ctx, companyID, departmentID)
+}
+
+func (s *server) Delete(ctx context.Context, companyID CompanyID, id ID) error {
+	if ok := companyID.Valid() && id.Valid(); !ok {
+		return fmt.Errorf("pkg/assignment.Delete: %w", failure.New(failure.ErrInvalidArgument, "invalid company_id or assignment_id"))
+	}
+
+	return s.repository.AssignmentDelete(ctx, companyID, id)
+}
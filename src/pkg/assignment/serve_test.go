@@ -0,0 +1,409 @@
+package assignment
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// mock
+type makeRepository func(t *testing.T) Repository
+
+type repository struct {
+	assignment  *Assignment
+	assignments []*Assignment
+	err         error
+	// flag
+	create, read, listByEmployee, listByDepartment, listBySubtree, delete bool
+	// test
+	t *testing.T
+}
+
+func (r *repository) AssignmentCreate(context.Context, *Assignment) (*Assignment, error) {
+	r.t.Helper()
+
+	if r.create {
+		return r.assignment, r.err
+	}
+	r.t.Fatal("invalid AssignmentCreate")
+	panic("invalid AssignmentCreate")
+}
+
+func (r *repository) AssignmentRead(context.Context, CompanyID, ID) (*Assignment, error) {
+	r.t.Helper()
+
+	if r.read {
+		return r.assignment, r.err
+	}
+	r.t.Fatal("invalid AssignmentRead")
+	panic("invalid AssignmentRead")
+}
+
+func (r *repository) AssignmentListByEmployee(context.Context, CompanyID, EmployeeID) ([]*Assignment, error) {
+	r.t.Helper()
+
+	if r.listByEmployee {
+		return r.assignments, r.err
+	}
+	r.t.Fatal("invalid AssignmentListByEmployee")
+	panic("invalid AssignmentListByEmployee")
+}
+
+func (r *repository) AssignmentListByDepartment(context.Context, CompanyID, DepartmentID) ([]*Assignment, error) {
+	r.t.Helper()
+
+	if r.listByDepartment {
+		return r.assignments, r.err
+	}
+	r.t.Fatal("invalid AssignmentListByDepartment")
+	panic("invalid AssignmentListByDepartment")
+}
+
+func (r *repository) AssignmentListBySubtree(context.Context, CompanyID, DepartmentID) ([]*Assignment, error) {
+	r.t.Helper()
+
+	if r.listBySubtree {
+		return r.assignments, r.err
+	}
+	r.t.Fatal("invalid AssignmentListBySubtree")
+	panic("invalid AssignmentListBySubtree")
+}
+
+func (r *repository) AssignmentDelete(context.Context, CompanyID, ID) error {
+	r.t.Helper()
+
+	if r.delete {
+		return r.err
+	}
+	r.t.Fatal("invalid AssignmentDelete")
+	panic("invalid AssignmentDelete")
+}
+
+func TestServer_Create(t *testing.T) {
+	type test struct {
+		name           string
+		makeRepository makeRepository
+		assignment     *Assignment
+		want           *Assignment
+		wantErr        bool
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewServer(tt.makeRepository(t)).Create(context.Background(), tt.assignment)
+			if tt.wantErr != (err != nil) {
+				t.Fatalf("want-error=%v, error=%v.", tt.wantErr, err)
+			}
+
+			if !reflect.DeepEqual(tt.want, got) {
+				t.Fatalf("want=%v, got=%v.", tt.want, got)
+			}
+		})
+	}
+
+	updatedAt := time.Date(2022, 9, 3, 12, 34, 56, 0, time.UTC)
+
+	tests := []*test{
+		{
+			name:       "ok",
+			assignment: New(1, 2, 3, 4),
+			makeRepository: func(t *testing.T) Repository {
+				return &repository{
+					assignment: &Assignment{ID: 5, CompanyID: 1, EmployeeID: 2, DepartmentID: 3, RoleID: 4, UpdatedAt: updatedAt},
+					create:     true,
+					t:          t,
+				}
+			},
+			want:    &Assignment{ID: 5, CompanyID: 1, EmployeeID: 2, DepartmentID: 3, RoleID: 4, UpdatedAt: updatedAt},
+			wantErr: false,
+		},
+		{
+			name:       "invalid",
+			assignment: New(1, 2, 0, 4),
+			makeRepository: func(t *testing.T) Repository {
+				return &repository{t: t}
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:       "duplicate",
+			assignment: New(1, 2, 3, 4),
+			makeRepository: func(t *testing.T) Repository {
+				return &repository{err: ErrDuplicate, create: true, t: t}
+			},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+}
+
+func TestServer_Read(t *testing.T) {
+	type test struct {
+		name           string
+		companyID      CompanyID
+		id             ID
+		makeRepository makeRepository
+		want           *Assignment
+		wantErr        bool
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewServer(tt.makeRepository(t)).Read(context.Background(), tt.companyID, tt.id)
+			if tt.wantErr != (err != nil) {
+				t.Fatalf("want-error=%v, error=%v.", tt.wantErr, err)
+			}
+
+			if !reflect.DeepEqual(tt.want, got) {
+				t.Fatalf("want=%v, got=%v.", tt.want, got)
+			}
+		})
+	}
+
+	tests := []*test{
+		{
+			name:      "ok",
+			companyID: 1,
+			id:        5,
+			makeRepository: func(t *testing.T) Repository {
+				return &repository{assignment: &Assignment{ID: 5, CompanyID: 1}, read: true, t: t}
+			},
+			want:    &Assignment{ID: 5, CompanyID: 1},
+			wantErr: false,
+		},
+		{
+			name:      "invalid assignment_id",
+			companyID: 1,
+			id:        0,
+			makeRepository: func(t *testing.T) Repository {
+				return &repository{t: t}
+			},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+}
+
+func TestServer_ListByEmployee(t *testing.T) {
+	type test struct {
+		name           string
+		companyID      CompanyID
+		employeeID     EmployeeID
+		makeRepository makeRepository
+		want           []*Assignment
+		wantErr        bool
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewServer(tt.makeRepository(t)).ListByEmployee(context.Background(), tt.companyID, tt.employeeID)
+			if tt.wantErr != (err != nil) {
+				t.Fatalf("want-error=%v, error=%v.", tt.wantErr, err)
+			}
+
+			if !reflect.DeepEqual(tt.want, got) {
+				t.Fatalf("want=%v, got=%v.", tt.want, got)
+			}
+		})
+	}
+
+	// 兼任
+	list := []*Assignment{
+		{ID: 5, CompanyID: 1, EmployeeID: 2, DepartmentID: 3, RoleID: 4},
+		{ID: 6, CompanyID: 1, EmployeeID: 2, DepartmentID: 7, RoleID: 4},
+	}
+
+	tests := []*test{
+		{
+			name:       "ok",
+			companyID:  1,
+			employeeID: 2,
+			makeRepository: func(t *testing.T) Repository {
+				return &repository{assignments: list, listByEmployee: true, t: t}
+			},
+			want:    list,
+			wantErr: false,
+		},
+		{
+			name:       "invalid employee_id",
+			companyID:  1,
+			employeeID: 0,
+			makeRepository: func(t *testing.T) Repository {
+				return &repository{t: t}
+			},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+}
+
+func TestServer_ListByDepartment(t *testing.T) {
+	type test struct {
+		name           string
+		companyID      CompanyID
+		departmentID   DepartmentID
+		makeRepository makeRepository
+		want           []*Assignment
+		wantErr        bool
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewServer(tt.makeRepository(t)).ListByDepartment(context.Background(), tt.companyID, tt.departmentID)
+			if tt.wantErr != (err != nil) {
+				t.Fatalf("want-error=%v, error=%v.", tt.wantErr, err)
+			}
+
+			if !reflect.DeepEqual(tt.want, got) {
+				t.Fatalf("want=%v, got=%v.", tt.want, got)
+			}
+		})
+	}
+
+	list := []*Assignment{
+		{ID: 5, CompanyID: 1, EmployeeID: 2, DepartmentID: 3, RoleID: 4},
+		{ID: 8, CompanyID: 1, EmployeeID: 9, DepartmentID: 3, RoleID: 10},
+	}
+
+	tests := []*test{
+		{
+			name:         "ok",
+			companyID:    1,
+			departmentID: 3,
+			makeRepository: func(t *testing.T) Repository {
+				return &repository{assignments: list, listByDepartment: true, t: t}
+			},
+			want:    list,
+			wantErr: false,
+		},
+		{
+			name:         "invalid department_id",
+			companyID:    1,
+			departmentID: 0,
+			makeRepository: func(t *testing.T) Repository {
+				return &repository{t: t}
+			},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+}
+
+func TestServer_ListBySubtree(t *testing.T) {
+	type test struct {
+		name           string
+		companyID      CompanyID
+		departmentID   DepartmentID
+		makeRepository makeRepository
+		want           []*Assignment
+		wantErr        bool
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewServer(tt.makeRepository(t)).ListBySubtree(context.Background(), tt.companyID, tt.departmentID)
+			if tt.wantErr != (err != nil) {
+				t.Fatalf("want-error=%v, error=%v.", tt.wantErr, err)
+			}
+
+			if !reflect.DeepEqual(tt.want, got) {
+				t.Fatalf("want=%v, got=%v.", tt.want, got)
+			}
+		})
+	}
+
+	// 部署 3 と配下の部署 6
+	list := []*Assignment{
+		{ID: 5, CompanyID: 1, EmployeeID: 2, DepartmentID: 3, RoleID: 4},
+		{ID: 8, CompanyID: 1, EmployeeID: 9, DepartmentID: 6, RoleID: 10},
+	}
+
+	tests := []*test{
+		{
+			name:         "ok",
+			companyID:    1,
+			departmentID: 3,
+			makeRepository: func(t *testing.T) Repository {
+				return &repository{assignments: list, listBySubtree: true, t: t}
+			},
+			want:    list,
+			wantErr: false,
+		},
+		{
+			name:         "invalid department_id",
+			companyID:    1,
+			departmentID: 0,
+			makeRepository: func(t *testing.T) Repository {
+				return &repository{t: t}
+			},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+}
+
+func TestServer_Delete(t *testing.T) {
+	type test struct {
+		name           string
+		companyID      CompanyID
+		id             ID
+		makeRepository makeRepository
+		wantErr        bool
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.name, func(t *testing.T) {
+			err := NewServer(tt.makeRepository(t)).Delete(context.Background(), tt.companyID, tt.id)
+			if tt.wantErr != (err != nil) {
+				t.Fatalf("want-error=%v, error=%v.", tt.wantErr, err)
+			}
+		})
+	}
+
+	tests := []*test{
+		{
+			name:      "ok",
+			companyID: 1,
+			id:        5,
+			makeRepository: func(t *testing.T) Repository {
+				return &repository{delete: true, t: t}
+			},
+			wantErr: false,
+		},
+		{
+			name:      "invalid",
+			companyID: 0,
+			id:        5,
+			makeRepository: func(t *testing.T) Repository {
+				return &repository{t: t}
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+}
@@ -0,0 +1,81 @@
+package auth
+
+import (
+	"context"
+
+	"api.example.com/pkg/company"
+	"api.example.com/pkg/employee"
+	"api.example.com/pkg/failure"
+	"api.example.com/pkg/permission"
+	"api.example.com/pkg/user"
+)
+
+// 呼び出し元のユーザー
+type UserID = user.ID
+
+// 操作対象の会社
+type CompanyID = company.ID
+
+// 操作対象の従業員
+type EmployeeID = employee.ID
+
+var (
+	// 呼び出し元のユーザーが識別できない
+	ErrUnauthenticated = failure.ErrUnauthenticated
+	// 呼び出し元のユーザーに操作が許可されていない
+	// permission.ErrForbidden と同じ値とし、どちらでも判定できるようにする
+	ErrForbidden = permission.ErrForbidden
+)
+
+type callerKey struct{}
+
+// 呼び出し元のユーザーを保存した context
+func WithCaller(ctx context.Context, id UserID) context.Context {
+	return context.WithValue(ctx, callerKey{}, id)
+}
+
+// context に保存された呼び出し元のユーザー
+func CallerFrom(ctx context.Context) (UserID, bool) {
+	id, ok := ctx.Value(callerKey{}).(UserID)
+	return id, ok && id.Valid()
+}
+
+// API キーで識別された呼び出し元
+// ユーザーではないため、キーを発行した会社の操作のみが許される
+type Key struct {
+	CompanyID CompanyID
+	// 参照以外に許可された操作権限
+	Permissions []permission.Permission
+}
+
+// 会社 companyID に対して p の操作が許可されているか
+// p が空であれば参照のみ
+func (k *Key) allows(companyID CompanyID, p permission.Permission) bool {
+	if k.CompanyID != companyID {
+		return false
+	}
+
+	if p == "" {
+		return true
+	}
+
+	for _, v := range k.Permissions {
+		if p == v {
+			return true
+		}
+	}
+	return false
+}
+
+type keyKey struct{}
+
+// 呼び出し元の API キーを保存した context
+func WithKey(ctx context.Context, k *Key) context.Context {
+	return context.WithValue(ctx, keyKey{}, k)
+}
+
+// context に保存された呼び出し元の API キー
+func KeyFrom(ctx context.Context) (*Key, bool) {
+	k, ok := ctx.Value(keyKey{}).(*Key)
+	return k, ok && k != nil
+}
@@ -0,0 +1,150 @@
+package auth
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"api.example.com/pkg/permission"
+)
+
+func TestCallerFrom(t *testing.T) {
+	type test struct {
+		name   string
+		ctx    context.Context
+		want   UserID
+		wantOK bool
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := CallerFrom(tt.ctx)
+			if tt.wantOK != ok {
+				t.Fatalf("want-ok=%v, ok=%v.", tt.wantOK, ok)
+			}
+
+			if tt.want != got {
+				t.Fatalf("want=%v, got=%v.", tt.want, got)
+			}
+		})
+	}
+
+	tests := []*test{
+		{
+			name:   "ok",
+			ctx:    WithCaller(context.Background(), 1),
+			want:   1,
+			wantOK: true,
+		},
+		{
+			name:   "no caller",
+			ctx:    context.Background(),
+			want:   0,
+			wantOK: false,
+		},
+		{
+			name:   "invalid caller",
+			ctx:    WithCaller(context.Background(), 0),
+			want:   0,
+			wantOK: false,
+		},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+}
+
+func TestKeyFrom(t *testing.T) {
+	key := &Key{CompanyID: 1, Permissions: []permission.Permission{permission.ManageEmployees}}
+
+	type test struct {
+		name   string
+		ctx    context.Context
+		want   *Key
+		wantOK bool
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := KeyFrom(tt.ctx)
+			if tt.wantOK != ok {
+				t.Fatalf("want-ok=%v, ok=%v.", tt.wantOK, ok)
+			}
+
+			if !reflect.DeepEqual(tt.want, got) {
+				t.Fatalf("want=%v, got=%v.", tt.want, got)
+			}
+		})
+	}
+
+	tests := []*test{
+		{
+			name:   "ok",
+			ctx:    WithKey(context.Background(), key),
+			want:   key,
+			wantOK: true,
+		},
+		{
+			name:   "no key",
+			ctx:    context.Background(),
+			want:   nil,
+			wantOK: false,
+		},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+}
+
+func TestKey_allows(t *testing.T) {
+	key := &Key{CompanyID: 1, Permissions: []permission.Permission{permission.ManageEmployees}}
+
+	type test struct {
+		name       string
+		companyID  CompanyID
+		permission permission.Permission
+		want       bool
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.name, func(t *testing.T) {
+			got := key.allows(tt.companyID, tt.permission)
+			if tt.want != got {
+				t.Fatalf("want=%v, got=%v.", tt.want, got)
+			}
+		})
+	}
+
+	tests := []*test{
+		{
+			name:       "read",
+			companyID:  1,
+			permission: "",
+			want:       true,
+		},
+		{
+			name:       "permitted",
+			companyID:  1,
+			permission: permission.ManageEmployees,
+			want:       true,
+		},
+		{
+			name:       "not permitted",
+			companyID:  1,
+			permission: permission.ManageCompany,
+			want:       false,
+		},
+		{
+			name:       "other company",
+			companyID:  2,
+			permission: "",
+			want:       false,
+		},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+}
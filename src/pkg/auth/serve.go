@@ -0,0 +1,122 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"api.example.com/pkg/employee"
+	"api.example.com/pkg/failure"
+	"api.example.com/pkg/permission"
+)
+
+type Repository interface {
+	// ユーザーが会社の従業員でなければエラー
+	EmployeeReadByUserID(context.Context, CompanyID, UserID) (*employee.Employee, error)
+}
+
+// 会社の情報は管理者のみが操作でき、
+// 管理者でない従業員は自身の従業員情報の確認のみが許される
+type Server interface {
+	// 呼び出し元のユーザーが識別できること
+	// API キーによる呼び出しは ErrForbidden
+	Caller(context.Context) (UserID, error)
+	// 呼び出し元が会社の管理者であること
+	// p が空でなければ p の権限も持つこと
+	Administrator(context.Context, CompanyID, permission.Permission) error
+	// 呼び出し元が会社の管理者であるか、従業員本人であること
+	Self(context.Context, CompanyID, EmployeeID) error
+}
+
+// impl Server
+type server struct {
+	repository Repository
+	permission permission.Checker
+}
+
+func NewServer(repo Repository, checker permission.Checker) Server {
+	return &server{repo, checker}
+}
+
+func (s *server) Caller(ctx context.Context) (UserID, error) {
+	// API キーはユーザーとして振る舞えない
+	if _, ok := KeyFrom(ctx); ok {
+		return 0, fmt.Errorf("pkg/auth.Caller: api key: %w", ErrForbidden)
+	}
+
+	id, ok := CallerFrom(ctx)
+	if !ok {
+		return 0, fmt.Errorf("pkg/auth.Caller: %w", ErrUnauthenticated)
+	}
+
+	return id, nil
+}
+
+// 呼び出し元の会社での従業員情報
+// 従業員でなければ ErrForbidden
+func (s *server) callerEmployee(ctx context.Context, companyID CompanyID) (*employee.Employee, error) {
+	id, err := s.Caller(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	e, err := s.repository.EmployeeReadByUserID(ctx, companyID, id)
+	if errors.Is(err, failure.ErrNotFound) {
+		return nil, fmt.Errorf("%w: %v", ErrForbidden, err)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return e, nil
+}
+
+func (s *server) Administrator(ctx context.Context, companyID CompanyID, p permission.Permission) error {
+	if k, ok := KeyFrom(ctx); ok {
+		if !k.allows(companyID, p) {
+			return fmt.Errorf("pkg/auth.Administrator: api key: %w", ErrForbidden)
+		}
+		return nil
+	}
+
+	e, err := s.callerEmployee(ctx, companyID)
+	if err != nil {
+		return fmt.Errorf("pkg/auth.Administrator: %w", err)
+	}
+
+	if !e.Administrator {
+		return fmt.Errorf("pkg/auth.Administrator: not administrator: %w", ErrForbidden)
+	}
+
+	if p == "" {
+		return nil
+	}
+
+	err = s.permission.Check(ctx, companyID, e.ID, p)
+	if err != nil {
+		return fmt.Errorf("pkg/auth.Administrator: %w", err)
+	}
+
+	return nil
+}
+
+func (s *server) Self(ctx context.Context, companyID CompanyID, employeeID EmployeeID) error {
+	// API キーは会社の全ての従業員を参照できる
+	if k, ok := KeyFrom(ctx); ok {
+		if !k.allows(companyID, "") {
+			return fmt.Errorf("pkg/auth.Self: api key: %w", ErrForbidden)
+		}
+		return nil
+	}
+
+	e, err := s.callerEmployee(ctx, companyID)
+	if err != nil {
+		return fmt.Errorf("pkg/auth.Self: %w", err)
+	}
+
+	if !e.Administrator && e.ID != employeeID {
+		return fmt.Errorf("pkg/auth.Self: %w", ErrForbidden)
+	}
+
+	return nil
+}
@@ -0,0 +1,334 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"api.example.com/pkg/employee"
+	"api.example.com/pkg/failure"
+	"api.example.com/pkg/permission"
+)
+
+// mock
+type repository struct {
+	employee *employee.Employee
+	err      error
+	// flag
+	read bool
+	// test
+	t *testing.T
+}
+
+func (r *repository) EmployeeReadByUserID(context.Context, CompanyID, UserID) (*employee.Employee, error) {
+	r.t.Helper()
+
+	if r.read {
+		return r.employee, r.err
+	}
+	r.t.Fatal("invalid EmployeeReadByUserID")
+	panic("invalid EmployeeReadByUserID")
+}
+
+// mock
+type checker struct {
+	err error
+	// flag
+	check bool
+	// test
+	t *testing.T
+}
+
+func (c *checker) Check(context.Context, CompanyID, permission.EmployeeID, permission.Permission) error {
+	c.t.Helper()
+
+	if c.check {
+		return c.err
+	}
+	c.t.Fatal("invalid Check")
+	panic("invalid Check")
+}
+
+func TestServer_Caller(t *testing.T) {
+	type test struct {
+		name    string
+		ctx     context.Context
+		want    UserID
+		wantErr error
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewServer(&repository{t: t}, &checker{t: t}).Caller(tt.ctx)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("want-error=%v, error=%v.", tt.wantErr, err)
+			}
+
+			if tt.want != got {
+				t.Fatalf("want=%v, got=%v.", tt.want, got)
+			}
+		})
+	}
+
+	tests := []*test{
+		{
+			name:    "ok",
+			ctx:     WithCaller(context.Background(), 1),
+			want:    1,
+			wantErr: nil,
+		},
+		{
+			name:    "unauthenticated",
+			ctx:     context.Background(),
+			want:    0,
+			wantErr: ErrUnauthenticated,
+		},
+		{
+			name:    "api key",
+			ctx:     WithKey(context.Background(), &Key{CompanyID: 1}),
+			want:    0,
+			wantErr: ErrForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+}
+
+func TestServer_Administrator(t *testing.T) {
+	type test struct {
+		name           string
+		ctx            context.Context
+		permission     permission.Permission
+		makeRepository func(*testing.T) Repository
+		makeChecker    func(*testing.T) permission.Checker
+		wantErr        error
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.name, func(t *testing.T) {
+			err := NewServer(tt.makeRepository(t), tt.makeChecker(t)).Administrator(tt.ctx, 1, tt.permission)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("want-error=%v, error=%v.", tt.wantErr, err)
+			}
+		})
+	}
+
+	ctx := WithCaller(context.Background(), 2)
+	admin := &employee.Employee{ID: 3, CompanyID: 1, UserID: 2, Administrator: true}
+	member := &employee.Employee{ID: 3, CompanyID: 1, UserID: 2, Administrator: false}
+
+	errRead := errors.New("failed read")
+	tests := []*test{
+		{
+			name:       "ok",
+			ctx:        ctx,
+			permission: permission.ManageEmployees,
+			makeRepository: func(t *testing.T) Repository {
+				return &repository{employee: admin, read: true, t: t}
+			},
+			makeChecker: func(t *testing.T) permission.Checker {
+				return &checker{check: true, t: t}
+			},
+			wantErr: nil,
+		},
+		{
+			name:       "ok (no permission)",
+			ctx:        ctx,
+			permission: "",
+			makeRepository: func(t *testing.T) Repository {
+				return &repository{employee: admin, read: true, t: t}
+			},
+			makeChecker: func(t *testing.T) permission.Checker {
+				return &checker{t: t}
+			},
+			wantErr: nil,
+		},
+		{
+			name:       "unauthenticated",
+			ctx:        context.Background(),
+			permission: permission.ManageEmployees,
+			makeRepository: func(t *testing.T) Repository {
+				return &repository{t: t}
+			},
+			makeChecker: func(t *testing.T) permission.Checker {
+				return &checker{t: t}
+			},
+			wantErr: ErrUnauthenticated,
+		},
+		{
+			name:       "not employee",
+			ctx:        ctx,
+			permission: permission.ManageEmployees,
+			makeRepository: func(t *testing.T) Repository {
+				return &repository{err: failure.ErrNotFound, read: true, t: t}
+			},
+			makeChecker: func(t *testing.T) permission.Checker {
+				return &checker{t: t}
+			},
+			wantErr: ErrForbidden,
+		},
+		{
+			name:       "failed read",
+			ctx:        ctx,
+			permission: permission.ManageEmployees,
+			makeRepository: func(t *testing.T) Repository {
+				return &repository{err: errRead, read: true, t: t}
+			},
+			makeChecker: func(t *testing.T) permission.Checker {
+				return &checker{t: t}
+			},
+			wantErr: errRead,
+		},
+		{
+			name:       "not administrator",
+			ctx:        ctx,
+			permission: permission.ManageEmployees,
+			makeRepository: func(t *testing.T) Repository {
+				return &repository{employee: member, read: true, t: t}
+			},
+			makeChecker: func(t *testing.T) permission.Checker {
+				return &checker{t: t}
+			},
+			wantErr: ErrForbidden,
+		},
+		{
+			name:       "no permission",
+			ctx:        ctx,
+			permission: permission.ManageEmployees,
+			makeRepository: func(t *testing.T) Repository {
+				return &repository{employee: admin, read: true, t: t}
+			},
+			makeChecker: func(t *testing.T) permission.Checker {
+				return &checker{err: fmt.Errorf("test: %w", permission.ErrForbidden), check: true, t: t}
+			},
+			wantErr: ErrForbidden,
+		},
+		{
+			name:       "api key",
+			ctx:        WithKey(context.Background(), &Key{CompanyID: 1, Permissions: []permission.Permission{permission.ManageEmployees}}),
+			permission: permission.ManageEmployees,
+			makeRepository: func(t *testing.T) Repository {
+				return &repository{t: t}
+			},
+			makeChecker: func(t *testing.T) permission.Checker {
+				return &checker{t: t}
+			},
+			wantErr: nil,
+		},
+		{
+			name:       "api key without permission",
+			ctx:        WithKey(context.Background(), &Key{CompanyID: 1}),
+			permission: permission.ManageEmployees,
+			makeRepository: func(t *testing.T) Repository {
+				return &repository{t: t}
+			},
+			makeChecker: func(t *testing.T) permission.Checker {
+				return &checker{t: t}
+			},
+			wantErr: ErrForbidden,
+		},
+		{
+			name:       "api key of other company",
+			ctx:        WithKey(context.Background(), &Key{CompanyID: 2}),
+			permission: "",
+			makeRepository: func(t *testing.T) Repository {
+				return &repository{t: t}
+			},
+			makeChecker: func(t *testing.T) permission.Checker {
+				return &checker{t: t}
+			},
+			wantErr: ErrForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+}
+
+func TestServer_Self(t *testing.T) {
+	type test struct {
+		name           string
+		ctx            context.Context
+		employeeID     EmployeeID
+		makeRepository func(*testing.T) Repository
+		wantErr        error
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.name, func(t *testing.T) {
+			err := NewServer(tt.makeRepository(t), &checker{t: t}).Self(tt.ctx, 1, tt.employeeID)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("want-error=%v, error=%v.", tt.wantErr, err)
+			}
+		})
+	}
+
+	ctx := WithCaller(context.Background(), 2)
+	admin := &employee.Employee{ID: 3, CompanyID: 1, UserID: 2, Administrator: true}
+	member := &employee.Employee{ID: 3, CompanyID: 1, UserID: 2, Administrator: false}
+
+	tests := []*test{
+		{
+			name:       "ok (self)",
+			ctx:        ctx,
+			employeeID: 3,
+			makeRepository: func(t *testing.T) Repository {
+				return &repository{employee: member, read: true, t: t}
+			},
+			wantErr: nil,
+		},
+		{
+			name:       "ok (administrator)",
+			ctx:        ctx,
+			employeeID: 4,
+			makeRepository: func(t *testing.T) Repository {
+				return &repository{employee: admin, read: true, t: t}
+			},
+			wantErr: nil,
+		},
+		{
+			name:       "other employee",
+			ctx:        ctx,
+			employeeID: 4,
+			makeRepository: func(t *testing.T) Repository {
+				return &repository{employee: member, read: true, t: t}
+			},
+			wantErr: ErrForbidden,
+		},
+		{
+			name:       "unauthenticated",
+			ctx:        context.Background(),
+			employeeID: 3,
+			makeRepository: func(t *testing.T) Repository {
+				return &repository{t: t}
+			},
+			wantErr: ErrUnauthenticated,
+		},
+		{
+			name:       "api key",
+			ctx:        WithKey(context.Background(), &Key{CompanyID: 1}),
+			employeeID: 4,
+			makeRepository: func(t *testing.T) Repository {
+				return &repository{t: t}
+			},
+			wantErr: nil,
+		},
+		{
+			name:       "api key of other company",
+			ctx:        WithKey(context.Background(), &Key{CompanyID: 2}),
+			employeeID: 4,
+			makeRepository: func(t *testing.T) Repository {
+				return &repository{t: t}
+			},
+			wantErr: ErrForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+}
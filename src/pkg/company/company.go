@@ -3,6 +3,8 @@ package company
 import (
 	"time"
 
+	"api.example.com/pkg/failure"
+	"api.example.com/pkg/page"
 	"api.example.com/pkg/user"
 )
 
@@ -15,15 +17,18 @@ type OwnerID = user.ID
 // Company Name
 type Name string
 
-func (n Name) valid() bool {
-	l := len(n)
-	return l > 0 && l < 256
+// 1 ≤ name.length ≤ 255
+func (n Name) validate() []failure.Violation {
+	return failure.Length("company.name", len(n), 1, 255)
 }
 
 func (id ID) Valid() bool {
 	return id > 0
 }
 
+// 呼び出し元以外を所有者とする作成
+var ErrForbiddenOwner = failure.New(failure.ErrForbidden, "forbidden: owner_id must be the caller")
+
 type Company struct {
 	ID        ID
 	Name      Name
@@ -38,6 +43,31 @@ func New(name Name, ownerid OwnerID) *Company {
 	}
 }
 
-func (c *Company) validCreate() bool {
-	return c.Name.valid() && c.OwnerID.Valid()
+// 作成時の入力値の違反
+func (c *Company) validateCreate() []failure.Violation {
+	return append(c.Name.validate(), failure.Min("company.owner_id", int(c.OwnerID), 1)...)
+}
+
+// 更新時の入力値の違反
+func (c *Company) validateUpdate() []failure.Violation {
+	return append(failure.Min("company_id", int(c.ID), 1), c.validateCreate()...)
+}
+
+// 一覧の条件
+type ListQuery struct {
+	// 空でなければ名前がこれで始まる会社に絞り込む
+	NamePrefix Name
+	page.Query
+}
+
+// name_prefix.length ≤ 255
+func (q ListQuery) validate() []failure.Violation {
+	return append(failure.Length("name_prefix", len(q.NamePrefix), 0, 255), q.Query.Validate()...)
+}
+
+// 一覧の1ページ
+type Page struct {
+	Companies []*Company
+	// 最後のページでは空
+	Next page.Cursor
 }
@@ -4,6 +4,8 @@ import (
 	"reflect"
 	"strings"
 	"testing"
+
+	"api.example.com/pkg/failure"
 )
 
 func TestNew(t *testing.T) {
@@ -46,17 +48,17 @@ func TestNew(t *testing.T) {
 	}
 }
 
-func TestCompany_validCreate(t *testing.T) {
+func TestCompany_validateCreate(t *testing.T) {
 	type test struct {
 		name    string
 		company *Company
-		want    bool
+		want    []failure.Violation
 	}
 
 	do := func(tt *test) {
 		t.Run(tt.name, func(t *testing.T) {
-			got := tt.company.validCreate()
-			if tt.want != got {
+			got := tt.company.validateCreate()
+			if !reflect.DeepEqual(tt.want, got) {
 				t.Fatalf("want=%v, got=%v.", tt.want, got)
 			}
 		})
@@ -66,32 +68,76 @@ func TestCompany_validCreate(t *testing.T) {
 		{
 			name:    "ok",
 			company: New("GREATE COMPANY", 1),
-			want:    true,
+			want:    nil,
 		},
 		{
 			name:    "name valid min",
 			company: New("G", 1),
-			want:    true,
+			want:    nil,
 		},
 		{
 			name:    "name valid max",
 			company: New(Name(strings.Repeat("1", 255)), 1),
-			want:    true,
+			want:    nil,
 		},
 		{
 			name:    "name invalid min",
 			company: New("", 1),
-			want:    false,
+			want:    []failure.Violation{{Field: "company.name", Rule: failure.RuleMinLength, Limit: 1, Actual: 0}},
 		},
 		{
 			name:    "name invalid max",
 			company: New(Name(strings.Repeat("1", 256)), 1),
-			want:    false,
+			want:    []failure.Violation{{Field: "company.name", Rule: failure.RuleMaxLength, Limit: 255, Actual: 256}},
 		},
 		{
 			name:    "name invalid ownerid",
 			company: New("GREATE COMPANY", 0),
-			want:    false,
+			want:    []failure.Violation{{Field: "company.owner_id", Rule: failure.RuleMin, Limit: 1, Actual: 0}},
+		},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+}
+
+func TestCompany_validateUpdate(t *testing.T) {
+	type test struct {
+		name    string
+		company *Company
+		want    []failure.Violation
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.company.validateUpdate()
+			if !reflect.DeepEqual(tt.want, got) {
+				t.Fatalf("want=%v, got=%v.", tt.want, got)
+			}
+		})
+	}
+
+	tests := []*test{
+		{
+			name:    "ok",
+			company: &Company{ID: 1, Name: "GREATE COMPANY", OwnerID: 1},
+			want:    nil,
+		},
+		{
+			name:    "invalid id",
+			company: &Company{ID: 0, Name: "GREATE COMPANY", OwnerID: 1},
+			want:    []failure.Violation{{Field: "company_id", Rule: failure.RuleMin, Limit: 1, Actual: 0}},
+		},
+		{
+			name:    "invalid name",
+			company: &Company{ID: 1, Name: "", OwnerID: 1},
+			want:    []failure.Violation{{Field: "company.name", Rule: failure.RuleMinLength, Limit: 1, Actual: 0}},
+		},
+		{
+			name:    "invalid ownerid",
+			company: &Company{ID: 1, Name: "GREATE COMPANY", OwnerID: 0},
+			want:    []failure.Violation{{Field: "company.owner_id", Rule: failure.RuleMin, Limit: 1, Actual: 0}},
 		},
 	}
 
@@ -1,18 +1,31 @@
 package company
 
 import (
-	"errors"
+	"context"
 	"fmt"
+
+	"api.example.com/pkg/failure"
+	"api.example.com/pkg/page"
 )
 
 type Repository interface {
-	CompanyCreate(*Company) (*Company, error)
-	CompanyRead(ID) (*Company, error)
+	CompanyCreate(context.Context, *Company) (*Company, error)
+	CompanyRead(context.Context, ID) (*Company, error)
+	// ユーザーが従業員である会社のうち、名前が prefix で始まり、 ID が after より大きい会社を ID の順に limit 件まで
+	CompanyList(ctx context.Context, userID OwnerID, prefix Name, after ID, limit int) ([]*Company, error)
+	CompanyUpdate(context.Context, *Company) (*Company, error)
+	CompanyDelete(context.Context, ID) error
 }
 
 type Server interface {
-	Create(*Company) (*Company, error)
-	Read(ID) (*Company, error)
+	// owner_id を省略すれば呼び出し元を所有者とし、呼び出し元以外を所有者にはできない
+	Create(ctx context.Context, c *Company, caller OwnerID) (*Company, error)
+	Read(context.Context, ID) (*Company, error)
+	// 呼び出し元が従業員である会社を ID の順に1ページずつ
+	List(ctx context.Context, q ListQuery, caller OwnerID) (*Page, error)
+	// 新しい所有者は会社の管理者であること
+	Update(context.Context, *Company) (*Company, error)
+	Delete(context.Context, ID) error
 }
 
 // impl Server
@@ -24,20 +37,67 @@ func NewServer(repo Repository) Server {
 	return &server{repo}
 }
 
-func (s *server) Create(c *Company) (*Company, error) {
-	if ok := c.validCreate(); !ok {
-		return nil, errors.New("invalid create")
+func (s *server) Create(ctx context.Context, c *Company, caller OwnerID) (*Company, error) {
+	if c.OwnerID == 0 {
+		c.OwnerID = caller
+	}
+
+	if c.OwnerID != caller {
+		return nil, fmt.Errorf("pkg/company.Create: %w", ErrForbiddenOwner)
 	}
 
-	return s.repository.CompanyCreate(c)
+	if v := c.validateCreate(); len(v) > 0 {
+		return nil, fmt.Errorf("pkg/company.Create: %w", failure.Invalid("invalid company", v))
+	}
+
+	return s.repository.CompanyCreate(ctx, c)
 }
 
-func (s *server) Read(id ID) (*Company, error) {
+func (s *server) Read(ctx context.Context, id ID) (*Company, error) {
 	ok := id.Valid()
 
 	if !ok {
-		return nil, fmt.Errorf("pkg/comopany.Read: invalid company_id")
+		return nil, fmt.Errorf("pkg/company.Read: %w", failure.New(failure.ErrInvalidArgument, "invalid company_id"))
+	}
+
+	return s.repository.CompanyRead(ctx, id)
+}
+
+func (s *server) List(ctx context.Context, q ListQuery, caller OwnerID) (*Page, error) {
+	if v := q.validate(); len(v) > 0 {
+		return nil, fmt.Errorf("pkg/company.List: %w", failure.Invalid("invalid query", v))
+	}
+
+	after, err := q.Cursor.After()
+	if err != nil {
+		return nil, fmt.Errorf("pkg/company.List: %w", err)
+	}
+
+	// 続きの有無を知るため1件多く読み込む
+	size := q.Size()
+	list, err := s.repository.CompanyList(ctx, caller, q.NamePrefix, ID(after), size+1)
+	if err != nil {
+		return nil, fmt.Errorf("pkg/company.List: %w", err)
+	}
+
+	list, next := page.Split(list, size, func(c *Company) int {
+		return int(c.ID)
+	})
+	return &Page{Companies: list, Next: next}, nil
+}
+
+func (s *server) Update(ctx context.Context, c *Company) (*Company, error) {
+	if v := c.validateUpdate(); len(v) > 0 {
+		return nil, fmt.Errorf("pkg/company.Update: %w", failure.Invalid("invalid company", v))
+	}
+
+	return s.repository.CompanyUpdate(ctx, c)
+}
+
+func (s *server) Delete(ctx context.Context, id ID) error {
+	if ok := id.Valid(); !ok {
+		return fmt.Errorf("pkg/company.Delete: %w", failure.New(failure.ErrInvalidArgument, "invalid company_id"))
 	}
 
-	return s.repository.CompanyRead(id)
+	return s.repository.CompanyDelete(ctx, id)
 }
@@ -1,26 +1,41 @@
 package company
 
 import (
+	"context"
 	"errors"
+	"fmt"
 	"reflect"
+	"strings"
 	"testing"
 	"time"
+
+	"api.example.com/pkg/failure"
+	"api.example.com/pkg/page"
 )
 
 // mock
 type makeRepository func(t *testing.T) Repository
 
 type repository struct {
-	company *Company
-	err     error
+	company   *Company
+	companies []*Company
+	err       error
 	// flag
 	create bool
 	read   bool
+	list   bool
+	update bool
+	delete bool
+	// CompanyList に渡された条件
+	userID OwnerID
+	prefix Name
+	after  ID
+	limit  int
 	// test
 	t *testing.T
 }
 
-func (r *repository) CompanyCreate(*Company) (*Company, error) {
+func (r *repository) CompanyCreate(context.Context, *Company) (*Company, error) {
 	r.t.Helper()
 
 	if r.create {
@@ -30,7 +45,7 @@ func (r *repository) CompanyCreate(*Company) (*Company, error) {
 	panic("invalid CompanyCreate")
 }
 
-func (r *repository) CompanyRead(ID) (*Company, error) {
+func (r *repository) CompanyRead(context.Context, ID) (*Company, error) {
 	if r.read {
 		return r.company, r.err
 	}
@@ -39,19 +54,51 @@ func (r *repository) CompanyRead(ID) (*Company, error) {
 	panic("invalid CompanyRead")
 }
 
+func (r *repository) CompanyList(_ context.Context, userID OwnerID, prefix Name, after ID, limit int) ([]*Company, error) {
+	r.t.Helper()
+
+	if r.list {
+		r.userID, r.prefix, r.after, r.limit = userID, prefix, after, limit
+		return r.companies, r.err
+	}
+	r.t.Fatal("invalid CompanyList")
+	panic("invalid CompanyList")
+}
+
+func (r *repository) CompanyUpdate(context.Context, *Company) (*Company, error) {
+	r.t.Helper()
+
+	if r.update {
+		return r.company, r.err
+	}
+	r.t.Fatal("invalid CompanyUpdate")
+	panic("invalid CompanyUpdate")
+}
+
+func (r *repository) CompanyDelete(context.Context, ID) error {
+	r.t.Helper()
+
+	if r.delete {
+		return r.err
+	}
+	r.t.Fatal("invalid CompanyDelete")
+	panic("invalid CompanyDelete")
+}
+
 func TestServer_Create(t *testing.T) {
 	type test struct {
 		name           string
 		makeRepository makeRepository
 		company        *Company
+		caller         OwnerID
 		want           *Company
-		wantErr        bool
+		wantErr        error
 	}
 
 	do := func(tt *test) {
 		t.Run(tt.name, func(t *testing.T) {
-			got, err := NewServer(tt.makeRepository(t)).Create(tt.company)
-			if tt.wantErr != (err != nil) {
+			got, err := NewServer(tt.makeRepository(t)).Create(context.Background(), tt.company, tt.caller)
+			if !errors.Is(err, tt.wantErr) {
 				t.Fatalf("want-erorr=%v, error=%v.", tt.wantErr, err)
 			}
 
@@ -68,6 +115,7 @@ func TestServer_Create(t *testing.T) {
 			return &test{
 				name:    "ok",
 				company: New("GREATE COMPANY", 1),
+				caller:  1,
 				makeRepository: func(t *testing.T) Repository {
 					return &repository{
 						company: &Company{
@@ -87,12 +135,37 @@ func TestServer_Create(t *testing.T) {
 					OwnerID:   1,
 					UpdatedAt: updatedAt,
 				},
-				wantErr: false,
+				wantErr: nil,
 			}
 		}(),
+		{
+			name:    "owner is caller",
+			company: New("GREATE COMPANY", 0),
+			caller:  1,
+			makeRepository: func(t *testing.T) Repository {
+				return &repository{
+					company: &Company{ID: 2, Name: "GREATE COMPANY", OwnerID: 1},
+					create:  true,
+					t:       t,
+				}
+			},
+			want:    &Company{ID: 2, Name: "GREATE COMPANY", OwnerID: 1},
+			wantErr: nil,
+		},
+		{
+			name:    "owner is not caller",
+			company: New("GREATE COMPANY", 2),
+			caller:  1,
+			makeRepository: func(t *testing.T) Repository {
+				return &repository{t: t}
+			},
+			want:    nil,
+			wantErr: failure.ErrForbidden,
+		},
 		{
 			name:    "invalid",
 			company: New("", 1),
+			caller:  1,
 			makeRepository: func(t *testing.T) Repository {
 				return &repository{
 					create: false,
@@ -100,7 +173,7 @@ func TestServer_Create(t *testing.T) {
 				}
 			},
 			want:    nil,
-			wantErr: true,
+			wantErr: failure.ErrInvalidArgument,
 		},
 	}
 
@@ -124,7 +197,7 @@ func TestServer_Read(t *testing.T) {
 
 	do := func(tt *test) {
 		t.Run(tt.name, func(t *testing.T) {
-			got, err := tt.server.Read(tt.args.id)
+			got, err := tt.server.Read(context.Background(), tt.args.id)
 			if tt.wantErr != (err != nil) {
 				t.Fatalf("want=%v, got%v.", tt.wantErr, err)
 			}
@@ -193,3 +266,274 @@ func TestServer_Read(t *testing.T) {
 		do(tt)
 	}
 }
+
+func TestServer_List(t *testing.T) {
+	type want struct {
+		page *Page
+		// リポジトリに渡した条件
+		userID OwnerID
+		prefix Name
+		after  ID
+		limit  int
+	}
+
+	type test struct {
+		name    string
+		list    []*Company
+		err     error
+		query   ListQuery
+		want    want
+		wantErr bool
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &repository{companies: tt.list, err: tt.err, list: true, t: t}
+			got, err := NewServer(repo).List(context.Background(), tt.query, 1)
+			if tt.wantErr != (err != nil) {
+				t.Fatalf("want-error=%v, error=%v.", tt.wantErr, err)
+			}
+
+			gotWant := want{page: got, userID: repo.userID, prefix: repo.prefix, after: repo.after, limit: repo.limit}
+			if !reflect.DeepEqual(tt.want, gotWant) {
+				t.Fatalf("want=%v, got=%v.", tt.want, gotWant)
+			}
+		})
+	}
+
+	// ID が 1 から n の会社
+	newList := func(n int) []*Company {
+		list := []*Company{}
+		for i := 1; i <= n; i++ {
+			list = append(list, &Company{ID: ID(i), Name: Name(fmt.Sprintf("company-%d", i)), OwnerID: 1})
+		}
+		return list
+	}
+
+	tests := []*test{
+		{
+			name:  "next page",
+			list:  newList(3),
+			query: ListQuery{NamePrefix: "company", Query: page.Query{Limit: 2}},
+			want: want{
+				page:   &Page{Companies: newList(2), Next: page.NewCursor(2)},
+				userID: 1,
+				prefix: "company",
+				limit:  3,
+			},
+		},
+		{
+			name:  "last page",
+			list:  newList(2),
+			query: ListQuery{Query: page.Query{Cursor: page.NewCursor(5), Limit: 2}},
+			want: want{
+				page:   &Page{Companies: newList(2)},
+				userID: 1,
+				after:  5,
+				limit:  3,
+			},
+		},
+		{
+			name:  "default limit",
+			list:  []*Company{},
+			query: ListQuery{},
+			want: want{
+				page:   &Page{Companies: []*Company{}},
+				userID: 1,
+				limit:  page.DefaultLimit + 1,
+			},
+		},
+		{
+			name:  "max limit",
+			list:  []*Company{},
+			query: ListQuery{Query: page.Query{Limit: 1000}},
+			want: want{
+				page:   &Page{Companies: []*Company{}},
+				userID: 1,
+				limit:  page.MaxLimit + 1,
+			},
+		},
+		{
+			name:    "invalid limit",
+			query:   ListQuery{Query: page.Query{Limit: -1}},
+			wantErr: true,
+		},
+		{
+			name:    "invalid cursor",
+			query:   ListQuery{Query: page.Query{Cursor: "xxx"}},
+			wantErr: true,
+		},
+		{
+			name:    "too long name_prefix",
+			query:   ListQuery{NamePrefix: Name(strings.Repeat("a", 256))},
+			wantErr: true,
+		},
+		{
+			name:    "failed list",
+			err:     errors.New("internal server error"),
+			query:   ListQuery{},
+			want:    want{userID: 1, limit: page.DefaultLimit + 1},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+}
+
+func TestServer_Update(t *testing.T) {
+	type test struct {
+		name           string
+		makeRepository makeRepository
+		company        *Company
+		want           *Company
+		wantErr        bool
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewServer(tt.makeRepository(t)).Update(context.Background(), tt.company)
+			if tt.wantErr != (err != nil) {
+				t.Fatalf("want-error=%v, error=%v.", tt.wantErr, err)
+			}
+
+			if !reflect.DeepEqual(tt.want, got) {
+				t.Fatalf("want=%v, got=%v.", tt.want, got)
+			}
+		})
+	}
+
+	tests := []*test{
+		{
+			name: "ok",
+			company: &Company{
+				ID:      2,
+				Name:    "greate company",
+				OwnerID: 1,
+			},
+			makeRepository: func(t *testing.T) Repository {
+				return &repository{
+					company: &Company{
+						ID:        2,
+						Name:      "greate company",
+						OwnerID:   1,
+						UpdatedAt: time.Date(2022, 9, 3, 12, 34, 56, 0, time.UTC),
+					},
+					update: true,
+					t:      t,
+				}
+			},
+			want: &Company{
+				ID:        2,
+				Name:      "greate company",
+				OwnerID:   1,
+				UpdatedAt: time.Date(2022, 9, 3, 12, 34, 56, 0, time.UTC),
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid company.id",
+			company: &Company{
+				ID:      0,
+				Name:    "greate company",
+				OwnerID: 1,
+			},
+			makeRepository: func(t *testing.T) Repository {
+				return &repository{t: t}
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "invalid company.name",
+			company: &Company{
+				ID:      2,
+				Name:    "",
+				OwnerID: 1,
+			},
+			makeRepository: func(t *testing.T) Repository {
+				return &repository{t: t}
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "failed update",
+			company: &Company{
+				ID:      2,
+				Name:    "greate company",
+				OwnerID: 1,
+			},
+			makeRepository: func(t *testing.T) Repository {
+				return &repository{
+					err:    errors.New("internal server error"),
+					update: true,
+					t:      t,
+				}
+			},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+}
+
+func TestServer_Delete(t *testing.T) {
+	type test struct {
+		name           string
+		makeRepository makeRepository
+		id             ID
+		wantErr        bool
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.name, func(t *testing.T) {
+			err := NewServer(tt.makeRepository(t)).Delete(context.Background(), tt.id)
+			if tt.wantErr != (err != nil) {
+				t.Fatalf("want-error=%v, error=%v.", tt.wantErr, err)
+			}
+		})
+	}
+
+	tests := []*test{
+		{
+			name: "ok",
+			id:   1,
+			makeRepository: func(t *testing.T) Repository {
+				return &repository{
+					delete: true,
+					t:      t,
+				}
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid company.id",
+			id:   0,
+			makeRepository: func(t *testing.T) Repository {
+				return &repository{t: t}
+			},
+			wantErr: true,
+		},
+		{
+			name: "failed delete",
+			id:   1,
+			makeRepository: func(t *testing.T) Repository {
+				return &repository{
+					err:    errors.New("internal server error"),
+					delete: true,
+					t:      t,
+				}
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+}
@@ -0,0 +1,50 @@
+package employee
+
+import (
+	"time"
+
+	"api.example.com/pkg/company"
+	"api.example.com/pkg/failure"
+	"api.example.com/pkg/user"
+)
+
+var (
+	// 会社の所有者の従業員を削除しようとした
+	ErrOwner = failure.Conflict("employee_id is the company owner", "employee_id", failure.RuleUnreferenced)
+	// 会社の最後の管理者を削除しようとした
+	ErrLastAdministrator = failure.Conflict("employee_id is the last administrator", "employee_id", failure.RuleOtherAdministrator)
+)
+
+// Employee ID
+type ID int
+
+func (id ID) Valid() bool {
+	return id > 0
+}
+
+// 所属する会社
+type CompanyID = company.ID
+
+// 従業員となるユーザー
+// ユーザーは複数の会社に所属することができる
+type UserID = user.ID
+
+type Employee struct {
+	ID            ID
+	CompanyID     CompanyID
+	UserID        UserID
+	Administrator bool
+	UpdatedAt     time.Time
+}
+
+func New(companyID CompanyID, userID UserID, administrator bool) *Employee {
+	return &Employee{
+		CompanyID:     companyID,
+		UserID:        userID,
+		Administrator: administrator,
+	}
+}
+
+func (e *Employee) validCreate() bool {
+	return e.CompanyID.Valid() && e.UserID.Valid()
+}
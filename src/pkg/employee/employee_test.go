@@ -0,0 +1,88 @@
+package employee
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNew(t *testing.T) {
+	type args struct {
+		companyID     CompanyID
+		userID        UserID
+		administrator bool
+	}
+
+	type test struct {
+		name string
+		args
+		want *Employee
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.name, func(t *testing.T) {
+			got := New(tt.companyID, tt.userID, tt.administrator)
+			if !reflect.DeepEqual(tt.want, got) {
+				t.Fatalf("want=%v, got=%v.", tt.want, got)
+			}
+		})
+	}
+
+	tests := []*test{
+		{
+			name: "ok",
+			args: args{
+				companyID:     1,
+				userID:        2,
+				administrator: true,
+			},
+			want: &Employee{
+				CompanyID:     1,
+				UserID:        2,
+				Administrator: true,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+}
+
+func TestEmployee_validCreate(t *testing.T) {
+	type test struct {
+		name     string
+		employee *Employee
+		want     bool
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.employee.validCreate()
+			if tt.want != got {
+				t.Fatalf("want=%v, got=%v.", tt.want, got)
+			}
+		})
+	}
+
+	tests := []*test{
+		{
+			name:     "ok",
+			employee: New(1, 2, false),
+			want:     true,
+		},
+		{
+			name:     "invalid company_id",
+			employee: New(0, 2, false),
+			want:     false,
+		},
+		{
+			name:     "invalid user_id",
+			employee: New(1, 0, false),
+			want:     false,
+		},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+}
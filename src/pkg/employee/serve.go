@@ -0,0 +1,64 @@
+package employee
+
+import (
+	"context"
+	"fmt"
+
+	"api.example.com/pkg/failure"
+)
+
+type Repository interface {
+	EmployeeCreate(context.Context, *Employee) (*Employee, error)
+	EmployeeRead(context.Context, CompanyID, ID) (*Employee, error)
+	EmployeeList(context.Context, CompanyID) ([]*Employee, error)
+	// 会社の所有者は ErrOwner, 最後の管理者は ErrLastAdministrator
+	EmployeeDelete(context.Context, CompanyID, ID) error
+}
+
+type Server interface {
+	Create(context.Context, *Employee) (*Employee, error)
+	Read(context.Context, CompanyID, ID) (*Employee, error)
+	List(context.Context, CompanyID) ([]*Employee, error)
+	Delete(context.Context, CompanyID, ID) error
+}
+
+// impl Server
+type server struct {
+	repository Repository
+}
+
+func NewServer(repo Repository) Server {
+	return &server{repo}
+}
+
+func (s *server) Create(ctx context.Context, e *Employee) (*Employee, error) {
+	if ok := e.validCreate(); !ok {
+		return nil, fmt.Errorf("pkg/employee.Create: %w", failure.New(failure.ErrInvalidArgument, "invalid employee"))
+	}
+
+	return s.repository.EmployeeCreate(ctx, e)
+}
+
+func (s *server) Read(ctx context.Context, companyID CompanyID, id ID) (*Employee, error) {
+	if ok := companyID.Valid() && id.Valid(); !ok {
+		return nil, fmt.Errorf("pkg/employee.Read: %w", failure.New(failure.ErrInvalidArgument, "invalid company_id or employee_id"))
+	}
+
+	return s.repository.EmployeeRead(ctx, companyID, id)
+}
+
+func (s *server) List(ctx context.Context, companyID CompanyID) ([]*Employee, error) {
+	if ok := companyID.Valid(); !ok {
+		return nil, fmt.Errorf("pkg/employee.List: %w", failure.New(failure.ErrInvalidArgument, "invalid company_id"))
+	}
+
+	return s.repository.EmployeeList(ctx, companyID)
+}
+
+func (s *server) Delete(ctx context.Context, companyID CompanyID, id ID) error {
+	if ok := companyID.Valid() && id.Valid(); !ok {
+		return fmt.Errorf("pkg/employee.Delete: %w", failure.New(failure.ErrInvalidArgument, "invalid company_id or employee_id"))
+	}
+
+	return s.repository.EmployeeDelete(ctx, companyID, id)
+}
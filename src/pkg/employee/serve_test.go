@@ -0,0 +1,358 @@
+package employee
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// mock
+type makeRepository func(t *testing.T) Repository
+
+type repository struct {
+	employee  *Employee
+	employees []*Employee
+	err       error
+	// flag
+	create, read, list, delete bool
+	// test
+	t *testing.T
+}
+
+func (r *repository) EmployeeCreate(context.Context, *Employee) (*Employee, error) {
+	r.t.Helper()
+
+	if r.create {
+		return r.employee, r.err
+	}
+	r.t.Fatal("invalid EmployeeCreate")
+	panic("invalid EmployeeCreate")
+}
+
+func (r *repository) EmployeeRead(context.Context, CompanyID, ID) (*Employee, error) {
+	r.t.Helper()
+
+	if r.read {
+		return r.employee, r.err
+	}
+	r.t.Fatal("invalid EmployeeRead")
+	panic("invalid EmployeeRead")
+}
+
+func (r *repository) EmployeeList(context.Context, CompanyID) ([]*Employee, error) {
+	r.t.Helper()
+
+	if r.list {
+		return r.employees, r.err
+	}
+	r.t.Fatal("invalid EmployeeList")
+	panic("invalid EmployeeList")
+}
+
+func (r *repository) EmployeeDelete(context.Context, CompanyID, ID) error {
+	r.t.Helper()
+
+	if r.delete {
+		return r.err
+	}
+	r.t.Fatal("invalid EmployeeDelete")
+	panic("invalid EmployeeDelete")
+}
+
+func TestServer_Create(t *testing.T) {
+	type test struct {
+		name           string
+		makeRepository makeRepository
+		employee       *Employee
+		want           *Employee
+		wantErr        bool
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewServer(tt.makeRepository(t)).Create(context.Background(), tt.employee)
+			if tt.wantErr != (err != nil) {
+				t.Fatalf("want-error=%v, error=%v.", tt.wantErr, err)
+			}
+
+			if !reflect.DeepEqual(tt.want, got) {
+				t.Fatalf("want=%v, got=%v.", tt.want, got)
+			}
+		})
+	}
+
+	tests := []*test{
+		{
+			name:     "ok",
+			employee: New(1, 2, false),
+			makeRepository: func(t *testing.T) Repository {
+				return &repository{
+					employee: &Employee{
+						ID:        3,
+						CompanyID: 1,
+						UserID:    2,
+						UpdatedAt: time.Date(2022, 9, 3, 12, 34, 56, 0, time.UTC),
+					},
+					create: true,
+					t:      t,
+				}
+			},
+			want: &Employee{
+				ID:        3,
+				CompanyID: 1,
+				UserID:    2,
+				UpdatedAt: time.Date(2022, 9, 3, 12, 34, 56, 0, time.UTC),
+			},
+			wantErr: false,
+		},
+		{
+			name:     "invalid",
+			employee: New(1, 0, false),
+			makeRepository: func(t *testing.T) Repository {
+				return &repository{t: t}
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:     "failed create",
+			employee: New(1, 2, false),
+			makeRepository: func(t *testing.T) Repository {
+				return &repository{
+					err:    errors.New("internal server error"),
+					create: true,
+					t:      t,
+				}
+			},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+}
+
+func TestServer_Read(t *testing.T) {
+	type args struct {
+		companyID CompanyID
+		id        ID
+	}
+
+	type test struct {
+		name           string
+		makeRepository makeRepository
+		args
+		want    *Employee
+		wantErr bool
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewServer(tt.makeRepository(t)).Read(context.Background(), tt.companyID, tt.id)
+			if tt.wantErr != (err != nil) {
+				t.Fatalf("want-error=%v, error=%v.", tt.wantErr, err)
+			}
+
+			if !reflect.DeepEqual(tt.want, got) {
+				t.Fatalf("want=%v, got=%v.", tt.want, got)
+			}
+		})
+	}
+
+	tests := []*test{
+		{
+			name: "ok",
+			args: args{companyID: 1, id: 3},
+			makeRepository: func(t *testing.T) Repository {
+				return &repository{
+					employee: &Employee{
+						ID:        3,
+						CompanyID: 1,
+						UserID:    2,
+						UpdatedAt: time.Date(2022, 9, 3, 12, 34, 56, 0, time.UTC),
+					},
+					read: true,
+					t:    t,
+				}
+			},
+			want: &Employee{
+				ID:        3,
+				CompanyID: 1,
+				UserID:    2,
+				UpdatedAt: time.Date(2022, 9, 3, 12, 34, 56, 0, time.UTC),
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid company_id",
+			args: args{companyID: 0, id: 3},
+			makeRepository: func(t *testing.T) Repository {
+				return &repository{t: t}
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "invalid employee_id",
+			args: args{companyID: 1, id: 0},
+			makeRepository: func(t *testing.T) Repository {
+				return &repository{t: t}
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "failed read",
+			args: args{companyID: 1, id: 3},
+			makeRepository: func(t *testing.T) Repository {
+				return &repository{
+					err:  errors.New("internal server error"),
+					read: true,
+					t:    t,
+				}
+			},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+}
+
+func TestServer_List(t *testing.T) {
+	type test struct {
+		name           string
+		makeRepository makeRepository
+		companyID      CompanyID
+		want           []*Employee
+		wantErr        bool
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewServer(tt.makeRepository(t)).List(context.Background(), tt.companyID)
+			if tt.wantErr != (err != nil) {
+				t.Fatalf("want-error=%v, error=%v.", tt.wantErr, err)
+			}
+
+			if !reflect.DeepEqual(tt.want, got) {
+				t.Fatalf("want=%v, got=%v.", tt.want, got)
+			}
+		})
+	}
+
+	tests := []*test{
+		{
+			name:      "ok",
+			companyID: 1,
+			makeRepository: func(t *testing.T) Repository {
+				return &repository{
+					employees: []*Employee{
+						{ID: 1, CompanyID: 1, UserID: 1, Administrator: true},
+						{ID: 3, CompanyID: 1, UserID: 2},
+					},
+					list: true,
+					t:    t,
+				}
+			},
+			want: []*Employee{
+				{ID: 1, CompanyID: 1, UserID: 1, Administrator: true},
+				{ID: 3, CompanyID: 1, UserID: 2},
+			},
+			wantErr: false,
+		},
+		{
+			name:      "invalid company_id",
+			companyID: 0,
+			makeRepository: func(t *testing.T) Repository {
+				return &repository{t: t}
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:      "failed list",
+			companyID: 1,
+			makeRepository: func(t *testing.T) Repository {
+				return &repository{
+					err:  errors.New("internal server error"),
+					list: true,
+					t:    t,
+				}
+			},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+}
+
+func TestServer_Delete(t *testing.T) {
+	type args struct {
+		companyID CompanyID
+		id        ID
+	}
+
+	type test struct {
+		name           string
+		makeRepository makeRepository
+		args
+		wantErr bool
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.name, func(t *testing.T) {
+			err := NewServer(tt.makeRepository(t)).Delete(context.Background(), tt.companyID, tt.id)
+			if tt.wantErr != (err != nil) {
+				t.Fatalf("want-error=%v, error=%v.", tt.wantErr, err)
+			}
+		})
+	}
+
+	tests := []*test{
+		{
+			name: "ok",
+			args: args{companyID: 1, id: 3},
+			makeRepository: func(t *testing.T) Repository {
+				return &repository{
+					delete: true,
+					t:      t,
+				}
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid employee_id",
+			args: args{companyID: 1, id: 0},
+			makeRepository: func(t *testing.T) Repository {
+				return &repository{t: t}
+			},
+			wantErr: true,
+		},
+		{
+			name: "failed delete",
+			args: args{companyID: 1, id: 3},
+			makeRepository: func(t *testing.T) Repository {
+				return &repository{
+					err:    errors.New("internal server error"),
+					delete: true,
+					t:      t,
+				}
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+}
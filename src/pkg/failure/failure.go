@@ -0,0 +1,75 @@
+// エラーの種類
+// 各パッケージのエラーはいずれかの種類を包み、種類ごとに HTTP のステータスコードへ対応付ける
+package failure
+
+import (
+	"errors"
+)
+
+var (
+	// 引数やリクエストの内容が不正
+	ErrInvalidArgument = errors.New("invalid argument")
+	// 対象が存在しない
+	ErrNotFound = errors.New("not found")
+	// 既存のデータと矛盾する
+	ErrConflict = errors.New("conflict")
+	// 呼び出し元を識別できない
+	ErrUnauthenticated = errors.New("unauthenticated")
+	// 呼び出し元に許可されていない
+	ErrForbidden = errors.New("forbidden")
+)
+
+// 全ての種類
+var kinds = []error{
+	ErrInvalidArgument,
+	ErrNotFound,
+	ErrConflict,
+	ErrUnauthenticated,
+	ErrForbidden,
+}
+
+// 種類と呼び出し元に返すメッセージを持つエラー
+type Error struct {
+	kind    error
+	message string
+	// 入力値や制約の違反
+	violations []Violation
+}
+
+func New(kind error, message string) error {
+	return &Error{kind, message, nil}
+}
+
+func (e *Error) Error() string {
+	return e.message
+}
+
+func (e *Error) Unwrap() error {
+	return e.kind
+}
+
+// err の種類
+// いずれの種類も包んでいなければ nil
+func Kind(err error) error {
+	for _, k := range kinds {
+		if errors.Is(err, k) {
+			return k
+		}
+	}
+	return nil
+}
+
+// 呼び出し元に返すメッセージ
+// 内部の情報を含めないため、Error でなければ種類のメッセージを返す
+// 種類もなければ空
+func Message(err error) string {
+	var e *Error
+	if errors.As(err, &e) {
+		return e.message
+	}
+
+	if k := Kind(err); k != nil {
+		return k.Error()
+	}
+	return ""
+}
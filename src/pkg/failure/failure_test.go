@@ -0,0 +1,90 @@
+package failure
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestKind(t *testing.T) {
+	type test struct {
+		name string
+		err  error
+		want error
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Kind(tt.err)
+			if tt.want != got {
+				t.Fatalf("want=%v, got=%v.", tt.want, got)
+			}
+		})
+	}
+
+	tests := []*test{
+		{
+			name: "kind",
+			err:  fmt.Errorf("pkg/user.Read: %w", ErrNotFound),
+			want: ErrNotFound,
+		},
+		{
+			name: "error",
+			err:  fmt.Errorf("pkg/role.Create: %w", New(ErrConflict, "role name already exists")),
+			want: ErrConflict,
+		},
+		{
+			name: "wrapped cause",
+			err:  fmt.Errorf("%w: %v", ErrForbidden, errors.New("sql: no rows in result set")),
+			want: ErrForbidden,
+		},
+		{
+			name: "unknown",
+			err:  errors.New("test error"),
+			want: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+}
+
+func TestMessage(t *testing.T) {
+	type test struct {
+		name string
+		err  error
+		want string
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Message(tt.err)
+			if tt.want != got {
+				t.Fatalf("want=%v, got=%v.", tt.want, got)
+			}
+		})
+	}
+
+	tests := []*test{
+		{
+			name: "error",
+			err:  fmt.Errorf("pkg/role.Create: %w", New(ErrConflict, "role name already exists")),
+			want: "role name already exists",
+		},
+		{
+			name: "kind",
+			err:  fmt.Errorf("repository/model.User.Read: %w: %v", ErrNotFound, errors.New("sql: no rows in result set")),
+			want: "not found",
+		},
+		{
+			name: "unknown",
+			err:  errors.New("dial tcp 127.0.0.1:3306: connect: connection refused"),
+			want: "",
+		},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+}
@@ -0,0 +1,92 @@
+package failure
+
+import (
+	"errors"
+)
+
+// 入力値が満たしていない規則
+type Violation struct {
+	// リクエスト上の位置 (例: user.password)
+	Field string
+	// 規則
+	Rule Rule
+	// 規則の値 (例: 最小の長さ)
+	Limit int
+	// 実際の値 (長さの規則では長さ)
+	Actual int
+}
+
+// 入力値の規則
+type Rule string
+
+const (
+	// 長さが Limit 以上
+	RuleMinLength Rule = "min_length"
+	// 長さが Limit 以下
+	RuleMaxLength Rule = "max_length"
+	// 値が Limit 以上
+	RuleMin Rule = "min"
+	// 値が他と重複しない
+	RuleUnique Rule = "unique"
+	// 参照先が存在する
+	RuleExists Rule = "exists"
+	// 他から参照されていない
+	RuleUnreferenced Rule = "unreferenced"
+	// 会社の管理者である
+	RuleAdministrator Rule = "administrator"
+	// 会社に他の管理者がいる
+	RuleOtherAdministrator Rule = "other_administrator"
+)
+
+// Limit と Actual を持つ規則であるか
+func (r Rule) Bounded() bool {
+	switch r {
+	case RuleMinLength, RuleMaxLength, RuleMin:
+		return true
+	default:
+		return false
+	}
+}
+
+// 長さが min 以上 max 以下であること
+func Length(field string, length, min, max int) []Violation {
+	switch {
+	case length < min:
+		return []Violation{{field, RuleMinLength, min, length}}
+	case length > max:
+		return []Violation{{field, RuleMaxLength, max, length}}
+	default:
+		return nil
+	}
+}
+
+// 値が min 以上であること
+func Min(field string, value, min int) []Violation {
+	if value < min {
+		return []Violation{{field, RuleMin, min, value}}
+	}
+	return nil
+}
+
+// 入力値の違反を持つ ErrInvalidArgument
+func Invalid(message string, violations []Violation) error {
+	return &Error{ErrInvalidArgument, message, violations}
+}
+
+// 制約に違反した項目を持つ ErrConflict
+// field が空であれば項目を持たない
+func Conflict(message, field string, rule Rule) error {
+	if field == "" {
+		return New(ErrConflict, message)
+	}
+	return &Error{ErrConflict, message, []Violation{{Field: field, Rule: rule}}}
+}
+
+// err が持つ入力値や制約の違反
+func Violations(err error) []Violation {
+	var e *Error
+	if errors.As(err, &e) {
+		return e.violations
+	}
+	return nil
+}
@@ -0,0 +1,206 @@
+package failure
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestLength(t *testing.T) {
+	type test struct {
+		name   string
+		length int
+		want   []Violation
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Length("user.password", tt.length, 8, 255)
+			if !reflect.DeepEqual(tt.want, got) {
+				t.Fatalf("want=%v, got=%v.", tt.want, got)
+			}
+		})
+	}
+
+	tests := []*test{
+		{
+			name:   "ok",
+			length: 8,
+			want:   nil,
+		},
+		{
+			name:   "max",
+			length: 255,
+			want:   nil,
+		},
+		{
+			name:   "too short",
+			length: 3,
+			want:   []Violation{{Field: "user.password", Rule: RuleMinLength, Limit: 8, Actual: 3}},
+		},
+		{
+			name:   "too long",
+			length: 256,
+			want:   []Violation{{Field: "user.password", Rule: RuleMaxLength, Limit: 255, Actual: 256}},
+		},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+}
+
+func TestMin(t *testing.T) {
+	type test struct {
+		name  string
+		value int
+		want  []Violation
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Min("company.owner_id", tt.value, 1)
+			if !reflect.DeepEqual(tt.want, got) {
+				t.Fatalf("want=%v, got=%v.", tt.want, got)
+			}
+		})
+	}
+
+	tests := []*test{
+		{
+			name:  "ok",
+			value: 1,
+			want:  nil,
+		},
+		{
+			name:  "zero",
+			value: 0,
+			want:  []Violation{{Field: "company.owner_id", Rule: RuleMin, Limit: 1, Actual: 0}},
+		},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+}
+
+func TestViolations(t *testing.T) {
+	list := []Violation{{Field: "user.name", Rule: RuleMinLength, Limit: 1, Actual: 0}}
+
+	type test struct {
+		name string
+		err  error
+		want []Violation
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Violations(tt.err)
+			if !reflect.DeepEqual(tt.want, got) {
+				t.Fatalf("want=%v, got=%v.", tt.want, got)
+			}
+		})
+	}
+
+	tests := []*test{
+		{
+			name: "invalid",
+			err:  fmt.Errorf("pkg/user.Create: %w", Invalid("invalid user", list)),
+			want: list,
+		},
+		{
+			name: "without violations",
+			err:  fmt.Errorf("pkg/user.Read: %w", New(ErrInvalidArgument, "invalid user_id")),
+			want: nil,
+		},
+		{
+			name: "other",
+			err:  errors.New("test error"),
+			want: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+
+	if !errors.Is(Invalid("invalid user", list), ErrInvalidArgument) {
+		t.Fatal("want ErrInvalidArgument")
+	}
+}
+
+func TestRule_Bounded(t *testing.T) {
+	type test struct {
+		rule Rule
+		want bool
+	}
+
+	do := func(tt *test) {
+		t.Run(string(tt.rule), func(t *testing.T) {
+			got := tt.rule.Bounded()
+			if tt.want != got {
+				t.Fatalf("want=%v, got=%v.", tt.want, got)
+			}
+		})
+	}
+
+	tests := []*test{
+		{rule: RuleMinLength, want: true},
+		{rule: RuleMaxLength, want: true},
+		{rule: RuleMin, want: true},
+		{rule: RuleUnique, want: false},
+		{rule: RuleExists, want: false},
+		{rule: RuleUnreferenced, want: false},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+}
+
+func TestConflict(t *testing.T) {
+	type test struct {
+		name       string
+		field      string
+		want       []Violation
+		wantString string
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Conflict("user.name already exists", tt.field, RuleUnique)
+			if !errors.Is(err, ErrConflict) {
+				t.Fatalf("want=%v, got=%v.", ErrConflict, err)
+			}
+
+			got := Violations(err)
+			if !reflect.DeepEqual(tt.want, got) {
+				t.Fatalf("want=%v, got=%v.", tt.want, got)
+			}
+
+			if tt.wantString != err.Error() {
+				t.Fatalf("want=%v, got=%v.", tt.wantString, err.Error())
+			}
+		})
+	}
+
+	tests := []*test{
+		{
+			name:       "with field",
+			field:      "user.name",
+			want:       []Violation{{Field: "user.name", Rule: RuleUnique}},
+			wantString: "user.name already exists",
+		},
+		{
+			name:       "without field",
+			field:      "",
+			want:       nil,
+			wantString: "user.name already exists",
+		},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+}
@@ -0,0 +1,57 @@
+package organization
+
+import (
+	"time"
+
+	"api.example.com/pkg/company"
+)
+
+// Department ID
+type ID int
+
+// 最上位の部署の親
+// 親を持たない部署は Root を親とする
+const Root ID = 0
+
+func (id ID) Valid() bool {
+	return id > 0
+}
+
+// Root または実在しうる部署
+func (id ID) validParent() bool {
+	return id == Root || id.Valid()
+}
+
+// 部署が所属する会社
+type CompanyID = company.ID
+
+// Department Name
+type Name string
+
+// 1 ≤ name.length ≤ 255
+func (n Name) valid() bool {
+	l := len(n)
+	return l > 0 && l < 256
+}
+
+// 部署
+// 部署は親を一つだけ持ち、子は任意の数だけ持つことができる
+type Department struct {
+	ID        ID
+	CompanyID CompanyID
+	ParentID  ID
+	Name      Name
+	UpdatedAt time.Time
+}
+
+func New(companyID CompanyID, parentID ID, name Name) *Department {
+	return &Department{
+		CompanyID: companyID,
+		ParentID:  parentID,
+		Name:      name,
+	}
+}
+
+func (d *Department) validCreate() bool {
+	return d.CompanyID.Valid() && d.ParentID.validParent() && d.Name.valid()
+}
@@ -0,0 +1,73 @@
+package organization
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestNew(t *testing.T) {
+	got := New(1, 2, "開発部")
+	want := &Department{
+		CompanyID: 1,
+		ParentID:  2,
+		Name:      "開発部",
+	}
+	if !reflect.DeepEqual(want, got) {
+		t.Fatalf("want=%v, got=%v.", want, got)
+	}
+}
+
+func TestDepartment_validCreate(t *testing.T) {
+	type test struct {
+		name       string
+		department *Department
+		want       bool
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.department.validCreate()
+			if tt.want != got {
+				t.Fatalf("want=%v, got=%v.", tt.want, got)
+			}
+		})
+	}
+
+	tests := []*test{
+		{
+			name:       "ok",
+			department: New(1, 2, "開発部"),
+			want:       true,
+		},
+		{
+			name:       "root",
+			department: New(1, Root, "営業部"),
+			want:       true,
+		},
+		{
+			name:       "invalid company_id",
+			department: New(0, Root, "営業部"),
+			want:       false,
+		},
+		{
+			name:       "invalid parent_id",
+			department: New(1, -1, "営業部"),
+			want:       false,
+		},
+		{
+			name:       "empty name",
+			department: New(1, Root, ""),
+			want:       false,
+		},
+		{
+			name:       "too long name",
+			department: New(1, Root, Name(strings.Repeat("a", 256))),
+			want:       false,
+		},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+}
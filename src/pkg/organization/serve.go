@@ -0,0 +1,149 @@
+package organization
+
+import (
+	"context"
+	"fmt"
+
+	"api.example.com/pkg/failure"
+)
+
+var (
+	// 部署を自身または自身の配下へ移動しようとした
+	ErrCycle = failure.New(failure.ErrInvalidArgument, "department cycle")
+	// 子を持つ部署を削除しようとした
+	ErrHasChildren = failure.New(failure.ErrConflict, "department has children")
+)
+
+type Repository interface {
+	DepartmentCreate(context.Context, *Department) (*Department, error)
+	DepartmentRead(context.Context, CompanyID, ID) (*Department, error)
+	// 親は変更しない
+	DepartmentUpdate(context.Context, *Department) (*Department, error)
+	// 配下の部署ごと ParentID の子へ移動する
+	DepartmentMove(context.Context, *Department) (*Department, error)
+	// 子を持つ部署は ErrHasChildren
+	DepartmentDelete(context.Context, CompanyID, ID) error
+	// 直下の子部署, Root の場合は最上位の部署
+	DepartmentChildren(context.Context, CompanyID, ID) ([]*Department, error)
+	// 最上位から親までの部署, 自身は含まない
+	DepartmentAncestors(context.Context, CompanyID, ID) ([]*Department, error)
+	// 自身と配下の全ての部署
+	DepartmentSubtree(context.Context, CompanyID, ID) ([]*Department, error)
+}
+
+type Server interface {
+	Create(context.Context, *Department) (*Department, error)
+	Read(context.Context, CompanyID, ID) (*Department, error)
+	Rename(context.Context, CompanyID, ID, Name) (*Department, error)
+	Move(context.Context, CompanyID, ID, ID) (*Department, error)
+	Delete(context.Context, CompanyID, ID) error
+	Children(context.Context, CompanyID, ID) ([]*Department, error)
+	Ancestors(context.Context, CompanyID, ID) ([]*Department, error)
+	Subtree(context.Context, CompanyID, ID) ([]*Department, error)
+}
+
+// impl Server
+type server struct {
+	repository Repository
+}
+
+func NewServer(repo Repository) Server {
+	return &server{repo}
+}
+
+func (s *server) Create(ctx context.Context, d *Department) (*Department, error) {
+	if ok := d.validCreate(); !ok {
+		return nil, fmt.Errorf("pkg/organization.Create: %w", failure.New(failure.ErrInvalidArgument, "invalid department"))
+	}
+
+	return s.repository.DepartmentCreate(ctx, d)
+}
+
+func (s *server) Read(ctx context.Context, companyID CompanyID, id ID) (*Department, error) {
+	if ok := companyID.Valid() && id.Valid(); !ok {
+		return nil, fmt.Errorf("pkg/organization.Read: %w", failure.New(failure.ErrInvalidArgument, "invalid company_id or department_id"))
+	}
+
+	return s.repository.DepartmentRead(ctx, companyID, id)
+}
+
+func (s *server) Rename(ctx context.Context, companyID CompanyID, id ID, name Name) (*Department, error) {
+	if ok := companyID.Valid() && id.Valid() && name.valid(); !ok {
+		return nil, fmt.Errorf("pkg/organization.Rename: %w", failure.New(failure.ErrInvalidArgument, "invalid department"))
+	}
+
+	d, err := s.repository.DepartmentRead(ctx, companyID, id)
+	if err != nil {
+		return nil, fmt.Errorf("pkg/organization.Rename: %w", err)
+	}
+
+	d.Name = name
+	return s.repository.DepartmentUpdate(ctx, d)
+}
+
+// 部署を parentID の子へ移動する
+// 配下の部署も一緒に移動する
+func (s *server) Move(ctx context.Context, companyID CompanyID, id ID, parentID ID) (*Department, error) {
+	if ok := companyID.Valid() && id.Valid() && parentID.validParent(); !ok {
+		return nil, fmt.Errorf("pkg/organization.Move: %w", failure.New(failure.ErrInvalidArgument, "invalid department"))
+	}
+
+	if parentID == id {
+		return nil, fmt.Errorf("pkg/organization.Move: %w", ErrCycle)
+	}
+
+	if parentID != Root {
+		// 移動先の祖先に自身が含まれる場合は、自身の配下への移動となる
+		ancestors, err := s.repository.DepartmentAncestors(ctx, companyID, parentID)
+		if err != nil {
+			return nil, fmt.Errorf("pkg/organization.Move: %w", err)
+		}
+
+		for _, a := range ancestors {
+			if a.ID == id {
+				return nil, fmt.Errorf("pkg/organization.Move: %w", ErrCycle)
+			}
+		}
+	}
+
+	d, err := s.repository.DepartmentRead(ctx, companyID, id)
+	if err != nil {
+		return nil, fmt.Errorf("pkg/organization.Move: %w", err)
+	}
+
+	d.ParentID = parentID
+	return s.repository.DepartmentMove(ctx, d)
+}
+
+// 子を持つ部署は削除できない
+func (s *server) Delete(ctx context.Context, companyID CompanyID, id ID) error {
+	if ok := companyID.Valid() && id.Valid(); !ok {
+		return fmt.Errorf("pkg/organization.Delete: %w", failure.New(failure.ErrInvalidArgument, "invalid company_id or department_id"))
+	}
+
+	return s.repository.DepartmentDelete(ctx, companyID, id)
+}
+
+func (s *server) Children(ctx context.Context, companyID CompanyID, id ID) ([]*Department, error) {
+	if ok := companyID.Valid() && id.validParent(); !ok {
+		return nil, fmt.Errorf("pkg/organization.Children: %w", failure.New(failure.ErrInvalidArgument, "invalid company_id or department_id"))
+	}
+
+	return s.repository.DepartmentChildren(ctx, companyID, id)
+}
+
+func (s *server) Ancestors(ctx context.Context, companyID CompanyID, id ID) ([]*Department, error) {
+	if ok := companyID.Valid() && id.Valid(); !ok {
+		return nil, fmt.Errorf("pkg/organization.Ancestors: %w", failure.New(failure.ErrInvalidArgument, "invalid company_id or department_id"))
+	}
+
+	return s.repository.DepartmentAncestors(ctx, companyID, id)
+}
+
+func (s *server) Subtree(ctx context.Context, companyID CompanyID, id ID) ([]*Department, error) {
+	if ok := companyID.Valid() && id.Valid(); !ok {
+		return nil, fmt.Errorf("pkg/organization.Subtree: %w", failure.New(failure.ErrInvalidArgument, "invalid company_id or department_id"))
+	}
+
+	return s.repository.DepartmentSubtree(ctx, companyID, id)
+}
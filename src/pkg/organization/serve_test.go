@@ -0,0 +1,589 @@
+package organization
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"api.example.com/pkg/failure"
+)
+
+// mock
+type makeRepository func(t *testing.T) Repository
+
+type repository struct {
+	department *Department
+	children   []*Department
+	ancestors  []*Department
+	subtree    []*Department
+	err        error
+	// flag
+	create, read, update, move, delete       bool
+	listChildren, listAncestors, listSubtree bool
+	// test
+	t *testing.T
+}
+
+func (r *repository) DepartmentCreate(context.Context, *Department) (*Department, error) {
+	r.t.Helper()
+
+	if r.create {
+		return r.department, r.err
+	}
+	r.t.Fatal("invalid DepartmentCreate")
+	panic("invalid DepartmentCreate")
+}
+
+func (r *repository) DepartmentRead(context.Context, CompanyID, ID) (*Department, error) {
+	r.t.Helper()
+
+	if r.read {
+		// 呼び出し側での変更が mock に影響しないようにする
+		d := *r.department
+		return &d, r.err
+	}
+	r.t.Fatal("invalid DepartmentRead")
+	panic("invalid DepartmentRead")
+}
+
+func (r *repository) DepartmentUpdate(_ context.Context, d *Department) (*Department, error) {
+	r.t.Helper()
+
+	if r.update {
+		return d, r.err
+	}
+	r.t.Fatal("invalid DepartmentUpdate")
+	panic("invalid DepartmentUpdate")
+}
+
+func (r *repository) DepartmentMove(_ context.Context, d *Department) (*Department, error) {
+	r.t.Helper()
+
+	if r.move {
+		return d, r.err
+	}
+	r.t.Fatal("invalid DepartmentMove")
+	panic("invalid DepartmentMove")
+}
+
+func (r *repository) DepartmentDelete(context.Context, CompanyID, ID) error {
+	r.t.Helper()
+
+	if r.delete {
+		return r.err
+	}
+	r.t.Fatal("invalid DepartmentDelete")
+	panic("invalid DepartmentDelete")
+}
+
+func (r *repository) DepartmentChildren(context.Context, CompanyID, ID) ([]*Department, error) {
+	r.t.Helper()
+
+	if r.listChildren {
+		return r.children, r.err
+	}
+	r.t.Fatal("invalid DepartmentChildren")
+	panic("invalid DepartmentChildren")
+}
+
+func (r *repository) DepartmentAncestors(context.Context, CompanyID, ID) ([]*Department, error) {
+	r.t.Helper()
+
+	if r.listAncestors {
+		return r.ancestors, r.err
+	}
+	r.t.Fatal("invalid DepartmentAncestors")
+	panic("invalid DepartmentAncestors")
+}
+
+func (r *repository) DepartmentSubtree(context.Context, CompanyID, ID) ([]*Department, error) {
+	r.t.Helper()
+
+	if r.listSubtree {
+		return r.subtree, r.err
+	}
+	r.t.Fatal("invalid DepartmentSubtree")
+	panic("invalid DepartmentSubtree")
+}
+
+func TestServer_Create(t *testing.T) {
+	type test struct {
+		name           string
+		makeRepository makeRepository
+		department     *Department
+		want           *Department
+		wantErr        bool
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewServer(tt.makeRepository(t)).Create(context.Background(), tt.department)
+			if tt.wantErr != (err != nil) {
+				t.Fatalf("want-error=%v, error=%v.", tt.wantErr, err)
+			}
+
+			if !reflect.DeepEqual(tt.want, got) {
+				t.Fatalf("want=%v, got=%v.", tt.want, got)
+			}
+		})
+	}
+
+	updatedAt := time.Date(2022, 9, 3, 12, 34, 56, 0, time.UTC)
+
+	tests := []*test{
+		{
+			name:       "ok",
+			department: New(1, Root, "開発部"),
+			makeRepository: func(t *testing.T) Repository {
+				return &repository{
+					department: &Department{ID: 3, CompanyID: 1, Name: "開発部", UpdatedAt: updatedAt},
+					create:     true,
+					t:          t,
+				}
+			},
+			want:    &Department{ID: 3, CompanyID: 1, Name: "開発部", UpdatedAt: updatedAt},
+			wantErr: false,
+		},
+		{
+			name:       "invalid",
+			department: New(1, Root, ""),
+			makeRepository: func(t *testing.T) Repository {
+				return &repository{t: t}
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:       "failed",
+			department: New(1, 2, "開発一課"),
+			makeRepository: func(t *testing.T) Repository {
+				return &repository{
+					err:    errors.New("parent not found"),
+					create: true,
+					t:      t,
+				}
+			},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+}
+
+func TestServer_Rename(t *testing.T) {
+	type args struct {
+		companyID CompanyID
+		id        ID
+		name      Name
+	}
+
+	type test struct {
+		name           string
+		args           args
+		makeRepository makeRepository
+		want           *Department
+		wantErr        bool
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewServer(tt.makeRepository(t)).Rename(context.Background(), tt.args.companyID, tt.args.id, tt.args.name)
+			if tt.wantErr != (err != nil) {
+				t.Fatalf("want-error=%v, error=%v.", tt.wantErr, err)
+			}
+
+			if !reflect.DeepEqual(tt.want, got) {
+				t.Fatalf("want=%v, got=%v.", tt.want, got)
+			}
+		})
+	}
+
+	tests := []*test{
+		{
+			name: "ok",
+			args: args{companyID: 1, id: 3, name: "開発本部"},
+			makeRepository: func(t *testing.T) Repository {
+				return &repository{
+					department: &Department{ID: 3, CompanyID: 1, ParentID: 2, Name: "開発部"},
+					read:       true,
+					update:     true,
+					t:          t,
+				}
+			},
+			want:    &Department{ID: 3, CompanyID: 1, ParentID: 2, Name: "開発本部"},
+			wantErr: false,
+		},
+		{
+			name: "invalid name",
+			args: args{companyID: 1, id: 3, name: ""},
+			makeRepository: func(t *testing.T) Repository {
+				return &repository{t: t}
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "not found",
+			args: args{companyID: 1, id: 3, name: "開発本部"},
+			makeRepository: func(t *testing.T) Repository {
+				return &repository{
+					department: &Department{},
+					err:        errors.New("not found"),
+					read:       true,
+					t:          t,
+				}
+			},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+}
+
+func TestServer_Move(t *testing.T) {
+	type args struct {
+		companyID CompanyID
+		id        ID
+		parentID  ID
+	}
+
+	type test struct {
+		name           string
+		args           args
+		makeRepository makeRepository
+		want           *Department
+		wantErr        error
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewServer(tt.makeRepository(t)).Move(context.Background(), tt.args.companyID, tt.args.id, tt.args.parentID)
+			if tt.wantErr == nil && err != nil {
+				t.Fatalf("error=%v.", err)
+			}
+
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Fatalf("want-error=%v, error=%v.", tt.wantErr, err)
+			}
+
+			if !reflect.DeepEqual(tt.want, got) {
+				t.Fatalf("want=%v, got=%v.", tt.want, got)
+			}
+		})
+	}
+
+	errInvalid := errors.New("")
+
+	tests := []*test{
+		{
+			name: "ok",
+			args: args{companyID: 1, id: 3, parentID: 5},
+			makeRepository: func(t *testing.T) Repository {
+				return &repository{
+					department:    &Department{ID: 3, CompanyID: 1, ParentID: 2, Name: "開発一課"},
+					ancestors:     []*Department{{ID: 4}},
+					read:          true,
+					move:          true,
+					listAncestors: true,
+					t:             t,
+				}
+			},
+			want:    &Department{ID: 3, CompanyID: 1, ParentID: 5, Name: "開発一課"},
+			wantErr: nil,
+		},
+		{
+			name: "to root",
+			args: args{companyID: 1, id: 3, parentID: Root},
+			makeRepository: func(t *testing.T) Repository {
+				return &repository{
+					department: &Department{ID: 3, CompanyID: 1, ParentID: 2, Name: "開発一課"},
+					read:       true,
+					move:       true,
+					t:          t,
+				}
+			},
+			want:    &Department{ID: 3, CompanyID: 1, ParentID: Root, Name: "開発一課"},
+			wantErr: nil,
+		},
+		{
+			name: "to self",
+			args: args{companyID: 1, id: 3, parentID: 3},
+			makeRepository: func(t *testing.T) Repository {
+				return &repository{t: t}
+			},
+			want:    nil,
+			wantErr: ErrCycle,
+		},
+		{
+			name: "to descendant",
+			args: args{companyID: 1, id: 3, parentID: 5},
+			makeRepository: func(t *testing.T) Repository {
+				return &repository{
+					ancestors:     []*Department{{ID: 2}, {ID: 3}, {ID: 4}},
+					listAncestors: true,
+					t:             t,
+				}
+			},
+			want:    nil,
+			wantErr: ErrCycle,
+		},
+		{
+			name: "parent not found",
+			args: args{companyID: 1, id: 3, parentID: 5},
+			makeRepository: func(t *testing.T) Repository {
+				return &repository{
+					err:           errInvalid,
+					listAncestors: true,
+					t:             t,
+				}
+			},
+			want:    nil,
+			wantErr: errInvalid,
+		},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+}
+
+func TestServer_Delete(t *testing.T) {
+	type test struct {
+		name           string
+		id             ID
+		makeRepository makeRepository
+		wantErr        error
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.name, func(t *testing.T) {
+			err := NewServer(tt.makeRepository(t)).Delete(context.Background(), 1, tt.id)
+			if tt.wantErr == nil && err != nil {
+				t.Fatalf("error=%v.", err)
+			}
+
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Fatalf("want-error=%v, error=%v.", tt.wantErr, err)
+			}
+		})
+	}
+
+	tests := []*test{
+		{
+			name: "ok",
+			id:   3,
+			makeRepository: func(t *testing.T) Repository {
+				return &repository{
+					delete: true,
+					t:      t,
+				}
+			},
+			wantErr: nil,
+		},
+		{
+			name: "has children",
+			id:   3,
+			makeRepository: func(t *testing.T) Repository {
+				return &repository{
+					err:    ErrHasChildren,
+					delete: true,
+					t:      t,
+				}
+			},
+			wantErr: ErrHasChildren,
+		},
+		{
+			name: "invalid",
+			id:   0,
+			makeRepository: func(t *testing.T) Repository {
+				return &repository{t: t}
+			},
+			wantErr: failure.ErrInvalidArgument,
+		},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+}
+
+func TestServer_Children(t *testing.T) {
+	type test struct {
+		name           string
+		id             ID
+		makeRepository makeRepository
+		want           []*Department
+		wantErr        bool
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewServer(tt.makeRepository(t)).Children(context.Background(), 1, tt.id)
+			if tt.wantErr != (err != nil) {
+				t.Fatalf("want-error=%v, error=%v.", tt.wantErr, err)
+			}
+
+			if !reflect.DeepEqual(tt.want, got) {
+				t.Fatalf("want=%v, got=%v.", tt.want, got)
+			}
+		})
+	}
+
+	tests := []*test{
+		{
+			name: "ok",
+			id:   2,
+			makeRepository: func(t *testing.T) Repository {
+				return &repository{
+					children:     []*Department{{ID: 3, ParentID: 2}},
+					listChildren: true,
+					t:            t,
+				}
+			},
+			want:    []*Department{{ID: 3, ParentID: 2}},
+			wantErr: false,
+		},
+		{
+			name: "root",
+			id:   Root,
+			makeRepository: func(t *testing.T) Repository {
+				return &repository{
+					children:     []*Department{{ID: 2}},
+					listChildren: true,
+					t:            t,
+				}
+			},
+			want:    []*Department{{ID: 2}},
+			wantErr: false,
+		},
+		{
+			name: "invalid",
+			id:   -1,
+			makeRepository: func(t *testing.T) Repository {
+				return &repository{t: t}
+			},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+}
+
+func TestServer_Ancestors(t *testing.T) {
+	type test struct {
+		name           string
+		id             ID
+		makeRepository makeRepository
+		want           []*Department
+		wantErr        bool
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewServer(tt.makeRepository(t)).Ancestors(context.Background(), 1, tt.id)
+			if tt.wantErr != (err != nil) {
+				t.Fatalf("want-error=%v, error=%v.", tt.wantErr, err)
+			}
+
+			if !reflect.DeepEqual(tt.want, got) {
+				t.Fatalf("want=%v, got=%v.", tt.want, got)
+			}
+		})
+	}
+
+	tests := []*test{
+		{
+			name: "ok",
+			id:   3,
+			makeRepository: func(t *testing.T) Repository {
+				return &repository{
+					ancestors:     []*Department{{ID: 1}, {ID: 2, ParentID: 1}},
+					listAncestors: true,
+					t:             t,
+				}
+			},
+			want:    []*Department{{ID: 1}, {ID: 2, ParentID: 1}},
+			wantErr: false,
+		},
+		{
+			name: "root is not department",
+			id:   Root,
+			makeRepository: func(t *testing.T) Repository {
+				return &repository{t: t}
+			},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+}
+
+func TestServer_Subtree(t *testing.T) {
+	type test struct {
+		name           string
+		id             ID
+		makeRepository makeRepository
+		want           []*Department
+		wantErr        bool
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewServer(tt.makeRepository(t)).Subtree(context.Background(), 1, tt.id)
+			if tt.wantErr != (err != nil) {
+				t.Fatalf("want-error=%v, error=%v.", tt.wantErr, err)
+			}
+
+			if !reflect.DeepEqual(tt.want, got) {
+				t.Fatalf("want=%v, got=%v.", tt.want, got)
+			}
+		})
+	}
+
+	tests := []*test{
+		{
+			name: "ok",
+			id:   2,
+			makeRepository: func(t *testing.T) Repository {
+				return &repository{
+					subtree:     []*Department{{ID: 2}, {ID: 3, ParentID: 2}, {ID: 4, ParentID: 3}},
+					listSubtree: true,
+					t:           t,
+				}
+			},
+			want:    []*Department{{ID: 2}, {ID: 3, ParentID: 2}, {ID: 4, ParentID: 3}},
+			wantErr: false,
+		},
+		{
+			name: "failed",
+			id:   2,
+			makeRepository: func(t *testing.T) Repository {
+				return &repository{
+					err:         errors.New("not found"),
+					listSubtree: true,
+					t:           t,
+				}
+			},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+}
@@ -0,0 +1,81 @@
+// 一覧のページ分割
+// 一覧は ID の昇順に並べ、前のページの最後の ID より大きいものを次のページとする (keyset pagination)
+package page
+
+import (
+	"encoding/base64"
+	"strconv"
+
+	"api.example.com/pkg/failure"
+)
+
+const (
+	// 件数を指定しない場合の1ページの件数
+	DefaultLimit = 20
+	// 1ページの最大の件数
+	MaxLimit = 100
+)
+
+// 次のページの位置
+// 前のページの最後の ID を符号化したもので、呼び出し元は中身に依存しないこと
+// 空であれば先頭のページ
+type Cursor string
+
+func NewCursor(id int) Cursor {
+	return Cursor(base64.RawURLEncoding.EncodeToString([]byte(strconv.Itoa(id))))
+}
+
+// この ID より大きいものを読み込む
+// 空であれば 0
+func (c Cursor) After() (int, error) {
+	if c == "" {
+		return 0, nil
+	}
+
+	b, err := base64.RawURLEncoding.DecodeString(string(c))
+	if err != nil {
+		return 0, failure.New(failure.ErrInvalidArgument, "invalid cursor")
+	}
+
+	id, err := strconv.Atoi(string(b))
+	if err != nil || id < 1 {
+		return 0, failure.New(failure.ErrInvalidArgument, "invalid cursor")
+	}
+
+	return id, nil
+}
+
+// 一覧の読み込み位置と件数
+type Query struct {
+	Cursor Cursor
+	// 0 であれば DefaultLimit、 MaxLimit を超えれば MaxLimit とする
+	Limit int
+}
+
+// 0 ≤ limit
+func (q Query) Validate() []failure.Violation {
+	return failure.Min("limit", q.Limit, 0)
+}
+
+// 1ページの件数
+func (q Query) Size() int {
+	switch {
+	case q.Limit == 0:
+		return DefaultLimit
+	case q.Limit > MaxLimit:
+		return MaxLimit
+	default:
+		return q.Limit
+	}
+}
+
+// size より1件多く読み込んだ list を size 件に切り詰める
+// 続きがあれば、最後の要素の ID を次のページのカーソルとする
+func Split[T any](list []T, size int, id func(T) int) ([]T, Cursor) {
+	if len(list) <= size {
+		return list, ""
+	}
+
+	list = list[:size]
+	return list, NewCursor(id(list[size-1]))
+}
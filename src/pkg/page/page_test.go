@@ -0,0 +1,164 @@
+package page
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"api.example.com/pkg/failure"
+)
+
+func TestCursor(t *testing.T) {
+	type test struct {
+		name    string
+		cursor  Cursor
+		want    int
+		wantErr error
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.cursor.After()
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("want=%v, got=%v.", tt.wantErr, err)
+			}
+
+			if tt.want != got {
+				t.Fatalf("want=%v, got=%v.", tt.want, got)
+			}
+		})
+	}
+
+	tests := []*test{
+		{
+			name:   "ok",
+			cursor: NewCursor(42),
+			want:   42,
+		},
+		{
+			name:   "first page",
+			cursor: "",
+			want:   0,
+		},
+		{
+			name:    "invalid base64",
+			cursor:  "!!",
+			wantErr: failure.ErrInvalidArgument,
+		},
+		{
+			name:    "not a number",
+			cursor:  Cursor("eHh4"),
+			wantErr: failure.ErrInvalidArgument,
+		},
+		{
+			name:    "zero",
+			cursor:  NewCursor(0),
+			wantErr: failure.ErrInvalidArgument,
+		},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+}
+
+func TestQuery(t *testing.T) {
+	type test struct {
+		name      string
+		limit     int
+		want      int
+		violation []failure.Violation
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.name, func(t *testing.T) {
+			q := Query{Limit: tt.limit}
+			if got := q.Validate(); !reflect.DeepEqual(tt.violation, got) {
+				t.Fatalf("want=%v, got=%v.", tt.violation, got)
+			}
+
+			if got := q.Size(); tt.want != got {
+				t.Fatalf("want=%v, got=%v.", tt.want, got)
+			}
+		})
+	}
+
+	tests := []*test{
+		{
+			name:  "ok",
+			limit: 10,
+			want:  10,
+		},
+		{
+			name:  "default",
+			limit: 0,
+			want:  DefaultLimit,
+		},
+		{
+			name:  "max",
+			limit: MaxLimit + 1,
+			want:  MaxLimit,
+		},
+		{
+			name:      "negative",
+			limit:     -1,
+			want:      -1,
+			violation: []failure.Violation{{Field: "limit", Rule: failure.RuleMin, Limit: 0, Actual: -1}},
+		},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+}
+
+func TestSplit(t *testing.T) {
+	type test struct {
+		name       string
+		list       []int
+		size       int
+		want       []int
+		wantCursor Cursor
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.name, func(t *testing.T) {
+			got, cursor := Split(tt.list, tt.size, func(id int) int {
+				return id
+			})
+			if !reflect.DeepEqual(tt.want, got) {
+				t.Fatalf("want=%v, got=%v.", tt.want, got)
+			}
+
+			if tt.wantCursor != cursor {
+				t.Fatalf("want=%v, got=%v.", tt.wantCursor, cursor)
+			}
+		})
+	}
+
+	tests := []*test{
+		{
+			name:       "next page",
+			list:       []int{3, 5, 8},
+			size:       2,
+			want:       []int{3, 5},
+			wantCursor: NewCursor(5),
+		},
+		{
+			name: "last page",
+			list: []int{3, 5},
+			size: 2,
+			want: []int{3, 5},
+		},
+		{
+			name: "empty",
+			list: []int{},
+			size: 2,
+			want: []int{},
+		},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+}
@@ -0,0 +1,63 @@
+package permission
+
+import (
+	"api.example.com/pkg/company"
+	"api.example.com/pkg/employee"
+	"api.example.com/pkg/failure"
+)
+
+// 管理者の操作権限
+type Permission string
+
+const (
+	// 従業員の登録、削除、配置
+	ManageEmployees Permission = "manage_employees"
+	// 部署の登録、変更、削除
+	ManageDepartments Permission = "manage_departments"
+	// 肩書きの登録、変更、削除
+	ManageRoles Permission = "manage_roles"
+	// 会社情報の変更、削除と権限の付与、剥奪
+	ManageCompany Permission = "manage_company"
+)
+
+// 全ての操作権限
+// 会社を作成したユーザーは全ての操作権限を持つ管理者になる
+var All = []Permission{
+	ManageEmployees,
+	ManageDepartments,
+	ManageRoles,
+	ManageCompany,
+}
+
+func (p Permission) Valid() bool {
+	for _, v := range All {
+		if p == v {
+			return true
+		}
+	}
+	return false
+}
+
+// 権限を持つ会社
+type CompanyID = company.ID
+
+// 権限を持つ従業員
+// 管理者でない従業員は権限を持つことができない
+type EmployeeID = employee.ID
+
+var (
+	// 管理者でない従業員に権限を付与しようとした
+	ErrNotAdministrator = failure.New(failure.ErrConflict, "employee is not an administrator")
+	// 操作に必要な権限を持っていない
+	ErrForbidden = failure.New(failure.ErrForbidden, "permission denied")
+)
+
+// 権限の一覧に p が含まれているか
+func contains(list []Permission, p Permission) bool {
+	for _, v := range list {
+		if p == v {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,35 @@
+package permission
+
+import (
+	"testing"
+)
+
+func TestPermission_Valid(t *testing.T) {
+	type test struct {
+		name       string
+		permission Permission
+		want       bool
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.permission.Valid()
+			if tt.want != got {
+				t.Fatalf("want=%v, got=%v.", tt.want, got)
+			}
+		})
+	}
+
+	tests := []*test{
+		{name: "manage_employees", permission: ManageEmployees, want: true},
+		{name: "manage_departments", permission: ManageDepartments, want: true},
+		{name: "manage_roles", permission: ManageRoles, want: true},
+		{name: "manage_company", permission: ManageCompany, want: true},
+		{name: "empty", permission: "", want: false},
+		{name: "unknown", permission: "manage_everything", want: false},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+}
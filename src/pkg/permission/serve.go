@@ -0,0 +1,85 @@
+package permission
+
+import (
+	"context"
+	"fmt"
+
+	"api.example.com/pkg/failure"
+)
+
+type Repository interface {
+	PermissionList(context.Context, CompanyID, EmployeeID) ([]Permission, error)
+	PermissionGrant(context.Context, CompanyID, EmployeeID, Permission) error
+	PermissionRevoke(context.Context, CompanyID, EmployeeID, Permission) error
+}
+
+// 他のサービスは変更の前に Check で権限を確認する
+type Checker interface {
+	Check(context.Context, CompanyID, EmployeeID, Permission) error
+}
+
+type Server interface {
+	Checker
+	List(context.Context, CompanyID, EmployeeID) ([]Permission, error)
+	Grant(context.Context, CompanyID, EmployeeID, Permission) ([]Permission, error)
+	Revoke(context.Context, CompanyID, EmployeeID, Permission) ([]Permission, error)
+}
+
+// impl Server
+type server struct {
+	repository Repository
+}
+
+func NewServer(repo Repository) Server {
+	return &server{repo}
+}
+
+// 権限を持っていなければ ErrForbidden
+func (s *server) Check(ctx context.Context, companyID CompanyID, employeeID EmployeeID, p Permission) error {
+	list, err := s.List(ctx, companyID, employeeID)
+	if err != nil {
+		return fmt.Errorf("pkg/permission.Check: %w", err)
+	}
+
+	if !contains(list, p) {
+		return fmt.Errorf("pkg/permission.Check: %s: %w", p, ErrForbidden)
+	}
+
+	return nil
+}
+
+func (s *server) List(ctx context.Context, companyID CompanyID, employeeID EmployeeID) ([]Permission, error) {
+	if ok := companyID.Valid() && employeeID.Valid(); !ok {
+		return nil, fmt.Errorf("pkg/permission.List: %w", failure.New(failure.ErrInvalidArgument, "invalid company_id or employee_id"))
+	}
+
+	return s.repository.PermissionList(ctx, companyID, employeeID)
+}
+
+// 付与済みの権限を付与してもエラーにしない
+func (s *server) Grant(ctx context.Context, companyID CompanyID, employeeID EmployeeID, p Permission) ([]Permission, error) {
+	if ok := companyID.Valid() && employeeID.Valid() && p.Valid(); !ok {
+		return nil, fmt.Errorf("pkg/permission.Grant: %w", failure.New(failure.ErrInvalidArgument, "invalid company_id, employee_id or permission"))
+	}
+
+	err := s.repository.PermissionGrant(ctx, companyID, employeeID, p)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.repository.PermissionList(ctx, companyID, employeeID)
+}
+
+// 付与されていない権限を剥奪してもエラーにしない
+func (s *server) Revoke(ctx context.Context, companyID CompanyID, employeeID EmployeeID, p Permission) ([]Permission, error) {
+	if ok := companyID.Valid() && employeeID.Valid() && p.Valid(); !ok {
+		return nil, fmt.Errorf("pkg/permission.Revoke: %w", failure.New(failure.ErrInvalidArgument, "invalid company_id, employee_id or permission"))
+	}
+
+	err := s.repository.PermissionRevoke(ctx, companyID, employeeID, p)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.repository.PermissionList(ctx, companyID, employeeID)
+}
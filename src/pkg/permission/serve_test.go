@@ -0,0 +1,304 @@
+package permission
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// mock
+type makeRepository func(t *testing.T) Repository
+
+type repository struct {
+	permissions []Permission
+	err         error
+	// flag
+	list, grant, revoke bool
+	// test
+	t *testing.T
+}
+
+func (r *repository) PermissionList(context.Context, CompanyID, EmployeeID) ([]Permission, error) {
+	r.t.Helper()
+
+	if r.list {
+		return r.permissions, r.err
+	}
+	r.t.Fatal("invalid PermissionList")
+	panic("invalid PermissionList")
+}
+
+func (r *repository) PermissionGrant(context.Context, CompanyID, EmployeeID, Permission) error {
+	r.t.Helper()
+
+	if r.grant {
+		return r.err
+	}
+	r.t.Fatal("invalid PermissionGrant")
+	panic("invalid PermissionGrant")
+}
+
+func (r *repository) PermissionRevoke(context.Context, CompanyID, EmployeeID, Permission) error {
+	r.t.Helper()
+
+	if r.revoke {
+		return r.err
+	}
+	r.t.Fatal("invalid PermissionRevoke")
+	panic("invalid PermissionRevoke")
+}
+
+func TestServer_Check(t *testing.T) {
+	type test struct {
+		name           string
+		companyID      CompanyID
+		employeeID     EmployeeID
+		permission     Permission
+		makeRepository makeRepository
+		wantErr        error
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.name, func(t *testing.T) {
+			err := NewServer(tt.makeRepository(t)).Check(context.Background(), tt.companyID, tt.employeeID, tt.permission)
+			if tt.wantErr == nil && err != nil {
+				t.Fatalf("error=%v.", err)
+			}
+
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Fatalf("want-error=%v, error=%v.", tt.wantErr, err)
+			}
+		})
+	}
+
+	errTest := errors.New("test error")
+
+	tests := []*test{
+		{
+			name:       "ok",
+			companyID:  1,
+			employeeID: 2,
+			permission: ManageRoles,
+			makeRepository: func(t *testing.T) Repository {
+				return &repository{permissions: []Permission{ManageEmployees, ManageRoles}, list: true, t: t}
+			},
+			wantErr: nil,
+		},
+		{
+			name:       "forbidden",
+			companyID:  1,
+			employeeID: 2,
+			permission: ManageCompany,
+			makeRepository: func(t *testing.T) Repository {
+				return &repository{permissions: []Permission{ManageEmployees, ManageRoles}, list: true, t: t}
+			},
+			wantErr: ErrForbidden,
+		},
+		{
+			name:       "not administrator",
+			companyID:  1,
+			employeeID: 2,
+			permission: ManageEmployees,
+			makeRepository: func(t *testing.T) Repository {
+				return &repository{permissions: []Permission{}, list: true, t: t}
+			},
+			wantErr: ErrForbidden,
+		},
+		{
+			name:       "failed list",
+			companyID:  1,
+			employeeID: 2,
+			permission: ManageEmployees,
+			makeRepository: func(t *testing.T) Repository {
+				return &repository{err: errTest, list: true, t: t}
+			},
+			wantErr: errTest,
+		},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+}
+
+func TestServer_List(t *testing.T) {
+	type test struct {
+		name           string
+		companyID      CompanyID
+		employeeID     EmployeeID
+		makeRepository makeRepository
+		want           []Permission
+		wantErr        bool
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewServer(tt.makeRepository(t)).List(context.Background(), tt.companyID, tt.employeeID)
+			if tt.wantErr != (err != nil) {
+				t.Fatalf("want-error=%v, error=%v.", tt.wantErr, err)
+			}
+
+			if !reflect.DeepEqual(tt.want, got) {
+				t.Fatalf("want=%v, got=%v.", tt.want, got)
+			}
+		})
+	}
+
+	tests := []*test{
+		{
+			name:       "ok",
+			companyID:  1,
+			employeeID: 2,
+			makeRepository: func(t *testing.T) Repository {
+				return &repository{permissions: All, list: true, t: t}
+			},
+			want:    All,
+			wantErr: false,
+		},
+		{
+			name:       "invalid employee_id",
+			companyID:  1,
+			employeeID: 0,
+			makeRepository: func(t *testing.T) Repository {
+				return &repository{t: t}
+			},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+}
+
+func TestServer_Grant(t *testing.T) {
+	type test struct {
+		name           string
+		companyID      CompanyID
+		employeeID     EmployeeID
+		permission     Permission
+		makeRepository makeRepository
+		want           []Permission
+		wantErr        bool
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewServer(tt.makeRepository(t)).Grant(context.Background(), tt.companyID, tt.employeeID, tt.permission)
+			if tt.wantErr != (err != nil) {
+				t.Fatalf("want-error=%v, error=%v.", tt.wantErr, err)
+			}
+
+			if !reflect.DeepEqual(tt.want, got) {
+				t.Fatalf("want=%v, got=%v.", tt.want, got)
+			}
+		})
+	}
+
+	tests := []*test{
+		{
+			name:       "ok",
+			companyID:  1,
+			employeeID: 2,
+			permission: ManageRoles,
+			makeRepository: func(t *testing.T) Repository {
+				return &repository{permissions: []Permission{ManageRoles}, grant: true, list: true, t: t}
+			},
+			want:    []Permission{ManageRoles},
+			wantErr: false,
+		},
+		{
+			name:       "invalid permission",
+			companyID:  1,
+			employeeID: 2,
+			permission: "manage_everything",
+			makeRepository: func(t *testing.T) Repository {
+				return &repository{t: t}
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:       "not administrator",
+			companyID:  1,
+			employeeID: 2,
+			permission: ManageRoles,
+			makeRepository: func(t *testing.T) Repository {
+				return &repository{err: ErrNotAdministrator, grant: true, t: t}
+			},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+}
+
+func TestServer_Revoke(t *testing.T) {
+	type test struct {
+		name           string
+		companyID      CompanyID
+		employeeID     EmployeeID
+		permission     Permission
+		makeRepository makeRepository
+		want           []Permission
+		wantErr        bool
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewServer(tt.makeRepository(t)).Revoke(context.Background(), tt.companyID, tt.employeeID, tt.permission)
+			if tt.wantErr != (err != nil) {
+				t.Fatalf("want-error=%v, error=%v.", tt.wantErr, err)
+			}
+
+			if !reflect.DeepEqual(tt.want, got) {
+				t.Fatalf("want=%v, got=%v.", tt.want, got)
+			}
+		})
+	}
+
+	tests := []*test{
+		{
+			name:       "ok",
+			companyID:  1,
+			employeeID: 2,
+			permission: ManageRoles,
+			makeRepository: func(t *testing.T) Repository {
+				return &repository{permissions: []Permission{ManageEmployees}, revoke: true, list: true, t: t}
+			},
+			want:    []Permission{ManageEmployees},
+			wantErr: false,
+		},
+		{
+			name:       "invalid company_id",
+			companyID:  0,
+			employeeID: 2,
+			permission: ManageRoles,
+			makeRepository: func(t *testing.T) Repository {
+				return &repository{t: t}
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:       "failed revoke",
+			companyID:  1,
+			employeeID: 2,
+			permission: ManageRoles,
+			makeRepository: func(t *testing.T) Repository {
+				return &repository{err: errors.New("test error"), revoke: true, t: t}
+			},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+}
@@ -0,0 +1,53 @@
+package role
+
+import (
+	"time"
+
+	"api.example.com/pkg/company"
+	"api.example.com/pkg/failure"
+)
+
+// 会社の肩書き(部長、課長など)の ID
+type ID int
+
+func (id ID) Valid() bool {
+	return id > 0
+}
+
+// 肩書きを持つ会社
+type CompanyID = company.ID
+
+// Role Name
+type Name string
+
+// 1 ≤ name.length ≤ 255
+func (n Name) valid() bool {
+	l := len(n)
+	return l > 0 && l < 256
+}
+
+// 同じ会社に同じ名前の肩書きは作成できない
+var ErrDuplicateName = failure.New(failure.ErrConflict, "role name already exists")
+
+// 肩書き
+type Role struct {
+	ID        ID
+	CompanyID CompanyID
+	Name      Name
+	UpdatedAt time.Time
+}
+
+func New(companyID CompanyID, name Name) *Role {
+	return &Role{
+		CompanyID: companyID,
+		Name:      name,
+	}
+}
+
+func (r *Role) validCreate() bool {
+	return r.CompanyID.Valid() && r.Name.valid()
+}
+
+func (r *Role) validUpdate() bool {
+	return r.ID.Valid() && r.validCreate()
+}
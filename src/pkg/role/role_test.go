@@ -0,0 +1,98 @@
+package role
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestNew(t *testing.T) {
+	got := New(1, "部長")
+	want := &Role{CompanyID: 1, Name: "部長"}
+	if !reflect.DeepEqual(want, got) {
+		t.Fatalf("want=%v, got=%v.", want, got)
+	}
+}
+
+func TestRole_validCreate(t *testing.T) {
+	type test struct {
+		name string
+		role *Role
+		want bool
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.role.validCreate()
+			if tt.want != got {
+				t.Fatalf("want=%v, got=%v.", tt.want, got)
+			}
+		})
+	}
+
+	tests := []*test{
+		{
+			name: "ok",
+			role: New(1, "部長"),
+			want: true,
+		},
+		{
+			name: "invalid company_id",
+			role: New(0, "部長"),
+			want: false,
+		},
+		{
+			name: "empty name",
+			role: New(1, ""),
+			want: false,
+		},
+		{
+			name: "too long name",
+			role: New(1, Name(strings.Repeat("a", 256))),
+			want: false,
+		},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+}
+
+func TestRole_validUpdate(t *testing.T) {
+	type test struct {
+		name string
+		role *Role
+		want bool
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.role.validUpdate()
+			if tt.want != got {
+				t.Fatalf("want=%v, got=%v.", tt.want, got)
+			}
+		})
+	}
+
+	tests := []*test{
+		{
+			name: "ok",
+			role: &Role{ID: 2, CompanyID: 1, Name: "課長"},
+			want: true,
+		},
+		{
+			name: "invalid id",
+			role: &Role{ID: 0, CompanyID: 1, Name: "課長"},
+			want: false,
+		},
+		{
+			name: "invalid name",
+			role: &Role{ID: 2, CompanyID: 1, Name: ""},
+			want: false,
+		},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+}
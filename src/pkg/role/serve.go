@@ -0,0 +1,73 @@
+package role
+
+import (
+	"context"
+	"fmt"
+
+	"api.example.com/pkg/failure"
+)
+
+type Repository interface {
+	RoleCreate(context.Context, *Role) (*Role, error)
+	RoleRead(context.Context, CompanyID, ID) (*Role, error)
+	RoleList(context.Context, CompanyID) ([]*Role, error)
+	RoleUpdate(context.Context, *Role) (*Role, error)
+	RoleDelete(context.Context, CompanyID, ID) error
+}
+
+type Server interface {
+	Create(context.Context, *Role) (*Role, error)
+	Read(context.Context, CompanyID, ID) (*Role, error)
+	List(context.Context, CompanyID) ([]*Role, error)
+	Update(context.Context, *Role) (*Role, error)
+	Delete(context.Context, CompanyID, ID) error
+}
+
+// impl Server
+type server struct {
+	repository Repository
+}
+
+func NewServer(repo Repository) Server {
+	return &server{repo}
+}
+
+func (s *server) Create(ctx context.Context, r *Role) (*Role, error) {
+	if ok := r.validCreate(); !ok {
+		return nil, fmt.Errorf("pkg/role.Create: %w", failure.New(failure.ErrInvalidArgument, "invalid role"))
+	}
+
+	return s.repository.RoleCreate(ctx, r)
+}
+
+func (s *server) Read(ctx context.Context, companyID CompanyID, id ID) (*Role, error) {
+	if ok := companyID.Valid() && id.Valid(); !ok {
+		return nil, fmt.Errorf("pkg/role.Read: %w", failure.New(failure.ErrInvalidArgument, "invalid company_id or role_id"))
+	}
+
+	return s.repository.RoleRead(ctx, companyID, id)
+}
+
+func (s *server) List(ctx context.Context, companyID CompanyID) ([]*Role, error) {
+	if ok := companyID.Valid(); !ok {
+		return nil, fmt.Errorf("pkg/role.List: %w", failure.New(failure.ErrInvalidArgument, "invalid company_id"))
+	}
+
+	return s.repository.RoleList(ctx, companyID)
+}
+
+func (s *server) Update(ctx context.Context, r *Role) (*Role, error) {
+	if ok := r.validUpdate(); !ok {
+		return nil, fmt.Errorf("pkg/role.Update: %w", failure.New(failure.ErrInvalidArgument, "invalid role"))
+	}
+
+	return s.repository.RoleUpdate(ctx, r)
+}
+
+func (s *server) Delete(ctx context.Context, companyID CompanyID, id ID) error {
+	if ok := companyID.Valid() && id.Valid(); !ok {
+		return fmt.Errorf("pkg/role.Delete: %w", failure.New(failure.ErrInvalidArgument, "invalid company_id or role_id"))
+	}
+
+	return s.repository.RoleDelete(ctx, companyID, id)
+}
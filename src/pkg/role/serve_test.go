@@ -0,0 +1,334 @@
+package role
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// mock
+type makeRepository func(t *testing.T) Repository
+
+type repository struct {
+	role  *Role
+	roles []*Role
+	err   error
+	// flag
+	create, read, list, update, delete bool
+	// test
+	t *testing.T
+}
+
+func (r *repository) RoleCreate(context.Context, *Role) (*Role, error) {
+	r.t.Helper()
+
+	if r.create {
+		return r.role, r.err
+	}
+	r.t.Fatal("invalid RoleCreate")
+	panic("invalid RoleCreate")
+}
+
+func (r *repository) RoleRead(context.Context, CompanyID, ID) (*Role, error) {
+	r.t.Helper()
+
+	if r.read {
+		return r.role, r.err
+	}
+	r.t.Fatal("invalid RoleRead")
+	panic("invalid RoleRead")
+}
+
+func (r *repository) RoleList(context.Context, CompanyID) ([]*Role, error) {
+	r.t.Helper()
+
+	if r.list {
+		return r.roles, r.err
+	}
+	r.t.Fatal("invalid RoleList")
+	panic("invalid RoleList")
+}
+
+func (r *repository) RoleUpdate(context.Context, *Role) (*Role, error) {
+	r.t.Helper()
+
+	if r.update {
+		return r.role, r.err
+	}
+	r.t.Fatal("invalid RoleUpdate")
+	panic("invalid RoleUpdate")
+}
+
+func (r *repository) RoleDelete(context.Context, CompanyID, ID) error {
+	r.t.Helper()
+
+	if r.delete {
+		return r.err
+	}
+	r.t.Fatal("invalid RoleDelete")
+	panic("invalid RoleDelete")
+}
+
+func TestServer_Create(t *testing.T) {
+	type test struct {
+		name           string
+		makeRepository makeRepository
+		role           *Role
+		want           *Role
+		wantErr        bool
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewServer(tt.makeRepository(t)).Create(context.Background(), tt.role)
+			if tt.wantErr != (err != nil) {
+				t.Fatalf("want-error=%v, error=%v.", tt.wantErr, err)
+			}
+
+			if !reflect.DeepEqual(tt.want, got) {
+				t.Fatalf("want=%v, got=%v.", tt.want, got)
+			}
+		})
+	}
+
+	updatedAt := time.Date(2022, 9, 3, 12, 34, 56, 0, time.UTC)
+
+	tests := []*test{
+		{
+			name: "ok",
+			role: New(1, "部長"),
+			makeRepository: func(t *testing.T) Repository {
+				return &repository{
+					role:   &Role{ID: 2, CompanyID: 1, Name: "部長", UpdatedAt: updatedAt},
+					create: true,
+					t:      t,
+				}
+			},
+			want:    &Role{ID: 2, CompanyID: 1, Name: "部長", UpdatedAt: updatedAt},
+			wantErr: false,
+		},
+		{
+			name: "invalid",
+			role: New(1, ""),
+			makeRepository: func(t *testing.T) Repository {
+				return &repository{t: t}
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "duplicate",
+			role: New(1, "部長"),
+			makeRepository: func(t *testing.T) Repository {
+				return &repository{err: ErrDuplicateName, create: true, t: t}
+			},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+}
+
+func TestServer_Read(t *testing.T) {
+	type test struct {
+		name           string
+		companyID      CompanyID
+		id             ID
+		makeRepository makeRepository
+		want           *Role
+		wantErr        bool
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewServer(tt.makeRepository(t)).Read(context.Background(), tt.companyID, tt.id)
+			if tt.wantErr != (err != nil) {
+				t.Fatalf("want-error=%v, error=%v.", tt.wantErr, err)
+			}
+
+			if !reflect.DeepEqual(tt.want, got) {
+				t.Fatalf("want=%v, got=%v.", tt.want, got)
+			}
+		})
+	}
+
+	tests := []*test{
+		{
+			name:      "ok",
+			companyID: 1,
+			id:        2,
+			makeRepository: func(t *testing.T) Repository {
+				return &repository{role: &Role{ID: 2, CompanyID: 1, Name: "部長"}, read: true, t: t}
+			},
+			want:    &Role{ID: 2, CompanyID: 1, Name: "部長"},
+			wantErr: false,
+		},
+		{
+			name:      "invalid role_id",
+			companyID: 1,
+			id:        0,
+			makeRepository: func(t *testing.T) Repository {
+				return &repository{t: t}
+			},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+}
+
+func TestServer_List(t *testing.T) {
+	type test struct {
+		name           string
+		companyID      CompanyID
+		makeRepository makeRepository
+		want           []*Role
+		wantErr        bool
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewServer(tt.makeRepository(t)).List(context.Background(), tt.companyID)
+			if tt.wantErr != (err != nil) {
+				t.Fatalf("want-error=%v, error=%v.", tt.wantErr, err)
+			}
+
+			if !reflect.DeepEqual(tt.want, got) {
+				t.Fatalf("want=%v, got=%v.", tt.want, got)
+			}
+		})
+	}
+
+	tests := []*test{
+		{
+			name:      "ok",
+			companyID: 1,
+			makeRepository: func(t *testing.T) Repository {
+				return &repository{roles: []*Role{{ID: 2, CompanyID: 1, Name: "部長"}}, list: true, t: t}
+			},
+			want:    []*Role{{ID: 2, CompanyID: 1, Name: "部長"}},
+			wantErr: false,
+		},
+		{
+			name:      "invalid company_id",
+			companyID: 0,
+			makeRepository: func(t *testing.T) Repository {
+				return &repository{t: t}
+			},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+}
+
+func TestServer_Update(t *testing.T) {
+	type test struct {
+		name           string
+		role           *Role
+		makeRepository makeRepository
+		want           *Role
+		wantErr        bool
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewServer(tt.makeRepository(t)).Update(context.Background(), tt.role)
+			if tt.wantErr != (err != nil) {
+				t.Fatalf("want-error=%v, error=%v.", tt.wantErr, err)
+			}
+
+			if !reflect.DeepEqual(tt.want, got) {
+				t.Fatalf("want=%v, got=%v.", tt.want, got)
+			}
+		})
+	}
+
+	tests := []*test{
+		{
+			name: "ok",
+			role: &Role{ID: 2, CompanyID: 1, Name: "課長"},
+			makeRepository: func(t *testing.T) Repository {
+				return &repository{role: &Role{ID: 2, CompanyID: 1, Name: "課長"}, update: true, t: t}
+			},
+			want:    &Role{ID: 2, CompanyID: 1, Name: "課長"},
+			wantErr: false,
+		},
+		{
+			name: "invalid",
+			role: &Role{ID: 0, CompanyID: 1, Name: "課長"},
+			makeRepository: func(t *testing.T) Repository {
+				return &repository{t: t}
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "duplicate",
+			role: &Role{ID: 2, CompanyID: 1, Name: "課長"},
+			makeRepository: func(t *testing.T) Repository {
+				return &repository{err: ErrDuplicateName, update: true, t: t}
+			},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+}
+
+func TestServer_Delete(t *testing.T) {
+	type test struct {
+		name           string
+		companyID      CompanyID
+		id             ID
+		makeRepository makeRepository
+		wantErr        bool
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.name, func(t *testing.T) {
+			err := NewServer(tt.makeRepository(t)).Delete(context.Background(), tt.companyID, tt.id)
+			if tt.wantErr != (err != nil) {
+				t.Fatalf("want-error=%v, error=%v.", tt.wantErr, err)
+			}
+		})
+	}
+
+	tests := []*test{
+		{
+			name:      "ok",
+			companyID: 1,
+			id:        2,
+			makeRepository: func(t *testing.T) Repository {
+				return &repository{delete: true, t: t}
+			},
+			wantErr: false,
+		},
+		{
+			name:      "invalid",
+			companyID: 0,
+			id:        2,
+			makeRepository: func(t *testing.T) Repository {
+				return &repository{t: t}
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+}
@@ -0,0 +1,145 @@
+package session
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"api.example.com/pkg/failure"
+	"api.example.com/pkg/user"
+)
+
+type Repository interface {
+	UserReadByName(context.Context, user.Name) (*user.User, error)
+	SessionCreate(context.Context, *Session) (*Session, error)
+	// 有効期限は確認しない
+	SessionRead(context.Context, ID) (*Session, error)
+	SessionDelete(context.Context, ID) error
+	// ユーザーの全てのセッション
+	SessionDeleteByUserID(context.Context, UserID) error
+	// 有効期限が切れたセッション
+	SessionDeleteExpired(context.Context, time.Time) error
+}
+
+type Server interface {
+	// パスワードを確認してセッションを作成し、資格情報を発行する
+	Login(context.Context, user.Name, user.PlainPassword) (*Session, error)
+	// 資格情報が示すセッション
+	// 署名を検証した後、ログアウトやパスワードの変更を反映するため保存したセッションと照合する
+	Authenticate(context.Context, Token) (*Session, error)
+	// セッションIDが示すセッション
+	Resume(context.Context, ID) (*Session, error)
+	Logout(context.Context, ID) error
+	// 有効期限が切れたセッションの削除
+	Cleanup(context.Context) error
+}
+
+// impl Server
+type server struct {
+	repository Repository
+	signer     Signer
+	ttl        time.Duration
+}
+
+func NewServer(repo Repository, signer Signer, ttl time.Duration) Server {
+	return &server{repo, signer, ttl}
+}
+
+func (s *server) Login(ctx context.Context, name user.Name, plain user.PlainPassword) (*Session, error) {
+	if name == "" || plain == "" {
+		return nil, fmt.Errorf("pkg/session.Login: %w", ErrInvalidCredentials)
+	}
+
+	// 障害によるエラーは誤ったユーザー名と区別する
+	u, err := s.repository.UserReadByName(ctx, name)
+	if errors.Is(err, failure.ErrNotFound) {
+		return nil, fmt.Errorf("pkg/session.Login: %w: %v", ErrInvalidCredentials, err)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("pkg/session.Login: %w", err)
+	}
+
+	if !u.Password.Verify(plain) {
+		return nil, fmt.Errorf("pkg/session.Login: %w", ErrInvalidCredentials)
+	}
+
+	id, err := newID()
+	if err != nil {
+		return nil, fmt.Errorf("pkg/session.Login: %w", err)
+	}
+
+	session, err := s.repository.SessionCreate(ctx, New(id, u.ID, now().Add(s.ttl).Truncate(time.Second)))
+	if err != nil {
+		return nil, fmt.Errorf("pkg/session.Login: %w", err)
+	}
+
+	session.Token, err = s.signer.Issue(session)
+	if err != nil {
+		return nil, fmt.Errorf("pkg/session.Login: %w", err)
+	}
+
+	return session, nil
+}
+
+func (s *server) Authenticate(ctx context.Context, token Token) (*Session, error) {
+	claims, err := s.signer.Verify(token)
+	if err != nil {
+		return nil, fmt.Errorf("pkg/session.Authenticate: %w", err)
+	}
+
+	session, err := s.Resume(ctx, claims.ID)
+	if err != nil {
+		return nil, fmt.Errorf("pkg/session.Authenticate: %w", err)
+	}
+
+	if session.UserID != claims.UserID {
+		return nil, fmt.Errorf("pkg/session.Authenticate: %w", ErrInvalidToken)
+	}
+
+	session.Token = token
+	return session, nil
+}
+
+func (s *server) Resume(ctx context.Context, id ID) (*Session, error) {
+	if !id.Valid() {
+		return nil, fmt.Errorf("pkg/session.Resume: %w", ErrInvalidSession)
+	}
+
+	// 障害によるエラーをログアウト済みとして扱わない
+	session, err := s.repository.SessionRead(ctx, id)
+	if errors.Is(err, failure.ErrNotFound) {
+		return nil, fmt.Errorf("pkg/session.Resume: %w: %v", ErrInvalidSession, err)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("pkg/session.Resume: %w", err)
+	}
+
+	if session.expired(now()) {
+		return nil, fmt.Errorf("pkg/session.Resume: %w", ErrInvalidSession)
+	}
+
+	return session, nil
+}
+
+func (s *server) Logout(ctx context.Context, id ID) error {
+	if !id.Valid() {
+		return fmt.Errorf("pkg/session.Logout: %w", ErrInvalidSession)
+	}
+
+	err := s.repository.SessionDelete(ctx, id)
+	if err != nil {
+		return fmt.Errorf("pkg/session.Logout: %w", err)
+	}
+
+	return nil
+}
+
+func (s *server) Cleanup(ctx context.Context) error {
+	err := s.repository.SessionDeleteExpired(ctx, now())
+	if err != nil {
+		return fmt.Errorf("pkg/session.Cleanup: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,496 @@
+package session
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"api.example.com/pkg/auth"
+	"api.example.com/pkg/failure"
+	"api.example.com/pkg/user"
+	"api.example.com/pkg/user/password"
+)
+
+// mock
+type repository struct {
+	user    *user.User
+	session *Session
+	err     error
+	// SessionCreate のみのエラー
+	createErr error
+	// flag
+	readUser, create, read, delete, deleteExpired bool
+	// test
+	t *testing.T
+}
+
+func (r *repository) UserReadByName(context.Context, user.Name) (*user.User, error) {
+	r.t.Helper()
+
+	if r.readUser {
+		return r.user, r.err
+	}
+	r.t.Fatal("invalid UserReadByName")
+	panic("invalid UserReadByName")
+}
+
+func (r *repository) SessionCreate(_ context.Context, s *Session) (*Session, error) {
+	r.t.Helper()
+
+	if r.create {
+		if r.createErr != nil {
+			return nil, r.createErr
+		}
+		r.session = s
+		return s, nil
+	}
+	r.t.Fatal("invalid SessionCreate")
+	panic("invalid SessionCreate")
+}
+
+func (r *repository) SessionRead(context.Context, ID) (*Session, error) {
+	r.t.Helper()
+
+	if r.read {
+		return r.session, r.err
+	}
+	r.t.Fatal("invalid SessionRead")
+	panic("invalid SessionRead")
+}
+
+func (r *repository) SessionDelete(context.Context, ID) error {
+	r.t.Helper()
+
+	if r.delete {
+		return r.err
+	}
+	r.t.Fatal("invalid SessionDelete")
+	panic("invalid SessionDelete")
+}
+
+func (r *repository) SessionDeleteByUserID(context.Context, UserID) error {
+	r.t.Helper()
+
+	r.t.Fatal("invalid SessionDeleteByUserID")
+	panic("invalid SessionDeleteByUserID")
+}
+
+func (r *repository) SessionDeleteExpired(context.Context, time.Time) error {
+	r.t.Helper()
+
+	if r.deleteExpired {
+		return r.err
+	}
+	r.t.Fatal("invalid SessionDeleteExpired")
+	panic("invalid SessionDeleteExpired")
+}
+
+// mock
+type signer struct {
+	token   Token
+	session *Session
+	err     error
+	// flag
+	issue, verify bool
+	// test
+	t *testing.T
+}
+
+func (s *signer) Issue(*Session) (Token, error) {
+	s.t.Helper()
+
+	if s.issue {
+		return s.token, s.err
+	}
+	s.t.Fatal("invalid Issue")
+	panic("invalid Issue")
+}
+
+func (s *signer) Verify(Token) (*Session, error) {
+	s.t.Helper()
+
+	if s.verify {
+		return s.session, s.err
+	}
+	s.t.Fatal("invalid Verify")
+	panic("invalid Verify")
+}
+
+func TestServer_Login(t *testing.T) {
+	defer func() {
+		now = time.Now
+	}()
+
+	type test struct {
+		name           string
+		userName       user.Name
+		password       user.PlainPassword
+		makeRepository func(*testing.T) Repository
+		makeSigner     func(*testing.T) Signer
+		want           *Session
+		wantErr        error
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewServer(tt.makeRepository(t), tt.makeSigner(t), time.Hour).Login(context.Background(), tt.userName, tt.password)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("want-error=%v, error=%v.", tt.wantErr, err)
+			}
+
+			// セッションIDは無作為に生成される
+			if got != nil {
+				if !got.ID.Valid() {
+					t.Fatalf("invalid session id: %v.", got.ID)
+				}
+				tt.want.ID = got.ID
+			}
+
+			if !reflect.DeepEqual(tt.want, got) {
+				t.Fatalf("want=%v, got=%v.", tt.want, got)
+			}
+		})
+	}
+
+	pw, err := password.New("password")
+	if err != nil {
+		panic(err)
+	}
+	bob := &user.User{ID: 1, Name: "Bob", Password: pw}
+	errFailed := errors.New("failed")
+	current := time.Date(2022, 9, 3, 12, 34, 56, 0, time.UTC)
+	now = func() time.Time { return current }
+
+	errRead := errors.New("failed read")
+	tests := []*test{
+		{
+			name:     "ok",
+			userName: "Bob",
+			password: "password",
+			makeRepository: func(t *testing.T) Repository {
+				return &repository{user: bob, readUser: true, create: true, t: t}
+			},
+			makeSigner: func(t *testing.T) Signer {
+				return &signer{token: "token", issue: true, t: t}
+			},
+			want:    &Session{UserID: 1, Token: "token", ExpiresAt: current.Add(time.Hour)},
+			wantErr: nil,
+		},
+		{
+			name:     "empty",
+			userName: "",
+			password: "",
+			makeRepository: func(t *testing.T) Repository {
+				return &repository{t: t}
+			},
+			makeSigner: func(t *testing.T) Signer {
+				return &signer{t: t}
+			},
+			want:    nil,
+			wantErr: ErrInvalidCredentials,
+		},
+		{
+			name:     "not found",
+			userName: "Alice",
+			password: "password",
+			makeRepository: func(t *testing.T) Repository {
+				return &repository{err: failure.ErrNotFound, readUser: true, t: t}
+			},
+			makeSigner: func(t *testing.T) Signer {
+				return &signer{t: t}
+			},
+			want:    nil,
+			wantErr: auth.ErrUnauthenticated,
+		},
+		{
+			name:     "failed read",
+			userName: "Alice",
+			password: "password",
+			makeRepository: func(t *testing.T) Repository {
+				return &repository{err: errRead, readUser: true, t: t}
+			},
+			makeSigner: func(t *testing.T) Signer {
+				return &signer{t: t}
+			},
+			want:    nil,
+			wantErr: errRead,
+		},
+		{
+			name:     "wrong password",
+			userName: "Bob",
+			password: "qwerty12",
+			makeRepository: func(t *testing.T) Repository {
+				return &repository{user: bob, readUser: true, t: t}
+			},
+			makeSigner: func(t *testing.T) Signer {
+				return &signer{t: t}
+			},
+			want:    nil,
+			wantErr: ErrInvalidCredentials,
+		},
+		{
+			name:     "failed create",
+			userName: "Bob",
+			password: "password",
+			makeRepository: func(t *testing.T) Repository {
+				return &repository{user: bob, createErr: errFailed, readUser: true, create: true, t: t}
+			},
+			makeSigner: func(t *testing.T) Signer {
+				return &signer{t: t}
+			},
+			want:    nil,
+			wantErr: errFailed,
+		},
+		{
+			name:     "failed issue",
+			userName: "Bob",
+			password: "password",
+			makeRepository: func(t *testing.T) Repository {
+				return &repository{user: bob, readUser: true, create: true, t: t}
+			},
+			makeSigner: func(t *testing.T) Signer {
+				return &signer{err: errFailed, issue: true, t: t}
+			},
+			want:    nil,
+			wantErr: errFailed,
+		},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+
+	// 資格情報はユーザーIDから推測できず、ログインのたびに異なる
+	t.Run("unguessable", func(t *testing.T) {
+		s := NewServer(&repository{user: bob, readUser: true, create: true, t: t}, NewSigner([]byte("key")), time.Hour)
+		a, err := s.Login(context.Background(), "Bob", "password")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		b, err := s.Login(context.Background(), "Bob", "password")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if a.Token == b.Token || a.ID == b.ID || a.Token == "1" {
+			t.Fatalf("guessable token a=%v, b=%v.", a.Token, b.Token)
+		}
+	})
+}
+
+func TestServer_Authenticate(t *testing.T) {
+	defer func() {
+		now = time.Now
+	}()
+
+	type test struct {
+		name           string
+		makeRepository func(*testing.T) Repository
+		makeSigner     func(*testing.T) Signer
+		want           *Session
+		wantErr        error
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewServer(tt.makeRepository(t), tt.makeSigner(t), time.Hour).Authenticate(context.Background(), "token")
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("want-error=%v, error=%v.", tt.wantErr, err)
+			}
+
+			if !reflect.DeepEqual(tt.want, got) {
+				t.Fatalf("want=%v, got=%v.", tt.want, got)
+			}
+		})
+	}
+
+	current := time.Date(2022, 9, 3, 12, 34, 56, 0, time.UTC)
+	now = func() time.Time { return current }
+	expiresAt := current.Add(time.Hour)
+
+	errRead := errors.New("failed read")
+	tests := []*test{
+		{
+			name: "ok",
+			makeRepository: func(t *testing.T) Repository {
+				return &repository{session: New("session", 1, expiresAt), read: true, t: t}
+			},
+			makeSigner: func(t *testing.T) Signer {
+				return &signer{session: New("session", 1, expiresAt), verify: true, t: t}
+			},
+			want:    &Session{ID: "session", UserID: 1, Token: "token", ExpiresAt: expiresAt},
+			wantErr: nil,
+		},
+		{
+			name: "invalid token",
+			makeRepository: func(t *testing.T) Repository {
+				return &repository{t: t}
+			},
+			makeSigner: func(t *testing.T) Signer {
+				return &signer{err: ErrInvalidToken, verify: true, t: t}
+			},
+			want:    nil,
+			wantErr: ErrInvalidToken,
+		},
+		{
+			name: "logged out",
+			makeRepository: func(t *testing.T) Repository {
+				return &repository{err: failure.ErrNotFound, read: true, t: t}
+			},
+			makeSigner: func(t *testing.T) Signer {
+				return &signer{session: New("session", 1, expiresAt), verify: true, t: t}
+			},
+			want:    nil,
+			wantErr: ErrInvalidSession,
+		},
+		{
+			name: "failed read",
+			makeRepository: func(t *testing.T) Repository {
+				return &repository{err: errRead, read: true, t: t}
+			},
+			makeSigner: func(t *testing.T) Signer {
+				return &signer{session: New("session", 1, expiresAt), verify: true, t: t}
+			},
+			want:    nil,
+			wantErr: errRead,
+		},
+		{
+			name: "other user",
+			makeRepository: func(t *testing.T) Repository {
+				return &repository{session: New("session", 2, expiresAt), read: true, t: t}
+			},
+			makeSigner: func(t *testing.T) Signer {
+				return &signer{session: New("session", 1, expiresAt), verify: true, t: t}
+			},
+			want:    nil,
+			wantErr: ErrInvalidToken,
+		},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+}
+
+func TestServer_Resume(t *testing.T) {
+	defer func() {
+		now = time.Now
+	}()
+
+	type test struct {
+		name           string
+		id             ID
+		makeRepository func(*testing.T) Repository
+		want           *Session
+		wantErr        error
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewServer(tt.makeRepository(t), &signer{t: t}, time.Hour).Resume(context.Background(), tt.id)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("want-error=%v, error=%v.", tt.wantErr, err)
+			}
+
+			if !reflect.DeepEqual(tt.want, got) {
+				t.Fatalf("want=%v, got=%v.", tt.want, got)
+			}
+		})
+	}
+
+	current := time.Date(2022, 9, 3, 12, 34, 56, 0, time.UTC)
+	now = func() time.Time { return current }
+
+	tests := []*test{
+		{
+			name: "ok",
+			id:   "session",
+			makeRepository: func(t *testing.T) Repository {
+				return &repository{session: New("session", 1, current.Add(time.Second)), read: true, t: t}
+			},
+			want:    New("session", 1, current.Add(time.Second)),
+			wantErr: nil,
+		},
+		{
+			name: "empty",
+			id:   "",
+			makeRepository: func(t *testing.T) Repository {
+				return &repository{t: t}
+			},
+			want:    nil,
+			wantErr: ErrInvalidSession,
+		},
+		{
+			name: "expired",
+			id:   "session",
+			makeRepository: func(t *testing.T) Repository {
+				return &repository{session: New("session", 1, current), read: true, t: t}
+			},
+			want:    nil,
+			wantErr: ErrInvalidSession,
+		},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+}
+
+func TestServer_Logout(t *testing.T) {
+	type test struct {
+		name           string
+		id             ID
+		makeRepository func(*testing.T) Repository
+		wantErr        bool
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.name, func(t *testing.T) {
+			err := NewServer(tt.makeRepository(t), &signer{t: t}, time.Hour).Logout(context.Background(), tt.id)
+			if tt.wantErr != (err != nil) {
+				t.Fatalf("want-error=%v, error=%v.", tt.wantErr, err)
+			}
+		})
+	}
+
+	tests := []*test{
+		{
+			name: "ok",
+			id:   "session",
+			makeRepository: func(t *testing.T) Repository {
+				return &repository{delete: true, t: t}
+			},
+			wantErr: false,
+		},
+		{
+			name: "empty",
+			id:   "",
+			makeRepository: func(t *testing.T) Repository {
+				return &repository{t: t}
+			},
+			wantErr: true,
+		},
+		{
+			name: "failed delete",
+			id:   "session",
+			makeRepository: func(t *testing.T) Repository {
+				return &repository{err: errors.New("failed"), delete: true, t: t}
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+}
+
+func TestServer_Cleanup(t *testing.T) {
+	err := NewServer(&repository{deleteExpired: true, t: t}, &signer{t: t}, time.Hour).Cleanup(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,84 @@
+package session
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"time"
+
+	"api.example.com/pkg/auth"
+	"api.example.com/pkg/failure"
+	"api.example.com/pkg/user"
+)
+
+type UserID = user.ID
+
+// クライアントに渡す不透明なセッションID
+type ID string
+
+func (id ID) Valid() bool {
+	return id != ""
+}
+
+// 保存するのはセッションIDのダイジェストのみとする
+func (id ID) Digest() string {
+	sum := sha256.Sum256([]byte(id))
+	return hex.EncodeToString(sum[:])
+}
+
+// 推測できないセッションID
+func newID() (ID, error) {
+	b := make([]byte, 32)
+	_, err := rand.Read(b)
+	if err != nil {
+		return "", err
+	}
+
+	return ID(base64.RawURLEncoding.EncodeToString(b)), nil
+}
+
+// 認証済みのユーザーであることを示す資格情報
+type Token string
+
+// ログインしたユーザーのセッション
+type Session struct {
+	ID        ID
+	UserID    UserID
+	Token     Token
+	ExpiresAt time.Time
+}
+
+func New(id ID, userID UserID, expiresAt time.Time) *Session {
+	return &Session{
+		ID:        id,
+		UserID:    userID,
+		ExpiresAt: expiresAt,
+	}
+}
+
+func (s *Session) expired(at time.Time) bool {
+	return !at.Before(s.ExpiresAt)
+}
+
+var (
+	// ユーザー名またはパスワードが誤っている
+	// どちらが誤っているかは区別しない
+	ErrInvalidCredentials = failure.New(auth.ErrUnauthenticated, "invalid name or password")
+	// セッションが存在しない(ログアウト済み)か、有効期限が切れている
+	ErrInvalidSession = failure.New(auth.ErrUnauthenticated, "invalid session")
+)
+
+type sessionKey struct{}
+
+// 呼び出し元のセッションを保存した context
+func NewContext(ctx context.Context, s *Session) context.Context {
+	return context.WithValue(ctx, sessionKey{}, s)
+}
+
+// context に保存された呼び出し元のセッション
+func FromContext(ctx context.Context) (*Session, bool) {
+	s, ok := ctx.Value(sessionKey{}).(*Session)
+	return s, ok
+}
@@ -0,0 +1,46 @@
+package session
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func TestNewID(t *testing.T) {
+	a, err := newID()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	b, err := newID()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !a.Valid() || a == b {
+		t.Fatalf("invalid session id a=%v, b=%v.", a, b)
+	}
+}
+
+func TestID_Digest(t *testing.T) {
+	// echo -n session | sha256sum
+	want := "3f3af1ecebbd1410ab417ec0d27bbfcb5d340e177ae159b59fc8626c2dfd9175"
+	got := ID("session").Digest()
+	if want != got {
+		t.Fatalf("want=%v, got=%v.", want, got)
+	}
+}
+
+func TestFromContext(t *testing.T) {
+	s := New("session", 1, now())
+
+	got, ok := FromContext(NewContext(context.Background(), s))
+	if !ok || !reflect.DeepEqual(s, got) {
+		t.Fatalf("want=%v, got=%v.", s, got)
+	}
+
+	_, ok = FromContext(context.Background())
+	if ok {
+		t.Fatal("want no session")
+	}
+}
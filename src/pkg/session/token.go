@@ -0,0 +1,116 @@
+package session
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"api.example.com/pkg/auth"
+	"api.example.com/pkg/failure"
+)
+
+var (
+	// 署名や形式が正しくない
+	ErrInvalidToken = failure.New(auth.ErrUnauthenticated, "invalid token")
+	// 有効期限が切れている
+	ErrExpiredToken = failure.New(auth.ErrUnauthenticated, "expired token")
+)
+
+// セッションを示す資格情報の発行
+type Issuer interface {
+	Issue(*Session) (Token, error)
+}
+
+// 資格情報の検証
+// 資格情報が示すセッションを返す
+type Verifier interface {
+	Verify(Token) (*Session, error)
+}
+
+type Signer interface {
+	Issuer
+	Verifier
+}
+
+var now = time.Now
+
+// JWT と同じ header.payload.signature の形式
+var tokenHeader = encodeSegment([]byte(`{"alg":"HS256","typ":"JWT"}`))
+
+type tokenPayload struct {
+	UserID    UserID `json:"sub"`
+	SessionID ID     `json:"sid"`
+	ExpiresAt int64  `json:"exp"`
+}
+
+func encodeSegment(b []byte) string {
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// impl Signer
+// HMAC-SHA256 で署名する
+type hmacSigner struct {
+	key []byte
+}
+
+func NewSigner(key []byte) Signer {
+	return &hmacSigner{key}
+}
+
+func (s *hmacSigner) sign(unsigned string) string {
+	mac := hmac.New(sha256.New, s.key)
+	mac.Write([]byte(unsigned))
+	return encodeSegment(mac.Sum(nil))
+}
+
+func (s *hmacSigner) Issue(session *Session) (Token, error) {
+	payload, err := json.Marshal(&tokenPayload{
+		UserID:    session.UserID,
+		SessionID: session.ID,
+		ExpiresAt: session.ExpiresAt.Unix(),
+	})
+	if err != nil {
+		return "", fmt.Errorf("pkg/session.Issue: %w", err)
+	}
+
+	unsigned := tokenHeader + "." + encodeSegment(payload)
+	return Token(unsigned + "." + s.sign(unsigned)), nil
+}
+
+func (s *hmacSigner) Verify(token Token) (*Session, error) {
+	segments := strings.Split(string(token), ".")
+	if len(segments) != 3 || segments[0] != tokenHeader {
+		return nil, fmt.Errorf("pkg/session.Verify: %w", ErrInvalidToken)
+	}
+
+	unsigned := segments[0] + "." + segments[1]
+	if !hmac.Equal([]byte(segments[2]), []byte(s.sign(unsigned))) {
+		return nil, fmt.Errorf("pkg/session.Verify: %w", ErrInvalidToken)
+	}
+
+	b, err := base64.RawURLEncoding.DecodeString(segments[1])
+	if err != nil {
+		return nil, fmt.Errorf("pkg/session.Verify: %w: %v", ErrInvalidToken, err)
+	}
+
+	payload := tokenPayload{}
+	decoder := json.NewDecoder(bytes.NewReader(b))
+	decoder.DisallowUnknownFields()
+	err = decoder.Decode(&payload)
+	if err != nil || !payload.UserID.Valid() || !payload.SessionID.Valid() {
+		return nil, fmt.Errorf("pkg/session.Verify: %w", ErrInvalidToken)
+	}
+
+	session := New(payload.SessionID, payload.UserID, time.Unix(payload.ExpiresAt, 0))
+	if session.expired(now()) {
+		return nil, fmt.Errorf("pkg/session.Verify: %w", ErrExpiredToken)
+	}
+
+	session.Token = token
+	return session, nil
+}
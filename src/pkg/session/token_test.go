@@ -0,0 +1,109 @@
+package session
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSigner(t *testing.T) {
+	defer func() {
+		now = time.Now
+	}()
+
+	type test struct {
+		name    string
+		now     time.Time
+		modify  func(Token) Token
+		verify  Signer
+		want    *Session
+		wantErr error
+	}
+
+	issuer := NewSigner([]byte("secret"))
+	expiresAt := time.Date(2022, 9, 3, 13, 0, 0, 0, time.UTC)
+
+	do := func(tt *test) {
+		t.Run(tt.name, func(t *testing.T) {
+			token, err := issuer.Issue(New("session", 1, expiresAt))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			now = func() time.Time { return tt.now }
+			got, err := tt.verify.Verify(tt.modify(token))
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("want-error=%v, error=%v.", tt.wantErr, err)
+			}
+
+			if tt.want != nil {
+				tt.want.Token = tt.modify(token)
+				tt.want.ExpiresAt = got.ExpiresAt
+				if !expiresAt.Equal(got.ExpiresAt) {
+					t.Fatalf("expires-at want=%v, got=%v.", expiresAt, got.ExpiresAt)
+				}
+			}
+
+			if !reflect.DeepEqual(tt.want, got) {
+				t.Fatalf("want=%v, got=%v.", tt.want, got)
+			}
+		})
+	}
+
+	same := func(token Token) Token { return token }
+
+	tests := []*test{
+		{
+			name:    "ok",
+			now:     expiresAt.Add(-time.Second),
+			modify:  same,
+			verify:  issuer,
+			want:    &Session{ID: "session", UserID: 1},
+			wantErr: nil,
+		},
+		{
+			name:    "expired",
+			now:     expiresAt,
+			modify:  same,
+			verify:  issuer,
+			want:    nil,
+			wantErr: ErrExpiredToken,
+		},
+		{
+			name:    "other key",
+			now:     expiresAt.Add(-time.Hour),
+			modify:  same,
+			verify:  NewSigner([]byte("other")),
+			want:    nil,
+			wantErr: ErrInvalidToken,
+		},
+		{
+			name: "tampered payload",
+			now:  expiresAt.Add(-time.Hour),
+			modify: func(token Token) Token {
+				segments := strings.Split(string(token), ".")
+				segments[1] = encodeSegment([]byte(`{"sub":2,"sid":"session","exp":9999999999}`))
+				return Token(strings.Join(segments, "."))
+			},
+			verify:  issuer,
+			want:    nil,
+			wantErr: ErrInvalidToken,
+		},
+		{
+			name: "malformed",
+			now:  expiresAt.Add(-time.Hour),
+			modify: func(Token) Token {
+				return "token"
+			},
+			verify:  issuer,
+			want:    nil,
+			wantErr: ErrInvalidToken,
+		},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+}
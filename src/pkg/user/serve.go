@@ -1,21 +1,36 @@
 package user
 
 import (
+	"context"
 	"fmt"
+
+	"api.example.com/pkg/failure"
+	"api.example.com/pkg/page"
 )
 
 type Repository interface {
-	UserCreate(*User) (*User, error)
-	UserRead(ID) (*User, error)
-	UserUpdate(*User) (*User, error)
-	UserDelete(ID) error
+	UserCreate(context.Context, *User) (*User, error)
+	UserRead(context.Context, ID) (*User, error)
+	// name は一意
+	UserReadByName(context.Context, Name) (*User, error)
+	// 名前が prefix で始まり、 ID が after より大きいユーザーを ID の順に limit 件まで
+	UserList(ctx context.Context, prefix Name, after ID, limit int) ([]*User, error)
+	UserUpdate(context.Context, *User) (*User, error)
+	UserDelete(context.Context, ID) error
+	// ユーザーの全てのログインセッション
+	SessionDeleteByUserID(context.Context, ID) error
+	// fn の中の操作を1つのトランザクションで行う
+	UserTx(context.Context, func(Repository) error) error
 }
 
 type Server interface {
-	Create(*User) (*User, error)
-	Read(ID) (*User, error)
-	Update(*User) (*User, error)
-	Delete(ID) error
+	Create(context.Context, *User) (*User, error)
+	Read(context.Context, ID) (*User, error)
+	// ID の順に1ページずつ
+	List(context.Context, ListQuery) (*Page, error)
+	// 本人のみ操作できる
+	Update(context.Context, *User, Credential) (*User, error)
+	Delete(context.Context, ID, Credential) error
 }
 
 // impl Server
@@ -27,38 +42,114 @@ func NewServer(repo Repository) Server {
 	return &server{repo}
 }
 
-func (s *server) Create(u *User) (*User, error) {
-	ok := u.validCreate()
-	if !ok {
-		return nil, fmt.Errorf("user.Create: invalid user")
+func (s *server) Create(ctx context.Context, u *User) (*User, error) {
+	if v := u.validateCreate(); len(v) > 0 {
+		return nil, fmt.Errorf("pkg/user.Create: %w", failure.Invalid("invalid user", v))
 	}
 
-	return s.repository.UserCreate(u)
+	return s.repository.UserCreate(ctx, u)
 }
 
-func (s *server) Read(id ID) (*User, error) {
+func (s *server) Read(ctx context.Context, id ID) (*User, error) {
 	ok := id.Valid()
 	if !ok {
-		return nil, fmt.Errorf("pkg/user.Read: invalid user_id")
+		return nil, fmt.Errorf("pkg/user.Read: %w", failure.New(failure.ErrInvalidArgument, "invalid user_id"))
 	}
 
-	return s.repository.UserRead(id)
+	return s.repository.UserRead(ctx, id)
 }
 
-func (s *server) Update(u *User) (*User, error) {
-	ok := u.validUpdate()
-	if !ok {
-		return nil, fmt.Errorf("pkg/user.Update: invalid user")
+func (s *server) List(ctx context.Context, q ListQuery) (*Page, error) {
+	if v := q.validate(); len(v) > 0 {
+		return nil, fmt.Errorf("pkg/user.List: %w", failure.Invalid("invalid query", v))
+	}
+
+	after, err := q.Cursor.After()
+	if err != nil {
+		return nil, fmt.Errorf("pkg/user.List: %w", err)
+	}
+
+	// 続きの有無を知るため1件多く読み込む
+	size := q.Size()
+	list, err := s.repository.UserList(ctx, q.NamePrefix, ID(after), size+1)
+	if err != nil {
+		return nil, fmt.Errorf("pkg/user.List: %w", err)
+	}
+
+	list, next := page.Split(list, size, func(u *User) int {
+		return int(u.ID)
+	})
+	return &Page{Users: list, Next: next}, nil
+}
+
+// 呼び出し元が本人であり、現在のパスワードが一致すること
+// ユーザーの有無を推測させないため、本人でなければ読み込まずに拒否する
+func verify(ctx context.Context, repo Repository, id ID, c Credential) error {
+	if c.Caller != id {
+		return fmt.Errorf("%w: caller=%d", ErrForbidden, c.Caller)
+	}
+
+	if c.Password == "" {
+		return fmt.Errorf("%w: current password is required", ErrForbidden)
+	}
+
+	u, err := repo.UserRead(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if !u.Password.Verify(c.Password) {
+		return fmt.Errorf("%w: current password mismatch", ErrForbidden)
+	}
+
+	return nil
+}
+
+func (s *server) Update(ctx context.Context, u *User, c Credential) (*User, error) {
+	if v := u.validateUpdate(); len(v) > 0 {
+		return nil, fmt.Errorf("pkg/user.Update: %w", failure.Invalid("invalid user", v))
 	}
 
-	return s.repository.UserUpdate(u)
+	// 確認から更新、ログアウトまでを1つのトランザクションで行う
+	var updated *User
+	err := s.repository.UserTx(ctx, func(tx Repository) error {
+		err := verify(ctx, tx, u.ID, c)
+		if err != nil {
+			return err
+		}
+
+		updated, err = tx.UserUpdate(ctx, u)
+		if err != nil {
+			return err
+		}
+
+		// 更新ではパスワードが再設定されるため、全ての端末からログアウトさせる
+		return tx.SessionDeleteByUserID(ctx, u.ID)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("pkg/user.Update: %w", err)
+	}
+
+	return updated, nil
 }
 
-func (s *server) Delete(id ID) error {
+func (s *server) Delete(ctx context.Context, id ID, c Credential) error {
 	ok := id.Valid()
 	if !ok {
-		return fmt.Errorf("pkg/user.Delete: invalid user_id")
+		return fmt.Errorf("pkg/user.Delete: %w", failure.New(failure.ErrInvalidArgument, "invalid user_id"))
+	}
+
+	err := s.repository.UserTx(ctx, func(tx Repository) error {
+		err := verify(ctx, tx, id, c)
+		if err != nil {
+			return err
+		}
+
+		return tx.UserDelete(ctx, id)
+	})
+	if err != nil {
+		return fmt.Errorf("pkg/user.Delete: %w", err)
 	}
 
-	return s.repository.UserDelete(id)
+	return nil
 }
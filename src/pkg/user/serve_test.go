@@ -1,49 +1,99 @@
 package user
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"reflect"
+	"strings"
 	"testing"
 	"time"
+
+	"api.example.com/pkg/page"
 )
 
 // mock
 type repository struct {
-	user *User
-	err  error
+	user  *User
+	users []*User
+	err   error
 	// flags
-	create, read, update, delete bool
+	create, read, readByName, list, update, delete, deleteSessions bool
+	// UserList に渡された条件
+	prefix Name
+	after  ID
+	limit  int
+	// UserUpdate と UserDelete のみのエラー
+	writeErr error
+	// SessionDeleteByUserID のみのエラー
+	sessionErr error
+	// UserTx の開始に失敗する
+	txErr error
 }
 
-func (r *repository) UserCreate(*User) (*User, error) {
+func (r *repository) UserCreate(context.Context, *User) (*User, error) {
 	if r.create {
 		return r.user, r.err
 	}
 	return nil, fmt.Errorf("failed create")
 }
 
-func (r *repository) UserRead(ID) (*User, error) {
+func (r *repository) UserRead(context.Context, ID) (*User, error) {
 	if r.read {
 		return r.user, r.err
 	}
 	return nil, fmt.Errorf("failed read")
 }
 
-func (r *repository) UserUpdate(*User) (*User, error) {
+func (r *repository) UserReadByName(context.Context, Name) (*User, error) {
+	if r.readByName {
+		return r.user, r.err
+	}
+	return nil, fmt.Errorf("failed read by name")
+}
+
+func (r *repository) UserList(_ context.Context, prefix Name, after ID, limit int) ([]*User, error) {
+	if r.list {
+		r.prefix, r.after, r.limit = prefix, after, limit
+		return r.users, r.err
+	}
+	return nil, fmt.Errorf("failed list")
+}
+
+func (r *repository) UserUpdate(context.Context, *User) (*User, error) {
 	if r.update {
+		if r.writeErr != nil {
+			return nil, r.writeErr
+		}
 		return r.user, r.err
 	}
 	return nil, fmt.Errorf("failed update")
 }
 
-func (r *repository) UserDelete(ID) error {
+func (r *repository) UserDelete(context.Context, ID) error {
 	if r.delete {
+		if r.writeErr != nil {
+			return r.writeErr
+		}
 		return r.err
 	}
 	return fmt.Errorf("failed delete")
 }
 
+func (r *repository) SessionDeleteByUserID(context.Context, ID) error {
+	if r.deleteSessions {
+		return r.sessionErr
+	}
+	return fmt.Errorf("failed delete sessions")
+}
+
+func (r *repository) UserTx(_ context.Context, fn func(Repository) error) error {
+	if r.txErr != nil {
+		return r.txErr
+	}
+	return fn(r)
+}
+
 // test
 func TestNewServer(t *testing.T) {
 	type args struct {
@@ -95,7 +145,7 @@ func TestServer_Create(t *testing.T) {
 
 	do := func(tt *test) {
 		t.Run(tt.name, func(t *testing.T) {
-			got, err := tt.server.Create(tt.args.user)
+			got, err := tt.server.Create(context.Background(), tt.args.user)
 			if tt.wantErr != (err != nil) {
 				t.Fatalf("want-error=%v, error=%v.", tt.wantErr, err)
 			}
@@ -214,7 +264,7 @@ func TestServer_Read(t *testing.T) {
 
 	do := func(tt *test) {
 		t.Run(tt.name, func(t *testing.T) {
-			got, err := tt.server.Read(tt.args.id)
+			got, err := tt.server.Read(context.Background(), tt.args.id)
 			if tt.wantErr != (err != nil) {
 				t.Fatalf("want=%v, got=%v.", tt.wantErr, err)
 			}
@@ -284,9 +334,120 @@ func TestServer_Read(t *testing.T) {
 	}
 }
 
+func TestServer_List(t *testing.T) {
+	type want struct {
+		page *Page
+		// リポジトリに渡した条件
+		prefix Name
+		after  ID
+		limit  int
+	}
+
+	type test struct {
+		name    string
+		list    []*User
+		err     error
+		query   ListQuery
+		want    want
+		wantErr bool
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &repository{users: tt.list, err: tt.err, list: true}
+			got, err := NewServer(repo).List(context.Background(), tt.query)
+			if tt.wantErr != (err != nil) {
+				t.Fatalf("want-error=%v, error=%v.", tt.wantErr, err)
+			}
+
+			gotWant := want{page: got, prefix: repo.prefix, after: repo.after, limit: repo.limit}
+			if !reflect.DeepEqual(tt.want, gotWant) {
+				t.Fatalf("want=%v, got=%v.", tt.want, gotWant)
+			}
+		})
+	}
+
+	// ID が 1 から n のユーザー
+	newList := func(n int) []*User {
+		list := []*User{}
+		for i := 1; i <= n; i++ {
+			list = append(list, &User{ID: ID(i), Name: Name(fmt.Sprintf("user-%d", i))})
+		}
+		return list
+	}
+
+	tests := []*test{
+		{
+			name:  "next page",
+			list:  newList(3),
+			query: ListQuery{NamePrefix: "user", Query: page.Query{Limit: 2}},
+			want: want{
+				page:   &Page{Users: newList(2), Next: page.NewCursor(2)},
+				prefix: "user",
+				limit:  3,
+			},
+		},
+		{
+			name:  "last page",
+			list:  newList(2),
+			query: ListQuery{Query: page.Query{Cursor: page.NewCursor(5), Limit: 2}},
+			want: want{
+				page:  &Page{Users: newList(2)},
+				after: 5,
+				limit: 3,
+			},
+		},
+		{
+			name:  "default limit",
+			list:  []*User{},
+			query: ListQuery{},
+			want: want{
+				page:  &Page{Users: []*User{}},
+				limit: page.DefaultLimit + 1,
+			},
+		},
+		{
+			name:  "max limit",
+			list:  []*User{},
+			query: ListQuery{Query: page.Query{Limit: 1000}},
+			want: want{
+				page:  &Page{Users: []*User{}},
+				limit: page.MaxLimit + 1,
+			},
+		},
+		{
+			name:    "invalid limit",
+			query:   ListQuery{Query: page.Query{Limit: -1}},
+			wantErr: true,
+		},
+		{
+			name:    "invalid cursor",
+			query:   ListQuery{Query: page.Query{Cursor: "xxx"}},
+			wantErr: true,
+		},
+		{
+			name:    "too long name_prefix",
+			query:   ListQuery{NamePrefix: Name(strings.Repeat("a", 256))},
+			wantErr: true,
+		},
+		{
+			name:    "failed list",
+			err:     errors.New("internal server error"),
+			query:   ListQuery{},
+			want:    want{limit: page.DefaultLimit + 1},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+}
+
 func TestServer_Update(t *testing.T) {
 	type args struct {
-		user *User
+		user       *User
+		credential Credential
 	}
 
 	type test struct {
@@ -295,57 +456,136 @@ func TestServer_Update(t *testing.T) {
 		args    args
 		want    *User
 		wantErr bool
+		// 本人確認の失敗
+		forbidden bool
 	}
 
 	do := func(tt *test) {
 		t.Run(tt.name, func(t *testing.T) {
-			got, err := tt.server.Update(tt.args.user)
+			got, err := tt.server.Update(context.Background(), tt.args.user, tt.args.credential)
 			if tt.wantErr != (err != nil) {
 				t.Fatalf("want-error=%v, error=%v.", tt.wantErr, err)
 			}
 
+			if tt.forbidden != errors.Is(err, ErrForbidden) {
+				t.Fatalf("want-forbidden=%v, error=%v.", tt.forbidden, err)
+			}
+
 			if !reflect.DeepEqual(tt.want, got) {
 				t.Fatalf("want=%v, got=%v.", tt.want, got)
 			}
 		})
 	}
 
+	errInternal := errors.New("internal server error")
+	bob := func() *User {
+		return &User{
+			ID:        1,
+			Name:      "Bob",
+			Password:  newPassword("password"),
+			UpdatedAt: time.Date(2022, 8, 9, 12, 34, 56, 0, time.UTC),
+		}
+	}
 	tests := []*test{
 		{
 			name: "true",
 			server: NewServer(&repository{
+				user:           bob(),
+				read:           true,
+				update:         true,
+				deleteSessions: true,
+			}),
+			args: args{
 				user: &User{
-					ID:        1,
-					Name:      "Bob",
-					Password:  newPassword("password"),
-					UpdatedAt: time.Date(2022, 8, 9, 12, 34, 56, 0, time.UTC),
+					ID:       1,
+					Name:     "Bob",
+					Password: newPassword("qwertyui"),
 				},
+				credential: NewCredential(1, "password"),
+			},
+			want:    bob(),
+			wantErr: false,
+		},
+		{
+			// 本人でなければユーザーを読み込まずに拒否する
+			name: "without caller",
+			server: NewServer(&repository{
+				user:   bob(),
 				update: true,
 			}),
 			args: args{
 				user: &User{
 					ID:       1,
 					Name:     "Bob",
-					Password: newPassword("password"),
+					Password: newPassword("qwertyui"),
 				},
+				credential: NewCredential(0, "password"),
 			},
-			want: &User{
-				ID:        1,
-				Name:      "Bob",
-				Password:  newPassword("password"),
-				UpdatedAt: time.Date(2022, 8, 9, 12, 34, 56, 0, time.UTC),
+			want:      nil,
+			wantErr:   true,
+			forbidden: true,
+		},
+		{
+			name: "other caller",
+			server: NewServer(&repository{
+				user:   bob(),
+				update: true,
+			}),
+			args: args{
+				user: &User{
+					ID:       1,
+					Name:     "Bob",
+					Password: newPassword("qwertyui"),
+				},
+				credential: NewCredential(2, "password"),
 			},
-			wantErr: false,
+			want:      nil,
+			wantErr:   true,
+			forbidden: true,
 		},
 		{
-			name: "invalid user.id",
+			name: "without current password",
 			server: NewServer(&repository{
+				user:   bob(),
+				read:   true,
+				update: true,
+			}),
+			args: args{
 				user: &User{
-					ID:        1,
-					Name:      "Bob",
-					Password:  newPassword("password"),
-					UpdatedAt: time.Date(2022, 8, 9, 12, 34, 56, 0, time.UTC),
+					ID:       1,
+					Name:     "Bob",
+					Password: newPassword("qwertyui"),
 				},
+				credential: NewCredential(1, ""),
+			},
+			want:      nil,
+			wantErr:   true,
+			forbidden: true,
+		},
+		{
+			name: "wrong current password",
+			server: NewServer(&repository{
+				user:   bob(),
+				read:   true,
+				update: true,
+			}),
+			args: args{
+				user: &User{
+					ID:       1,
+					Name:     "Bob",
+					Password: newPassword("qwertyui"),
+				},
+				credential: NewCredential(1, "wrongpassword"),
+			},
+			want:      nil,
+			wantErr:   true,
+			forbidden: true,
+		},
+		{
+			name: "invalid user.id",
+			server: NewServer(&repository{
+				user:   bob(),
+				read:   true,
 				update: true,
 			}),
 			args: args{
@@ -354,6 +594,7 @@ func TestServer_Update(t *testing.T) {
 					Name:     "Bob",
 					Password: newPassword("password"),
 				},
+				credential: NewCredential(0, "password"),
 			},
 			want:    nil,
 			wantErr: true,
@@ -361,12 +602,8 @@ func TestServer_Update(t *testing.T) {
 		{
 			name: "invalid user.name",
 			server: NewServer(&repository{
-				user: &User{
-					ID:        1,
-					Name:      "Bob",
-					Password:  newPassword("password"),
-					UpdatedAt: time.Date(2022, 8, 9, 12, 34, 56, 0, time.UTC),
-				},
+				user:   bob(),
+				read:   true,
 				update: true,
 			}),
 			args: args{
@@ -375,6 +612,7 @@ func TestServer_Update(t *testing.T) {
 					Name:     "",
 					Password: newPassword("password"),
 				},
+				credential: NewCredential(1, "password"),
 			},
 			want:    nil,
 			wantErr: true,
@@ -382,20 +620,55 @@ func TestServer_Update(t *testing.T) {
 		{
 			name: "invalid user.password",
 			server: NewServer(&repository{
+				user:   bob(),
+				read:   true,
+				update: true,
+			}),
+			args: args{
 				user: &User{
-					ID:        1,
-					Name:      "Bob",
-					Password:  newPassword("password"),
-					UpdatedAt: time.Date(2022, 8, 9, 12, 34, 56, 0, time.UTC),
+					ID:       1,
+					Name:     "Bob",
+					Password: newPassword(""),
 				},
+				credential: NewCredential(1, "password"),
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "failed read",
+			server: NewServer(&repository{
+				err:    errInternal,
+				read:   true,
 				update: true,
 			}),
 			args: args{
 				user: &User{
 					ID:       1,
 					Name:     "Bob",
-					Password: newPassword(""),
+					Password: newPassword("password"),
 				},
+				credential: NewCredential(1, "password"),
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "failed delete sessions",
+			server: NewServer(&repository{
+				user:           bob(),
+				sessionErr:     errInternal,
+				read:           true,
+				update:         true,
+				deleteSessions: true,
+			}),
+			args: args{
+				user: &User{
+					ID:       1,
+					Name:     "Bob",
+					Password: newPassword("password"),
+				},
+				credential: NewCredential(1, "password"),
 			},
 			want:    nil,
 			wantErr: true,
@@ -403,8 +676,10 @@ func TestServer_Update(t *testing.T) {
 		{
 			name: "failed update",
 			server: NewServer(&repository{
-				err:    errors.New("internal server error"),
-				update: true,
+				user:     bob(),
+				writeErr: errInternal,
+				read:     true,
+				update:   true,
 			}),
 			args: args{
 				user: &User{
@@ -412,6 +687,27 @@ func TestServer_Update(t *testing.T) {
 					Name:     "Bob",
 					Password: newPassword("password"),
 				},
+				credential: NewCredential(1, "password"),
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "failed transaction",
+			server: NewServer(&repository{
+				user:           bob(),
+				txErr:          errInternal,
+				read:           true,
+				update:         true,
+				deleteSessions: true,
+			}),
+			args: args{
+				user: &User{
+					ID:       1,
+					Name:     "Bob",
+					Password: newPassword("password"),
+				},
+				credential: NewCredential(1, "password"),
 			},
 			want:    nil,
 			wantErr: true,
@@ -425,7 +721,8 @@ func TestServer_Update(t *testing.T) {
 
 func TestServer_Delete(t *testing.T) {
 	type args struct {
-		id ID
+		id         ID
+		credential Credential
 	}
 
 	type test struct {
@@ -433,48 +730,118 @@ func TestServer_Delete(t *testing.T) {
 		server  Server
 		args    args
 		wantErr bool
+		// 本人確認の失敗
+		forbidden bool
 	}
 
 	do := func(tt *test) {
 		t.Run(tt.name, func(t *testing.T) {
-			err := tt.server.Delete(tt.args.id)
+			err := tt.server.Delete(context.Background(), tt.args.id, tt.args.credential)
 			if tt.wantErr != (err != nil) {
 				t.Fatalf("want-error=%v, error=%v.", tt.wantErr, err)
 			}
+
+			if tt.forbidden != errors.Is(err, ErrForbidden) {
+				t.Fatalf("want-forbidden=%v, error=%v.", tt.forbidden, err)
+			}
 		})
 	}
 
+	errInternal := errors.New("internal server error")
+	bob := &User{ID: 1, Name: "Bob", Password: newPassword("password")}
 	tests := []*test{
 		{
 			name: "true",
 			server: NewServer(&repository{
-				err:    nil,
+				user:   bob,
+				read:   true,
 				delete: true,
 			}),
 			args: args{
-				id: 1,
+				id:         1,
+				credential: NewCredential(1, "password"),
 			},
 			wantErr: false,
 		},
+		{
+			// 本人でなければユーザーを読み込まずに拒否する
+			name: "without caller",
+			server: NewServer(&repository{
+				user:   bob,
+				delete: true,
+			}),
+			args: args{
+				id:         1,
+				credential: NewCredential(0, "password"),
+			},
+			wantErr:   true,
+			forbidden: true,
+		},
+		{
+			name: "other caller",
+			server: NewServer(&repository{
+				user:   bob,
+				delete: true,
+			}),
+			args: args{
+				id:         1,
+				credential: NewCredential(2, "password"),
+			},
+			wantErr:   true,
+			forbidden: true,
+		},
+		{
+			name: "wrong current password",
+			server: NewServer(&repository{
+				user:   bob,
+				read:   true,
+				delete: true,
+			}),
+			args: args{
+				id:         1,
+				credential: NewCredential(1, "wrongpassword"),
+			},
+			wantErr:   true,
+			forbidden: true,
+		},
 		{
 			name: "invalid user.id",
 			server: NewServer(&repository{
-				err:    nil,
+				user:   bob,
+				read:   true,
 				delete: true,
 			}),
 			args: args{
-				id: 0,
+				id:         0,
+				credential: NewCredential(0, "password"),
 			},
 			wantErr: true,
 		},
 		{
 			name: "failed delete",
 			server: NewServer(&repository{
-				err:    errors.New("internal server error"),
+				user:     bob,
+				writeErr: errInternal,
+				read:     true,
+				delete:   true,
+			}),
+			args: args{
+				id:         1,
+				credential: NewCredential(1, "password"),
+			},
+			wantErr: true,
+		},
+		{
+			name: "failed transaction",
+			server: NewServer(&repository{
+				user:   bob,
+				txErr:  errInternal,
+				read:   true,
 				delete: true,
 			}),
 			args: args{
-				id: 1,
+				id:         1,
+				credential: NewCredential(1, "password"),
 			},
 			wantErr: true,
 		},
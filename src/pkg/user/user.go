@@ -2,6 +2,9 @@ package user
 
 import (
 	"time"
+
+	"api.example.com/pkg/failure"
+	"api.example.com/pkg/page"
 )
 
 type ID int
@@ -13,8 +16,8 @@ func (id ID) Valid() bool {
 type Name string
 
 // 1 ≤ name.length ≤ 255
-func (n Name) valid() bool {
-	return len(n) > 0 && len(n) < 256
+func (n Name) validate() []failure.Violation {
+	return failure.Length("user.name", len(n), 1, 255)
 }
 
 type PlainPassword = string
@@ -26,8 +29,26 @@ type Password interface {
 }
 
 // 8 ≤ password.length ≤ 255
-func validPassword(p Password) bool {
-	return p.Length() > 7 && p.Length() < 256
+func validatePassword(p Password) []failure.Violation {
+	return failure.Length("user.password", p.Length(), 8, 255)
+}
+
+// 本人以外による操作、または現在のパスワードの誤り
+var ErrForbidden = failure.New(failure.ErrForbidden, "forbidden: not the account owner")
+
+// 本人確認に用いる資格情報
+type Credential struct {
+	// 識別済みの呼び出し元 (識別できない場合は 0)
+	Caller ID
+	// 現在のパスワード
+	Password PlainPassword
+}
+
+func NewCredential(caller ID, current PlainPassword) Credential {
+	return Credential{
+		Caller:   caller,
+		Password: current,
+	}
 }
 
 type User struct {
@@ -44,12 +65,31 @@ func New(name Name, pw Password) *User {
 	}
 }
 
-// valid user when create
-func (u *User) validCreate() bool {
-	return u.Name.valid() && validPassword(u.Password)
+// 作成時の入力値の違反
+func (u *User) validateCreate() []failure.Violation {
+	return append(u.Name.validate(), validatePassword(u.Password)...)
+}
+
+// 更新時の入力値の違反
+func (u *User) validateUpdate() []failure.Violation {
+	return append(failure.Min("user_id", int(u.ID), 1), u.validateCreate()...)
+}
+
+// 一覧の条件
+type ListQuery struct {
+	// 空でなければ名前がこれで始まるユーザーに絞り込む
+	NamePrefix Name
+	page.Query
+}
+
+// name_prefix.length ≤ 255
+func (q ListQuery) validate() []failure.Violation {
+	return append(failure.Length("name_prefix", len(q.NamePrefix), 0, 255), q.Query.Validate()...)
 }
 
-// vlid user when update
-func (u *User) validUpdate() bool {
-	return u.ID.Valid() && u.validCreate()
+// 一覧の1ページ
+type Page struct {
+	Users []*User
+	// 最後のページでは空
+	Next page.Cursor
 }
@@ -4,6 +4,8 @@ import (
 	"reflect"
 	"strings"
 	"testing"
+
+	"api.example.com/pkg/failure"
 )
 
 // mock password
@@ -65,7 +67,7 @@ func TestID_Valid(t *testing.T) {
 	}
 }
 
-func TestName_valid(t *testing.T) {
+func TestName_validate(t *testing.T) {
 	type test struct {
 		testname string
 		username Name
@@ -74,7 +76,7 @@ func TestName_valid(t *testing.T) {
 
 	do := func(tt *test) {
 		t.Run(tt.testname, func(t *testing.T) {
-			got := tt.username.valid()
+			got := len(tt.username.validate()) == 0
 			if tt.want != got {
 				t.Fatalf("want=%v, got=%v.", tt.want, got)
 			}
@@ -109,7 +111,7 @@ func TestName_valid(t *testing.T) {
 	}
 }
 
-func TestValidPassword(t *testing.T) {
+func TestValidatePassword(t *testing.T) {
 	type test struct {
 		name     string
 		password Password
@@ -118,7 +120,7 @@ func TestValidPassword(t *testing.T) {
 
 	do := func(tt *test) {
 		t.Run(tt.name, func(t *testing.T) {
-			got := validPassword(tt.password)
+			got := len(validatePassword(tt.password)) == 0
 			if tt.want != got {
 				t.Fatalf("want=%v, got=%v.", tt.want, got)
 			}
@@ -192,17 +194,17 @@ func TestNew(t *testing.T) {
 	}
 }
 
-func TestUser_validCreate(t *testing.T) {
+func TestUser_validateCreate(t *testing.T) {
 	type test struct {
 		name string
 		user *User
-		want bool
+		want []failure.Violation
 	}
 
 	do := func(tt *test) {
 		t.Run(tt.name, func(t *testing.T) {
-			got := tt.user.validCreate()
-			if tt.want != got {
+			got := tt.user.validateCreate()
+			if !reflect.DeepEqual(tt.want, got) {
 				t.Fatalf("want=%v, got=%v.", tt.want, got)
 			}
 		})
@@ -215,7 +217,7 @@ func TestUser_validCreate(t *testing.T) {
 				Name:     "Bob",
 				Password: newPassword("password"),
 			},
-			want: true,
+			want: nil,
 		},
 		{
 			name: "invalid user.name",
@@ -223,7 +225,7 @@ func TestUser_validCreate(t *testing.T) {
 				Name:     "",
 				Password: newPassword("password"),
 			},
-			want: false,
+			want: []failure.Violation{{Field: "user.name", Rule: failure.RuleMinLength, Limit: 1, Actual: 0}},
 		},
 		{
 			name: "invalid user.password",
@@ -231,7 +233,7 @@ func TestUser_validCreate(t *testing.T) {
 				Name:     "Bob",
 				Password: newPassword("1234567"),
 			},
-			want: false,
+			want: []failure.Violation{{Field: "user.password", Rule: failure.RuleMinLength, Limit: 8, Actual: 7}},
 		},
 	}
 
@@ -240,17 +242,17 @@ func TestUser_validCreate(t *testing.T) {
 	}
 }
 
-func TestUser_validUpdate(t *testing.T) {
+func TestUser_validateUpdate(t *testing.T) {
 	type test struct {
 		name string
 		user *User
-		want bool
+		want []failure.Violation
 	}
 
 	do := func(tt *test) {
 		t.Run(tt.name, func(t *testing.T) {
-			got := tt.user.validUpdate()
-			if tt.want != got {
+			got := tt.user.validateUpdate()
+			if !reflect.DeepEqual(tt.want, got) {
 				t.Fatalf("want=%v, got=%v.", tt.want, got)
 			}
 		})
@@ -264,7 +266,7 @@ func TestUser_validUpdate(t *testing.T) {
 				Name:     "Bob",
 				Password: newPassword("password"),
 			},
-			want: true,
+			want: nil,
 		},
 		{
 			name: "invalid user.id",
@@ -273,7 +275,7 @@ func TestUser_validUpdate(t *testing.T) {
 				Name:     "Bob",
 				Password: newPassword("password"),
 			},
-			want: false,
+			want: []failure.Violation{{Field: "user_id", Rule: failure.RuleMin, Limit: 1, Actual: 0}},
 		},
 		{
 			name: "invalid user.name",
@@ -282,7 +284,7 @@ func TestUser_validUpdate(t *testing.T) {
 				Name:     "",
 				Password: newPassword("password"),
 			},
-			want: false,
+			want: []failure.Violation{{Field: "user.name", Rule: failure.RuleMinLength, Limit: 1, Actual: 0}},
 		},
 		{
 			name: "invalid user.password",
@@ -291,7 +293,7 @@ func TestUser_validUpdate(t *testing.T) {
 				Name:     "Bob",
 				Password: newPassword(""),
 			},
-			want: false,
+			want: []failure.Violation{{Field: "user.password", Rule: failure.RuleMinLength, Limit: 8, Actual: 0}},
 		},
 	}
 
@@ -0,0 +1,95 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	apikeys "api.example.com/pkg/apikey"
+	"api.example.com/repository/model"
+)
+
+// company は実在すること
+func apiKeyCreate(ctx context.Context, tx Transaction, company model.Company, key model.APIKey) (*apikeys.Key, error) {
+	err := company.Read(ctx, tx)
+	if err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("repository.APIKeyCreate: company: %w", err)
+	}
+
+	err = key.Create(ctx, tx)
+	if err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("repository.APIKeyCreate: %w", err)
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, fmt.Errorf("repository.APIKeyCreate: %w", err)
+	}
+
+	return key.NewEntity(), nil
+}
+
+func apiKeyRead(ctx context.Context, db model.DB, key model.APIKey) (*apikeys.Key, error) {
+	err := key.Read(ctx, db)
+	if err != nil {
+		return nil, fmt.Errorf("repository.APIKeyRead: %w", err)
+	}
+
+	return key.NewEntity(), nil
+}
+
+func apiKeyReadByID(ctx context.Context, db model.DB, key model.APIKey) (*apikeys.Key, error) {
+	err := key.ReadByID(ctx, db)
+	if err != nil {
+		return nil, fmt.Errorf("repository.APIKeyReadByID: %w", err)
+	}
+
+	return key.NewEntity(), nil
+}
+
+func apiKeyList(ctx context.Context, db model.DB, list model.APIKeys) ([]*apikeys.Key, error) {
+	err := list.Read(ctx, db)
+	if err != nil {
+		return nil, fmt.Errorf("repository.APIKeyList: %w", err)
+	}
+
+	return list.NewEntity(), nil
+}
+
+// 更新後の名前とスコープを返すため、読み込み直す
+func apiKeyUpdate(ctx context.Context, tx Transaction, key model.APIKey) (*apikeys.Key, error) {
+	err := key.Update(ctx, tx)
+	if err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("repository.APIKeyUpdate: %w", err)
+	}
+
+	err = key.Read(ctx, tx)
+	if err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("repository.APIKeyUpdate: %w", err)
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, fmt.Errorf("repository.APIKeyUpdate: %w", err)
+	}
+
+	return key.NewEntity(), nil
+}
+
+func apiKeyDelete(ctx context.Context, tx Transaction, key model.APIKey) error {
+	err := key.Delete(ctx, tx)
+	if err != nil {
+		tx.Rollback()
+		return fmt.Errorf("repository.APIKeyDelete: %w", err)
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return fmt.Errorf("repository.APIKeyDelete: %w", err)
+	}
+
+	return nil
+}
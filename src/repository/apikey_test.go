@@ -0,0 +1,452 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	apikeys "api.example.com/pkg/apikey"
+	"api.example.com/repository/model"
+)
+
+type makeModelAPIKey func(*testing.T) model.APIKey
+
+// mock
+type modelAPIKey struct {
+	entity *apikeys.Key
+	err    error
+	// 読み込みのみ失敗させる
+	errRead error
+	// flags
+	create, read, readByID, update, delete, newEntity bool
+	// test
+	t *testing.T
+}
+
+func (k *modelAPIKey) Create(ctx context.Context, tx model.DB) error {
+	k.t.Helper()
+	if k.create {
+		return k.err
+	}
+
+	k.t.Fatal("invalid Create")
+	panic("invalid Create")
+}
+
+func (k *modelAPIKey) Read(ctx context.Context, tx model.DB) error {
+	k.t.Helper()
+	if k.read {
+		if k.errRead != nil {
+			return k.errRead
+		}
+		return k.err
+	}
+
+	k.t.Fatal("invalid Read")
+	panic("invalid Read")
+}
+
+func (k *modelAPIKey) ReadByID(ctx context.Context, tx model.DB) error {
+	k.t.Helper()
+	if k.readByID {
+		return k.err
+	}
+
+	k.t.Fatal("invalid ReadByID")
+	panic("invalid ReadByID")
+}
+
+func (k *modelAPIKey) Update(ctx context.Context, tx model.DB) error {
+	k.t.Helper()
+	if k.update {
+		return k.err
+	}
+
+	k.t.Fatal("invalid Update")
+	panic("invalid Update")
+}
+
+func (k *modelAPIKey) Delete(ctx context.Context, tx model.DB) error {
+	k.t.Helper()
+	if k.delete {
+		return k.err
+	}
+
+	k.t.Fatal("invalid Delete")
+	panic("invalid Delete")
+}
+
+func (k *modelAPIKey) NewEntity() *apikeys.Key {
+	k.t.Helper()
+	if k.newEntity {
+		return k.entity
+	}
+
+	k.t.Fatal("invalid NewEntity")
+	panic("invalid NewEntity")
+}
+
+// mock
+type modelAPIKeys struct {
+	entities []*apikeys.Key
+	err      error
+	// flags
+	read, newEntity bool
+	// test
+	t *testing.T
+}
+
+func (l *modelAPIKeys) Read(ctx context.Context, tx model.DB) error {
+	l.t.Helper()
+	if l.read {
+		return l.err
+	}
+
+	l.t.Fatal("invalid Read")
+	panic("invalid Read")
+}
+
+func (l *modelAPIKeys) NewEntity() []*apikeys.Key {
+	l.t.Helper()
+	if l.newEntity {
+		return l.entities
+	}
+
+	l.t.Fatal("invalid NewEntity")
+	panic("invalid NewEntity")
+}
+
+func TestAPIKeyCreate(t *testing.T) {
+	type test struct {
+		name        string
+		tx          Transaction
+		makeCompany makeModelCompany
+		makeKey     makeModelAPIKey
+		want        *apikeys.Key
+		wantErr     bool
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := apiKeyCreate(context.Background(), tt.tx, tt.makeCompany(t), tt.makeKey(t))
+			if tt.wantErr != (err != nil) {
+				t.Fatalf("want-error=%v, error=%v.", tt.wantErr, err)
+			}
+
+			if !reflect.DeepEqual(tt.want, got) {
+				t.Fatalf("want=%v, got=%v.", tt.want, got)
+			}
+		})
+	}
+
+	entity := &apikeys.Key{ID: 2, CompanyID: 1, Name: "batch", Scopes: []apikeys.Scope{apikeys.ScopeRead}}
+	tests := []*test{
+		{
+			name: "ok",
+			tx: &transaction{
+				commit: true,
+			},
+			makeCompany: func(t *testing.T) model.Company {
+				return &modelCompany{read: true, t: t}
+			},
+			makeKey: func(t *testing.T) model.APIKey {
+				return &modelAPIKey{entity: entity, create: true, newEntity: true, t: t}
+			},
+			want:    entity,
+			wantErr: false,
+		},
+		{
+			name: "company not found",
+			tx: &transaction{
+				rollback: true,
+			},
+			makeCompany: func(t *testing.T) model.Company {
+				return &modelCompany{err: errors.New("not found"), read: true, t: t}
+			},
+			makeKey: func(t *testing.T) model.APIKey {
+				return &modelAPIKey{t: t}
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "failed create",
+			tx: &transaction{
+				rollback: true,
+			},
+			makeCompany: func(t *testing.T) model.Company {
+				return &modelCompany{read: true, t: t}
+			},
+			makeKey: func(t *testing.T) model.APIKey {
+				return &modelAPIKey{err: errors.New("test error"), create: true, t: t}
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "failed commit",
+			tx: &transaction{
+				errCommit: errors.New("test error"),
+				commit:    true,
+			},
+			makeCompany: func(t *testing.T) model.Company {
+				return &modelCompany{read: true, t: t}
+			},
+			makeKey: func(t *testing.T) model.APIKey {
+				return &modelAPIKey{create: true, t: t}
+			},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+}
+
+func TestAPIKeyRead(t *testing.T) {
+	type test struct {
+		name    string
+		read    func(context.Context, model.DB, model.APIKey) (*apikeys.Key, error)
+		makeKey makeModelAPIKey
+		want    *apikeys.Key
+		wantErr bool
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.read(context.Background(), &mockDB{}, tt.makeKey(t))
+			if tt.wantErr != (err != nil) {
+				t.Fatalf("want-error=%v, error=%v.", tt.wantErr, err)
+			}
+
+			if !reflect.DeepEqual(tt.want, got) {
+				t.Fatalf("want=%v, got=%v.", tt.want, got)
+			}
+		})
+	}
+
+	entity := &apikeys.Key{ID: 2, CompanyID: 1, Name: "batch", Scopes: []apikeys.Scope{apikeys.ScopeRead}}
+	tests := []*test{
+		{
+			name: "ok",
+			read: apiKeyRead,
+			makeKey: func(t *testing.T) model.APIKey {
+				return &modelAPIKey{entity: entity, read: true, newEntity: true, t: t}
+			},
+			want:    entity,
+			wantErr: false,
+		},
+		{
+			name: "failed read",
+			read: apiKeyRead,
+			makeKey: func(t *testing.T) model.APIKey {
+				return &modelAPIKey{err: errors.New("test error"), read: true, t: t}
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "by id",
+			read: apiKeyReadByID,
+			makeKey: func(t *testing.T) model.APIKey {
+				return &modelAPIKey{entity: entity, readByID: true, newEntity: true, t: t}
+			},
+			want:    entity,
+			wantErr: false,
+		},
+		{
+			name: "failed read by id",
+			read: apiKeyReadByID,
+			makeKey: func(t *testing.T) model.APIKey {
+				return &modelAPIKey{err: errors.New("test error"), readByID: true, t: t}
+			},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+}
+
+func TestAPIKeyList(t *testing.T) {
+	type test struct {
+		name     string
+		makeList func(*testing.T) model.APIKeys
+		want     []*apikeys.Key
+		wantErr  bool
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := apiKeyList(context.Background(), &mockDB{}, tt.makeList(t))
+			if tt.wantErr != (err != nil) {
+				t.Fatalf("want-error=%v, error=%v.", tt.wantErr, err)
+			}
+
+			if !reflect.DeepEqual(tt.want, got) {
+				t.Fatalf("want=%v, got=%v.", tt.want, got)
+			}
+		})
+	}
+
+	entities := []*apikeys.Key{{ID: 2, CompanyID: 1, Name: "batch"}}
+	tests := []*test{
+		{
+			name: "ok",
+			makeList: func(t *testing.T) model.APIKeys {
+				return &modelAPIKeys{entities: entities, read: true, newEntity: true, t: t}
+			},
+			want:    entities,
+			wantErr: false,
+		},
+		{
+			name: "failed read",
+			makeList: func(t *testing.T) model.APIKeys {
+				return &modelAPIKeys{err: errors.New("test error"), read: true, t: t}
+			},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+}
+
+func TestAPIKeyUpdate(t *testing.T) {
+	type test struct {
+		name    string
+		tx      Transaction
+		makeKey makeModelAPIKey
+		want    *apikeys.Key
+		wantErr bool
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := apiKeyUpdate(context.Background(), tt.tx, tt.makeKey(t))
+			if tt.wantErr != (err != nil) {
+				t.Fatalf("want-error=%v, error=%v.", tt.wantErr, err)
+			}
+
+			if !reflect.DeepEqual(tt.want, got) {
+				t.Fatalf("want=%v, got=%v.", tt.want, got)
+			}
+		})
+	}
+
+	entity := &apikeys.Key{ID: 2, CompanyID: 1, Name: "batch", Scopes: []apikeys.Scope{apikeys.ScopeRead}}
+	tests := []*test{
+		{
+			name: "ok",
+			tx: &transaction{
+				commit: true,
+			},
+			makeKey: func(t *testing.T) model.APIKey {
+				return &modelAPIKey{entity: entity, update: true, read: true, newEntity: true, t: t}
+			},
+			want:    entity,
+			wantErr: false,
+		},
+		{
+			name: "failed update",
+			tx: &transaction{
+				rollback: true,
+			},
+			makeKey: func(t *testing.T) model.APIKey {
+				return &modelAPIKey{err: errors.New("test error"), update: true, t: t}
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "failed read",
+			tx: &transaction{
+				rollback: true,
+			},
+			makeKey: func(t *testing.T) model.APIKey {
+				return &modelAPIKey{errRead: errors.New("test error"), update: true, read: true, t: t}
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "failed commit",
+			tx: &transaction{
+				errCommit: errors.New("test error"),
+				commit:    true,
+			},
+			makeKey: func(t *testing.T) model.APIKey {
+				return &modelAPIKey{update: true, read: true, t: t}
+			},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+}
+
+func TestAPIKeyDelete(t *testing.T) {
+	type test struct {
+		name    string
+		tx      Transaction
+		makeKey makeModelAPIKey
+		wantErr bool
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.name, func(t *testing.T) {
+			err := apiKeyDelete(context.Background(), tt.tx, tt.makeKey(t))
+			if tt.wantErr != (err != nil) {
+				t.Fatalf("want-error=%v, error=%v.", tt.wantErr, err)
+			}
+		})
+	}
+
+	tests := []*test{
+		{
+			name: "ok",
+			tx: &transaction{
+				commit: true,
+			},
+			makeKey: func(t *testing.T) model.APIKey {
+				return &modelAPIKey{delete: true, t: t}
+			},
+			wantErr: false,
+		},
+		{
+			name: "failed delete",
+			tx: &transaction{
+				rollback: true,
+			},
+			makeKey: func(t *testing.T) model.APIKey {
+				return &modelAPIKey{err: errors.New("test error"), delete: true, t: t}
+			},
+			wantErr: true,
+		},
+		{
+			name: "failed commit",
+			tx: &transaction{
+				errCommit: errors.New("test error"),
+				commit:    true,
+			},
+			makeKey: func(t *testing.T) model.APIKey {
+				return &modelAPIKey{delete: true, t: t}
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+}
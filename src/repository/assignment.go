@@ -0,0 +1,107 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	assignments "api.example.com/pkg/assignment"
+	"api.example.com/repository/model"
+)
+
+// 従業員に同じ部署と肩書きの組が既に配置されていれば assignments.ErrDuplicate
+func assignmentCheckDuplicate(ctx context.Context, tx model.DB, sameEmployee model.Assignments, a *assignments.Assignment) error {
+	err := sameEmployee.Read(ctx, tx)
+	if err != nil {
+		return err
+	}
+
+	for _, same := range sameEmployee.NewEntity() {
+		if same.DepartmentID == a.DepartmentID && same.RoleID == a.RoleID {
+			return assignments.ErrDuplicate
+		}
+	}
+
+	return nil
+}
+
+// employee, department, role は配置と同じ会社に実在すること
+// sameEmployee は従業員の配置
+func assignmentCreate(
+	ctx context.Context,
+	tx Transaction,
+	employee model.Employee,
+	department model.Department,
+	role model.Role,
+	sameEmployee model.Assignments,
+	assignment model.Assignment,
+) (*assignments.Assignment, error) {
+	err := employee.Read(ctx, tx)
+	if err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("repository.AssignmentCreate: employee: %w", err)
+	}
+
+	err = department.Read(ctx, tx)
+	if err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("repository.AssignmentCreate: department: %w", err)
+	}
+
+	err = role.Read(ctx, tx)
+	if err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("repository.AssignmentCreate: role: %w", err)
+	}
+
+	err = assignmentCheckDuplicate(ctx, tx, sameEmployee, assignment.NewEntity())
+	if err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("repository.AssignmentCreate: %w", err)
+	}
+
+	err = assignment.Create(ctx, tx)
+	if err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("repository.AssignmentCreate: %w", err)
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, fmt.Errorf("repository.AssignmentCreate: %w", err)
+	}
+
+	return assignment.NewEntity(), nil
+}
+
+func assignmentRead(ctx context.Context, db model.DB, assignment model.Assignment) (*assignments.Assignment, error) {
+	err := assignment.Read(ctx, db)
+	if err != nil {
+		return nil, fmt.Errorf("repository.AssignmentRead: %w", err)
+	}
+
+	return assignment.NewEntity(), nil
+}
+
+func assignmentList(ctx context.Context, db model.DB, list model.Assignments) ([]*assignments.Assignment, error) {
+	err := list.Read(ctx, db)
+	if err != nil {
+		return nil, fmt.Errorf("repository.AssignmentList: %w", err)
+	}
+
+	return list.NewEntity(), nil
+}
+
+func assignmentDelete(ctx context.Context, tx Transaction, assignment model.Assignment) error {
+	err := assignment.Delete(ctx, tx)
+	if err != nil {
+		tx.Rollback()
+		return fmt.Errorf("repository.AssignmentDelete: %w", err)
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return fmt.Errorf("repository.AssignmentDelete: %w", err)
+	}
+
+	return nil
+}
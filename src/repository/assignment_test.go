@@ -0,0 +1,255 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	assignments "api.example.com/pkg/assignment"
+	"api.example.com/repository/model"
+)
+
+// mock
+type modelAssignment struct {
+	entity *assignments.Assignment
+	err    error
+	// flags
+	create, read, delete, newEntity bool
+	// test
+	t *testing.T
+}
+
+func (a *modelAssignment) Create(ctx context.Context, tx model.DB) error {
+	a.t.Helper()
+	if a.create {
+		return a.err
+	}
+
+	a.t.Fatal("invalid Create")
+	panic("invalid Create")
+}
+
+func (a *modelAssignment) Read(ctx context.Context, tx model.DB) error {
+	a.t.Helper()
+	if a.read {
+		return a.err
+	}
+
+	a.t.Fatal("invalid Read")
+	panic("invalid Read")
+}
+
+func (a *modelAssignment) Delete(ctx context.Context, tx model.DB) error {
+	a.t.Helper()
+	if a.delete {
+		return a.err
+	}
+
+	a.t.Fatal("invalid Delete")
+	panic("invalid Delete")
+}
+
+func (a *modelAssignment) NewEntity() *assignments.Assignment {
+	a.t.Helper()
+	if a.newEntity {
+		return a.entity
+	}
+
+	a.t.Fatal("invalid NewEntity")
+	panic("invalid NewEntity")
+}
+
+// mock
+type modelAssignments struct {
+	entity []*assignments.Assignment
+	err    error
+	// flags
+	read, newEntity bool
+	// test
+	t *testing.T
+}
+
+func (l *modelAssignments) Read(ctx context.Context, tx model.DB) error {
+	l.t.Helper()
+	if l.read {
+		return l.err
+	}
+
+	l.t.Fatal("invalid Read")
+	panic("invalid Read")
+}
+
+func (l *modelAssignments) NewEntity() []*assignments.Assignment {
+	l.t.Helper()
+	if l.newEntity {
+		return l.entity
+	}
+
+	l.t.Fatal("invalid NewEntity")
+	panic("invalid NewEntity")
+}
+
+func TestAssignmentCreate(t *testing.T) {
+	type test struct {
+		name             string
+		tx               Transaction
+		makeEmployee     makeModelEmployee
+		makeDepartment   makeModelDepartment
+		makeRole         func(*testing.T) model.Role
+		makeSameEmployee func(*testing.T) model.Assignments
+		makeAssignment   func(*testing.T) model.Assignment
+		want             *assignments.Assignment
+		wantErr          error
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := assignmentCreate(
+				context.Background(),
+				tt.tx,
+				tt.makeEmployee(t),
+				tt.makeDepartment(t),
+				tt.makeRole(t),
+				tt.makeSameEmployee(t),
+				tt.makeAssignment(t),
+			)
+			if tt.wantErr == nil && err != nil {
+				t.Fatalf("error=%v.", err)
+			}
+
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Fatalf("want-error=%v, error=%v.", tt.wantErr, err)
+			}
+
+			if !reflect.DeepEqual(tt.want, got) {
+				t.Fatalf("want=%v, got=%v.", tt.want, got)
+			}
+		})
+	}
+
+	entity := &assignments.Assignment{
+		ID:           5,
+		CompanyID:    1,
+		EmployeeID:   2,
+		DepartmentID: 3,
+		RoleID:       4,
+		UpdatedAt:    time.Date(2022, 9, 3, 12, 34, 56, 0, time.UTC),
+	}
+	// 兼任
+	concurrent := &assignments.Assignment{ID: 6, CompanyID: 1, EmployeeID: 2, DepartmentID: 7, RoleID: 4}
+	errNotFound := errors.New("not found")
+
+	tests := []*test{
+		{
+			name: "ok",
+			tx:   &transaction{commit: true},
+			makeEmployee: func(t *testing.T) model.Employee {
+				return &modelEmployee{read: true, t: t}
+			},
+			makeDepartment: func(t *testing.T) model.Department {
+				return &modelDepartment{read: true, t: t}
+			},
+			makeRole: func(t *testing.T) model.Role {
+				return &modelRole{read: true, t: t}
+			},
+			makeSameEmployee: func(t *testing.T) model.Assignments {
+				return &modelAssignments{entity: []*assignments.Assignment{concurrent}, read: true, newEntity: true, t: t}
+			},
+			makeAssignment: func(t *testing.T) model.Assignment {
+				return &modelAssignment{entity: entity, create: true, newEntity: true, t: t}
+			},
+			want:    entity,
+			wantErr: nil,
+		},
+		{
+			name: "employee not found",
+			tx:   &transaction{rollback: true},
+			makeEmployee: func(t *testing.T) model.Employee {
+				return &modelEmployee{err: errNotFound, read: true, t: t}
+			},
+			makeDepartment: func(t *testing.T) model.Department {
+				return &modelDepartment{t: t}
+			},
+			makeRole: func(t *testing.T) model.Role {
+				return &modelRole{t: t}
+			},
+			makeSameEmployee: func(t *testing.T) model.Assignments {
+				return &modelAssignments{t: t}
+			},
+			makeAssignment: func(t *testing.T) model.Assignment {
+				return &modelAssignment{t: t}
+			},
+			want:    nil,
+			wantErr: errNotFound,
+		},
+		{
+			name: "department in other company",
+			tx:   &transaction{rollback: true},
+			makeEmployee: func(t *testing.T) model.Employee {
+				return &modelEmployee{read: true, t: t}
+			},
+			makeDepartment: func(t *testing.T) model.Department {
+				return &modelDepartment{err: errNotFound, read: true, t: t}
+			},
+			makeRole: func(t *testing.T) model.Role {
+				return &modelRole{t: t}
+			},
+			makeSameEmployee: func(t *testing.T) model.Assignments {
+				return &modelAssignments{t: t}
+			},
+			makeAssignment: func(t *testing.T) model.Assignment {
+				return &modelAssignment{t: t}
+			},
+			want:    nil,
+			wantErr: errNotFound,
+		},
+		{
+			name: "role in other company",
+			tx:   &transaction{rollback: true},
+			makeEmployee: func(t *testing.T) model.Employee {
+				return &modelEmployee{read: true, t: t}
+			},
+			makeDepartment: func(t *testing.T) model.Department {
+				return &modelDepartment{read: true, t: t}
+			},
+			makeRole: func(t *testing.T) model.Role {
+				return &modelRole{err: errNotFound, read: true, t: t}
+			},
+			makeSameEmployee: func(t *testing.T) model.Assignments {
+				return &modelAssignments{t: t}
+			},
+			makeAssignment: func(t *testing.T) model.Assignment {
+				return &modelAssignment{t: t}
+			},
+			want:    nil,
+			wantErr: errNotFound,
+		},
+		{
+			name: "duplicate",
+			tx:   &transaction{rollback: true},
+			makeEmployee: func(t *testing.T) model.Employee {
+				return &modelEmployee{read: true, t: t}
+			},
+			makeDepartment: func(t *testing.T) model.Department {
+				return &modelDepartment{read: true, t: t}
+			},
+			makeRole: func(t *testing.T) model.Role {
+				return &modelRole{read: true, t: t}
+			},
+			makeSameEmployee: func(t *testing.T) model.Assignments {
+				return &modelAssignments{entity: []*assignments.Assignment{entity}, read: true, newEntity: true, t: t}
+			},
+			makeAssignment: func(t *testing.T) model.Assignment {
+				return &modelAssignment{entity: entity, newEntity: true, t: t}
+			},
+			want:    nil,
+			wantErr: assignments.ErrDuplicate,
+		},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+}
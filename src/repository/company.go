@@ -1,32 +1,121 @@
 package repository
 
 import (
+	"context"
+	"errors"
 	"fmt"
 
 	companies "api.example.com/pkg/company"
+	"api.example.com/pkg/failure"
 	"api.example.com/repository/model"
 )
 
-func companyCreate(tx Transaction, model model.Company) (*companies.Company, error) {
-	err := model.Create(tx)
+// owner は実在するユーザーであること
+// 会社を作成したユーザー(owner)は管理者として会社の従業員になる
+func companyCreate(
+	ctx context.Context,
+	tx Transaction,
+	owner model.User,
+	company model.Company,
+	newAdmin func(*companies.Company) model.Employee,
+) (*companies.Company, error) {
+	err := owner.Read(ctx, tx)
+	if err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("repository.CompanyCreate: owner: %w", err)
+	}
+
+	err = company.Create(ctx, tx)
 	if err != nil {
 		tx.Rollback()
 		return nil, fmt.Errorf("repository.CompanyCreate: %w:", err)
 	}
 
+	entity := company.NewEntity()
+	err = newAdmin(entity).Create(ctx, tx)
+	if err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("repository.CompanyCreate: administrator: %w", err)
+	}
+
 	err = tx.Commit()
 	if err != nil {
 		return nil, fmt.Errorf("repository.CompanyCreate: %w:", err)
 	}
 
-	return model.NewEntity(), nil
+	return entity, nil
 }
 
-func companyRead(db DB, model model.Company) (*companies.Company, error) {
-	err := model.Read(db)
+func companyRead(ctx context.Context, db model.DB, model model.Company) (*companies.Company, error) {
+	err := model.Read(ctx, db)
 	if err != nil {
 		return nil, fmt.Errorf("repository.CompanyRead: %w:", err)
 	}
 
 	return model.NewEntity(), nil
 }
+
+func companyList(ctx context.Context, db model.DB, list model.Companies) ([]*companies.Company, error) {
+	err := list.Read(ctx, db)
+	if err != nil {
+		return nil, fmt.Errorf("repository.CompanyList: %w", err)
+	}
+
+	return list.NewEntity(), nil
+}
+
+// current は更新前の会社
+// 所有者を変更する場合、新しい所有者(owner)は会社の管理者であること
+func companyUpdate(ctx context.Context, tx Transaction, current model.Company, owner model.Employee, model model.Company) (*companies.Company, error) {
+	err := current.Read(ctx, tx)
+	if err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("repository.CompanyUpdate: %w", err)
+	}
+
+	if current.NewEntity().OwnerID != model.NewEntity().OwnerID {
+		err = companyCheckOwner(ctx, tx, owner)
+		if err != nil {
+			tx.Rollback()
+			return nil, fmt.Errorf("repository.CompanyUpdate: owner: %w", err)
+		}
+	}
+
+	err = model.Update(ctx, tx)
+	if err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("repository.CompanyUpdate: %w", err)
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, fmt.Errorf("repository.CompanyUpdate: %w", err)
+	}
+
+	return model.NewEntity(), nil
+}
+
+// 管理者の従業員でなければ failure.ErrConflict
+func companyCheckOwner(ctx context.Context, tx model.DB, owner model.Employee) error {
+	err := owner.ReadByUserID(ctx, tx)
+	if errors.Is(err, failure.ErrNotFound) || (err == nil && !owner.NewEntity().Administrator) {
+		return failure.Conflict("company.owner_id is not an administrator", "company.owner_id", failure.RuleAdministrator)
+	}
+
+	return err
+}
+
+func companyDelete(ctx context.Context, tx Transaction, model model.Company) error {
+	err := model.Delete(ctx, tx)
+	if err != nil {
+		tx.Rollback()
+		return fmt.Errorf("repository.CompanyDelete: %w", err)
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return fmt.Errorf("repository.CompanyDelete: %w", err)
+	}
+
+	return nil
+}
@@ -1,12 +1,15 @@
 package repository
 
 import (
+	"context"
 	"errors"
 	"reflect"
 	"testing"
 	"time"
 
 	companies "api.example.com/pkg/company"
+	employees "api.example.com/pkg/employee"
+	"api.example.com/pkg/failure"
 	"api.example.com/repository/model"
 )
 
@@ -17,12 +20,12 @@ type modelCompany struct {
 	entity *companies.Company
 	err    error
 	// flags
-	create, read, newEntity bool
+	create, read, update, delete, newEntity bool
 	// test
 	t *testing.T
 }
 
-func (c *modelCompany) Create(tx model.DB) error {
+func (c *modelCompany) Create(ctx context.Context, tx model.DB) error {
 	c.t.Helper()
 	if c.create {
 		return c.err
@@ -32,7 +35,7 @@ func (c *modelCompany) Create(tx model.DB) error {
 	panic("invalid Create")
 }
 
-func (c *modelCompany) Read(tx model.DB) error {
+func (c *modelCompany) Read(ctx context.Context, tx model.DB) error {
 	c.t.Helper()
 	if c.read {
 		return c.err
@@ -42,6 +45,26 @@ func (c *modelCompany) Read(tx model.DB) error {
 	panic("invalid Read")
 }
 
+func (c *modelCompany) Update(ctx context.Context, tx model.DB) error {
+	c.t.Helper()
+	if c.update {
+		return c.err
+	}
+
+	c.t.Fatal("invalid Update")
+	panic("invalid Update")
+}
+
+func (c *modelCompany) Delete(ctx context.Context, tx model.DB) error {
+	c.t.Helper()
+	if c.delete {
+		return c.err
+	}
+
+	c.t.Fatal("invalid Delete")
+	panic("invalid Delete")
+}
+
 func (c *modelCompany) NewEntity() *companies.Company {
 	c.t.Helper()
 	if c.newEntity {
@@ -51,20 +74,38 @@ func (c *modelCompany) NewEntity() *companies.Company {
 	panic("invalid NewEntity")
 }
 
-func TestCompanyCreate(t *testing.T) {
-	t.Skip("TODO OwnerID")
+// mock
+type modelCompanies struct {
+	entity []*companies.Company
+	err    error
+}
+
+func (l *modelCompanies) Read(ctx context.Context, tx model.DB) error {
+	return l.err
+}
 
+func (l *modelCompanies) NewEntity() []*companies.Company {
+	return l.entity
+}
+
+func TestCompanyCreate(t *testing.T) {
 	type test struct {
 		name        string
 		tx          Transaction
+		owner       model.User
 		makeCompany makeModelCompany
+		makeAdmin   func(*testing.T) model.Employee
 		want        *companies.Company
 		wantErr     bool
 	}
 
 	do := func(tt *test) {
 		t.Run(tt.name, func(t *testing.T) {
-			got, err := companyCreate(tt.tx, tt.makeCompany(t))
+			newAdmin := func(*companies.Company) model.Employee {
+				return tt.makeAdmin(t)
+			}
+
+			got, err := companyCreate(context.Background(), tt.tx, tt.owner, tt.makeCompany(t), newAdmin)
 			if tt.wantErr != (err != nil) {
 				t.Fatalf("want-error=%v, error=%v.", tt.wantErr, err)
 			}
@@ -81,6 +122,7 @@ func TestCompanyCreate(t *testing.T) {
 			tx: &transaction{
 				commit: true,
 			},
+			owner: &user{read: true},
 			makeCompany: func(t *testing.T) model.Company {
 				return &modelCompany{
 					entity: &companies.Company{
@@ -94,6 +136,12 @@ func TestCompanyCreate(t *testing.T) {
 					t:         t,
 				}
 			},
+			makeAdmin: func(t *testing.T) model.Employee {
+				return &modelEmployee{
+					create: true,
+					t:      t,
+				}
+			},
 			want: &companies.Company{
 				ID:        1,
 				Name:      "GREATE COMPANY",
@@ -107,6 +155,7 @@ func TestCompanyCreate(t *testing.T) {
 			tx: &transaction{
 				rollback: true,
 			},
+			owner: &user{read: true},
 			makeCompany: func(t *testing.T) model.Company {
 				return &modelCompany{
 					err:    errors.New("test error"),
@@ -123,8 +172,17 @@ func TestCompanyCreate(t *testing.T) {
 				errCommit: errors.New("test error"),
 				commit:    true,
 			},
+			owner: &user{read: true},
 			makeCompany: func(t *testing.T) model.Company {
 				return &modelCompany{
+					entity:    &companies.Company{ID: 1, Name: "GREATE COMPANY", OwnerID: 2},
+					create:    true,
+					newEntity: true,
+					t:         t,
+				}
+			},
+			makeAdmin: func(t *testing.T) model.Employee {
+				return &modelEmployee{
 					create: true,
 					t:      t,
 				}
@@ -132,6 +190,45 @@ func TestCompanyCreate(t *testing.T) {
 			want:    nil,
 			wantErr: true,
 		},
+		{
+			name: "failed create administrator",
+			tx: &transaction{
+				rollback: true,
+			},
+			owner: &user{read: true},
+			makeCompany: func(t *testing.T) model.Company {
+				return &modelCompany{
+					entity:    &companies.Company{ID: 1, Name: "GREATE COMPANY", OwnerID: 2},
+					create:    true,
+					newEntity: true,
+					t:         t,
+				}
+			},
+			makeAdmin: func(t *testing.T) model.Employee {
+				return &modelEmployee{
+					err:    errors.New("test error"),
+					create: true,
+					t:      t,
+				}
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "owner not found",
+			tx: &transaction{
+				rollback: true,
+			},
+			owner: &user{
+				err:  errors.New("test error"),
+				read: true,
+			},
+			makeCompany: func(t *testing.T) model.Company {
+				return &modelCompany{t: t}
+			},
+			want:    nil,
+			wantErr: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -150,7 +247,7 @@ func TestCompanyRead(t *testing.T) {
 
 	do := func(tt *test) {
 		t.Run(tt.name, func(t *testing.T) {
-			got, err := companyRead(tt.db, tt.makeCompany(t))
+			got, err := companyRead(context.Background(), tt.db, tt.makeCompany(t))
 			if tt.wantErr != (err != nil) {
 				t.Fatalf("want-error=%v, error=%v.", tt.wantErr, err)
 			}
@@ -204,3 +301,256 @@ func TestCompanyRead(t *testing.T) {
 		do(tt)
 	}
 }
+
+func TestCompanyList(t *testing.T) {
+	type test struct {
+		name    string
+		list    model.Companies
+		want    []*companies.Company
+		wantErr bool
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := companyList(context.Background(), &mockDB{}, tt.list)
+			if tt.wantErr != (err != nil) {
+				t.Fatalf("want-error=%v, error=%v.", tt.wantErr, err)
+			}
+
+			if !reflect.DeepEqual(tt.want, got) {
+				t.Fatalf("want=%v, got=%v.", tt.want, got)
+			}
+		})
+	}
+
+	tests := []*test{
+		{
+			name: "ok",
+			list: &modelCompanies{
+				entity: []*companies.Company{{ID: 1, Name: "testCompany", OwnerID: 1}},
+			},
+			want: []*companies.Company{{ID: 1, Name: "testCompany", OwnerID: 1}},
+		},
+		{
+			name:    "failed read",
+			list:    &modelCompanies{err: errors.New("test error")},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+}
+
+func TestCompanyUpdate(t *testing.T) {
+	type test struct {
+		name string
+		tx   Transaction
+		// 更新前の所有者
+		currentOwner companies.OwnerID
+		// 新しい所有者の従業員
+		makeOwner   func(*testing.T) model.Employee
+		makeCompany makeModelCompany
+		want        *companies.Company
+		wantErr     error
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.name, func(t *testing.T) {
+			current := &modelCompany{
+				entity:    &companies.Company{ID: 1, OwnerID: tt.currentOwner},
+				read:      true,
+				newEntity: true,
+				t:         t,
+			}
+			if tt.currentOwner == 0 {
+				current = &modelCompany{err: failure.ErrNotFound, read: true, t: t}
+			}
+
+			got, err := companyUpdate(context.Background(), tt.tx, current, tt.makeOwner(t), tt.makeCompany(t))
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("want=%v, got=%v.", tt.wantErr, err)
+			}
+
+			if !reflect.DeepEqual(tt.want, got) {
+				t.Fatalf("want=%v, got=%v.", tt.want, got)
+			}
+		})
+	}
+
+	entity := &companies.Company{
+		ID:        1,
+		Name:      "greate company",
+		OwnerID:   2,
+		UpdatedAt: time.Date(2022, 9, 3, 12, 34, 56, 0, time.UTC),
+	}
+	testErr := errors.New("test error")
+
+	tests := []*test{
+		{
+			name:         "ok",
+			tx:           &transaction{commit: true},
+			currentOwner: 2,
+			makeOwner: func(t *testing.T) model.Employee {
+				return &modelEmployee{t: t}
+			},
+			makeCompany: func(t *testing.T) model.Company {
+				return &modelCompany{entity: entity, update: true, newEntity: true, t: t}
+			},
+			want: entity,
+		},
+		{
+			name:         "change owner to administrator",
+			tx:           &transaction{commit: true},
+			currentOwner: 1,
+			makeOwner: func(t *testing.T) model.Employee {
+				return &modelEmployee{
+					entity:       &employees.Employee{ID: 3, CompanyID: 1, UserID: 2, Administrator: true},
+					readByUserID: true,
+					newEntity:    true,
+					t:            t,
+				}
+			},
+			makeCompany: func(t *testing.T) model.Company {
+				return &modelCompany{entity: entity, update: true, newEntity: true, t: t}
+			},
+			want: entity,
+		},
+		{
+			name:         "change owner to not administrator",
+			tx:           &transaction{rollback: true},
+			currentOwner: 1,
+			makeOwner: func(t *testing.T) model.Employee {
+				return &modelEmployee{
+					entity:       &employees.Employee{ID: 3, CompanyID: 1, UserID: 2, Administrator: false},
+					readByUserID: true,
+					newEntity:    true,
+					t:            t,
+				}
+			},
+			makeCompany: func(t *testing.T) model.Company {
+				return &modelCompany{entity: entity, newEntity: true, t: t}
+			},
+			wantErr: failure.ErrConflict,
+		},
+		{
+			name:         "change owner to not employee",
+			tx:           &transaction{rollback: true},
+			currentOwner: 1,
+			makeOwner: func(t *testing.T) model.Employee {
+				return &modelEmployee{err: failure.ErrNotFound, readByUserID: true, t: t}
+			},
+			makeCompany: func(t *testing.T) model.Company {
+				return &modelCompany{entity: entity, newEntity: true, t: t}
+			},
+			wantErr: failure.ErrConflict,
+		},
+		{
+			name: "not found",
+			tx:   &transaction{rollback: true},
+			makeOwner: func(t *testing.T) model.Employee {
+				return &modelEmployee{t: t}
+			},
+			makeCompany: func(t *testing.T) model.Company {
+				return &modelCompany{t: t}
+			},
+			wantErr: failure.ErrNotFound,
+		},
+		{
+			name:         "failed update",
+			tx:           &transaction{rollback: true},
+			currentOwner: 2,
+			makeOwner: func(t *testing.T) model.Employee {
+				return &modelEmployee{t: t}
+			},
+			makeCompany: func(t *testing.T) model.Company {
+				return &modelCompany{entity: entity, err: testErr, update: true, newEntity: true, t: t}
+			},
+			wantErr: testErr,
+		},
+		{
+			name:         "failed commit",
+			tx:           &transaction{errCommit: testErr, commit: true},
+			currentOwner: 2,
+			makeOwner: func(t *testing.T) model.Employee {
+				return &modelEmployee{t: t}
+			},
+			makeCompany: func(t *testing.T) model.Company {
+				return &modelCompany{entity: entity, update: true, newEntity: true, t: t}
+			},
+			wantErr: testErr,
+		},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+}
+
+func TestCompanyDelete(t *testing.T) {
+	type test struct {
+		name        string
+		tx          Transaction
+		makeCompany makeModelCompany
+		wantErr     bool
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.name, func(t *testing.T) {
+			err := companyDelete(context.Background(), tt.tx, tt.makeCompany(t))
+			if tt.wantErr != (err != nil) {
+				t.Fatalf("want-error=%v, error=%v.", tt.wantErr, err)
+			}
+		})
+	}
+
+	tests := []*test{
+		{
+			name: "ok",
+			tx: &transaction{
+				commit: true,
+			},
+			makeCompany: func(t *testing.T) model.Company {
+				return &modelCompany{
+					delete: true,
+					t:      t,
+				}
+			},
+			wantErr: false,
+		},
+		{
+			name: "failed delete",
+			tx: &transaction{
+				rollback: true,
+			},
+			makeCompany: func(t *testing.T) model.Company {
+				return &modelCompany{
+					err:    errors.New("test error"),
+					delete: true,
+					t:      t,
+				}
+			},
+			wantErr: true,
+		},
+		{
+			name: "failed commit",
+			tx: &transaction{
+				errCommit: errors.New("test error"),
+				commit:    true,
+			},
+			makeCompany: func(t *testing.T) model.Company {
+				return &modelCompany{
+					delete: true,
+					t:      t,
+				}
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+}
@@ -0,0 +1,160 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	organizations "api.example.com/pkg/organization"
+	"api.example.com/repository/model"
+)
+
+// company は実在すること
+// parent が nil の場合は最上位の部署とする
+func departmentCreate(
+	ctx context.Context,
+	tx Transaction,
+	company model.Company,
+	parent model.Department,
+	department model.Department,
+) (*organizations.Department, error) {
+	err := company.Read(ctx, tx)
+	if err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("repository.DepartmentCreate: company: %w", err)
+	}
+
+	if parent != nil {
+		err = parent.Read(ctx, tx)
+		if err != nil {
+			tx.Rollback()
+			return nil, fmt.Errorf("repository.DepartmentCreate: parent: %w", err)
+		}
+	}
+
+	err = department.Create(ctx, tx)
+	if err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("repository.DepartmentCreate: %w", err)
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, fmt.Errorf("repository.DepartmentCreate: %w", err)
+	}
+
+	return department.NewEntity(), nil
+}
+
+func departmentRead(ctx context.Context, db model.DB, department model.Department) (*organizations.Department, error) {
+	err := department.Read(ctx, db)
+	if err != nil {
+		return nil, fmt.Errorf("repository.DepartmentRead: %w", err)
+	}
+
+	return department.NewEntity(), nil
+}
+
+func departmentUpdate(ctx context.Context, tx Transaction, department model.Department) (*organizations.Department, error) {
+	err := department.Update(ctx, tx)
+	if err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("repository.DepartmentUpdate: %w", err)
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, fmt.Errorf("repository.DepartmentUpdate: %w", err)
+	}
+
+	return department.NewEntity(), nil
+}
+
+// parent が nil の場合は最上位へ移動する
+// 配下の部署の経路も同じトランザクションで更新する
+func departmentMove(
+	ctx context.Context,
+	tx Transaction,
+	parent model.Department,
+	department model.Department,
+) (*organizations.Department, error) {
+	if parent != nil {
+		err := parent.Read(ctx, tx)
+		if err != nil {
+			tx.Rollback()
+			return nil, fmt.Errorf("repository.DepartmentMove: parent: %w", err)
+		}
+	}
+
+	err := department.Move(ctx, tx)
+	if err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("repository.DepartmentMove: %w", err)
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, fmt.Errorf("repository.DepartmentMove: %w", err)
+	}
+
+	return department.NewEntity(), nil
+}
+
+func departmentDelete(ctx context.Context, tx Transaction, department model.Department) error {
+	err := department.Delete(ctx, tx)
+	if err != nil {
+		tx.Rollback()
+		return fmt.Errorf("repository.DepartmentDelete: %w", err)
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return fmt.Errorf("repository.DepartmentDelete: %w", err)
+	}
+
+	return nil
+}
+
+// parent が nil の場合は最上位の部署の一覧
+func departmentChildren(ctx context.Context, db model.DB, parent model.Department, children model.Departments) ([]*organizations.Department, error) {
+	if parent != nil {
+		err := parent.Read(ctx, db)
+		if err != nil {
+			return nil, fmt.Errorf("repository.DepartmentChildren: parent: %w", err)
+		}
+	}
+
+	err := children.Read(ctx, db)
+	if err != nil {
+		return nil, fmt.Errorf("repository.DepartmentChildren: %w", err)
+	}
+
+	return children.NewEntity(), nil
+}
+
+func departmentAncestors(ctx context.Context, db model.DB, department model.Department, ancestors model.Departments) ([]*organizations.Department, error) {
+	err := department.Read(ctx, db)
+	if err != nil {
+		return nil, fmt.Errorf("repository.DepartmentAncestors: %w", err)
+	}
+
+	err = ancestors.Read(ctx, db)
+	if err != nil {
+		return nil, fmt.Errorf("repository.DepartmentAncestors: %w", err)
+	}
+
+	return ancestors.NewEntity(), nil
+}
+
+func departmentSubtree(ctx context.Context, db model.DB, department model.Department, subtree model.Departments) ([]*organizations.Department, error) {
+	err := department.Read(ctx, db)
+	if err != nil {
+		return nil, fmt.Errorf("repository.DepartmentSubtree: %w", err)
+	}
+
+	err = subtree.Read(ctx, db)
+	if err != nil {
+		return nil, fmt.Errorf("repository.DepartmentSubtree: %w", err)
+	}
+
+	return subtree.NewEntity(), nil
+}
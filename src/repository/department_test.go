@@ -0,0 +1,446 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	organizations "api.example.com/pkg/organization"
+	"api.example.com/repository/model"
+)
+
+type makeModelDepartment func(*testing.T) model.Department
+
+// mock
+type modelDepartment struct {
+	entity *organizations.Department
+	err    error
+	// flags
+	create, read, update, move, delete, newEntity bool
+	// test
+	t *testing.T
+}
+
+func (d *modelDepartment) Create(ctx context.Context, tx model.DB) error {
+	d.t.Helper()
+	if d.create {
+		return d.err
+	}
+
+	d.t.Fatal("invalid Create")
+	panic("invalid Create")
+}
+
+func (d *modelDepartment) Read(ctx context.Context, tx model.DB) error {
+	d.t.Helper()
+	if d.read {
+		return d.err
+	}
+
+	d.t.Fatal("invalid Read")
+	panic("invalid Read")
+}
+
+func (d *modelDepartment) Update(ctx context.Context, tx model.DB) error {
+	d.t.Helper()
+	if d.update {
+		return d.err
+	}
+
+	d.t.Fatal("invalid Update")
+	panic("invalid Update")
+}
+
+func (d *modelDepartment) Move(ctx context.Context, tx model.DB) error {
+	d.t.Helper()
+	if d.move {
+		return d.err
+	}
+
+	d.t.Fatal("invalid Move")
+	panic("invalid Move")
+}
+
+func (d *modelDepartment) Delete(ctx context.Context, tx model.DB) error {
+	d.t.Helper()
+	if d.delete {
+		return d.err
+	}
+
+	d.t.Fatal("invalid Delete")
+	panic("invalid Delete")
+}
+
+func (d *modelDepartment) NewEntity() *organizations.Department {
+	d.t.Helper()
+	if d.newEntity {
+		return d.entity
+	}
+
+	d.t.Fatal("invalid NewEntity")
+	panic("invalid NewEntity")
+}
+
+// mock
+type modelDepartments struct {
+	entity []*organizations.Department
+	err    error
+	// flags
+	read, newEntity bool
+	// test
+	t *testing.T
+}
+
+func (l *modelDepartments) Read(ctx context.Context, tx model.DB) error {
+	l.t.Helper()
+	if l.read {
+		return l.err
+	}
+
+	l.t.Fatal("invalid Read")
+	panic("invalid Read")
+}
+
+func (l *modelDepartments) NewEntity() []*organizations.Department {
+	l.t.Helper()
+	if l.newEntity {
+		return l.entity
+	}
+
+	l.t.Fatal("invalid NewEntity")
+	panic("invalid NewEntity")
+}
+
+func TestDepartmentCreate(t *testing.T) {
+	type test struct {
+		name           string
+		tx             Transaction
+		makeCompany    makeModelCompany
+		makeParent     func(*testing.T) model.Department
+		makeDepartment makeModelDepartment
+		want           *organizations.Department
+		wantErr        bool
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := departmentCreate(context.Background(), tt.tx, tt.makeCompany(t), tt.makeParent(t), tt.makeDepartment(t))
+			if tt.wantErr != (err != nil) {
+				t.Fatalf("want-error=%v, error=%v.", tt.wantErr, err)
+			}
+
+			if !reflect.DeepEqual(tt.want, got) {
+				t.Fatalf("want=%v, got=%v.", tt.want, got)
+			}
+		})
+	}
+
+	entity := &organizations.Department{
+		ID:        3,
+		CompanyID: 1,
+		ParentID:  2,
+		Name:      "開発一課",
+		UpdatedAt: time.Date(2022, 9, 3, 12, 34, 56, 0, time.UTC),
+	}
+
+	tests := []*test{
+		{
+			name: "ok",
+			tx:   &transaction{commit: true},
+			makeCompany: func(t *testing.T) model.Company {
+				return &modelCompany{read: true, t: t}
+			},
+			makeParent: func(t *testing.T) model.Department {
+				return &modelDepartment{read: true, t: t}
+			},
+			makeDepartment: func(t *testing.T) model.Department {
+				return &modelDepartment{entity: entity, create: true, newEntity: true, t: t}
+			},
+			want:    entity,
+			wantErr: false,
+		},
+		{
+			name: "ok (root)",
+			tx:   &transaction{commit: true},
+			makeCompany: func(t *testing.T) model.Company {
+				return &modelCompany{read: true, t: t}
+			},
+			makeParent: func(t *testing.T) model.Department {
+				return nil
+			},
+			makeDepartment: func(t *testing.T) model.Department {
+				return &modelDepartment{entity: entity, create: true, newEntity: true, t: t}
+			},
+			want:    entity,
+			wantErr: false,
+		},
+		{
+			name: "company not found",
+			tx:   &transaction{rollback: true},
+			makeCompany: func(t *testing.T) model.Company {
+				return &modelCompany{err: errors.New("not found"), read: true, t: t}
+			},
+			makeParent: func(t *testing.T) model.Department {
+				return nil
+			},
+			makeDepartment: func(t *testing.T) model.Department {
+				return &modelDepartment{t: t}
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "parent not found",
+			tx:   &transaction{rollback: true},
+			makeCompany: func(t *testing.T) model.Company {
+				return &modelCompany{read: true, t: t}
+			},
+			makeParent: func(t *testing.T) model.Department {
+				return &modelDepartment{err: errors.New("not found"), read: true, t: t}
+			},
+			makeDepartment: func(t *testing.T) model.Department {
+				return &modelDepartment{t: t}
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "failed create",
+			tx:   &transaction{rollback: true},
+			makeCompany: func(t *testing.T) model.Company {
+				return &modelCompany{read: true, t: t}
+			},
+			makeParent: func(t *testing.T) model.Department {
+				return nil
+			},
+			makeDepartment: func(t *testing.T) model.Department {
+				return &modelDepartment{err: errors.New("failed"), create: true, t: t}
+			},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+}
+
+func TestDepartmentMove(t *testing.T) {
+	type test struct {
+		name           string
+		tx             Transaction
+		makeParent     func(*testing.T) model.Department
+		makeDepartment makeModelDepartment
+		want           *organizations.Department
+		wantErr        error
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := departmentMove(context.Background(), tt.tx, tt.makeParent(t), tt.makeDepartment(t))
+			if tt.wantErr == nil && err != nil {
+				t.Fatalf("error=%v.", err)
+			}
+
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Fatalf("want-error=%v, error=%v.", tt.wantErr, err)
+			}
+
+			if !reflect.DeepEqual(tt.want, got) {
+				t.Fatalf("want=%v, got=%v.", tt.want, got)
+			}
+		})
+	}
+
+	entity := &organizations.Department{
+		ID:        3,
+		CompanyID: 1,
+		ParentID:  2,
+		Name:      "開発一課",
+		UpdatedAt: time.Date(2022, 9, 3, 12, 34, 56, 0, time.UTC),
+	}
+	errNotFound := errors.New("not found")
+
+	tests := []*test{
+		{
+			name: "ok",
+			tx:   &transaction{commit: true},
+			makeParent: func(t *testing.T) model.Department {
+				return &modelDepartment{read: true, t: t}
+			},
+			makeDepartment: func(t *testing.T) model.Department {
+				return &modelDepartment{entity: entity, move: true, newEntity: true, t: t}
+			},
+			want:    entity,
+			wantErr: nil,
+		},
+		{
+			name: "ok (root)",
+			tx:   &transaction{commit: true},
+			makeParent: func(t *testing.T) model.Department {
+				return nil
+			},
+			makeDepartment: func(t *testing.T) model.Department {
+				return &modelDepartment{entity: entity, move: true, newEntity: true, t: t}
+			},
+			want:    entity,
+			wantErr: nil,
+		},
+		{
+			name: "parent not found",
+			tx:   &transaction{rollback: true},
+			makeParent: func(t *testing.T) model.Department {
+				return &modelDepartment{err: errNotFound, read: true, t: t}
+			},
+			makeDepartment: func(t *testing.T) model.Department {
+				return &modelDepartment{t: t}
+			},
+			want:    nil,
+			wantErr: errNotFound,
+		},
+		{
+			name: "cycle",
+			tx:   &transaction{rollback: true},
+			makeParent: func(t *testing.T) model.Department {
+				return &modelDepartment{read: true, t: t}
+			},
+			makeDepartment: func(t *testing.T) model.Department {
+				return &modelDepartment{err: organizations.ErrCycle, move: true, t: t}
+			},
+			want:    nil,
+			wantErr: organizations.ErrCycle,
+		},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+}
+
+func TestDepartmentAncestors(t *testing.T) {
+	type test struct {
+		name           string
+		makeDepartment makeModelDepartment
+		makeAncestors  func(*testing.T) model.Departments
+		want           []*organizations.Department
+		wantErr        bool
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := departmentAncestors(context.Background(), &mockDB{}, tt.makeDepartment(t), tt.makeAncestors(t))
+			if tt.wantErr != (err != nil) {
+				t.Fatalf("want-error=%v, error=%v.", tt.wantErr, err)
+			}
+
+			if !reflect.DeepEqual(tt.want, got) {
+				t.Fatalf("want=%v, got=%v.", tt.want, got)
+			}
+		})
+	}
+
+	tests := []*test{
+		{
+			name: "ok",
+			makeDepartment: func(t *testing.T) model.Department {
+				return &modelDepartment{read: true, t: t}
+			},
+			makeAncestors: func(t *testing.T) model.Departments {
+				return &modelDepartments{
+					entity:    []*organizations.Department{{ID: 1}, {ID: 2, ParentID: 1}},
+					read:      true,
+					newEntity: true,
+					t:         t,
+				}
+			},
+			want:    []*organizations.Department{{ID: 1}, {ID: 2, ParentID: 1}},
+			wantErr: false,
+		},
+		{
+			name: "not found",
+			makeDepartment: func(t *testing.T) model.Department {
+				return &modelDepartment{err: errors.New("not found"), read: true, t: t}
+			},
+			makeAncestors: func(t *testing.T) model.Departments {
+				return &modelDepartments{t: t}
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "failed read",
+			makeDepartment: func(t *testing.T) model.Department {
+				return &modelDepartment{read: true, t: t}
+			},
+			makeAncestors: func(t *testing.T) model.Departments {
+				return &modelDepartments{err: errors.New("failed"), read: true, t: t}
+			},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+}
+
+func TestDepartmentSubtree(t *testing.T) {
+	type test struct {
+		name           string
+		makeDepartment makeModelDepartment
+		makeSubtree    func(*testing.T) model.Departments
+		want           []*organizations.Department
+		wantErr        bool
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := departmentSubtree(context.Background(), &mockDB{}, tt.makeDepartment(t), tt.makeSubtree(t))
+			if tt.wantErr != (err != nil) {
+				t.Fatalf("want-error=%v, error=%v.", tt.wantErr, err)
+			}
+
+			if !reflect.DeepEqual(tt.want, got) {
+				t.Fatalf("want=%v, got=%v.", tt.want, got)
+			}
+		})
+	}
+
+	tests := []*test{
+		{
+			name: "ok",
+			makeDepartment: func(t *testing.T) model.Department {
+				return &modelDepartment{read: true, t: t}
+			},
+			makeSubtree: func(t *testing.T) model.Departments {
+				return &modelDepartments{
+					entity:    []*organizations.Department{{ID: 1}, {ID: 2, ParentID: 1}, {ID: 3, ParentID: 2}},
+					read:      true,
+					newEntity: true,
+					t:         t,
+				}
+			},
+			want:    []*organizations.Department{{ID: 1}, {ID: 2, ParentID: 1}, {ID: 3, ParentID: 2}},
+			wantErr: false,
+		},
+		{
+			name: "not found",
+			makeDepartment: func(t *testing.T) model.Department {
+				return &modelDepartment{err: errors.New("not found"), read: true, t: t}
+			},
+			makeSubtree: func(t *testing.T) model.Departments {
+				return &modelDepartments{t: t}
+			},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+}
@@ -0,0 +1,63 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"api.example.com/repository/model"
+)
+
+// 方言に合わせて文とエラーを変換する接続
+type dialectDB struct {
+	db      model.DB
+	dialect model.Dialect
+}
+
+func (d *dialectDB) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	result, err := d.db.ExecContext(ctx, d.dialect.Rebind(query), args...)
+	if err != nil {
+		return nil, d.dialect.Error(query, err)
+	}
+	return result, nil
+}
+
+func (d *dialectDB) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	rows, err := d.db.QueryContext(ctx, d.dialect.Rebind(query), args...)
+	if err != nil {
+		return nil, d.dialect.Error(query, err)
+	}
+	return rows, nil
+}
+
+func (d *dialectDB) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	return d.db.QueryRowContext(ctx, d.dialect.Rebind(query), args...)
+}
+
+// 方言に合わせて文とエラーを変換するトランザクション
+type dialectTx struct {
+	dialectDB
+	tx Transaction
+}
+
+func (t *dialectTx) Commit() error {
+	return t.tx.Commit()
+}
+
+func (t *dialectTx) Rollback() error {
+	return t.tx.Rollback()
+}
+
+// 方言がなければ (MySQL) そのまま返す
+func (r *repository) bindDB(db model.DB) model.DB {
+	if r.dialect == nil {
+		return db
+	}
+	return &dialectDB{db: db, dialect: r.dialect}
+}
+
+func (r *repository) bindTx(tx Transaction) Transaction {
+	if r.dialect == nil {
+		return tx
+	}
+	return &dialectTx{dialectDB: dialectDB{db: tx, dialect: r.dialect}, tx: tx}
+}
@@ -0,0 +1,194 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"reflect"
+	"testing"
+
+	companies "api.example.com/pkg/company"
+	"api.example.com/pkg/failure"
+	organizations "api.example.com/pkg/organization"
+	permissions "api.example.com/pkg/permission"
+	users "api.example.com/pkg/user"
+	"api.example.com/pkg/user/password"
+	"api.example.com/repository/model"
+	_ "github.com/mattn/go-sqlite3"
+)
+
+// SQLite のメモリ上のデータベースを使う Repository
+// 接続ごとに別のデータベースとなるため、接続は1つに限る
+func newSQLiteRepository(t *testing.T) Repository {
+	t.Helper()
+
+	db, err := sql.Open("sqlite3", "file::memory:?_foreign_keys=1")
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	db.SetMaxOpenConns(1)
+
+	repo, err := NewSQLite(context.Background(), db)
+	if err != nil {
+		db.Close()
+		t.Fatalf("want=nil, got=%v.", err)
+	}
+	t.Cleanup(func() { repo.Close() })
+	return repo
+}
+
+func TestNewSQLite(t *testing.T) {
+	db, err := sql.Open("sqlite3", "file::memory:")
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	defer db.Close()
+
+	got, err := NewSQLite(context.Background(), db)
+	if err != nil {
+		t.Fatalf("want=nil, got=%v.", err)
+	}
+
+	want := &repository{db: db, dialect: model.SQLite}
+	if !reflect.DeepEqual(want, got) {
+		t.Fatalf("want=%v, got=%v.", want, got)
+	}
+
+	// 閉じた接続では表を作成できない
+	db.Close()
+	_, err = NewSQLite(context.Background(), db)
+	if err == nil {
+		t.Fatalf("want=error, got=nil.")
+	}
+}
+
+func TestRepository_SQLite(t *testing.T) {
+	ctx := context.Background()
+	repo := newSQLiteRepository(t)
+
+	bob, err := repo.UserCreate(ctx, users.New("bob", password.FromHash([]byte("password"))))
+	if err != nil {
+		t.Fatalf("want=nil, got=%v.", err)
+	}
+
+	t.Run("duplicate user", func(t *testing.T) {
+		_, err := repo.UserCreate(ctx, users.New("bob", password.FromHash([]byte("password"))))
+		if !errors.Is(err, failure.ErrConflict) {
+			t.Fatalf("want=%v, got=%v.", failure.ErrConflict, err)
+		}
+
+		want := []failure.Violation{{Field: "user.name", Rule: failure.RuleUnique}}
+		if got := failure.Violations(err); !reflect.DeepEqual(want, got) {
+			t.Fatalf("want=%v, got=%v.", want, got)
+		}
+	})
+
+	company, err := repo.CompanyCreate(ctx, companies.New("GREATE COMPANY", bob.ID))
+	if err != nil {
+		t.Fatalf("want=nil, got=%v.", err)
+	}
+
+	t.Run("referenced user", func(t *testing.T) {
+		err := repo.UserDelete(ctx, bob.ID)
+		if !errors.Is(err, failure.ErrConflict) {
+			t.Fatalf("want=%v, got=%v.", failure.ErrConflict, err)
+		}
+
+		want := []failure.Violation{{Field: "user_id", Rule: failure.RuleUnreferenced}}
+		if got := failure.Violations(err); !reflect.DeepEqual(want, got) {
+			t.Fatalf("want=%v, got=%v.", want, got)
+		}
+	})
+
+	t.Run("permission revoke", func(t *testing.T) {
+		admin, err := repo.EmployeeReadByUserID(ctx, company.ID, bob.ID)
+		if err != nil {
+			t.Fatalf("want=nil, got=%v.", err)
+		}
+
+		err = repo.PermissionRevoke(ctx, company.ID, admin.ID, permissions.ManageRoles)
+		if err != nil {
+			t.Fatalf("want=nil, got=%v.", err)
+		}
+
+		got, err := repo.PermissionList(ctx, company.ID, admin.ID)
+		if err != nil {
+			t.Fatalf("want=nil, got=%v.", err)
+		}
+
+		want := []permissions.Permission{permissions.ManageEmployees, permissions.ManageDepartments, permissions.ManageCompany}
+		if !reflect.DeepEqual(want, got) {
+			t.Fatalf("want=%v, got=%v.", want, got)
+		}
+	})
+
+	t.Run("department move", func(t *testing.T) {
+		ids := map[organizations.Name]organizations.ID{}
+		for _, d := range []struct {
+			parent organizations.Name
+			name   organizations.Name
+		}{
+			{name: "開発部"},
+			{parent: "開発部", name: "開発一課"},
+			{parent: "開発一課", name: "開発一係"},
+			{name: "営業部"},
+		} {
+			created, err := repo.DepartmentCreate(ctx, organizations.New(company.ID, ids[d.parent], d.name))
+			if err != nil {
+				t.Fatalf("want=nil, got=%v.", err)
+			}
+			ids[d.name] = created.ID
+		}
+
+		_, err := repo.DepartmentMove(ctx, &organizations.Department{ID: ids["開発一課"], CompanyID: company.ID, ParentID: ids["営業部"]})
+		if err != nil {
+			t.Fatalf("want=nil, got=%v.", err)
+		}
+
+		ancestors, err := repo.DepartmentAncestors(ctx, company.ID, ids["開発一係"])
+		if err != nil {
+			t.Fatalf("want=nil, got=%v.", err)
+		}
+
+		want := []organizations.ID{ids["営業部"], ids["開発一課"]}
+		got := []organizations.ID{}
+		for _, d := range ancestors {
+			got = append(got, d.ID)
+		}
+		if !reflect.DeepEqual(want, got) {
+			t.Fatalf("want=%v, got=%v.", want, got)
+		}
+	})
+
+	t.Run("rollback", func(t *testing.T) {
+		testErr := errors.New("test error")
+		err := repo.WithTx(ctx, func(tx Repository) error {
+			_, err := tx.UserCreate(ctx, users.New("alice", password.FromHash([]byte("password"))))
+			if err != nil {
+				return err
+			}
+			return testErr
+		})
+		if !errors.Is(err, testErr) {
+			t.Fatalf("want=%v, got=%v.", testErr, err)
+		}
+
+		_, err = repo.UserReadByName(ctx, "alice")
+		if !errors.Is(err, failure.ErrNotFound) {
+			t.Fatalf("want=%v, got=%v.", failure.ErrNotFound, err)
+		}
+	})
+
+	t.Run("company delete", func(t *testing.T) {
+		err := repo.CompanyDelete(ctx, company.ID)
+		if err != nil {
+			t.Fatalf("want=nil, got=%v.", err)
+		}
+
+		// 会社の削除により従業員も削除され、ユーザーを削除できる
+		err = repo.UserDelete(ctx, bob.ID)
+		if err != nil {
+			t.Fatalf("want=nil, got=%v.", err)
+		}
+	})
+}
@@ -0,0 +1,123 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	employees "api.example.com/pkg/employee"
+	"api.example.com/repository/model"
+)
+
+// company, user は実在すること
+func employeeCreate(
+	ctx context.Context,
+	tx Transaction,
+	company model.Company,
+	user model.User,
+	employee model.Employee,
+) (*employees.Employee, error) {
+	err := company.Read(ctx, tx)
+	if err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("repository.EmployeeCreate: company: %w", err)
+	}
+
+	err = user.Read(ctx, tx)
+	if err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("repository.EmployeeCreate: user: %w", err)
+	}
+
+	err = employee.Create(ctx, tx)
+	if err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("repository.EmployeeCreate: %w", err)
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, fmt.Errorf("repository.EmployeeCreate: %w", err)
+	}
+
+	return employee.NewEntity(), nil
+}
+
+func employeeRead(ctx context.Context, db model.DB, employee model.Employee) (*employees.Employee, error) {
+	err := employee.Read(ctx, db)
+	if err != nil {
+		return nil, fmt.Errorf("repository.EmployeeRead: %w", err)
+	}
+
+	return employee.NewEntity(), nil
+}
+
+func employeeReadByUserID(ctx context.Context, db model.DB, employee model.Employee) (*employees.Employee, error) {
+	err := employee.ReadByUserID(ctx, db)
+	if err != nil {
+		return nil, fmt.Errorf("repository.EmployeeReadByUserID: %w", err)
+	}
+
+	return employee.NewEntity(), nil
+}
+
+func employeeList(ctx context.Context, db model.DB, list model.Employees) ([]*employees.Employee, error) {
+	err := list.Read(ctx, db)
+	if err != nil {
+		return nil, fmt.Errorf("repository.EmployeeList: %w", err)
+	}
+
+	return list.NewEntity(), nil
+}
+
+// 会社の所有者と最後の管理者は削除しない
+func employeeDelete(
+	ctx context.Context,
+	tx Transaction,
+	company model.Company,
+	administrators model.Employees,
+	employee model.Employee,
+) error {
+	err := employee.Read(ctx, tx)
+	if err != nil {
+		tx.Rollback()
+		return fmt.Errorf("repository.EmployeeDelete: %w", err)
+	}
+
+	err = company.Read(ctx, tx)
+	if err != nil {
+		tx.Rollback()
+		return fmt.Errorf("repository.EmployeeDelete: company: %w", err)
+	}
+
+	e := employee.NewEntity()
+	if company.NewEntity().OwnerID == e.UserID {
+		tx.Rollback()
+		return fmt.Errorf("repository.EmployeeDelete: %w", employees.ErrOwner)
+	}
+
+	if e.Administrator {
+		err = administrators.Read(ctx, tx)
+		if err != nil {
+			tx.Rollback()
+			return fmt.Errorf("repository.EmployeeDelete: administrators: %w", err)
+		}
+
+		if len(administrators.NewEntity()) <= 1 {
+			tx.Rollback()
+			return fmt.Errorf("repository.EmployeeDelete: %w", employees.ErrLastAdministrator)
+		}
+	}
+
+	err = employee.Delete(ctx, tx)
+	if err != nil {
+		tx.Rollback()
+		return fmt.Errorf("repository.EmployeeDelete: %w", err)
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return fmt.Errorf("repository.EmployeeDelete: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,567 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	companies "api.example.com/pkg/company"
+	employees "api.example.com/pkg/employee"
+	"api.example.com/pkg/failure"
+	"api.example.com/repository/model"
+)
+
+type makeModelEmployee func(*testing.T) model.Employee
+
+// mock
+type modelEmployee struct {
+	entity *employees.Employee
+	err    error
+	// Delete のみが返すエラー
+	errDelete error
+	// flags
+	create, read, readByUserID, delete, newEntity bool
+	// test
+	t *testing.T
+}
+
+func (e *modelEmployee) Create(ctx context.Context, tx model.DB) error {
+	e.t.Helper()
+	if e.create {
+		return e.err
+	}
+
+	e.t.Fatal("invalid Create")
+	panic("invalid Create")
+}
+
+func (e *modelEmployee) Read(ctx context.Context, tx model.DB) error {
+	e.t.Helper()
+	if e.read {
+		return e.err
+	}
+
+	e.t.Fatal("invalid Read")
+	panic("invalid Read")
+}
+
+func (e *modelEmployee) ReadByUserID(ctx context.Context, tx model.DB) error {
+	e.t.Helper()
+	if e.readByUserID {
+		return e.err
+	}
+
+	e.t.Fatal("invalid ReadByUserID")
+	panic("invalid ReadByUserID")
+}
+
+func (e *modelEmployee) Delete(ctx context.Context, tx model.DB) error {
+	e.t.Helper()
+	if e.delete {
+		if e.errDelete != nil {
+			return e.errDelete
+		}
+		return e.err
+	}
+
+	e.t.Fatal("invalid Delete")
+	panic("invalid Delete")
+}
+
+func (e *modelEmployee) NewEntity() *employees.Employee {
+	e.t.Helper()
+	if e.newEntity {
+		return e.entity
+	}
+
+	e.t.Fatal("invalid NewEntity")
+	panic("invalid NewEntity")
+}
+
+// mock
+type modelEmployees struct {
+	entity []*employees.Employee
+	err    error
+	// flags
+	read, newEntity bool
+	// test
+	t *testing.T
+}
+
+func (l *modelEmployees) Read(ctx context.Context, tx model.DB) error {
+	l.t.Helper()
+	if l.read {
+		return l.err
+	}
+
+	l.t.Fatal("invalid Read")
+	panic("invalid Read")
+}
+
+func (l *modelEmployees) NewEntity() []*employees.Employee {
+	l.t.Helper()
+	if l.newEntity {
+		return l.entity
+	}
+
+	l.t.Fatal("invalid NewEntity")
+	panic("invalid NewEntity")
+}
+
+func TestEmployeeCreate(t *testing.T) {
+	type test struct {
+		name         string
+		tx           Transaction
+		makeCompany  makeModelCompany
+		user         model.User
+		makeEmployee makeModelEmployee
+		want         *employees.Employee
+		wantErr      bool
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := employeeCreate(context.Background(), tt.tx, tt.makeCompany(t), tt.user, tt.makeEmployee(t))
+			if tt.wantErr != (err != nil) {
+				t.Fatalf("want-error=%v, error=%v.", tt.wantErr, err)
+			}
+
+			if !reflect.DeepEqual(tt.want, got) {
+				t.Fatalf("want=%v, got=%v.", tt.want, got)
+			}
+		})
+	}
+
+	tests := []*test{
+		{
+			name: "ok",
+			tx: &transaction{
+				commit: true,
+			},
+			makeCompany: func(t *testing.T) model.Company {
+				return &modelCompany{read: true, t: t}
+			},
+			user: &user{read: true},
+			makeEmployee: func(t *testing.T) model.Employee {
+				return &modelEmployee{
+					entity: &employees.Employee{
+						ID:        3,
+						CompanyID: 1,
+						UserID:    2,
+						UpdatedAt: time.Date(2022, 9, 3, 12, 34, 56, 0, time.UTC),
+					},
+					create:    true,
+					newEntity: true,
+					t:         t,
+				}
+			},
+			want: &employees.Employee{
+				ID:        3,
+				CompanyID: 1,
+				UserID:    2,
+				UpdatedAt: time.Date(2022, 9, 3, 12, 34, 56, 0, time.UTC),
+			},
+			wantErr: false,
+		},
+		{
+			name: "company not found",
+			tx: &transaction{
+				rollback: true,
+			},
+			makeCompany: func(t *testing.T) model.Company {
+				return &modelCompany{
+					err:  errors.New("test error"),
+					read: true,
+					t:    t,
+				}
+			},
+			user: &user{read: true},
+			makeEmployee: func(t *testing.T) model.Employee {
+				return &modelEmployee{t: t}
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "user not found",
+			tx: &transaction{
+				rollback: true,
+			},
+			makeCompany: func(t *testing.T) model.Company {
+				return &modelCompany{read: true, t: t}
+			},
+			user: &user{
+				err:  errors.New("test error"),
+				read: true,
+			},
+			makeEmployee: func(t *testing.T) model.Employee {
+				return &modelEmployee{t: t}
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "failed create",
+			tx: &transaction{
+				rollback: true,
+			},
+			makeCompany: func(t *testing.T) model.Company {
+				return &modelCompany{read: true, t: t}
+			},
+			user: &user{read: true},
+			makeEmployee: func(t *testing.T) model.Employee {
+				return &modelEmployee{
+					err:    errors.New("test error"),
+					create: true,
+					t:      t,
+				}
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "failed commit",
+			tx: &transaction{
+				errCommit: errors.New("test error"),
+				commit:    true,
+			},
+			makeCompany: func(t *testing.T) model.Company {
+				return &modelCompany{read: true, t: t}
+			},
+			user: &user{read: true},
+			makeEmployee: func(t *testing.T) model.Employee {
+				return &modelEmployee{
+					create: true,
+					t:      t,
+				}
+			},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+}
+
+func TestEmployeeRead(t *testing.T) {
+	type test struct {
+		name         string
+		db           DB
+		makeEmployee makeModelEmployee
+		want         *employees.Employee
+		wantErr      bool
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := employeeRead(context.Background(), tt.db, tt.makeEmployee(t))
+			if tt.wantErr != (err != nil) {
+				t.Fatalf("want-error=%v, error=%v.", tt.wantErr, err)
+			}
+
+			if !reflect.DeepEqual(tt.want, got) {
+				t.Fatalf("want=%v, got=%v.", tt.want, got)
+			}
+		})
+	}
+
+	tests := []*test{
+		{
+			name: "ok",
+			db:   &mockDB{},
+			makeEmployee: func(t *testing.T) model.Employee {
+				return &modelEmployee{
+					entity: &employees.Employee{
+						ID:            1,
+						CompanyID:     1,
+						UserID:        1,
+						Administrator: true,
+						UpdatedAt:     time.Date(2022, 9, 3, 12, 34, 56, 0, time.UTC),
+					},
+					read:      true,
+					newEntity: true,
+					t:         t,
+				}
+			},
+			want: &employees.Employee{
+				ID:            1,
+				CompanyID:     1,
+				UserID:        1,
+				Administrator: true,
+				UpdatedAt:     time.Date(2022, 9, 3, 12, 34, 56, 0, time.UTC),
+			},
+			wantErr: false,
+		},
+		{
+			name: "failed read",
+			db:   &mockDB{},
+			makeEmployee: func(t *testing.T) model.Employee {
+				return &modelEmployee{
+					err:  errors.New("test error"),
+					read: true,
+					t:    t,
+				}
+			},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+}
+
+func TestEmployeeReadByUserID(t *testing.T) {
+	type test struct {
+		name         string
+		db           DB
+		makeEmployee makeModelEmployee
+		want         *employees.Employee
+		wantErr      bool
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := employeeReadByUserID(context.Background(), tt.db, tt.makeEmployee(t))
+			if tt.wantErr != (err != nil) {
+				t.Fatalf("want-error=%v, error=%v.", tt.wantErr, err)
+			}
+
+			if !reflect.DeepEqual(tt.want, got) {
+				t.Fatalf("want=%v, got=%v.", tt.want, got)
+			}
+		})
+	}
+
+	tests := []*test{
+		{
+			name: "ok",
+			db:   &mockDB{},
+			makeEmployee: func(t *testing.T) model.Employee {
+				return &modelEmployee{
+					entity: &employees.Employee{
+						ID:            1,
+						CompanyID:     1,
+						UserID:        1,
+						Administrator: true,
+						UpdatedAt:     time.Date(2022, 9, 3, 12, 34, 56, 0, time.UTC),
+					},
+					readByUserID: true,
+					newEntity:    true,
+					t:            t,
+				}
+			},
+			want: &employees.Employee{
+				ID:            1,
+				CompanyID:     1,
+				UserID:        1,
+				Administrator: true,
+				UpdatedAt:     time.Date(2022, 9, 3, 12, 34, 56, 0, time.UTC),
+			},
+			wantErr: false,
+		},
+		{
+			name: "failed read",
+			db:   &mockDB{},
+			makeEmployee: func(t *testing.T) model.Employee {
+				return &modelEmployee{
+					err:          errors.New("test error"),
+					readByUserID: true,
+					t:            t,
+				}
+			},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+}
+
+func TestEmployeeList(t *testing.T) {
+	type test struct {
+		name          string
+		db            DB
+		makeEmployees func(*testing.T) model.Employees
+		want          []*employees.Employee
+		wantErr       bool
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := employeeList(context.Background(), tt.db, tt.makeEmployees(t))
+			if tt.wantErr != (err != nil) {
+				t.Fatalf("want-error=%v, error=%v.", tt.wantErr, err)
+			}
+
+			if !reflect.DeepEqual(tt.want, got) {
+				t.Fatalf("want=%v, got=%v.", tt.want, got)
+			}
+		})
+	}
+
+	tests := []*test{
+		{
+			name: "ok",
+			db:   &mockDB{},
+			makeEmployees: func(t *testing.T) model.Employees {
+				return &modelEmployees{
+					entity: []*employees.Employee{
+						{ID: 1, CompanyID: 1, UserID: 1, Administrator: true},
+						{ID: 2, CompanyID: 1, UserID: 2},
+					},
+					read:      true,
+					newEntity: true,
+					t:         t,
+				}
+			},
+			want: []*employees.Employee{
+				{ID: 1, CompanyID: 1, UserID: 1, Administrator: true},
+				{ID: 2, CompanyID: 1, UserID: 2},
+			},
+			wantErr: false,
+		},
+		{
+			name: "failed read",
+			db:   &mockDB{},
+			makeEmployees: func(t *testing.T) model.Employees {
+				return &modelEmployees{
+					err:  errors.New("test error"),
+					read: true,
+					t:    t,
+				}
+			},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+}
+
+func TestEmployeeDelete(t *testing.T) {
+	type test struct {
+		name               string
+		tx                 Transaction
+		company            *companies.Company
+		makeAdministrators func(*testing.T) model.Employees
+		makeEmployee       makeModelEmployee
+		wantErr            error
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.name, func(t *testing.T) {
+			company := &modelCompany{entity: tt.company, read: true, newEntity: true, t: t}
+			err := employeeDelete(context.Background(), tt.tx, company, tt.makeAdministrators(t), tt.makeEmployee(t))
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("want=%v, got=%v.", tt.wantErr, err)
+			}
+		})
+	}
+
+	company := &companies.Company{ID: 1, Name: "GREATE COMPANY", OwnerID: 1}
+	member := &employees.Employee{ID: 2, CompanyID: 1, UserID: 2, Administrator: false}
+	admin := &employees.Employee{ID: 3, CompanyID: 1, UserID: 3, Administrator: true}
+	owner := &employees.Employee{ID: 1, CompanyID: 1, UserID: 1, Administrator: true}
+	testErr := errors.New("test error")
+
+	tests := []*test{
+		{
+			name:    "ok",
+			tx:      &transaction{commit: true},
+			company: company,
+			makeAdministrators: func(t *testing.T) model.Employees {
+				return &modelEmployees{t: t}
+			},
+			makeEmployee: func(t *testing.T) model.Employee {
+				return &modelEmployee{entity: member, read: true, newEntity: true, delete: true, t: t}
+			},
+			wantErr: nil,
+		},
+		{
+			name:    "ok administrator",
+			tx:      &transaction{commit: true},
+			company: company,
+			makeAdministrators: func(t *testing.T) model.Employees {
+				return &modelEmployees{entity: []*employees.Employee{owner, admin}, read: true, newEntity: true, t: t}
+			},
+			makeEmployee: func(t *testing.T) model.Employee {
+				return &modelEmployee{entity: admin, read: true, newEntity: true, delete: true, t: t}
+			},
+			wantErr: nil,
+		},
+		{
+			name:    "owner",
+			tx:      &transaction{rollback: true},
+			company: company,
+			makeAdministrators: func(t *testing.T) model.Employees {
+				return &modelEmployees{t: t}
+			},
+			makeEmployee: func(t *testing.T) model.Employee {
+				return &modelEmployee{entity: owner, read: true, newEntity: true, t: t}
+			},
+			wantErr: employees.ErrOwner,
+		},
+		{
+			name:    "last administrator",
+			tx:      &transaction{rollback: true},
+			company: &companies.Company{ID: 1, Name: "GREATE COMPANY", OwnerID: 2},
+			makeAdministrators: func(t *testing.T) model.Employees {
+				return &modelEmployees{entity: []*employees.Employee{admin}, read: true, newEntity: true, t: t}
+			},
+			makeEmployee: func(t *testing.T) model.Employee {
+				return &modelEmployee{entity: admin, read: true, newEntity: true, t: t}
+			},
+			wantErr: employees.ErrLastAdministrator,
+		},
+		{
+			name:    "not found",
+			tx:      &transaction{rollback: true},
+			company: company,
+			makeAdministrators: func(t *testing.T) model.Employees {
+				return &modelEmployees{t: t}
+			},
+			makeEmployee: func(t *testing.T) model.Employee {
+				return &modelEmployee{err: failure.ErrNotFound, read: true, t: t}
+			},
+			wantErr: failure.ErrNotFound,
+		},
+		{
+			name:    "failed delete",
+			tx:      &transaction{rollback: true},
+			company: company,
+			makeAdministrators: func(t *testing.T) model.Employees {
+				return &modelEmployees{t: t}
+			},
+			makeEmployee: func(t *testing.T) model.Employee {
+				return &modelEmployee{entity: member, errDelete: testErr, read: true, newEntity: true, delete: true, t: t}
+			},
+			wantErr: testErr,
+		},
+		{
+			name:    "failed commit",
+			tx:      &transaction{errCommit: testErr, commit: true},
+			company: company,
+			makeAdministrators: func(t *testing.T) model.Employees {
+				return &modelEmployees{t: t}
+			},
+			makeEmployee: func(t *testing.T) model.Employee {
+				return &modelEmployee{entity: member, read: true, newEntity: true, delete: true, t: t}
+			},
+			wantErr: testErr,
+		},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+}
@@ -0,0 +1,154 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	apikeys "api.example.com/pkg/apikey"
+	"api.example.com/pkg/failure"
+	"api.example.com/pkg/user/password"
+)
+
+const tableAPIKeys = "api_keys"
+
+// 呼び出し側がスコープを書き換えても保存した値に影響しないよう複製する
+func copyAPIKey(k apikeys.Key) *apikeys.Key {
+	k.Scopes = append([]apikeys.Scope{}, k.Scopes...)
+	return &k
+}
+
+// 他の会社の API キーは存在しないものとする
+func (d *data) apiKey(companyID apikeys.CompanyID, id apikeys.ID) (*apikeys.Key, error) {
+	k, ok := d.apiKeys[id]
+	if !ok || k.CompanyID != companyID {
+		return nil, fmt.Errorf("%w: api_key_id=%d", failure.ErrNotFound, id)
+	}
+	return copyAPIKey(k), nil
+}
+
+// repository/model と同じく秘密の値はハッシュのみを保存する
+func secretHash(k *apikeys.Key) []byte {
+	if k.Secret == nil {
+		return nil
+	}
+	return k.Secret.Hash()
+}
+
+// company は実在すること
+func (m *memory) APIKeyCreate(ctx context.Context, k *apikeys.Key) (*apikeys.Key, error) {
+	var created apikeys.Key
+	err := m.write(ctx, func(d *data) error {
+		_, err := d.company(k.CompanyID)
+		if err != nil {
+			return fmt.Errorf("company: %w", err)
+		}
+
+		created = apikeys.Key{
+			ID:        apikeys.ID(d.nextID(tableAPIKeys)),
+			CompanyID: k.CompanyID,
+			Name:      k.Name,
+			Scopes:    append([]apikeys.Scope{}, k.Scopes...),
+			Secret:    password.FromHash(secretHash(k)),
+			UpdatedAt: currentTime(),
+		}
+		d.apiKeys[created.ID] = created
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("repository/memory.APIKeyCreate: %w", err)
+	}
+
+	return copyAPIKey(created), nil
+}
+
+func (m *memory) APIKeyRead(ctx context.Context, companyID apikeys.CompanyID, id apikeys.ID) (*apikeys.Key, error) {
+	var k *apikeys.Key
+	err := m.read(ctx, func(d *data) (err error) {
+		k, err = d.apiKey(companyID, id)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("repository/memory.APIKeyRead: %w", err)
+	}
+
+	return k, nil
+}
+
+// 会社を問わない
+func (m *memory) APIKeyReadByID(ctx context.Context, id apikeys.ID) (*apikeys.Key, error) {
+	var k apikeys.Key
+	err := m.read(ctx, func(d *data) error {
+		var ok bool
+		k, ok = d.apiKeys[id]
+		if !ok {
+			return fmt.Errorf("%w: api_key_id=%d", failure.ErrNotFound, id)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("repository/memory.APIKeyReadByID: %w", err)
+	}
+
+	return copyAPIKey(k), nil
+}
+
+// ID の順に並ぶ
+func (m *memory) APIKeyList(ctx context.Context, companyID apikeys.CompanyID) ([]*apikeys.Key, error) {
+	list := []*apikeys.Key{}
+	err := m.read(ctx, func(d *data) error {
+		for _, k := range d.apiKeys {
+			if k.CompanyID == companyID {
+				list = append(list, copyAPIKey(k))
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("repository/memory.APIKeyList: %w", err)
+	}
+
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].ID < list[j].ID
+	})
+	return list, nil
+}
+
+// 秘密の値のみ更新する
+func (m *memory) APIKeyUpdate(ctx context.Context, k *apikeys.Key) (*apikeys.Key, error) {
+	var updated apikeys.Key
+	err := m.write(ctx, func(d *data) error {
+		current, err := d.apiKey(k.CompanyID, k.ID)
+		if err != nil {
+			return err
+		}
+
+		updated = *current
+		updated.Secret = password.FromHash(secretHash(k))
+		updated.UpdatedAt = currentTime()
+		d.apiKeys[updated.ID] = updated
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("repository/memory.APIKeyUpdate: %w", err)
+	}
+
+	return copyAPIKey(updated), nil
+}
+
+func (m *memory) APIKeyDelete(ctx context.Context, companyID apikeys.CompanyID, id apikeys.ID) error {
+	err := m.write(ctx, func(d *data) error {
+		_, err := d.apiKey(companyID, id)
+		if err != nil {
+			return err
+		}
+
+		delete(d.apiKeys, id)
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("repository/memory.APIKeyDelete: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,269 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	apikeys "api.example.com/pkg/apikey"
+	"api.example.com/pkg/failure"
+	"api.example.com/pkg/user/password"
+)
+
+// 会社 1 の API キー
+func newFixtureAPIKey(t *testing.T, m *memory) *apikeys.Key {
+	t.Helper()
+
+	k, err := m.APIKeyCreate(context.Background(), &apikeys.Key{
+		CompanyID: 1,
+		Name:      "batch",
+		Scopes:    []apikeys.Scope{apikeys.ScopeRead},
+		Secret:    password.FromHash([]byte("secret")),
+	})
+	if err != nil {
+		t.Fatalf("fixture: %v", err)
+	}
+	return k
+}
+
+func TestMemory_APIKeyCreate(t *testing.T) {
+	type test struct {
+		name    string
+		key     *apikeys.Key
+		want    *apikeys.Key
+		wantErr error
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := newFixture(t).APIKeyCreate(context.Background(), tt.key)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("want=%v, got=%v.", tt.wantErr, err)
+			}
+
+			if !reflect.DeepEqual(tt.want, got) {
+				t.Fatalf("want=%v, got=%v.", tt.want, got)
+			}
+		})
+	}
+
+	tests := []*test{
+		{
+			name: "true",
+			key: &apikeys.Key{
+				CompanyID: 1,
+				Name:      "batch",
+				Scopes:    []apikeys.Scope{apikeys.ScopeRead},
+				Secret:    password.FromHash([]byte("secret")),
+				Token:     "token",
+			},
+			// 資格情報は保存しない
+			want: &apikeys.Key{
+				ID:        1,
+				CompanyID: 1,
+				Name:      "batch",
+				Scopes:    []apikeys.Scope{apikeys.ScopeRead},
+				Secret:    password.FromHash([]byte("secret")),
+				UpdatedAt: testTime,
+			},
+			wantErr: nil,
+		},
+		{
+			name:    "company not found",
+			key:     &apikeys.Key{CompanyID: 99, Name: "batch", Secret: password.FromHash([]byte("secret"))},
+			want:    nil,
+			wantErr: failure.ErrNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+}
+
+func TestMemory_APIKeyRead(t *testing.T) {
+	type test struct {
+		name string
+		// 読み込む操作
+		read    func(m *memory) (*apikeys.Key, error)
+		wantErr error
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newFixture(t)
+			want := newFixtureAPIKey(t, m)
+
+			got, err := tt.read(m)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("want=%v, got=%v.", tt.wantErr, err)
+			}
+
+			if tt.wantErr != nil {
+				return
+			}
+
+			if !reflect.DeepEqual(want, got) {
+				t.Fatalf("want=%v, got=%v.", want, got)
+			}
+
+			// 読み込んだスコープを書き換えても保存した値は変わらない
+			got.Scopes[0] = apikeys.ScopeManageUsers
+			got, err = tt.read(m)
+			if err != nil || !reflect.DeepEqual(want, got) {
+				t.Fatalf("want=%v, got=%v, error=%v.", want, got, err)
+			}
+		})
+	}
+
+	tests := []*test{
+		{
+			name: "true",
+			read: func(m *memory) (*apikeys.Key, error) {
+				return m.APIKeyRead(context.Background(), 1, 1)
+			},
+			wantErr: nil,
+		},
+		{
+			name: "other company",
+			read: func(m *memory) (*apikeys.Key, error) {
+				return m.APIKeyRead(context.Background(), 2, 1)
+			},
+			wantErr: failure.ErrNotFound,
+		},
+		{
+			name: "by id",
+			read: func(m *memory) (*apikeys.Key, error) {
+				return m.APIKeyReadByID(context.Background(), 1)
+			},
+			wantErr: nil,
+		},
+		{
+			name: "by unknown id",
+			read: func(m *memory) (*apikeys.Key, error) {
+				return m.APIKeyReadByID(context.Background(), 99)
+			},
+			wantErr: failure.ErrNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+}
+
+func TestMemory_APIKeyList(t *testing.T) {
+	m := newFixture(t)
+	first := newFixtureAPIKey(t, m)
+	second := newFixtureAPIKey(t, m)
+
+	got, err := m.APIKeyList(context.Background(), 1)
+	if err != nil {
+		t.Fatalf("want=nil, got=%v.", err)
+	}
+
+	want := []*apikeys.Key{first, second}
+	if !reflect.DeepEqual(want, got) {
+		t.Fatalf("want=%v, got=%v.", want, got)
+	}
+}
+
+func TestMemory_APIKeyUpdate(t *testing.T) {
+	type test struct {
+		name    string
+		key     *apikeys.Key
+		want    *apikeys.Key
+		wantErr error
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newFixture(t)
+			newFixtureAPIKey(t, m)
+
+			got, err := m.APIKeyUpdate(context.Background(), tt.key)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("want=%v, got=%v.", tt.wantErr, err)
+			}
+
+			if !reflect.DeepEqual(tt.want, got) {
+				t.Fatalf("want=%v, got=%v.", tt.want, got)
+			}
+		})
+	}
+
+	tests := []*test{
+		{
+			// 秘密の値のみ更新する
+			name: "true",
+			key: &apikeys.Key{
+				ID:        1,
+				CompanyID: 1,
+				Name:      "other",
+				Secret:    password.FromHash([]byte("rotated")),
+			},
+			want: &apikeys.Key{
+				ID:        1,
+				CompanyID: 1,
+				Name:      "batch",
+				Scopes:    []apikeys.Scope{apikeys.ScopeRead},
+				Secret:    password.FromHash([]byte("rotated")),
+				UpdatedAt: testTime,
+			},
+			wantErr: nil,
+		},
+		{
+			name:    "other company",
+			key:     &apikeys.Key{ID: 1, CompanyID: 2, Secret: password.FromHash([]byte("rotated"))},
+			want:    nil,
+			wantErr: failure.ErrNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+}
+
+func TestMemory_APIKeyDelete(t *testing.T) {
+	type test struct {
+		name      string
+		companyID apikeys.CompanyID
+		wantErr   error
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newFixture(t)
+			newFixtureAPIKey(t, m)
+
+			err := m.APIKeyDelete(context.Background(), tt.companyID, 1)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("want=%v, got=%v.", tt.wantErr, err)
+			}
+
+			_, err = m.APIKeyRead(context.Background(), tt.companyID, 1)
+			if !errors.Is(err, failure.ErrNotFound) {
+				t.Fatalf("want=%v, got=%v.", failure.ErrNotFound, err)
+			}
+		})
+	}
+
+	tests := []*test{
+		{
+			name:      "true",
+			companyID: 1,
+			wantErr:   nil,
+		},
+		{
+			name:      "other company",
+			companyID: 2,
+			wantErr:   failure.ErrNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+}
@@ -0,0 +1,167 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	assignments "api.example.com/pkg/assignment"
+	"api.example.com/pkg/failure"
+)
+
+const tableAssignments = "employee_roles"
+
+// match に一致する会社の配置
+// ID の順に並ぶ
+func (d *data) assignmentList(companyID assignments.CompanyID, match func(assignments.Assignment) bool) []*assignments.Assignment {
+	list := []*assignments.Assignment{}
+	for _, a := range d.assignments {
+		if a.CompanyID == companyID && match(a) {
+			a := a
+			list = append(list, &a)
+		}
+	}
+
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].ID < list[j].ID
+	})
+	return list
+}
+
+// employee, department, role は配置と同じ会社に実在すること
+// 従業員に同じ部署と肩書きの組が既に配置されていれば assignments.ErrDuplicate
+func (m *memory) AssignmentCreate(ctx context.Context, a *assignments.Assignment) (*assignments.Assignment, error) {
+	var created assignments.Assignment
+	err := m.write(ctx, func(d *data) error {
+		_, err := d.employee(a.CompanyID, a.EmployeeID)
+		if err != nil {
+			return fmt.Errorf("employee: %w", err)
+		}
+
+		_, err = d.department(a.CompanyID, a.DepartmentID)
+		if err != nil {
+			return fmt.Errorf("department: %w", err)
+		}
+
+		_, err = d.role(a.CompanyID, a.RoleID)
+		if err != nil {
+			return fmt.Errorf("role: %w", err)
+		}
+
+		for _, same := range d.assignments {
+			if same.EmployeeID == a.EmployeeID && same.DepartmentID == a.DepartmentID && same.RoleID == a.RoleID {
+				return assignments.ErrDuplicate
+			}
+		}
+
+		created = assignments.Assignment{
+			ID:           assignments.ID(d.nextID(tableAssignments)),
+			CompanyID:    a.CompanyID,
+			EmployeeID:   a.EmployeeID,
+			DepartmentID: a.DepartmentID,
+			RoleID:       a.RoleID,
+			UpdatedAt:    currentTime(),
+		}
+		d.assignments[created.ID] = created
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("repository/memory.AssignmentCreate: %w", err)
+	}
+
+	return &created, nil
+}
+
+func (m *memory) AssignmentRead(ctx context.Context, companyID assignments.CompanyID, id assignments.ID) (*assignments.Assignment, error) {
+	var a assignments.Assignment
+	err := m.read(ctx, func(d *data) error {
+		var ok bool
+		a, ok = d.assignments[id]
+		if !ok || a.CompanyID != companyID {
+			return fmt.Errorf("%w: assignment_id=%d", failure.ErrNotFound, id)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("repository/memory.AssignmentRead: %w", err)
+	}
+
+	return &a, nil
+}
+
+// 従業員の配置(兼任を含む)
+func (m *memory) AssignmentListByEmployee(ctx context.Context, companyID assignments.CompanyID, employeeID assignments.EmployeeID) ([]*assignments.Assignment, error) {
+	var list []*assignments.Assignment
+	err := m.read(ctx, func(d *data) error {
+		list = d.assignmentList(companyID, func(a assignments.Assignment) bool {
+			return a.EmployeeID == employeeID
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("repository/memory.AssignmentListByEmployee: %w", err)
+	}
+
+	return list, nil
+}
+
+// 部署に配置された従業員
+func (m *memory) AssignmentListByDepartment(ctx context.Context, companyID assignments.CompanyID, departmentID assignments.DepartmentID) ([]*assignments.Assignment, error) {
+	var list []*assignments.Assignment
+	err := m.read(ctx, func(d *data) error {
+		list = d.assignmentList(companyID, func(a assignments.Assignment) bool {
+			return a.DepartmentID == departmentID
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("repository/memory.AssignmentListByDepartment: %w", err)
+	}
+
+	return list, nil
+}
+
+// 部署と配下の全ての部署に配置された従業員
+func (m *memory) AssignmentListBySubtree(ctx context.Context, companyID assignments.CompanyID, departmentID assignments.DepartmentID) ([]*assignments.Assignment, error) {
+	var list []*assignments.Assignment
+	err := m.read(ctx, func(d *data) error {
+		// 部署が実在しなければ空の一覧
+		dept, err := d.department(companyID, departmentID)
+		if err != nil {
+			list = []*assignments.Assignment{}
+			return nil
+		}
+
+		subtree := map[assignments.DepartmentID]bool{}
+		for _, sub := range d.departmentSubtree(dept) {
+			subtree[sub.ID] = true
+		}
+
+		list = d.assignmentList(companyID, func(a assignments.Assignment) bool {
+			return subtree[a.DepartmentID]
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("repository/memory.AssignmentListBySubtree: %w", err)
+	}
+
+	return list, nil
+}
+
+func (m *memory) AssignmentDelete(ctx context.Context, companyID assignments.CompanyID, id assignments.ID) error {
+	err := m.write(ctx, func(d *data) error {
+		a, ok := d.assignments[id]
+		if !ok || a.CompanyID != companyID {
+			return fmt.Errorf("%w: assignment_id=%d", failure.ErrNotFound, id)
+		}
+
+		delete(d.assignments, id)
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("repository/memory.AssignmentDelete: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,223 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	assignments "api.example.com/pkg/assignment"
+	"api.example.com/pkg/failure"
+)
+
+func TestMemory_AssignmentCreate(t *testing.T) {
+	type test struct {
+		name       string
+		assignment *assignments.Assignment
+		want       *assignments.Assignment
+		wantErr    error
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := newFixture(t).AssignmentCreate(context.Background(), tt.assignment)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("want=%v, got=%v.", tt.wantErr, err)
+			}
+
+			if !reflect.DeepEqual(tt.want, got) {
+				t.Fatalf("want=%v, got=%v.", tt.want, got)
+			}
+		})
+	}
+
+	tests := []*test{
+		{
+			// 兼任
+			name:       "true",
+			assignment: &assignments.Assignment{CompanyID: 1, EmployeeID: 2, DepartmentID: 4, RoleID: 1},
+			want:       &assignments.Assignment{ID: 2, CompanyID: 1, EmployeeID: 2, DepartmentID: 4, RoleID: 1, UpdatedAt: testTime},
+			wantErr:    nil,
+		},
+		{
+			name:       "duplicate",
+			assignment: &assignments.Assignment{CompanyID: 1, EmployeeID: 2, DepartmentID: 2, RoleID: 2},
+			want:       nil,
+			wantErr:    assignments.ErrDuplicate,
+		},
+		{
+			name:       "employee not found",
+			assignment: &assignments.Assignment{CompanyID: 1, EmployeeID: 99, DepartmentID: 2, RoleID: 2},
+			want:       nil,
+			wantErr:    failure.ErrNotFound,
+		},
+		{
+			name:       "department not found",
+			assignment: &assignments.Assignment{CompanyID: 1, EmployeeID: 1, DepartmentID: 99, RoleID: 2},
+			want:       nil,
+			wantErr:    failure.ErrNotFound,
+		},
+		{
+			name:       "role not found",
+			assignment: &assignments.Assignment{CompanyID: 1, EmployeeID: 1, DepartmentID: 2, RoleID: 99},
+			want:       nil,
+			wantErr:    failure.ErrNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+}
+
+func TestMemory_AssignmentRead(t *testing.T) {
+	type test struct {
+		name      string
+		companyID assignments.CompanyID
+		id        assignments.ID
+		want      *assignments.Assignment
+		wantErr   error
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := newFixture(t).AssignmentRead(context.Background(), tt.companyID, tt.id)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("want=%v, got=%v.", tt.wantErr, err)
+			}
+
+			if !reflect.DeepEqual(tt.want, got) {
+				t.Fatalf("want=%v, got=%v.", tt.want, got)
+			}
+		})
+	}
+
+	tests := []*test{
+		{
+			name:      "true",
+			companyID: 1,
+			id:        1,
+			want:      &assignments.Assignment{ID: 1, CompanyID: 1, EmployeeID: 2, DepartmentID: 2, RoleID: 2, UpdatedAt: testTime},
+			wantErr:   nil,
+		},
+		{
+			name:      "other company",
+			companyID: 2,
+			id:        1,
+			want:      nil,
+			wantErr:   failure.ErrNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+}
+
+func TestMemory_AssignmentList(t *testing.T) {
+	type test struct {
+		name string
+		// 一覧を読み込む操作
+		list func(m *memory) ([]*assignments.Assignment, error)
+		want []*assignments.Assignment
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.list(newFixture(t))
+			if err != nil {
+				t.Fatalf("want=nil, got=%v.", err)
+			}
+
+			if !reflect.DeepEqual(tt.want, got) {
+				t.Fatalf("want=%v, got=%v.", tt.want, got)
+			}
+		})
+	}
+
+	alice := &assignments.Assignment{ID: 1, CompanyID: 1, EmployeeID: 2, DepartmentID: 2, RoleID: 2, UpdatedAt: testTime}
+	tests := []*test{
+		{
+			name: "by employee",
+			list: func(m *memory) ([]*assignments.Assignment, error) {
+				return m.AssignmentListByEmployee(context.Background(), 1, 2)
+			},
+			want: []*assignments.Assignment{alice},
+		},
+		{
+			name: "by department",
+			list: func(m *memory) ([]*assignments.Assignment, error) {
+				return m.AssignmentListByDepartment(context.Background(), 1, 2)
+			},
+			want: []*assignments.Assignment{alice},
+		},
+		{
+			name: "by subtree",
+			list: func(m *memory) ([]*assignments.Assignment, error) {
+				return m.AssignmentListBySubtree(context.Background(), 1, 1)
+			},
+			want: []*assignments.Assignment{alice},
+		},
+		{
+			name: "by subtree below",
+			list: func(m *memory) ([]*assignments.Assignment, error) {
+				return m.AssignmentListBySubtree(context.Background(), 1, 3)
+			},
+			want: []*assignments.Assignment{},
+		},
+		{
+			name: "other company",
+			list: func(m *memory) ([]*assignments.Assignment, error) {
+				return m.AssignmentListByEmployee(context.Background(), 2, 2)
+			},
+			want: []*assignments.Assignment{},
+		},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+}
+
+func TestMemory_AssignmentDelete(t *testing.T) {
+	type test struct {
+		name      string
+		companyID assignments.CompanyID
+		id        assignments.ID
+		wantErr   error
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newFixture(t)
+			err := m.AssignmentDelete(context.Background(), tt.companyID, tt.id)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("want=%v, got=%v.", tt.wantErr, err)
+			}
+
+			_, err = m.AssignmentRead(context.Background(), tt.companyID, tt.id)
+			if !errors.Is(err, failure.ErrNotFound) {
+				t.Fatalf("want=%v, got=%v.", failure.ErrNotFound, err)
+			}
+		})
+	}
+
+	tests := []*test{
+		{
+			name:      "true",
+			companyID: 1,
+			id:        1,
+			wantErr:   nil,
+		},
+		{
+			name:      "other company",
+			companyID: 2,
+			id:        1,
+			wantErr:   failure.ErrNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+}
@@ -0,0 +1,183 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	companies "api.example.com/pkg/company"
+	"api.example.com/pkg/failure"
+	permissions "api.example.com/pkg/permission"
+)
+
+const tableCompanies = "companies"
+
+func (d *data) company(id companies.ID) (*companies.Company, error) {
+	c, ok := d.companies[id]
+	if !ok {
+		return nil, fmt.Errorf("%w: company_id=%d", failure.ErrNotFound, id)
+	}
+	return &c, nil
+}
+
+// owner は実在するユーザーであり、id 以外に同じ名前の会社がないこと
+func (d *data) checkCompany(c *companies.Company, id companies.ID) error {
+	_, err := d.user(c.OwnerID)
+	if err != nil {
+		return fmt.Errorf("owner: %w", err)
+	}
+
+	for _, other := range d.companies {
+		if sameName(string(other.Name), string(c.Name)) && other.ID != id {
+			return failure.Conflict("company.name already exists", "company.name", failure.RuleUnique)
+		}
+	}
+
+	return nil
+}
+
+// 会社に属する全てのデータも削除する
+func (d *data) deleteCompany(id companies.ID) {
+	for _, e := range d.employees {
+		if e.CompanyID == id {
+			d.deleteEmployee(e.ID)
+		}
+	}
+
+	for _, dept := range d.departments {
+		if dept.CompanyID == id {
+			delete(d.departments, dept.ID)
+		}
+	}
+
+	for _, r := range d.roles {
+		if r.CompanyID == id {
+			delete(d.roles, r.ID)
+		}
+	}
+
+	for _, k := range d.apiKeys {
+		if k.CompanyID == id {
+			delete(d.apiKeys, k.ID)
+		}
+	}
+
+	delete(d.companies, id)
+}
+
+// 会社を作成したユーザー(owner)は全ての権限を持つ管理者として会社の従業員になる
+func (m *memory) CompanyCreate(ctx context.Context, c *companies.Company) (*companies.Company, error) {
+	var created companies.Company
+	err := m.write(ctx, func(d *data) error {
+		err := d.checkCompany(c, 0)
+		if err != nil {
+			return err
+		}
+
+		created = companies.Company{
+			ID:        companies.ID(d.nextID(tableCompanies)),
+			Name:      c.Name,
+			OwnerID:   c.OwnerID,
+			UpdatedAt: currentTime(),
+		}
+		d.companies[created.ID] = created
+
+		admin := d.createEmployee(created.ID, created.OwnerID, true)
+		d.permissions[admin.ID] = append([]permissions.Permission{}, permissions.All...)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("repository/memory.CompanyCreate: %w", err)
+	}
+
+	return &created, nil
+}
+
+func (m *memory) CompanyRead(ctx context.Context, id companies.ID) (*companies.Company, error) {
+	var c *companies.Company
+	err := m.read(ctx, func(d *data) (err error) {
+		c, err = d.company(id)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("repository/memory.CompanyRead: %w", err)
+	}
+
+	return c, nil
+}
+
+// ユーザーが従業員である会社のうち、名前が prefix で始まり、 ID が after より大きい会社を ID の順に limit 件まで
+func (m *memory) CompanyList(ctx context.Context, userID companies.OwnerID, prefix companies.Name, after companies.ID, limit int) ([]*companies.Company, error) {
+	list := []*companies.Company{}
+	err := m.read(ctx, func(d *data) error {
+		for _, c := range d.companies {
+			if c.ID > after && hasPrefix(string(c.Name), string(prefix)) && d.employed(c.ID, userID) {
+				c := c
+				list = append(list, &c)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("repository/memory.CompanyList: %w", err)
+	}
+
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].ID < list[j].ID
+	})
+	if len(list) > limit {
+		list = list[:limit]
+	}
+	return list, nil
+}
+
+func (m *memory) CompanyUpdate(ctx context.Context, c *companies.Company) (*companies.Company, error) {
+	var updated companies.Company
+	err := m.write(ctx, func(d *data) error {
+		current, err := d.company(c.ID)
+		if err != nil {
+			return err
+		}
+
+		// 新しい所有者は会社の管理者であること
+		if current.OwnerID != c.OwnerID && !d.administrator(c.ID, c.OwnerID) {
+			return fmt.Errorf("owner: %w", failure.Conflict("company.owner_id is not an administrator", "company.owner_id", failure.RuleAdministrator))
+		}
+
+		err = d.checkCompany(c, c.ID)
+		if err != nil {
+			return err
+		}
+
+		updated = companies.Company{
+			ID:        c.ID,
+			Name:      c.Name,
+			OwnerID:   c.OwnerID,
+			UpdatedAt: currentTime(),
+		}
+		d.companies[updated.ID] = updated
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("repository/memory.CompanyUpdate: %w", err)
+	}
+
+	return &updated, nil
+}
+
+func (m *memory) CompanyDelete(ctx context.Context, id companies.ID) error {
+	err := m.write(ctx, func(d *data) error {
+		_, err := d.company(id)
+		if err != nil {
+			return err
+		}
+
+		d.deleteCompany(id)
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("repository/memory.CompanyDelete: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,306 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	companies "api.example.com/pkg/company"
+	employees "api.example.com/pkg/employee"
+	"api.example.com/pkg/failure"
+	permissions "api.example.com/pkg/permission"
+)
+
+func TestMemory_CompanyCreate(t *testing.T) {
+	type test struct {
+		name    string
+		company *companies.Company
+		want    *companies.Company
+		wantErr error
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newFixture(t)
+			ctx := context.Background()
+			got, err := m.CompanyCreate(ctx, tt.company)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("want=%v, got=%v.", tt.wantErr, err)
+			}
+
+			if !reflect.DeepEqual(tt.want, got) {
+				t.Fatalf("want=%v, got=%v.", tt.want, got)
+			}
+
+			if tt.want == nil {
+				return
+			}
+
+			// 所有者は全ての権限を持つ管理者になる
+			admin, err := m.EmployeeReadByUserID(ctx, got.ID, got.OwnerID)
+			if err != nil || !admin.Administrator {
+				t.Fatalf("want-administrator=true, got=%v, error=%v.", admin, err)
+			}
+
+			granted, err := m.PermissionList(ctx, got.ID, admin.ID)
+			if err != nil || !reflect.DeepEqual(permissions.All, granted) {
+				t.Fatalf("want=%v, got=%v, error=%v.", permissions.All, granted, err)
+			}
+		})
+	}
+
+	tests := []*test{
+		{
+			name:    "true",
+			company: &companies.Company{Name: "NEW COMPANY", OwnerID: 3},
+			want:    &companies.Company{ID: 2, Name: "NEW COMPANY", OwnerID: 3, UpdatedAt: testTime},
+			wantErr: nil,
+		},
+		{
+			name:    "duplicate name",
+			company: &companies.Company{Name: "GREATE COMPANY", OwnerID: 3},
+			want:    nil,
+			wantErr: failure.ErrConflict,
+		},
+		{
+			name:    "duplicate name ignoring case",
+			company: &companies.Company{Name: "greate company", OwnerID: 3},
+			want:    nil,
+			wantErr: failure.ErrConflict,
+		},
+		{
+			name:    "owner not found",
+			company: &companies.Company{Name: "NEW COMPANY", OwnerID: 99},
+			want:    nil,
+			wantErr: failure.ErrNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+}
+
+func TestMemory_CompanyRead(t *testing.T) {
+	type test struct {
+		name    string
+		id      companies.ID
+		want    *companies.Company
+		wantErr error
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := newFixture(t).CompanyRead(context.Background(), tt.id)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("want=%v, got=%v.", tt.wantErr, err)
+			}
+
+			if !reflect.DeepEqual(tt.want, got) {
+				t.Fatalf("want=%v, got=%v.", tt.want, got)
+			}
+		})
+	}
+
+	tests := []*test{
+		{
+			name:    "true",
+			id:      1,
+			want:    &companies.Company{ID: 1, Name: "GREATE COMPANY", OwnerID: 1, UpdatedAt: testTime},
+			wantErr: nil,
+		},
+		{
+			name:    "not found",
+			id:      99,
+			want:    nil,
+			wantErr: failure.ErrNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+}
+
+func TestMemory_CompanyList(t *testing.T) {
+	type test struct {
+		name   string
+		userID companies.OwnerID
+		prefix companies.Name
+		after  companies.ID
+		want   []*companies.Company
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := newFixture(t).CompanyList(context.Background(), tt.userID, tt.prefix, tt.after, 10)
+			if err != nil {
+				t.Fatalf("want=nil, got=%v.", err)
+			}
+
+			if !reflect.DeepEqual(tt.want, got) {
+				t.Fatalf("want=%v, got=%v.", tt.want, got)
+			}
+		})
+	}
+
+	tests := []*test{
+		{
+			name:   "all",
+			userID: 2,
+			want:   []*companies.Company{{ID: 1, Name: "GREATE COMPANY", OwnerID: 1, UpdatedAt: testTime}},
+		},
+		{
+			name:   "prefix",
+			userID: 2,
+			prefix: "GREATE",
+			want:   []*companies.Company{{ID: 1, Name: "GREATE COMPANY", OwnerID: 1, UpdatedAt: testTime}},
+		},
+		{
+			name:   "after",
+			userID: 2,
+			after:  1,
+			want:   []*companies.Company{},
+		},
+		{
+			name:   "not found",
+			userID: 2,
+			prefix: "OTHER",
+			want:   []*companies.Company{},
+		},
+		{
+			name:   "not employee",
+			userID: 3,
+			want:   []*companies.Company{},
+		},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+}
+
+func TestMemory_CompanyUpdate(t *testing.T) {
+	type test struct {
+		name    string
+		company *companies.Company
+		want    *companies.Company
+		wantErr error
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newFixture(t)
+			_, err := m.CompanyCreate(context.Background(), &companies.Company{Name: "OTHER COMPANY", OwnerID: 3})
+			if err != nil {
+				t.Fatalf("fixture: %v", err)
+			}
+
+			got, err := m.CompanyUpdate(context.Background(), tt.company)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("want=%v, got=%v.", tt.wantErr, err)
+			}
+
+			if !reflect.DeepEqual(tt.want, got) {
+				t.Fatalf("want=%v, got=%v.", tt.want, got)
+			}
+		})
+	}
+
+	tests := []*test{
+		{
+			name:    "true",
+			company: &companies.Company{ID: 1, Name: "greate company", OwnerID: 1},
+			want:    &companies.Company{ID: 1, Name: "greate company", OwnerID: 1, UpdatedAt: testTime},
+			wantErr: nil,
+		},
+		{
+			name:    "duplicate name",
+			company: &companies.Company{ID: 1, Name: "OTHER COMPANY", OwnerID: 1},
+			want:    nil,
+			wantErr: failure.ErrConflict,
+		},
+		{
+			name:    "owner not administrator",
+			company: &companies.Company{ID: 1, Name: "GREATE COMPANY", OwnerID: 2},
+			want:    nil,
+			wantErr: failure.ErrConflict,
+		},
+		{
+			name:    "owner not employee",
+			company: &companies.Company{ID: 1, Name: "GREATE COMPANY", OwnerID: 99},
+			want:    nil,
+			wantErr: failure.ErrConflict,
+		},
+		{
+			name:    "not found",
+			company: &companies.Company{ID: 99, Name: "NEW COMPANY", OwnerID: 1},
+			want:    nil,
+			wantErr: failure.ErrNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+}
+
+func TestMemory_CompanyDelete(t *testing.T) {
+	type test struct {
+		name    string
+		id      companies.ID
+		wantErr error
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newFixture(t)
+			ctx := context.Background()
+			err := m.CompanyDelete(ctx, tt.id)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("want=%v, got=%v.", tt.wantErr, err)
+			}
+
+			if tt.wantErr != nil {
+				return
+			}
+
+			// 会社に属するデータも削除される
+			list, err := m.EmployeeList(ctx, tt.id)
+			if err != nil || len(list) != 0 {
+				t.Fatalf("want=[], got=%v, error=%v.", list, err)
+			}
+
+			if len(m.store.data.departments) != 0 || len(m.store.data.roles) != 0 ||
+				len(m.store.data.assignments) != 0 || len(m.store.data.permissions) != 0 {
+				t.Fatalf("want=empty, got=%+v.", m.store.data)
+			}
+
+			// 所有者と従業員だったユーザーは削除できる
+			for _, id := range []employees.UserID{1, 2} {
+				err = m.UserDelete(ctx, id)
+				if err != nil {
+					t.Fatalf("want=nil, got=%v.", err)
+				}
+			}
+		})
+	}
+
+	tests := []*test{
+		{
+			name:    "true",
+			id:      1,
+			wantErr: nil,
+		},
+		{
+			name:    "not found",
+			id:      99,
+			wantErr: failure.ErrNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+}
@@ -0,0 +1,256 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"api.example.com/pkg/failure"
+	organizations "api.example.com/pkg/organization"
+)
+
+const tableDepartments = "departments"
+
+// 部署の階層は親の ID のみで管理し、祖先と子孫は都度辿る
+
+// 他の会社の部署は存在しないものとする
+func (d *data) department(companyID organizations.CompanyID, id organizations.ID) (*organizations.Department, error) {
+	dept, ok := d.departments[id]
+	if !ok || dept.CompanyID != companyID {
+		return nil, fmt.Errorf("%w: department_id=%d", failure.ErrNotFound, id)
+	}
+	return &dept, nil
+}
+
+// parentID が Root の場合は最上位の部署
+// ID の順に並ぶ
+func (d *data) departmentChildren(companyID organizations.CompanyID, parentID organizations.ID) []*organizations.Department {
+	list := []*organizations.Department{}
+	for _, dept := range d.departments {
+		if dept.CompanyID == companyID && dept.ParentID == parentID {
+			dept := dept
+			list = append(list, &dept)
+		}
+	}
+
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].ID < list[j].ID
+	})
+	return list
+}
+
+// 自身と配下の全ての部署
+// 浅い部署から順に、同じ深さでは ID の順に並ぶ
+func (d *data) departmentSubtree(dept *organizations.Department) []*organizations.Department {
+	list := []*organizations.Department{dept}
+	level := list
+	for len(level) > 0 {
+		next := []*organizations.Department{}
+		for _, parent := range level {
+			next = append(next, d.departmentChildren(parent.CompanyID, parent.ID)...)
+		}
+
+		sort.Slice(next, func(i, j int) bool {
+			return next[i].ID < next[j].ID
+		})
+		list = append(list, next...)
+		level = next
+	}
+	return list
+}
+
+// company は実在すること
+// 親が Root でなければ、同じ会社に実在すること
+func (m *memory) DepartmentCreate(ctx context.Context, dept *organizations.Department) (*organizations.Department, error) {
+	var created organizations.Department
+	err := m.write(ctx, func(d *data) error {
+		_, err := d.company(dept.CompanyID)
+		if err != nil {
+			return fmt.Errorf("company: %w", err)
+		}
+
+		if dept.ParentID != organizations.Root {
+			_, err = d.department(dept.CompanyID, dept.ParentID)
+			if err != nil {
+				return fmt.Errorf("parent: %w", err)
+			}
+		}
+
+		created = organizations.Department{
+			ID:        organizations.ID(d.nextID(tableDepartments)),
+			CompanyID: dept.CompanyID,
+			ParentID:  dept.ParentID,
+			Name:      dept.Name,
+			UpdatedAt: currentTime(),
+		}
+		d.departments[created.ID] = created
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("repository/memory.DepartmentCreate: %w", err)
+	}
+
+	return &created, nil
+}
+
+func (m *memory) DepartmentRead(ctx context.Context, companyID organizations.CompanyID, id organizations.ID) (*organizations.Department, error) {
+	var dept *organizations.Department
+	err := m.read(ctx, func(d *data) (err error) {
+		dept, err = d.department(companyID, id)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("repository/memory.DepartmentRead: %w", err)
+	}
+
+	return dept, nil
+}
+
+// 親は変更しない
+func (m *memory) DepartmentUpdate(ctx context.Context, dept *organizations.Department) (*organizations.Department, error) {
+	var updated organizations.Department
+	err := m.write(ctx, func(d *data) error {
+		current, err := d.department(dept.CompanyID, dept.ID)
+		if err != nil {
+			return err
+		}
+
+		updated = *current
+		updated.Name = dept.Name
+		updated.UpdatedAt = currentTime()
+		d.departments[updated.ID] = updated
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("repository/memory.DepartmentUpdate: %w", err)
+	}
+
+	return &updated, nil
+}
+
+// 配下の部署ごと ParentID の子へ移動する
+// 移動先が自身または配下の部署であれば organizations.ErrCycle
+func (m *memory) DepartmentMove(ctx context.Context, dept *organizations.Department) (*organizations.Department, error) {
+	var moved organizations.Department
+	err := m.write(ctx, func(d *data) error {
+		if dept.ParentID != organizations.Root {
+			_, err := d.department(dept.CompanyID, dept.ParentID)
+			if err != nil {
+				return fmt.Errorf("parent: %w", err)
+			}
+		}
+
+		// 移動先から最上位まで辿り、自身を通れば循環する
+		for id := dept.ParentID; id != organizations.Root; id = d.departments[id].ParentID {
+			if id == dept.ID {
+				return organizations.ErrCycle
+			}
+		}
+
+		current, err := d.department(dept.CompanyID, dept.ID)
+		if err != nil {
+			return err
+		}
+
+		moved = *current
+		moved.ParentID = dept.ParentID
+		moved.UpdatedAt = currentTime()
+		d.departments[moved.ID] = moved
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("repository/memory.DepartmentMove: %w", err)
+	}
+
+	return &moved, nil
+}
+
+// 配下の部署と、それらへの配置も削除する
+func (m *memory) DepartmentDelete(ctx context.Context, companyID organizations.CompanyID, id organizations.ID) error {
+	err := m.write(ctx, func(d *data) error {
+		dept, err := d.department(companyID, id)
+		if err != nil {
+			return err
+		}
+
+		if len(d.departmentChildren(companyID, dept.ID)) > 0 {
+			return organizations.ErrHasChildren
+		}
+
+		for _, a := range d.assignments {
+			if a.DepartmentID == dept.ID {
+				delete(d.assignments, a.ID)
+			}
+		}
+		delete(d.departments, dept.ID)
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("repository/memory.DepartmentDelete: %w", err)
+	}
+
+	return nil
+}
+
+// 直下の子部署, Root の場合は最上位の部署
+func (m *memory) DepartmentChildren(ctx context.Context, companyID organizations.CompanyID, id organizations.ID) ([]*organizations.Department, error) {
+	var list []*organizations.Department
+	err := m.read(ctx, func(d *data) error {
+		if id != organizations.Root {
+			_, err := d.department(companyID, id)
+			if err != nil {
+				return fmt.Errorf("parent: %w", err)
+			}
+		}
+
+		list = d.departmentChildren(companyID, id)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("repository/memory.DepartmentChildren: %w", err)
+	}
+
+	return list, nil
+}
+
+// 最上位から親までの部署, 自身は含まない
+func (m *memory) DepartmentAncestors(ctx context.Context, companyID organizations.CompanyID, id organizations.ID) ([]*organizations.Department, error) {
+	list := []*organizations.Department{}
+	err := m.read(ctx, func(d *data) error {
+		dept, err := d.department(companyID, id)
+		if err != nil {
+			return err
+		}
+
+		for parentID := dept.ParentID; parentID != organizations.Root; {
+			parent := d.departments[parentID]
+			list = append([]*organizations.Department{&parent}, list...)
+			parentID = parent.ParentID
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("repository/memory.DepartmentAncestors: %w", err)
+	}
+
+	return list, nil
+}
+
+// 自身と配下の全ての部署
+func (m *memory) DepartmentSubtree(ctx context.Context, companyID organizations.CompanyID, id organizations.ID) ([]*organizations.Department, error) {
+	var list []*organizations.Department
+	err := m.read(ctx, func(d *data) error {
+		dept, err := d.department(companyID, id)
+		if err != nil {
+			return err
+		}
+
+		list = d.departmentSubtree(dept)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("repository/memory.DepartmentSubtree: %w", err)
+	}
+
+	return list, nil
+}
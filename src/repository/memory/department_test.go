@@ -0,0 +1,409 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"api.example.com/pkg/failure"
+	organizations "api.example.com/pkg/organization"
+)
+
+// 部署の ID の一覧
+func departmentIDs(list []*organizations.Department) []organizations.ID {
+	ids := []organizations.ID{}
+	for _, d := range list {
+		ids = append(ids, d.ID)
+	}
+	return ids
+}
+
+func TestMemory_DepartmentCreate(t *testing.T) {
+	type test struct {
+		name       string
+		department *organizations.Department
+		want       *organizations.Department
+		wantErr    error
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := newFixture(t).DepartmentCreate(context.Background(), tt.department)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("want=%v, got=%v.", tt.wantErr, err)
+			}
+
+			if !reflect.DeepEqual(tt.want, got) {
+				t.Fatalf("want=%v, got=%v.", tt.want, got)
+			}
+		})
+	}
+
+	tests := []*test{
+		{
+			name:       "root",
+			department: organizations.New(1, organizations.Root, "総務部"),
+			want:       &organizations.Department{ID: 5, CompanyID: 1, ParentID: organizations.Root, Name: "総務部", UpdatedAt: testTime},
+			wantErr:    nil,
+		},
+		{
+			name:       "child",
+			department: organizations.New(1, 4, "営業一課"),
+			want:       &organizations.Department{ID: 5, CompanyID: 1, ParentID: 4, Name: "営業一課", UpdatedAt: testTime},
+			wantErr:    nil,
+		},
+		{
+			name:       "company not found",
+			department: organizations.New(99, organizations.Root, "総務部"),
+			want:       nil,
+			wantErr:    failure.ErrNotFound,
+		},
+		{
+			name:       "parent not found",
+			department: organizations.New(1, 99, "総務部"),
+			want:       nil,
+			wantErr:    failure.ErrNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+}
+
+func TestMemory_DepartmentRead(t *testing.T) {
+	type test struct {
+		name      string
+		companyID organizations.CompanyID
+		id        organizations.ID
+		want      *organizations.Department
+		wantErr   error
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := newFixture(t).DepartmentRead(context.Background(), tt.companyID, tt.id)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("want=%v, got=%v.", tt.wantErr, err)
+			}
+
+			if !reflect.DeepEqual(tt.want, got) {
+				t.Fatalf("want=%v, got=%v.", tt.want, got)
+			}
+		})
+	}
+
+	tests := []*test{
+		{
+			name:      "true",
+			companyID: 1,
+			id:        2,
+			want:      &organizations.Department{ID: 2, CompanyID: 1, ParentID: 1, Name: "開発一課", UpdatedAt: testTime},
+			wantErr:   nil,
+		},
+		{
+			name:      "other company",
+			companyID: 2,
+			id:        2,
+			want:      nil,
+			wantErr:   failure.ErrNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+}
+
+func TestMemory_DepartmentUpdate(t *testing.T) {
+	type test struct {
+		name       string
+		department *organizations.Department
+		want       *organizations.Department
+		wantErr    error
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := newFixture(t).DepartmentUpdate(context.Background(), tt.department)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("want=%v, got=%v.", tt.wantErr, err)
+			}
+
+			if !reflect.DeepEqual(tt.want, got) {
+				t.Fatalf("want=%v, got=%v.", tt.want, got)
+			}
+		})
+	}
+
+	tests := []*test{
+		{
+			// 親は変更しない
+			name:       "true",
+			department: &organizations.Department{ID: 2, CompanyID: 1, ParentID: organizations.Root, Name: "開発二課"},
+			want:       &organizations.Department{ID: 2, CompanyID: 1, ParentID: 1, Name: "開発二課", UpdatedAt: testTime},
+			wantErr:    nil,
+		},
+		{
+			name:       "not found",
+			department: &organizations.Department{ID: 99, CompanyID: 1, Name: "開発二課"},
+			want:       nil,
+			wantErr:    failure.ErrNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+}
+
+func TestMemory_DepartmentMove(t *testing.T) {
+	type test struct {
+		name       string
+		department *organizations.Department
+		want       *organizations.Department
+		wantErr    error
+		// 移動後の祖先
+		wantAncestors []organizations.ID
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newFixture(t)
+			ctx := context.Background()
+			got, err := m.DepartmentMove(ctx, tt.department)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("want=%v, got=%v.", tt.wantErr, err)
+			}
+
+			if !reflect.DeepEqual(tt.want, got) {
+				t.Fatalf("want=%v, got=%v.", tt.want, got)
+			}
+
+			if tt.want == nil {
+				return
+			}
+
+			// 配下の部署も共に移動する
+			ancestors, err := m.DepartmentAncestors(ctx, 1, 3)
+			if err != nil {
+				t.Fatalf("want=nil, got=%v.", err)
+			}
+
+			if got := departmentIDs(ancestors); !reflect.DeepEqual(tt.wantAncestors, got) {
+				t.Fatalf("want=%v, got=%v.", tt.wantAncestors, got)
+			}
+		})
+	}
+
+	tests := []*test{
+		{
+			name:          "true",
+			department:    &organizations.Department{ID: 2, CompanyID: 1, ParentID: 4},
+			want:          &organizations.Department{ID: 2, CompanyID: 1, ParentID: 4, Name: "開発一課", UpdatedAt: testTime},
+			wantErr:       nil,
+			wantAncestors: []organizations.ID{4, 2},
+		},
+		{
+			name:          "to root",
+			department:    &organizations.Department{ID: 3, CompanyID: 1, ParentID: organizations.Root},
+			want:          &organizations.Department{ID: 3, CompanyID: 1, ParentID: organizations.Root, Name: "開発一係", UpdatedAt: testTime},
+			wantErr:       nil,
+			wantAncestors: []organizations.ID{},
+		},
+		{
+			name:       "to descendant",
+			department: &organizations.Department{ID: 1, CompanyID: 1, ParentID: 3},
+			want:       nil,
+			wantErr:    organizations.ErrCycle,
+		},
+		{
+			name:       "to self",
+			department: &organizations.Department{ID: 2, CompanyID: 1, ParentID: 2},
+			want:       nil,
+			wantErr:    organizations.ErrCycle,
+		},
+		{
+			name:       "parent not found",
+			department: &organizations.Department{ID: 2, CompanyID: 1, ParentID: 99},
+			want:       nil,
+			wantErr:    failure.ErrNotFound,
+		},
+		{
+			name:       "not found",
+			department: &organizations.Department{ID: 99, CompanyID: 1, ParentID: 4},
+			want:       nil,
+			wantErr:    failure.ErrNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+}
+
+func TestMemory_DepartmentDelete(t *testing.T) {
+	type test struct {
+		name    string
+		id      organizations.ID
+		wantErr error
+		// 残る部署
+		want []organizations.ID
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newFixture(t)
+			ctx := context.Background()
+			err := m.DepartmentDelete(ctx, 1, tt.id)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("want=%v, got=%v.", tt.wantErr, err)
+			}
+
+			got := []organizations.ID{}
+			for _, id := range []organizations.ID{1, 2, 3, 4} {
+				_, err := m.DepartmentRead(ctx, 1, id)
+				if err == nil {
+					got = append(got, id)
+				}
+			}
+
+			if !reflect.DeepEqual(tt.want, got) {
+				t.Fatalf("want=%v, got=%v.", tt.want, got)
+			}
+		})
+	}
+
+	tests := []*test{
+		{
+			name:    "true",
+			id:      3,
+			wantErr: nil,
+			want:    []organizations.ID{1, 2, 4},
+		},
+		{
+			name:    "has children",
+			id:      1,
+			wantErr: organizations.ErrHasChildren,
+			want:    []organizations.ID{1, 2, 3, 4},
+		},
+		{
+			name:    "not found",
+			id:      99,
+			wantErr: failure.ErrNotFound,
+			want:    []organizations.ID{1, 2, 3, 4},
+		},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+
+	t.Run("assignments", func(t *testing.T) {
+		m := newFixture(t)
+		ctx := context.Background()
+		// 配置も削除される
+		for _, id := range []organizations.ID{3, 2} {
+			err := m.DepartmentDelete(ctx, 1, id)
+			if err != nil {
+				t.Fatalf("want=nil, got=%v.", err)
+			}
+		}
+
+		list, err := m.AssignmentListByDepartment(ctx, 1, 2)
+		if err != nil || len(list) != 0 {
+			t.Fatalf("want=[], got=%v, error=%v.", list, err)
+		}
+	})
+}
+
+func TestMemory_DepartmentList(t *testing.T) {
+	type test struct {
+		name string
+		// 一覧を読み込む操作
+		list    func(m *memory) ([]*organizations.Department, error)
+		want    []organizations.ID
+		wantErr error
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.name, func(t *testing.T) {
+			list, err := tt.list(newFixture(t))
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("want=%v, got=%v.", tt.wantErr, err)
+			}
+
+			if tt.wantErr != nil {
+				return
+			}
+
+			if got := departmentIDs(list); !reflect.DeepEqual(tt.want, got) {
+				t.Fatalf("want=%v, got=%v.", tt.want, got)
+			}
+		})
+	}
+
+	tests := []*test{
+		{
+			name: "root children",
+			list: func(m *memory) ([]*organizations.Department, error) {
+				return m.DepartmentChildren(context.Background(), 1, organizations.Root)
+			},
+			want: []organizations.ID{1, 4},
+		},
+		{
+			name: "children",
+			list: func(m *memory) ([]*organizations.Department, error) {
+				return m.DepartmentChildren(context.Background(), 1, 1)
+			},
+			want: []organizations.ID{2},
+		},
+		{
+			name: "children of unknown parent",
+			list: func(m *memory) ([]*organizations.Department, error) {
+				return m.DepartmentChildren(context.Background(), 1, 99)
+			},
+			wantErr: failure.ErrNotFound,
+		},
+		{
+			name: "ancestors",
+			list: func(m *memory) ([]*organizations.Department, error) {
+				return m.DepartmentAncestors(context.Background(), 1, 3)
+			},
+			want: []organizations.ID{1, 2},
+		},
+		{
+			name: "ancestors of top",
+			list: func(m *memory) ([]*organizations.Department, error) {
+				return m.DepartmentAncestors(context.Background(), 1, 4)
+			},
+			want: []organizations.ID{},
+		},
+		{
+			name: "ancestors of unknown",
+			list: func(m *memory) ([]*organizations.Department, error) {
+				return m.DepartmentAncestors(context.Background(), 1, 99)
+			},
+			wantErr: failure.ErrNotFound,
+		},
+		{
+			name: "subtree",
+			list: func(m *memory) ([]*organizations.Department, error) {
+				return m.DepartmentSubtree(context.Background(), 1, 1)
+			},
+			want: []organizations.ID{1, 2, 3},
+		},
+		{
+			name: "subtree of other company",
+			list: func(m *memory) ([]*organizations.Department, error) {
+				return m.DepartmentSubtree(context.Background(), 2, 1)
+			},
+			wantErr: failure.ErrNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+}
@@ -0,0 +1,190 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	employees "api.example.com/pkg/employee"
+	"api.example.com/pkg/failure"
+)
+
+const tableEmployees = "company_employees"
+
+// 他の会社の従業員は存在しないものとする
+func (d *data) employee(companyID employees.CompanyID, id employees.ID) (*employees.Employee, error) {
+	e, ok := d.employees[id]
+	if !ok || e.CompanyID != companyID {
+		return nil, fmt.Errorf("%w: employee_id=%d", failure.ErrNotFound, id)
+	}
+	return &e, nil
+}
+
+// 会社の管理者の従業員の数
+func (d *data) administrators(companyID employees.CompanyID) int {
+	count := 0
+	for _, e := range d.employees {
+		if e.CompanyID == companyID && e.Administrator {
+			count++
+		}
+	}
+	return count
+}
+
+// ユーザーが会社の管理者の従業員であるか
+func (d *data) administrator(companyID employees.CompanyID, userID employees.UserID) bool {
+	for _, e := range d.employees {
+		if e.CompanyID == companyID && e.UserID == userID {
+			return e.Administrator
+		}
+	}
+	return false
+}
+
+// ユーザーが会社の従業員であるか
+func (d *data) employed(companyID employees.CompanyID, userID employees.UserID) bool {
+	for _, e := range d.employees {
+		if e.CompanyID == companyID && e.UserID == userID {
+			return true
+		}
+	}
+	return false
+}
+
+// 会社とユーザーは実在すること
+func (d *data) createEmployee(companyID employees.CompanyID, userID employees.UserID, administrator bool) employees.Employee {
+	e := employees.Employee{
+		ID:            employees.ID(d.nextID(tableEmployees)),
+		CompanyID:     companyID,
+		UserID:        userID,
+		Administrator: administrator,
+		UpdatedAt:     currentTime(),
+	}
+	d.employees[e.ID] = e
+	return e
+}
+
+// 従業員の権限と配置も削除する
+func (d *data) deleteEmployee(id employees.ID) {
+	for _, a := range d.assignments {
+		if a.EmployeeID == id {
+			delete(d.assignments, a.ID)
+		}
+	}
+
+	delete(d.permissions, id)
+	delete(d.employees, id)
+}
+
+// company, user は実在すること
+func (m *memory) EmployeeCreate(ctx context.Context, e *employees.Employee) (*employees.Employee, error) {
+	var created employees.Employee
+	err := m.write(ctx, func(d *data) error {
+		_, err := d.company(e.CompanyID)
+		if err != nil {
+			return fmt.Errorf("company: %w", err)
+		}
+
+		_, err = d.user(e.UserID)
+		if err != nil {
+			return fmt.Errorf("user: %w", err)
+		}
+
+		for _, other := range d.employees {
+			if other.CompanyID == e.CompanyID && other.UserID == e.UserID {
+				return failure.Conflict("employee.user_id already exists", "employee.user_id", failure.RuleUnique)
+			}
+		}
+
+		created = d.createEmployee(e.CompanyID, e.UserID, e.Administrator)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("repository/memory.EmployeeCreate: %w", err)
+	}
+
+	return &created, nil
+}
+
+func (m *memory) EmployeeRead(ctx context.Context, companyID employees.CompanyID, id employees.ID) (*employees.Employee, error) {
+	var e *employees.Employee
+	err := m.read(ctx, func(d *data) (err error) {
+		e, err = d.employee(companyID, id)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("repository/memory.EmployeeRead: %w", err)
+	}
+
+	return e, nil
+}
+
+func (m *memory) EmployeeReadByUserID(ctx context.Context, companyID employees.CompanyID, userID employees.UserID) (*employees.Employee, error) {
+	var found *employees.Employee
+	err := m.read(ctx, func(d *data) error {
+		for _, e := range d.employees {
+			if e.CompanyID == companyID && e.UserID == userID {
+				found = &e
+				return nil
+			}
+		}
+		return fmt.Errorf("%w: user_id=%d", failure.ErrNotFound, userID)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("repository/memory.EmployeeReadByUserID: %w", err)
+	}
+
+	return found, nil
+}
+
+// ID の順に並ぶ
+func (m *memory) EmployeeList(ctx context.Context, companyID employees.CompanyID) ([]*employees.Employee, error) {
+	list := []*employees.Employee{}
+	err := m.read(ctx, func(d *data) error {
+		for _, e := range d.employees {
+			if e.CompanyID == companyID {
+				e := e
+				list = append(list, &e)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("repository/memory.EmployeeList: %w", err)
+	}
+
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].ID < list[j].ID
+	})
+	return list, nil
+}
+
+func (m *memory) EmployeeDelete(ctx context.Context, companyID employees.CompanyID, id employees.ID) error {
+	err := m.write(ctx, func(d *data) error {
+		e, err := d.employee(companyID, id)
+		if err != nil {
+			return err
+		}
+
+		c, err := d.company(companyID)
+		if err != nil {
+			return fmt.Errorf("company: %w", err)
+		}
+
+		if c.OwnerID == e.UserID {
+			return employees.ErrOwner
+		}
+
+		if e.Administrator && d.administrators(companyID) <= 1 {
+			return employees.ErrLastAdministrator
+		}
+
+		d.deleteEmployee(id)
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("repository/memory.EmployeeDelete: %w", err)
+	}
+
+	return nil
+}
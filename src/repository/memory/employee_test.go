@@ -0,0 +1,282 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	employees "api.example.com/pkg/employee"
+	"api.example.com/pkg/failure"
+)
+
+func TestMemory_EmployeeCreate(t *testing.T) {
+	type test struct {
+		name     string
+		employee *employees.Employee
+		want     *employees.Employee
+		wantErr  error
+		// 違反した項目
+		wantViolations []failure.Violation
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := newFixture(t).EmployeeCreate(context.Background(), tt.employee)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("want=%v, got=%v.", tt.wantErr, err)
+			}
+
+			if got := failure.Violations(err); !reflect.DeepEqual(tt.wantViolations, got) {
+				t.Fatalf("want=%v, got=%v.", tt.wantViolations, got)
+			}
+
+			if !reflect.DeepEqual(tt.want, got) {
+				t.Fatalf("want=%v, got=%v.", tt.want, got)
+			}
+		})
+	}
+
+	tests := []*test{
+		{
+			name:     "true",
+			employee: &employees.Employee{CompanyID: 1, UserID: 3},
+			want:     &employees.Employee{ID: 3, CompanyID: 1, UserID: 3, UpdatedAt: testTime},
+			wantErr:  nil,
+		},
+		{
+			name:     "already employee",
+			employee: &employees.Employee{CompanyID: 1, UserID: 2},
+			want:     nil,
+			wantErr:  failure.ErrConflict,
+			wantViolations: []failure.Violation{
+				{Field: "employee.user_id", Rule: failure.RuleUnique},
+			},
+		},
+		{
+			name:     "company not found",
+			employee: &employees.Employee{CompanyID: 99, UserID: 3},
+			want:     nil,
+			wantErr:  failure.ErrNotFound,
+		},
+		{
+			name:     "user not found",
+			employee: &employees.Employee{CompanyID: 1, UserID: 99},
+			want:     nil,
+			wantErr:  failure.ErrNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+}
+
+func TestMemory_EmployeeRead(t *testing.T) {
+	type test struct {
+		name      string
+		companyID employees.CompanyID
+		id        employees.ID
+		want      *employees.Employee
+		wantErr   error
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := newFixture(t).EmployeeRead(context.Background(), tt.companyID, tt.id)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("want=%v, got=%v.", tt.wantErr, err)
+			}
+
+			if !reflect.DeepEqual(tt.want, got) {
+				t.Fatalf("want=%v, got=%v.", tt.want, got)
+			}
+		})
+	}
+
+	tests := []*test{
+		{
+			name:      "true",
+			companyID: 1,
+			id:        1,
+			want:      &employees.Employee{ID: 1, CompanyID: 1, UserID: 1, Administrator: true, UpdatedAt: testTime},
+			wantErr:   nil,
+		},
+		{
+			name:      "other company",
+			companyID: 2,
+			id:        1,
+			want:      nil,
+			wantErr:   failure.ErrNotFound,
+		},
+		{
+			name:      "not found",
+			companyID: 1,
+			id:        99,
+			want:      nil,
+			wantErr:   failure.ErrNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+}
+
+func TestMemory_EmployeeReadByUserID(t *testing.T) {
+	type test struct {
+		name      string
+		companyID employees.CompanyID
+		userID    employees.UserID
+		want      *employees.Employee
+		wantErr   error
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := newFixture(t).EmployeeReadByUserID(context.Background(), tt.companyID, tt.userID)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("want=%v, got=%v.", tt.wantErr, err)
+			}
+
+			if !reflect.DeepEqual(tt.want, got) {
+				t.Fatalf("want=%v, got=%v.", tt.want, got)
+			}
+		})
+	}
+
+	tests := []*test{
+		{
+			name:      "true",
+			companyID: 1,
+			userID:    2,
+			want:      &employees.Employee{ID: 2, CompanyID: 1, UserID: 2, UpdatedAt: testTime},
+			wantErr:   nil,
+		},
+		{
+			name:      "not employee",
+			companyID: 1,
+			userID:    3,
+			want:      nil,
+			wantErr:   failure.ErrNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+}
+
+func TestMemory_EmployeeList(t *testing.T) {
+	type test struct {
+		name      string
+		companyID employees.CompanyID
+		want      []*employees.Employee
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := newFixture(t).EmployeeList(context.Background(), tt.companyID)
+			if err != nil {
+				t.Fatalf("want=nil, got=%v.", err)
+			}
+
+			if !reflect.DeepEqual(tt.want, got) {
+				t.Fatalf("want=%v, got=%v.", tt.want, got)
+			}
+		})
+	}
+
+	tests := []*test{
+		{
+			name:      "true",
+			companyID: 1,
+			want: []*employees.Employee{
+				{ID: 1, CompanyID: 1, UserID: 1, Administrator: true, UpdatedAt: testTime},
+				{ID: 2, CompanyID: 1, UserID: 2, UpdatedAt: testTime},
+			},
+		},
+		{
+			name:      "empty",
+			companyID: 99,
+			want:      []*employees.Employee{},
+		},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+}
+
+func TestMemory_EmployeeDelete(t *testing.T) {
+	type test struct {
+		name      string
+		companyID employees.CompanyID
+		id        employees.ID
+		// 削除前に fixture を変更する
+		prepare func(m *memory)
+		wantErr error
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newFixture(t)
+			if tt.prepare != nil {
+				tt.prepare(m)
+			}
+
+			ctx := context.Background()
+			err := m.EmployeeDelete(ctx, tt.companyID, tt.id)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("want=%v, got=%v.", tt.wantErr, err)
+			}
+
+			if tt.wantErr != nil {
+				return
+			}
+
+			// 従業員の配置も削除される
+			list, err := m.AssignmentListByEmployee(ctx, tt.companyID, tt.id)
+			if err != nil || len(list) != 0 {
+				t.Fatalf("want=[], got=%v, error=%v.", list, err)
+			}
+		})
+	}
+
+	tests := []*test{
+		{
+			name:      "true",
+			companyID: 1,
+			id:        2,
+			wantErr:   nil,
+		},
+		{
+			name:      "other company",
+			companyID: 2,
+			id:        2,
+			wantErr:   failure.ErrNotFound,
+		},
+		{
+			name:      "owner",
+			companyID: 1,
+			id:        1,
+			wantErr:   employees.ErrOwner,
+		},
+		{
+			// 所有者が従業員でない会社の唯一の管理者
+			name:      "last administrator",
+			companyID: 1,
+			id:        1,
+			prepare: func(m *memory) {
+				c := m.store.data.companies[1]
+				c.OwnerID = 3
+				m.store.data.companies[1] = c
+			},
+			wantErr: employees.ErrLastAdministrator,
+		},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+}
@@ -0,0 +1,196 @@
+// データベースを使わない repository.Repository の実装
+// テストやローカルでの開発のため、全てのデータをメモリ上に保持する
+package memory
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	apikeys "api.example.com/pkg/apikey"
+	assignments "api.example.com/pkg/assignment"
+	companies "api.example.com/pkg/company"
+	employees "api.example.com/pkg/employee"
+	organizations "api.example.com/pkg/organization"
+	permissions "api.example.com/pkg/permission"
+	roles "api.example.com/pkg/role"
+	users "api.example.com/pkg/user"
+	"api.example.com/repository"
+)
+
+// model.currentTime と同じく秒に丸める
+// テストで固定できるよう変数とする
+var currentTime = func() time.Time {
+	return time.Now().Round(time.Second)
+}
+
+// 保存したセッション
+// repository/model と同じくセッションIDは保存せず、ダイジェストで検索する
+type session struct {
+	userID    users.ID
+	expiresAt time.Time
+}
+
+// 全てのテーブル
+// 値は保存後に書き換えず、変更する場合は置き換える
+// そのため clone はマップの複製のみで済む
+type data struct {
+	users     map[users.ID]users.User
+	sessions  map[string]session
+	companies map[companies.ID]companies.Company
+	employees map[employees.ID]employees.Employee
+	// 付与した順に並ぶ
+	permissions map[employees.ID][]permissions.Permission
+	departments map[organizations.ID]organizations.Department
+	roles       map[roles.ID]roles.Role
+	assignments map[assignments.ID]assignments.Assignment
+	apiKeys     map[apikeys.ID]apikeys.Key
+	// テーブルごとに最後に割り当てた ID
+	lastID map[string]int
+}
+
+func newData() *data {
+	return &data{
+		users:       map[users.ID]users.User{},
+		sessions:    map[string]session{},
+		companies:   map[companies.ID]companies.Company{},
+		employees:   map[employees.ID]employees.Employee{},
+		permissions: map[employees.ID][]permissions.Permission{},
+		departments: map[organizations.ID]organizations.Department{},
+		roles:       map[roles.ID]roles.Role{},
+		assignments: map[assignments.ID]assignments.Assignment{},
+		apiKeys:     map[apikeys.ID]apikeys.Key{},
+		lastID:      map[string]int{},
+	}
+}
+
+// SQL の like と同じく、大文字と小文字を区別しない前方一致
+func hasPrefix(s, prefix string) bool {
+	return strings.HasPrefix(strings.ToLower(s), strings.ToLower(prefix))
+}
+
+// MySQL の照合順序 (utf8mb4_0900_ai_ci) と同じく、大文字と小文字を区別しない名前の比較
+func sameName(a, b string) bool {
+	return strings.EqualFold(a, b)
+}
+
+func cloneMap[K comparable, V any](m map[K]V) map[K]V {
+	c := make(map[K]V, len(m))
+	for k, v := range m {
+		c[k] = v
+	}
+	return c
+}
+
+func (d *data) clone() *data {
+	return &data{
+		users:       cloneMap(d.users),
+		sessions:    cloneMap(d.sessions),
+		companies:   cloneMap(d.companies),
+		employees:   cloneMap(d.employees),
+		permissions: cloneMap(d.permissions),
+		departments: cloneMap(d.departments),
+		roles:       cloneMap(d.roles),
+		assignments: cloneMap(d.assignments),
+		apiKeys:     cloneMap(d.apiKeys),
+		lastID:      cloneMap(d.lastID),
+	}
+}
+
+// auto increment と同じく、削除した ID は再利用しない
+func (d *data) nextID(table string) int {
+	d.lastID[table]++
+	return d.lastID[table]
+}
+
+// 全ての memory が共有するデータ
+type store struct {
+	mu   sync.RWMutex
+	data *data
+}
+
+// impl repository.Repository
+type memory struct {
+	store *store
+	// WithTx の中でのみ持つデータの複製
+	// ロックは WithTx が保持している
+	tx *data
+}
+
+func New() repository.Repository {
+	return &memory{
+		store: &store{
+			data: newData(),
+		},
+	}
+}
+
+func (m *memory) Close() error {
+	return nil
+}
+
+func (m *memory) read(ctx context.Context, fn func(*data) error) error {
+	err := ctx.Err()
+	if err != nil {
+		return err
+	}
+
+	if m.tx != nil {
+		return fn(m.tx)
+	}
+
+	m.store.mu.RLock()
+	defer m.store.mu.RUnlock()
+	return fn(m.store.data)
+}
+
+// fn はエラーを返す前に何も変更しないこと
+// 複数の変更をまとめて取り消す場合は WithTx を使う
+func (m *memory) write(ctx context.Context, fn func(*data) error) error {
+	err := ctx.Err()
+	if err != nil {
+		return err
+	}
+
+	if m.tx != nil {
+		return fn(m.tx)
+	}
+
+	m.store.mu.Lock()
+	defer m.store.mu.Unlock()
+	return fn(m.store.data)
+}
+
+// fn の中の操作はデータの複製に対して行い、エラーがなければ複製で置き換える
+// fn がエラーを返すか panic すれば複製を捨てる
+// 他の操作は fn が終わるまで待つため、fn の中では引数の Repository のみを使うこと
+func (m *memory) WithTx(ctx context.Context, fn func(repository.Repository) error) error {
+	if m.tx != nil {
+		return fn(m)
+	}
+
+	err := ctx.Err()
+	if err != nil {
+		return fmt.Errorf("repository/memory.WithTx: %w", err)
+	}
+
+	m.store.mu.Lock()
+	defer m.store.mu.Unlock()
+
+	tx := m.store.data.clone()
+	err = fn(&memory{store: m.store, tx: tx})
+	if err != nil {
+		return err
+	}
+
+	m.store.data = tx
+	return nil
+}
+
+func (m *memory) UserTx(ctx context.Context, fn func(users.Repository) error) error {
+	return m.WithTx(ctx, func(tx repository.Repository) error {
+		return fn(tx)
+	})
+}
@@ -0,0 +1,276 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	assignments "api.example.com/pkg/assignment"
+	companies "api.example.com/pkg/company"
+	employees "api.example.com/pkg/employee"
+	"api.example.com/pkg/failure"
+	organizations "api.example.com/pkg/organization"
+	roles "api.example.com/pkg/role"
+	users "api.example.com/pkg/user"
+	"api.example.com/pkg/user/password"
+	"api.example.com/repository"
+)
+
+// 作成、更新の日時を固定する
+var testTime = time.Date(2022, 8, 9, 12, 34, 56, 0, time.UTC)
+
+func init() {
+	currentTime = func() time.Time {
+		return testTime
+	}
+}
+
+var testPassword = password.FromHash([]byte("password"))
+
+// テスト用のデータ
+// ユーザー: 1 bob, 2 alice, 3 carol
+// 会社: 1 GREATE COMPANY (所有者 bob)
+// 従業員: 1 bob (管理者), 2 alice
+// 部署: 1 開発部 > 2 開発一課 > 3 開発一係, 4 営業部
+// 肩書き: 1 部長, 2 課長
+// 配置: 1 alice 開発一課 課長
+func newFixture(t *testing.T) *memory {
+	t.Helper()
+
+	m := New().(*memory)
+	ctx := context.Background()
+	must := func(_ interface{}, err error) {
+		t.Helper()
+		if err != nil {
+			t.Fatalf("fixture: %v", err)
+		}
+	}
+
+	must(m.UserCreate(ctx, users.New("bob", testPassword)))
+	must(m.UserCreate(ctx, users.New("alice", testPassword)))
+	must(m.UserCreate(ctx, users.New("carol", testPassword)))
+	must(m.CompanyCreate(ctx, &companies.Company{Name: "GREATE COMPANY", OwnerID: 1}))
+	must(m.EmployeeCreate(ctx, &employees.Employee{CompanyID: 1, UserID: 2}))
+	must(m.DepartmentCreate(ctx, organizations.New(1, organizations.Root, "開発部")))
+	must(m.DepartmentCreate(ctx, organizations.New(1, 1, "開発一課")))
+	must(m.DepartmentCreate(ctx, organizations.New(1, 2, "開発一係")))
+	must(m.DepartmentCreate(ctx, organizations.New(1, organizations.Root, "営業部")))
+	must(m.RoleCreate(ctx, &roles.Role{CompanyID: 1, Name: "部長"}))
+	must(m.RoleCreate(ctx, &roles.Role{CompanyID: 1, Name: "課長"}))
+	must(m.AssignmentCreate(ctx, &assignments.Assignment{CompanyID: 1, EmployeeID: 2, DepartmentID: 2, RoleID: 2}))
+	return m
+}
+
+func TestNew(t *testing.T) {
+	var r repository.Repository = New()
+	if err := r.Close(); err != nil {
+		t.Fatalf("want=nil, got=%v.", err)
+	}
+}
+
+func TestMemory_read(t *testing.T) {
+	m := newFixture(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := m.UserRead(ctx, 1)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("want=%v, got=%v.", context.Canceled, err)
+	}
+
+	_, err = m.UserCreate(ctx, users.New("dave", testPassword))
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("want=%v, got=%v.", context.Canceled, err)
+	}
+}
+
+func TestMemory_WithTx(t *testing.T) {
+	errTest := errors.New("test error")
+
+	type test struct {
+		name string
+		// トランザクションの中の操作
+		fn func(repo repository.Repository) error
+		// fn が panic すること
+		panics     bool
+		wantErr    error
+		wantExists bool
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newFixture(t)
+			ctx := context.Background()
+
+			func() {
+				defer func() {
+					if p := recover(); (p != nil) != tt.panics {
+						t.Fatalf("want-panic=%v, panic=%v.", tt.panics, p)
+					}
+				}()
+
+				err := m.WithTx(ctx, func(repo repository.Repository) error {
+					_, err := repo.UserCreate(ctx, users.New("dave", testPassword))
+					if err != nil {
+						return err
+					}
+
+					// トランザクションの中では作成したユーザーを読み込める
+					_, err = repo.UserReadByName(ctx, "dave")
+					if err != nil {
+						return err
+					}
+
+					return tt.fn(repo)
+				})
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("want=%v, got=%v.", tt.wantErr, err)
+				}
+			}()
+
+			_, err := m.UserReadByName(ctx, "dave")
+			if tt.wantExists != (err == nil) {
+				t.Fatalf("want-exists=%v, error=%v.", tt.wantExists, err)
+			}
+
+			// 取り消した場合も他のデータは残る
+			_, err = m.UserReadByName(ctx, "bob")
+			if err != nil {
+				t.Fatalf("want=nil, got=%v.", err)
+			}
+		})
+	}
+
+	tests := []*test{
+		{
+			name: "commit",
+			fn: func(repository.Repository) error {
+				return nil
+			},
+			wantErr:    nil,
+			wantExists: true,
+		},
+		{
+			name: "rollback",
+			fn: func(repository.Repository) error {
+				return errTest
+			},
+			wantErr:    errTest,
+			wantExists: false,
+		},
+		{
+			name: "rollback after failed operation",
+			fn: func(repo repository.Repository) error {
+				_, err := repo.UserCreate(context.Background(), users.New("bob", testPassword))
+				return err
+			},
+			wantErr:    failure.ErrConflict,
+			wantExists: false,
+		},
+		{
+			name: "panic",
+			fn: func(repository.Repository) error {
+				panic("test panic")
+			},
+			panics:     true,
+			wantErr:    nil,
+			wantExists: false,
+		},
+		{
+			name: "nested rollback",
+			fn: func(repo repository.Repository) error {
+				// 内側の WithTx は外側のトランザクションに含まれる
+				return repo.WithTx(context.Background(), func(repository.Repository) error {
+					return errTest
+				})
+			},
+			wantErr:    errTest,
+			wantExists: false,
+		},
+		{
+			name: "user tx",
+			fn: func(repo repository.Repository) error {
+				return repo.UserTx(context.Background(), func(users.Repository) error {
+					return nil
+				})
+			},
+			wantErr:    nil,
+			wantExists: true,
+		},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+
+	t.Run("canceled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		err := newFixture(t).WithTx(ctx, func(repository.Repository) error {
+			t.Fatal("fn must not be called")
+			return nil
+		})
+		if !errors.Is(err, context.Canceled) {
+			t.Fatalf("want=%v, got=%v.", context.Canceled, err)
+		}
+	})
+}
+
+// 並行して操作しても ID と名前が重複しない
+func TestMemory_concurrency(t *testing.T) {
+	m := New()
+	ctx := context.Background()
+
+	const n = 50
+	var wg sync.WaitGroup
+	ids := make(chan users.ID, n*2)
+	for i := 0; i < n; i++ {
+		wg.Add(2)
+		name := users.Name(fmt.Sprintf("user%d", i))
+		go func() {
+			defer wg.Done()
+			u, err := m.UserCreate(ctx, users.New(name, testPassword))
+			if err == nil {
+				ids <- u.ID
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			err := m.UserTx(ctx, func(tx users.Repository) error {
+				_, err := tx.UserReadByName(ctx, name)
+				if err == nil {
+					return nil
+				}
+
+				u, err := tx.UserCreate(ctx, users.New(name, testPassword))
+				if err != nil {
+					return err
+				}
+				ids <- u.ID
+				return nil
+			})
+			if err != nil && !errors.Is(err, failure.ErrConflict) {
+				t.Errorf("want=nil, got=%v.", err)
+			}
+		}()
+	}
+	wg.Wait()
+	close(ids)
+
+	seen := map[users.ID]bool{}
+	for id := range ids {
+		if seen[id] {
+			t.Fatalf("duplicate id=%d.", id)
+		}
+		seen[id] = true
+	}
+
+	if len(seen) != n {
+		t.Fatalf("want=%d, got=%d.", n, len(seen))
+	}
+}
@@ -0,0 +1,93 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+
+	permissions "api.example.com/pkg/permission"
+)
+
+func permissionContains(list []permissions.Permission, p permissions.Permission) bool {
+	for _, v := range list {
+		if v == p {
+			return true
+		}
+	}
+	return false
+}
+
+// 他の会社の従業員であれば権限を持たない
+func (d *data) permissionList(companyID permissions.CompanyID, employeeID permissions.EmployeeID) []permissions.Permission {
+	e, ok := d.employees[employeeID]
+	if !ok || e.CompanyID != companyID {
+		return []permissions.Permission{}
+	}
+	return d.permissions[employeeID]
+}
+
+// 付与した順に並ぶ
+func (m *memory) PermissionList(ctx context.Context, companyID permissions.CompanyID, employeeID permissions.EmployeeID) ([]permissions.Permission, error) {
+	list := []permissions.Permission{}
+	err := m.read(ctx, func(d *data) error {
+		list = append(list, d.permissionList(companyID, employeeID)...)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("repository/memory.PermissionList: %w", err)
+	}
+
+	return list, nil
+}
+
+// employee は会社に実在する管理者であること
+// 付与済みであれば何もしない
+func (m *memory) PermissionGrant(ctx context.Context, companyID permissions.CompanyID, employeeID permissions.EmployeeID, p permissions.Permission) error {
+	err := m.write(ctx, func(d *data) error {
+		e, err := d.employee(companyID, employeeID)
+		if err != nil {
+			return fmt.Errorf("employee: %w", err)
+		}
+
+		if !e.Administrator {
+			return permissions.ErrNotAdministrator
+		}
+
+		granted := d.permissions[employeeID]
+		if permissionContains(granted, p) {
+			return nil
+		}
+
+		// WithTx の複製と配列を共有しないよう、常に新しいスライスを作る
+		d.permissions[employeeID] = append(granted[:len(granted):len(granted)], p)
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("repository/memory.PermissionGrant: %w", err)
+	}
+
+	return nil
+}
+
+// 付与されていなければ何もしない
+func (m *memory) PermissionRevoke(ctx context.Context, companyID permissions.CompanyID, employeeID permissions.EmployeeID, p permissions.Permission) error {
+	err := m.write(ctx, func(d *data) error {
+		granted := d.permissionList(companyID, employeeID)
+		if !permissionContains(granted, p) {
+			return nil
+		}
+
+		list := make([]permissions.Permission, 0, len(granted)-1)
+		for _, v := range granted {
+			if v != p {
+				list = append(list, v)
+			}
+		}
+		d.permissions[employeeID] = list
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("repository/memory.PermissionRevoke: %w", err)
+	}
+
+	return nil
+}
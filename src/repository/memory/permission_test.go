@@ -0,0 +1,151 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"api.example.com/pkg/failure"
+	permissions "api.example.com/pkg/permission"
+)
+
+func TestMemory_PermissionList(t *testing.T) {
+	type test struct {
+		name       string
+		companyID  permissions.CompanyID
+		employeeID permissions.EmployeeID
+		want       []permissions.Permission
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := newFixture(t).PermissionList(context.Background(), tt.companyID, tt.employeeID)
+			if err != nil {
+				t.Fatalf("want=nil, got=%v.", err)
+			}
+
+			if !reflect.DeepEqual(tt.want, got) {
+				t.Fatalf("want=%v, got=%v.", tt.want, got)
+			}
+		})
+	}
+
+	tests := []*test{
+		{
+			name:       "administrator",
+			companyID:  1,
+			employeeID: 1,
+			want:       permissions.All,
+		},
+		{
+			name:       "not administrator",
+			companyID:  1,
+			employeeID: 2,
+			want:       []permissions.Permission{},
+		},
+		{
+			name:       "other company",
+			companyID:  2,
+			employeeID: 1,
+			want:       []permissions.Permission{},
+		},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+}
+
+func TestMemory_PermissionGrantRevoke(t *testing.T) {
+	type test struct {
+		name string
+		// 付与または剥奪する操作
+		change  func(m *memory) error
+		wantErr error
+		// 管理者の権限
+		want []permissions.Permission
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newFixture(t)
+			ctx := context.Background()
+
+			// 1つを剥奪した状態から始める
+			err := m.PermissionRevoke(ctx, 1, 1, permissions.ManageEmployees)
+			if err != nil {
+				t.Fatalf("fixture: %v", err)
+			}
+
+			err = tt.change(m)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("want=%v, got=%v.", tt.wantErr, err)
+			}
+
+			got, err := m.PermissionList(ctx, 1, 1)
+			if err != nil {
+				t.Fatalf("want=nil, got=%v.", err)
+			}
+
+			if !reflect.DeepEqual(tt.want, got) {
+				t.Fatalf("want=%v, got=%v.", tt.want, got)
+			}
+		})
+	}
+
+	tests := []*test{
+		{
+			name: "grant",
+			change: func(m *memory) error {
+				return m.PermissionGrant(context.Background(), 1, 1, permissions.ManageEmployees)
+			},
+			wantErr: nil,
+			want:    append(append([]permissions.Permission{}, permissions.All[1:]...), permissions.ManageEmployees),
+		},
+		{
+			name: "grant granted",
+			change: func(m *memory) error {
+				return m.PermissionGrant(context.Background(), 1, 1, permissions.ManageRoles)
+			},
+			wantErr: nil,
+			want:    permissions.All[1:],
+		},
+		{
+			name: "grant to not administrator",
+			change: func(m *memory) error {
+				return m.PermissionGrant(context.Background(), 1, 2, permissions.ManageRoles)
+			},
+			wantErr: permissions.ErrNotAdministrator,
+			want:    permissions.All[1:],
+		},
+		{
+			name: "grant to unknown employee",
+			change: func(m *memory) error {
+				return m.PermissionGrant(context.Background(), 1, 99, permissions.ManageRoles)
+			},
+			wantErr: failure.ErrNotFound,
+			want:    permissions.All[1:],
+		},
+		{
+			name: "revoke",
+			change: func(m *memory) error {
+				return m.PermissionRevoke(context.Background(), 1, 1, permissions.ManageRoles)
+			},
+			wantErr: nil,
+			want:    []permissions.Permission{permissions.ManageDepartments, permissions.ManageCompany},
+		},
+		{
+			name: "revoke not granted",
+			change: func(m *memory) error {
+				return m.PermissionRevoke(context.Background(), 1, 1, permissions.ManageEmployees)
+			},
+			wantErr: nil,
+			want:    permissions.All[1:],
+		},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+}
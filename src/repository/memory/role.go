@@ -0,0 +1,148 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"api.example.com/pkg/failure"
+	roles "api.example.com/pkg/role"
+)
+
+const tableRoles = "company_roles"
+
+// 他の会社の肩書きは存在しないものとする
+func (d *data) role(companyID roles.CompanyID, id roles.ID) (*roles.Role, error) {
+	r, ok := d.roles[id]
+	if !ok || r.CompanyID != companyID {
+		return nil, fmt.Errorf("%w: role_id=%d", failure.ErrNotFound, id)
+	}
+	return &r, nil
+}
+
+// 会社の中で同じ名前の肩書きが id 以外に存在すれば roles.ErrDuplicateName
+func (d *data) checkRoleName(r *roles.Role, id roles.ID) error {
+	for _, other := range d.roles {
+		if other.CompanyID == r.CompanyID && sameName(string(other.Name), string(r.Name)) && other.ID != id {
+			return roles.ErrDuplicateName
+		}
+	}
+	return nil
+}
+
+// company は実在すること
+func (m *memory) RoleCreate(ctx context.Context, r *roles.Role) (*roles.Role, error) {
+	var created roles.Role
+	err := m.write(ctx, func(d *data) error {
+		_, err := d.company(r.CompanyID)
+		if err != nil {
+			return fmt.Errorf("company: %w", err)
+		}
+
+		err = d.checkRoleName(r, 0)
+		if err != nil {
+			return err
+		}
+
+		created = roles.Role{
+			ID:        roles.ID(d.nextID(tableRoles)),
+			CompanyID: r.CompanyID,
+			Name:      r.Name,
+			UpdatedAt: currentTime(),
+		}
+		d.roles[created.ID] = created
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("repository/memory.RoleCreate: %w", err)
+	}
+
+	return &created, nil
+}
+
+func (m *memory) RoleRead(ctx context.Context, companyID roles.CompanyID, id roles.ID) (*roles.Role, error) {
+	var r *roles.Role
+	err := m.read(ctx, func(d *data) (err error) {
+		r, err = d.role(companyID, id)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("repository/memory.RoleRead: %w", err)
+	}
+
+	return r, nil
+}
+
+// ID の順に並ぶ
+func (m *memory) RoleList(ctx context.Context, companyID roles.CompanyID) ([]*roles.Role, error) {
+	list := []*roles.Role{}
+	err := m.read(ctx, func(d *data) error {
+		for _, r := range d.roles {
+			if r.CompanyID == companyID {
+				r := r
+				list = append(list, &r)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("repository/memory.RoleList: %w", err)
+	}
+
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].ID < list[j].ID
+	})
+	return list, nil
+}
+
+func (m *memory) RoleUpdate(ctx context.Context, r *roles.Role) (*roles.Role, error) {
+	var updated roles.Role
+	err := m.write(ctx, func(d *data) error {
+		err := d.checkRoleName(r, r.ID)
+		if err != nil {
+			return err
+		}
+
+		_, err = d.role(r.CompanyID, r.ID)
+		if err != nil {
+			return err
+		}
+
+		updated = roles.Role{
+			ID:        r.ID,
+			CompanyID: r.CompanyID,
+			Name:      r.Name,
+			UpdatedAt: currentTime(),
+		}
+		d.roles[updated.ID] = updated
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("repository/memory.RoleUpdate: %w", err)
+	}
+
+	return &updated, nil
+}
+
+// 肩書きへの配置も削除する
+func (m *memory) RoleDelete(ctx context.Context, companyID roles.CompanyID, id roles.ID) error {
+	err := m.write(ctx, func(d *data) error {
+		_, err := d.role(companyID, id)
+		if err != nil {
+			return err
+		}
+
+		for _, a := range d.assignments {
+			if a.RoleID == id {
+				delete(d.assignments, a.ID)
+			}
+		}
+		delete(d.roles, id)
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("repository/memory.RoleDelete: %w", err)
+	}
+
+	return nil
+}
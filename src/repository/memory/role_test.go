@@ -0,0 +1,218 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"api.example.com/pkg/failure"
+	roles "api.example.com/pkg/role"
+)
+
+func TestMemory_RoleCreate(t *testing.T) {
+	type test struct {
+		name    string
+		role    *roles.Role
+		want    *roles.Role
+		wantErr error
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := newFixture(t).RoleCreate(context.Background(), tt.role)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("want=%v, got=%v.", tt.wantErr, err)
+			}
+
+			if !reflect.DeepEqual(tt.want, got) {
+				t.Fatalf("want=%v, got=%v.", tt.want, got)
+			}
+		})
+	}
+
+	tests := []*test{
+		{
+			name:    "true",
+			role:    &roles.Role{CompanyID: 1, Name: "係長"},
+			want:    &roles.Role{ID: 3, CompanyID: 1, Name: "係長", UpdatedAt: testTime},
+			wantErr: nil,
+		},
+		{
+			name:    "duplicate name",
+			role:    &roles.Role{CompanyID: 1, Name: "部長"},
+			want:    nil,
+			wantErr: roles.ErrDuplicateName,
+		},
+		{
+			name:    "company not found",
+			role:    &roles.Role{CompanyID: 99, Name: "部長"},
+			want:    nil,
+			wantErr: failure.ErrNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+}
+
+func TestMemory_RoleRead(t *testing.T) {
+	type test struct {
+		name      string
+		companyID roles.CompanyID
+		id        roles.ID
+		want      *roles.Role
+		wantErr   error
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := newFixture(t).RoleRead(context.Background(), tt.companyID, tt.id)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("want=%v, got=%v.", tt.wantErr, err)
+			}
+
+			if !reflect.DeepEqual(tt.want, got) {
+				t.Fatalf("want=%v, got=%v.", tt.want, got)
+			}
+		})
+	}
+
+	tests := []*test{
+		{
+			name:      "true",
+			companyID: 1,
+			id:        2,
+			want:      &roles.Role{ID: 2, CompanyID: 1, Name: "課長", UpdatedAt: testTime},
+			wantErr:   nil,
+		},
+		{
+			name:      "other company",
+			companyID: 2,
+			id:        2,
+			want:      nil,
+			wantErr:   failure.ErrNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+}
+
+func TestMemory_RoleList(t *testing.T) {
+	m := newFixture(t)
+
+	got, err := m.RoleList(context.Background(), 1)
+	if err != nil {
+		t.Fatalf("want=nil, got=%v.", err)
+	}
+
+	want := []*roles.Role{
+		{ID: 1, CompanyID: 1, Name: "部長", UpdatedAt: testTime},
+		{ID: 2, CompanyID: 1, Name: "課長", UpdatedAt: testTime},
+	}
+	if !reflect.DeepEqual(want, got) {
+		t.Fatalf("want=%v, got=%v.", want, got)
+	}
+}
+
+func TestMemory_RoleUpdate(t *testing.T) {
+	type test struct {
+		name    string
+		role    *roles.Role
+		want    *roles.Role
+		wantErr error
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := newFixture(t).RoleUpdate(context.Background(), tt.role)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("want=%v, got=%v.", tt.wantErr, err)
+			}
+
+			if !reflect.DeepEqual(tt.want, got) {
+				t.Fatalf("want=%v, got=%v.", tt.want, got)
+			}
+		})
+	}
+
+	tests := []*test{
+		{
+			name:    "true",
+			role:    &roles.Role{ID: 2, CompanyID: 1, Name: "係長"},
+			want:    &roles.Role{ID: 2, CompanyID: 1, Name: "係長", UpdatedAt: testTime},
+			wantErr: nil,
+		},
+		{
+			name:    "same name",
+			role:    &roles.Role{ID: 2, CompanyID: 1, Name: "課長"},
+			want:    &roles.Role{ID: 2, CompanyID: 1, Name: "課長", UpdatedAt: testTime},
+			wantErr: nil,
+		},
+		{
+			name:    "duplicate name",
+			role:    &roles.Role{ID: 2, CompanyID: 1, Name: "部長"},
+			want:    nil,
+			wantErr: roles.ErrDuplicateName,
+		},
+		{
+			name:    "not found",
+			role:    &roles.Role{ID: 99, CompanyID: 1, Name: "係長"},
+			want:    nil,
+			wantErr: failure.ErrNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+}
+
+func TestMemory_RoleDelete(t *testing.T) {
+	type test struct {
+		name    string
+		id      roles.ID
+		wantErr error
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newFixture(t)
+			ctx := context.Background()
+			err := m.RoleDelete(ctx, 1, tt.id)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("want=%v, got=%v.", tt.wantErr, err)
+			}
+
+			if tt.wantErr != nil {
+				return
+			}
+
+			// 肩書きへの配置も削除される
+			list, err := m.AssignmentListByEmployee(ctx, 1, 2)
+			if err != nil || len(list) != 0 {
+				t.Fatalf("want=[], got=%v, error=%v.", list, err)
+			}
+		})
+	}
+
+	tests := []*test{
+		{
+			name:    "true",
+			id:      2,
+			wantErr: nil,
+		},
+		{
+			name:    "not found",
+			id:      99,
+			wantErr: failure.ErrNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+}
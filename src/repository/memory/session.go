@@ -0,0 +1,105 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"api.example.com/pkg/failure"
+	sessions "api.example.com/pkg/session"
+	users "api.example.com/pkg/user"
+)
+
+// match に一致するセッション
+// 対象がなくてもエラーにしない
+func (d *data) deleteSessions(match func(session) bool) {
+	for digest, s := range d.sessions {
+		if match(s) {
+			delete(d.sessions, digest)
+		}
+	}
+}
+
+// user は実在すること
+func (m *memory) SessionCreate(ctx context.Context, s *sessions.Session) (*sessions.Session, error) {
+	err := m.write(ctx, func(d *data) error {
+		_, err := d.user(s.UserID)
+		if err != nil {
+			return fmt.Errorf("user: %w", err)
+		}
+
+		d.sessions[s.ID.Digest()] = session{
+			userID:    s.UserID,
+			expiresAt: s.ExpiresAt,
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("repository/memory.SessionCreate: %w", err)
+	}
+
+	return sessions.New(s.ID, s.UserID, s.ExpiresAt), nil
+}
+
+func (m *memory) SessionRead(ctx context.Context, id sessions.ID) (*sessions.Session, error) {
+	var s session
+	err := m.read(ctx, func(d *data) error {
+		var ok bool
+		s, ok = d.sessions[id.Digest()]
+		if !ok {
+			return fmt.Errorf("%w: session", failure.ErrNotFound)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("repository/memory.SessionRead: %w", err)
+	}
+
+	return sessions.New(id, s.userID, s.expiresAt), nil
+}
+
+func (m *memory) SessionDelete(ctx context.Context, id sessions.ID) error {
+	err := m.write(ctx, func(d *data) error {
+		_, ok := d.sessions[id.Digest()]
+		if !ok {
+			return fmt.Errorf("%w: session", failure.ErrNotFound)
+		}
+
+		delete(d.sessions, id.Digest())
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("repository/memory.SessionDelete: %w", err)
+	}
+
+	return nil
+}
+
+func (m *memory) SessionDeleteByUserID(ctx context.Context, userID users.ID) error {
+	err := m.write(ctx, func(d *data) error {
+		d.deleteSessions(func(s session) bool {
+			return s.userID == userID
+		})
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("repository/memory.SessionDeleteByUserID: %w", err)
+	}
+
+	return nil
+}
+
+// at の時点で有効期限が切れているセッション
+func (m *memory) SessionDeleteExpired(ctx context.Context, at time.Time) error {
+	err := m.write(ctx, func(d *data) error {
+		d.deleteSessions(func(s session) bool {
+			return !s.expiresAt.After(at)
+		})
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("repository/memory.SessionDeleteExpired: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,195 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"api.example.com/pkg/failure"
+	sessions "api.example.com/pkg/session"
+	users "api.example.com/pkg/user"
+)
+
+func TestMemory_SessionCreate(t *testing.T) {
+	type test struct {
+		name    string
+		session *sessions.Session
+		want    *sessions.Session
+		wantErr error
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newFixture(t)
+			got, err := m.SessionCreate(context.Background(), tt.session)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("want=%v, got=%v.", tt.wantErr, err)
+			}
+
+			if !reflect.DeepEqual(tt.want, got) {
+				t.Fatalf("want=%v, got=%v.", tt.want, got)
+			}
+
+			if tt.want == nil {
+				return
+			}
+
+			read, err := m.SessionRead(context.Background(), tt.want.ID)
+			if err != nil || !reflect.DeepEqual(tt.want, read) {
+				t.Fatalf("want=%v, got=%v, error=%v.", tt.want, read, err)
+			}
+		})
+	}
+
+	tests := []*test{
+		{
+			name:    "true",
+			session: &sessions.Session{ID: "session", UserID: 1, Token: "token", ExpiresAt: testTime},
+			// 資格情報は保存しない
+			want:    sessions.New("session", 1, testTime),
+			wantErr: nil,
+		},
+		{
+			name:    "user not found",
+			session: sessions.New("session", 99, testTime),
+			want:    nil,
+			wantErr: failure.ErrNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+}
+
+func TestMemory_SessionRead(t *testing.T) {
+	m := newFixture(t)
+	ctx := context.Background()
+
+	_, err := m.SessionRead(ctx, "session")
+	if !errors.Is(err, failure.ErrNotFound) {
+		t.Fatalf("want=%v, got=%v.", failure.ErrNotFound, err)
+	}
+}
+
+func TestMemory_SessionDelete(t *testing.T) {
+	type test struct {
+		name    string
+		id      sessions.ID
+		wantErr error
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newFixture(t)
+			ctx := context.Background()
+			_, err := m.SessionCreate(ctx, sessions.New("session", 1, testTime))
+			if err != nil {
+				t.Fatalf("fixture: %v", err)
+			}
+
+			err = m.SessionDelete(ctx, tt.id)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("want=%v, got=%v.", tt.wantErr, err)
+			}
+
+			_, err = m.SessionRead(ctx, tt.id)
+			if !errors.Is(err, failure.ErrNotFound) {
+				t.Fatalf("want=%v, got=%v.", failure.ErrNotFound, err)
+			}
+		})
+	}
+
+	tests := []*test{
+		{
+			name:    "true",
+			id:      "session",
+			wantErr: nil,
+		},
+		{
+			name:    "not found",
+			id:      "other",
+			wantErr: failure.ErrNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+}
+
+func TestMemory_SessionDeleteAll(t *testing.T) {
+	type test struct {
+		name string
+		// 削除する操作
+		delete func(m *memory) error
+		// 残るセッション
+		want []sessions.ID
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newFixture(t)
+			ctx := context.Background()
+			all := map[sessions.ID]*sessions.Session{
+				"bob-expired": sessions.New("bob-expired", 1, testTime.Add(-time.Hour)),
+				"bob-now":     sessions.New("bob-now", 1, testTime),
+				"bob":         sessions.New("bob", 1, testTime.Add(time.Hour)),
+				"alice":       sessions.New("alice", 2, testTime.Add(time.Hour)),
+			}
+			for _, s := range all {
+				_, err := m.SessionCreate(ctx, s)
+				if err != nil {
+					t.Fatalf("fixture: %v", err)
+				}
+			}
+
+			err := tt.delete(m)
+			if err != nil {
+				t.Fatalf("want=nil, got=%v.", err)
+			}
+
+			got := []sessions.ID{}
+			for _, id := range []sessions.ID{"bob-expired", "bob-now", "bob", "alice"} {
+				_, err := m.SessionRead(ctx, id)
+				if err == nil {
+					got = append(got, id)
+				}
+			}
+
+			if !reflect.DeepEqual(tt.want, got) {
+				t.Fatalf("want=%v, got=%v.", tt.want, got)
+			}
+		})
+	}
+
+	tests := []*test{
+		{
+			name: "by user id",
+			delete: func(m *memory) error {
+				return m.SessionDeleteByUserID(context.Background(), users.ID(1))
+			},
+			want: []sessions.ID{"alice"},
+		},
+		{
+			name: "by user id without sessions",
+			delete: func(m *memory) error {
+				return m.SessionDeleteByUserID(context.Background(), users.ID(3))
+			},
+			want: []sessions.ID{"bob-expired", "bob-now", "bob", "alice"},
+		},
+		{
+			name: "expired",
+			delete: func(m *memory) error {
+				return m.SessionDeleteExpired(context.Background(), testTime)
+			},
+			want: []sessions.ID{"bob", "alice"},
+		},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+}
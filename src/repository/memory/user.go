@@ -0,0 +1,182 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"api.example.com/pkg/failure"
+	users "api.example.com/pkg/user"
+	"api.example.com/pkg/user/password"
+)
+
+const tableUsers = "users"
+
+// repository/model と同じくハッシュのみを保存する
+func newUser(u *users.User, id users.ID) users.User {
+	return users.User{
+		ID:        id,
+		Name:      u.Name,
+		Password:  password.FromHash(u.Password.Hash()),
+		UpdatedAt: currentTime(),
+	}
+}
+
+func (d *data) user(id users.ID) (*users.User, error) {
+	u, ok := d.users[id]
+	if !ok {
+		return nil, fmt.Errorf("%w: user_id=%d", failure.ErrNotFound, id)
+	}
+	return &u, nil
+}
+
+// id 以外に同じ名前のユーザーがいれば failure.ErrConflict
+func (d *data) checkUserName(name users.Name, id users.ID) error {
+	for _, u := range d.users {
+		if sameName(string(u.Name), string(name)) && u.ID != id {
+			return failure.Conflict("user.name already exists", "user.name", failure.RuleUnique)
+		}
+	}
+	return nil
+}
+
+// 会社の所有者や従業員であるユーザー
+func (d *data) userReferenced(id users.ID) bool {
+	for _, c := range d.companies {
+		if c.OwnerID == id {
+			return true
+		}
+	}
+
+	for _, e := range d.employees {
+		if e.UserID == id {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (m *memory) UserCreate(ctx context.Context, u *users.User) (*users.User, error) {
+	var created users.User
+	err := m.write(ctx, func(d *data) error {
+		err := d.checkUserName(u.Name, 0)
+		if err != nil {
+			return err
+		}
+
+		created = newUser(u, users.ID(d.nextID(tableUsers)))
+		d.users[created.ID] = created
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("repository/memory.UserCreate: %w", err)
+	}
+
+	return &created, nil
+}
+
+func (m *memory) UserRead(ctx context.Context, id users.ID) (*users.User, error) {
+	var u *users.User
+	err := m.read(ctx, func(d *data) (err error) {
+		u, err = d.user(id)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("repository/memory.UserRead: %w", err)
+	}
+
+	return u, nil
+}
+
+func (m *memory) UserReadByName(ctx context.Context, name users.Name) (*users.User, error) {
+	var found *users.User
+	err := m.read(ctx, func(d *data) error {
+		for _, u := range d.users {
+			if sameName(string(u.Name), string(name)) {
+				found = &u
+				return nil
+			}
+		}
+		return fmt.Errorf("%w: user.name=%s", failure.ErrNotFound, name)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("repository/memory.UserReadByName: %w", err)
+	}
+
+	return found, nil
+}
+
+// 名前が prefix で始まり、 ID が after より大きいユーザーを ID の順に limit 件まで
+func (m *memory) UserList(ctx context.Context, prefix users.Name, after users.ID, limit int) ([]*users.User, error) {
+	list := []*users.User{}
+	err := m.read(ctx, func(d *data) error {
+		for _, u := range d.users {
+			if u.ID > after && hasPrefix(string(u.Name), string(prefix)) {
+				u := u
+				list = append(list, &u)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("repository/memory.UserList: %w", err)
+	}
+
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].ID < list[j].ID
+	})
+	if len(list) > limit {
+		list = list[:limit]
+	}
+	return list, nil
+}
+
+func (m *memory) UserUpdate(ctx context.Context, u *users.User) (*users.User, error) {
+	var updated users.User
+	err := m.write(ctx, func(d *data) error {
+		err := d.checkUserName(u.Name, u.ID)
+		if err != nil {
+			return err
+		}
+
+		_, err = d.user(u.ID)
+		if err != nil {
+			return err
+		}
+
+		updated = newUser(u, u.ID)
+		d.users[updated.ID] = updated
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("repository/memory.UserUpdate: %w", err)
+	}
+
+	return &updated, nil
+}
+
+// ユーザーのセッションも削除する
+func (m *memory) UserDelete(ctx context.Context, id users.ID) error {
+	err := m.write(ctx, func(d *data) error {
+		_, err := d.user(id)
+		if err != nil {
+			return err
+		}
+
+		if d.userReferenced(id) {
+			return failure.Conflict("user_id is still referenced", "user_id", failure.RuleUnreferenced)
+		}
+
+		delete(d.users, id)
+		d.deleteSessions(func(s session) bool {
+			return s.userID == id
+		})
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("repository/memory.UserDelete: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,352 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"api.example.com/pkg/failure"
+	sessions "api.example.com/pkg/session"
+	users "api.example.com/pkg/user"
+)
+
+func TestMemory_UserCreate(t *testing.T) {
+	type test struct {
+		name    string
+		user    *users.User
+		want    *users.User
+		wantErr error
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := newFixture(t).UserCreate(context.Background(), tt.user)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("want=%v, got=%v.", tt.wantErr, err)
+			}
+
+			if !reflect.DeepEqual(tt.want, got) {
+				t.Fatalf("want=%v, got=%v.", tt.want, got)
+			}
+		})
+	}
+
+	tests := []*test{
+		{
+			name:    "true",
+			user:    users.New("dave", testPassword),
+			want:    &users.User{ID: 4, Name: "dave", Password: testPassword, UpdatedAt: testTime},
+			wantErr: nil,
+		},
+		{
+			name:    "duplicate name",
+			user:    users.New("bob", testPassword),
+			want:    nil,
+			wantErr: failure.ErrConflict,
+		},
+		{
+			name:    "duplicate name ignoring case",
+			user:    users.New("BOB", testPassword),
+			want:    nil,
+			wantErr: failure.ErrConflict,
+		},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+}
+
+func TestMemory_UserRead(t *testing.T) {
+	type test struct {
+		name    string
+		id      users.ID
+		want    *users.User
+		wantErr error
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := newFixture(t).UserRead(context.Background(), tt.id)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("want=%v, got=%v.", tt.wantErr, err)
+			}
+
+			if !reflect.DeepEqual(tt.want, got) {
+				t.Fatalf("want=%v, got=%v.", tt.want, got)
+			}
+		})
+	}
+
+	tests := []*test{
+		{
+			name:    "true",
+			id:      2,
+			want:    &users.User{ID: 2, Name: "alice", Password: testPassword, UpdatedAt: testTime},
+			wantErr: nil,
+		},
+		{
+			name:    "not found",
+			id:      99,
+			want:    nil,
+			wantErr: failure.ErrNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+}
+
+func TestMemory_UserReadByName(t *testing.T) {
+	type test struct {
+		name     string
+		userName users.Name
+		want     *users.User
+		wantErr  error
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := newFixture(t).UserReadByName(context.Background(), tt.userName)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("want=%v, got=%v.", tt.wantErr, err)
+			}
+
+			if !reflect.DeepEqual(tt.want, got) {
+				t.Fatalf("want=%v, got=%v.", tt.want, got)
+			}
+		})
+	}
+
+	tests := []*test{
+		{
+			name:     "true",
+			userName: "carol",
+			want:     &users.User{ID: 3, Name: "carol", Password: testPassword, UpdatedAt: testTime},
+			wantErr:  nil,
+		},
+		{
+			name:     "ignoring case",
+			userName: "Carol",
+			want:     &users.User{ID: 3, Name: "carol", Password: testPassword, UpdatedAt: testTime},
+			wantErr:  nil,
+		},
+		{
+			name:     "not found",
+			userName: "dave",
+			want:     nil,
+			wantErr:  failure.ErrNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+}
+
+func TestMemory_UserList(t *testing.T) {
+	type test struct {
+		name   string
+		prefix users.Name
+		after  users.ID
+		limit  int
+		want   []users.ID
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.name, func(t *testing.T) {
+			list, err := newFixture(t).UserList(context.Background(), tt.prefix, tt.after, tt.limit)
+			if err != nil {
+				t.Fatalf("want=nil, got=%v.", err)
+			}
+
+			got := []users.ID{}
+			for _, u := range list {
+				got = append(got, u.ID)
+			}
+
+			if !reflect.DeepEqual(tt.want, got) {
+				t.Fatalf("want=%v, got=%v.", tt.want, got)
+			}
+		})
+	}
+
+	tests := []*test{
+		{
+			name:  "all",
+			limit: 10,
+			want:  []users.ID{1, 2, 3},
+		},
+		{
+			name:  "limit",
+			limit: 2,
+			want:  []users.ID{1, 2},
+		},
+		{
+			name:  "after",
+			after: 1,
+			limit: 10,
+			want:  []users.ID{2, 3},
+		},
+		{
+			name:   "prefix",
+			prefix: "ca",
+			limit:  10,
+			want:   []users.ID{3},
+		},
+		{
+			name:   "not found",
+			prefix: "dave",
+			limit:  10,
+			want:   []users.ID{},
+		},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+}
+
+func TestMemory_UserUpdate(t *testing.T) {
+	type test struct {
+		name    string
+		user    *users.User
+		want    *users.User
+		wantErr error
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newFixture(t)
+			got, err := m.UserUpdate(context.Background(), tt.user)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("want=%v, got=%v.", tt.wantErr, err)
+			}
+
+			if !reflect.DeepEqual(tt.want, got) {
+				t.Fatalf("want=%v, got=%v.", tt.want, got)
+			}
+
+			if tt.want == nil {
+				return
+			}
+
+			read, err := m.UserRead(context.Background(), tt.want.ID)
+			if err != nil || !reflect.DeepEqual(tt.want, read) {
+				t.Fatalf("want=%v, got=%v, error=%v.", tt.want, read, err)
+			}
+		})
+	}
+
+	tests := []*test{
+		{
+			name:    "true",
+			user:    &users.User{ID: 2, Name: "alice2", Password: testPassword},
+			want:    &users.User{ID: 2, Name: "alice2", Password: testPassword, UpdatedAt: testTime},
+			wantErr: nil,
+		},
+		{
+			name:    "same name",
+			user:    &users.User{ID: 2, Name: "alice", Password: testPassword},
+			want:    &users.User{ID: 2, Name: "alice", Password: testPassword, UpdatedAt: testTime},
+			wantErr: nil,
+		},
+		{
+			name:    "duplicate name",
+			user:    &users.User{ID: 2, Name: "bob", Password: testPassword},
+			want:    nil,
+			wantErr: failure.ErrConflict,
+		},
+		{
+			name:    "not found",
+			user:    &users.User{ID: 99, Name: "dave", Password: testPassword},
+			want:    nil,
+			wantErr: failure.ErrNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+}
+
+func TestMemory_UserDelete(t *testing.T) {
+	type test struct {
+		name    string
+		id      users.ID
+		wantErr error
+		// 違反した項目
+		wantViolations []failure.Violation
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newFixture(t)
+			ctx := context.Background()
+			_, err := m.SessionCreate(ctx, sessions.New("session", tt.id, testTime.Add(time.Hour)))
+			if err != nil && tt.wantErr == nil {
+				t.Fatalf("fixture: %v", err)
+			}
+
+			err = m.UserDelete(ctx, tt.id)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("want=%v, got=%v.", tt.wantErr, err)
+			}
+
+			if got := failure.Violations(err); !reflect.DeepEqual(tt.wantViolations, got) {
+				t.Fatalf("want=%v, got=%v.", tt.wantViolations, got)
+			}
+
+			if tt.wantErr != nil {
+				return
+			}
+
+			_, err = m.UserRead(ctx, tt.id)
+			if !errors.Is(err, failure.ErrNotFound) {
+				t.Fatalf("want=%v, got=%v.", failure.ErrNotFound, err)
+			}
+
+			// ユーザーのセッションも削除される
+			_, err = m.SessionRead(ctx, "session")
+			if !errors.Is(err, failure.ErrNotFound) {
+				t.Fatalf("want=%v, got=%v.", failure.ErrNotFound, err)
+			}
+		})
+	}
+
+	tests := []*test{
+		{
+			name:    "true",
+			id:      3,
+			wantErr: nil,
+		},
+		{
+			name:    "owner of company",
+			id:      1,
+			wantErr: failure.ErrConflict,
+			wantViolations: []failure.Violation{
+				{Field: "user_id", Rule: failure.RuleUnreferenced},
+			},
+		},
+		{
+			name:    "employee",
+			id:      2,
+			wantErr: failure.ErrConflict,
+			wantViolations: []failure.Violation{
+				{Field: "user_id", Rule: failure.RuleUnreferenced},
+			},
+		},
+		{
+			name:    "not found",
+			id:      99,
+			wantErr: failure.ErrNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+}
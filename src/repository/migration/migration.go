@@ -0,0 +1,274 @@
+// データベースのスキーマのマイグレーション
+// migrations/<dialect>/<version>_<name>.up.sql と <version>_<name>.down.sql を埋め込み、
+// 適用したバージョンを `schema_migrations` に記録する
+// MySQL (mysql) と SQLite (sqlite) は同じバージョンと名前で、同じ表と索引を作成する
+// `schema_migrations` は ActiveRecord と同じ構成のため、 ActiveRecord で適用済みのデータベースを引き継げる
+package migration
+
+import (
+	"context"
+	"database/sql"
+	"embed"
+	"fmt"
+	"io/fs"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// SQL 抽象化
+type DB interface {
+	ExecContext(context.Context, string, ...interface{}) (sql.Result, error)
+	QueryContext(context.Context, string, ...interface{}) (*sql.Rows, error)
+}
+
+//go:embed migrations/mysql/*.sql migrations/sqlite/*.sql
+var embedded embed.FS
+
+// 1つのマイグレーション
+type Migration struct {
+	// 適用する順序 (例: 20261018180000)
+	Version string
+	Name    string
+	up      string
+	down    string
+}
+
+// マイグレーションの適用状況
+type Status struct {
+	*Migration
+	Applied bool
+}
+
+// ファイル名 (例: 20261018180000_create_tables.up.sql)
+var fileName = regexp.MustCompile(`^(\d+)_(\w+)\.(up|down)\.sql$`)
+
+// fsys の直下のファイルをバージョン順に読み込む
+// 全てのバージョンに up と down の両方が必要
+func Load(fsys fs.FS) ([]*Migration, error) {
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, fmt.Errorf("repository/migration.Load: %w", err)
+	}
+
+	versions := map[string]*Migration{}
+	for _, entry := range entries {
+		m := fileName.FindStringSubmatch(entry.Name())
+		if entry.IsDir() || m == nil {
+			return nil, fmt.Errorf("repository/migration.Load: invalid file name: %s", entry.Name())
+		}
+
+		b, err := fs.ReadFile(fsys, entry.Name())
+		if err != nil {
+			return nil, fmt.Errorf("repository/migration.Load: %w", err)
+		}
+
+		migration, ok := versions[m[1]]
+		if !ok {
+			migration = &Migration{Version: m[1], Name: m[2]}
+			versions[m[1]] = migration
+		}
+
+		if migration.Name != m[2] {
+			return nil, fmt.Errorf("repository/migration.Load: duplicate version: %s", m[1])
+		}
+
+		if m[3] == "up" {
+			migration.up = string(b)
+		} else {
+			migration.down = string(b)
+		}
+	}
+
+	list := []*Migration{}
+	for _, migration := range versions {
+		if migration.up == "" || migration.down == "" {
+			return nil, fmt.Errorf("repository/migration.Load: up and down are required: %s_%s", migration.Version, migration.Name)
+		}
+		list = append(list, migration)
+	}
+
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].Version < list[j].Version
+	})
+	return list, nil
+}
+
+// 文ごとに分割する
+// 行頭の -- はコメントとして除き、 ; で区切る
+func statements(script string) []string {
+	lines := []string{}
+	for _, line := range strings.Split(script, "\n") {
+		if !strings.HasPrefix(strings.TrimSpace(line), "--") {
+			lines = append(lines, line)
+		}
+	}
+
+	list := []string{}
+	for _, s := range strings.Split(strings.Join(lines, "\n"), ";") {
+		if s = strings.TrimSpace(s); s != "" {
+			list = append(list, s)
+		}
+	}
+	return list
+}
+
+type Migrator struct {
+	db         DB
+	migrations []*Migration
+}
+
+// 埋め込んだ MySQL のマイグレーションを db に適用する
+func New(db DB) (*Migrator, error) {
+	fsys, err := fs.Sub(embedded, "migrations/mysql")
+	if err != nil {
+		return nil, fmt.Errorf("repository/migration.New: %w", err)
+	}
+
+	return NewFromFS(db, fsys)
+}
+
+// 埋め込んだ SQLite のマイグレーションを db に適用する
+// `schema_migrations` の文は SQLite でもそのまま実行できる
+func NewSQLite(db DB) (*Migrator, error) {
+	fsys, err := fs.Sub(embedded, "migrations/sqlite")
+	if err != nil {
+		return nil, fmt.Errorf("repository/migration.NewSQLite: %w", err)
+	}
+
+	return NewFromFS(db, fsys)
+}
+
+// fsys のマイグレーションを db に適用する
+func NewFromFS(db DB, fsys fs.FS) (*Migrator, error) {
+	migrations, err := Load(fsys)
+	if err != nil {
+		return nil, fmt.Errorf("repository/migration.NewFromFS: %w", err)
+	}
+
+	return &Migrator{
+		db:         db,
+		migrations: migrations,
+	}, nil
+}
+
+// 適用済みのバージョン
+// ActiveRecord が適用した、このパッケージが知らないバージョンを含む
+func (m *Migrator) applied(ctx context.Context) (map[string]bool, error) {
+	_, err := m.db.ExecContext(
+		ctx,
+		"create table if not exists `schema_migrations` (`version` varchar(255) not null, primary key (`version`))",
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := m.db.QueryContext(ctx, "select `version` from `schema_migrations`")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	versions := map[string]bool{}
+	for rows.Next() {
+		var version string
+		err := rows.Scan(&version)
+		if err != nil {
+			return nil, err
+		}
+		versions[version] = true
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return nil, err
+	}
+
+	return versions, nil
+}
+
+// DDL はトランザクションに含められないため、文を1つずつ実行する
+// 途中で失敗した場合、それまでの文は適用されたまま残る
+func (m *Migrator) exec(ctx context.Context, script string) error {
+	for _, s := range statements(script) {
+		_, err := m.db.ExecContext(ctx, s)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// バージョン順の適用状況
+func (m *Migrator) Status(ctx context.Context) ([]*Status, error) {
+	applied, err := m.applied(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("repository/migration.Migrator.Status: %w", err)
+	}
+
+	list := []*Status{}
+	for _, migration := range m.migrations {
+		list = append(list, &Status{
+			Migration: migration,
+			Applied:   applied[migration.Version],
+		})
+	}
+	return list, nil
+}
+
+// 未適用のマイグレーションをバージョン順に全て適用し、適用したものを返す
+func (m *Migrator) Up(ctx context.Context) ([]*Migration, error) {
+	applied, err := m.applied(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("repository/migration.Migrator.Up: %w", err)
+	}
+
+	list := []*Migration{}
+	for _, migration := range m.migrations {
+		if applied[migration.Version] {
+			continue
+		}
+
+		err := m.exec(ctx, migration.up)
+		if err != nil {
+			return list, fmt.Errorf("repository/migration.Migrator.Up: %s_%s: %w", migration.Version, migration.Name, err)
+		}
+
+		_, err = m.db.ExecContext(ctx, "insert into `schema_migrations`(`version`) values (?)", migration.Version)
+		if err != nil {
+			return list, fmt.Errorf("repository/migration.Migrator.Up: %s_%s: %w", migration.Version, migration.Name, err)
+		}
+
+		list = append(list, migration)
+	}
+	return list, nil
+}
+
+// 最後に適用したマイグレーションを1つ取り消し、取り消したものを返す
+// 適用済みのものがなければ nil
+func (m *Migrator) Down(ctx context.Context) (*Migration, error) {
+	applied, err := m.applied(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("repository/migration.Migrator.Down: %w", err)
+	}
+
+	for i := len(m.migrations) - 1; i >= 0; i-- {
+		migration := m.migrations[i]
+		if !applied[migration.Version] {
+			continue
+		}
+
+		err := m.exec(ctx, migration.down)
+		if err != nil {
+			return nil, fmt.Errorf("repository/migration.Migrator.Down: %s_%s: %w", migration.Version, migration.Name, err)
+		}
+
+		_, err = m.db.ExecContext(ctx, "delete from `schema_migrations` where `version`=?", migration.Version)
+		if err != nil {
+			return nil, fmt.Errorf("repository/migration.Migrator.Down: %s_%s: %w", migration.Version, migration.Name, err)
+		}
+
+		return migration, nil
+	}
+	return nil, nil
+}
@@ -0,0 +1,439 @@
+package migration
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"testing"
+	"testing/fstest"
+
+	"api.example.com/env"
+	_ "github.com/go-sql-driver/mysql"
+	_ "github.com/mattn/go-sqlite3"
+)
+
+// 2つのバージョンのマイグレーション
+func newTestFS() fstest.MapFS {
+	return fstest.MapFS{
+		"2_add_name.up.sql":       {Data: []byte("-- 名前\nalter table `items` add column `name` varchar(255);")},
+		"2_add_name.down.sql":     {Data: []byte("alter table `items` drop column `name`;")},
+		"1_create_items.up.sql":   {Data: []byte("create table `items` (`id` integer);\ncreate table `tags` (`id` integer);\n")},
+		"1_create_items.down.sql": {Data: []byte("drop table `tags`;\ndrop table `items`;\n")},
+	}
+}
+
+// 適用したバージョン
+func versions(list []*Migration) []string {
+	got := []string{}
+	for _, m := range list {
+		got = append(got, m.Version)
+	}
+	return got
+}
+
+func TestLoad(t *testing.T) {
+	type test struct {
+		name    string
+		fsys    fstest.MapFS
+		want    []string
+		wantErr bool
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.name, func(t *testing.T) {
+			list, err := Load(tt.fsys)
+			if tt.wantErr != (err != nil) {
+				t.Fatalf("want-error=%v, got=%v.", tt.wantErr, err)
+			}
+
+			if tt.wantErr {
+				return
+			}
+
+			if got := versions(list); !reflect.DeepEqual(tt.want, got) {
+				t.Fatalf("want=%v, got=%v.", tt.want, got)
+			}
+		})
+	}
+
+	tests := []*test{
+		{
+			name:    "true",
+			fsys:    newTestFS(),
+			want:    []string{"1", "2"},
+			wantErr: false,
+		},
+		{
+			name:    "empty",
+			fsys:    fstest.MapFS{},
+			want:    []string{},
+			wantErr: false,
+		},
+		func() *test {
+			fsys := newTestFS()
+			fsys["3_other.txt"] = &fstest.MapFile{Data: []byte("")}
+			return &test{
+				name:    "invalid file name",
+				fsys:    fsys,
+				wantErr: true,
+			}
+		}(),
+		func() *test {
+			fsys := newTestFS()
+			delete(fsys, "2_add_name.down.sql")
+			return &test{
+				name:    "without down",
+				fsys:    fsys,
+				wantErr: true,
+			}
+		}(),
+		func() *test {
+			fsys := newTestFS()
+			fsys["2_add_tags.up.sql"] = &fstest.MapFile{Data: []byte("select 1;")}
+			return &test{
+				name:    "duplicate version",
+				fsys:    fsys,
+				wantErr: true,
+			}
+		}(),
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+}
+
+func TestStatements(t *testing.T) {
+	script := "-- コメント\ncreate table `a` (\n  `id` integer\n);\n\n  -- コメント\ndrop table `b`;\n"
+
+	want := []string{"create table `a` (\n  `id` integer\n)", "drop table `b`"}
+	if got := statements(script); !reflect.DeepEqual(want, got) {
+		t.Fatalf("want=%q, got=%q.", want, got)
+	}
+}
+
+func TestNew(t *testing.T) {
+	m, err := New(nil)
+	if err != nil {
+		t.Fatalf("want=nil, got=%v.", err)
+	}
+
+	// 埋め込んだマイグレーションは ActiveRecord の最後のバージョンから始まる
+	if len(m.migrations) == 0 || m.migrations[0].Version != "20261018180000" {
+		t.Fatalf("want=20261018180000, got=%v.", versions(m.migrations))
+	}
+}
+
+// MySQL と SQLite は同じバージョンと名前のマイグレーションを持つ
+func TestNewSQLite(t *testing.T) {
+	mysql, err := New(nil)
+	if err != nil {
+		t.Fatalf("want=nil, got=%v.", err)
+	}
+
+	sqlite, err := NewSQLite(nil)
+	if err != nil {
+		t.Fatalf("want=nil, got=%v.", err)
+	}
+
+	names := func(list []*Migration) []string {
+		got := []string{}
+		for _, m := range list {
+			got = append(got, m.Version+"_"+m.Name)
+		}
+		return got
+	}
+
+	if want, got := names(mysql.migrations), names(sqlite.migrations); !reflect.DeepEqual(want, got) {
+		t.Fatalf("want=%v, got=%v.", want, got)
+	}
+}
+
+// SQLite のメモリ上のデータベース
+func newSQLite(t *testing.T) *sql.DB {
+	t.Helper()
+
+	db, err := sql.Open("sqlite3", "file::memory:")
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	db.SetMaxOpenConns(1)
+	t.Cleanup(func() { db.Close() })
+	return db
+}
+
+func TestMigrator(t *testing.T) {
+	ctx := context.Background()
+	m, err := NewFromFS(newSQLite(t), newTestFS())
+	if err != nil {
+		t.Fatalf("want=nil, got=%v.", err)
+	}
+
+	// 適用済みのバージョン
+	testStatus := func(t *testing.T, want []string) {
+		t.Helper()
+
+		list, err := m.Status(ctx)
+		if err != nil {
+			t.Fatalf("want=nil, got=%v.", err)
+		}
+
+		got := []string{}
+		for _, s := range list {
+			if s.Applied {
+				got = append(got, s.Version)
+			}
+		}
+
+		if !reflect.DeepEqual(want, got) {
+			t.Fatalf("want=%v, got=%v.", want, got)
+		}
+	}
+
+	testStatus(t, []string{})
+
+	list, err := m.Up(ctx)
+	if err != nil {
+		t.Fatalf("want=nil, got=%v.", err)
+	}
+
+	if got := versions(list); !reflect.DeepEqual([]string{"1", "2"}, got) {
+		t.Fatalf("want=[1 2], got=%v.", got)
+	}
+	testStatus(t, []string{"1", "2"})
+
+	// 適用済みであれば何もしない
+	list, err = m.Up(ctx)
+	if err != nil || len(list) != 0 {
+		t.Fatalf("want=[], got=%v, error=%v.", versions(list), err)
+	}
+
+	for _, want := range []string{"2", "1"} {
+		got, err := m.Down(ctx)
+		if err != nil {
+			t.Fatalf("want=nil, got=%v.", err)
+		}
+
+		if got == nil || want != got.Version {
+			t.Fatalf("want=%v, got=%v.", want, got)
+		}
+	}
+	testStatus(t, []string{})
+
+	// 適用済みのものがなければ何もしない
+	got, err := m.Down(ctx)
+	if err != nil || got != nil {
+		t.Fatalf("want=nil, got=%v, error=%v.", got, err)
+	}
+}
+
+func TestMigrator_Error(t *testing.T) {
+	ctx := context.Background()
+	fsys := newTestFS()
+	fsys["3_invalid.up.sql"] = &fstest.MapFile{Data: []byte("invalid;")}
+	fsys["3_invalid.down.sql"] = &fstest.MapFile{Data: []byte("invalid;")}
+
+	m, err := NewFromFS(newSQLite(t), fsys)
+	if err != nil {
+		t.Fatalf("want=nil, got=%v.", err)
+	}
+
+	// 失敗したマイグレーションより前のものは適用される
+	list, err := m.Up(ctx)
+	if err == nil {
+		t.Fatalf("want=error, got=nil.")
+	}
+
+	if got := versions(list); !reflect.DeepEqual([]string{"1", "2"}, got) {
+		t.Fatalf("want=[1 2], got=%v.", got)
+	}
+
+	status, err := m.Status(ctx)
+	if err != nil {
+		t.Fatalf("want=nil, got=%v.", err)
+	}
+
+	if status[2].Applied {
+		t.Fatalf("want=not applied, got=%v.", status[2])
+	}
+}
+
+// 埋め込んだマイグレーションを MySQL の空のデータベースに適用する
+func TestMigrator_MySQL(t *testing.T) {
+	addr := env.Get("TEST_DB_ADDR")
+	name := env.Get("TEST_DB_NAME")
+	user := env.Get("TEST_DB_USER")
+	password := env.GetSecure("TEST_DB_PASSWORD")
+
+	open := func(name string) *sql.DB {
+		dsn := fmt.Sprintf(
+			"%s:%s@(%s)/%s?charset=utf8mb4&parseTime=true",
+			user.Value(),
+			password.Value(),
+			addr.Value(),
+			name,
+		)
+		db, err := sql.Open("mysql", dsn)
+		if err != nil {
+			t.Fatalf("open: %v", err)
+		}
+		t.Cleanup(func() { db.Close() })
+		return db
+	}
+
+	ctx := context.Background()
+	database := name.Value() + "_migration"
+	admin := open(name.Value())
+	_, err := admin.ExecContext(ctx, "create database if not exists `"+database+"`")
+	if err != nil {
+		t.Fatalf("create database: %v", err)
+	}
+	t.Cleanup(func() {
+		admin.ExecContext(ctx, "drop database if exists `"+database+"`")
+	})
+
+	db := open(database)
+	m, err := New(db)
+	if err != nil {
+		t.Fatalf("want=nil, got=%v.", err)
+	}
+
+	list, err := m.Up(ctx)
+	if err != nil {
+		t.Fatalf("want=nil, got=%v.", err)
+	}
+
+	if len(list) != len(m.migrations) {
+		t.Fatalf("want=%v, got=%v.", versions(m.migrations), versions(list))
+	}
+
+	// 作成した表を使える
+	_, err = db.ExecContext(ctx, "insert into `users`(`name`, `password`, `created_at`, `updated_at`) values ('bob', '', now(), now())")
+	if err != nil {
+		t.Fatalf("want=nil, got=%v.", err)
+	}
+
+	for range m.migrations {
+		_, err := m.Down(ctx)
+		if err != nil {
+			t.Fatalf("want=nil, got=%v.", err)
+		}
+	}
+
+	_, err = db.ExecContext(ctx, "select 1 from `users`")
+	if err == nil {
+		t.Fatalf("want=error, got=nil.")
+	}
+}
+
+// MySQL のマイグレーションで作成する表と索引の名前
+var (
+	mysqlTable = regexp.MustCompile("(?i)create table `(\\w+)`")
+	mysqlIndex = regexp.MustCompile("(?i)key `(\\w+)`")
+)
+
+// SQLite で作成した表または索引の名前
+func sqliteNames(t *testing.T, db *sql.DB, kind string) []string {
+	t.Helper()
+
+	rows, err := db.Query(`select "name" from "sqlite_master" where "type"=? and "name" not like 'sqlite_%' and "name"<>'schema_migrations' order by "name"`, kind)
+	if err != nil {
+		t.Fatalf("want=nil, got=%v.", err)
+	}
+	defer rows.Close()
+
+	got := []string{}
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			t.Fatalf("want=nil, got=%v.", err)
+		}
+		got = append(got, name)
+	}
+	return got
+}
+
+// 埋め込んだマイグレーションを SQLite の空のデータベースに適用する
+// MySQL と同じ表と索引を作成する
+func TestMigrator_SQLite(t *testing.T) {
+	ctx := context.Background()
+	db := newSQLite(t)
+	m, err := NewSQLite(db)
+	if err != nil {
+		t.Fatalf("want=nil, got=%v.", err)
+	}
+
+	list, err := m.Up(ctx)
+	if err != nil {
+		t.Fatalf("want=nil, got=%v.", err)
+	}
+
+	if len(list) != len(m.migrations) {
+		t.Fatalf("want=%v, got=%v.", versions(m.migrations), versions(list))
+	}
+
+	mysql, err := New(nil)
+	if err != nil {
+		t.Fatalf("want=nil, got=%v.", err)
+	}
+
+	wantTables, wantIndexes := []string{}, []string{}
+	for _, migration := range mysql.migrations {
+		for _, match := range mysqlTable.FindAllStringSubmatch(migration.up, -1) {
+			wantTables = append(wantTables, match[1])
+		}
+		for _, match := range mysqlIndex.FindAllStringSubmatch(migration.up, -1) {
+			wantIndexes = append(wantIndexes, match[1])
+		}
+	}
+	sort.Strings(wantTables)
+	sort.Strings(wantIndexes)
+
+	if got := sqliteNames(t, db, "table"); !reflect.DeepEqual(wantTables, got) {
+		t.Fatalf("want=%v, got=%v.", wantTables, got)
+	}
+
+	if got := sqliteNames(t, db, "index"); !reflect.DeepEqual(wantIndexes, got) {
+		t.Fatalf("want=%v, got=%v.", wantIndexes, got)
+	}
+
+	for range m.migrations {
+		_, err := m.Down(ctx)
+		if err != nil {
+			t.Fatalf("want=nil, got=%v.", err)
+		}
+	}
+
+	if got := sqliteNames(t, db, "table"); len(got) != 0 {
+		t.Fatalf("want=[], got=%v.", got)
+	}
+}
+
+// マイグレーションを記録する前に起動時に作成した表を引き継ぐ
+func TestMigrator_SQLiteExisting(t *testing.T) {
+	ctx := context.Background()
+	db := newSQLite(t)
+	m, err := NewSQLite(db)
+	if err != nil {
+		t.Fatalf("want=nil, got=%v.", err)
+	}
+
+	// 索引の一部が欠けた以前の表
+	_, err = db.ExecContext(ctx, "create table \"users\" (\"id\" integer primary key autoincrement, \"name\" varchar(255) not null collate nocase, \"password\" varchar(255) not null, \"created_at\" datetime not null, \"updated_at\" datetime not null)")
+	if err != nil {
+		t.Fatalf("want=nil, got=%v.", err)
+	}
+
+	_, err = m.Up(ctx)
+	if err != nil {
+		t.Fatalf("want=nil, got=%v.", err)
+	}
+
+	_, err = db.ExecContext(ctx, "select 1 from \"department_paths\" indexed by \"index_department_paths_on_ancestor_id\"")
+	if err != nil {
+		t.Fatalf("want=nil, got=%v.", err)
+	}
+}
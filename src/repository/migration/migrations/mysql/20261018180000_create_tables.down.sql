@@ -0,0 +1,13 @@
+-- 参照する表から削除する
+
+DROP TABLE `api_keys`;
+DROP TABLE `sessions`;
+DROP TABLE `employee_permissions`;
+DROP TABLE `employee_roles`;
+DROP TABLE `department_paths`;
+DROP TABLE `departments`;
+DROP TABLE `company_roles`;
+DROP TABLE `roles`;
+DROP TABLE `company_employees`;
+DROP TABLE `companies`;
+DROP TABLE `users`;
@@ -0,0 +1,132 @@
+-- ActiveRecord の db/schema.rb (version 2026_10_18_180000) と同じ表
+
+CREATE TABLE `users` (
+  `id` bigint NOT NULL AUTO_INCREMENT PRIMARY KEY,
+  `name` varchar(255) NOT NULL,
+  `password` varchar(255) NOT NULL,
+  `created_at` datetime(6) NOT NULL,
+  `updated_at` datetime(6) NOT NULL,
+  UNIQUE KEY `index_users_on_name` (`name`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
+
+CREATE TABLE `companies` (
+  `id` bigint NOT NULL AUTO_INCREMENT PRIMARY KEY,
+  `name` varchar(255) NOT NULL,
+  `created_at` datetime(6) NOT NULL,
+  `updated_at` datetime(6) NOT NULL,
+  `owner_id` bigint DEFAULT NULL,
+  UNIQUE KEY `index_companies_on_name` (`name`),
+  KEY `index_companies_on_owner_id` (`owner_id`),
+  CONSTRAINT `fk_companies_owner_id` FOREIGN KEY (`owner_id`) REFERENCES `users` (`id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
+
+CREATE TABLE `company_employees` (
+  `id` bigint NOT NULL AUTO_INCREMENT PRIMARY KEY,
+  `company_id` bigint DEFAULT NULL,
+  `user_id` bigint DEFAULT NULL,
+  `created_at` datetime(6) NOT NULL,
+  `updated_at` datetime(6) NOT NULL,
+  `administrator` tinyint(1) NOT NULL DEFAULT '0',
+  UNIQUE KEY `index_company_employees_on_company_id_and_user_id` (`company_id`,`user_id`),
+  KEY `index_company_employees_on_company_id` (`company_id`),
+  KEY `index_company_employees_on_user_id` (`user_id`),
+  CONSTRAINT `fk_company_employees_company_id` FOREIGN KEY (`company_id`) REFERENCES `companies` (`id`) ON DELETE CASCADE,
+  CONSTRAINT `fk_company_employees_user_id` FOREIGN KEY (`user_id`) REFERENCES `users` (`id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
+
+CREATE TABLE `roles` (
+  `id` bigint NOT NULL AUTO_INCREMENT PRIMARY KEY,
+  `name` varchar(255) NOT NULL,
+  `created_at` datetime(6) NOT NULL,
+  `updated_at` datetime(6) NOT NULL,
+  UNIQUE KEY `index_roles_on_name` (`name`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
+
+CREATE TABLE `company_roles` (
+  `id` bigint NOT NULL AUTO_INCREMENT PRIMARY KEY,
+  `company_id` bigint DEFAULT NULL,
+  `role_id` bigint DEFAULT NULL,
+  `created_at` datetime(6) NOT NULL,
+  `updated_at` datetime(6) NOT NULL,
+  UNIQUE KEY `index_company_roles_on_company_id_and_role_id` (`company_id`,`role_id`),
+  KEY `index_company_roles_on_company_id` (`company_id`),
+  KEY `index_company_roles_on_role_id` (`role_id`),
+  CONSTRAINT `fk_company_roles_company_id` FOREIGN KEY (`company_id`) REFERENCES `companies` (`id`) ON DELETE CASCADE,
+  CONSTRAINT `fk_company_roles_role_id` FOREIGN KEY (`role_id`) REFERENCES `roles` (`id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
+
+CREATE TABLE `departments` (
+  `id` bigint NOT NULL AUTO_INCREMENT PRIMARY KEY,
+  `company_id` bigint NOT NULL,
+  `parent_id` bigint DEFAULT NULL,
+  `name` varchar(255) NOT NULL,
+  `created_at` datetime(6) NOT NULL,
+  `updated_at` datetime(6) NOT NULL,
+  KEY `index_departments_on_company_id` (`company_id`),
+  KEY `index_departments_on_parent_id` (`parent_id`),
+  CONSTRAINT `fk_departments_company_id` FOREIGN KEY (`company_id`) REFERENCES `companies` (`id`) ON DELETE CASCADE,
+  CONSTRAINT `fk_departments_parent_id` FOREIGN KEY (`parent_id`) REFERENCES `departments` (`id`) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
+
+CREATE TABLE `department_paths` (
+  `ancestor_id` bigint NOT NULL,
+  `descendant_id` bigint NOT NULL,
+  `depth` int NOT NULL,
+  UNIQUE KEY `index_department_paths_on_ancestor_id_and_descendant_id` (`ancestor_id`,`descendant_id`),
+  KEY `index_department_paths_on_ancestor_id` (`ancestor_id`),
+  KEY `index_department_paths_on_descendant_id` (`descendant_id`),
+  CONSTRAINT `fk_department_paths_ancestor_id` FOREIGN KEY (`ancestor_id`) REFERENCES `departments` (`id`) ON DELETE CASCADE,
+  CONSTRAINT `fk_department_paths_descendant_id` FOREIGN KEY (`descendant_id`) REFERENCES `departments` (`id`) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
+
+CREATE TABLE `employee_roles` (
+  `id` bigint NOT NULL AUTO_INCREMENT PRIMARY KEY,
+  `company_employee_id` bigint DEFAULT NULL,
+  `company_role_id` bigint DEFAULT NULL,
+  `created_at` datetime(6) NOT NULL,
+  `updated_at` datetime(6) NOT NULL,
+  `department_id` bigint NOT NULL,
+  UNIQUE KEY `index_employee_roles_on_employee_and_department_and_role` (`company_employee_id`,`department_id`,`company_role_id`),
+  KEY `index_employee_roles_on_company_employee_id` (`company_employee_id`),
+  KEY `index_employee_roles_on_company_role_id` (`company_role_id`),
+  KEY `index_employee_roles_on_department_id` (`department_id`),
+  CONSTRAINT `fk_employee_roles_company_employee_id` FOREIGN KEY (`company_employee_id`) REFERENCES `company_employees` (`id`) ON DELETE CASCADE,
+  CONSTRAINT `fk_employee_roles_company_role_id` FOREIGN KEY (`company_role_id`) REFERENCES `company_roles` (`id`) ON DELETE CASCADE,
+  CONSTRAINT `fk_employee_roles_department_id` FOREIGN KEY (`department_id`) REFERENCES `departments` (`id`) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
+
+CREATE TABLE `employee_permissions` (
+  `id` bigint NOT NULL AUTO_INCREMENT PRIMARY KEY,
+  `company_employee_id` bigint NOT NULL,
+  `permission` varchar(255) NOT NULL,
+  `created_at` datetime(6) NOT NULL,
+  `updated_at` datetime(6) NOT NULL,
+  UNIQUE KEY `index_employee_permissions_on_company_employee_id_and_permission` (`company_employee_id`,`permission`),
+  KEY `index_employee_permissions_on_company_employee_id` (`company_employee_id`),
+  CONSTRAINT `fk_employee_permissions_company_employee_id` FOREIGN KEY (`company_employee_id`) REFERENCES `company_employees` (`id`) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
+
+CREATE TABLE `sessions` (
+  `id` bigint NOT NULL AUTO_INCREMENT PRIMARY KEY,
+  `digest` varchar(64) NOT NULL,
+  `user_id` bigint NOT NULL,
+  `expires_at` datetime(6) NOT NULL,
+  `created_at` datetime(6) NOT NULL,
+  `updated_at` datetime(6) NOT NULL,
+  UNIQUE KEY `index_sessions_on_digest` (`digest`),
+  KEY `index_sessions_on_expires_at` (`expires_at`),
+  KEY `index_sessions_on_user_id` (`user_id`),
+  CONSTRAINT `fk_sessions_user_id` FOREIGN KEY (`user_id`) REFERENCES `users` (`id`) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
+
+CREATE TABLE `api_keys` (
+  `id` bigint NOT NULL AUTO_INCREMENT PRIMARY KEY,
+  `company_id` bigint NOT NULL,
+  `name` varchar(255) NOT NULL,
+  `secret` varchar(255) NOT NULL,
+  `scopes` varchar(255) NOT NULL,
+  `created_at` datetime(6) NOT NULL,
+  `updated_at` datetime(6) NOT NULL,
+  KEY `index_api_keys_on_company_id` (`company_id`),
+  CONSTRAINT `fk_api_keys_company_id` FOREIGN KEY (`company_id`) REFERENCES `companies` (`id`) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
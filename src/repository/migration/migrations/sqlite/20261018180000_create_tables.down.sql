@@ -0,0 +1,13 @@
+-- 参照する表から削除する
+
+drop table "api_keys";
+drop table "sessions";
+drop table "employee_permissions";
+drop table "employee_roles";
+drop table "department_paths";
+drop table "departments";
+drop table "company_roles";
+drop table "roles";
+drop table "company_employees";
+drop table "companies";
+drop table "users";
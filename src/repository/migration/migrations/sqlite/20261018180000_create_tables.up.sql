@@ -0,0 +1,119 @@
+-- mysql/20261018180000_create_tables.up.sql と同じ表と索引
+-- 以前の起動時に作成した表を引き継ぐため、既に表や索引があれば作成しない
+-- 名前は MySQL の照合順序 (utf8mb4_0900_ai_ci) と同じく大文字と小文字を区別しない (nocase)
+
+create table if not exists "users" (
+  "id" integer primary key autoincrement,
+  "name" varchar(255) not null collate nocase,
+  "password" varchar(255) not null,
+  "created_at" datetime not null,
+  "updated_at" datetime not null
+);
+create unique index if not exists "index_users_on_name" on "users"("name");
+
+create table if not exists "companies" (
+  "id" integer primary key autoincrement,
+  "name" varchar(255) not null collate nocase,
+  "created_at" datetime not null,
+  "updated_at" datetime not null,
+  "owner_id" bigint references "users"("id")
+);
+create unique index if not exists "index_companies_on_name" on "companies"("name");
+create index if not exists "index_companies_on_owner_id" on "companies"("owner_id");
+
+create table if not exists "company_employees" (
+  "id" integer primary key autoincrement,
+  "company_id" bigint references "companies"("id") on delete cascade,
+  "user_id" bigint references "users"("id"),
+  "created_at" datetime not null,
+  "updated_at" datetime not null,
+  "administrator" boolean not null default false
+);
+create unique index if not exists "index_company_employees_on_company_id_and_user_id" on "company_employees"("company_id", "user_id");
+create index if not exists "index_company_employees_on_company_id" on "company_employees"("company_id");
+create index if not exists "index_company_employees_on_user_id" on "company_employees"("user_id");
+
+create table if not exists "roles" (
+  "id" integer primary key autoincrement,
+  "name" varchar(255) not null collate nocase,
+  "created_at" datetime not null,
+  "updated_at" datetime not null
+);
+create unique index if not exists "index_roles_on_name" on "roles"("name");
+
+create table if not exists "company_roles" (
+  "id" integer primary key autoincrement,
+  "company_id" bigint references "companies"("id") on delete cascade,
+  "role_id" bigint references "roles"("id"),
+  "created_at" datetime not null,
+  "updated_at" datetime not null
+);
+create unique index if not exists "index_company_roles_on_company_id_and_role_id" on "company_roles"("company_id", "role_id");
+create index if not exists "index_company_roles_on_company_id" on "company_roles"("company_id");
+create index if not exists "index_company_roles_on_role_id" on "company_roles"("role_id");
+
+create table if not exists "departments" (
+  "id" integer primary key autoincrement,
+  "company_id" bigint not null references "companies"("id") on delete cascade,
+  "parent_id" bigint references "departments"("id") on delete cascade,
+  "name" varchar(255) not null,
+  "created_at" datetime not null,
+  "updated_at" datetime not null
+);
+create index if not exists "index_departments_on_company_id" on "departments"("company_id");
+create index if not exists "index_departments_on_parent_id" on "departments"("parent_id");
+
+create table if not exists "department_paths" (
+  "ancestor_id" bigint not null references "departments"("id") on delete cascade,
+  "descendant_id" bigint not null references "departments"("id") on delete cascade,
+  "depth" integer not null
+);
+create unique index if not exists "index_department_paths_on_ancestor_id_and_descendant_id" on "department_paths"("ancestor_id", "descendant_id");
+create index if not exists "index_department_paths_on_ancestor_id" on "department_paths"("ancestor_id");
+create index if not exists "index_department_paths_on_descendant_id" on "department_paths"("descendant_id");
+
+create table if not exists "employee_roles" (
+  "id" integer primary key autoincrement,
+  "company_employee_id" bigint references "company_employees"("id") on delete cascade,
+  "company_role_id" bigint references "company_roles"("id") on delete cascade,
+  "created_at" datetime not null,
+  "updated_at" datetime not null,
+  "department_id" bigint not null references "departments"("id") on delete cascade
+);
+create unique index if not exists "index_employee_roles_on_employee_and_department_and_role" on "employee_roles"("company_employee_id", "department_id", "company_role_id");
+create index if not exists "index_employee_roles_on_company_employee_id" on "employee_roles"("company_employee_id");
+create index if not exists "index_employee_roles_on_company_role_id" on "employee_roles"("company_role_id");
+create index if not exists "index_employee_roles_on_department_id" on "employee_roles"("department_id");
+
+create table if not exists "employee_permissions" (
+  "id" integer primary key autoincrement,
+  "company_employee_id" bigint not null references "company_employees"("id") on delete cascade,
+  "permission" varchar(255) not null,
+  "created_at" datetime not null,
+  "updated_at" datetime not null
+);
+create unique index if not exists "index_employee_permissions_on_company_employee_id_and_permission" on "employee_permissions"("company_employee_id", "permission");
+create index if not exists "index_employee_permissions_on_company_employee_id" on "employee_permissions"("company_employee_id");
+
+create table if not exists "sessions" (
+  "id" integer primary key autoincrement,
+  "digest" varchar(64) not null,
+  "user_id" bigint not null references "users"("id") on delete cascade,
+  "expires_at" datetime not null,
+  "created_at" datetime not null,
+  "updated_at" datetime not null
+);
+create unique index if not exists "index_sessions_on_digest" on "sessions"("digest");
+create index if not exists "index_sessions_on_expires_at" on "sessions"("expires_at");
+create index if not exists "index_sessions_on_user_id" on "sessions"("user_id");
+
+create table if not exists "api_keys" (
+  "id" integer primary key autoincrement,
+  "company_id" bigint not null references "companies"("id") on delete cascade,
+  "name" varchar(255) not null,
+  "secret" varchar(255) not null,
+  "scopes" varchar(255) not null,
+  "created_at" datetime not null,
+  "updated_at" datetime not null
+);
+create index if not exists "index_api_keys_on_company_id" on "api_keys"("company_id");
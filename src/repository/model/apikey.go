@@ -0,0 +1,251 @@
+package model
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	apikeys "api.example.com/pkg/apikey"
+	"api.example.com/pkg/failure"
+	"api.example.com/pkg/user/password"
+)
+
+// スコープはカンマ区切りで保存する
+func joinScopes(list []apikeys.Scope) string {
+	s := make([]string, 0, len(list))
+	for _, v := range list {
+		s = append(s, string(v))
+	}
+	return strings.Join(s, ",")
+}
+
+func splitScopes(s string) []apikeys.Scope {
+	list := []apikeys.Scope{}
+	if s == "" {
+		return list
+	}
+
+	for _, v := range strings.Split(s, ",") {
+		list = append(list, apikeys.Scope(v))
+	}
+	return list
+}
+
+type APIKey interface {
+	Create(context.Context, DB) error
+	Read(context.Context, DB) error
+	// 会社を問わず id で読み込む
+	ReadByID(context.Context, DB) error
+	// 秘密の値のみ更新する
+	Update(context.Context, DB) error
+	Delete(context.Context, DB) error
+	NewEntity() *apikeys.Key
+}
+
+// impl APIKey
+type apiKey struct {
+	id        apikeys.ID
+	companyID apikeys.CompanyID
+	name      apikeys.Name
+	secret    passwordHash
+	scopes    string
+	createdAt dateTime
+	updatedAt dateTime
+}
+
+func NewAPIKey(k *apikeys.Key) APIKey {
+	key := &apiKey{
+		id:        k.ID,
+		companyID: k.CompanyID,
+		name:      k.Name,
+		scopes:    joinScopes(k.Scopes),
+	}
+	if k.Secret != nil {
+		key.secret = k.Secret.Hash()
+	}
+	return key
+}
+
+func NewAPIKeyFromID(companyID apikeys.CompanyID, id apikeys.ID) APIKey {
+	return &apiKey{
+		id:        id,
+		companyID: companyID,
+	}
+}
+
+func NewAPIKeyFromKeyID(id apikeys.ID) APIKey {
+	return &apiKey{
+		id: id,
+	}
+}
+
+func (k *apiKey) Create(ctx context.Context, tx DB) error {
+	now := currentTime()
+	result, err := tx.ExecContext(
+		ctx,
+		"insert into `api_keys`(`company_id`, `name`, `secret`, `scopes`, `created_at`, `updated_at`) value (?, ?, ?, ?, ?, ?)",
+		k.companyID,
+		k.name,
+		k.secret,
+		k.scopes,
+		now,
+		now,
+	)
+	if err != nil {
+		return fmt.Errorf("repository/model.APIKey.Create: %w", err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("repository/model.APIKey.Create: %w", err)
+	}
+
+	k.id = apikeys.ID(id)
+	k.createdAt = now
+	k.updatedAt = now
+	return nil
+}
+
+func (k *apiKey) Read(ctx context.Context, tx DB) error {
+	err := tx.QueryRowContext(
+		ctx,
+		"select `name`, `secret`, `scopes`, `created_at`, `updated_at` from `api_keys` where `id`=? and `company_id`=?",
+		k.id,
+		k.companyID,
+	).Scan(&k.name, &k.secret, &k.scopes, &k.createdAt, &k.updatedAt)
+	if err != nil {
+		return fmt.Errorf("repository/model.APIKey.Read: %w", notFound(err))
+	}
+
+	return nil
+}
+
+func (k *apiKey) ReadByID(ctx context.Context, tx DB) error {
+	err := tx.QueryRowContext(
+		ctx,
+		"select `company_id`, `name`, `secret`, `scopes`, `created_at`, `updated_at` from `api_keys` where `id`=?",
+		k.id,
+	).Scan(&k.companyID, &k.name, &k.secret, &k.scopes, &k.createdAt, &k.updatedAt)
+	if err != nil {
+		return fmt.Errorf("repository/model.APIKey.ReadByID: %w", notFound(err))
+	}
+
+	return nil
+}
+
+func (k *apiKey) Update(ctx context.Context, tx DB) error {
+	now := currentTime()
+	result, err := tx.ExecContext(
+		ctx,
+		"update `api_keys` set `secret`=?, `updated_at`=? where `id`=? and `company_id`=?",
+		k.secret,
+		now,
+		k.id,
+		k.companyID,
+	)
+	if err != nil {
+		return fmt.Errorf("repository/model.APIKey.Update: %w", err)
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("repository/model.APIKey.Update: %w", err)
+	}
+
+	if count != 1 {
+		return fmt.Errorf("repository/model.APIKey.Update: %w: rows affected not 1 (affected=%d)", failure.ErrNotFound, count)
+	}
+
+	k.updatedAt = now
+	return nil
+}
+
+func (k *apiKey) Delete(ctx context.Context, tx DB) error {
+	result, err := tx.ExecContext(
+		ctx,
+		"delete from `api_keys` where `id`=? and `company_id`=?",
+		k.id,
+		k.companyID,
+	)
+	if err != nil {
+		return fmt.Errorf("repository/model.APIKey.Delete: %w", err)
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("repository/model.APIKey.Delete: %w", err)
+	}
+
+	if count != 1 {
+		return fmt.Errorf("repository/model.APIKey.Delete: %w: rows affected not 1 (affected=%d)", failure.ErrNotFound, count)
+	}
+
+	return nil
+}
+
+func (k *apiKey) NewEntity() *apikeys.Key {
+	return &apikeys.Key{
+		ID:        k.id,
+		CompanyID: k.companyID,
+		Name:      k.name,
+		Scopes:    splitScopes(k.scopes),
+		Secret:    password.FromHash(k.secret),
+		UpdatedAt: k.updatedAt,
+	}
+}
+
+// 会社の API キーの一覧
+type APIKeys interface {
+	Read(context.Context, DB) error
+	NewEntity() []*apikeys.Key
+}
+
+// impl APIKeys
+type apiKeyList struct {
+	companyID apikeys.CompanyID
+	list      []*apiKey
+}
+
+func NewAPIKeysFromCompanyID(companyID apikeys.CompanyID) APIKeys {
+	return &apiKeyList{
+		companyID: companyID,
+	}
+}
+
+func (l *apiKeyList) Read(ctx context.Context, tx DB) error {
+	rows, err := tx.QueryContext(
+		ctx,
+		"select `id`, `name`, `secret`, `scopes`, `created_at`, `updated_at` from `api_keys` where `company_id`=? order by `id`",
+		l.companyID,
+	)
+	if err != nil {
+		return fmt.Errorf("repository/model.APIKeys.Read: %w", err)
+	}
+	defer rows.Close()
+
+	list := []*apiKey{}
+	for rows.Next() {
+		k := &apiKey{companyID: l.companyID}
+		err = rows.Scan(&k.id, &k.name, &k.secret, &k.scopes, &k.createdAt, &k.updatedAt)
+		if err != nil {
+			return fmt.Errorf("repository/model.APIKeys.Read: %w", err)
+		}
+		list = append(list, k)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return fmt.Errorf("repository/model.APIKeys.Read: %w", err)
+	}
+
+	l.list = list
+	return nil
+}
+
+func (l *apiKeyList) NewEntity() []*apikeys.Key {
+	list := make([]*apikeys.Key, 0, len(l.list))
+	for _, k := range l.list {
+		list = append(list, k.NewEntity())
+	}
+	return list
+}
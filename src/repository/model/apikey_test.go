@@ -0,0 +1,402 @@
+package model
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	apikeys "api.example.com/pkg/apikey"
+	"api.example.com/pkg/user/password"
+)
+
+func createAPIKey(db DB, k *apikeys.Key) *apiKey {
+	key := NewAPIKey(k).(*apiKey)
+	err := key.Create(context.Background(), db)
+	if err != nil {
+		panic(err)
+	}
+
+	return key
+}
+
+func newSecret(plain string) *apikeys.Key {
+	hash, err := password.New(plain)
+	if err != nil {
+		panic(err)
+	}
+
+	return &apikeys.Key{Secret: hash}
+}
+
+// 比較のため UpdatedAt と Secret を除いた entity
+func apiKeyEntity(k APIKey) *apikeys.Key {
+	entity := k.NewEntity()
+	entity.UpdatedAt = time.Time{}
+	entity.Secret = nil
+	return entity
+}
+
+func TestJoinScopes(t *testing.T) {
+	list := []apikeys.Scope{apikeys.ScopeRead, apikeys.ScopeManageUsers}
+	got := joinScopes(list)
+	if got != "read,manage_users" {
+		t.Fatalf("want=%v, got=%v.", "read,manage_users", got)
+	}
+
+	if !reflect.DeepEqual(list, splitScopes(got)) {
+		t.Fatalf("want=%v, got=%v.", list, splitScopes(got))
+	}
+
+	if !reflect.DeepEqual([]apikeys.Scope{}, splitScopes("")) {
+		t.Fatalf("want empty, got=%v.", splitScopes(""))
+	}
+}
+
+func TestNewAPIKey(t *testing.T) {
+	k := newSecret("secret")
+	k.ID, k.CompanyID, k.Name, k.Scopes = 2, 1, "batch", []apikeys.Scope{apikeys.ScopeRead, apikeys.ScopeManageUsers}
+
+	got := NewAPIKey(k)
+	want := &apiKey{id: 2, companyID: 1, name: "batch", secret: k.Secret.Hash(), scopes: "read,manage_users"}
+	if !reflect.DeepEqual(want, got) {
+		t.Fatalf("want=%v, got=%v.", want, got)
+	}
+}
+
+func TestNewAPIKeyFromID(t *testing.T) {
+	got := NewAPIKeyFromID(1, 2)
+	want := &apiKey{id: 2, companyID: 1}
+	if !reflect.DeepEqual(want, got) {
+		t.Fatalf("want=%v, got=%v.", want, got)
+	}
+}
+
+func TestAPIKey_Create(t *testing.T) {
+	tableLock.Lock()
+	defer tableLock.Unlock()
+
+	db := newDB()
+	defer db.Close()
+	defer db.Exec("delete from users")
+	defer db.Exec("delete from companies")
+
+	companyID := createCompany(db, "COMPANY")
+
+	type test struct {
+		name    string
+		key     *apiKey
+		wantErr bool
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.key.Create(context.Background(), db)
+			if tt.wantErr != (err != nil) {
+				t.Fatalf("want-error=%v, error=%v.", tt.wantErr, err)
+			}
+
+			if tt.wantErr {
+				return
+			}
+
+			testDiffTime(t, time.Now(), tt.key.updatedAt)
+
+			got := NewAPIKeyFromID(companyID, tt.key.id)
+			err = got.Read(context.Background(), db)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !reflect.DeepEqual(apiKeyEntity(tt.key), apiKeyEntity(got)) {
+				t.Fatalf("want=%v, got=%v.", apiKeyEntity(tt.key), apiKeyEntity(got))
+			}
+
+			if !got.NewEntity().Secret.Verify("secret") {
+				t.Fatal("secret is not stored")
+			}
+		})
+	}
+
+	k := newSecret("secret")
+	k.CompanyID, k.Name, k.Scopes = companyID, "batch", []apikeys.Scope{apikeys.ScopeRead}
+	other := newSecret("secret")
+	other.CompanyID, other.Name, other.Scopes = companyID+100, "batch", []apikeys.Scope{apikeys.ScopeRead}
+
+	tests := []*test{
+		{
+			name:    "ok",
+			key:     NewAPIKey(k).(*apiKey),
+			wantErr: false,
+		},
+		{
+			name:    "company not found",
+			key:     NewAPIKey(other).(*apiKey),
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+}
+
+func TestAPIKey_Read(t *testing.T) {
+	tableLock.Lock()
+	defer tableLock.Unlock()
+
+	db := newDB()
+	defer db.Close()
+	defer db.Exec("delete from users")
+	defer db.Exec("delete from companies")
+
+	companyA := createCompany(db, "COMPANY A")
+	companyB := createCompany(db, "COMPANY B")
+	k := newSecret("secret")
+	k.CompanyID, k.Name, k.Scopes = companyA, "batch", []apikeys.Scope{apikeys.ScopeManageCompany}
+	key := createAPIKey(db, k)
+
+	type test struct {
+		name    string
+		read    func(APIKey) error
+		key     APIKey
+		want    *apikeys.Key
+		wantErr bool
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.read(tt.key)
+			if tt.wantErr != (err != nil) {
+				t.Fatalf("want-error=%v, error=%v.", tt.wantErr, err)
+			}
+
+			if tt.wantErr {
+				return
+			}
+
+			if !reflect.DeepEqual(tt.want, apiKeyEntity(tt.key)) {
+				t.Fatalf("want=%v, got=%v.", tt.want, apiKeyEntity(tt.key))
+			}
+		})
+	}
+
+	read := func(k APIKey) error { return k.Read(context.Background(), db) }
+	readByID := func(k APIKey) error { return k.ReadByID(context.Background(), db) }
+
+	tests := []*test{
+		{
+			name:    "ok",
+			read:    read,
+			key:     NewAPIKeyFromID(companyA, key.id),
+			want:    apiKeyEntity(key),
+			wantErr: false,
+		},
+		{
+			name:    "other company",
+			read:    read,
+			key:     NewAPIKeyFromID(companyB, key.id),
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "by id",
+			read:    readByID,
+			key:     NewAPIKeyFromKeyID(key.id),
+			want:    apiKeyEntity(key),
+			wantErr: false,
+		},
+		{
+			name:    "by id not found",
+			read:    readByID,
+			key:     NewAPIKeyFromKeyID(key.id + 100),
+			want:    nil,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+}
+
+func TestAPIKey_Update(t *testing.T) {
+	tableLock.Lock()
+	defer tableLock.Unlock()
+
+	db := newDB()
+	defer db.Close()
+	defer db.Exec("delete from users")
+	defer db.Exec("delete from companies")
+
+	companyA := createCompany(db, "COMPANY A")
+	companyB := createCompany(db, "COMPANY B")
+	k := newSecret("secret")
+	k.CompanyID, k.Name, k.Scopes = companyA, "batch", []apikeys.Scope{apikeys.ScopeRead}
+	key := createAPIKey(db, k)
+
+	type test struct {
+		name    string
+		key     *apikeys.Key
+		wantErr bool
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.name, func(t *testing.T) {
+			err := NewAPIKey(tt.key).Update(context.Background(), db)
+			if tt.wantErr != (err != nil) {
+				t.Fatalf("want-error=%v, error=%v.", tt.wantErr, err)
+			}
+
+			if tt.wantErr {
+				return
+			}
+
+			got := NewAPIKeyFromID(companyA, key.id)
+			err = got.Read(context.Background(), db)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !got.NewEntity().Secret.Verify("rotated") {
+				t.Fatal("secret is not updated")
+			}
+		})
+	}
+
+	rotated := newSecret("rotated")
+	rotated.ID, rotated.CompanyID = key.id, companyA
+	other := newSecret("rotated")
+	other.ID, other.CompanyID = key.id, companyB
+
+	tests := []*test{
+		{
+			name:    "other company",
+			key:     other,
+			wantErr: true,
+		},
+		{
+			name:    "ok",
+			key:     rotated,
+			wantErr: false,
+		},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+}
+
+func TestAPIKey_Delete(t *testing.T) {
+	tableLock.Lock()
+	defer tableLock.Unlock()
+
+	db := newDB()
+	defer db.Close()
+	defer db.Exec("delete from users")
+	defer db.Exec("delete from companies")
+
+	companyA := createCompany(db, "COMPANY A")
+	companyB := createCompany(db, "COMPANY B")
+	k := newSecret("secret")
+	k.CompanyID, k.Name, k.Scopes = companyA, "batch", []apikeys.Scope{apikeys.ScopeRead}
+	key := createAPIKey(db, k)
+
+	type test struct {
+		name    string
+		key     APIKey
+		wantErr bool
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.key.Delete(context.Background(), db)
+			if tt.wantErr != (err != nil) {
+				t.Fatalf("want-error=%v, error=%v.", tt.wantErr, err)
+			}
+		})
+	}
+
+	tests := []*test{
+		{
+			name:    "other company",
+			key:     NewAPIKeyFromID(companyB, key.id),
+			wantErr: true,
+		},
+		{
+			name:    "ok",
+			key:     NewAPIKeyFromID(companyA, key.id),
+			wantErr: false,
+		},
+		{
+			name:    "not found",
+			key:     NewAPIKeyFromID(companyA, key.id),
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+}
+
+func TestAPIKeys_Read(t *testing.T) {
+	tableLock.Lock()
+	defer tableLock.Unlock()
+
+	db := newDB()
+	defer db.Close()
+	defer db.Exec("delete from users")
+	defer db.Exec("delete from companies")
+
+	companyA := createCompany(db, "COMPANY A")
+	companyB := createCompany(db, "COMPANY B")
+	k := newSecret("secret")
+	k.CompanyID, k.Name, k.Scopes = companyA, "batch", []apikeys.Scope{apikeys.ScopeRead}
+	first := createAPIKey(db, k)
+	k.Name = "sync"
+	second := createAPIKey(db, k)
+
+	type test struct {
+		name string
+		list APIKeys
+		want []*apikeys.Key
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.list.Read(context.Background(), db)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got := []*apikeys.Key{}
+			for _, k := range tt.list.NewEntity() {
+				k.UpdatedAt = time.Time{}
+				k.Secret = nil
+				got = append(got, k)
+			}
+
+			if !reflect.DeepEqual(tt.want, got) {
+				t.Fatalf("want=%v, got=%v.", tt.want, got)
+			}
+		})
+	}
+
+	tests := []*test{
+		{
+			name: "ok",
+			list: NewAPIKeysFromCompanyID(companyA),
+			want: []*apikeys.Key{apiKeyEntity(first), apiKeyEntity(second)},
+		},
+		{
+			name: "empty",
+			list: NewAPIKeysFromCompanyID(companyB),
+			want: []*apikeys.Key{},
+		},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+}
@@ -0,0 +1,215 @@
+package model
+
+import (
+	"context"
+	"fmt"
+
+	assignments "api.example.com/pkg/assignment"
+	"api.example.com/pkg/failure"
+)
+
+// 配置は `employee_roles` に従業員、部署、肩書きの組として保存する
+// 会社は従業員(`company_employees`)から辿る
+type Assignment interface {
+	Create(context.Context, DB) error
+	Read(context.Context, DB) error
+	Delete(context.Context, DB) error
+	NewEntity() *assignments.Assignment
+}
+
+// impl Assignment
+type assignment struct {
+	id           assignments.ID
+	companyID    assignments.CompanyID
+	employeeID   assignments.EmployeeID
+	departmentID assignments.DepartmentID
+	roleID       assignments.RoleID
+	createdAt    dateTime
+	updatedAt    dateTime
+}
+
+func NewAssignment(a *assignments.Assignment) Assignment {
+	return &assignment{
+		id:           a.ID,
+		companyID:    a.CompanyID,
+		employeeID:   a.EmployeeID,
+		departmentID: a.DepartmentID,
+		roleID:       a.RoleID,
+	}
+}
+
+func NewAssignmentFromID(companyID assignments.CompanyID, id assignments.ID) Assignment {
+	return &assignment{
+		id:        id,
+		companyID: companyID,
+	}
+}
+
+// 作成時の制約に違反する項目 (同じ従業員、部署、肩書きの組)
+var assignmentConstraints = map[uint16]string{
+	erDupEntry: "assignment",
+}
+
+// 従業員、部署、肩書きが同じ会社に属することは呼び出し側で確認すること
+func (a *assignment) Create(ctx context.Context, tx DB) error {
+	now := currentTime()
+	result, err := tx.ExecContext(
+		ctx,
+		"insert into `employee_roles`(`company_employee_id`, `department_id`, `company_role_id`, `created_at`, `updated_at`) value (?, ?, ?, ?, ?)",
+		a.employeeID,
+		a.departmentID,
+		a.roleID,
+		now,
+		now,
+	)
+	if err != nil {
+		return fmt.Errorf("repository/model.Assignment.Create: %w", conflict(err, assignmentConstraints))
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("repository/model.Assignment.Create: %w", err)
+	}
+
+	a.id = assignments.ID(id)
+	a.createdAt = now
+	a.updatedAt = now
+	return nil
+}
+
+func (a *assignment) Read(ctx context.Context, tx DB) error {
+	err := tx.QueryRowContext(
+		ctx,
+		"select `a`.`company_employee_id`, `a`.`department_id`, `a`.`company_role_id`, `a`.`created_at`, `a`.`updated_at` from `employee_roles` `a` "+
+			"join `company_employees` `e` on `e`.`id`=`a`.`company_employee_id` "+
+			"where `a`.`id`=? and `e`.`company_id`=?",
+		a.id,
+		a.companyID,
+	).Scan(&a.employeeID, &a.departmentID, &a.roleID, &a.createdAt, &a.updatedAt)
+	if err != nil {
+		return fmt.Errorf("repository/model.Assignment.Read: %w", notFound(err))
+	}
+
+	return nil
+}
+
+func (a *assignment) Delete(ctx context.Context, tx DB) error {
+	result, err := tx.ExecContext(
+		ctx,
+		"delete `a` from `employee_roles` `a` "+
+			"join `company_employees` `e` on `e`.`id`=`a`.`company_employee_id` "+
+			"where `a`.`id`=? and `e`.`company_id`=?",
+		a.id,
+		a.companyID,
+	)
+	if err != nil {
+		return fmt.Errorf("repository/model.Assignment.Delete: %w", err)
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("repository/model.Assignment.Delete: %w", err)
+	}
+
+	if count != 1 {
+		return fmt.Errorf("repository/model.Assignment.Delete: %w: rows affected not 1 (affected=%d)", failure.ErrNotFound, count)
+	}
+
+	return nil
+}
+
+func (a *assignment) NewEntity() *assignments.Assignment {
+	return &assignments.Assignment{
+		ID:           a.id,
+		CompanyID:    a.companyID,
+		EmployeeID:   a.employeeID,
+		DepartmentID: a.departmentID,
+		RoleID:       a.roleID,
+		UpdatedAt:    a.updatedAt,
+	}
+}
+
+// 配置の一覧
+type Assignments interface {
+	Read(context.Context, DB) error
+	NewEntity() []*assignments.Assignment
+}
+
+// impl Assignments
+type assignmentList struct {
+	companyID assignments.CompanyID
+	// 追加で結合する表と、絞り込む列と値
+	join   string
+	column string
+	value  int
+	list   []*assignment
+}
+
+// 従業員の配置(兼任を含む)
+func NewAssignmentsFromEmployeeID(companyID assignments.CompanyID, employeeID assignments.EmployeeID) Assignments {
+	return &assignmentList{
+		companyID: companyID,
+		column:    "`a`.`company_employee_id`",
+		value:     int(employeeID),
+	}
+}
+
+// 部署に配置された従業員
+func NewAssignmentsFromDepartmentID(companyID assignments.CompanyID, departmentID assignments.DepartmentID) Assignments {
+	return &assignmentList{
+		companyID: companyID,
+		column:    "`a`.`department_id`",
+		value:     int(departmentID),
+	}
+}
+
+// 部署と配下の全ての部署に配置された従業員
+func NewAssignmentsFromSubtree(companyID assignments.CompanyID, departmentID assignments.DepartmentID) Assignments {
+	return &assignmentList{
+		companyID: companyID,
+		join:      "join `department_paths` `p` on `p`.`descendant_id`=`a`.`department_id` ",
+		column:    "`p`.`ancestor_id`",
+		value:     int(departmentID),
+	}
+}
+
+func (l *assignmentList) Read(ctx context.Context, tx DB) error {
+	rows, err := tx.QueryContext(
+		ctx,
+		"select `a`.`id`, `a`.`company_employee_id`, `a`.`department_id`, `a`.`company_role_id`, `a`.`created_at`, `a`.`updated_at` from `employee_roles` `a` "+
+			"join `company_employees` `e` on `e`.`id`=`a`.`company_employee_id` "+l.join+
+			"where "+l.column+"=? and `e`.`company_id`=? order by `a`.`id`",
+		l.value,
+		l.companyID,
+	)
+	if err != nil {
+		return fmt.Errorf("repository/model.Assignments.Read: %w", err)
+	}
+	defer rows.Close()
+
+	list := []*assignment{}
+	for rows.Next() {
+		a := &assignment{companyID: l.companyID}
+		err = rows.Scan(&a.id, &a.employeeID, &a.departmentID, &a.roleID, &a.createdAt, &a.updatedAt)
+		if err != nil {
+			return fmt.Errorf("repository/model.Assignments.Read: %w", err)
+		}
+		list = append(list, a)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return fmt.Errorf("repository/model.Assignments.Read: %w", err)
+	}
+
+	l.list = list
+	return nil
+}
+
+func (l *assignmentList) NewEntity() []*assignments.Assignment {
+	list := make([]*assignments.Assignment, 0, len(l.list))
+	for _, a := range l.list {
+		list = append(list, a.NewEntity())
+	}
+	return list
+}
@@ -0,0 +1,366 @@
+package model
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	assignments "api.example.com/pkg/assignment"
+	employees "api.example.com/pkg/employee"
+	"api.example.com/pkg/failure"
+	users "api.example.com/pkg/user"
+)
+
+func createEmployee(db DB, companyID employees.CompanyID, name users.Name) employees.ID {
+	employee := NewEmployee(employees.New(companyID, createOwner(db, name), false)).(*employee)
+	err := employee.Create(context.Background(), db)
+	if err != nil {
+		panic(err)
+	}
+
+	return employee.id
+}
+
+func createAssignment(db DB, a *assignments.Assignment) *assignment {
+	assignment := NewAssignment(a).(*assignment)
+	err := assignment.Create(context.Background(), db)
+	if err != nil {
+		panic(err)
+	}
+
+	return assignment
+}
+
+// 比較のため UpdatedAt を除いた entity
+func assignmentEntity(a *assignment) *assignments.Assignment {
+	entity := a.NewEntity()
+	entity.UpdatedAt = time.Time{}
+	return entity
+}
+
+func TestNewAssignment(t *testing.T) {
+	got := NewAssignment(&assignments.Assignment{ID: 5, CompanyID: 1, EmployeeID: 2, DepartmentID: 3, RoleID: 4})
+	want := &assignment{id: 5, companyID: 1, employeeID: 2, departmentID: 3, roleID: 4}
+	if !reflect.DeepEqual(want, got) {
+		t.Fatalf("want=%v, got=%v.", want, got)
+	}
+}
+
+func TestNewAssignmentFromID(t *testing.T) {
+	got := NewAssignmentFromID(1, 5)
+	want := &assignment{id: 5, companyID: 1}
+	if !reflect.DeepEqual(want, got) {
+		t.Fatalf("want=%v, got=%v.", want, got)
+	}
+}
+
+func TestAssignment_Create(t *testing.T) {
+	tableLock.Lock()
+	defer tableLock.Unlock()
+
+	db := newDB()
+	defer db.Close()
+	defer db.Exec("delete from users")
+	defer db.Exec("delete from roles")
+	defer db.Exec("delete from companies")
+
+	companyID := createCompany(db, "COMPANY")
+	employeeID := createEmployee(db, companyID, "Alice")
+	sales := createDepartment(db, companyID, 0, "営業部")
+	dev := createDepartment(db, companyID, 0, "開発部")
+	manager := createRole(db, companyID, "部長")
+
+	type test struct {
+		name       string
+		assignment *assignment
+		wantErr    bool
+		// 制約の違反であるか
+		conflict bool
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.assignment.Create(context.Background(), db)
+			if tt.wantErr != (err != nil) {
+				t.Fatalf("want-error=%v, error=%v.", tt.wantErr, err)
+			}
+
+			if tt.conflict != errors.Is(err, failure.ErrConflict) {
+				t.Fatalf("want-conflict=%v, error=%v.", tt.conflict, err)
+			}
+
+			if tt.wantErr {
+				return
+			}
+
+			testDiffTime(t, time.Now(), tt.assignment.updatedAt)
+
+			got := NewAssignmentFromID(companyID, tt.assignment.id).(*assignment)
+			err = got.Read(context.Background(), db)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !reflect.DeepEqual(assignmentEntity(tt.assignment), assignmentEntity(got)) {
+				t.Fatalf("want=%v, got=%v.", assignmentEntity(tt.assignment), assignmentEntity(got))
+			}
+		})
+	}
+
+	tests := []*test{
+		{
+			name:       "営業部長",
+			assignment: NewAssignment(assignments.New(companyID, employeeID, sales.id, manager.id)).(*assignment),
+			wantErr:    false,
+		},
+		{
+			name:       "開発部長を兼任",
+			assignment: NewAssignment(assignments.New(companyID, employeeID, dev.id, manager.id)).(*assignment),
+			wantErr:    false,
+		},
+		{
+			name:       "duplicate",
+			assignment: NewAssignment(assignments.New(companyID, employeeID, sales.id, manager.id)).(*assignment),
+			wantErr:    true,
+			conflict:   true,
+		},
+		{
+			name:       "department not found",
+			assignment: NewAssignment(assignments.New(companyID, employeeID, dev.id+100, manager.id)).(*assignment),
+			wantErr:    true,
+			conflict:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+}
+
+func TestAssignment_Read(t *testing.T) {
+	tableLock.Lock()
+	defer tableLock.Unlock()
+
+	db := newDB()
+	defer db.Close()
+	defer db.Exec("delete from users")
+	defer db.Exec("delete from roles")
+	defer db.Exec("delete from companies")
+
+	companyA := createCompany(db, "COMPANY A")
+	companyB := createCompany(db, "COMPANY B")
+	employeeID := createEmployee(db, companyA, "Alice")
+	sales := createDepartment(db, companyA, 0, "営業部")
+	manager := createRole(db, companyA, "部長")
+	a := createAssignment(db, assignments.New(companyA, employeeID, sales.id, manager.id))
+
+	type test struct {
+		name       string
+		assignment *assignment
+		want       *assignments.Assignment
+		wantErr    bool
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.assignment.Read(context.Background(), db)
+			if tt.wantErr != (err != nil) {
+				t.Fatalf("want-error=%v, error=%v.", tt.wantErr, err)
+			}
+
+			if tt.wantErr {
+				return
+			}
+
+			if !reflect.DeepEqual(tt.want, assignmentEntity(tt.assignment)) {
+				t.Fatalf("want=%v, got=%v.", tt.want, assignmentEntity(tt.assignment))
+			}
+		})
+	}
+
+	tests := []*test{
+		{
+			name:       "ok",
+			assignment: NewAssignmentFromID(companyA, a.id).(*assignment),
+			want:       assignmentEntity(a),
+			wantErr:    false,
+		},
+		{
+			name:       "other company",
+			assignment: NewAssignmentFromID(companyB, a.id).(*assignment),
+			want:       nil,
+			wantErr:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+}
+
+func TestAssignment_Delete(t *testing.T) {
+	tableLock.Lock()
+	defer tableLock.Unlock()
+
+	db := newDB()
+	defer db.Close()
+	defer db.Exec("delete from users")
+	defer db.Exec("delete from roles")
+	defer db.Exec("delete from companies")
+
+	companyA := createCompany(db, "COMPANY A")
+	companyB := createCompany(db, "COMPANY B")
+	employeeID := createEmployee(db, companyA, "Alice")
+	sales := createDepartment(db, companyA, 0, "営業部")
+	manager := createRole(db, companyA, "部長")
+	a := createAssignment(db, assignments.New(companyA, employeeID, sales.id, manager.id))
+
+	type test struct {
+		name       string
+		assignment Assignment
+		wantErr    bool
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.assignment.Delete(context.Background(), db)
+			if tt.wantErr != (err != nil) {
+				t.Fatalf("want-error=%v, error=%v.", tt.wantErr, err)
+			}
+		})
+	}
+
+	tests := []*test{
+		{
+			name:       "other company",
+			assignment: NewAssignmentFromID(companyB, a.id),
+			wantErr:    true,
+		},
+		{
+			name:       "ok",
+			assignment: NewAssignmentFromID(companyA, a.id),
+			wantErr:    false,
+		},
+		{
+			name:       "not found",
+			assignment: NewAssignmentFromID(companyA, a.id),
+			wantErr:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+}
+
+func TestAssignments_Read(t *testing.T) {
+	tableLock.Lock()
+	defer tableLock.Unlock()
+
+	db := newDB()
+	defer db.Close()
+	defer db.Exec("delete from users")
+	defer db.Exec("delete from roles")
+	defer db.Exec("delete from companies")
+
+	companyA := createCompany(db, "COMPANY A")
+	companyB := createCompany(db, "COMPANY B")
+	alice := createEmployee(db, companyA, "Alice")
+	bob := createEmployee(db, companyA, "Bob")
+	sales := createDepartment(db, companyA, 0, "営業部")
+	dev := createDepartment(db, companyA, 0, "開発部")
+	team := createDepartment(db, companyA, dev.id, "開発一課")
+	manager := createRole(db, companyA, "部長")
+	chief := createRole(db, companyA, "課長")
+
+	aliceSales := createAssignment(db, assignments.New(companyA, alice, sales.id, manager.id))
+	aliceDev := createAssignment(db, assignments.New(companyA, alice, dev.id, manager.id))
+	bobDev := createAssignment(db, assignments.New(companyA, bob, dev.id, chief.id))
+	bobTeam := createAssignment(db, assignments.New(companyA, bob, team.id, chief.id))
+
+	type test struct {
+		name    string
+		list    Assignments
+		want    []*assignments.Assignment
+		wantErr bool
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.list.Read(context.Background(), db)
+			if tt.wantErr != (err != nil) {
+				t.Fatalf("want-error=%v, error=%v.", tt.wantErr, err)
+			}
+
+			got := tt.list.NewEntity()
+			for _, a := range got {
+				a.UpdatedAt = time.Time{}
+			}
+
+			if !reflect.DeepEqual(tt.want, got) {
+				t.Fatalf("want=%v, got=%v.", tt.want, got)
+			}
+		})
+	}
+
+	tests := []*test{
+		{
+			name:    "兼任",
+			list:    NewAssignmentsFromEmployeeID(companyA, alice),
+			want:    []*assignments.Assignment{assignmentEntity(aliceSales), assignmentEntity(aliceDev)},
+			wantErr: false,
+		},
+		{
+			name:    "部署",
+			list:    NewAssignmentsFromDepartmentID(companyA, dev.id),
+			want:    []*assignments.Assignment{assignmentEntity(aliceDev), assignmentEntity(bobDev)},
+			wantErr: false,
+		},
+		{
+			name:    "配下の部署を含む",
+			list:    NewAssignmentsFromSubtree(companyA, dev.id),
+			want:    []*assignments.Assignment{assignmentEntity(aliceDev), assignmentEntity(bobDev), assignmentEntity(bobTeam)},
+			wantErr: false,
+		},
+		{
+			name:    "配下の部署",
+			list:    NewAssignmentsFromSubtree(companyA, team.id),
+			want:    []*assignments.Assignment{assignmentEntity(bobTeam)},
+			wantErr: false,
+		},
+		{
+			name:    "other company subtree",
+			list:    NewAssignmentsFromSubtree(companyB, dev.id),
+			want:    []*assignments.Assignment{},
+			wantErr: false,
+		},
+		{
+			name:    "other company",
+			list:    NewAssignmentsFromEmployeeID(companyB, alice),
+			want:    []*assignments.Assignment{},
+			wantErr: false,
+		},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+
+	// 従業員の削除時に配置も削除する
+	err := NewEmployeeFromID(companyA, alice).Delete(context.Background(), db)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	list := NewAssignmentsFromDepartmentID(companyA, dev.id)
+	err = list.Read(context.Background(), db)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got := len(list.NewEntity()); got != 1 {
+		t.Fatalf("want=1, got=%v.", got)
+	}
+}
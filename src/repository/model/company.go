@@ -5,11 +5,14 @@ import (
 	"fmt"
 
 	companies "api.example.com/pkg/company"
+	"api.example.com/pkg/failure"
 )
 
 type Company interface {
-	Create(DB) error
-	Read(DB) error
+	Create(context.Context, DB) error
+	Read(context.Context, DB) error
+	Update(context.Context, DB) error
+	Delete(context.Context, DB) error
 	NewEntity() *companies.Company
 }
 
@@ -17,14 +20,16 @@ type Company interface {
 type company struct {
 	id        companies.ID
 	name      companies.Name
+	ownerID   companies.OwnerID
 	createdAt dateTime
 	updatedAt dateTime
-	// TODO OwnerID: テーブル設計を見直すこと
 }
 
 func NewCompany(c *companies.Company) Company {
 	return &company{
-		name: c.Name,
+		id:      c.ID,
+		name:    c.Name,
+		ownerID: c.OwnerID,
 	}
 }
 
@@ -34,17 +39,24 @@ func NewCompanyFromID(id companies.ID) Company {
 	}
 }
 
-func (c *company) Create(tx DB) error {
+// 作成、更新時の制約に違反する項目
+var companyConstraints = map[uint16]string{
+	erDupEntry:        "company.name",
+	erNoReferencedRow: "company.owner_id",
+}
+
+func (c *company) Create(ctx context.Context, tx DB) error {
 	now := currentTime()
 	result, err := tx.ExecContext(
-		context.TODO(),
-		"insert into `companies`(`name`, `created_at`, `updated_at`) value (?, ?, ?)",
+		ctx,
+		"insert into `companies`(`name`, `owner_id`, `created_at`, `updated_at`) value (?, ?, ?, ?)",
 		c.name,
+		c.ownerID,
 		now,
 		now,
 	)
 	if err != nil {
-		return fmt.Errorf("repository/model.Company.Create: %w", err)
+		return fmt.Errorf("repository/model.Company.Create: %w", conflict(err, companyConstraints))
 	}
 
 	id, err := result.LastInsertId()
@@ -58,15 +70,64 @@ func (c *company) Create(tx DB) error {
 	return nil
 }
 
-func (c *company) Read(tx DB) error {
+func (c *company) Read(ctx context.Context, tx DB) error {
 	err := tx.QueryRowContext(
-		context.TODO(),
-		"select `id`, `name`, `created_at`, `updated_at` from `companies` where `id`=?",
+		ctx,
+		"select `id`, `name`, `owner_id`, `created_at`, `updated_at` from `companies` where `id`=?",
+		c.id,
+	).Scan(&c.id, &c.name, &c.ownerID, &c.createdAt, &c.updatedAt)
+
+	if err != nil {
+		return fmt.Errorf("repository/model.Company.Read: %w", notFound(err))
+	}
+
+	return nil
+}
+
+func (c *company) Update(ctx context.Context, tx DB) error {
+	now := currentTime()
+	result, err := tx.ExecContext(
+		ctx,
+		"update `companies` set `name`=?, `owner_id`=?, `updated_at`=? where `id`=?",
+		c.name,
+		c.ownerID,
+		now,
+		c.id,
+	)
+	if err != nil {
+		return fmt.Errorf("repository/model.Company.Update: %w", conflict(err, companyConstraints))
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("repository/model.Company.Update: %w", err)
+	}
+
+	if count != 1 {
+		return fmt.Errorf("repository/model.Company.Update: %w: rows affected not 1 (affected=%d)", failure.ErrNotFound, count)
+	}
+
+	c.updatedAt = now
+	return nil
+}
+
+func (c *company) Delete(ctx context.Context, tx DB) error {
+	result, err := tx.ExecContext(
+		ctx,
+		"delete from `companies` where `id`=?",
 		c.id,
-	).Scan(&c.id, &c.name, &c.createdAt, &c.updatedAt)
+	)
+	if err != nil {
+		return fmt.Errorf("repository/model.Company.Delete: %w", err)
+	}
 
+	count, err := result.RowsAffected()
 	if err != nil {
-		return fmt.Errorf("repository/model.Company.Read: %w", err)
+		return fmt.Errorf("repository/model.Company.Delete: %w", err)
+	}
+
+	if count != 1 {
+		return fmt.Errorf("repository/model.Company.Delete: %w: rows affected not 1 (affected=%d)", failure.ErrNotFound, count)
 	}
 
 	return nil
@@ -74,9 +135,80 @@ func (c *company) Read(tx DB) error {
 
 func (c *company) NewEntity() *companies.Company {
 	return &companies.Company{
-		ID:   c.id,
-		Name: c.name,
-		// TODO OwnerID
+		ID:        c.id,
+		Name:      c.name,
+		OwnerID:   c.ownerID,
 		UpdatedAt: c.updatedAt,
 	}
 }
+
+// 会社の一覧
+type Companies interface {
+	Read(context.Context, DB) error
+	NewEntity() []*companies.Company
+}
+
+// impl Companies
+type companyList struct {
+	// このユーザーが従業員である会社に限る
+	userID companies.OwnerID
+	// 空でなければ名前の前方一致で絞り込む
+	prefix companies.Name
+	after  companies.ID
+	limit  int
+	list   []*company
+}
+
+// ユーザーが従業員である会社のうち、名前が prefix で始まり、 ID が after より大きい会社を ID の順に limit 件まで
+func NewCompaniesFromUserID(userID companies.OwnerID, prefix companies.Name, after companies.ID, limit int) Companies {
+	return &companyList{
+		userID: userID,
+		prefix: prefix,
+		after:  after,
+		limit:  limit,
+	}
+}
+
+func (l *companyList) Read(ctx context.Context, tx DB) error {
+	query := "select `c`.`id`, `c`.`name`, `c`.`owner_id`, `c`.`created_at`, `c`.`updated_at` from `companies` `c` " +
+		"join `company_employees` `e` on `e`.`company_id`=`c`.`id` where `e`.`user_id`=? and `c`.`id`>?"
+	args := []interface{}{l.userID, l.after}
+	if l.prefix != "" {
+		query += " and `c`.`name` like ? escape '!'"
+		args = append(args, likePrefix(string(l.prefix)))
+	}
+	query += " order by `c`.`id` limit ?"
+	args = append(args, l.limit)
+
+	rows, err := tx.QueryContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("repository/model.Companies.Read: %w", err)
+	}
+	defer rows.Close()
+
+	list := []*company{}
+	for rows.Next() {
+		c := &company{}
+		err = rows.Scan(&c.id, &c.name, &c.ownerID, &c.createdAt, &c.updatedAt)
+		if err != nil {
+			return fmt.Errorf("repository/model.Companies.Read: %w", err)
+		}
+		list = append(list, c)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return fmt.Errorf("repository/model.Companies.Read: %w", err)
+	}
+
+	l.list = list
+	return nil
+}
+
+func (l *companyList) NewEntity() []*companies.Company {
+	list := make([]*companies.Company, 0, len(l.list))
+	for _, c := range l.list {
+		list = append(list, c.NewEntity())
+	}
+	return list
+}
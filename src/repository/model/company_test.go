@@ -1,14 +1,36 @@
 package model
 
 import (
+	"context"
 	"errors"
+	"math"
 	"reflect"
 	"testing"
 	"time"
 
 	companies "api.example.com/pkg/company"
+	employees "api.example.com/pkg/employee"
+	"api.example.com/pkg/failure"
+	users "api.example.com/pkg/user"
+	"api.example.com/pkg/user/password"
 )
 
+// helper: 会社の owner となるユーザーを作成する
+func createOwner(db DB, name users.Name) users.ID {
+	pw, err := password.New("password")
+	if err != nil {
+		panic(err)
+	}
+
+	model := NewUser(users.New(name, pw))
+	err = model.Create(context.Background(), db)
+	if err != nil {
+		panic(err)
+	}
+
+	return model.NewEntity().ID
+}
+
 func TestNewCompany(t *testing.T) {
 	type test struct {
 		name    string
@@ -30,7 +52,8 @@ func TestNewCompany(t *testing.T) {
 			name:    "ok (create)",
 			company: companies.New("GREATE COMPANY", 1),
 			want: &company{
-				name: "GREATE COMPANY",
+				name:    "GREATE COMPANY",
+				ownerID: 1,
 			},
 		},
 	}
@@ -46,6 +69,7 @@ func TestCompany_Create(t *testing.T) {
 
 	db := newDB()
 	defer db.Close()
+	defer db.Exec("delete from users")
 	defer db.Exec("delete from companies")
 
 	type test struct {
@@ -54,17 +78,23 @@ func TestCompany_Create(t *testing.T) {
 		company *companies.Company
 		want    *company
 		wantErr bool
+		// 制約に違反した項目
+		wantViolations []failure.Violation
 	}
 
 	do := func(tt *test) {
 		t.Run(tt.name, func(t *testing.T) {
 			got := NewCompany(tt.company).(*company)
 
-			err := got.Create(tt.db)
+			err := got.Create(context.Background(), tt.db)
 			if tt.wantErr != (err != nil) {
 				t.Fatalf("want-error=%v, error=%v.", tt.wantErr, err)
 			}
 
+			if v := failure.Violations(err); !reflect.DeepEqual(tt.wantViolations, v) {
+				t.Fatalf("want=%v, got=%v.", tt.wantViolations, v)
+			}
+
 			if tt.wantErr {
 				return
 			}
@@ -82,8 +112,8 @@ func TestCompany_Create(t *testing.T) {
 
 			// 作成されていることの確認
 			err = db.
-				QueryRow("select id, name, created_at, updated_at from companies where id = ?", got.id).
-				Scan(&got.id, &got.name, &got.updatedAt, &got.createdAt)
+				QueryRow("select id, name, owner_id, created_at, updated_at from companies where id = ?", got.id).
+				Scan(&got.id, &got.name, &got.ownerID, &got.updatedAt, &got.createdAt)
 			if err != nil {
 				t.Fatal(err)
 			}
@@ -100,15 +130,20 @@ func TestCompany_Create(t *testing.T) {
 	}
 
 	tests := []*test{
-		{
-			name:    "ok",
-			db:      db,
-			company: companies.New("GREATE COMPANY", 1),
-			want: &company{
-				name: "GREATE COMPANY",
-			},
-			wantErr: false,
-		},
+		func() *test {
+			ownerID := createOwner(db, "Bob")
+
+			return &test{
+				name:    "ok",
+				db:      db,
+				company: companies.New("GREATE COMPANY", ownerID),
+				want: &company{
+					name:    "GREATE COMPANY",
+					ownerID: ownerID,
+				},
+				wantErr: false,
+			}
+		}(),
 		{
 			name: "failed ExecContext",
 			db: &testdb{
@@ -119,6 +154,30 @@ func TestCompany_Create(t *testing.T) {
 			want:    nil,
 			wantErr: true,
 		},
+		func() *test {
+			ownerID := createOwner(db, "Alice")
+			err := NewCompany(companies.New("DUPLICATE COMPANY", ownerID)).Create(context.Background(), db)
+			if err != nil {
+				panic(err)
+			}
+
+			return &test{
+				name:           "duplicate name",
+				db:             db,
+				company:        companies.New("DUPLICATE COMPANY", ownerID),
+				want:           nil,
+				wantErr:        true,
+				wantViolations: []failure.Violation{{Field: "company.name", Rule: failure.RuleUnique}},
+			}
+		}(),
+		{
+			name:           "owner does not exist",
+			db:             db,
+			company:        companies.New("NO OWNER COMPANY", math.MaxInt32),
+			want:           nil,
+			wantErr:        true,
+			wantViolations: []failure.Violation{{Field: "company.owner_id", Rule: failure.RuleExists}},
+		},
 		{
 			name: "failed result.LastInsertId",
 			db: &testdb{
@@ -140,8 +199,6 @@ func TestCompany_Create(t *testing.T) {
 }
 
 func TestCompany_NewEntity(t *testing.T) {
-	t.Skip("TODO OwnerID")
-
 	type test struct {
 		name    string
 		company Company
@@ -163,12 +220,13 @@ func TestCompany_NewEntity(t *testing.T) {
 			company: &company{
 				id:        1,
 				name:      "GREATE COMPANY",
+				ownerID:   2,
 				updatedAt: time.Date(2022, 9, 3, 12, 34, 56, 0, time.UTC),
 			},
 			want: &companies.Company{
-				ID:   1,
-				Name: "GREATE COMPANY",
-				// TODO OwnerID
+				ID:        1,
+				Name:      "GREATE COMPANY",
+				OwnerID:   2,
 				UpdatedAt: time.Date(2022, 9, 3, 12, 34, 56, 0, time.UTC),
 			},
 		},
@@ -216,6 +274,7 @@ func TestCompany_Read(t *testing.T) {
 
 	db := newDB()
 	defer db.Close()
+	defer db.Exec("delete from users")
 	defer db.Exec("delete from companies")
 
 	type test struct {
@@ -229,7 +288,7 @@ func TestCompany_Read(t *testing.T) {
 	do := func(tt *test) {
 		t.Run(tt.name, func(t *testing.T) {
 			got := NewCompanyFromID(tt.id).(*company)
-			err := got.Read(tt.db)
+			err := got.Read(context.Background(), tt.db)
 			if tt.wantErr != (err != nil) {
 				t.Fatalf("want-error=%v, error=%v.", tt.wantErr, err)
 			}
@@ -251,8 +310,8 @@ func TestCompany_Read(t *testing.T) {
 
 	tests := []*test{
 		func() *test {
-			model := NewCompany(companies.New("testCompany", 1)).(*company)
-			err := model.Create(db)
+			model := NewCompany(companies.New("testCompany", createOwner(db, "Bob"))).(*company)
+			err := model.Create(context.Background(), db)
 
 			if err != nil {
 				panic(err)
@@ -279,3 +338,306 @@ func TestCompany_Read(t *testing.T) {
 		do(tt)
 	}
 }
+
+func TestCompanies_Read(t *testing.T) {
+	tableLock.Lock()
+	defer tableLock.Unlock()
+
+	db := newDB()
+	defer db.Close()
+	defer db.Exec("delete from users")
+	defer db.Exec("delete from companies")
+
+	greate := createCompany(db, "GREATE COMPANY")
+	// like のワイルドカードを含む名前
+	wildcard := createCompany(db, "GREATE_CO")
+	greatest := createCompany(db, "GREATEST")
+	// Alice が従業員でない会社
+	createCompany(db, "GREATE OTHER")
+
+	alice := createOwner(db, "Alice")
+	for _, id := range []companies.ID{greate, wildcard, greatest} {
+		err := NewEmployee(employees.New(id, alice, false)).Create(context.Background(), db)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	type test struct {
+		name string
+		list Companies
+		want []companies.ID
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.list.Read(context.Background(), db)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got := []companies.ID{}
+			for _, c := range tt.list.NewEntity() {
+				got = append(got, c.ID)
+			}
+
+			if !reflect.DeepEqual(tt.want, got) {
+				t.Fatalf("want=%v, got=%v.", tt.want, got)
+			}
+		})
+	}
+
+	tests := []*test{
+		{
+			name: "all",
+			list: NewCompaniesFromUserID(alice, "", 0, 10),
+			want: []companies.ID{greate, wildcard, greatest},
+		},
+		{
+			name: "limit after",
+			list: NewCompaniesFromUserID(alice, "", greate, 1),
+			want: []companies.ID{wildcard},
+		},
+		{
+			name: "prefix",
+			list: NewCompaniesFromUserID(alice, "GREATE ", 0, 10),
+			want: []companies.ID{greate},
+		},
+		{
+			name: "escape",
+			list: NewCompaniesFromUserID(alice, "GREATE_", 0, 10),
+			want: []companies.ID{wildcard},
+		},
+		{
+			name: "not found",
+			list: NewCompaniesFromUserID(alice, "OTHER", 0, 10),
+			want: []companies.ID{},
+		},
+		{
+			name: "not employee",
+			list: NewCompaniesFromUserID(alice+100, "", 0, 10),
+			want: []companies.ID{},
+		},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+}
+
+func TestCompany_Update(t *testing.T) {
+	tableLock.Lock()
+	defer tableLock.Unlock()
+
+	db := newDB()
+	defer db.Close()
+	defer db.Exec("delete from users")
+	defer db.Exec("delete from companies")
+
+	type test struct {
+		name    string
+		db      DB
+		company *companies.Company
+		want    *company
+		wantErr bool
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.name, func(t *testing.T) {
+			got := NewCompany(tt.company).(*company)
+			err := got.Update(context.Background(), tt.db)
+			if tt.wantErr != (err != nil) {
+				t.Fatalf("want-error=%v, error=%v.", tt.wantErr, err)
+			}
+
+			if tt.wantErr {
+				return
+			}
+
+			wantTime := time.Now()
+
+			testDiffTime(t, wantTime, got.updatedAt)
+			tt.want.updatedAt = got.updatedAt
+
+			if !reflect.DeepEqual(tt.want, got) {
+				t.Fatalf("want=%v, got=%v.", tt.want, got)
+			}
+
+			// 更新されていることの確認
+			err = db.
+				QueryRow("select id, name, owner_id, created_at, updated_at from companies where id = ?", got.id).
+				Scan(&got.id, &got.name, &got.ownerID, &got.createdAt, &got.updatedAt)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			testDiffTime(t, wantTime, got.createdAt)
+			tt.want.createdAt = got.createdAt
+			testDiffTime(t, wantTime, got.updatedAt)
+			tt.want.updatedAt = got.updatedAt
+
+			if !reflect.DeepEqual(tt.want, got) {
+				t.Fatalf("want=%v, got=%v.", tt.want, got)
+			}
+		})
+	}
+
+	tests := []*test{
+		func() *test {
+			model := NewCompany(companies.New("testCompany", createOwner(db, "Bob"))).(*company)
+			err := model.Create(context.Background(), db)
+			if err != nil {
+				panic(err)
+			}
+
+			return &test{
+				name: "ok",
+				db:   db,
+				company: &companies.Company{
+					ID:      model.id,
+					Name:    "greatCompany",
+					OwnerID: model.ownerID,
+				},
+				want: &company{
+					id:      model.id,
+					name:    "greatCompany",
+					ownerID: model.ownerID,
+				},
+				wantErr: false,
+			}
+		}(),
+		{
+			name: "failed ExecContext",
+			db: &testdb{
+				err:         errors.New("test error"),
+				execContext: true,
+			},
+			company: &companies.Company{ID: 1, Name: "greatCompany", OwnerID: 1},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "failed result.RowsAffected",
+			db: &testdb{
+				result: &queryResult{
+					err:          errors.New("test error"),
+					rowsAffected: true,
+				},
+				execContext: true,
+			},
+			company: &companies.Company{ID: 1, Name: "greatCompany", OwnerID: 1},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "invalid rows-affected",
+			db: &testdb{
+				result: &queryResult{
+					rows:         0,
+					rowsAffected: true,
+				},
+				execContext: true,
+			},
+			company: &companies.Company{ID: 1, Name: "greatCompany", OwnerID: 1},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+}
+
+func TestCompany_Delete(t *testing.T) {
+	tableLock.Lock()
+	defer tableLock.Unlock()
+
+	db := newDB()
+	defer db.Close()
+	defer db.Exec("delete from users")
+	defer db.Exec("delete from companies")
+
+	type test struct {
+		name    string
+		db      DB
+		id      companies.ID
+		wantErr bool
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.name, func(t *testing.T) {
+			got := NewCompanyFromID(tt.id).(*company)
+			err := got.Delete(context.Background(), tt.db)
+			if tt.wantErr != (err != nil) {
+				t.Fatalf("want-error=%v, error=%v.", tt.wantErr, err)
+			}
+
+			if tt.wantErr {
+				return
+			}
+
+			var count int64
+			err = db.
+				QueryRow("select count(*) from companies where id = ?", tt.id).
+				Scan(&count)
+			if count != 0 {
+				t.Fatalf("failed delete id=%v, count=%v.", tt.id, count)
+			}
+		})
+	}
+
+	tests := []*test{
+		func() *test {
+			model := NewCompany(companies.New("testCompany", createOwner(db, "Bob"))).(*company)
+			err := model.Create(context.Background(), db)
+			if err != nil {
+				panic(err)
+			}
+
+			return &test{
+				name:    "ok",
+				db:      db,
+				id:      model.id,
+				wantErr: false,
+			}
+		}(),
+		{
+			name: "failed ExecContext",
+			db: &testdb{
+				err:         errors.New("test error"),
+				execContext: true,
+			},
+			id:      1,
+			wantErr: true,
+		},
+		{
+			name: "failed result.RowsAffected",
+			db: &testdb{
+				result: &queryResult{
+					err:          errors.New("test error"),
+					rowsAffected: true,
+				},
+				execContext: true,
+			},
+			id:      1,
+			wantErr: true,
+		},
+		{
+			name: "invalid rows-affected",
+			db: &testdb{
+				result: &queryResult{
+					rows:         0,
+					rowsAffected: true,
+				},
+				execContext: true,
+			},
+			id:      1,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+}
@@ -0,0 +1,431 @@
+package model
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"api.example.com/pkg/failure"
+	organizations "api.example.com/pkg/organization"
+)
+
+// 部署の階層は `department_paths` (閉包テーブル) で管理する
+// 全ての祖先と子孫の組み合わせを深さと共に保持する(自身への深さ 0 の経路を含む)
+type Department interface {
+	Create(context.Context, DB) error
+	Read(context.Context, DB) error
+	Update(context.Context, DB) error
+	Move(context.Context, DB) error
+	Delete(context.Context, DB) error
+	NewEntity() *organizations.Department
+}
+
+// impl Department
+type department struct {
+	id        organizations.ID
+	companyID organizations.CompanyID
+	parentID  organizations.ID
+	name      organizations.Name
+	createdAt dateTime
+	updatedAt dateTime
+}
+
+func NewDepartment(d *organizations.Department) Department {
+	return &department{
+		id:        d.ID,
+		companyID: d.CompanyID,
+		parentID:  d.ParentID,
+		name:      d.Name,
+	}
+}
+
+func NewDepartmentFromID(companyID organizations.CompanyID, id organizations.ID) Department {
+	return &department{
+		id:        id,
+		companyID: companyID,
+	}
+}
+
+// 最上位の部署の `parent_id` は null
+func nullParentID(id organizations.ID) sql.NullInt64 {
+	return sql.NullInt64{
+		Int64: int64(id),
+		Valid: id != organizations.Root,
+	}
+}
+
+func (d *department) Create(ctx context.Context, tx DB) error {
+	now := currentTime()
+	result, err := tx.ExecContext(
+		ctx,
+		"insert into `departments`(`company_id`, `parent_id`, `name`, `created_at`, `updated_at`) value (?, ?, ?, ?, ?)",
+		d.companyID,
+		nullParentID(d.parentID),
+		d.name,
+		now,
+		now,
+	)
+	if err != nil {
+		return fmt.Errorf("repository/model.Department.Create: %w", err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("repository/model.Department.Create: %w", err)
+	}
+
+	d.id = organizations.ID(id)
+
+	// 親の祖先への経路と自身への経路
+	_, err = tx.ExecContext(
+		ctx,
+		"insert into `department_paths`(`ancestor_id`, `descendant_id`, `depth`) "+
+			"select `ancestor_id`, ?, `depth` + 1 from `department_paths` where `descendant_id`=? "+
+			"union all select ?, ?, 0",
+		d.id,
+		d.parentID,
+		d.id,
+		d.id,
+	)
+	if err != nil {
+		return fmt.Errorf("repository/model.Department.Create: %w", err)
+	}
+
+	d.createdAt = now
+	d.updatedAt = now
+	return nil
+}
+
+func (d *department) Read(ctx context.Context, tx DB) error {
+	var parentID sql.NullInt64
+	err := tx.QueryRowContext(
+		ctx,
+		"select `parent_id`, `name`, `created_at`, `updated_at` from `departments` where `id`=? and `company_id`=?",
+		d.id,
+		d.companyID,
+	).Scan(&parentID, &d.name, &d.createdAt, &d.updatedAt)
+	if err != nil {
+		return fmt.Errorf("repository/model.Department.Read: %w", notFound(err))
+	}
+
+	d.parentID = organizations.ID(parentID.Int64)
+	return nil
+}
+
+// 親の変更は Move で行う
+func (d *department) Update(ctx context.Context, tx DB) error {
+	now := currentTime()
+	result, err := tx.ExecContext(
+		ctx,
+		"update `departments` set `name`=?, `updated_at`=? where `id`=? and `company_id`=?",
+		d.name,
+		now,
+		d.id,
+		d.companyID,
+	)
+	if err != nil {
+		return fmt.Errorf("repository/model.Department.Update: %w", err)
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("repository/model.Department.Update: %w", err)
+	}
+
+	if count != 1 {
+		return fmt.Errorf("repository/model.Department.Update: %w: rows affected not 1 (affected=%d)", failure.ErrNotFound, count)
+	}
+
+	d.updatedAt = now
+	return nil
+}
+
+// 配下の部署ごと parentID の子へ移動する
+// 複数の文を実行するため、トランザクション内で呼び出すこと
+// 同時に互いの配下へ移動して循環しないよう、配下と移動先の祖先の経路をロックして確かめる
+func (d *department) Move(ctx context.Context, tx DB) error {
+	// 移動先が自身の配下であれば循環する
+	var count int64
+	err := tx.QueryRowContext(
+		ctx,
+		"select count(case when `ancestor_id`=? and `descendant_id`=? then 1 end) from `department_paths` where `ancestor_id`=? or `descendant_id`=? for update",
+		d.id,
+		d.parentID,
+		d.id,
+		d.parentID,
+	).Scan(&count)
+	if err != nil {
+		return fmt.Errorf("repository/model.Department.Move: %w", err)
+	}
+
+	if count > 0 {
+		return fmt.Errorf("repository/model.Department.Move: %w", organizations.ErrCycle)
+	}
+
+	now := currentTime()
+	result, err := tx.ExecContext(
+		ctx,
+		"update `departments` set `parent_id`=?, `updated_at`=? where `id`=? and `company_id`=?",
+		nullParentID(d.parentID),
+		now,
+		d.id,
+		d.companyID,
+	)
+	if err != nil {
+		return fmt.Errorf("repository/model.Department.Move: %w", err)
+	}
+
+	count, err = result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("repository/model.Department.Move: %w", err)
+	}
+
+	if count != 1 {
+		return fmt.Errorf("repository/model.Department.Move: %w: rows affected not 1 (affected=%d)", failure.ErrNotFound, count)
+	}
+
+	// 配下の部署から、自身より上の祖先への経路を削除する
+	_, err = tx.ExecContext(
+		ctx,
+		"delete `p` from `department_paths` `p` "+
+			"join `department_paths` `s` on `p`.`descendant_id`=`s`.`descendant_id` "+
+			"where `s`.`ancestor_id`=? and `p`.`depth` > `s`.`depth`",
+		d.id,
+	)
+	if err != nil {
+		return fmt.Errorf("repository/model.Department.Move: %w", err)
+	}
+
+	// 移動先の祖先から、配下の部署への経路を追加する
+	_, err = tx.ExecContext(
+		ctx,
+		"insert into `department_paths`(`ancestor_id`, `descendant_id`, `depth`) "+
+			"select `a`.`ancestor_id`, `s`.`descendant_id`, `a`.`depth` + `s`.`depth` + 1 "+
+			"from `department_paths` `a` cross join `department_paths` `s` "+
+			"where `a`.`descendant_id`=? and `s`.`ancestor_id`=?",
+		d.parentID,
+		d.id,
+	)
+	if err != nil {
+		return fmt.Errorf("repository/model.Department.Move: %w", err)
+	}
+
+	d.updatedAt = now
+	return nil
+}
+
+// 経路は外部キーにより削除される
+// 子を持つ部署は organizations.ErrHasChildren
+// 削除までの間に子が追加されないよう、経路をロックして確かめる
+func (d *department) Delete(ctx context.Context, tx DB) error {
+	var children int
+	err := tx.QueryRowContext(
+		ctx,
+		"select count(*) from `department_paths` `p` join `departments` `d` on `d`.`id`=`p`.`ancestor_id` where `p`.`ancestor_id`=? and `p`.`depth`>0 and `d`.`company_id`=? for update",
+		d.id,
+		d.companyID,
+	).Scan(&children)
+	if err != nil {
+		return fmt.Errorf("repository/model.Department.Delete: %w", err)
+	}
+
+	if children > 0 {
+		return fmt.Errorf("repository/model.Department.Delete: %w", organizations.ErrHasChildren)
+	}
+
+	result, err := tx.ExecContext(
+		ctx,
+		"delete from `departments` where `id`=? and `company_id`=?",
+		d.id,
+		d.companyID,
+	)
+	if err != nil {
+		return fmt.Errorf("repository/model.Department.Delete: %w", err)
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("repository/model.Department.Delete: %w", err)
+	}
+
+	if count != 1 {
+		return fmt.Errorf("repository/model.Department.Delete: %w: rows affected not 1 (affected=%d)", failure.ErrNotFound, count)
+	}
+
+	return nil
+}
+
+func (d *department) NewEntity() *organizations.Department {
+	return &organizations.Department{
+		ID:        d.id,
+		CompanyID: d.companyID,
+		ParentID:  d.parentID,
+		Name:      d.name,
+		UpdatedAt: d.updatedAt,
+	}
+}
+
+// 部署の一覧
+type Departments interface {
+	Read(context.Context, DB) error
+	NewEntity() []*organizations.Department
+}
+
+// impl Departments
+// 親が同じ部署の一覧
+type departmentChildren struct {
+	companyID organizations.CompanyID
+	parentID  organizations.ID
+	list      []*department
+}
+
+// parentID が Root の場合は最上位の部署の一覧
+func NewDepartmentsFromParentID(companyID organizations.CompanyID, parentID organizations.ID) Departments {
+	return &departmentChildren{
+		companyID: companyID,
+		parentID:  parentID,
+	}
+}
+
+func (l *departmentChildren) Read(ctx context.Context, tx DB) error {
+	query := "select `id`, `parent_id`, `name`, `created_at`, `updated_at` from `departments` where `company_id`=? and `parent_id`=? order by `id`"
+	args := []interface{}{l.companyID, l.parentID}
+	if l.parentID == organizations.Root {
+		query = "select `id`, `parent_id`, `name`, `created_at`, `updated_at` from `departments` where `company_id`=? and `parent_id` is null order by `id`"
+		args = args[:1]
+	}
+
+	rows, err := tx.QueryContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("repository/model.Departments.Read: %w", err)
+	}
+
+	list, err := scanDepartments(rows, l.companyID)
+	if err != nil {
+		return fmt.Errorf("repository/model.Departments.Read: %w", err)
+	}
+
+	l.list = list
+	return nil
+}
+
+func (l *departmentChildren) NewEntity() []*organizations.Department {
+	return newDepartmentEntities(l.list)
+}
+
+// impl Departments
+// 最上位から親までの部署の一覧
+type departmentAncestors struct {
+	companyID organizations.CompanyID
+	id        organizations.ID
+	list      []*department
+}
+
+func NewDepartmentAncestors(companyID organizations.CompanyID, id organizations.ID) Departments {
+	return &departmentAncestors{
+		companyID: companyID,
+		id:        id,
+	}
+}
+
+func (l *departmentAncestors) Read(ctx context.Context, tx DB) error {
+	rows, err := tx.QueryContext(
+		ctx,
+		"select `d`.`id`, `d`.`parent_id`, `d`.`name`, `d`.`created_at`, `d`.`updated_at` "+
+			"from `department_paths` `p` join `departments` `d` on `d`.`id`=`p`.`ancestor_id` "+
+			"where `p`.`descendant_id`=? and `p`.`depth` > 0 and `d`.`company_id`=? "+
+			"order by `p`.`depth` desc",
+		l.id,
+		l.companyID,
+	)
+	if err != nil {
+		return fmt.Errorf("repository/model.Departments.Read: %w", err)
+	}
+
+	list, err := scanDepartments(rows, l.companyID)
+	if err != nil {
+		return fmt.Errorf("repository/model.Departments.Read: %w", err)
+	}
+
+	l.list = list
+	return nil
+}
+
+func (l *departmentAncestors) NewEntity() []*organizations.Department {
+	return newDepartmentEntities(l.list)
+}
+
+// impl Departments
+// 自身と配下の全ての部署の一覧
+// 浅い部署から順に並ぶ
+type departmentSubtree struct {
+	companyID organizations.CompanyID
+	id        organizations.ID
+	list      []*department
+}
+
+func NewDepartmentSubtree(companyID organizations.CompanyID, id organizations.ID) Departments {
+	return &departmentSubtree{
+		companyID: companyID,
+		id:        id,
+	}
+}
+
+func (l *departmentSubtree) Read(ctx context.Context, tx DB) error {
+	rows, err := tx.QueryContext(
+		ctx,
+		"select `d`.`id`, `d`.`parent_id`, `d`.`name`, `d`.`created_at`, `d`.`updated_at` "+
+			"from `department_paths` `p` join `departments` `d` on `d`.`id`=`p`.`descendant_id` "+
+			"where `p`.`ancestor_id`=? and `d`.`company_id`=? "+
+			"order by `p`.`depth`, `d`.`id`",
+		l.id,
+		l.companyID,
+	)
+	if err != nil {
+		return fmt.Errorf("repository/model.Departments.Read: %w", err)
+	}
+
+	list, err := scanDepartments(rows, l.companyID)
+	if err != nil {
+		return fmt.Errorf("repository/model.Departments.Read: %w", err)
+	}
+
+	l.list = list
+	return nil
+}
+
+func (l *departmentSubtree) NewEntity() []*organizations.Department {
+	return newDepartmentEntities(l.list)
+}
+
+// `id`, `parent_id`, `name`, `created_at`, `updated_at` の順で読み込む
+func scanDepartments(rows *sql.Rows, companyID organizations.CompanyID) ([]*department, error) {
+	defer rows.Close()
+
+	list := []*department{}
+	for rows.Next() {
+		var parentID sql.NullInt64
+		d := &department{companyID: companyID}
+		err := rows.Scan(&d.id, &parentID, &d.name, &d.createdAt, &d.updatedAt)
+		if err != nil {
+			return nil, err
+		}
+		d.parentID = organizations.ID(parentID.Int64)
+		list = append(list, d)
+	}
+
+	err := rows.Err()
+	if err != nil {
+		return nil, err
+	}
+
+	return list, nil
+}
+
+func newDepartmentEntities(list []*department) []*organizations.Department {
+	entities := make([]*organizations.Department, 0, len(list))
+	for _, d := range list {
+		entities = append(entities, d.NewEntity())
+	}
+	return entities
+}
@@ -0,0 +1,696 @@
+package model
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	companies "api.example.com/pkg/company"
+	"api.example.com/pkg/failure"
+	organizations "api.example.com/pkg/organization"
+	users "api.example.com/pkg/user"
+)
+
+func createCompany(db DB, name companies.Name) companies.ID {
+	company := NewCompany(companies.New(name, createOwner(db, users.Name(name)))).(*company)
+	err := company.Create(context.Background(), db)
+	if err != nil {
+		panic(err)
+	}
+
+	return company.id
+}
+
+// 比較のため UpdatedAt を除いた entity
+func departmentEntity(d *department) *organizations.Department {
+	entity := d.NewEntity()
+	entity.UpdatedAt = time.Time{}
+	return entity
+}
+
+func createDepartment(db DB, companyID organizations.CompanyID, parentID organizations.ID, name organizations.Name) *department {
+	department := NewDepartment(organizations.New(companyID, parentID, name)).(*department)
+	err := department.Create(context.Background(), db)
+	if err != nil {
+		panic(err)
+	}
+
+	return department
+}
+
+func TestNewDepartment(t *testing.T) {
+	got := NewDepartment(&organizations.Department{
+		ID:        3,
+		CompanyID: 1,
+		ParentID:  2,
+		Name:      "開発部",
+	})
+	want := &department{
+		id:        3,
+		companyID: 1,
+		parentID:  2,
+		name:      "開発部",
+	}
+	if !reflect.DeepEqual(want, got) {
+		t.Fatalf("want=%v, got=%v.", want, got)
+	}
+}
+
+func TestNewDepartmentFromID(t *testing.T) {
+	got := NewDepartmentFromID(1, 3)
+	want := &department{id: 3, companyID: 1}
+	if !reflect.DeepEqual(want, got) {
+		t.Fatalf("want=%v, got=%v.", want, got)
+	}
+}
+
+func TestDepartment_Create(t *testing.T) {
+	tableLock.Lock()
+	defer tableLock.Unlock()
+
+	db := newDB()
+	defer db.Close()
+	defer db.Exec("delete from users")
+	defer db.Exec("delete from companies")
+	defer db.Exec("delete from departments")
+
+	companyID := createCompany(db, "GREATE COMPANY")
+	root := createDepartment(db, companyID, organizations.Root, "開発部")
+
+	type test struct {
+		name       string
+		department *department
+		wantErr    bool
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.department.Create(context.Background(), db)
+			if tt.wantErr != (err != nil) {
+				t.Fatalf("want-error=%v, error=%v.", tt.wantErr, err)
+			}
+
+			if tt.wantErr {
+				return
+			}
+
+			got := NewDepartmentFromID(companyID, tt.department.id).(*department)
+			err = got.Read(context.Background(), db)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			want := tt.department.NewEntity()
+			testDiffTime(t, want.UpdatedAt, got.updatedAt)
+			got.updatedAt = want.UpdatedAt
+
+			if !reflect.DeepEqual(want, got.NewEntity()) {
+				t.Fatalf("want=%v, got=%v.", want, got.NewEntity())
+			}
+		})
+	}
+
+	tests := []*test{
+		{
+			name:       "root",
+			department: NewDepartment(organizations.New(companyID, organizations.Root, "営業部")).(*department),
+			wantErr:    false,
+		},
+		{
+			name:       "child",
+			department: NewDepartment(organizations.New(companyID, root.id, "開発一課")).(*department),
+			wantErr:    false,
+		},
+		{
+			name:       "parent not found",
+			department: NewDepartment(organizations.New(companyID, root.id+100, "開発二課")).(*department),
+			wantErr:    true,
+		},
+		{
+			name:       "company not found",
+			department: NewDepartment(organizations.New(companyID+100, organizations.Root, "人事部")).(*department),
+			wantErr:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+}
+
+func TestDepartment_Read(t *testing.T) {
+	tableLock.Lock()
+	defer tableLock.Unlock()
+
+	db := newDB()
+	defer db.Close()
+	defer db.Exec("delete from users")
+	defer db.Exec("delete from companies")
+	defer db.Exec("delete from departments")
+
+	companyID := createCompany(db, "GREATE COMPANY")
+	root := createDepartment(db, companyID, organizations.Root, "開発部")
+	child := createDepartment(db, companyID, root.id, "開発一課")
+
+	type test struct {
+		name      string
+		companyID organizations.CompanyID
+		id        organizations.ID
+		want      *department
+		wantErr   bool
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.name, func(t *testing.T) {
+			got := NewDepartmentFromID(tt.companyID, tt.id).(*department)
+			err := got.Read(context.Background(), db)
+			if tt.wantErr != (err != nil) {
+				t.Fatalf("want-error=%v, error=%v.", tt.wantErr, err)
+			}
+
+			if tt.wantErr {
+				return
+			}
+
+			testDiffTime(t, tt.want.createdAt, got.createdAt)
+			tt.want.createdAt = got.createdAt
+			testDiffTime(t, tt.want.updatedAt, got.updatedAt)
+			tt.want.updatedAt = got.updatedAt
+
+			if !reflect.DeepEqual(tt.want, got) {
+				t.Fatalf("want=%v, got=%v.", tt.want, got)
+			}
+		})
+	}
+
+	tests := []*test{
+		{
+			name:      "root",
+			companyID: companyID,
+			id:        root.id,
+			want:      root,
+			wantErr:   false,
+		},
+		{
+			name:      "child",
+			companyID: companyID,
+			id:        child.id,
+			want:      child,
+			wantErr:   false,
+		},
+		{
+			name:      "other company",
+			companyID: companyID + 1,
+			id:        root.id,
+			want:      nil,
+			wantErr:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+}
+
+func TestDepartment_Update(t *testing.T) {
+	tableLock.Lock()
+	defer tableLock.Unlock()
+
+	db := newDB()
+	defer db.Close()
+	defer db.Exec("delete from users")
+	defer db.Exec("delete from companies")
+	defer db.Exec("delete from departments")
+
+	companyID := createCompany(db, "GREATE COMPANY")
+	dev := createDepartment(db, companyID, organizations.Root, "開発部")
+	team := createDepartment(db, companyID, dev.id, "開発一課")
+
+	type test struct {
+		name       string
+		department *organizations.Department
+		want       *organizations.Department
+		wantErr    bool
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.name, func(t *testing.T) {
+			err := NewDepartment(tt.department).Update(context.Background(), db)
+			if tt.wantErr != (err != nil) {
+				t.Fatalf("want-error=%v, error=%v.", tt.wantErr, err)
+			}
+
+			if tt.wantErr {
+				return
+			}
+
+			got := NewDepartmentFromID(tt.department.CompanyID, tt.department.ID).(*department)
+			err = got.Read(context.Background(), db)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !reflect.DeepEqual(tt.want, departmentEntity(got)) {
+				t.Fatalf("want=%v, got=%v.", tt.want, departmentEntity(got))
+			}
+		})
+	}
+
+	tests := []*test{
+		{
+			name:       "rename",
+			department: &organizations.Department{ID: team.id, CompanyID: companyID, ParentID: dev.id, Name: "開発二課"},
+			want:       &organizations.Department{ID: team.id, CompanyID: companyID, ParentID: dev.id, Name: "開発二課"},
+			wantErr:    false,
+		},
+		{
+			name:       "parent is not changed",
+			department: &organizations.Department{ID: team.id, CompanyID: companyID, ParentID: organizations.Root, Name: "開発三課"},
+			want:       &organizations.Department{ID: team.id, CompanyID: companyID, ParentID: dev.id, Name: "開発三課"},
+			wantErr:    false,
+		},
+		{
+			name:       "other company",
+			department: &organizations.Department{ID: team.id, CompanyID: companyID + 1, ParentID: dev.id, Name: "開発四課"},
+			want:       nil,
+			wantErr:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+}
+
+// 各部署の祖先(自身を含む)と深さ
+func readPaths(db DB) map[organizations.ID]map[organizations.ID]int {
+	rows, err := db.QueryContext(context.Background(), "select `ancestor_id`, `descendant_id`, `depth` from `department_paths`")
+	if err != nil {
+		panic(err)
+	}
+	defer rows.Close()
+
+	paths := map[organizations.ID]map[organizations.ID]int{}
+	for rows.Next() {
+		var ancestor, descendant organizations.ID
+		var depth int
+		err = rows.Scan(&ancestor, &descendant, &depth)
+		if err != nil {
+			panic(err)
+		}
+
+		if paths[descendant] == nil {
+			paths[descendant] = map[organizations.ID]int{}
+		}
+		paths[descendant][ancestor] = depth
+	}
+
+	return paths
+}
+
+func TestDepartment_Move(t *testing.T) {
+	tableLock.Lock()
+	defer tableLock.Unlock()
+
+	db := newDB()
+	defer db.Close()
+	defer db.Exec("delete from users")
+	defer db.Exec("delete from companies")
+	defer db.Exec("delete from departments")
+
+	// 開発部 > 開発一課 > 基盤チーム
+	// 営業部
+	companyID := createCompany(db, "GREATE COMPANY")
+	dev := createDepartment(db, companyID, organizations.Root, "開発部")
+	team := createDepartment(db, companyID, dev.id, "開発一課")
+	infra := createDepartment(db, companyID, team.id, "基盤チーム")
+	sales := createDepartment(db, companyID, organizations.Root, "営業部")
+
+	type test struct {
+		name       string
+		department *organizations.Department
+		want       map[organizations.ID]map[organizations.ID]int
+		wantErr    error
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.name, func(t *testing.T) {
+			err := NewDepartment(tt.department).Move(context.Background(), db)
+			if tt.wantErr == nil && err != nil {
+				t.Fatalf("error=%v.", err)
+			}
+
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Fatalf("want-error=%v, error=%v.", tt.wantErr, err)
+			}
+
+			got := readPaths(db)
+			if !reflect.DeepEqual(tt.want, got) {
+				t.Fatalf("want=%v, got=%v.", tt.want, got)
+			}
+		})
+	}
+
+	tests := []*test{
+		{
+			name:       "move subtree",
+			department: &organizations.Department{ID: team.id, CompanyID: companyID, ParentID: sales.id, Name: team.name},
+			want: map[organizations.ID]map[organizations.ID]int{
+				dev.id:   {dev.id: 0},
+				team.id:  {team.id: 0, sales.id: 1},
+				infra.id: {infra.id: 0, team.id: 1, sales.id: 2},
+				sales.id: {sales.id: 0},
+			},
+			wantErr: nil,
+		},
+		{
+			name:       "move to root",
+			department: &organizations.Department{ID: team.id, CompanyID: companyID, ParentID: organizations.Root, Name: team.name},
+			want: map[organizations.ID]map[organizations.ID]int{
+				dev.id:   {dev.id: 0},
+				team.id:  {team.id: 0},
+				infra.id: {infra.id: 0, team.id: 1},
+				sales.id: {sales.id: 0},
+			},
+			wantErr: nil,
+		},
+		{
+			name:       "move root department",
+			department: &organizations.Department{ID: dev.id, CompanyID: companyID, ParentID: infra.id, Name: dev.name},
+			want: map[organizations.ID]map[organizations.ID]int{
+				dev.id:   {dev.id: 0, infra.id: 1, team.id: 2},
+				team.id:  {team.id: 0},
+				infra.id: {infra.id: 0, team.id: 1},
+				sales.id: {sales.id: 0},
+			},
+			wantErr: nil,
+		},
+		{
+			name:       "move to descendant",
+			department: &organizations.Department{ID: team.id, CompanyID: companyID, ParentID: dev.id, Name: team.name},
+			want: map[organizations.ID]map[organizations.ID]int{
+				dev.id:   {dev.id: 0, infra.id: 1, team.id: 2},
+				team.id:  {team.id: 0},
+				infra.id: {infra.id: 0, team.id: 1},
+				sales.id: {sales.id: 0},
+			},
+			wantErr: organizations.ErrCycle,
+		},
+		{
+			name:       "move to self",
+			department: &organizations.Department{ID: team.id, CompanyID: companyID, ParentID: team.id, Name: team.name},
+			want: map[organizations.ID]map[organizations.ID]int{
+				dev.id:   {dev.id: 0, infra.id: 1, team.id: 2},
+				team.id:  {team.id: 0},
+				infra.id: {infra.id: 0, team.id: 1},
+				sales.id: {sales.id: 0},
+			},
+			wantErr: organizations.ErrCycle,
+		},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+}
+
+func TestDepartment_Delete(t *testing.T) {
+	tableLock.Lock()
+	defer tableLock.Unlock()
+
+	db := newDB()
+	defer db.Close()
+	defer db.Exec("delete from users")
+	defer db.Exec("delete from companies")
+	defer db.Exec("delete from departments")
+
+	companyID := createCompany(db, "GREATE COMPANY")
+	dev := createDepartment(db, companyID, organizations.Root, "開発部")
+	team := createDepartment(db, companyID, dev.id, "開発一課")
+
+	type test struct {
+		name      string
+		companyID organizations.CompanyID
+		id        organizations.ID
+		wantErr   error
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.name, func(t *testing.T) {
+			err := NewDepartmentFromID(tt.companyID, tt.id).Delete(context.Background(), db)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("want-error=%v, error=%v.", tt.wantErr, err)
+			}
+		})
+	}
+
+	tests := []*test{
+		{
+			name:      "other company",
+			companyID: companyID + 1,
+			id:        team.id,
+			wantErr:   failure.ErrNotFound,
+		},
+		{
+			name:      "has children",
+			companyID: companyID,
+			id:        dev.id,
+			wantErr:   organizations.ErrHasChildren,
+		},
+		{
+			name:      "ok",
+			companyID: companyID,
+			id:        team.id,
+			wantErr:   nil,
+		},
+		{
+			name:      "ok parent",
+			companyID: companyID,
+			id:        dev.id,
+			wantErr:   nil,
+		},
+		{
+			name:      "not found",
+			companyID: companyID,
+			id:        dev.id,
+			wantErr:   failure.ErrNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+}
+
+func TestDepartments_Read(t *testing.T) {
+	tableLock.Lock()
+	defer tableLock.Unlock()
+
+	db := newDB()
+	defer db.Close()
+	defer db.Exec("delete from users")
+	defer db.Exec("delete from companies")
+	defer db.Exec("delete from departments")
+
+	companyID := createCompany(db, "GREATE COMPANY")
+	sales := createDepartment(db, companyID, organizations.Root, "営業部")
+	dev := createDepartment(db, companyID, organizations.Root, "開発部")
+	team1 := createDepartment(db, companyID, dev.id, "開発一課")
+	team2 := createDepartment(db, companyID, dev.id, "開発二課")
+
+	// 他社の部署は含まない
+	otherID := createCompany(db, "OTHER COMPANY")
+	createDepartment(db, otherID, organizations.Root, "営業部")
+
+	type test struct {
+		name     string
+		parentID organizations.ID
+		want     []*organizations.Department
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.name, func(t *testing.T) {
+			list := NewDepartmentsFromParentID(companyID, tt.parentID)
+			err := list.Read(context.Background(), db)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got := list.NewEntity()
+			for i := range got {
+				testDiffTime(t, time.Now(), got[i].UpdatedAt)
+				got[i].UpdatedAt = time.Time{}
+			}
+
+			if !reflect.DeepEqual(tt.want, got) {
+				t.Fatalf("want=%v, got=%v.", tt.want, got)
+			}
+		})
+	}
+
+	tests := []*test{
+		{
+			name:     "root",
+			parentID: organizations.Root,
+			want:     []*organizations.Department{departmentEntity(sales), departmentEntity(dev)},
+		},
+		{
+			name:     "children",
+			parentID: dev.id,
+			want:     []*organizations.Department{departmentEntity(team1), departmentEntity(team2)},
+		},
+		{
+			name:     "leaf",
+			parentID: team1.id,
+			want:     []*organizations.Department{},
+		},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+}
+
+func TestDepartmentAncestors_Read(t *testing.T) {
+	tableLock.Lock()
+	defer tableLock.Unlock()
+
+	db := newDB()
+	defer db.Close()
+	defer db.Exec("delete from users")
+	defer db.Exec("delete from companies")
+	defer db.Exec("delete from departments")
+
+	companyID := createCompany(db, "GREATE COMPANY")
+	dev := createDepartment(db, companyID, organizations.Root, "開発部")
+	team := createDepartment(db, companyID, dev.id, "開発一課")
+	infra := createDepartment(db, companyID, team.id, "基盤チーム")
+
+	type test struct {
+		name      string
+		companyID organizations.CompanyID
+		id        organizations.ID
+		want      []*organizations.Department
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.name, func(t *testing.T) {
+			list := NewDepartmentAncestors(tt.companyID, tt.id)
+			err := list.Read(context.Background(), db)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got := list.NewEntity()
+			for i := range got {
+				got[i].UpdatedAt = time.Time{}
+			}
+
+			if !reflect.DeepEqual(tt.want, got) {
+				t.Fatalf("want=%v, got=%v.", tt.want, got)
+			}
+		})
+	}
+
+	tests := []*test{
+		{
+			name:      "ok",
+			companyID: companyID,
+			id:        infra.id,
+			want:      []*organizations.Department{departmentEntity(dev), departmentEntity(team)},
+		},
+		{
+			name:      "root",
+			companyID: companyID,
+			id:        dev.id,
+			want:      []*organizations.Department{},
+		},
+		{
+			name:      "other company",
+			companyID: companyID + 1,
+			id:        infra.id,
+			want:      []*organizations.Department{},
+		},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+}
+
+func TestDepartmentSubtree_Read(t *testing.T) {
+	tableLock.Lock()
+	defer tableLock.Unlock()
+
+	db := newDB()
+	defer db.Close()
+	defer db.Exec("delete from users")
+	defer db.Exec("delete from companies")
+	defer db.Exec("delete from departments")
+
+	// 開発部 > 開発一課 > 基盤チーム
+	// 開発部 > 開発二課
+	companyID := createCompany(db, "GREATE COMPANY")
+	dev := createDepartment(db, companyID, organizations.Root, "開発部")
+	team1 := createDepartment(db, companyID, dev.id, "開発一課")
+	infra := createDepartment(db, companyID, team1.id, "基盤チーム")
+	team2 := createDepartment(db, companyID, dev.id, "開発二課")
+
+	type test struct {
+		name      string
+		companyID organizations.CompanyID
+		id        organizations.ID
+		want      []*organizations.Department
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.name, func(t *testing.T) {
+			list := NewDepartmentSubtree(tt.companyID, tt.id)
+			err := list.Read(context.Background(), db)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got := list.NewEntity()
+			for i := range got {
+				got[i].UpdatedAt = time.Time{}
+			}
+
+			if !reflect.DeepEqual(tt.want, got) {
+				t.Fatalf("want=%v, got=%v.", tt.want, got)
+			}
+		})
+	}
+
+	tests := []*test{
+		{
+			name:      "ok",
+			companyID: companyID,
+			id:        dev.id,
+			want: []*organizations.Department{
+				departmentEntity(dev),
+				departmentEntity(team1),
+				departmentEntity(team2),
+				departmentEntity(infra),
+			},
+		},
+		{
+			name:      "leaf",
+			companyID: companyID,
+			id:        infra.id,
+			want:      []*organizations.Department{departmentEntity(infra)},
+		},
+		{
+			name:      "other company",
+			companyID: companyID + 1,
+			id:        dev.id,
+			want:      []*organizations.Department{},
+		},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+}
@@ -0,0 +1,27 @@
+package model
+
+// SQL の方言
+// モデルは MySQL の書式で文を書き、MySQL のエラー番号で制約の違反を判定する
+// 他のデータベースでは、方言が文とエラーを MySQL との間で変換する
+// 作成した行の ID は sql.Result.LastInsertId で得るため、これに対応するドライバーであること
+type Dialect interface {
+	// MySQL の書式の文を方言の書式へ書き換える
+	Rebind(query string) string
+	// query の実行で発生したエラーを、MySQL のエラーへ変換する
+	// 変換できないエラーはそのまま返す
+	Error(query string, err error) error
+}
+
+// MySQL は変換しない
+var MySQL Dialect = mysqlDialect{}
+
+// impl Dialect
+type mysqlDialect struct{}
+
+func (mysqlDialect) Rebind(query string) string {
+	return query
+}
+
+func (mysqlDialect) Error(_ string, err error) error {
+	return err
+}
@@ -0,0 +1,18 @@
+package model
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestMySQL(t *testing.T) {
+	query := "insert into `users`(`name`) value (?)"
+	if got := MySQL.Rebind(query); got != query {
+		t.Fatalf("want=%v, got=%v.", query, got)
+	}
+
+	err := errors.New("test error")
+	if got := MySQL.Error(query, err); got != err {
+		t.Fatalf("want=%v, got=%v.", err, got)
+	}
+}
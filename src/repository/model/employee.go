@@ -0,0 +1,230 @@
+package model
+
+import (
+	"context"
+	"fmt"
+
+	companies "api.example.com/pkg/company"
+	employees "api.example.com/pkg/employee"
+	"api.example.com/pkg/failure"
+	permissions "api.example.com/pkg/permission"
+)
+
+type Employee interface {
+	Create(context.Context, DB) error
+	Read(context.Context, DB) error
+	// user_id で読み込む
+	ReadByUserID(context.Context, DB) error
+	Delete(context.Context, DB) error
+	NewEntity() *employees.Employee
+}
+
+// impl Employee
+type employee struct {
+	id            employees.ID
+	companyID     employees.CompanyID
+	userID        employees.UserID
+	administrator bool
+	// 作成時に付与する管理者の権限
+	permissions []permissions.Permission
+	createdAt   dateTime
+	updatedAt   dateTime
+}
+
+func NewEmployee(e *employees.Employee) Employee {
+	return &employee{
+		id:            e.ID,
+		companyID:     e.CompanyID,
+		userID:        e.UserID,
+		administrator: e.Administrator,
+	}
+}
+
+func NewEmployeeFromID(companyID employees.CompanyID, id employees.ID) Employee {
+	return &employee{
+		id:        id,
+		companyID: companyID,
+	}
+}
+
+func NewEmployeeFromUserID(companyID employees.CompanyID, userID employees.UserID) Employee {
+	return &employee{
+		companyID: companyID,
+		userID:    userID,
+	}
+}
+
+// 会社の owner を全ての権限を持つ管理者の従業員とする
+func NewAdministrator(c *companies.Company) Employee {
+	return &employee{
+		companyID:     c.ID,
+		userID:        c.OwnerID,
+		administrator: true,
+		permissions:   permissions.All,
+	}
+}
+
+func (e *employee) Create(ctx context.Context, tx DB) error {
+	now := currentTime()
+	result, err := tx.ExecContext(
+		ctx,
+		"insert into `company_employees`(`company_id`, `user_id`, `administrator`, `created_at`, `updated_at`) value (?, ?, ?, ?, ?)",
+		e.companyID,
+		e.userID,
+		e.administrator,
+		now,
+		now,
+	)
+	if err != nil {
+		return fmt.Errorf("repository/model.Employee.Create: %w", conflict(err, map[uint16]string{
+			erDupEntry:        "employee.user_id",
+			erNoReferencedRow: "employee.user_id",
+		}))
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("repository/model.Employee.Create: %w", err)
+	}
+
+	e.id = employees.ID(id)
+	for _, p := range e.permissions {
+		err = NewPermission(e.companyID, e.id, p).Create(ctx, tx)
+		if err != nil {
+			return fmt.Errorf("repository/model.Employee.Create: %w", err)
+		}
+	}
+
+	e.createdAt = now
+	e.updatedAt = now
+	return nil
+}
+
+func (e *employee) Read(ctx context.Context, tx DB) error {
+	err := tx.QueryRowContext(
+		ctx,
+		"select `user_id`, `administrator`, `created_at`, `updated_at` from `company_employees` where `id`=? and `company_id`=?",
+		e.id,
+		e.companyID,
+	).Scan(&e.userID, &e.administrator, &e.createdAt, &e.updatedAt)
+	if err != nil {
+		return fmt.Errorf("repository/model.Employee.Read: %w", notFound(err))
+	}
+
+	return nil
+}
+
+func (e *employee) ReadByUserID(ctx context.Context, tx DB) error {
+	err := tx.QueryRowContext(
+		ctx,
+		"select `id`, `administrator`, `created_at`, `updated_at` from `company_employees` where `user_id`=? and `company_id`=?",
+		e.userID,
+		e.companyID,
+	).Scan(&e.id, &e.administrator, &e.createdAt, &e.updatedAt)
+	if err != nil {
+		return fmt.Errorf("repository/model.Employee.ReadByUserID: %w", notFound(err))
+	}
+
+	return nil
+}
+
+func (e *employee) Delete(ctx context.Context, tx DB) error {
+	result, err := tx.ExecContext(
+		ctx,
+		"delete from `company_employees` where `id`=? and `company_id`=?",
+		e.id,
+		e.companyID,
+	)
+	if err != nil {
+		return fmt.Errorf("repository/model.Employee.Delete: %w", err)
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("repository/model.Employee.Delete: %w", err)
+	}
+
+	if count != 1 {
+		return fmt.Errorf("repository/model.Employee.Delete: %w: rows affected not 1 (affected=%d)", failure.ErrNotFound, count)
+	}
+
+	return nil
+}
+
+func (e *employee) NewEntity() *employees.Employee {
+	return &employees.Employee{
+		ID:            e.id,
+		CompanyID:     e.companyID,
+		UserID:        e.userID,
+		Administrator: e.administrator,
+		UpdatedAt:     e.updatedAt,
+	}
+}
+
+// 会社に所属する従業員の一覧
+type Employees interface {
+	Read(context.Context, DB) error
+	NewEntity() []*employees.Employee
+}
+
+// impl Employees
+type employeeList struct {
+	companyID employees.CompanyID
+	// 管理者のみ
+	administrators bool
+	list           []*employee
+}
+
+func NewEmployeesFromCompanyID(companyID employees.CompanyID) Employees {
+	return &employeeList{
+		companyID: companyID,
+	}
+}
+
+// 会社の管理者の一覧
+// 管理者が同時に削除されないよう、読み込んだ行をロックする
+func NewAdministratorsFromCompanyID(companyID employees.CompanyID) Employees {
+	return &employeeList{
+		companyID:      companyID,
+		administrators: true,
+	}
+}
+
+func (l *employeeList) Read(ctx context.Context, tx DB) error {
+	query := "select `id`, `user_id`, `administrator`, `created_at`, `updated_at` from `company_employees` where `company_id`=? order by `id`"
+	if l.administrators {
+		query = "select `id`, `user_id`, `administrator`, `created_at`, `updated_at` from `company_employees` where `company_id`=? and `administrator`=1 order by `id` for update"
+	}
+
+	rows, err := tx.QueryContext(ctx, query, l.companyID)
+	if err != nil {
+		return fmt.Errorf("repository/model.Employees.Read: %w", err)
+	}
+	defer rows.Close()
+
+	list := []*employee{}
+	for rows.Next() {
+		e := &employee{companyID: l.companyID}
+		err = rows.Scan(&e.id, &e.userID, &e.administrator, &e.createdAt, &e.updatedAt)
+		if err != nil {
+			return fmt.Errorf("repository/model.Employees.Read: %w", err)
+		}
+		list = append(list, e)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return fmt.Errorf("repository/model.Employees.Read: %w", err)
+	}
+
+	l.list = list
+	return nil
+}
+
+func (l *employeeList) NewEntity() []*employees.Employee {
+	list := make([]*employees.Employee, 0, len(l.list))
+	for _, e := range l.list {
+		list = append(list, e.NewEntity())
+	}
+	return list
+}
@@ -0,0 +1,647 @@
+package model
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	companies "api.example.com/pkg/company"
+	employees "api.example.com/pkg/employee"
+	permissions "api.example.com/pkg/permission"
+	users "api.example.com/pkg/user"
+)
+
+func TestNewAdministrator(t *testing.T) {
+	type test struct {
+		name    string
+		company *companies.Company
+		want    Employee
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.name, func(t *testing.T) {
+			got := NewAdministrator(tt.company)
+			if !reflect.DeepEqual(tt.want, got) {
+				t.Fatalf("want=%v, got=%v.", tt.want, got)
+			}
+		})
+	}
+
+	tests := []*test{
+		{
+			name: "ok",
+			company: &companies.Company{
+				ID:      1,
+				Name:    "GREATE COMPANY",
+				OwnerID: 2,
+			},
+			want: &employee{
+				companyID:     1,
+				userID:        2,
+				administrator: true,
+				permissions:   permissions.All,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+}
+
+func TestEmployee_Create(t *testing.T) {
+	tableLock.Lock()
+	defer tableLock.Unlock()
+
+	db := newDB()
+	defer db.Close()
+	defer db.Exec("delete from users")
+	defer db.Exec("delete from companies")
+	defer db.Exec("delete from company_employees")
+
+	type test struct {
+		name     string
+		db       DB
+		employee *employee
+		want     *employee
+		wantErr  bool
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.employee
+
+			err := got.Create(context.Background(), tt.db)
+			if tt.wantErr != (err != nil) {
+				t.Fatalf("want-error=%v, error=%v.", tt.wantErr, err)
+			}
+
+			if tt.wantErr {
+				return
+			}
+
+			wantTime := time.Now()
+			tt.want.id = got.id
+			testDiffTime(t, wantTime, got.createdAt)
+			tt.want.createdAt = got.createdAt
+			testDiffTime(t, wantTime, got.updatedAt)
+			tt.want.updatedAt = got.updatedAt
+
+			if !reflect.DeepEqual(tt.want, got) {
+				t.Fatalf("want=%v, got=%v.", tt.want, got)
+			}
+
+			// 作成されていることの確認
+			err = db.
+				QueryRow("select company_id, user_id, administrator from company_employees where id = ?", got.id).
+				Scan(&got.companyID, &got.userID, &got.administrator)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !reflect.DeepEqual(tt.want, got) {
+				t.Fatalf("want=%v, got=%v.", tt.want, got)
+			}
+		})
+	}
+
+	tests := []*test{
+		func() *test {
+			company := NewCompany(companies.New("GREATE COMPANY", createOwner(db, "Bob"))).(*company)
+			err := company.Create(context.Background(), db)
+			if err != nil {
+				panic(err)
+			}
+
+			return &test{
+				name:     "ok",
+				db:       db,
+				employee: NewAdministrator(company.NewEntity()).(*employee),
+				want: &employee{
+					companyID:     company.id,
+					userID:        company.ownerID,
+					administrator: true,
+					permissions:   permissions.All,
+				},
+				wantErr: false,
+			}
+		}(),
+		{
+			name: "failed ExecContext",
+			db: &testdb{
+				err:         errors.New("test error"),
+				execContext: true,
+			},
+			employee: &employee{companyID: 1, userID: 1},
+			want:     nil,
+			wantErr:  true,
+		},
+		{
+			name: "failed result.LastInsertId",
+			db: &testdb{
+				result: &queryResult{
+					err:          errors.New("test error"),
+					lastInsertID: true,
+				},
+				execContext: true,
+			},
+			employee: &employee{companyID: 1, userID: 1},
+			want:     nil,
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+}
+
+func TestNewEmployee(t *testing.T) {
+	type test struct {
+		name     string
+		employee *employees.Employee
+		want     Employee
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.name, func(t *testing.T) {
+			got := NewEmployee(tt.employee)
+			if !reflect.DeepEqual(tt.want, got) {
+				t.Fatalf("want=%v, got=%v.", tt.want, got)
+			}
+		})
+	}
+
+	tests := []*test{
+		{
+			name:     "ok",
+			employee: employees.New(1, 2, false),
+			want: &employee{
+				companyID: 1,
+				userID:    2,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+}
+
+func TestNewEmployeeFromID(t *testing.T) {
+	got := NewEmployeeFromID(1, 3)
+	want := &employee{id: 3, companyID: 1}
+	if !reflect.DeepEqual(want, got) {
+		t.Fatalf("want=%v, got=%v.", want, got)
+	}
+}
+
+func TestEmployee_NewEntity(t *testing.T) {
+	type test struct {
+		name     string
+		employee Employee
+		want     *employees.Employee
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.employee.NewEntity()
+			if !reflect.DeepEqual(tt.want, got) {
+				t.Fatalf("want=%v, got=%v.", tt.want, got)
+			}
+		})
+	}
+
+	tests := []*test{
+		{
+			name: "ok",
+			employee: &employee{
+				id:            3,
+				companyID:     1,
+				userID:        2,
+				administrator: true,
+				updatedAt:     time.Date(2022, 9, 3, 12, 34, 56, 0, time.UTC),
+			},
+			want: &employees.Employee{
+				ID:            3,
+				CompanyID:     1,
+				UserID:        2,
+				Administrator: true,
+				UpdatedAt:     time.Date(2022, 9, 3, 12, 34, 56, 0, time.UTC),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+}
+
+func TestEmployee_Read(t *testing.T) {
+	tableLock.Lock()
+	defer tableLock.Unlock()
+
+	db := newDB()
+	defer db.Close()
+	defer db.Exec("delete from users")
+	defer db.Exec("delete from companies")
+	defer db.Exec("delete from company_employees")
+
+	type test struct {
+		name      string
+		db        DB
+		companyID employees.CompanyID
+		id        employees.ID
+		want      *employee
+		wantErr   bool
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.name, func(t *testing.T) {
+			got := NewEmployeeFromID(tt.companyID, tt.id).(*employee)
+			err := got.Read(context.Background(), tt.db)
+			if tt.wantErr != (err != nil) {
+				t.Fatalf("want-error=%v, error=%v.", tt.wantErr, err)
+			}
+
+			if tt.wantErr {
+				return
+			}
+
+			testDiffTime(t, tt.want.createdAt, got.createdAt)
+			tt.want.createdAt = got.createdAt
+			testDiffTime(t, tt.want.updatedAt, got.updatedAt)
+			tt.want.updatedAt = got.updatedAt
+
+			if !reflect.DeepEqual(tt.want, got) {
+				t.Fatalf("want=%v, got=%v.", tt.want, got)
+			}
+		})
+	}
+
+	company := NewCompany(companies.New("GREATE COMPANY", createOwner(db, "Bob"))).(*company)
+	err := company.Create(context.Background(), db)
+	if err != nil {
+		panic(err)
+	}
+
+	admin := NewAdministrator(company.NewEntity()).(*employee)
+	err = admin.Create(context.Background(), db)
+	if err != nil {
+		panic(err)
+	}
+
+	// 権限は作成時のみ使い、Read では読み込まない
+	want := *admin
+	want.permissions = nil
+
+	tests := []*test{
+		{
+			name:      "ok",
+			db:        db,
+			companyID: company.id,
+			id:        admin.id,
+			want:      &want,
+			wantErr:   false,
+		},
+		{
+			name:      "other company",
+			db:        db,
+			companyID: company.id + 1,
+			id:        admin.id,
+			want:      nil,
+			wantErr:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+}
+
+func TestEmployee_ReadByUserID(t *testing.T) {
+	tableLock.Lock()
+	defer tableLock.Unlock()
+
+	db := newDB()
+	defer db.Close()
+	defer db.Exec("delete from users")
+	defer db.Exec("delete from companies")
+	defer db.Exec("delete from company_employees")
+
+	type test struct {
+		name      string
+		db        DB
+		companyID employees.CompanyID
+		userID    employees.UserID
+		want      *employee
+		wantErr   bool
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.name, func(t *testing.T) {
+			got := NewEmployeeFromUserID(tt.companyID, tt.userID).(*employee)
+			err := got.ReadByUserID(context.Background(), tt.db)
+			if tt.wantErr != (err != nil) {
+				t.Fatalf("want-error=%v, error=%v.", tt.wantErr, err)
+			}
+
+			if tt.wantErr {
+				return
+			}
+
+			testDiffTime(t, tt.want.createdAt, got.createdAt)
+			tt.want.createdAt = got.createdAt
+			testDiffTime(t, tt.want.updatedAt, got.updatedAt)
+			tt.want.updatedAt = got.updatedAt
+
+			if !reflect.DeepEqual(tt.want, got) {
+				t.Fatalf("want=%v, got=%v.", tt.want, got)
+			}
+		})
+	}
+
+	company := NewCompany(companies.New("GREATE COMPANY", createOwner(db, "Bob"))).(*company)
+	err := company.Create(context.Background(), db)
+	if err != nil {
+		panic(err)
+	}
+
+	admin := NewAdministrator(company.NewEntity()).(*employee)
+	err = admin.Create(context.Background(), db)
+	if err != nil {
+		panic(err)
+	}
+
+	want := *admin
+	want.permissions = nil
+
+	tests := []*test{
+		{
+			name:      "ok",
+			db:        db,
+			companyID: company.id,
+			userID:    admin.userID,
+			want:      &want,
+			wantErr:   false,
+		},
+		{
+			name:      "not employee",
+			db:        db,
+			companyID: company.id,
+			userID:    admin.userID + 1,
+			want:      nil,
+			wantErr:   true,
+		},
+		{
+			name:      "other company",
+			db:        db,
+			companyID: company.id + 1,
+			userID:    admin.userID,
+			want:      nil,
+			wantErr:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+}
+
+func TestEmployee_Delete(t *testing.T) {
+	tableLock.Lock()
+	defer tableLock.Unlock()
+
+	db := newDB()
+	defer db.Close()
+	defer db.Exec("delete from users")
+	defer db.Exec("delete from companies")
+	defer db.Exec("delete from company_employees")
+
+	type test struct {
+		name      string
+		db        DB
+		companyID employees.CompanyID
+		id        employees.ID
+		wantErr   bool
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.name, func(t *testing.T) {
+			err := NewEmployeeFromID(tt.companyID, tt.id).Delete(context.Background(), tt.db)
+			if tt.wantErr != (err != nil) {
+				t.Fatalf("want-error=%v, error=%v.", tt.wantErr, err)
+			}
+
+			if tt.wantErr {
+				return
+			}
+
+			var count int64
+			err = db.
+				QueryRow("select count(*) from company_employees where id = ?", tt.id).
+				Scan(&count)
+			if count != 0 {
+				t.Fatalf("failed delete id=%v, count=%v.", tt.id, count)
+			}
+		})
+	}
+
+	tests := []*test{
+		func() *test {
+			company := NewCompany(companies.New("GREATE COMPANY", createOwner(db, "Bob"))).(*company)
+			err := company.Create(context.Background(), db)
+			if err != nil {
+				panic(err)
+			}
+
+			admin := NewAdministrator(company.NewEntity()).(*employee)
+			err = admin.Create(context.Background(), db)
+			if err != nil {
+				panic(err)
+			}
+
+			return &test{
+				name:      "ok",
+				db:        db,
+				companyID: company.id,
+				id:        admin.id,
+				wantErr:   false,
+			}
+		}(),
+		{
+			name: "failed ExecContext",
+			db: &testdb{
+				err:         errors.New("test error"),
+				execContext: true,
+			},
+			companyID: 1,
+			id:        1,
+			wantErr:   true,
+		},
+		{
+			name: "failed result.RowsAffected",
+			db: &testdb{
+				result: &queryResult{
+					err:          errors.New("test error"),
+					rowsAffected: true,
+				},
+				execContext: true,
+			},
+			companyID: 1,
+			id:        1,
+			wantErr:   true,
+		},
+		{
+			name: "invalid rows-affected",
+			db: &testdb{
+				result: &queryResult{
+					rows:         0,
+					rowsAffected: true,
+				},
+				execContext: true,
+			},
+			companyID: 1,
+			id:        1,
+			wantErr:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+}
+
+func TestEmployees_Read(t *testing.T) {
+	tableLock.Lock()
+	defer tableLock.Unlock()
+
+	db := newDB()
+	defer db.Close()
+	defer db.Exec("delete from users")
+	defer db.Exec("delete from companies")
+	defer db.Exec("delete from company_employees")
+
+	type test struct {
+		name      string
+		db        DB
+		companyID employees.CompanyID
+		// 管理者のみ読み込むか
+		administrators bool
+		want           []*employees.Employee
+		wantErr        bool
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.name, func(t *testing.T) {
+			list := NewEmployeesFromCompanyID(tt.companyID)
+			if tt.administrators {
+				list = NewAdministratorsFromCompanyID(tt.companyID)
+			}
+
+			err := list.Read(context.Background(), tt.db)
+			if tt.wantErr != (err != nil) {
+				t.Fatalf("want-error=%v, error=%v.", tt.wantErr, err)
+			}
+
+			if tt.wantErr {
+				return
+			}
+
+			// id, updated_at は比較対象外とする
+			got := list.NewEntity()
+			for i := range got {
+				testDiffTime(t, time.Now(), got[i].UpdatedAt)
+				got[i].ID = 0
+				got[i].UpdatedAt = time.Time{}
+			}
+
+			if !reflect.DeepEqual(tt.want, got) {
+				t.Fatalf("want=%v, got=%v.", tt.want, got)
+			}
+		})
+	}
+
+	// 副業: 同じユーザーが複数の会社に所属する
+	bob := createOwner(db, "Bob")
+	alice := createOwner(db, "Alice")
+
+	newCompany := func(name companies.Name, ownerID users.ID) *company {
+		c := NewCompany(companies.New(name, ownerID)).(*company)
+		err := c.Create(context.Background(), db)
+		if err != nil {
+			panic(err)
+		}
+
+		admin := NewAdministrator(c.NewEntity()).(*employee)
+		err = admin.Create(context.Background(), db)
+		if err != nil {
+			panic(err)
+		}
+		return c
+	}
+
+	companyA := newCompany("COMPANY A", bob)
+	companyB := newCompany("COMPANY B", alice)
+
+	member := NewEmployee(employees.New(companyB.id, bob, false))
+	err := member.Create(context.Background(), db)
+	if err != nil {
+		panic(err)
+	}
+
+	tests := []*test{
+		{
+			name:      "ok",
+			db:        db,
+			companyID: companyB.id,
+			want: []*employees.Employee{
+				{CompanyID: companyB.id, UserID: alice, Administrator: true},
+				{CompanyID: companyB.id, UserID: bob, Administrator: false},
+			},
+			wantErr: false,
+		},
+		{
+			name:      "ok (only company A)",
+			db:        db,
+			companyID: companyA.id,
+			want: []*employees.Employee{
+				{CompanyID: companyA.id, UserID: bob, Administrator: true},
+			},
+			wantErr: false,
+		},
+		{
+			name:           "administrators",
+			db:             db,
+			companyID:      companyB.id,
+			administrators: true,
+			want: []*employees.Employee{
+				{CompanyID: companyB.id, UserID: alice, Administrator: true},
+			},
+			wantErr: false,
+		},
+		{
+			name:      "empty",
+			db:        db,
+			companyID: companyB.id + 1,
+			want:      []*employees.Employee{},
+			wantErr:   false,
+		},
+		{
+			name: "failed QueryContext",
+			db: &testdb{
+				err:          errors.New("test error"),
+				queryContext: true,
+			},
+			companyID: 1,
+			want:      nil,
+			wantErr:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+}
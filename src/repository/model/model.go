@@ -3,12 +3,19 @@ package model
 import (
 	"context"
 	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
 	"time"
+
+	"api.example.com/pkg/failure"
+	"github.com/go-sql-driver/mysql"
 )
 
 // SQL 抽象化
 type DB interface {
 	ExecContext(context.Context, string, ...interface{}) (sql.Result, error)
+	QueryContext(context.Context, string, ...interface{}) (*sql.Rows, error)
 	QueryRowContext(context.Context, string, ...interface{}) *sql.Row
 }
 
@@ -17,3 +24,51 @@ type dateTime = time.Time
 func currentTime() dateTime {
 	return time.Now().Round(time.Second)
 }
+
+// 該当する行がなければ failure.ErrNotFound
+func notFound(err error) error {
+	if errors.Is(err, sql.ErrNoRows) {
+		return fmt.Errorf("%w: %v", failure.ErrNotFound, err)
+	}
+	return err
+}
+
+// like で前方一致させるパターン
+// MySQL と SQLite で既定のエスケープ文字が異なるため、 escape '!' と共に使う
+func likePrefix(prefix string) string {
+	return strings.NewReplacer("!", "!!", "%", "!%", "_", "!_").Replace(prefix) + "%"
+}
+
+// MySQL のエラー番号
+const (
+	// 一意制約の違反
+	erDupEntry uint16 = 1062
+	// 参照されている行の削除、更新
+	erRowIsReferenced uint16 = 1451
+	// 存在しない行への参照
+	erNoReferencedRow uint16 = 1452
+)
+
+// 制約の違反であれば、違反した項目を持つ failure.ErrConflict
+// fields はエラー番号ごとの項目 (例: user.name)
+func conflict(err error, fields map[uint16]string) error {
+	var e *mysql.MySQLError
+	if !errors.As(err, &e) {
+		return err
+	}
+
+	field := fields[e.Number]
+	var c error
+	switch e.Number {
+	case erDupEntry:
+		c = failure.Conflict(strings.TrimSpace(field+" already exists"), field, failure.RuleUnique)
+	case erRowIsReferenced:
+		c = failure.Conflict(strings.TrimSpace(field+" is still referenced"), field, failure.RuleUnreferenced)
+	case erNoReferencedRow:
+		c = failure.Conflict(strings.TrimSpace(field+" does not exist"), field, failure.RuleExists)
+	default:
+		return err
+	}
+
+	return fmt.Errorf("%w: %v", c, err)
+}
@@ -2,11 +2,14 @@ package model
 
 import (
 	"api.example.com/env"
+	"api.example.com/pkg/failure"
 	"context"
 	"database/sql"
 	"errors"
 	"fmt"
+	"github.com/go-sql-driver/mysql"
 	"math"
+	"reflect"
 	"sync"
 	"testing"
 	"time"
@@ -40,7 +43,7 @@ type testdb struct {
 	result sql.Result
 	err    error
 	// flag
-	execContext bool
+	execContext, queryContext bool
 }
 
 func (db *testdb) ExecContext(context.Context, string, ...interface{}) (sql.Result, error) {
@@ -50,6 +53,13 @@ func (db *testdb) ExecContext(context.Context, string, ...interface{}) (sql.Resu
 	return nil, errors.New("test invalid ExecContext")
 }
 
+func (db *testdb) QueryContext(context.Context, string, ...interface{}) (*sql.Rows, error) {
+	if db.queryContext {
+		return nil, db.err
+	}
+	return nil, errors.New("test invalid QueryContext")
+}
+
 func (db *testdb) QueryRowContext(context.Context, string, ...interface{}) *sql.Row {
 	panic("test invalid QueryRowContext")
 }
@@ -90,3 +100,137 @@ func TestCurrentTime(t *testing.T) {
 
 	testDiffTime(t, want, got)
 }
+
+func TestLikePrefix(t *testing.T) {
+	type test struct {
+		prefix string
+		want   string
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.prefix, func(t *testing.T) {
+			got := likePrefix(tt.prefix)
+			if tt.want != got {
+				t.Fatalf("want=%v, got=%v.", tt.want, got)
+			}
+		})
+	}
+
+	tests := []*test{
+		{prefix: "bob", want: "bob%"},
+		{prefix: "", want: "%"},
+		{prefix: "50%_off!", want: "50!%!_off!!%"},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+}
+
+func TestNotFound(t *testing.T) {
+	type test struct {
+		name     string
+		err      error
+		notFound bool
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.name, func(t *testing.T) {
+			got := notFound(tt.err)
+			if tt.notFound != errors.Is(got, failure.ErrNotFound) {
+				t.Fatalf("want-not-found=%v, got=%v.", tt.notFound, got)
+			}
+		})
+	}
+
+	tests := []*test{
+		{
+			name:     "no rows",
+			err:      fmt.Errorf("test: %w", sql.ErrNoRows),
+			notFound: true,
+		},
+		{
+			name:     "other",
+			err:      errors.New("test error"),
+			notFound: false,
+		},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+}
+
+func TestConflict(t *testing.T) {
+	fields := map[uint16]string{
+		erDupEntry:        "user.name",
+		erRowIsReferenced: "user_id",
+	}
+
+	type test struct {
+		name        string
+		err         error
+		conflict    bool
+		wantMessage string
+		want        []failure.Violation
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.name, func(t *testing.T) {
+			got := conflict(tt.err, fields)
+			if tt.conflict != errors.Is(got, failure.ErrConflict) {
+				t.Fatalf("want-conflict=%v, got=%v.", tt.conflict, got)
+			}
+
+			if !tt.conflict {
+				return
+			}
+
+			if tt.wantMessage != failure.Message(got) {
+				t.Fatalf("want=%v, got=%v.", tt.wantMessage, failure.Message(got))
+			}
+
+			if v := failure.Violations(got); !reflect.DeepEqual(tt.want, v) {
+				t.Fatalf("want=%v, got=%v.", tt.want, v)
+			}
+		})
+	}
+
+	tests := []*test{
+		{
+			name:        "duplicate entry",
+			err:         fmt.Errorf("test: %w", &mysql.MySQLError{Number: erDupEntry, Message: "Duplicate entry"}),
+			conflict:    true,
+			wantMessage: "user.name already exists",
+			want:        []failure.Violation{{Field: "user.name", Rule: failure.RuleUnique}},
+		},
+		{
+			name:        "row is referenced",
+			err:         &mysql.MySQLError{Number: erRowIsReferenced, Message: "Cannot delete or update a parent row"},
+			conflict:    true,
+			wantMessage: "user_id is still referenced",
+			want:        []failure.Violation{{Field: "user_id", Rule: failure.RuleUnreferenced}},
+		},
+		{
+			name:        "no referenced row without field",
+			err:         &mysql.MySQLError{Number: erNoReferencedRow, Message: "Cannot add or update a child row"},
+			conflict:    true,
+			wantMessage: "does not exist",
+			want:        nil,
+		},
+		{
+			name:     "other mysql error",
+			err:      &mysql.MySQLError{Number: 1064, Message: "syntax error"},
+			conflict: false,
+		},
+		{
+			name:     "other",
+			err:      errors.New("test error"),
+			conflict: false,
+		},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+}
@@ -0,0 +1,136 @@
+package model
+
+import (
+	"context"
+	"fmt"
+
+	"api.example.com/pkg/failure"
+	permissions "api.example.com/pkg/permission"
+)
+
+// 管理者の権限は `employee_permissions` に1権限1行で保存する
+// 従業員が会社に属することは呼び出し側で確認すること
+type Permission interface {
+	Create(context.Context, DB) error
+	Delete(context.Context, DB) error
+	NewEntity() permissions.Permission
+}
+
+// impl Permission
+type permission struct {
+	companyID  permissions.CompanyID
+	employeeID permissions.EmployeeID
+	permission permissions.Permission
+}
+
+func NewPermission(companyID permissions.CompanyID, employeeID permissions.EmployeeID, p permissions.Permission) Permission {
+	return &permission{
+		companyID:  companyID,
+		employeeID: employeeID,
+		permission: p,
+	}
+}
+
+func (p *permission) Create(ctx context.Context, tx DB) error {
+	now := currentTime()
+	_, err := tx.ExecContext(
+		ctx,
+		"insert into `employee_permissions`(`company_employee_id`, `permission`, `created_at`, `updated_at`) value (?, ?, ?, ?)",
+		p.employeeID,
+		p.permission,
+		now,
+		now,
+	)
+	if err != nil {
+		return fmt.Errorf("repository/model.Permission.Create: %w", err)
+	}
+
+	return nil
+}
+
+func (p *permission) Delete(ctx context.Context, tx DB) error {
+	result, err := tx.ExecContext(
+		ctx,
+		"delete `p` from `employee_permissions` `p` "+
+			"join `company_employees` `e` on `e`.`id`=`p`.`company_employee_id` "+
+			"where `p`.`company_employee_id`=? and `p`.`permission`=? and `e`.`company_id`=?",
+		p.employeeID,
+		p.permission,
+		p.companyID,
+	)
+	if err != nil {
+		return fmt.Errorf("repository/model.Permission.Delete: %w", err)
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("repository/model.Permission.Delete: %w", err)
+	}
+
+	if count != 1 {
+		return fmt.Errorf("repository/model.Permission.Delete: %w: rows affected not 1 (affected=%d)", failure.ErrNotFound, count)
+	}
+
+	return nil
+}
+
+func (p *permission) NewEntity() permissions.Permission {
+	return p.permission
+}
+
+// 従業員の権限の一覧
+type Permissions interface {
+	Read(context.Context, DB) error
+	NewEntity() []permissions.Permission
+}
+
+// impl Permissions
+type permissionList struct {
+	companyID  permissions.CompanyID
+	employeeID permissions.EmployeeID
+	list       []permissions.Permission
+}
+
+func NewPermissionsFromEmployeeID(companyID permissions.CompanyID, employeeID permissions.EmployeeID) Permissions {
+	return &permissionList{
+		companyID:  companyID,
+		employeeID: employeeID,
+	}
+}
+
+func (l *permissionList) Read(ctx context.Context, tx DB) error {
+	rows, err := tx.QueryContext(
+		ctx,
+		"select `p`.`permission` from `employee_permissions` `p` "+
+			"join `company_employees` `e` on `e`.`id`=`p`.`company_employee_id` "+
+			"where `p`.`company_employee_id`=? and `e`.`company_id`=? order by `p`.`id`",
+		l.employeeID,
+		l.companyID,
+	)
+	if err != nil {
+		return fmt.Errorf("repository/model.Permissions.Read: %w", err)
+	}
+	defer rows.Close()
+
+	list := []permissions.Permission{}
+	for rows.Next() {
+		var p permissions.Permission
+		err = rows.Scan(&p)
+		if err != nil {
+			return fmt.Errorf("repository/model.Permissions.Read: %w", err)
+		}
+		list = append(list, p)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return fmt.Errorf("repository/model.Permissions.Read: %w", err)
+	}
+
+	l.list = list
+	return nil
+}
+
+func (l *permissionList) NewEntity() []permissions.Permission {
+	return l.list
+}
@@ -0,0 +1,139 @@
+package model
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	companies "api.example.com/pkg/company"
+	employees "api.example.com/pkg/employee"
+	permissions "api.example.com/pkg/permission"
+)
+
+func TestNewPermission(t *testing.T) {
+	got := NewPermission(1, 2, permissions.ManageRoles)
+	want := &permission{companyID: 1, employeeID: 2, permission: permissions.ManageRoles}
+	if !reflect.DeepEqual(want, got) {
+		t.Fatalf("want=%v, got=%v.", want, got)
+	}
+}
+
+func TestPermission(t *testing.T) {
+	tableLock.Lock()
+	defer tableLock.Unlock()
+
+	db := newDB()
+	defer db.Close()
+	defer db.Exec("delete from users")
+	defer db.Exec("delete from companies")
+
+	companyA := createCompany(db, "COMPANY A")
+	companyB := createCompany(db, "COMPANY B")
+	admin := NewEmployee(employees.New(companyA, createOwner(db, "Alice"), true)).(*employee)
+	err := admin.Create(context.Background(), db)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	read := func(t *testing.T, companyID permissions.CompanyID) []permissions.Permission {
+		t.Helper()
+
+		list := NewPermissionsFromEmployeeID(companyID, admin.id)
+		err := list.Read(context.Background(), db)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		return list.NewEntity()
+	}
+
+	t.Run("create", func(t *testing.T) {
+		err := NewPermission(companyA, admin.id, permissions.ManageRoles).Create(context.Background(), db)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		err = NewPermission(companyA, admin.id, permissions.ManageEmployees).Create(context.Background(), db)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		want := []permissions.Permission{permissions.ManageRoles, permissions.ManageEmployees}
+		if got := read(t, companyA); !reflect.DeepEqual(want, got) {
+			t.Fatalf("want=%v, got=%v.", want, got)
+		}
+	})
+
+	t.Run("create duplicate", func(t *testing.T) {
+		err := NewPermission(companyA, admin.id, permissions.ManageRoles).Create(context.Background(), db)
+		if err == nil {
+			t.Fatal("want error")
+		}
+	})
+
+	t.Run("read other company", func(t *testing.T) {
+		want := []permissions.Permission{}
+		if got := read(t, companyB); !reflect.DeepEqual(want, got) {
+			t.Fatalf("want=%v, got=%v.", want, got)
+		}
+	})
+
+	t.Run("delete other company", func(t *testing.T) {
+		err := NewPermission(companyB, admin.id, permissions.ManageRoles).Delete(context.Background(), db)
+		if err == nil {
+			t.Fatal("want error")
+		}
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		err := NewPermission(companyA, admin.id, permissions.ManageRoles).Delete(context.Background(), db)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		want := []permissions.Permission{permissions.ManageEmployees}
+		if got := read(t, companyA); !reflect.DeepEqual(want, got) {
+			t.Fatalf("want=%v, got=%v.", want, got)
+		}
+	})
+
+	t.Run("delete not granted", func(t *testing.T) {
+		err := NewPermission(companyA, admin.id, permissions.ManageRoles).Delete(context.Background(), db)
+		if err == nil {
+			t.Fatal("want error")
+		}
+	})
+}
+
+func TestEmployee_Create_administratorPermissions(t *testing.T) {
+	tableLock.Lock()
+	defer tableLock.Unlock()
+
+	db := newDB()
+	defer db.Close()
+	defer db.Exec("delete from users")
+	defer db.Exec("delete from companies")
+
+	company := NewCompany(companies.New("GREATE COMPANY", createOwner(db, "Bob"))).(*company)
+	err := company.Create(context.Background(), db)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 会社を作成したユーザーは全ての権限を持つ
+	admin := NewAdministrator(company.NewEntity()).(*employee)
+	err = admin.Create(context.Background(), db)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	list := NewPermissionsFromEmployeeID(company.id, admin.id)
+	err = list.Read(context.Background(), db)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got := list.NewEntity(); !reflect.DeepEqual(permissions.All, got) {
+		t.Fatalf("want=%v, got=%v.", permissions.All, got)
+	}
+}
@@ -0,0 +1,279 @@
+package model
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"api.example.com/pkg/failure"
+	roles "api.example.com/pkg/role"
+	"github.com/go-sql-driver/mysql"
+)
+
+// 肩書きの名前は `roles` で全ての会社に共有し、
+// 会社の肩書きは `company_roles` で会社と名前を紐付ける
+// 肩書きの ID は `company_roles` の ID とする
+type Role interface {
+	Create(context.Context, DB) error
+	Read(context.Context, DB) error
+	Update(context.Context, DB) error
+	Delete(context.Context, DB) error
+	NewEntity() *roles.Role
+}
+
+// impl Role
+type role struct {
+	id        roles.ID
+	companyID roles.CompanyID
+	name      roles.Name
+	createdAt dateTime
+	updatedAt dateTime
+}
+
+func NewRole(r *roles.Role) Role {
+	return &role{
+		id:        r.ID,
+		companyID: r.CompanyID,
+		name:      r.Name,
+	}
+}
+
+func NewRoleFromID(companyID roles.CompanyID, id roles.ID) Role {
+	return &role{
+		id:        id,
+		companyID: companyID,
+	}
+}
+
+// 名前に対応する `roles` の ID
+// 存在しなければ作成する
+func roleNameID(ctx context.Context, tx DB, name roles.Name) (int64, error) {
+	id, err := roleNameRead(ctx, tx, name, "")
+	if err == nil {
+		return id, nil
+	}
+
+	if !errors.Is(err, sql.ErrNoRows) {
+		return 0, err
+	}
+
+	now := currentTime()
+	result, err := tx.ExecContext(
+		ctx,
+		"insert into `roles`(`name`, `created_at`, `updated_at`) value (?, ?, ?)",
+		name,
+		now,
+		now,
+	)
+
+	// 他のトランザクションが同時に作成した場合は、その行を使う
+	// 作成された行はトランザクションの読み込みの時点より新しいため、ロックして読む
+	var e *mysql.MySQLError
+	if errors.As(err, &e) && e.Number == erDupEntry {
+		return roleNameRead(ctx, tx, name, " for update")
+	}
+
+	if err != nil {
+		return 0, err
+	}
+
+	return result.LastInsertId()
+}
+
+func roleNameRead(ctx context.Context, tx DB, name roles.Name, lock string) (int64, error) {
+	var id int64
+	err := tx.QueryRowContext(
+		ctx,
+		"select `id` from `roles` where `name`=?"+lock,
+		name,
+	).Scan(&id)
+	return id, err
+}
+
+// 作成、更新時の制約に違反する項目 (同じ会社で同じ名前)
+var roleConstraints = map[uint16]string{
+	erDupEntry: "role.name",
+}
+
+func (r *role) Create(ctx context.Context, tx DB) error {
+	nameID, err := roleNameID(ctx, tx, r.name)
+	if err != nil {
+		return fmt.Errorf("repository/model.Role.Create: %w", err)
+	}
+
+	now := currentTime()
+	result, err := tx.ExecContext(
+		ctx,
+		"insert into `company_roles`(`company_id`, `role_id`, `created_at`, `updated_at`) value (?, ?, ?, ?)",
+		r.companyID,
+		nameID,
+		now,
+		now,
+	)
+	if err != nil {
+		return fmt.Errorf("repository/model.Role.Create: %w", conflict(err, roleConstraints))
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("repository/model.Role.Create: %w", err)
+	}
+
+	r.id = roles.ID(id)
+	r.createdAt = now
+	r.updatedAt = now
+	return nil
+}
+
+func (r *role) Read(ctx context.Context, tx DB) error {
+	err := tx.QueryRowContext(
+		ctx,
+		"select `r`.`name`, `c`.`created_at`, `c`.`updated_at` from `company_roles` `c` "+
+			"join `roles` `r` on `r`.`id`=`c`.`role_id` "+
+			"where `c`.`id`=? and `c`.`company_id`=?",
+		r.id,
+		r.companyID,
+	).Scan(&r.name, &r.createdAt, &r.updatedAt)
+	if err != nil {
+		return fmt.Errorf("repository/model.Role.Read: %w", notFound(err))
+	}
+
+	return nil
+}
+
+func (r *role) Update(ctx context.Context, tx DB) error {
+	nameID, err := roleNameID(ctx, tx, r.name)
+	if err != nil {
+		return fmt.Errorf("repository/model.Role.Update: %w", err)
+	}
+
+	now := currentTime()
+	result, err := tx.ExecContext(
+		ctx,
+		"update `company_roles` set `role_id`=?, `updated_at`=? where `id`=? and `company_id`=?",
+		nameID,
+		now,
+		r.id,
+		r.companyID,
+	)
+	if err != nil {
+		return fmt.Errorf("repository/model.Role.Update: %w", conflict(err, roleConstraints))
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("repository/model.Role.Update: %w", err)
+	}
+
+	if count != 1 {
+		return fmt.Errorf("repository/model.Role.Update: %w: rows affected not 1 (affected=%d)", failure.ErrNotFound, count)
+	}
+
+	r.updatedAt = now
+	return nil
+}
+
+// 名前(`roles`)は他の会社と共有するため削除しない
+func (r *role) Delete(ctx context.Context, tx DB) error {
+	result, err := tx.ExecContext(
+		ctx,
+		"delete from `company_roles` where `id`=? and `company_id`=?",
+		r.id,
+		r.companyID,
+	)
+	if err != nil {
+		return fmt.Errorf("repository/model.Role.Delete: %w", err)
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("repository/model.Role.Delete: %w", err)
+	}
+
+	if count != 1 {
+		return fmt.Errorf("repository/model.Role.Delete: %w: rows affected not 1 (affected=%d)", failure.ErrNotFound, count)
+	}
+
+	return nil
+}
+
+func (r *role) NewEntity() *roles.Role {
+	return &roles.Role{
+		ID:        r.id,
+		CompanyID: r.companyID,
+		Name:      r.name,
+		UpdatedAt: r.updatedAt,
+	}
+}
+
+// 会社の肩書きの一覧
+type Roles interface {
+	Read(context.Context, DB) error
+	NewEntity() []*roles.Role
+}
+
+// impl Roles
+type roleList struct {
+	companyID roles.CompanyID
+	// 空でなければ名前で絞り込む
+	name roles.Name
+	list []*role
+}
+
+func NewRolesFromCompanyID(companyID roles.CompanyID) Roles {
+	return &roleList{
+		companyID: companyID,
+	}
+}
+
+// 会社の中で名前が一致する肩書き
+func NewRolesFromName(companyID roles.CompanyID, name roles.Name) Roles {
+	return &roleList{
+		companyID: companyID,
+		name:      name,
+	}
+}
+
+func (l *roleList) Read(ctx context.Context, tx DB) error {
+	query := "select `c`.`id`, `r`.`name`, `c`.`created_at`, `c`.`updated_at` from `company_roles` `c` " +
+		"join `roles` `r` on `r`.`id`=`c`.`role_id` where `c`.`company_id`=?"
+	args := []interface{}{l.companyID}
+	if l.name != "" {
+		query += " and `r`.`name`=?"
+		args = append(args, l.name)
+	}
+	query += " order by `c`.`id`"
+
+	rows, err := tx.QueryContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("repository/model.Roles.Read: %w", err)
+	}
+	defer rows.Close()
+
+	list := []*role{}
+	for rows.Next() {
+		r := &role{companyID: l.companyID}
+		err = rows.Scan(&r.id, &r.name, &r.createdAt, &r.updatedAt)
+		if err != nil {
+			return fmt.Errorf("repository/model.Roles.Read: %w", err)
+		}
+		list = append(list, r)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return fmt.Errorf("repository/model.Roles.Read: %w", err)
+	}
+
+	l.list = list
+	return nil
+}
+
+func (l *roleList) NewEntity() []*roles.Role {
+	list := make([]*roles.Role, 0, len(l.list))
+	for _, r := range l.list {
+		list = append(list, r.NewEntity())
+	}
+	return list
+}
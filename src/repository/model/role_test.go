@@ -0,0 +1,447 @@
+package model
+
+import (
+	"context"
+	"database/sql"
+	"reflect"
+	"testing"
+	"time"
+
+	roles "api.example.com/pkg/role"
+)
+
+func createRole(db DB, companyID roles.CompanyID, name roles.Name) *role {
+	role := NewRole(roles.New(companyID, name)).(*role)
+	err := role.Create(context.Background(), db)
+	if err != nil {
+		panic(err)
+	}
+
+	return role
+}
+
+// 比較のため UpdatedAt を除いた entity
+func roleEntity(r *role) *roles.Role {
+	entity := r.NewEntity()
+	entity.UpdatedAt = time.Time{}
+	return entity
+}
+
+func TestNewRole(t *testing.T) {
+	got := NewRole(&roles.Role{ID: 2, CompanyID: 1, Name: "部長"})
+	want := &role{id: 2, companyID: 1, name: "部長"}
+	if !reflect.DeepEqual(want, got) {
+		t.Fatalf("want=%v, got=%v.", want, got)
+	}
+}
+
+func TestNewRoleFromID(t *testing.T) {
+	got := NewRoleFromID(1, 2)
+	want := &role{id: 2, companyID: 1}
+	if !reflect.DeepEqual(want, got) {
+		t.Fatalf("want=%v, got=%v.", want, got)
+	}
+}
+
+// 最初の読み込みで行が見えない DB
+// 他のトランザクションが同時に作成した行がまだ見えない状況を再現する
+type staleDB struct {
+	DB
+	stale bool
+}
+
+func (db *staleDB) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	if db.stale {
+		db.stale = false
+		return db.DB.QueryRowContext(ctx, query+" and false", args...)
+	}
+	return db.DB.QueryRowContext(ctx, query, args...)
+}
+
+func TestRoleNameID(t *testing.T) {
+	tableLock.Lock()
+	defer tableLock.Unlock()
+
+	db := newDB()
+	defer db.Close()
+	defer db.Exec("delete from roles")
+
+	existing, err := roleNameID(context.Background(), db, "部長")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	type test struct {
+		name     string
+		db       DB
+		roleName roles.Name
+		// 既存の ID を返すか
+		existing bool
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := roleNameID(context.Background(), tt.db, tt.roleName)
+			if err != nil {
+				t.Fatalf("want=nil, got=%v.", err)
+			}
+
+			if tt.existing != (got == existing) {
+				t.Fatalf("want-existing=%v, existing=%v, got=%v.", tt.existing, existing, got)
+			}
+		})
+	}
+
+	tests := []*test{
+		{
+			name:     "existing",
+			db:       db,
+			roleName: "部長",
+			existing: true,
+		},
+		{
+			name:     "created concurrently",
+			db:       &staleDB{DB: db, stale: true},
+			roleName: "部長",
+			existing: true,
+		},
+		{
+			name:     "new",
+			db:       db,
+			roleName: "課長",
+			existing: false,
+		},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+}
+
+func TestRole_Create(t *testing.T) {
+	tableLock.Lock()
+	defer tableLock.Unlock()
+
+	db := newDB()
+	defer db.Close()
+	defer db.Exec("delete from users")
+	defer db.Exec("delete from companies")
+	defer db.Exec("delete from roles")
+	defer db.Exec("delete from company_roles")
+
+	companyA := createCompany(db, "COMPANY A")
+	companyB := createCompany(db, "COMPANY B")
+
+	type test struct {
+		name    string
+		role    *role
+		wantErr bool
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.role.Create(context.Background(), db)
+			if tt.wantErr != (err != nil) {
+				t.Fatalf("want-error=%v, error=%v.", tt.wantErr, err)
+			}
+
+			if tt.wantErr {
+				return
+			}
+
+			testDiffTime(t, time.Now(), tt.role.updatedAt)
+
+			got := NewRoleFromID(tt.role.companyID, tt.role.id).(*role)
+			err = got.Read(context.Background(), db)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !reflect.DeepEqual(roleEntity(tt.role), roleEntity(got)) {
+				t.Fatalf("want=%v, got=%v.", roleEntity(tt.role), roleEntity(got))
+			}
+		})
+	}
+
+	tests := []*test{
+		{
+			name:    "ok",
+			role:    NewRole(roles.New(companyA, "部長")).(*role),
+			wantErr: false,
+		},
+		{
+			name:    "same name in other company",
+			role:    NewRole(roles.New(companyB, "部長")).(*role),
+			wantErr: false,
+		},
+		{
+			name:    "company not found",
+			role:    NewRole(roles.New(companyB+100, "課長")).(*role),
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+
+	// 名前は会社間で共有する
+	var count int
+	err := db.QueryRow("select count(*) from `roles` where `name`=?", "部長").Scan(&count)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if count != 1 {
+		t.Fatalf("roles count want=1, got=%v.", count)
+	}
+}
+
+func TestRole_Read(t *testing.T) {
+	tableLock.Lock()
+	defer tableLock.Unlock()
+
+	db := newDB()
+	defer db.Close()
+	defer db.Exec("delete from users")
+	defer db.Exec("delete from companies")
+	defer db.Exec("delete from roles")
+	defer db.Exec("delete from company_roles")
+
+	companyID := createCompany(db, "GREATE COMPANY")
+	manager := createRole(db, companyID, "部長")
+
+	type test struct {
+		name      string
+		companyID roles.CompanyID
+		id        roles.ID
+		want      *roles.Role
+		wantErr   bool
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.name, func(t *testing.T) {
+			got := NewRoleFromID(tt.companyID, tt.id).(*role)
+			err := got.Read(context.Background(), db)
+			if tt.wantErr != (err != nil) {
+				t.Fatalf("want-error=%v, error=%v.", tt.wantErr, err)
+			}
+
+			if tt.wantErr {
+				return
+			}
+
+			if !reflect.DeepEqual(tt.want, roleEntity(got)) {
+				t.Fatalf("want=%v, got=%v.", tt.want, roleEntity(got))
+			}
+		})
+	}
+
+	tests := []*test{
+		{
+			name:      "ok",
+			companyID: companyID,
+			id:        manager.id,
+			want:      roleEntity(manager),
+			wantErr:   false,
+		},
+		{
+			name:      "other company",
+			companyID: companyID + 1,
+			id:        manager.id,
+			want:      nil,
+			wantErr:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+}
+
+func TestRole_Update(t *testing.T) {
+	tableLock.Lock()
+	defer tableLock.Unlock()
+
+	db := newDB()
+	defer db.Close()
+	defer db.Exec("delete from users")
+	defer db.Exec("delete from companies")
+	defer db.Exec("delete from roles")
+	defer db.Exec("delete from company_roles")
+
+	companyID := createCompany(db, "GREATE COMPANY")
+	manager := createRole(db, companyID, "部長")
+
+	type test struct {
+		name    string
+		role    *roles.Role
+		wantErr bool
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.name, func(t *testing.T) {
+			err := NewRole(tt.role).Update(context.Background(), db)
+			if tt.wantErr != (err != nil) {
+				t.Fatalf("want-error=%v, error=%v.", tt.wantErr, err)
+			}
+
+			if tt.wantErr {
+				return
+			}
+
+			got := NewRoleFromID(tt.role.CompanyID, tt.role.ID).(*role)
+			err = got.Read(context.Background(), db)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !reflect.DeepEqual(tt.role, roleEntity(got)) {
+				t.Fatalf("want=%v, got=%v.", tt.role, roleEntity(got))
+			}
+		})
+	}
+
+	tests := []*test{
+		{
+			name:    "new name",
+			role:    &roles.Role{ID: manager.id, CompanyID: companyID, Name: "本部長"},
+			wantErr: false,
+		},
+		{
+			name:    "existing name",
+			role:    &roles.Role{ID: manager.id, CompanyID: companyID, Name: "部長"},
+			wantErr: false,
+		},
+		{
+			name:    "other company",
+			role:    &roles.Role{ID: manager.id, CompanyID: companyID + 1, Name: "課長"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+}
+
+func TestRole_Delete(t *testing.T) {
+	tableLock.Lock()
+	defer tableLock.Unlock()
+
+	db := newDB()
+	defer db.Close()
+	defer db.Exec("delete from users")
+	defer db.Exec("delete from companies")
+	defer db.Exec("delete from roles")
+	defer db.Exec("delete from company_roles")
+
+	companyID := createCompany(db, "GREATE COMPANY")
+	manager := createRole(db, companyID, "部長")
+
+	type test struct {
+		name      string
+		companyID roles.CompanyID
+		id        roles.ID
+		wantErr   bool
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.name, func(t *testing.T) {
+			err := NewRoleFromID(tt.companyID, tt.id).Delete(context.Background(), db)
+			if tt.wantErr != (err != nil) {
+				t.Fatalf("want-error=%v, error=%v.", tt.wantErr, err)
+			}
+		})
+	}
+
+	tests := []*test{
+		{
+			name:      "other company",
+			companyID: companyID + 1,
+			id:        manager.id,
+			wantErr:   true,
+		},
+		{
+			name:      "ok",
+			companyID: companyID,
+			id:        manager.id,
+			wantErr:   false,
+		},
+		{
+			name:      "not found",
+			companyID: companyID,
+			id:        manager.id,
+			wantErr:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+}
+
+func TestRoles_Read(t *testing.T) {
+	tableLock.Lock()
+	defer tableLock.Unlock()
+
+	db := newDB()
+	defer db.Close()
+	defer db.Exec("delete from users")
+	defer db.Exec("delete from companies")
+	defer db.Exec("delete from roles")
+	defer db.Exec("delete from company_roles")
+
+	companyID := createCompany(db, "GREATE COMPANY")
+	manager := createRole(db, companyID, "部長")
+	chief := createRole(db, companyID, "課長")
+
+	otherID := createCompany(db, "OTHER COMPANY")
+	createRole(db, otherID, "部長")
+
+	type test struct {
+		name string
+		list Roles
+		want []*roles.Role
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.list.Read(context.Background(), db)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got := tt.list.NewEntity()
+			for i := range got {
+				got[i].UpdatedAt = time.Time{}
+			}
+
+			if !reflect.DeepEqual(tt.want, got) {
+				t.Fatalf("want=%v, got=%v.", tt.want, got)
+			}
+		})
+	}
+
+	tests := []*test{
+		{
+			name: "company",
+			list: NewRolesFromCompanyID(companyID),
+			want: []*roles.Role{roleEntity(manager), roleEntity(chief)},
+		},
+		{
+			name: "name",
+			list: NewRolesFromName(companyID, "課長"),
+			want: []*roles.Role{roleEntity(chief)},
+		},
+		{
+			name: "name not found",
+			list: NewRolesFromName(companyID, "係長"),
+			want: []*roles.Role{},
+		},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+}
@@ -0,0 +1,155 @@
+package model
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"api.example.com/pkg/failure"
+	sessions "api.example.com/pkg/session"
+)
+
+// セッションIDは保存せず、ダイジェストで検索する
+type Session interface {
+	Create(context.Context, DB) error
+	Read(context.Context, DB) error
+	Delete(context.Context, DB) error
+	NewEntity() *sessions.Session
+}
+
+// impl Session
+type session struct {
+	id        sessions.ID
+	userID    sessions.UserID
+	expiresAt dateTime
+	createdAt dateTime
+	updatedAt dateTime
+}
+
+func NewSession(s *sessions.Session) Session {
+	return &session{
+		id:        s.ID,
+		userID:    s.UserID,
+		expiresAt: s.ExpiresAt,
+	}
+}
+
+func NewSessionFromID(id sessions.ID) Session {
+	return &session{
+		id: id,
+	}
+}
+
+func (s *session) Create(ctx context.Context, tx DB) error {
+	now := currentTime()
+	_, err := tx.ExecContext(
+		ctx,
+		"insert into `sessions`(`digest`, `user_id`, `expires_at`, `created_at`, `updated_at`) value (?, ?, ?, ?, ?)",
+		s.id.Digest(),
+		s.userID,
+		s.expiresAt,
+		now,
+		now,
+	)
+	if err != nil {
+		return fmt.Errorf("repository/model.Session.Create: %w", err)
+	}
+
+	s.createdAt = now
+	s.updatedAt = now
+	return nil
+}
+
+func (s *session) Read(ctx context.Context, tx DB) error {
+	err := tx.QueryRowContext(
+		ctx,
+		"select `user_id`, `expires_at`, `created_at`, `updated_at` from `sessions` where `digest`=?",
+		s.id.Digest(),
+	).Scan(&s.userID, &s.expiresAt, &s.createdAt, &s.updatedAt)
+	if err != nil {
+		return fmt.Errorf("repository/model.Session.Read: %w", notFound(err))
+	}
+
+	return nil
+}
+
+func (s *session) Delete(ctx context.Context, tx DB) error {
+	result, err := tx.ExecContext(
+		ctx,
+		"delete from `sessions` where `digest`=?",
+		s.id.Digest(),
+	)
+	if err != nil {
+		return fmt.Errorf("repository/model.Session.Delete: %w", err)
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("repository/model.Session.Delete: %w", err)
+	}
+
+	if count != 1 {
+		return fmt.Errorf("repository/model.Session.Delete: %w: rows affected not 1 (affected=%d)", failure.ErrNotFound, count)
+	}
+
+	return nil
+}
+
+func (s *session) NewEntity() *sessions.Session {
+	return sessions.New(s.id, s.userID, s.expiresAt)
+}
+
+// まとめて削除するセッション
+// 対象がなくてもエラーにしない
+type Sessions interface {
+	Delete(context.Context, DB) error
+}
+
+// impl Sessions
+type userSessions struct {
+	userID sessions.UserID
+}
+
+func NewSessionsFromUserID(userID sessions.UserID) Sessions {
+	return &userSessions{
+		userID: userID,
+	}
+}
+
+func (l *userSessions) Delete(ctx context.Context, tx DB) error {
+	_, err := tx.ExecContext(
+		ctx,
+		"delete from `sessions` where `user_id`=?",
+		l.userID,
+	)
+	if err != nil {
+		return fmt.Errorf("repository/model.Sessions.Delete: %w", err)
+	}
+
+	return nil
+}
+
+// impl Sessions
+type expiredSessions struct {
+	at time.Time
+}
+
+// at の時点で有効期限が切れているセッション
+func NewExpiredSessions(at time.Time) Sessions {
+	return &expiredSessions{
+		at: at,
+	}
+}
+
+func (l *expiredSessions) Delete(ctx context.Context, tx DB) error {
+	_, err := tx.ExecContext(
+		ctx,
+		"delete from `sessions` where `expires_at`<=?",
+		l.at,
+	)
+	if err != nil {
+		return fmt.Errorf("repository/model.Sessions.Delete: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,271 @@
+package model
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	sessions "api.example.com/pkg/session"
+)
+
+func createSession(db DB, s *sessions.Session) *session {
+	session := NewSession(s).(*session)
+	err := session.Create(context.Background(), db)
+	if err != nil {
+		panic(err)
+	}
+
+	return session
+}
+
+// 期限切れを含めたセッションの存在確認
+func sessionExists(db DB, id sessions.ID) bool {
+	return NewSessionFromID(id).Read(context.Background(), db) == nil
+}
+
+func TestNewSession(t *testing.T) {
+	expiresAt := time.Date(2022, 9, 3, 12, 34, 56, 0, time.UTC)
+	got := NewSession(sessions.New("sid", 1, expiresAt))
+	want := &session{id: "sid", userID: 1, expiresAt: expiresAt}
+	if !reflect.DeepEqual(want, got) {
+		t.Fatalf("want=%v, got=%v.", want, got)
+	}
+}
+
+func TestNewSessionFromID(t *testing.T) {
+	got := NewSessionFromID("sid")
+	want := &session{id: "sid"}
+	if !reflect.DeepEqual(want, got) {
+		t.Fatalf("want=%v, got=%v.", want, got)
+	}
+}
+
+func TestSession_Create(t *testing.T) {
+	tableLock.Lock()
+	defer tableLock.Unlock()
+
+	db := newDB()
+	defer db.Close()
+	defer db.Exec("delete from users")
+
+	userID := createOwner(db, "Alice")
+	expiresAt := time.Now().Add(time.Hour).Truncate(time.Second).UTC()
+
+	type test struct {
+		name    string
+		session *session
+		wantErr bool
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.session.Create(context.Background(), db)
+			if tt.wantErr != (err != nil) {
+				t.Fatalf("want-error=%v, error=%v.", tt.wantErr, err)
+			}
+
+			if tt.wantErr {
+				return
+			}
+
+			testDiffTime(t, time.Now(), tt.session.updatedAt)
+
+			got := NewSessionFromID(tt.session.id)
+			err = got.Read(context.Background(), db)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !reflect.DeepEqual(tt.session.NewEntity(), got.NewEntity()) {
+				t.Fatalf("want=%v, got=%v.", tt.session.NewEntity(), got.NewEntity())
+			}
+		})
+	}
+
+	tests := []*test{
+		{
+			name:    "ok",
+			session: NewSession(sessions.New("sid-a", userID, expiresAt)).(*session),
+			wantErr: false,
+		},
+		{
+			name:    "duplicate",
+			session: NewSession(sessions.New("sid-a", userID, expiresAt)).(*session),
+			wantErr: true,
+		},
+		{
+			name:    "user not found",
+			session: NewSession(sessions.New("sid-b", userID+100, expiresAt)).(*session),
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+}
+
+func TestSession_Read(t *testing.T) {
+	tableLock.Lock()
+	defer tableLock.Unlock()
+
+	db := newDB()
+	defer db.Close()
+	defer db.Exec("delete from users")
+
+	userID := createOwner(db, "Alice")
+	expiresAt := time.Now().Add(time.Hour).Truncate(time.Second).UTC()
+	createSession(db, sessions.New("sid", userID, expiresAt))
+
+	type test struct {
+		name    string
+		session Session
+		want    *sessions.Session
+		wantErr bool
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.session.Read(context.Background(), db)
+			if tt.wantErr != (err != nil) {
+				t.Fatalf("want-error=%v, error=%v.", tt.wantErr, err)
+			}
+
+			if tt.wantErr {
+				return
+			}
+
+			if !reflect.DeepEqual(tt.want, tt.session.NewEntity()) {
+				t.Fatalf("want=%v, got=%v.", tt.want, tt.session.NewEntity())
+			}
+		})
+	}
+
+	tests := []*test{
+		{
+			name:    "ok",
+			session: NewSessionFromID("sid"),
+			want:    sessions.New("sid", userID, expiresAt),
+			wantErr: false,
+		},
+		{
+			name:    "not found",
+			session: NewSessionFromID("other"),
+			want:    nil,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+}
+
+func TestSession_Delete(t *testing.T) {
+	tableLock.Lock()
+	defer tableLock.Unlock()
+
+	db := newDB()
+	defer db.Close()
+	defer db.Exec("delete from users")
+
+	userID := createOwner(db, "Alice")
+	createSession(db, sessions.New("sid", userID, time.Now().Add(time.Hour)))
+
+	type test struct {
+		name    string
+		session Session
+		wantErr bool
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.session.Delete(context.Background(), db)
+			if tt.wantErr != (err != nil) {
+				t.Fatalf("want-error=%v, error=%v.", tt.wantErr, err)
+			}
+		})
+	}
+
+	tests := []*test{
+		{
+			name:    "ok",
+			session: NewSessionFromID("sid"),
+			wantErr: false,
+		},
+		{
+			name:    "not found",
+			session: NewSessionFromID("sid"),
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+}
+
+func TestSessions_Delete(t *testing.T) {
+	tableLock.Lock()
+	defer tableLock.Unlock()
+
+	db := newDB()
+	defer db.Close()
+	defer db.Exec("delete from users")
+
+	alice := createOwner(db, "Alice")
+	bob := createOwner(db, "Bob")
+	now := time.Now().Truncate(time.Second)
+
+	type test struct {
+		name     string
+		sessions Sessions
+		want     map[sessions.ID]bool
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.name, func(t *testing.T) {
+			db.Exec("delete from sessions")
+			createSession(db, sessions.New("alice-1", alice, now.Add(time.Hour)))
+			createSession(db, sessions.New("alice-2", alice, now.Add(-time.Hour)))
+			createSession(db, sessions.New("bob-1", bob, now))
+
+			err := tt.sessions.Delete(context.Background(), db)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got := map[sessions.ID]bool{}
+			for id := range tt.want {
+				got[id] = sessionExists(db, id)
+			}
+
+			if !reflect.DeepEqual(tt.want, got) {
+				t.Fatalf("want=%v, got=%v.", tt.want, got)
+			}
+		})
+	}
+
+	tests := []*test{
+		{
+			name:     "user",
+			sessions: NewSessionsFromUserID(alice),
+			want:     map[sessions.ID]bool{"alice-1": false, "alice-2": false, "bob-1": true},
+		},
+		{
+			name:     "expired",
+			sessions: NewExpiredSessions(now),
+			want:     map[sessions.ID]bool{"alice-1": true, "alice-2": false, "bob-1": false},
+		},
+		{
+			name:     "no sessions",
+			sessions: NewSessionsFromUserID(bob + 100),
+			want:     map[sessions.ID]bool{"alice-1": true, "alice-2": true, "bob-1": true},
+		},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+}
@@ -0,0 +1,53 @@
+package model
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/go-sql-driver/mysql"
+)
+
+// SQLite の方言
+// 外部キーの制約は接続ごとに有効にすること (例: _foreign_keys=1)
+var SQLite Dialect = sqliteDialect{}
+
+// impl Dialect
+type sqliteDialect struct{}
+
+// 結合した表からの削除 (例: delete `p` from `t` `p` join ...)
+var deleteJoin = regexp.MustCompile("^delete (`\\w+`) from (`\\w+`) (`\\w+`) (.*)$")
+
+// 結合した表からの削除は rowid の副問い合わせへ、 value は values へ、
+// 識別子を囲むバッククォートはダブルクォートへ書き換える
+// 書き込みは直列に行われるため、行のロック (for update) は取り除く
+func (sqliteDialect) Rebind(query string) string {
+	query = strings.TrimSuffix(query, " for update")
+
+	if m := deleteJoin.FindStringSubmatch(query); m != nil && m[1] == m[3] {
+		query = fmt.Sprintf("delete from %s where rowid in (select %s.rowid from %s %s %s)", m[2], m[1], m[2], m[3], m[4])
+	}
+
+	query = strings.Replace(query, ") value (", ") values (", 1)
+	return strings.ReplaceAll(query, "`", `"`)
+}
+
+// 制約の違反を MySQL のエラー番号へ変換する
+// SQLite の外部キーのエラーは違反の向きを持たないため、文の種類で判定する
+func (sqliteDialect) Error(query string, err error) error {
+	message := err.Error()
+
+	var number uint16
+	switch {
+	case strings.Contains(message, "UNIQUE constraint failed"):
+		number = erDupEntry
+	case strings.Contains(message, "FOREIGN KEY constraint failed") && strings.HasPrefix(query, "delete "):
+		number = erRowIsReferenced
+	case strings.Contains(message, "FOREIGN KEY constraint failed"):
+		number = erNoReferencedRow
+	default:
+		return err
+	}
+
+	return &mysql.MySQLError{Number: number, Message: message}
+}
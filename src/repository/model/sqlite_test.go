@@ -0,0 +1,171 @@
+package model
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"testing"
+
+	"api.example.com/repository/migration"
+	"github.com/go-sql-driver/mysql"
+	_ "github.com/mattn/go-sqlite3"
+)
+
+func TestSQLite_Rebind(t *testing.T) {
+	type test struct {
+		name  string
+		query string
+		want  string
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.name, func(t *testing.T) {
+			got := SQLite.Rebind(tt.query)
+			if tt.want != got {
+				t.Fatalf("want=%v, got=%v.", tt.want, got)
+			}
+		})
+	}
+
+	tests := []*test{
+		{
+			name:  "select",
+			query: "select `name` from `users` where `id`=?",
+			want:  `select "name" from "users" where "id"=?`,
+		},
+		{
+			name:  "select for update",
+			query: "select count(*) from `department_paths` where `ancestor_id`=? for update",
+			want:  `select count(*) from "department_paths" where "ancestor_id"=?`,
+		},
+		{
+			name:  "insert",
+			query: "insert into `users`(`name`, `password`) value (?, ?)",
+			want:  `insert into "users"("name", "password") values (?, ?)`,
+		},
+		{
+			name:  "insert select",
+			query: "insert into `department_paths`(`ancestor_id`) select `ancestor_id` from `department_paths`",
+			want:  `insert into "department_paths"("ancestor_id") select "ancestor_id" from "department_paths"`,
+		},
+		{
+			name: "delete join",
+			query: "delete `p` from `employee_permissions` `p` " +
+				"join `company_employees` `e` on `e`.`id`=`p`.`company_employee_id` where `e`.`company_id`=?",
+			want: `delete from "employee_permissions" where rowid in (select "p".rowid from "employee_permissions" "p" ` +
+				`join "company_employees" "e" on "e"."id"="p"."company_employee_id" where "e"."company_id"=?)`,
+		},
+		{
+			name:  "delete",
+			query: "delete from `users` where `id`=?",
+			want:  `delete from "users" where "id"=?`,
+		},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+}
+
+// SQLite のメモリ上のデータベース
+// 接続ごとに別のデータベースとなるため、接続は1つに限る
+func newSQLite(t *testing.T) *sql.DB {
+	t.Helper()
+
+	db, err := sql.Open("sqlite3", "file::memory:?_foreign_keys=1")
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	db.SetMaxOpenConns(1)
+	t.Cleanup(func() { db.Close() })
+
+	m, err := migration.NewSQLite(db)
+	if err != nil {
+		t.Fatalf("migration: %v", err)
+	}
+
+	_, err = m.Up(context.Background())
+	if err != nil {
+		t.Fatalf("migration: %v", err)
+	}
+	return db
+}
+
+func TestSQLite_Error(t *testing.T) {
+	type test struct {
+		name  string
+		query string
+		// エラーになる文の前に実行する文
+		setup []string
+		want  uint16
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.name, func(t *testing.T) {
+			db := newSQLite(t)
+			ctx := context.Background()
+			for _, query := range tt.setup {
+				_, err := db.ExecContext(ctx, SQLite.Rebind(query))
+				if err != nil {
+					t.Fatalf("setup: %v", err)
+				}
+			}
+
+			_, err := db.ExecContext(ctx, SQLite.Rebind(tt.query))
+			if err == nil {
+				t.Fatalf("want=error, got=nil.")
+			}
+
+			var e *mysql.MySQLError
+			got := SQLite.Error(tt.query, err)
+			if !errors.As(got, &e) {
+				t.Fatalf("want=%v, got=%v.", tt.want, got)
+			}
+
+			if tt.want != e.Number {
+				t.Fatalf("want=%v, got=%v.", tt.want, e.Number)
+			}
+		})
+	}
+
+	user := "insert into `users`(`name`, `password`, `created_at`, `updated_at`) value ('bob', '', '', '')"
+	company := "insert into `companies`(`name`, `owner_id`, `created_at`, `updated_at`) value ('GREATE COMPANY', 1, '', '')"
+	tests := []*test{
+		{
+			name:  "duplicate entry",
+			query: user,
+			setup: []string{user},
+			want:  erDupEntry,
+		},
+		{
+			// 名前は大文字と小文字を区別しない
+			name:  "duplicate entry ignoring case",
+			query: strings.Replace(user, "'bob'", "'BOB'", 1),
+			setup: []string{user},
+			want:  erDupEntry,
+		},
+		{
+			name:  "row is referenced",
+			query: "delete from `users` where `id`=1",
+			setup: []string{user, company},
+			want:  erRowIsReferenced,
+		},
+		{
+			name:  "no referenced row",
+			query: company,
+			want:  erNoReferencedRow,
+		},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+
+	t.Run("other", func(t *testing.T) {
+		err := errors.New("test error")
+		if got := SQLite.Error("select 1", err); got != err {
+			t.Fatalf("want=%v, got=%v.", err, got)
+		}
+	})
+}
@@ -1,6 +1,7 @@
 package model
 
 import (
+	"api.example.com/pkg/failure"
 	users "api.example.com/pkg/user"
 	"api.example.com/pkg/user/password"
 	"context"
@@ -14,10 +15,12 @@ func (ph passwordHash) String() string {
 }
 
 type User interface {
-	Create(DB) error
-	Read(DB) error
-	Update(DB) error
-	Delete(DB) error
+	Create(context.Context, DB) error
+	Read(context.Context, DB) error
+	// name で読み込む
+	ReadByName(context.Context, DB) error
+	Update(context.Context, DB) error
+	Delete(context.Context, DB) error
 	NewEntity() *users.User
 }
 
@@ -44,6 +47,12 @@ func NewUserFromID(id users.ID) User {
 	}
 }
 
+func NewUserFromName(name users.Name) User {
+	return &user{
+		Name: name,
+	}
+}
+
 func (u *user) NewEntity() *users.User {
 	return &users.User{
 		ID:        u.ID,
@@ -53,10 +62,15 @@ func (u *user) NewEntity() *users.User {
 	}
 }
 
-func (u *user) Create(tx DB) error {
+// 作成、更新時の制約に違反する項目
+var userConstraints = map[uint16]string{
+	erDupEntry: "user.name",
+}
+
+func (u *user) Create(ctx context.Context, tx DB) error {
 	now := currentTime()
 	result, err := tx.ExecContext(
-		context.TODO(),
+		ctx,
 		"insert into `users`(`name`, `password`, `created_at`, `updated_at`) value (?, ?, ?, ?)",
 		u.Name,
 		u.Password,
@@ -64,7 +78,7 @@ func (u *user) Create(tx DB) error {
 		now,
 	)
 	if err != nil {
-		return fmt.Errorf("repository/model.User.Create: %w", err)
+		return fmt.Errorf("repository/model.User.Create: %w", conflict(err, userConstraints))
 	}
 
 	id, err := result.LastInsertId()
@@ -78,22 +92,34 @@ func (u *user) Create(tx DB) error {
 	return nil
 }
 
-func (u *user) Read(tx DB) error {
+func (u *user) Read(ctx context.Context, tx DB) error {
 	err := tx.QueryRowContext(
-		context.TODO(),
+		ctx,
 		"select `name`, `password`, `created_at`, `updated_at` from `users` where `id`=?",
 		u.ID,
 	).Scan(&u.Name, &u.Password, &u.CreatedAt, &u.UpdatedAt)
 	if err != nil {
-		return fmt.Errorf("repository/model.User.Read: %w", err)
+		return fmt.Errorf("repository/model.User.Read: %w", notFound(err))
 	}
 	return nil
 }
 
-func (u *user) Update(tx DB) error {
+func (u *user) ReadByName(ctx context.Context, tx DB) error {
+	err := tx.QueryRowContext(
+		ctx,
+		"select `id`, `name`, `password`, `created_at`, `updated_at` from `users` where `name`=?",
+		u.Name,
+	).Scan(&u.ID, &u.Name, &u.Password, &u.CreatedAt, &u.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("repository/model.User.ReadByName: %w", notFound(err))
+	}
+	return nil
+}
+
+func (u *user) Update(ctx context.Context, tx DB) error {
 	now := currentTime()
 	result, err := tx.ExecContext(
-		context.TODO(),
+		ctx,
 		"update `users` set `name`=?, `password`=?, `updated_at`=? where `id`=?",
 		u.Name,
 		u.Password,
@@ -101,7 +127,7 @@ func (u *user) Update(tx DB) error {
 		u.ID,
 	)
 	if err != nil {
-		return fmt.Errorf("rdb-repository/model.User.Update: %w", err)
+		return fmt.Errorf("rdb-repository/model.User.Update: %w", conflict(err, userConstraints))
 	}
 
 	count, err := result.RowsAffected()
@@ -110,21 +136,24 @@ func (u *user) Update(tx DB) error {
 	}
 
 	if count != 1 {
-		return fmt.Errorf("rdb-repository/model.User.Update: rows affected not 1 (affected=%d)", count)
+		return fmt.Errorf("rdb-repository/model.User.Update: %w: rows affected not 1 (affected=%d)", failure.ErrNotFound, count)
 	}
 
 	u.UpdatedAt = now
 	return nil
 }
 
-func (u *user) Delete(tx DB) error {
+func (u *user) Delete(ctx context.Context, tx DB) error {
 	result, err := tx.ExecContext(
-		context.TODO(),
+		ctx,
 		"delete from `users` where `id`=?",
 		u.ID,
 	)
 	if err != nil {
-		return fmt.Errorf("rdb-repository/model.User.Delete: %w", err)
+		return fmt.Errorf("rdb-repository/model.User.Delete: %w", conflict(err, map[uint16]string{
+			// 会社の所有者や従業員であるユーザー
+			erRowIsReferenced: "user_id",
+		}))
 	}
 
 	count, err := result.RowsAffected()
@@ -133,8 +162,75 @@ func (u *user) Delete(tx DB) error {
 	}
 
 	if count != 1 {
-		return fmt.Errorf("rdb-repository/model.User.Delete: rows affected not 1 (affected=%d)", count)
+		return fmt.Errorf("rdb-repository/model.User.Delete: %w: rows affected not 1 (affected=%d)", failure.ErrNotFound, count)
+	}
+
+	return nil
+}
+
+// ユーザーの一覧
+type Users interface {
+	Read(context.Context, DB) error
+	NewEntity() []*users.User
+}
+
+// impl Users
+type userList struct {
+	// 空でなければ名前の前方一致で絞り込む
+	prefix users.Name
+	after  users.ID
+	limit  int
+	list   []*user
+}
+
+// 名前が prefix で始まり、 ID が after より大きいユーザーを ID の順に limit 件まで
+func NewUsersFromPrefix(prefix users.Name, after users.ID, limit int) Users {
+	return &userList{
+		prefix: prefix,
+		after:  after,
+		limit:  limit,
 	}
+}
 
+func (l *userList) Read(ctx context.Context, tx DB) error {
+	query := "select `id`, `name`, `password`, `created_at`, `updated_at` from `users` where `id`>?"
+	args := []interface{}{l.after}
+	if l.prefix != "" {
+		query += " and `name` like ? escape '!'"
+		args = append(args, likePrefix(string(l.prefix)))
+	}
+	query += " order by `id` limit ?"
+	args = append(args, l.limit)
+
+	rows, err := tx.QueryContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("repository/model.Users.Read: %w", err)
+	}
+	defer rows.Close()
+
+	list := []*user{}
+	for rows.Next() {
+		u := &user{}
+		err = rows.Scan(&u.ID, &u.Name, &u.Password, &u.CreatedAt, &u.UpdatedAt)
+		if err != nil {
+			return fmt.Errorf("repository/model.Users.Read: %w", err)
+		}
+		list = append(list, u)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return fmt.Errorf("repository/model.Users.Read: %w", err)
+	}
+
+	l.list = list
 	return nil
 }
+
+func (l *userList) NewEntity() []*users.User {
+	list := make([]*users.User, 0, len(l.list))
+	for _, u := range l.list {
+		list = append(list, u.NewEntity())
+	}
+	return list
+}
@@ -1,8 +1,11 @@
 package model
 
 import (
+	companies "api.example.com/pkg/company"
+	"api.example.com/pkg/failure"
 	users "api.example.com/pkg/user"
 	"api.example.com/pkg/user/password"
+	"context"
 	"errors"
 	_ "github.com/go-sql-driver/mysql"
 	"reflect"
@@ -112,6 +115,35 @@ func TestNewUserFromID(t *testing.T) {
 	}
 }
 
+func TestNewUserFromName(t *testing.T) {
+	type test struct {
+		name     string
+		userName users.Name
+		want     User
+	}
+
+	do := func(tt *test) {
+		got := NewUserFromName(tt.userName)
+		if !reflect.DeepEqual(tt.want, got) {
+			t.Fatalf("want=%v, got=%v.", tt.want, got)
+		}
+	}
+
+	tests := []*test{
+		{
+			name:     "true",
+			userName: "Bob",
+			want: &user{
+				Name: "Bob",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+}
+
 func TestUser_NewEntity(t *testing.T) {
 	type test struct {
 		name string
@@ -165,17 +197,23 @@ func TestUser_Create(t *testing.T) {
 		user    *users.User
 		want    *user
 		wantErr bool
+		// 制約に違反した項目
+		wantViolations []failure.Violation
 	}
 
 	do := func(tt *test) {
 		t.Run(tt.name, func(t *testing.T) {
 			got := NewUser(tt.user).(*user)
 
-			err := got.Create(tt.db)
+			err := got.Create(context.Background(), tt.db)
 			if tt.wantErr != (err != nil) {
 				t.Fatalf("want-error=%v, error=%v.", tt.wantErr, err)
 			}
 
+			if v := failure.Violations(err); !reflect.DeepEqual(tt.wantViolations, v) {
+				t.Fatalf("want=%v, got=%v.", tt.wantViolations, v)
+			}
+
 			if tt.wantErr {
 				return
 			}
@@ -246,6 +284,25 @@ func TestUser_Create(t *testing.T) {
 				wantErr: true,
 			}
 		}(),
+		func() *test {
+			pw, err := password.New("password")
+			if err != nil {
+				panic(err)
+			}
+			err = NewUser(users.New("carol", pw)).Create(context.Background(), db)
+			if err != nil {
+				panic(err)
+			}
+
+			return &test{
+				name:           "duplicate name",
+				db:             db,
+				user:           users.New("carol", pw),
+				want:           nil,
+				wantErr:        true,
+				wantViolations: []failure.Violation{{Field: "user.name", Rule: failure.RuleUnique}},
+			}
+		}(),
 		func() *test {
 			pw, err := password.New("password")
 			if err != nil {
@@ -287,16 +344,28 @@ func TestUser_Read(t *testing.T) {
 		id      users.ID
 		want    *user
 		wantErr bool
+		// 取り消し済みの context で読み込む
+		canceled bool
 	}
 
 	do := func(tt *test) {
 		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			if tt.canceled {
+				cancel()
+			}
+
 			got := NewUserFromID(tt.id).(*user)
-			err := got.Read(tt.db)
+			err := got.Read(ctx, tt.db)
 			if tt.wantErr != (err != nil) {
 				t.Fatalf("want-error=%v, error=%v.", tt.wantErr, err)
 			}
 
+			if tt.canceled != errors.Is(err, context.Canceled) {
+				t.Fatalf("want-canceled=%v, error=%v.", tt.canceled, err)
+			}
+
 			if tt.wantErr {
 				return
 			}
@@ -319,7 +388,7 @@ func TestUser_Read(t *testing.T) {
 				panic(err)
 			}
 			model := NewUser(users.New("Bob", pw)).(*user)
-			err = model.Create(db)
+			err = model.Create(context.Background(), db)
 			if err != nil {
 				panic(err)
 			}
@@ -339,6 +408,180 @@ func TestUser_Read(t *testing.T) {
 			want:    nil,
 			wantErr: true,
 		},
+		func() *test {
+			pw, err := password.New("password")
+			if err != nil {
+				panic(err)
+			}
+			model := NewUser(users.New("Alice", pw)).(*user)
+			err = model.Create(context.Background(), db)
+			if err != nil {
+				panic(err)
+			}
+
+			return &test{
+				name:     "canceled",
+				db:       db,
+				id:       model.ID,
+				want:     nil,
+				wantErr:  true,
+				canceled: true,
+			}
+		}(),
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+}
+
+func TestUser_ReadByName(t *testing.T) {
+	tableLock.Lock()
+	defer tableLock.Unlock()
+
+	db := newDB()
+	defer db.Close()
+	defer db.Exec("delete from users")
+
+	type test struct {
+		name     string
+		db       DB
+		userName users.Name
+		want     *user
+		wantErr  bool
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.name, func(t *testing.T) {
+			got := NewUserFromName(tt.userName).(*user)
+			err := got.ReadByName(context.Background(), tt.db)
+			if tt.wantErr != (err != nil) {
+				t.Fatalf("want-error=%v, error=%v.", tt.wantErr, err)
+			}
+
+			if tt.wantErr {
+				return
+			}
+
+			testDiffTime(t, tt.want.CreatedAt, got.CreatedAt)
+			tt.want.CreatedAt = got.CreatedAt
+			testDiffTime(t, tt.want.UpdatedAt, got.UpdatedAt)
+			tt.want.UpdatedAt = got.UpdatedAt
+
+			if !reflect.DeepEqual(tt.want, got) {
+				t.Fatalf("want=%v, got=%v.", tt.want, got)
+			}
+		})
+	}
+
+	tests := []*test{
+		func() *test {
+			pw, err := password.New("password")
+			if err != nil {
+				panic(err)
+			}
+			model := NewUser(users.New("Bob", pw)).(*user)
+			err = model.Create(context.Background(), db)
+			if err != nil {
+				panic(err)
+			}
+
+			return &test{
+				name:     "true",
+				db:       db,
+				userName: "Bob",
+				want:     model,
+				wantErr:  false,
+			}
+		}(),
+		{
+			name:     "not found",
+			db:       db,
+			userName: "Alice",
+			want:     nil,
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+}
+
+func TestUsers_Read(t *testing.T) {
+	tableLock.Lock()
+	defer tableLock.Unlock()
+
+	db := newDB()
+	defer db.Close()
+	defer db.Exec("delete from users")
+
+	bob := createOwner(db, "bob")
+	bobby := createOwner(db, "bobby")
+	// like のワイルドカードを含む名前
+	wildcard := createOwner(db, "b%b")
+	alice := createOwner(db, "alice")
+
+	type test struct {
+		name string
+		list Users
+		want []users.ID
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.list.Read(context.Background(), db)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got := []users.ID{}
+			for _, u := range tt.list.NewEntity() {
+				got = append(got, u.ID)
+			}
+
+			if !reflect.DeepEqual(tt.want, got) {
+				t.Fatalf("want=%v, got=%v.", tt.want, got)
+			}
+		})
+	}
+
+	tests := []*test{
+		{
+			name: "all",
+			list: NewUsersFromPrefix("", 0, 10),
+			want: []users.ID{bob, bobby, wildcard, alice},
+		},
+		{
+			name: "limit",
+			list: NewUsersFromPrefix("", 0, 2),
+			want: []users.ID{bob, bobby},
+		},
+		{
+			name: "after",
+			list: NewUsersFromPrefix("", bobby, 10),
+			want: []users.ID{wildcard, alice},
+		},
+		{
+			name: "prefix",
+			list: NewUsersFromPrefix("bob", 0, 10),
+			want: []users.ID{bob, bobby},
+		},
+		{
+			name: "prefix after",
+			list: NewUsersFromPrefix("bob", bob, 10),
+			want: []users.ID{bobby},
+		},
+		{
+			name: "escape",
+			list: NewUsersFromPrefix("b%", 0, 10),
+			want: []users.ID{wildcard},
+		},
+		{
+			name: "not found",
+			list: NewUsersFromPrefix("carol", 0, 10),
+			want: []users.ID{},
+		},
 	}
 
 	for _, tt := range tests {
@@ -365,7 +608,7 @@ func TestUser_Update(t *testing.T) {
 	do := func(tt *test) {
 		t.Run(tt.name, func(t *testing.T) {
 			got := NewUser(tt.user).(*user)
-			err := got.Update(tt.db)
+			err := got.Update(context.Background(), tt.db)
 			if tt.wantErr != (err != nil) {
 				t.Fatalf("want-error=%v, error=%v.", tt.wantErr, err)
 			}
@@ -409,7 +652,7 @@ func TestUser_Update(t *testing.T) {
 				panic(err)
 			}
 			model := NewUser(users.New("Bob", pw)).(*user)
-			err = model.Create(db)
+			err = model.Create(context.Background(), db)
 			if err != nil {
 				panic(err)
 			}
@@ -518,22 +761,29 @@ func TestUser_Delete(t *testing.T) {
 	db := newDB()
 	defer db.Close()
 	defer db.Exec("delete from users")
+	defer db.Exec("delete from companies")
 
 	type test struct {
 		name    string
 		db      DB
 		id      users.ID
 		wantErr bool
+		// 制約に違反した項目
+		wantViolations []failure.Violation
 	}
 
 	do := func(tt *test) {
 		t.Run(tt.name, func(t *testing.T) {
 			got := NewUserFromID(tt.id).(*user)
-			err := got.Delete(tt.db)
+			err := got.Delete(context.Background(), tt.db)
 			if tt.wantErr != (err != nil) {
 				t.Fatalf("want-error=%v, error=%v.", tt.wantErr, err)
 			}
 
+			if v := failure.Violations(err); !reflect.DeepEqual(tt.wantViolations, v) {
+				t.Fatalf("want=%v, got=%v.", tt.wantViolations, v)
+			}
+
 			if tt.wantErr {
 				return
 			}
@@ -555,7 +805,7 @@ func TestUser_Delete(t *testing.T) {
 				panic(err)
 			}
 			model := NewUser(users.New("Bob", pw)).(*user)
-			err = model.Create(db)
+			err = model.Create(context.Background(), db)
 			if err != nil {
 				panic(err)
 			}
@@ -567,6 +817,21 @@ func TestUser_Delete(t *testing.T) {
 				wantErr: false,
 			}
 		}(),
+		func() *test {
+			ownerID := createOwner(db, "Owner")
+			err := NewCompany(companies.New("OWNED COMPANY", ownerID)).Create(context.Background(), db)
+			if err != nil {
+				panic(err)
+			}
+
+			return &test{
+				name:           "owner of company",
+				db:             db,
+				id:             ownerID,
+				wantErr:        true,
+				wantViolations: []failure.Violation{{Field: "user_id", Rule: failure.RuleUnreferenced}},
+			}
+		}(),
 		{
 			name: "failed ExecContext",
 			db: &testdb{
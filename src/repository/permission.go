@@ -0,0 +1,99 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	permissions "api.example.com/pkg/permission"
+	"api.example.com/repository/model"
+)
+
+func permissionContains(list []permissions.Permission, p permissions.Permission) bool {
+	for _, v := range list {
+		if v == p {
+			return true
+		}
+	}
+	return false
+}
+
+func permissionList(ctx context.Context, db model.DB, list model.Permissions) ([]permissions.Permission, error) {
+	err := list.Read(ctx, db)
+	if err != nil {
+		return nil, fmt.Errorf("repository.PermissionList: %w", err)
+	}
+
+	return list.NewEntity(), nil
+}
+
+// employee は会社に実在する管理者であること
+// granted は従業員に付与済みの権限
+func permissionGrant(
+	ctx context.Context,
+	tx Transaction,
+	employee model.Employee,
+	granted model.Permissions,
+	permission model.Permission,
+) error {
+	err := employee.Read(ctx, tx)
+	if err != nil {
+		tx.Rollback()
+		return fmt.Errorf("repository.PermissionGrant: employee: %w", err)
+	}
+
+	if !employee.NewEntity().Administrator {
+		tx.Rollback()
+		return fmt.Errorf("repository.PermissionGrant: %w", permissions.ErrNotAdministrator)
+	}
+
+	err = granted.Read(ctx, tx)
+	if err != nil {
+		tx.Rollback()
+		return fmt.Errorf("repository.PermissionGrant: %w", err)
+	}
+
+	if permissionContains(granted.NewEntity(), permission.NewEntity()) {
+		tx.Rollback()
+		return nil
+	}
+
+	err = permission.Create(ctx, tx)
+	if err != nil {
+		tx.Rollback()
+		return fmt.Errorf("repository.PermissionGrant: %w", err)
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return fmt.Errorf("repository.PermissionGrant: %w", err)
+	}
+
+	return nil
+}
+
+// granted は従業員に付与済みの権限
+func permissionRevoke(ctx context.Context, tx Transaction, granted model.Permissions, permission model.Permission) error {
+	err := granted.Read(ctx, tx)
+	if err != nil {
+		tx.Rollback()
+		return fmt.Errorf("repository.PermissionRevoke: %w", err)
+	}
+
+	if !permissionContains(granted.NewEntity(), permission.NewEntity()) {
+		tx.Rollback()
+		return nil
+	}
+
+	err = permission.Delete(ctx, tx)
+	if err != nil {
+		tx.Rollback()
+		return fmt.Errorf("repository.PermissionRevoke: %w", err)
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return fmt.Errorf("repository.PermissionRevoke: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,235 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	employees "api.example.com/pkg/employee"
+	permissions "api.example.com/pkg/permission"
+	"api.example.com/repository/model"
+)
+
+// mock
+type modelPermission struct {
+	entity permissions.Permission
+	err    error
+	// flags
+	create, delete, newEntity bool
+	// test
+	t *testing.T
+}
+
+func (p *modelPermission) Create(ctx context.Context, tx model.DB) error {
+	p.t.Helper()
+	if p.create {
+		return p.err
+	}
+
+	p.t.Fatal("invalid Create")
+	panic("invalid Create")
+}
+
+func (p *modelPermission) Delete(ctx context.Context, tx model.DB) error {
+	p.t.Helper()
+	if p.delete {
+		return p.err
+	}
+
+	p.t.Fatal("invalid Delete")
+	panic("invalid Delete")
+}
+
+func (p *modelPermission) NewEntity() permissions.Permission {
+	p.t.Helper()
+	if p.newEntity {
+		return p.entity
+	}
+
+	p.t.Fatal("invalid NewEntity")
+	panic("invalid NewEntity")
+}
+
+// mock
+type modelPermissions struct {
+	entity []permissions.Permission
+	err    error
+	// flags
+	read, newEntity bool
+	// test
+	t *testing.T
+}
+
+func (l *modelPermissions) Read(ctx context.Context, tx model.DB) error {
+	l.t.Helper()
+	if l.read {
+		return l.err
+	}
+
+	l.t.Fatal("invalid Read")
+	panic("invalid Read")
+}
+
+func (l *modelPermissions) NewEntity() []permissions.Permission {
+	l.t.Helper()
+	if l.newEntity {
+		return l.entity
+	}
+
+	l.t.Fatal("invalid NewEntity")
+	panic("invalid NewEntity")
+}
+
+func TestPermissionGrant(t *testing.T) {
+	type test struct {
+		name           string
+		tx             Transaction
+		makeEmployee   makeModelEmployee
+		makeGranted    func(*testing.T) model.Permissions
+		makePermission func(*testing.T) model.Permission
+		wantErr        error
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.name, func(t *testing.T) {
+			err := permissionGrant(context.Background(), tt.tx, tt.makeEmployee(t), tt.makeGranted(t), tt.makePermission(t))
+			if tt.wantErr == nil && err != nil {
+				t.Fatalf("error=%v.", err)
+			}
+
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Fatalf("want-error=%v, error=%v.", tt.wantErr, err)
+			}
+		})
+	}
+
+	admin := &employees.Employee{ID: 2, CompanyID: 1, UserID: 3, Administrator: true}
+	errNotFound := errors.New("not found")
+
+	tests := []*test{
+		{
+			name: "ok",
+			tx:   &transaction{commit: true},
+			makeEmployee: func(t *testing.T) model.Employee {
+				return &modelEmployee{entity: admin, read: true, newEntity: true, t: t}
+			},
+			makeGranted: func(t *testing.T) model.Permissions {
+				return &modelPermissions{entity: []permissions.Permission{permissions.ManageEmployees}, read: true, newEntity: true, t: t}
+			},
+			makePermission: func(t *testing.T) model.Permission {
+				return &modelPermission{entity: permissions.ManageRoles, create: true, newEntity: true, t: t}
+			},
+			wantErr: nil,
+		},
+		{
+			name: "already granted",
+			tx:   &transaction{rollback: true},
+			makeEmployee: func(t *testing.T) model.Employee {
+				return &modelEmployee{entity: admin, read: true, newEntity: true, t: t}
+			},
+			makeGranted: func(t *testing.T) model.Permissions {
+				return &modelPermissions{entity: []permissions.Permission{permissions.ManageRoles}, read: true, newEntity: true, t: t}
+			},
+			makePermission: func(t *testing.T) model.Permission {
+				return &modelPermission{entity: permissions.ManageRoles, newEntity: true, t: t}
+			},
+			wantErr: nil,
+		},
+		{
+			name: "employee not found",
+			tx:   &transaction{rollback: true},
+			makeEmployee: func(t *testing.T) model.Employee {
+				return &modelEmployee{err: errNotFound, read: true, t: t}
+			},
+			makeGranted: func(t *testing.T) model.Permissions {
+				return &modelPermissions{t: t}
+			},
+			makePermission: func(t *testing.T) model.Permission {
+				return &modelPermission{t: t}
+			},
+			wantErr: errNotFound,
+		},
+		{
+			name: "not administrator",
+			tx:   &transaction{rollback: true},
+			makeEmployee: func(t *testing.T) model.Employee {
+				return &modelEmployee{
+					entity:    &employees.Employee{ID: 2, CompanyID: 1, UserID: 3, Administrator: false},
+					read:      true,
+					newEntity: true,
+					t:         t,
+				}
+			},
+			makeGranted: func(t *testing.T) model.Permissions {
+				return &modelPermissions{t: t}
+			},
+			makePermission: func(t *testing.T) model.Permission {
+				return &modelPermission{t: t}
+			},
+			wantErr: permissions.ErrNotAdministrator,
+		},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+}
+
+func TestPermissionRevoke(t *testing.T) {
+	type test struct {
+		name           string
+		tx             Transaction
+		makeGranted    func(*testing.T) model.Permissions
+		makePermission func(*testing.T) model.Permission
+		wantErr        bool
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.name, func(t *testing.T) {
+			err := permissionRevoke(context.Background(), tt.tx, tt.makeGranted(t), tt.makePermission(t))
+			if tt.wantErr != (err != nil) {
+				t.Fatalf("want-error=%v, error=%v.", tt.wantErr, err)
+			}
+		})
+	}
+
+	tests := []*test{
+		{
+			name: "ok",
+			tx:   &transaction{commit: true},
+			makeGranted: func(t *testing.T) model.Permissions {
+				return &modelPermissions{entity: []permissions.Permission{permissions.ManageRoles}, read: true, newEntity: true, t: t}
+			},
+			makePermission: func(t *testing.T) model.Permission {
+				return &modelPermission{entity: permissions.ManageRoles, delete: true, newEntity: true, t: t}
+			},
+			wantErr: false,
+		},
+		{
+			name: "not granted",
+			tx:   &transaction{rollback: true},
+			makeGranted: func(t *testing.T) model.Permissions {
+				return &modelPermissions{entity: []permissions.Permission{}, read: true, newEntity: true, t: t}
+			},
+			makePermission: func(t *testing.T) model.Permission {
+				return &modelPermission{entity: permissions.ManageRoles, newEntity: true, t: t}
+			},
+			wantErr: false,
+		},
+		{
+			name: "failed delete",
+			tx:   &transaction{rollback: true},
+			makeGranted: func(t *testing.T) model.Permissions {
+				return &modelPermissions{entity: []permissions.Permission{permissions.ManageRoles}, read: true, newEntity: true, t: t}
+			},
+			makePermission: func(t *testing.T) model.Permission {
+				return &modelPermission{entity: permissions.ManageRoles, err: errors.New("test error"), delete: true, newEntity: true, t: t}
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+}
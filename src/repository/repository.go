@@ -1,17 +1,28 @@
 package repository
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
+	"time"
 
+	apikeys "api.example.com/pkg/apikey"
+	assignments "api.example.com/pkg/assignment"
+	auth "api.example.com/pkg/auth"
 	companies "api.example.com/pkg/company"
+	employees "api.example.com/pkg/employee"
+	organizations "api.example.com/pkg/organization"
+	permissions "api.example.com/pkg/permission"
+	roles "api.example.com/pkg/role"
+	sessions "api.example.com/pkg/session"
 	users "api.example.com/pkg/user"
+	"api.example.com/repository/migration"
 	"api.example.com/repository/model"
 )
 
 type DB interface {
 	model.DB
-	Begin() (*sql.Tx, error)
+	BeginTx(context.Context, *sql.TxOptions) (*sql.Tx, error)
 	Close() error
 }
 
@@ -24,11 +35,25 @@ type Transaction interface {
 type Repository interface {
 	users.Repository
 	companies.Repository
+	employees.Repository
+	organizations.Repository
+	roles.Repository
+	assignments.Repository
+	permissions.Repository
+	auth.Repository
+	sessions.Repository
+	apikeys.Repository
+	// fn の中の操作を1つのトランザクションで行う
+	WithTx(context.Context, func(Repository) error) error
 	Close() error
 }
 
 type repository struct {
 	db DB
+	// WithTx の中でのみ持つトランザクション
+	tx Transaction
+	// nil であれば MySQL の文をそのまま使う
+	dialect model.Dialect
 }
 
 func New(db *sql.DB) Repository {
@@ -37,50 +62,438 @@ func New(db *sql.DB) Repository {
 	}
 }
 
+// SQLite のデータベースを使う
+// 未適用のマイグレーションを適用する
+func NewSQLite(ctx context.Context, db *sql.DB) (Repository, error) {
+	r := &repository{
+		db:      db,
+		dialect: model.SQLite,
+	}
+
+	m, err := migration.NewSQLite(db)
+	if err != nil {
+		return nil, fmt.Errorf("repository.NewSQLite: %w", err)
+	}
+
+	_, err = m.Up(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("repository.NewSQLite: %w", err)
+	}
+
+	return r, nil
+}
+
 func (r *repository) Close() error {
 	return r.db.Close()
 }
 
-func (r *repository) UserCreate(u *users.User) (*users.User, error) {
-	tx, err := r.db.Begin()
+func (r *repository) UserCreate(ctx context.Context, u *users.User) (*users.User, error) {
+	tx, err := r.begin(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("repository.UserCreate: %w", err)
 	}
 
-	return UserCreate(tx, model.NewUser(u))
+	return UserCreate(ctx, tx, model.NewUser(u))
 }
 
-func (r *repository) UserRead(id users.ID) (*users.User, error) {
-	return UserRead(r.db, model.NewUserFromID(id))
+func (r *repository) UserRead(ctx context.Context, id users.ID) (*users.User, error) {
+	return UserRead(ctx, r.conn(), model.NewUserFromID(id))
 }
 
-func (r *repository) UserUpdate(u *users.User) (*users.User, error) {
-	tx, err := r.db.Begin()
+func (r *repository) UserReadByName(ctx context.Context, name users.Name) (*users.User, error) {
+	return UserReadByName(ctx, r.conn(), model.NewUserFromName(name))
+}
+
+func (r *repository) UserList(ctx context.Context, prefix users.Name, after users.ID, limit int) ([]*users.User, error) {
+	return UserList(ctx, r.conn(), model.NewUsersFromPrefix(prefix, after, limit))
+}
+
+func (r *repository) UserUpdate(ctx context.Context, u *users.User) (*users.User, error) {
+	tx, err := r.begin(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("repository.UserUpdate: %w", err)
 	}
 
-	return UserUpdate(tx, model.NewUser(u))
+	return UserUpdate(ctx, tx, model.NewUser(u))
 }
 
-func (r *repository) UserDelete(id users.ID) error {
-	tx, err := r.db.Begin()
+func (r *repository) UserDelete(ctx context.Context, id users.ID) error {
+	tx, err := r.begin(ctx)
 	if err != nil {
 		return fmt.Errorf("repository.UserDelete: %w", err)
 	}
 
-	return UserDelete(tx, model.NewUserFromID(id))
+	return UserDelete(ctx, tx, model.NewUserFromID(id))
+}
+
+func (r *repository) UserTx(ctx context.Context, fn func(users.Repository) error) error {
+	return r.WithTx(ctx, func(tx Repository) error {
+		return fn(tx)
+	})
+}
+
+func (r *repository) SessionCreate(ctx context.Context, s *sessions.Session) (*sessions.Session, error) {
+	tx, err := r.begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("repository.SessionCreate: %w", err)
+	}
+
+	return sessionCreate(ctx, tx, model.NewUserFromID(s.UserID), model.NewSession(s))
 }
 
-func (r *repository) CompanyCreate(c *companies.Company) (*companies.Company, error) {
-	tx, err := r.db.Begin()
+func (r *repository) SessionRead(ctx context.Context, id sessions.ID) (*sessions.Session, error) {
+	return sessionRead(ctx, r.conn(), model.NewSessionFromID(id))
+}
+
+func (r *repository) SessionDelete(ctx context.Context, id sessions.ID) error {
+	tx, err := r.begin(ctx)
+	if err != nil {
+		return fmt.Errorf("repository.SessionDelete: %w", err)
+	}
+
+	return sessionDelete(ctx, tx, model.NewSessionFromID(id))
+}
+
+func (r *repository) SessionDeleteByUserID(ctx context.Context, userID users.ID) error {
+	tx, err := r.begin(ctx)
+	if err != nil {
+		return fmt.Errorf("repository.SessionDeleteByUserID: %w", err)
+	}
+
+	return sessionDeleteAll(ctx, tx, model.NewSessionsFromUserID(userID))
+}
+
+func (r *repository) SessionDeleteExpired(ctx context.Context, at time.Time) error {
+	tx, err := r.begin(ctx)
+	if err != nil {
+		return fmt.Errorf("repository.SessionDeleteExpired: %w", err)
+	}
+
+	return sessionDeleteAll(ctx, tx, model.NewExpiredSessions(at))
+}
+
+func (r *repository) CompanyCreate(ctx context.Context, c *companies.Company) (*companies.Company, error) {
+	tx, err := r.begin(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("repository.CompanyCreate: %w", err)
 	}
 
-	return companyCreate(tx, model.NewCompany(c))
+	return companyCreate(
+		ctx,
+		tx,
+		model.NewUserFromID(c.OwnerID),
+		model.NewCompany(c),
+		model.NewAdministrator,
+	)
+}
+
+func (r *repository) CompanyRead(ctx context.Context, id companies.ID) (*companies.Company, error) {
+	return companyRead(ctx, r.conn(), model.NewCompanyFromID(id))
+}
+
+func (r *repository) CompanyList(ctx context.Context, userID companies.OwnerID, prefix companies.Name, after companies.ID, limit int) ([]*companies.Company, error) {
+	return companyList(ctx, r.conn(), model.NewCompaniesFromUserID(userID, prefix, after, limit))
+}
+
+func (r *repository) CompanyUpdate(ctx context.Context, c *companies.Company) (*companies.Company, error) {
+	tx, err := r.begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("repository.CompanyUpdate: %w", err)
+	}
+
+	return companyUpdate(
+		ctx,
+		tx,
+		model.NewCompanyFromID(c.ID),
+		model.NewEmployeeFromUserID(c.ID, c.OwnerID),
+		model.NewCompany(c),
+	)
+}
+
+func (r *repository) CompanyDelete(ctx context.Context, id companies.ID) error {
+	tx, err := r.begin(ctx)
+	if err != nil {
+		return fmt.Errorf("repository.CompanyDelete: %w", err)
+	}
+
+	return companyDelete(ctx, tx, model.NewCompanyFromID(id))
+}
+
+func (r *repository) EmployeeCreate(ctx context.Context, e *employees.Employee) (*employees.Employee, error) {
+	tx, err := r.begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("repository.EmployeeCreate: %w", err)
+	}
+
+	return employeeCreate(
+		ctx,
+		tx,
+		model.NewCompanyFromID(e.CompanyID),
+		model.NewUserFromID(e.UserID),
+		model.NewEmployee(e),
+	)
+}
+
+func (r *repository) EmployeeRead(ctx context.Context, companyID employees.CompanyID, id employees.ID) (*employees.Employee, error) {
+	return employeeRead(ctx, r.conn(), model.NewEmployeeFromID(companyID, id))
+}
+
+func (r *repository) EmployeeReadByUserID(ctx context.Context, companyID employees.CompanyID, userID employees.UserID) (*employees.Employee, error) {
+	return employeeReadByUserID(ctx, r.conn(), model.NewEmployeeFromUserID(companyID, userID))
+}
+
+func (r *repository) EmployeeList(ctx context.Context, companyID employees.CompanyID) ([]*employees.Employee, error) {
+	return employeeList(ctx, r.conn(), model.NewEmployeesFromCompanyID(companyID))
+}
+
+func (r *repository) EmployeeDelete(ctx context.Context, companyID employees.CompanyID, id employees.ID) error {
+	tx, err := r.begin(ctx)
+	if err != nil {
+		return fmt.Errorf("repository.EmployeeDelete: %w", err)
+	}
+
+	return employeeDelete(
+		ctx,
+		tx,
+		model.NewCompanyFromID(companyID),
+		model.NewAdministratorsFromCompanyID(companyID),
+		model.NewEmployeeFromID(companyID, id),
+	)
+}
+
+// parentID が Root の場合は nil
+func newParentDepartment(companyID organizations.CompanyID, parentID organizations.ID) model.Department {
+	if parentID == organizations.Root {
+		return nil
+	}
+
+	return model.NewDepartmentFromID(companyID, parentID)
+}
+
+func (r *repository) DepartmentCreate(ctx context.Context, d *organizations.Department) (*organizations.Department, error) {
+	tx, err := r.begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("repository.DepartmentCreate: %w", err)
+	}
+
+	return departmentCreate(
+		ctx,
+		tx,
+		model.NewCompanyFromID(d.CompanyID),
+		newParentDepartment(d.CompanyID, d.ParentID),
+		model.NewDepartment(d),
+	)
+}
+
+func (r *repository) DepartmentRead(ctx context.Context, companyID organizations.CompanyID, id organizations.ID) (*organizations.Department, error) {
+	return departmentRead(ctx, r.conn(), model.NewDepartmentFromID(companyID, id))
+}
+
+func (r *repository) DepartmentUpdate(ctx context.Context, d *organizations.Department) (*organizations.Department, error) {
+	tx, err := r.begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("repository.DepartmentUpdate: %w", err)
+	}
+
+	return departmentUpdate(ctx, tx, model.NewDepartment(d))
 }
 
-func (r *repository) CompanyRead(id companies.ID) (*companies.Company, error) {
-	return companyRead(r.db, model.NewCompanyFromID(id))
+func (r *repository) DepartmentMove(ctx context.Context, d *organizations.Department) (*organizations.Department, error) {
+	tx, err := r.begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("repository.DepartmentMove: %w", err)
+	}
+
+	return departmentMove(ctx, tx, newParentDepartment(d.CompanyID, d.ParentID), model.NewDepartment(d))
+}
+
+func (r *repository) DepartmentDelete(ctx context.Context, companyID organizations.CompanyID, id organizations.ID) error {
+	tx, err := r.begin(ctx)
+	if err != nil {
+		return fmt.Errorf("repository.DepartmentDelete: %w", err)
+	}
+
+	return departmentDelete(ctx, tx, model.NewDepartmentFromID(companyID, id))
+}
+
+func (r *repository) DepartmentChildren(ctx context.Context, companyID organizations.CompanyID, id organizations.ID) ([]*organizations.Department, error) {
+	return departmentChildren(
+		ctx,
+		r.conn(),
+		newParentDepartment(companyID, id),
+		model.NewDepartmentsFromParentID(companyID, id),
+	)
+}
+
+func (r *repository) DepartmentAncestors(ctx context.Context, companyID organizations.CompanyID, id organizations.ID) ([]*organizations.Department, error) {
+	return departmentAncestors(
+		ctx,
+		r.conn(),
+		model.NewDepartmentFromID(companyID, id),
+		model.NewDepartmentAncestors(companyID, id),
+	)
+}
+
+func (r *repository) DepartmentSubtree(ctx context.Context, companyID organizations.CompanyID, id organizations.ID) ([]*organizations.Department, error) {
+	return departmentSubtree(
+		ctx,
+		r.conn(),
+		model.NewDepartmentFromID(companyID, id),
+		model.NewDepartmentSubtree(companyID, id),
+	)
+}
+
+func (r *repository) RoleCreate(ctx context.Context, role *roles.Role) (*roles.Role, error) {
+	tx, err := r.begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("repository.RoleCreate: %w", err)
+	}
+
+	return roleCreate(
+		ctx,
+		tx,
+		model.NewCompanyFromID(role.CompanyID),
+		model.NewRolesFromName(role.CompanyID, role.Name),
+		model.NewRole(role),
+	)
+}
+
+func (r *repository) RoleRead(ctx context.Context, companyID roles.CompanyID, id roles.ID) (*roles.Role, error) {
+	return roleRead(ctx, r.conn(), model.NewRoleFromID(companyID, id))
+}
+
+func (r *repository) RoleList(ctx context.Context, companyID roles.CompanyID) ([]*roles.Role, error) {
+	return roleList(ctx, r.conn(), model.NewRolesFromCompanyID(companyID))
+}
+
+func (r *repository) RoleUpdate(ctx context.Context, role *roles.Role) (*roles.Role, error) {
+	tx, err := r.begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("repository.RoleUpdate: %w", err)
+	}
+
+	return roleUpdate(ctx, tx, model.NewRolesFromName(role.CompanyID, role.Name), model.NewRole(role))
+}
+
+func (r *repository) RoleDelete(ctx context.Context, companyID roles.CompanyID, id roles.ID) error {
+	tx, err := r.begin(ctx)
+	if err != nil {
+		return fmt.Errorf("repository.RoleDelete: %w", err)
+	}
+
+	return roleDelete(ctx, tx, model.NewRoleFromID(companyID, id))
+}
+
+func (r *repository) AssignmentCreate(ctx context.Context, a *assignments.Assignment) (*assignments.Assignment, error) {
+	tx, err := r.begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("repository.AssignmentCreate: %w", err)
+	}
+
+	return assignmentCreate(
+		ctx,
+		tx,
+		model.NewEmployeeFromID(a.CompanyID, a.EmployeeID),
+		model.NewDepartmentFromID(a.CompanyID, a.DepartmentID),
+		model.NewRoleFromID(a.CompanyID, a.RoleID),
+		model.NewAssignmentsFromEmployeeID(a.CompanyID, a.EmployeeID),
+		model.NewAssignment(a),
+	)
+}
+
+func (r *repository) AssignmentRead(ctx context.Context, companyID assignments.CompanyID, id assignments.ID) (*assignments.Assignment, error) {
+	return assignmentRead(ctx, r.conn(), model.NewAssignmentFromID(companyID, id))
+}
+
+func (r *repository) AssignmentListByEmployee(ctx context.Context, companyID assignments.CompanyID, employeeID assignments.EmployeeID) ([]*assignments.Assignment, error) {
+	return assignmentList(ctx, r.conn(), model.NewAssignmentsFromEmployeeID(companyID, employeeID))
+}
+
+func (r *repository) AssignmentListByDepartment(ctx context.Context, companyID assignments.CompanyID, departmentID assignments.DepartmentID) ([]*assignments.Assignment, error) {
+	return assignmentList(ctx, r.conn(), model.NewAssignmentsFromDepartmentID(companyID, departmentID))
+}
+
+func (r *repository) AssignmentListBySubtree(ctx context.Context, companyID assignments.CompanyID, departmentID assignments.DepartmentID) ([]*assignments.Assignment, error) {
+	return assignmentList(ctx, r.conn(), model.NewAssignmentsFromSubtree(companyID, departmentID))
+}
+
+func (r *repository) AssignmentDelete(ctx context.Context, companyID assignments.CompanyID, id assignments.ID) error {
+	tx, err := r.begin(ctx)
+	if err != nil {
+		return fmt.Errorf("repository.AssignmentDelete: %w", err)
+	}
+
+	return assignmentDelete(ctx, tx, model.NewAssignmentFromID(companyID, id))
+}
+
+func (r *repository) PermissionList(ctx context.Context, companyID permissions.CompanyID, employeeID permissions.EmployeeID) ([]permissions.Permission, error) {
+	return permissionList(ctx, r.conn(), model.NewPermissionsFromEmployeeID(companyID, employeeID))
+}
+
+func (r *repository) PermissionGrant(ctx context.Context, companyID permissions.CompanyID, employeeID permissions.EmployeeID, p permissions.Permission) error {
+	tx, err := r.begin(ctx)
+	if err != nil {
+		return fmt.Errorf("repository.PermissionGrant: %w", err)
+	}
+
+	return permissionGrant(
+		ctx,
+		tx,
+		model.NewEmployeeFromID(companyID, employeeID),
+		model.NewPermissionsFromEmployeeID(companyID, employeeID),
+		model.NewPermission(companyID, employeeID, p),
+	)
+}
+
+func (r *repository) PermissionRevoke(ctx context.Context, companyID permissions.CompanyID, employeeID permissions.EmployeeID, p permissions.Permission) error {
+	tx, err := r.begin(ctx)
+	if err != nil {
+		return fmt.Errorf("repository.PermissionRevoke: %w", err)
+	}
+
+	return permissionRevoke(
+		ctx,
+		tx,
+		model.NewPermissionsFromEmployeeID(companyID, employeeID),
+		model.NewPermission(companyID, employeeID, p),
+	)
+}
+
+func (r *repository) APIKeyCreate(ctx context.Context, k *apikeys.Key) (*apikeys.Key, error) {
+	tx, err := r.begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("repository.APIKeyCreate: %w", err)
+	}
+
+	return apiKeyCreate(ctx, tx, model.NewCompanyFromID(k.CompanyID), model.NewAPIKey(k))
+}
+
+func (r *repository) APIKeyRead(ctx context.Context, companyID apikeys.CompanyID, id apikeys.ID) (*apikeys.Key, error) {
+	return apiKeyRead(ctx, r.conn(), model.NewAPIKeyFromID(companyID, id))
+}
+
+func (r *repository) APIKeyReadByID(ctx context.Context, id apikeys.ID) (*apikeys.Key, error) {
+	return apiKeyReadByID(ctx, r.conn(), model.NewAPIKeyFromKeyID(id))
+}
+
+func (r *repository) APIKeyList(ctx context.Context, companyID apikeys.CompanyID) ([]*apikeys.Key, error) {
+	return apiKeyList(ctx, r.conn(), model.NewAPIKeysFromCompanyID(companyID))
+}
+
+func (r *repository) APIKeyUpdate(ctx context.Context, k *apikeys.Key) (*apikeys.Key, error) {
+	tx, err := r.begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("repository.APIKeyUpdate: %w", err)
+	}
+
+	return apiKeyUpdate(ctx, tx, model.NewAPIKey(k))
+}
+
+func (r *repository) APIKeyDelete(ctx context.Context, companyID apikeys.CompanyID, id apikeys.ID) error {
+	tx, err := r.begin(ctx)
+	if err != nil {
+		return fmt.Errorf("repository.APIKeyDelete: %w", err)
+	}
+
+	return apiKeyDelete(ctx, tx, model.NewAPIKeyFromID(companyID, id))
 }
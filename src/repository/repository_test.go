@@ -12,7 +12,14 @@ import (
 	"time"
 
 	"api.example.com/env"
+	apikeys "api.example.com/pkg/apikey"
+	assignments "api.example.com/pkg/assignment"
 	companies "api.example.com/pkg/company"
+	employees "api.example.com/pkg/employee"
+	organizations "api.example.com/pkg/organization"
+	permissions "api.example.com/pkg/permission"
+	roles "api.example.com/pkg/role"
+	sessions "api.example.com/pkg/session"
 	users "api.example.com/pkg/user"
 	"api.example.com/pkg/user/password"
 	"api.example.com/repository/model"
@@ -50,6 +57,22 @@ func newDB() *sql.DB {
 	return db
 }
 
+// helper
+func createUser(db DB, name users.Name) users.ID {
+	pw, err := password.New("password")
+	if err != nil {
+		panic(err)
+	}
+
+	model := model.NewUser(users.New(name, pw))
+	err = model.Create(context.Background(), db)
+	if err != nil {
+		panic(err)
+	}
+
+	return model.NewEntity().ID
+}
+
 // mock
 type mockDB struct {
 	tx  *sql.Tx
@@ -58,11 +81,11 @@ type mockDB struct {
 	begin, close bool
 }
 
-func (db *mockDB) Begin() (*sql.Tx, error) {
+func (db *mockDB) BeginTx(context.Context, *sql.TxOptions) (*sql.Tx, error) {
 	if db.begin {
 		return db.tx, db.err
 	}
-	return nil, errors.New("invalid Begin")
+	return nil, errors.New("invalid BeginTx")
 }
 
 func (db *mockDB) Close() error {
@@ -76,6 +99,10 @@ func (db *mockDB) ExecContext(context.Context, string, ...interface{}) (sql.Resu
 	panic("invalid ExecContext")
 }
 
+func (db *mockDB) QueryContext(context.Context, string, ...interface{}) (*sql.Rows, error) {
+	panic("invalid QueryContext")
+}
+
 func (db *mockDB) QueryRowContext(context.Context, string, ...interface{}) *sql.Row {
 	panic("invalid QueryRowContext")
 }
@@ -105,6 +132,10 @@ func (tx *transaction) ExecContext(context.Context, string, ...interface{}) (sql
 	panic("invalid ExecContext")
 }
 
+func (tx *transaction) QueryContext(context.Context, string, ...interface{}) (*sql.Rows, error) {
+	panic("invalid QueryContext")
+}
+
 func (tx *transaction) QueryRowContext(context.Context, string, ...interface{}) *sql.Row {
 	panic("invalid QueryRowContext")
 }
@@ -135,7 +166,7 @@ func TestNew(t *testing.T) {
 
 			return &test{
 				db:   db,
-				want: &repository{db},
+				want: &repository{db: db},
 			}
 		}(),
 	}
@@ -164,7 +195,7 @@ func TestRepository_Close(t *testing.T) {
 	tests := []*test{
 		{
 			name:       "true",
-			repository: &repository{newDB()},
+			repository: &repository{db: newDB()},
 			wantErr:    false,
 		},
 	}
@@ -192,8 +223,8 @@ func TestRepository_UserCreate(t *testing.T) {
 
 	do := func(tt *test) {
 		t.Run(tt.name, func(t *testing.T) {
-			repo := &repository{tt.db}
-			got, err := repo.UserCreate(tt.user)
+			repo := &repository{db: tt.db}
+			got, err := repo.UserCreate(context.Background(), tt.user)
 			if tt.wantErr != (err != nil) {
 				t.Fatalf("want-error=%v, error=%v.", tt.wantErr, err)
 			}
@@ -271,7 +302,7 @@ func TestRepository_UserRead(t *testing.T) {
 
 	do := func(tt *test) {
 		t.Run(tt.name, func(t *testing.T) {
-			got, err := repo.UserRead(tt.id)
+			got, err := repo.UserRead(context.Background(), tt.id)
 			if tt.wantErr != (err != nil) {
 				t.Fatalf("want-error=%v, error=%v.", tt.wantErr, err)
 			}
@@ -298,7 +329,7 @@ func TestRepository_UserRead(t *testing.T) {
 				panic(err)
 			}
 			model := model.NewUser(users.New("Bob", pw))
-			err = model.Create(repo.db)
+			err = model.Create(context.Background(), repo.db)
 			if err != nil {
 				panic(err)
 			}
@@ -323,6 +354,82 @@ func TestRepository_UserRead(t *testing.T) {
 	}
 }
 
+func TestRepository_UserReadByName(t *testing.T) {
+	tableLock.Lock()
+	defer tableLock.Unlock()
+
+	db := newDB()
+	repo := New(db).(*repository)
+	defer db.Close()
+	defer db.Exec("delete from users")
+
+	type test struct {
+		name     string
+		userName users.Name
+		want     *users.User
+		wantErr  bool
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := repo.UserReadByName(context.Background(), tt.userName)
+			if tt.wantErr != (err != nil) {
+				t.Fatalf("want-error=%v, error=%v.", tt.wantErr, err)
+			}
+
+			if tt.wantErr {
+				return
+			}
+
+			wantTime := time.Now()
+
+			testDiffTime(t, wantTime, got.UpdatedAt)
+			tt.want.UpdatedAt = got.UpdatedAt
+
+			if !reflect.DeepEqual(tt.want, got) {
+				t.Fatalf("want=%v, got=%v.", tt.want, got)
+			}
+		})
+	}
+
+	tests := []*test{
+		func() *test {
+			pw, err := password.New("password")
+			if err != nil {
+				panic(err)
+			}
+			model := model.NewUser(users.New("Bob", pw))
+			err = model.Create(context.Background(), repo.db)
+			if err != nil {
+				panic(err)
+			}
+
+			entity := model.NewEntity()
+
+			return &test{
+				name:     "true",
+				userName: "Bob",
+				want: &users.User{
+					ID:       entity.ID,
+					Name:     "Bob",
+					Password: password.FromHash(pw.Hash()),
+				},
+				wantErr: false,
+			}
+		}(),
+		{
+			name:     "not found",
+			userName: "Alice",
+			want:     nil,
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+}
+
 func TestRepository_UserUpdate(t *testing.T) {
 	tableLock.Lock()
 	defer tableLock.Unlock()
@@ -341,8 +448,8 @@ func TestRepository_UserUpdate(t *testing.T) {
 
 	do := func(tt *test) {
 		t.Run(tt.name, func(t *testing.T) {
-			repo := &repository{tt.db}
-			got, err := repo.UserUpdate(tt.user)
+			repo := &repository{db: tt.db}
+			got, err := repo.UserUpdate(context.Background(), tt.user)
 			if tt.wantErr != (err != nil) {
 				t.Fatalf("want-error=%v, error=%v.", tt.wantErr, err)
 			}
@@ -371,7 +478,7 @@ func TestRepository_UserUpdate(t *testing.T) {
 			}
 
 			model := model.NewUser(users.New("Bob", pw))
-			err = model.Create(db)
+			err = model.Create(context.Background(), db)
 			if err != nil {
 				panic(err)
 			}
@@ -405,7 +512,7 @@ func TestRepository_UserUpdate(t *testing.T) {
 			}
 
 			model := model.NewUser(users.New("Hoge", pw))
-			err = model.Create(db)
+			err = model.Create(context.Background(), db)
 			if err != nil {
 				panic(err)
 			}
@@ -455,8 +562,8 @@ func TestRepository_UserDelete(t *testing.T) {
 
 	do := func(tt *test) {
 		t.Run(tt.name, func(t *testing.T) {
-			repo := &repository{tt.db}
-			err := repo.UserDelete(tt.id)
+			repo := &repository{db: tt.db}
+			err := repo.UserDelete(context.Background(), tt.id)
 			if tt.wantErr != (err != nil) {
 				t.Fatalf("want-error=%v, error=%v.", tt.wantErr, err)
 			}
@@ -470,7 +577,7 @@ func TestRepository_UserDelete(t *testing.T) {
 				panic(err)
 			}
 			model := model.NewUser(users.New("Bob", pw))
-			err = model.Create(db)
+			err = model.Create(context.Background(), db)
 			if err != nil {
 				panic(err)
 			}
@@ -490,7 +597,7 @@ func TestRepository_UserDelete(t *testing.T) {
 				panic(err)
 			}
 			model := model.NewUser(users.New("Alice", pw))
-			err = model.Create(db)
+			err = model.Create(context.Background(), db)
 			if err != nil {
 				panic(err)
 			}
@@ -515,14 +622,14 @@ func TestRepository_UserDelete(t *testing.T) {
 }
 
 func TestRepository_CompanyCreate(t *testing.T) {
-	t.Skip("TODO OwnerID")
-
 	tableLock.Lock()
 	defer tableLock.Unlock()
 
 	db := newDB()
 	defer db.Close()
+	defer db.Exec("delete from users")
 	defer db.Exec("delete from companies")
+	defer db.Exec("delete from company_employees")
 
 	type test struct {
 		name    string
@@ -534,7 +641,7 @@ func TestRepository_CompanyCreate(t *testing.T) {
 
 	do := func(tt *test) {
 		t.Run(tt.name, func(t *testing.T) {
-			got, err := (&repository{tt.db}).CompanyCreate(tt.company)
+			got, err := (&repository{db: tt.db}).CompanyCreate(context.Background(), tt.company)
 			if tt.wantErr != (err != nil) {
 				t.Fatalf("want-error=%v, error=%v.", tt.wantErr, err)
 			}
@@ -552,16 +659,39 @@ func TestRepository_CompanyCreate(t *testing.T) {
 			if !reflect.DeepEqual(tt.want, got) {
 				t.Fatalf("want=%v, got=%v.", tt.want, got)
 			}
+
+			// owner が管理者として従業員になっていることの確認
+			var count int64
+			err = db.
+				QueryRow("select count(*) from company_employees where company_id = ? and user_id = ? and administrator", got.ID, got.OwnerID).
+				Scan(&count)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if count != 1 {
+				t.Fatalf("administrator want=1, got=%v.", count)
+			}
 		})
 	}
 
 	tests := []*test{
+		func() *test {
+			ownerID := createUser(db, "Bob")
+
+			return &test{
+				name:    "ok",
+				db:      db,
+				company: companies.New("GREATE COMPANY", ownerID),
+				want:    companies.New("GREATE COMPANY", ownerID),
+				wantErr: false,
+			}
+		}(),
 		{
-			name:    "ok",
+			name:    "owner not found",
 			db:      db,
-			company: companies.New("GREATE COMPANY", 1),
-			want:    companies.New("GREATE COMPANY", 0), // TODO
-			wantErr: false,
+			company: companies.New("GREATE COMPANY", 1<<30),
+			want:    nil,
+			wantErr: true,
 		},
 		{
 			name: "failed begin-transaction",
@@ -587,6 +717,7 @@ func TestRepository_CompanyRead(t *testing.T) {
 	db := newDB()
 	repo := New(db).(*repository)
 	defer db.Close()
+	defer db.Exec("delete from users")
 	defer db.Exec("delete from companies")
 
 	type test struct {
@@ -598,7 +729,7 @@ func TestRepository_CompanyRead(t *testing.T) {
 
 	do := func(tt *test) {
 		t.Run(tt.name, func(t *testing.T) {
-			got, err := repo.CompanyRead(tt.id)
+			got, err := repo.CompanyRead(context.Background(), tt.id)
 			if tt.wantErr != (err != nil) {
 				t.Fatalf("want-error=%v, error=%v.", tt.wantErr, err)
 			}
@@ -620,8 +751,8 @@ func TestRepository_CompanyRead(t *testing.T) {
 
 	tests := []*test{
 		func() *test {
-			model := model.NewCompany(companies.New("testCompany", 1))
-			err := model.Create(repo.db)
+			model := model.NewCompany(companies.New("testCompany", createUser(db, "Bob")))
+			err := model.Create(context.Background(), repo.db)
 			if err != nil {
 				panic(err)
 			}
@@ -645,3 +776,1032 @@ func TestRepository_CompanyRead(t *testing.T) {
 		do(tt)
 	}
 }
+
+func TestRepository_CompanyUpdate(t *testing.T) {
+	tableLock.Lock()
+	defer tableLock.Unlock()
+
+	db := newDB()
+	defer db.Close()
+	defer db.Exec("delete from users")
+	defer db.Exec("delete from companies")
+
+	type test struct {
+		name    string
+		db      DB
+		company *companies.Company
+		want    *companies.Company
+		wantErr bool
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := (&repository{db: tt.db}).CompanyUpdate(context.Background(), tt.company)
+			if tt.wantErr != (err != nil) {
+				t.Fatalf("want-error=%v, error=%v.", tt.wantErr, err)
+			}
+
+			if tt.wantErr {
+				return
+			}
+
+			wantTime := time.Now()
+
+			testDiffTime(t, wantTime, got.UpdatedAt)
+			tt.want.UpdatedAt = got.UpdatedAt
+
+			if !reflect.DeepEqual(tt.want, got) {
+				t.Fatalf("want=%v, got=%v.", tt.want, got)
+			}
+		})
+	}
+
+	tests := []*test{
+		func() *test {
+			model := model.NewCompany(companies.New("testCompany", createUser(db, "Bob")))
+			err := model.Create(context.Background(), db)
+			if err != nil {
+				panic(err)
+			}
+
+			entity := model.NewEntity()
+
+			return &test{
+				name: "ok",
+				db:   db,
+				company: &companies.Company{
+					ID:      entity.ID,
+					Name:    "greatCompany",
+					OwnerID: entity.OwnerID,
+				},
+				want: &companies.Company{
+					ID:      entity.ID,
+					Name:    "greatCompany",
+					OwnerID: entity.OwnerID,
+				},
+				wantErr: false,
+			}
+		}(),
+		{
+			name: "failed begin-transaction",
+			db: &mockDB{
+				err:   errors.New("test error"),
+				begin: true,
+			},
+			company: &companies.Company{ID: 1, Name: "greatCompany", OwnerID: 1},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+}
+
+func TestRepository_CompanyDelete(t *testing.T) {
+	tableLock.Lock()
+	defer tableLock.Unlock()
+
+	db := newDB()
+	defer db.Close()
+	defer db.Exec("delete from users")
+	defer db.Exec("delete from companies")
+
+	type test struct {
+		name    string
+		db      DB
+		id      companies.ID
+		wantErr bool
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.name, func(t *testing.T) {
+			err := (&repository{db: tt.db}).CompanyDelete(context.Background(), tt.id)
+			if tt.wantErr != (err != nil) {
+				t.Fatalf("want-error=%v, error=%v.", tt.wantErr, err)
+			}
+		})
+	}
+
+	tests := []*test{
+		func() *test {
+			model := model.NewCompany(companies.New("testCompany", createUser(db, "Bob")))
+			err := model.Create(context.Background(), db)
+			if err != nil {
+				panic(err)
+			}
+
+			return &test{
+				name:    "ok",
+				db:      db,
+				id:      model.NewEntity().ID,
+				wantErr: false,
+			}
+		}(),
+		{
+			name: "failed begin-transaction",
+			db: &mockDB{
+				err:   errors.New("test error"),
+				begin: true,
+			},
+			id:      1,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+}
+
+func TestRepository_EmployeeCreate(t *testing.T) {
+	tableLock.Lock()
+	defer tableLock.Unlock()
+
+	db := newDB()
+	defer db.Close()
+	defer db.Exec("delete from users")
+	defer db.Exec("delete from companies")
+	defer db.Exec("delete from company_employees")
+
+	type test struct {
+		name     string
+		db       DB
+		employee *employees.Employee
+		want     *employees.Employee
+		wantErr  bool
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := (&repository{db: tt.db}).EmployeeCreate(context.Background(), tt.employee)
+			if tt.wantErr != (err != nil) {
+				t.Fatalf("want-error=%v, error=%v.", tt.wantErr, err)
+			}
+
+			if tt.wantErr {
+				return
+			}
+
+			wantTime := time.Now()
+
+			tt.want.ID = got.ID
+			testDiffTime(t, wantTime, got.UpdatedAt)
+			tt.want.UpdatedAt = got.UpdatedAt
+
+			if !reflect.DeepEqual(tt.want, got) {
+				t.Fatalf("want=%v, got=%v.", tt.want, got)
+			}
+		})
+	}
+
+	repo := &repository{db: db}
+	bob := createUser(db, "Bob")
+	alice := createUser(db, "Alice")
+	companyA, err := repo.CompanyCreate(context.Background(), companies.New("COMPANY A", bob))
+	if err != nil {
+		panic(err)
+	}
+	companyB, err := repo.CompanyCreate(context.Background(), companies.New("COMPANY B", alice))
+	if err != nil {
+		panic(err)
+	}
+
+	tests := []*test{
+		{
+			name:     "ok",
+			db:       db,
+			employee: employees.New(companyA.ID, alice, false),
+			want:     employees.New(companyA.ID, alice, false),
+			wantErr:  false,
+		},
+		{
+			name:     "ok (副業)",
+			db:       db,
+			employee: employees.New(companyB.ID, bob, false),
+			want:     employees.New(companyB.ID, bob, false),
+			wantErr:  false,
+		},
+		{
+			name:     "already employee",
+			db:       db,
+			employee: employees.New(companyA.ID, bob, false),
+			want:     nil,
+			wantErr:  true,
+		},
+		{
+			name:     "company not found",
+			db:       db,
+			employee: employees.New(companyB.ID+1, bob, false),
+			want:     nil,
+			wantErr:  true,
+		},
+		{
+			name:     "user not found",
+			db:       db,
+			employee: employees.New(companyA.ID, alice+1, false),
+			want:     nil,
+			wantErr:  true,
+		},
+		{
+			name: "failed begin-transaction",
+			db: &mockDB{
+				err:   errors.New("test error"),
+				begin: true,
+			},
+			employee: employees.New(companyA.ID, alice, false),
+			want:     nil,
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+}
+
+// 部署ツリー
+// 開発部 > 開発一課 > 基盤チーム
+// 開発部 > 開発二課
+// 営業部
+func TestRepository_DepartmentTree(t *testing.T) {
+	tableLock.Lock()
+	defer tableLock.Unlock()
+
+	db := newDB()
+	defer db.Close()
+	defer db.Exec("delete from users")
+	defer db.Exec("delete from companies")
+	defer db.Exec("delete from company_employees")
+	defer db.Exec("delete from departments")
+
+	repo := &repository{db: db}
+	company, err := repo.CompanyCreate(context.Background(), companies.New("GREATE COMPANY", createUser(db, "Bob")))
+	if err != nil {
+		panic(err)
+	}
+
+	create := func(parentID organizations.ID, name organizations.Name) *organizations.Department {
+		d, err := repo.DepartmentCreate(context.Background(), organizations.New(company.ID, parentID, name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return d
+	}
+
+	ids := func(list []*organizations.Department) []organizations.ID {
+		got := []organizations.ID{}
+		for _, d := range list {
+			got = append(got, d.ID)
+		}
+		return got
+	}
+
+	dev := create(organizations.Root, "開発部")
+	team1 := create(dev.ID, "開発一課")
+	team2 := create(dev.ID, "開発二課")
+	infra := create(team1.ID, "基盤チーム")
+	sales := create(organizations.Root, "営業部")
+
+	t.Run("create parent not found", func(t *testing.T) {
+		_, err := repo.DepartmentCreate(context.Background(), organizations.New(company.ID, sales.ID+100, "人事部"))
+		if err == nil {
+			t.Fatal("want error")
+		}
+	})
+
+	t.Run("children", func(t *testing.T) {
+		got, err := repo.DepartmentChildren(context.Background(), company.ID, dev.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		want := []organizations.ID{team1.ID, team2.ID}
+		if !reflect.DeepEqual(want, ids(got)) {
+			t.Fatalf("want=%v, got=%v.", want, ids(got))
+		}
+	})
+
+	t.Run("root children", func(t *testing.T) {
+		got, err := repo.DepartmentChildren(context.Background(), company.ID, organizations.Root)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		want := []organizations.ID{dev.ID, sales.ID}
+		if !reflect.DeepEqual(want, ids(got)) {
+			t.Fatalf("want=%v, got=%v.", want, ids(got))
+		}
+	})
+
+	t.Run("ancestors", func(t *testing.T) {
+		got, err := repo.DepartmentAncestors(context.Background(), company.ID, infra.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		want := []organizations.ID{dev.ID, team1.ID}
+		if !reflect.DeepEqual(want, ids(got)) {
+			t.Fatalf("want=%v, got=%v.", want, ids(got))
+		}
+	})
+
+	t.Run("subtree", func(t *testing.T) {
+		got, err := repo.DepartmentSubtree(context.Background(), company.ID, dev.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		want := []organizations.ID{dev.ID, team1.ID, team2.ID, infra.ID}
+		if !reflect.DeepEqual(want, ids(got)) {
+			t.Fatalf("want=%v, got=%v.", want, ids(got))
+		}
+	})
+
+	t.Run("move subtree", func(t *testing.T) {
+		team1.ParentID = sales.ID
+		_, err := repo.DepartmentMove(context.Background(), team1)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		got, err := repo.DepartmentAncestors(context.Background(), company.ID, infra.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		want := []organizations.ID{sales.ID, team1.ID}
+		if !reflect.DeepEqual(want, ids(got)) {
+			t.Fatalf("want=%v, got=%v.", want, ids(got))
+		}
+
+		got, err = repo.DepartmentSubtree(context.Background(), company.ID, dev.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		want = []organizations.ID{dev.ID, team2.ID}
+		if !reflect.DeepEqual(want, ids(got)) {
+			t.Fatalf("want=%v, got=%v.", want, ids(got))
+		}
+	})
+
+	t.Run("move to descendant", func(t *testing.T) {
+		_, err := repo.DepartmentMove(context.Background(), &organizations.Department{
+			ID:        sales.ID,
+			CompanyID: company.ID,
+			ParentID:  infra.ID,
+			Name:      sales.Name,
+		})
+		if !errors.Is(err, organizations.ErrCycle) {
+			t.Fatalf("want-error=%v, error=%v.", organizations.ErrCycle, err)
+		}
+
+		// 失敗した移動は反映されない
+		got, err := repo.DepartmentSubtree(context.Background(), company.ID, sales.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		want := []organizations.ID{sales.ID, team1.ID, infra.ID}
+		if !reflect.DeepEqual(want, ids(got)) {
+			t.Fatalf("want=%v, got=%v.", want, ids(got))
+		}
+	})
+
+	t.Run("rename", func(t *testing.T) {
+		team1.Name = "開発本部"
+		_, err := repo.DepartmentUpdate(context.Background(), team1)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		got, err := repo.DepartmentRead(context.Background(), company.ID, team1.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if got.Name != "開発本部" || got.ParentID != sales.ID {
+			t.Fatalf("got=%v.", got)
+		}
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		err := repo.DepartmentDelete(context.Background(), company.ID, infra.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		got, err := repo.DepartmentSubtree(context.Background(), company.ID, team1.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		want := []organizations.ID{team1.ID}
+		if !reflect.DeepEqual(want, ids(got)) {
+			t.Fatalf("want=%v, got=%v.", want, ids(got))
+		}
+	})
+
+	t.Run("other company", func(t *testing.T) {
+		_, err := repo.DepartmentSubtree(context.Background(), company.ID+1, dev.ID)
+		if err == nil {
+			t.Fatal("want error")
+		}
+	})
+}
+
+func TestRepository_Role(t *testing.T) {
+	tableLock.Lock()
+	defer tableLock.Unlock()
+
+	db := newDB()
+	defer db.Close()
+	defer db.Exec("delete from users")
+	defer db.Exec("delete from companies")
+	defer db.Exec("delete from company_employees")
+	defer db.Exec("delete from roles")
+	defer db.Exec("delete from company_roles")
+
+	repo := &repository{db: db}
+	companyA, err := repo.CompanyCreate(context.Background(), companies.New("COMPANY A", createUser(db, "Bob")))
+	if err != nil {
+		panic(err)
+	}
+	companyB, err := repo.CompanyCreate(context.Background(), companies.New("COMPANY B", createUser(db, "Alice")))
+	if err != nil {
+		panic(err)
+	}
+
+	manager, err := repo.RoleCreate(context.Background(), roles.New(companyA.ID, "部長"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	chief, err := repo.RoleCreate(context.Background(), roles.New(companyA.ID, "課長"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("duplicate name", func(t *testing.T) {
+		_, err := repo.RoleCreate(context.Background(), roles.New(companyA.ID, "部長"))
+		if !errors.Is(err, roles.ErrDuplicateName) {
+			t.Fatalf("want-error=%v, error=%v.", roles.ErrDuplicateName, err)
+		}
+	})
+
+	t.Run("same name in other company", func(t *testing.T) {
+		_, err := repo.RoleCreate(context.Background(), roles.New(companyB.ID, "部長"))
+		if err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	t.Run("rename to duplicate name", func(t *testing.T) {
+		chief.Name = "部長"
+		_, err := repo.RoleUpdate(context.Background(), chief)
+		if !errors.Is(err, roles.ErrDuplicateName) {
+			t.Fatalf("want-error=%v, error=%v.", roles.ErrDuplicateName, err)
+		}
+	})
+
+	t.Run("rename", func(t *testing.T) {
+		manager.Name = "本部長"
+		_, err := repo.RoleUpdate(context.Background(), manager)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		got, err := repo.RoleRead(context.Background(), companyA.ID, manager.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if got.Name != "本部長" {
+			t.Fatalf("want=%v, got=%v.", "本部長", got.Name)
+		}
+	})
+
+	t.Run("list", func(t *testing.T) {
+		got, err := repo.RoleList(context.Background(), companyA.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		names := []roles.Name{}
+		for _, r := range got {
+			names = append(names, r.Name)
+		}
+
+		want := []roles.Name{"本部長", "課長"}
+		if !reflect.DeepEqual(want, names) {
+			t.Fatalf("want=%v, got=%v.", want, names)
+		}
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		err := repo.RoleDelete(context.Background(), companyA.ID, chief.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		_, err = repo.RoleRead(context.Background(), companyA.ID, chief.ID)
+		if err == nil {
+			t.Fatal("want error")
+		}
+	})
+}
+
+func TestRepository_Assignment(t *testing.T) {
+	tableLock.Lock()
+	defer tableLock.Unlock()
+
+	db := newDB()
+	defer db.Close()
+	defer db.Exec("delete from users")
+	defer db.Exec("delete from roles")
+	defer db.Exec("delete from companies")
+
+	repo := &repository{db: db}
+	companyA, err := repo.CompanyCreate(context.Background(), companies.New("COMPANY A", createUser(db, "Bob")))
+	if err != nil {
+		panic(err)
+	}
+	companyB, err := repo.CompanyCreate(context.Background(), companies.New("COMPANY B", createUser(db, "Alice")))
+	if err != nil {
+		panic(err)
+	}
+
+	employeesA, err := repo.EmployeeList(context.Background(), companyA.ID)
+	if err != nil {
+		panic(err)
+	}
+	bob := employeesA[0]
+
+	sales, err := repo.DepartmentCreate(context.Background(), organizations.New(companyA.ID, organizations.Root, "営業部"))
+	if err != nil {
+		panic(err)
+	}
+	dev, err := repo.DepartmentCreate(context.Background(), organizations.New(companyA.ID, organizations.Root, "開発部"))
+	if err != nil {
+		panic(err)
+	}
+	otherDepartment, err := repo.DepartmentCreate(context.Background(), organizations.New(companyB.ID, organizations.Root, "営業部"))
+	if err != nil {
+		panic(err)
+	}
+	manager, err := repo.RoleCreate(context.Background(), roles.New(companyA.ID, "部長"))
+	if err != nil {
+		panic(err)
+	}
+
+	// 営業部長と開発部長を兼任する
+	salesManager, err := repo.AssignmentCreate(context.Background(), assignments.New(companyA.ID, bob.ID, sales.ID, manager.ID))
+	if err != nil {
+		t.Fatal(err)
+	}
+	devManager, err := repo.AssignmentCreate(context.Background(), assignments.New(companyA.ID, bob.ID, dev.ID, manager.ID))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("duplicate", func(t *testing.T) {
+		_, err := repo.AssignmentCreate(context.Background(), assignments.New(companyA.ID, bob.ID, sales.ID, manager.ID))
+		if !errors.Is(err, assignments.ErrDuplicate) {
+			t.Fatalf("want-error=%v, error=%v.", assignments.ErrDuplicate, err)
+		}
+	})
+
+	t.Run("department in other company", func(t *testing.T) {
+		_, err := repo.AssignmentCreate(context.Background(), assignments.New(companyA.ID, bob.ID, otherDepartment.ID, manager.ID))
+		if err == nil {
+			t.Fatal("want error")
+		}
+	})
+
+	t.Run("list by employee", func(t *testing.T) {
+		got, err := repo.AssignmentListByEmployee(context.Background(), companyA.ID, bob.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		ids := []assignments.ID{}
+		for _, a := range got {
+			ids = append(ids, a.ID)
+		}
+
+		want := []assignments.ID{salesManager.ID, devManager.ID}
+		if !reflect.DeepEqual(want, ids) {
+			t.Fatalf("want=%v, got=%v.", want, ids)
+		}
+	})
+
+	t.Run("list by department", func(t *testing.T) {
+		got, err := repo.AssignmentListByDepartment(context.Background(), companyA.ID, dev.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if len(got) != 1 || got[0].ID != devManager.ID {
+			t.Fatalf("want=%v, got=%v.", devManager, got)
+		}
+	})
+
+	t.Run("list by subtree", func(t *testing.T) {
+		team, err := repo.DepartmentCreate(context.Background(), organizations.New(companyA.ID, dev.ID, "開発一課"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		teamManager, err := repo.AssignmentCreate(context.Background(), assignments.New(companyA.ID, bob.ID, team.ID, manager.ID))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		got, err := repo.AssignmentListBySubtree(context.Background(), companyA.ID, dev.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		ids := []assignments.ID{}
+		for _, a := range got {
+			ids = append(ids, a.ID)
+		}
+
+		want := []assignments.ID{devManager.ID, teamManager.ID}
+		if !reflect.DeepEqual(want, ids) {
+			t.Fatalf("want=%v, got=%v.", want, ids)
+		}
+
+		got, err = repo.AssignmentListBySubtree(context.Background(), companyB.ID, dev.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if len(got) != 0 {
+			t.Fatalf("want=0, got=%v.", len(got))
+		}
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		err := repo.AssignmentDelete(context.Background(), companyB.ID, salesManager.ID)
+		if err == nil {
+			t.Fatal("want error")
+		}
+
+		err = repo.AssignmentDelete(context.Background(), companyA.ID, salesManager.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		_, err = repo.AssignmentRead(context.Background(), companyA.ID, salesManager.ID)
+		if err == nil {
+			t.Fatal("want error")
+		}
+	})
+
+	t.Run("delete role", func(t *testing.T) {
+		err := repo.RoleDelete(context.Background(), companyA.ID, manager.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		got, err := repo.AssignmentListByEmployee(context.Background(), companyA.ID, bob.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if len(got) != 0 {
+			t.Fatalf("want=0, got=%v.", len(got))
+		}
+	})
+}
+
+func TestRepository_Permission(t *testing.T) {
+	tableLock.Lock()
+	defer tableLock.Unlock()
+
+	db := newDB()
+	defer db.Close()
+	defer db.Exec("delete from users")
+	defer db.Exec("delete from companies")
+
+	repo := &repository{db: db}
+	company, err := repo.CompanyCreate(context.Background(), companies.New("COMPANY", createUser(db, "Bob")))
+	if err != nil {
+		panic(err)
+	}
+
+	list, err := repo.EmployeeList(context.Background(), company.ID)
+	if err != nil {
+		panic(err)
+	}
+	owner := list[0]
+
+	admin, err := repo.EmployeeCreate(context.Background(), employees.New(company.ID, createUser(db, "Alice"), true))
+	if err != nil {
+		panic(err)
+	}
+	member, err := repo.EmployeeCreate(context.Background(), employees.New(company.ID, createUser(db, "Carol"), false))
+	if err != nil {
+		panic(err)
+	}
+
+	t.Run("owner has all permissions", func(t *testing.T) {
+		got, err := repo.PermissionList(context.Background(), company.ID, owner.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !reflect.DeepEqual(permissions.All, got) {
+			t.Fatalf("want=%v, got=%v.", permissions.All, got)
+		}
+	})
+
+	t.Run("grant", func(t *testing.T) {
+		for i := 0; i < 2; i++ {
+			err := repo.PermissionGrant(context.Background(), company.ID, admin.ID, permissions.ManageRoles)
+			if err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		got, err := repo.PermissionList(context.Background(), company.ID, admin.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		want := []permissions.Permission{permissions.ManageRoles}
+		if !reflect.DeepEqual(want, got) {
+			t.Fatalf("want=%v, got=%v.", want, got)
+		}
+	})
+
+	t.Run("grant not administrator", func(t *testing.T) {
+		err := repo.PermissionGrant(context.Background(), company.ID, member.ID, permissions.ManageRoles)
+		if !errors.Is(err, permissions.ErrNotAdministrator) {
+			t.Fatalf("want-error=%v, error=%v.", permissions.ErrNotAdministrator, err)
+		}
+	})
+
+	t.Run("revoke", func(t *testing.T) {
+		for i := 0; i < 2; i++ {
+			err := repo.PermissionRevoke(context.Background(), company.ID, admin.ID, permissions.ManageRoles)
+			if err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		got, err := repo.PermissionList(context.Background(), company.ID, admin.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if len(got) != 0 {
+			t.Fatalf("want=[], got=%v.", got)
+		}
+	})
+}
+
+func TestRepository_Session(t *testing.T) {
+	tableLock.Lock()
+	defer tableLock.Unlock()
+
+	db := newDB()
+	defer db.Close()
+	defer db.Exec("delete from users")
+
+	repo := &repository{db: db}
+	alice := createUser(db, "Alice")
+	bob := createUser(db, "Bob")
+	now := time.Now().Truncate(time.Second).UTC()
+
+	create := func(id sessions.ID, userID users.ID, expiresAt time.Time) {
+		_, err := repo.SessionCreate(context.Background(), sessions.New(id, userID, expiresAt))
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	create("alice-1", alice, now.Add(time.Hour))
+	create("alice-2", alice, now.Add(time.Hour))
+	create("bob-1", bob, now.Add(time.Hour))
+	create("bob-2", bob, now.Add(-time.Hour))
+
+	t.Run("user not found", func(t *testing.T) {
+		_, err := repo.SessionCreate(context.Background(), sessions.New("other", bob+100, now))
+		if err == nil {
+			t.Fatal("want error")
+		}
+	})
+
+	t.Run("read", func(t *testing.T) {
+		got, err := repo.SessionRead(context.Background(), "alice-1")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		want := sessions.New("alice-1", alice, now.Add(time.Hour))
+		if !reflect.DeepEqual(want, got) {
+			t.Fatalf("want=%v, got=%v.", want, got)
+		}
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		err := repo.SessionDelete(context.Background(), "alice-1")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		_, err = repo.SessionRead(context.Background(), "alice-1")
+		if err == nil {
+			t.Fatal("want error")
+		}
+	})
+
+	t.Run("delete expired", func(t *testing.T) {
+		err := repo.SessionDeleteExpired(context.Background(), now)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		_, err = repo.SessionRead(context.Background(), "bob-2")
+		if err == nil {
+			t.Fatal("want error")
+		}
+
+		_, err = repo.SessionRead(context.Background(), "bob-1")
+		if err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	t.Run("delete by user", func(t *testing.T) {
+		err := repo.SessionDeleteByUserID(context.Background(), alice)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		_, err = repo.SessionRead(context.Background(), "alice-2")
+		if err == nil {
+			t.Fatal("want error")
+		}
+
+		_, err = repo.SessionRead(context.Background(), "bob-1")
+		if err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	t.Run("deleted with user", func(t *testing.T) {
+		err := repo.UserDelete(context.Background(), bob)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		_, err = repo.SessionRead(context.Background(), "bob-1")
+		if err == nil {
+			t.Fatal("want error")
+		}
+	})
+}
+
+func TestRepository_APIKey(t *testing.T) {
+	tableLock.Lock()
+	defer tableLock.Unlock()
+
+	db := newDB()
+	defer db.Close()
+	defer db.Exec("delete from users")
+	defer db.Exec("delete from companies")
+
+	repo := &repository{db: db}
+	bob := createUser(db, "Bob")
+	companyA, err := repo.CompanyCreate(context.Background(), companies.New("COMPANY A", bob))
+	if err != nil {
+		panic(err)
+	}
+	companyB, err := repo.CompanyCreate(context.Background(), companies.New("COMPANY B", bob))
+	if err != nil {
+		panic(err)
+	}
+
+	secret := func(plain string) users.Password {
+		hash, err := password.New(plain)
+		if err != nil {
+			panic(err)
+		}
+		return hash
+	}
+
+	// 比較のため UpdatedAt と Secret を除く
+	strip := func(k *apikeys.Key) *apikeys.Key {
+		c := *k
+		c.UpdatedAt = time.Time{}
+		c.Secret = nil
+		return &c
+	}
+
+	created, err := repo.APIKeyCreate(context.Background(), &apikeys.Key{
+		CompanyID: apikeys.CompanyID(companyA.ID),
+		Name:      "batch",
+		Scopes:    []apikeys.Scope{apikeys.ScopeRead, apikeys.ScopeManageUsers},
+		Secret:    secret("secret"),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("company not found", func(t *testing.T) {
+		_, err := repo.APIKeyCreate(context.Background(), &apikeys.Key{
+			CompanyID: apikeys.CompanyID(companyB.ID + 100),
+			Name:      "batch",
+			Scopes:    []apikeys.Scope{apikeys.ScopeRead},
+			Secret:    secret("secret"),
+		})
+		if err == nil {
+			t.Fatal("want error")
+		}
+	})
+
+	t.Run("read", func(t *testing.T) {
+		got, err := repo.APIKeyRead(context.Background(), created.CompanyID, created.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !reflect.DeepEqual(strip(created), strip(got)) {
+			t.Fatalf("want=%v, got=%v.", strip(created), strip(got))
+		}
+
+		_, err = repo.APIKeyRead(context.Background(), apikeys.CompanyID(companyB.ID), created.ID)
+		if err == nil {
+			t.Fatal("want error")
+		}
+	})
+
+	t.Run("read by id", func(t *testing.T) {
+		got, err := repo.APIKeyReadByID(context.Background(), created.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !got.Secret.Verify("secret") {
+			t.Fatal("secret is not stored")
+		}
+	})
+
+	t.Run("list", func(t *testing.T) {
+		got, err := repo.APIKeyList(context.Background(), created.CompanyID)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if len(got) != 1 || !reflect.DeepEqual(strip(created), strip(got[0])) {
+			t.Fatalf("want=%v, got=%v.", strip(created), got)
+		}
+
+		got, err = repo.APIKeyList(context.Background(), apikeys.CompanyID(companyB.ID))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if len(got) != 0 {
+			t.Fatalf("want empty, got=%v.", got)
+		}
+	})
+
+	t.Run("update", func(t *testing.T) {
+		got, err := repo.APIKeyUpdate(context.Background(), &apikeys.Key{
+			ID:        created.ID,
+			CompanyID: created.CompanyID,
+			Secret:    secret("rotated"),
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !reflect.DeepEqual(strip(created), strip(got)) {
+			t.Fatalf("want=%v, got=%v.", strip(created), strip(got))
+		}
+
+		if !got.Secret.Verify("rotated") {
+			t.Fatal("secret is not updated")
+		}
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		err := repo.APIKeyDelete(context.Background(), apikeys.CompanyID(companyB.ID), created.ID)
+		if err == nil {
+			t.Fatal("want error")
+		}
+
+		err = repo.APIKeyDelete(context.Background(), created.CompanyID, created.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		_, err = repo.APIKeyReadByID(context.Background(), created.ID)
+		if err == nil {
+			t.Fatal("want error")
+		}
+	})
+}
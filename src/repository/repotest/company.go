@@ -0,0 +1,370 @@
+package repotest
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	companies "api.example.com/pkg/company"
+	employees "api.example.com/pkg/employee"
+	"api.example.com/pkg/failure"
+	"api.example.com/repository"
+)
+
+// 検証に使う会社
+// 所有者より先に、検証の終了時に削除する
+func createCompany(t *testing.T, repo repository.Repository) *companies.Company {
+	t.Helper()
+
+	return createNamedCompany(t, repo, companies.Name(uniqueName("company")))
+}
+
+func createNamedCompany(t *testing.T, repo repository.Repository, name companies.Name) *companies.Company {
+	t.Helper()
+
+	owner := createUser(t, repo)
+	c, err := repo.CompanyCreate(context.Background(), companies.New(name, owner.ID))
+	if err != nil {
+		t.Fatalf("fixture: %v", err)
+	}
+
+	t.Cleanup(func() {
+		repo.CompanyDelete(context.Background(), c.ID)
+	})
+	return c
+}
+
+// 削除済みの会社の ID
+func deletedCompanyID(t *testing.T, repo repository.Repository) companies.ID {
+	t.Helper()
+
+	c := createCompany(t, repo)
+	err := repo.CompanyDelete(context.Background(), c.ID)
+	if err != nil {
+		t.Fatalf("fixture: %v", err)
+	}
+	return c.ID
+}
+
+func testCompany(t *testing.T, want, got *companies.Company) {
+	t.Helper()
+
+	if got == nil {
+		t.Fatalf("want=%v, got=nil.", want)
+	}
+
+	if want.ID != got.ID || want.Name != got.Name || want.OwnerID != got.OwnerID {
+		t.Fatalf("want=%v, got=%v.", want, got)
+	}
+
+	testSameTime(t, want.UpdatedAt, got.UpdatedAt)
+}
+
+// ID の順に並ぶこと
+func testCompanies(t *testing.T, want, got []*companies.Company) {
+	t.Helper()
+
+	if len(want) != len(got) {
+		t.Fatalf("want=%v, got=%v.", want, got)
+	}
+
+	for i := range want {
+		testCompany(t, want[i], got[i])
+	}
+}
+
+// 会社の作成、読み込み、一覧、更新、削除を検証する
+func RunCompany(t *testing.T, newRepository Factory) {
+	ctx := context.Background()
+
+	t.Run("create", func(t *testing.T) {
+		repo := newRepository(t)
+		owner := createUser(t, repo)
+		name := companies.Name(uniqueName("company"))
+
+		before := time.Now()
+		got, err := repo.CompanyCreate(ctx, companies.New(name, owner.ID))
+		after := time.Now()
+		if err != nil {
+			t.Fatalf("want=nil, got=%v.", err)
+		}
+		t.Cleanup(func() {
+			repo.CompanyDelete(ctx, got.ID)
+		})
+
+		if got.ID == 0 || got.Name != name || got.OwnerID != owner.ID {
+			t.Fatalf("want=%v, got=%v.", name, got)
+		}
+
+		testTimeBetween(t, before, after, got.UpdatedAt)
+
+		read, err := repo.CompanyRead(ctx, got.ID)
+		if err != nil {
+			t.Fatalf("want=nil, got=%v.", err)
+		}
+		testCompany(t, got, read)
+
+		// 所有者は管理者として従業員になる
+		admin, err := repo.EmployeeReadByUserID(ctx, got.ID, owner.ID)
+		if err != nil {
+			t.Fatalf("want=nil, got=%v.", err)
+		}
+
+		if !admin.Administrator {
+			t.Fatalf("want=administrator, got=%v.", admin)
+		}
+	})
+
+	t.Run("create duplicate name", func(t *testing.T) {
+		repo := newRepository(t)
+		c := createCompany(t, repo)
+
+		_, err := repo.CompanyCreate(ctx, companies.New(c.Name, c.OwnerID))
+		testConflict(t, "company.name", failure.RuleUnique, err)
+	})
+
+	// MySQL の照合順序と同じく、名前は大文字と小文字を区別しない
+	t.Run("create duplicate name ignoring case", func(t *testing.T) {
+		repo := newRepository(t)
+		c := createCompany(t, repo)
+
+		_, err := repo.CompanyCreate(ctx, companies.New(companies.Name(strings.ToUpper(string(c.Name))), c.OwnerID))
+		testConflict(t, "company.name", failure.RuleUnique, err)
+	})
+
+	t.Run("create owner not found", func(t *testing.T) {
+		repo := newRepository(t)
+		owner := deletedUserID(t, repo)
+
+		_, err := repo.CompanyCreate(ctx, companies.New(companies.Name(uniqueName("company")), owner))
+		testError(t, failure.ErrNotFound, err)
+	})
+
+	t.Run("read not found", func(t *testing.T) {
+		repo := newRepository(t)
+		id := deletedCompanyID(t, repo)
+
+		_, err := repo.CompanyRead(ctx, id)
+		testError(t, failure.ErrNotFound, err)
+	})
+
+	t.Run("list", func(t *testing.T) {
+		repo := newRepository(t)
+		// 会社より後に削除するため、先に作成する
+		member := createUser(t, repo)
+		// 他の検証の会社を除くため、一意な名前で絞り込む
+		prefix := uniqueName("list")
+		first := createNamedCompany(t, repo, companies.Name(prefix+"-1"))
+		second := createNamedCompany(t, repo, companies.Name(prefix+"-2"))
+		third := createNamedCompany(t, repo, companies.Name(prefix+"-3"))
+		// 従業員でない会社は含めない
+		other := createNamedCompany(t, repo, companies.Name(prefix+"-4"))
+		createCompany(t, repo)
+
+		for _, c := range []*companies.Company{first, second, third} {
+			_, err := repo.EmployeeCreate(ctx, employees.New(c.ID, member.ID, false))
+			if err != nil {
+				t.Fatalf("fixture: %v", err)
+			}
+		}
+
+		got, err := repo.CompanyList(ctx, member.ID, companies.Name(prefix), 0, 2)
+		if err != nil {
+			t.Fatalf("want=nil, got=%v.", err)
+		}
+		testCompanies(t, []*companies.Company{first, second}, got)
+
+		// 前のページの最後より後から読み込む
+		got, err = repo.CompanyList(ctx, member.ID, companies.Name(prefix), second.ID, 2)
+		if err != nil {
+			t.Fatalf("want=nil, got=%v.", err)
+		}
+		testCompanies(t, []*companies.Company{third}, got)
+
+		got, err = repo.CompanyList(ctx, member.ID, companies.Name(prefix), third.ID, 2)
+		if err != nil {
+			t.Fatalf("want=nil, got=%v.", err)
+		}
+		testCompanies(t, []*companies.Company{}, got)
+
+		// 所有者は管理者として従業員になっている
+		got, err = repo.CompanyList(ctx, other.OwnerID, companies.Name(prefix), 0, 10)
+		if err != nil {
+			t.Fatalf("want=nil, got=%v.", err)
+		}
+		testCompanies(t, []*companies.Company{other}, got)
+	})
+
+	t.Run("list prefix is not a pattern", func(t *testing.T) {
+		repo := newRepository(t)
+		member := createUser(t, repo)
+		prefix := uniqueName("list")
+		plain := createNamedCompany(t, repo, companies.Name(prefix+"-a"))
+		wildcard := createNamedCompany(t, repo, companies.Name(prefix+"%_"))
+
+		for _, c := range []*companies.Company{plain, wildcard} {
+			_, err := repo.EmployeeCreate(ctx, employees.New(c.ID, member.ID, false))
+			if err != nil {
+				t.Fatalf("fixture: %v", err)
+			}
+		}
+
+		got, err := repo.CompanyList(ctx, member.ID, companies.Name(prefix+"%_"), 0, 10)
+		if err != nil {
+			t.Fatalf("want=nil, got=%v.", err)
+		}
+		testCompanies(t, []*companies.Company{wildcard}, got)
+
+		got, err = repo.CompanyList(ctx, member.ID, companies.Name(prefix+"_"), 0, 10)
+		if err != nil {
+			t.Fatalf("want=nil, got=%v.", err)
+		}
+		testCompanies(t, []*companies.Company{}, got)
+	})
+
+	// SQL の like と同じく大文字と小文字を区別しない
+	t.Run("list prefix ignores case", func(t *testing.T) {
+		repo := newRepository(t)
+		prefix := uniqueName("list")
+		mixed := createNamedCompany(t, repo, companies.Name(prefix+"-MiXed"))
+
+		got, err := repo.CompanyList(ctx, mixed.OwnerID, companies.Name(strings.ToUpper(prefix)+"-mIxED"), 0, 10)
+		if err != nil {
+			t.Fatalf("want=nil, got=%v.", err)
+		}
+		testCompanies(t, []*companies.Company{mixed}, got)
+	})
+
+	t.Run("update", func(t *testing.T) {
+		repo := newRepository(t)
+		c := createCompany(t, repo)
+		owner := createUser(t, repo)
+
+		// 新しい所有者は会社の管理者であること
+		_, err := repo.EmployeeCreate(ctx, employees.New(c.ID, owner.ID, true))
+		if err != nil {
+			t.Fatalf("fixture: %v", err)
+		}
+
+		want := &companies.Company{
+			ID:      c.ID,
+			Name:    companies.Name(uniqueName("renamed")),
+			OwnerID: owner.ID,
+		}
+
+		before := time.Now()
+		got, err := repo.CompanyUpdate(ctx, want)
+		after := time.Now()
+		if err != nil {
+			t.Fatalf("want=nil, got=%v.", err)
+		}
+
+		want.UpdatedAt = got.UpdatedAt
+		testCompany(t, want, got)
+		testTimeBetween(t, before, after, got.UpdatedAt)
+
+		if got.UpdatedAt.Before(c.UpdatedAt) {
+			t.Fatalf("updated_at want>=%v, got=%v.", c.UpdatedAt, got.UpdatedAt)
+		}
+
+		read, err := repo.CompanyRead(ctx, c.ID)
+		if err != nil {
+			t.Fatalf("want=nil, got=%v.", err)
+		}
+		testCompany(t, got, read)
+	})
+
+	t.Run("update duplicate name", func(t *testing.T) {
+		repo := newRepository(t)
+		c := createCompany(t, repo)
+		other := createCompany(t, repo)
+
+		_, err := repo.CompanyUpdate(ctx, &companies.Company{ID: c.ID, Name: other.Name, OwnerID: c.OwnerID})
+		testConflict(t, "company.name", failure.RuleUnique, err)
+
+		read, err := repo.CompanyRead(ctx, c.ID)
+		if err != nil {
+			t.Fatalf("want=nil, got=%v.", err)
+		}
+		testCompany(t, c, read)
+	})
+
+	t.Run("update owner not administrator", func(t *testing.T) {
+		repo := newRepository(t)
+		c := createCompany(t, repo)
+		owner := createUser(t, repo)
+
+		_, err := repo.EmployeeCreate(ctx, employees.New(c.ID, owner.ID, false))
+		if err != nil {
+			t.Fatalf("fixture: %v", err)
+		}
+
+		_, err = repo.CompanyUpdate(ctx, &companies.Company{ID: c.ID, Name: c.Name, OwnerID: owner.ID})
+		testConflict(t, "company.owner_id", failure.RuleAdministrator, err)
+
+		read, err := repo.CompanyRead(ctx, c.ID)
+		if err != nil {
+			t.Fatalf("want=nil, got=%v.", err)
+		}
+		testCompany(t, c, read)
+	})
+
+	t.Run("update owner not employee", func(t *testing.T) {
+		repo := newRepository(t)
+		c := createCompany(t, repo)
+		owner := createUser(t, repo)
+
+		_, err := repo.CompanyUpdate(ctx, &companies.Company{ID: c.ID, Name: c.Name, OwnerID: owner.ID})
+		testConflict(t, "company.owner_id", failure.RuleAdministrator, err)
+	})
+
+	t.Run("update owner not found", func(t *testing.T) {
+		repo := newRepository(t)
+		c := createCompany(t, repo)
+		owner := deletedUserID(t, repo)
+
+		_, err := repo.CompanyUpdate(ctx, &companies.Company{ID: c.ID, Name: c.Name, OwnerID: owner})
+		testConflict(t, "company.owner_id", failure.RuleAdministrator, err)
+	})
+
+	t.Run("update not found", func(t *testing.T) {
+		repo := newRepository(t)
+		id := deletedCompanyID(t, repo)
+		owner := createUser(t, repo)
+
+		_, err := repo.CompanyUpdate(ctx, &companies.Company{ID: id, Name: companies.Name(uniqueName("company")), OwnerID: owner.ID})
+		testError(t, failure.ErrNotFound, err)
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		repo := newRepository(t)
+		c := createCompany(t, repo)
+
+		// 所有者は会社がある間は削除できない
+		err := repo.UserDelete(ctx, c.OwnerID)
+		testConflict(t, "user_id", failure.RuleUnreferenced, err)
+
+		err = repo.CompanyDelete(ctx, c.ID)
+		if err != nil {
+			t.Fatalf("want=nil, got=%v.", err)
+		}
+
+		_, err = repo.CompanyRead(ctx, c.ID)
+		testError(t, failure.ErrNotFound, err)
+
+		// 従業員も削除され、所有者を削除できる
+		err = repo.UserDelete(ctx, c.OwnerID)
+		if err != nil {
+			t.Fatalf("want=nil, got=%v.", err)
+		}
+	})
+
+	t.Run("delete not found", func(t *testing.T) {
+		repo := newRepository(t)
+		id := deletedCompanyID(t, repo)
+
+		err := repo.CompanyDelete(ctx, id)
+		testError(t, failure.ErrNotFound, err)
+	})
+}
@@ -0,0 +1,89 @@
+package repotest
+
+import (
+	"context"
+	"testing"
+
+	companies "api.example.com/pkg/company"
+	employees "api.example.com/pkg/employee"
+	"api.example.com/pkg/failure"
+)
+
+// 従業員の削除を検証する
+func RunEmployee(t *testing.T, newRepository Factory) {
+	ctx := context.Background()
+
+	t.Run("delete", func(t *testing.T) {
+		repo := newRepository(t)
+		c := createCompany(t, repo)
+		member, err := repo.EmployeeCreate(ctx, employees.New(c.ID, createUser(t, repo).ID, false))
+		if err != nil {
+			t.Fatalf("fixture: %v", err)
+		}
+
+		err = repo.EmployeeDelete(ctx, c.ID, member.ID)
+		if err != nil {
+			t.Fatalf("want=nil, got=%v.", err)
+		}
+
+		_, err = repo.EmployeeRead(ctx, c.ID, member.ID)
+		testError(t, failure.ErrNotFound, err)
+	})
+
+	t.Run("delete owner", func(t *testing.T) {
+		repo := newRepository(t)
+		c := createCompany(t, repo)
+		owner, err := repo.EmployeeReadByUserID(ctx, c.ID, c.OwnerID)
+		if err != nil {
+			t.Fatalf("fixture: %v", err)
+		}
+
+		err = repo.EmployeeDelete(ctx, c.ID, owner.ID)
+		testConflict(t, "employee_id", failure.RuleUnreferenced, err)
+
+		_, err = repo.EmployeeRead(ctx, c.ID, owner.ID)
+		if err != nil {
+			t.Fatalf("want=nil, got=%v.", err)
+		}
+	})
+
+	t.Run("delete former owner", func(t *testing.T) {
+		repo := newRepository(t)
+		c := createCompany(t, repo)
+		former, err := repo.EmployeeReadByUserID(ctx, c.ID, c.OwnerID)
+		if err != nil {
+			t.Fatalf("fixture: %v", err)
+		}
+
+		// 他の管理者へ所有者を移せば、元の所有者は削除できる
+		owner := createUser(t, repo)
+		_, err = repo.EmployeeCreate(ctx, employees.New(c.ID, owner.ID, true))
+		if err != nil {
+			t.Fatalf("fixture: %v", err)
+		}
+
+		_, err = repo.CompanyUpdate(ctx, &companies.Company{ID: c.ID, Name: c.Name, OwnerID: owner.ID})
+		if err != nil {
+			t.Fatalf("fixture: %v", err)
+		}
+
+		err = repo.EmployeeDelete(ctx, c.ID, former.ID)
+		if err != nil {
+			t.Fatalf("want=nil, got=%v.", err)
+		}
+	})
+
+	t.Run("delete not found", func(t *testing.T) {
+		repo := newRepository(t)
+		c := createCompany(t, repo)
+		other := createCompany(t, repo)
+		owner, err := repo.EmployeeReadByUserID(ctx, other.ID, other.OwnerID)
+		if err != nil {
+			t.Fatalf("fixture: %v", err)
+		}
+
+		// 他の会社の従業員は存在しないものとする
+		err = repo.EmployeeDelete(ctx, c.ID, owner.ID)
+		testError(t, failure.ErrNotFound, err)
+	})
+}
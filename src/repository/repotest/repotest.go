@@ -0,0 +1,88 @@
+// repository.Repository の実装が共通して満たすべき振る舞いの検証
+// データベースを使う実装とそれ以外の実装 (スタブを含む) に、同じ検証を行う
+//
+//	func TestRepository(t *testing.T) {
+//		repotest.Run(t, func(t *testing.T) repository.Repository {
+//			return memory.New()
+//		})
+//	}
+package repotest
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"api.example.com/pkg/failure"
+	"api.example.com/repository"
+)
+
+// 検証ごとに Repository を返す
+// 同じデータベースを共有してもよいが、検証の間に他から書き込まないこと
+type Factory func(t *testing.T) repository.Repository
+
+// 全ての振る舞いを検証する
+func Run(t *testing.T, newRepository Factory) {
+	t.Run("User", func(t *testing.T) {
+		RunUser(t, newRepository)
+	})
+	t.Run("Company", func(t *testing.T) {
+		RunCompany(t, newRepository)
+	})
+	t.Run("Employee", func(t *testing.T) {
+		RunEmployee(t, newRepository)
+	})
+}
+
+// 名前の重複を避けるための連番
+var sequence int64
+
+// データベースを共有しても重複しない名前
+func uniqueName(prefix string) string {
+	return fmt.Sprintf("%s-%d-%d", prefix, time.Now().UnixNano(), atomic.AddInt64(&sequence, 1))
+}
+
+// 実装は時刻を秒に丸めてよい
+const timePrecision = time.Second
+
+// got が before から after の間の時刻であること
+func testTimeBetween(t *testing.T, before, after, got time.Time) {
+	t.Helper()
+
+	if got.Before(before.Add(-timePrecision)) || got.After(after.Add(timePrecision)) {
+		t.Fatalf("updated_at want=[%v, %v], got=%v.", before, after, got)
+	}
+}
+
+// 保存した時刻を同じ時刻として読み込むこと (タイムゾーンは問わない)
+func testSameTime(t *testing.T, want, got time.Time) {
+	t.Helper()
+
+	if !want.Equal(got) {
+		t.Fatalf("updated_at want=%v, got=%v.", want, got)
+	}
+}
+
+// err が kind であること
+func testError(t *testing.T, kind, err error) {
+	t.Helper()
+
+	if !errors.Is(err, kind) {
+		t.Fatalf("want=%v, got=%v.", kind, err)
+	}
+}
+
+// err が field の rule の違反による failure.ErrConflict であること
+func testConflict(t *testing.T, field string, rule failure.Rule, err error) {
+	t.Helper()
+
+	testError(t, failure.ErrConflict, err)
+
+	want := []failure.Violation{{Field: field, Rule: rule}}
+	if got := failure.Violations(err); !reflect.DeepEqual(want, got) {
+		t.Fatalf("want=%v, got=%v.", want, got)
+	}
+}
@@ -0,0 +1,65 @@
+package repotest
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"testing"
+
+	"api.example.com/env"
+	"api.example.com/repository"
+	"api.example.com/repository/memory"
+	_ "github.com/go-sql-driver/mysql"
+	_ "github.com/mattn/go-sqlite3"
+)
+
+func TestRun_MySQL(t *testing.T) {
+	addr := env.Get("TEST_DB_ADDR")
+	name := env.Get("TEST_DB_NAME")
+	user := env.Get("TEST_DB_USER")
+	password := env.GetSecure("TEST_DB_PASSWORD")
+
+	dsn := fmt.Sprintf(
+		"%s:%s@(%s)/%s?charset=utf8mb4&parseTime=true",
+		user.Value(),
+		password.Value(),
+		addr.Value(),
+		name.Value(),
+	)
+
+	Run(t, func(t *testing.T) repository.Repository {
+		db, err := sql.Open("mysql", dsn)
+		if err != nil {
+			t.Fatalf("open: %v", err)
+		}
+
+		repo := repository.New(db)
+		t.Cleanup(func() { repo.Close() })
+		return repo
+	})
+}
+
+func TestRun_SQLite(t *testing.T) {
+	Run(t, func(t *testing.T) repository.Repository {
+		// 接続ごとに別のデータベースとなるため、接続は1つに限る
+		db, err := sql.Open("sqlite3", "file::memory:?_foreign_keys=1")
+		if err != nil {
+			t.Fatalf("open: %v", err)
+		}
+		db.SetMaxOpenConns(1)
+
+		repo, err := repository.NewSQLite(context.Background(), db)
+		if err != nil {
+			db.Close()
+			t.Fatalf("schema: %v", err)
+		}
+		t.Cleanup(func() { repo.Close() })
+		return repo
+	})
+}
+
+func TestRun_Memory(t *testing.T) {
+	Run(t, func(t *testing.T) repository.Repository {
+		return memory.New()
+	})
+}
@@ -0,0 +1,310 @@
+package repotest
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"api.example.com/pkg/failure"
+	users "api.example.com/pkg/user"
+	"api.example.com/pkg/user/password"
+	"api.example.com/repository"
+)
+
+// 検証に使うユーザー
+// 検証の終了時に削除する
+func createUser(t *testing.T, repo repository.Repository) *users.User {
+	t.Helper()
+
+	return createNamedUser(t, repo, users.Name(uniqueName("user")))
+}
+
+func createNamedUser(t *testing.T, repo repository.Repository, name users.Name) *users.User {
+	t.Helper()
+
+	u, err := repo.UserCreate(context.Background(), users.New(name, password.FromHash([]byte("password"))))
+	if err != nil {
+		t.Fatalf("fixture: %v", err)
+	}
+
+	t.Cleanup(func() {
+		repo.UserDelete(context.Background(), u.ID)
+	})
+	return u
+}
+
+// 削除済みのユーザーの ID
+func deletedUserID(t *testing.T, repo repository.Repository) users.ID {
+	t.Helper()
+
+	u := createUser(t, repo)
+	err := repo.UserDelete(context.Background(), u.ID)
+	if err != nil {
+		t.Fatalf("fixture: %v", err)
+	}
+	return u.ID
+}
+
+// パスワードはハッシュを比較する
+func testUser(t *testing.T, want, got *users.User) {
+	t.Helper()
+
+	if got == nil {
+		t.Fatalf("want=%v, got=nil.", want)
+	}
+
+	if want.ID != got.ID || want.Name != got.Name || !bytes.Equal(want.Password.Hash(), got.Password.Hash()) {
+		t.Fatalf("want=%v, got=%v.", want, got)
+	}
+
+	testSameTime(t, want.UpdatedAt, got.UpdatedAt)
+}
+
+// ID の順に並ぶこと
+func testUsers(t *testing.T, want, got []*users.User) {
+	t.Helper()
+
+	if len(want) != len(got) {
+		t.Fatalf("want=%v, got=%v.", want, got)
+	}
+
+	for i := range want {
+		testUser(t, want[i], got[i])
+	}
+}
+
+// ユーザーの作成、読み込み、一覧、更新、削除を検証する
+func RunUser(t *testing.T, newRepository Factory) {
+	ctx := context.Background()
+
+	t.Run("create", func(t *testing.T) {
+		repo := newRepository(t)
+		name := users.Name(uniqueName("user"))
+
+		before := time.Now()
+		got, err := repo.UserCreate(ctx, users.New(name, password.FromHash([]byte("password"))))
+		after := time.Now()
+		if err != nil {
+			t.Fatalf("want=nil, got=%v.", err)
+		}
+		t.Cleanup(func() {
+			repo.UserDelete(ctx, got.ID)
+		})
+
+		if got.ID == 0 || got.Name != name || !bytes.Equal([]byte("password"), got.Password.Hash()) {
+			t.Fatalf("want=%v, got=%v.", name, got)
+		}
+
+		testTimeBetween(t, before, after, got.UpdatedAt)
+
+		// 作成した内容を読み込める
+		read, err := repo.UserRead(ctx, got.ID)
+		if err != nil {
+			t.Fatalf("want=nil, got=%v.", err)
+		}
+		testUser(t, got, read)
+
+		read, err = repo.UserReadByName(ctx, name)
+		if err != nil {
+			t.Fatalf("want=nil, got=%v.", err)
+		}
+		testUser(t, got, read)
+	})
+
+	t.Run("create duplicate name", func(t *testing.T) {
+		repo := newRepository(t)
+		u := createUser(t, repo)
+
+		_, err := repo.UserCreate(ctx, users.New(u.Name, password.FromHash([]byte("password"))))
+		testConflict(t, "user.name", failure.RuleUnique, err)
+	})
+
+	// MySQL の照合順序と同じく、名前は大文字と小文字を区別しない
+	t.Run("create duplicate name ignoring case", func(t *testing.T) {
+		repo := newRepository(t)
+		u := createUser(t, repo)
+
+		_, err := repo.UserCreate(ctx, users.New(users.Name(strings.ToUpper(string(u.Name))), password.FromHash([]byte("password"))))
+		testConflict(t, "user.name", failure.RuleUnique, err)
+	})
+
+	t.Run("read by name ignoring case", func(t *testing.T) {
+		repo := newRepository(t)
+		u := createUser(t, repo)
+
+		got, err := repo.UserReadByName(ctx, users.Name(strings.ToUpper(string(u.Name))))
+		if err != nil {
+			t.Fatalf("want=nil, got=%v.", err)
+		}
+		testUser(t, u, got)
+	})
+
+	t.Run("read not found", func(t *testing.T) {
+		repo := newRepository(t)
+		id := deletedUserID(t, repo)
+
+		_, err := repo.UserRead(ctx, id)
+		testError(t, failure.ErrNotFound, err)
+
+		_, err = repo.UserReadByName(ctx, users.Name(uniqueName("unknown")))
+		testError(t, failure.ErrNotFound, err)
+	})
+
+	t.Run("list", func(t *testing.T) {
+		repo := newRepository(t)
+		// 他の検証のユーザーを除くため、一意な名前で絞り込む
+		prefix := uniqueName("list")
+		first := createNamedUser(t, repo, users.Name(prefix+"-1"))
+		second := createNamedUser(t, repo, users.Name(prefix+"-2"))
+		third := createNamedUser(t, repo, users.Name(prefix+"-3"))
+		createUser(t, repo)
+
+		got, err := repo.UserList(ctx, users.Name(prefix), 0, 2)
+		if err != nil {
+			t.Fatalf("want=nil, got=%v.", err)
+		}
+		testUsers(t, []*users.User{first, second}, got)
+
+		// 前のページの最後より後から読み込む
+		got, err = repo.UserList(ctx, users.Name(prefix), second.ID, 2)
+		if err != nil {
+			t.Fatalf("want=nil, got=%v.", err)
+		}
+		testUsers(t, []*users.User{third}, got)
+
+		got, err = repo.UserList(ctx, users.Name(prefix), third.ID, 2)
+		if err != nil {
+			t.Fatalf("want=nil, got=%v.", err)
+		}
+		testUsers(t, []*users.User{}, got)
+	})
+
+	t.Run("list prefix is not a pattern", func(t *testing.T) {
+		repo := newRepository(t)
+		prefix := uniqueName("list")
+		createNamedUser(t, repo, users.Name(prefix+"-a"))
+		wildcard := createNamedUser(t, repo, users.Name(prefix+"%_"))
+
+		got, err := repo.UserList(ctx, users.Name(prefix+"%_"), 0, 10)
+		if err != nil {
+			t.Fatalf("want=nil, got=%v.", err)
+		}
+		testUsers(t, []*users.User{wildcard}, got)
+
+		got, err = repo.UserList(ctx, users.Name(prefix+"_"), 0, 10)
+		if err != nil {
+			t.Fatalf("want=nil, got=%v.", err)
+		}
+		testUsers(t, []*users.User{}, got)
+	})
+
+	// SQL の like と同じく大文字と小文字を区別しない
+	t.Run("list prefix ignores case", func(t *testing.T) {
+		repo := newRepository(t)
+		prefix := uniqueName("list")
+		mixed := createNamedUser(t, repo, users.Name(prefix+"-MiXed"))
+
+		got, err := repo.UserList(ctx, users.Name(strings.ToUpper(prefix)+"-mIxED"), 0, 10)
+		if err != nil {
+			t.Fatalf("want=nil, got=%v.", err)
+		}
+		testUsers(t, []*users.User{mixed}, got)
+	})
+
+	t.Run("update", func(t *testing.T) {
+		repo := newRepository(t)
+		u := createUser(t, repo)
+
+		want := &users.User{
+			ID:       u.ID,
+			Name:     users.Name(uniqueName("renamed")),
+			Password: password.FromHash([]byte("changed")),
+		}
+
+		before := time.Now()
+		got, err := repo.UserUpdate(ctx, want)
+		after := time.Now()
+		if err != nil {
+			t.Fatalf("want=nil, got=%v.", err)
+		}
+
+		want.UpdatedAt = got.UpdatedAt
+		testUser(t, want, got)
+		testTimeBetween(t, before, after, got.UpdatedAt)
+
+		// 更新日時は作成日時より前にならない
+		if got.UpdatedAt.Before(u.UpdatedAt) {
+			t.Fatalf("updated_at want>=%v, got=%v.", u.UpdatedAt, got.UpdatedAt)
+		}
+
+		read, err := repo.UserRead(ctx, u.ID)
+		if err != nil {
+			t.Fatalf("want=nil, got=%v.", err)
+		}
+		testUser(t, got, read)
+
+		// 以前の名前では読み込めない
+		_, err = repo.UserReadByName(ctx, u.Name)
+		testError(t, failure.ErrNotFound, err)
+	})
+
+	t.Run("update duplicate name", func(t *testing.T) {
+		repo := newRepository(t)
+		u := createUser(t, repo)
+		other := createUser(t, repo)
+
+		_, err := repo.UserUpdate(ctx, &users.User{ID: u.ID, Name: other.Name, Password: u.Password})
+		testConflict(t, "user.name", failure.RuleUnique, err)
+
+		// 失敗した更新は反映されない
+		read, err := repo.UserRead(ctx, u.ID)
+		if err != nil {
+			t.Fatalf("want=nil, got=%v.", err)
+		}
+		testUser(t, u, read)
+	})
+
+	t.Run("update not found", func(t *testing.T) {
+		repo := newRepository(t)
+		id := deletedUserID(t, repo)
+
+		_, err := repo.UserUpdate(ctx, &users.User{ID: id, Name: users.Name(uniqueName("user")), Password: password.FromHash([]byte("password"))})
+		testError(t, failure.ErrNotFound, err)
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		repo := newRepository(t)
+		u := createUser(t, repo)
+
+		err := repo.UserDelete(ctx, u.ID)
+		if err != nil {
+			t.Fatalf("want=nil, got=%v.", err)
+		}
+
+		_, err = repo.UserRead(ctx, u.ID)
+		testError(t, failure.ErrNotFound, err)
+
+		// 削除した名前は再び使える
+		again, err := repo.UserCreate(ctx, users.New(u.Name, password.FromHash([]byte("password"))))
+		if err != nil {
+			t.Fatalf("want=nil, got=%v.", err)
+		}
+		t.Cleanup(func() {
+			repo.UserDelete(ctx, again.ID)
+		})
+
+		if again.ID == u.ID {
+			t.Fatalf("want!=%v, got=%v.", u.ID, again.ID)
+		}
+	})
+
+	t.Run("delete not found", func(t *testing.T) {
+		repo := newRepository(t)
+		id := deletedUserID(t, repo)
+
+		err := repo.UserDelete(ctx, id)
+		testError(t, failure.ErrNotFound, err)
+	})
+}
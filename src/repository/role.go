@@ -0,0 +1,115 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	roles "api.example.com/pkg/role"
+	"api.example.com/repository/model"
+)
+
+// 同じ名前の肩書きが id 以外に存在すれば roles.ErrDuplicateName
+func roleCheckDuplicate(ctx context.Context, tx model.DB, sameName model.Roles, id roles.ID) error {
+	err := sameName.Read(ctx, tx)
+	if err != nil {
+		return err
+	}
+
+	for _, r := range sameName.NewEntity() {
+		if r.ID != id {
+			return roles.ErrDuplicateName
+		}
+	}
+
+	return nil
+}
+
+// company は実在すること
+// sameName は会社の中で名前が一致する肩書き
+func roleCreate(
+	ctx context.Context,
+	tx Transaction,
+	company model.Company,
+	sameName model.Roles,
+	role model.Role,
+) (*roles.Role, error) {
+	err := company.Read(ctx, tx)
+	if err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("repository.RoleCreate: company: %w", err)
+	}
+
+	err = roleCheckDuplicate(ctx, tx, sameName, 0)
+	if err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("repository.RoleCreate: %w", err)
+	}
+
+	err = role.Create(ctx, tx)
+	if err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("repository.RoleCreate: %w", err)
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, fmt.Errorf("repository.RoleCreate: %w", err)
+	}
+
+	return role.NewEntity(), nil
+}
+
+func roleRead(ctx context.Context, db model.DB, role model.Role) (*roles.Role, error) {
+	err := role.Read(ctx, db)
+	if err != nil {
+		return nil, fmt.Errorf("repository.RoleRead: %w", err)
+	}
+
+	return role.NewEntity(), nil
+}
+
+func roleList(ctx context.Context, db model.DB, list model.Roles) ([]*roles.Role, error) {
+	err := list.Read(ctx, db)
+	if err != nil {
+		return nil, fmt.Errorf("repository.RoleList: %w", err)
+	}
+
+	return list.NewEntity(), nil
+}
+
+// sameName は会社の中で名前が一致する肩書き
+func roleUpdate(ctx context.Context, tx Transaction, sameName model.Roles, role model.Role) (*roles.Role, error) {
+	err := roleCheckDuplicate(ctx, tx, sameName, role.NewEntity().ID)
+	if err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("repository.RoleUpdate: %w", err)
+	}
+
+	err = role.Update(ctx, tx)
+	if err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("repository.RoleUpdate: %w", err)
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, fmt.Errorf("repository.RoleUpdate: %w", err)
+	}
+
+	return role.NewEntity(), nil
+}
+
+func roleDelete(ctx context.Context, tx Transaction, role model.Role) error {
+	err := role.Delete(ctx, tx)
+	if err != nil {
+		tx.Rollback()
+		return fmt.Errorf("repository.RoleDelete: %w", err)
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return fmt.Errorf("repository.RoleDelete: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,269 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	roles "api.example.com/pkg/role"
+	"api.example.com/repository/model"
+)
+
+// mock
+type modelRole struct {
+	entity *roles.Role
+	err    error
+	// flags
+	create, read, update, delete, newEntity bool
+	// test
+	t *testing.T
+}
+
+func (r *modelRole) Create(ctx context.Context, tx model.DB) error {
+	r.t.Helper()
+	if r.create {
+		return r.err
+	}
+
+	r.t.Fatal("invalid Create")
+	panic("invalid Create")
+}
+
+func (r *modelRole) Read(ctx context.Context, tx model.DB) error {
+	r.t.Helper()
+	if r.read {
+		return r.err
+	}
+
+	r.t.Fatal("invalid Read")
+	panic("invalid Read")
+}
+
+func (r *modelRole) Update(ctx context.Context, tx model.DB) error {
+	r.t.Helper()
+	if r.update {
+		return r.err
+	}
+
+	r.t.Fatal("invalid Update")
+	panic("invalid Update")
+}
+
+func (r *modelRole) Delete(ctx context.Context, tx model.DB) error {
+	r.t.Helper()
+	if r.delete {
+		return r.err
+	}
+
+	r.t.Fatal("invalid Delete")
+	panic("invalid Delete")
+}
+
+func (r *modelRole) NewEntity() *roles.Role {
+	r.t.Helper()
+	if r.newEntity {
+		return r.entity
+	}
+
+	r.t.Fatal("invalid NewEntity")
+	panic("invalid NewEntity")
+}
+
+// mock
+type modelRoles struct {
+	entity []*roles.Role
+	err    error
+	// flags
+	read, newEntity bool
+	// test
+	t *testing.T
+}
+
+func (l *modelRoles) Read(ctx context.Context, tx model.DB) error {
+	l.t.Helper()
+	if l.read {
+		return l.err
+	}
+
+	l.t.Fatal("invalid Read")
+	panic("invalid Read")
+}
+
+func (l *modelRoles) NewEntity() []*roles.Role {
+	l.t.Helper()
+	if l.newEntity {
+		return l.entity
+	}
+
+	l.t.Fatal("invalid NewEntity")
+	panic("invalid NewEntity")
+}
+
+func TestRoleCreate(t *testing.T) {
+	type test struct {
+		name         string
+		tx           Transaction
+		makeCompany  makeModelCompany
+		makeSameName func(*testing.T) model.Roles
+		makeRole     func(*testing.T) model.Role
+		want         *roles.Role
+		wantErr      error
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := roleCreate(context.Background(), tt.tx, tt.makeCompany(t), tt.makeSameName(t), tt.makeRole(t))
+			if tt.wantErr == nil && err != nil {
+				t.Fatalf("error=%v.", err)
+			}
+
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Fatalf("want-error=%v, error=%v.", tt.wantErr, err)
+			}
+
+			if !reflect.DeepEqual(tt.want, got) {
+				t.Fatalf("want=%v, got=%v.", tt.want, got)
+			}
+		})
+	}
+
+	entity := &roles.Role{
+		ID:        2,
+		CompanyID: 1,
+		Name:      "部長",
+		UpdatedAt: time.Date(2022, 9, 3, 12, 34, 56, 0, time.UTC),
+	}
+	errNotFound := errors.New("not found")
+
+	tests := []*test{
+		{
+			name: "ok",
+			tx:   &transaction{commit: true},
+			makeCompany: func(t *testing.T) model.Company {
+				return &modelCompany{read: true, t: t}
+			},
+			makeSameName: func(t *testing.T) model.Roles {
+				return &modelRoles{entity: []*roles.Role{}, read: true, newEntity: true, t: t}
+			},
+			makeRole: func(t *testing.T) model.Role {
+				return &modelRole{entity: entity, create: true, newEntity: true, t: t}
+			},
+			want:    entity,
+			wantErr: nil,
+		},
+		{
+			name: "company not found",
+			tx:   &transaction{rollback: true},
+			makeCompany: func(t *testing.T) model.Company {
+				return &modelCompany{err: errNotFound, read: true, t: t}
+			},
+			makeSameName: func(t *testing.T) model.Roles {
+				return &modelRoles{t: t}
+			},
+			makeRole: func(t *testing.T) model.Role {
+				return &modelRole{t: t}
+			},
+			want:    nil,
+			wantErr: errNotFound,
+		},
+		{
+			name: "duplicate name",
+			tx:   &transaction{rollback: true},
+			makeCompany: func(t *testing.T) model.Company {
+				return &modelCompany{read: true, t: t}
+			},
+			makeSameName: func(t *testing.T) model.Roles {
+				return &modelRoles{entity: []*roles.Role{entity}, read: true, newEntity: true, t: t}
+			},
+			makeRole: func(t *testing.T) model.Role {
+				return &modelRole{t: t}
+			},
+			want:    nil,
+			wantErr: roles.ErrDuplicateName,
+		},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+}
+
+func TestRoleUpdate(t *testing.T) {
+	type test struct {
+		name         string
+		tx           Transaction
+		makeSameName func(*testing.T) model.Roles
+		makeRole     func(*testing.T) model.Role
+		want         *roles.Role
+		wantErr      error
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := roleUpdate(context.Background(), tt.tx, tt.makeSameName(t), tt.makeRole(t))
+			if tt.wantErr == nil && err != nil {
+				t.Fatalf("error=%v.", err)
+			}
+
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Fatalf("want-error=%v, error=%v.", tt.wantErr, err)
+			}
+
+			if !reflect.DeepEqual(tt.want, got) {
+				t.Fatalf("want=%v, got=%v.", tt.want, got)
+			}
+		})
+	}
+
+	entity := &roles.Role{
+		ID:        2,
+		CompanyID: 1,
+		Name:      "部長",
+		UpdatedAt: time.Date(2022, 9, 3, 12, 34, 56, 0, time.UTC),
+	}
+
+	tests := []*test{
+		{
+			name: "ok",
+			tx:   &transaction{commit: true},
+			makeSameName: func(t *testing.T) model.Roles {
+				return &modelRoles{entity: []*roles.Role{}, read: true, newEntity: true, t: t}
+			},
+			makeRole: func(t *testing.T) model.Role {
+				return &modelRole{entity: entity, update: true, newEntity: true, t: t}
+			},
+			want:    entity,
+			wantErr: nil,
+		},
+		{
+			name: "same name is self",
+			tx:   &transaction{commit: true},
+			makeSameName: func(t *testing.T) model.Roles {
+				return &modelRoles{entity: []*roles.Role{{ID: 2}}, read: true, newEntity: true, t: t}
+			},
+			makeRole: func(t *testing.T) model.Role {
+				return &modelRole{entity: entity, update: true, newEntity: true, t: t}
+			},
+			want:    entity,
+			wantErr: nil,
+		},
+		{
+			name: "duplicate name",
+			tx:   &transaction{rollback: true},
+			makeSameName: func(t *testing.T) model.Roles {
+				return &modelRoles{entity: []*roles.Role{{ID: 3}}, read: true, newEntity: true, t: t}
+			},
+			makeRole: func(t *testing.T) model.Role {
+				return &modelRole{entity: entity, newEntity: true, t: t}
+			},
+			want:    nil,
+			wantErr: roles.ErrDuplicateName,
+		},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+}
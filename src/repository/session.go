@@ -0,0 +1,70 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	sessions "api.example.com/pkg/session"
+	"api.example.com/repository/model"
+)
+
+// user は実在すること
+func sessionCreate(ctx context.Context, tx Transaction, user model.User, session model.Session) (*sessions.Session, error) {
+	err := user.Read(ctx, tx)
+	if err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("repository.SessionCreate: user: %w", err)
+	}
+
+	err = session.Create(ctx, tx)
+	if err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("repository.SessionCreate: %w", err)
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, fmt.Errorf("repository.SessionCreate: %w", err)
+	}
+
+	return session.NewEntity(), nil
+}
+
+func sessionRead(ctx context.Context, db model.DB, session model.Session) (*sessions.Session, error) {
+	err := session.Read(ctx, db)
+	if err != nil {
+		return nil, fmt.Errorf("repository.SessionRead: %w", err)
+	}
+
+	return session.NewEntity(), nil
+}
+
+func sessionDelete(ctx context.Context, tx Transaction, session model.Session) error {
+	err := session.Delete(ctx, tx)
+	if err != nil {
+		tx.Rollback()
+		return fmt.Errorf("repository.SessionDelete: %w", err)
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return fmt.Errorf("repository.SessionDelete: %w", err)
+	}
+
+	return nil
+}
+
+func sessionDeleteAll(ctx context.Context, tx Transaction, list model.Sessions) error {
+	err := list.Delete(ctx, tx)
+	if err != nil {
+		tx.Rollback()
+		return fmt.Errorf("repository.SessionDeleteAll: %w", err)
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return fmt.Errorf("repository.SessionDeleteAll: %w", err)
+	}
+
+	return nil
+}
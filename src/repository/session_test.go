@@ -0,0 +1,357 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	sessions "api.example.com/pkg/session"
+	"api.example.com/repository/model"
+)
+
+type makeModelSession func(*testing.T) model.Session
+
+// mock
+type modelSession struct {
+	entity *sessions.Session
+	err    error
+	// flags
+	create, read, delete, newEntity bool
+	// test
+	t *testing.T
+}
+
+func (s *modelSession) Create(ctx context.Context, tx model.DB) error {
+	s.t.Helper()
+	if s.create {
+		return s.err
+	}
+
+	s.t.Fatal("invalid Create")
+	panic("invalid Create")
+}
+
+func (s *modelSession) Read(ctx context.Context, tx model.DB) error {
+	s.t.Helper()
+	if s.read {
+		return s.err
+	}
+
+	s.t.Fatal("invalid Read")
+	panic("invalid Read")
+}
+
+func (s *modelSession) Delete(ctx context.Context, tx model.DB) error {
+	s.t.Helper()
+	if s.delete {
+		return s.err
+	}
+
+	s.t.Fatal("invalid Delete")
+	panic("invalid Delete")
+}
+
+func (s *modelSession) NewEntity() *sessions.Session {
+	s.t.Helper()
+	if s.newEntity {
+		return s.entity
+	}
+
+	s.t.Fatal("invalid NewEntity")
+	panic("invalid NewEntity")
+}
+
+// mock
+type modelSessions struct {
+	err error
+	// flags
+	delete bool
+	// test
+	t *testing.T
+}
+
+func (l *modelSessions) Delete(ctx context.Context, tx model.DB) error {
+	l.t.Helper()
+	if l.delete {
+		return l.err
+	}
+
+	l.t.Fatal("invalid Delete")
+	panic("invalid Delete")
+}
+
+func TestSessionCreate(t *testing.T) {
+	type test struct {
+		name        string
+		tx          Transaction
+		user        model.User
+		makeSession makeModelSession
+		want        *sessions.Session
+		wantErr     bool
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := sessionCreate(context.Background(), tt.tx, tt.user, tt.makeSession(t))
+			if tt.wantErr != (err != nil) {
+				t.Fatalf("want-error=%v, error=%v.", tt.wantErr, err)
+			}
+
+			if !reflect.DeepEqual(tt.want, got) {
+				t.Fatalf("want=%v, got=%v.", tt.want, got)
+			}
+		})
+	}
+
+	expiresAt := time.Date(2022, 9, 3, 12, 34, 56, 0, time.UTC)
+	tests := []*test{
+		{
+			name: "ok",
+			tx: &transaction{
+				commit: true,
+			},
+			user: &user{read: true},
+			makeSession: func(t *testing.T) model.Session {
+				return &modelSession{
+					entity:    sessions.New("sid", 1, expiresAt),
+					create:    true,
+					newEntity: true,
+					t:         t,
+				}
+			},
+			want:    sessions.New("sid", 1, expiresAt),
+			wantErr: false,
+		},
+		{
+			name: "user not found",
+			tx: &transaction{
+				rollback: true,
+			},
+			user: &user{
+				err:  errors.New("test error"),
+				read: true,
+			},
+			makeSession: func(t *testing.T) model.Session {
+				return &modelSession{t: t}
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "failed create",
+			tx: &transaction{
+				rollback: true,
+			},
+			user: &user{read: true},
+			makeSession: func(t *testing.T) model.Session {
+				return &modelSession{
+					err:    errors.New("test error"),
+					create: true,
+					t:      t,
+				}
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "failed commit",
+			tx: &transaction{
+				errCommit: errors.New("test error"),
+				commit:    true,
+			},
+			user: &user{read: true},
+			makeSession: func(t *testing.T) model.Session {
+				return &modelSession{
+					create: true,
+					t:      t,
+				}
+			},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+}
+
+func TestSessionRead(t *testing.T) {
+	type test struct {
+		name        string
+		db          DB
+		makeSession makeModelSession
+		want        *sessions.Session
+		wantErr     bool
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := sessionRead(context.Background(), tt.db, tt.makeSession(t))
+			if tt.wantErr != (err != nil) {
+				t.Fatalf("want-error=%v, error=%v.", tt.wantErr, err)
+			}
+
+			if !reflect.DeepEqual(tt.want, got) {
+				t.Fatalf("want=%v, got=%v.", tt.want, got)
+			}
+		})
+	}
+
+	expiresAt := time.Date(2022, 9, 3, 12, 34, 56, 0, time.UTC)
+	tests := []*test{
+		{
+			name: "ok",
+			db:   &mockDB{},
+			makeSession: func(t *testing.T) model.Session {
+				return &modelSession{
+					entity:    sessions.New("sid", 1, expiresAt),
+					read:      true,
+					newEntity: true,
+					t:         t,
+				}
+			},
+			want:    sessions.New("sid", 1, expiresAt),
+			wantErr: false,
+		},
+		{
+			name: "failed read",
+			db:   &mockDB{},
+			makeSession: func(t *testing.T) model.Session {
+				return &modelSession{
+					err:  errors.New("test error"),
+					read: true,
+					t:    t,
+				}
+			},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+}
+
+func TestSessionDelete(t *testing.T) {
+	type test struct {
+		name        string
+		tx          Transaction
+		makeSession makeModelSession
+		wantErr     bool
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.name, func(t *testing.T) {
+			err := sessionDelete(context.Background(), tt.tx, tt.makeSession(t))
+			if tt.wantErr != (err != nil) {
+				t.Fatalf("want-error=%v, error=%v.", tt.wantErr, err)
+			}
+		})
+	}
+
+	tests := []*test{
+		{
+			name: "ok",
+			tx: &transaction{
+				commit: true,
+			},
+			makeSession: func(t *testing.T) model.Session {
+				return &modelSession{delete: true, t: t}
+			},
+			wantErr: false,
+		},
+		{
+			name: "failed delete",
+			tx: &transaction{
+				rollback: true,
+			},
+			makeSession: func(t *testing.T) model.Session {
+				return &modelSession{
+					err:    errors.New("test error"),
+					delete: true,
+					t:      t,
+				}
+			},
+			wantErr: true,
+		},
+		{
+			name: "failed commit",
+			tx: &transaction{
+				errCommit: errors.New("test error"),
+				commit:    true,
+			},
+			makeSession: func(t *testing.T) model.Session {
+				return &modelSession{delete: true, t: t}
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+}
+
+func TestSessionDeleteAll(t *testing.T) {
+	type test struct {
+		name         string
+		tx           Transaction
+		makeSessions func(*testing.T) model.Sessions
+		wantErr      bool
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.name, func(t *testing.T) {
+			err := sessionDeleteAll(context.Background(), tt.tx, tt.makeSessions(t))
+			if tt.wantErr != (err != nil) {
+				t.Fatalf("want-error=%v, error=%v.", tt.wantErr, err)
+			}
+		})
+	}
+
+	tests := []*test{
+		{
+			name: "ok",
+			tx: &transaction{
+				commit: true,
+			},
+			makeSessions: func(t *testing.T) model.Sessions {
+				return &modelSessions{delete: true, t: t}
+			},
+			wantErr: false,
+		},
+		{
+			name: "failed delete",
+			tx: &transaction{
+				rollback: true,
+			},
+			makeSessions: func(t *testing.T) model.Sessions {
+				return &modelSessions{
+					err:    errors.New("test error"),
+					delete: true,
+					t:      t,
+				}
+			},
+			wantErr: true,
+		},
+		{
+			name: "failed commit",
+			tx: &transaction{
+				errCommit: errors.New("test error"),
+				commit:    true,
+			},
+			makeSessions: func(t *testing.T) model.Sessions {
+				return &modelSessions{delete: true, t: t}
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+}
@@ -0,0 +1,91 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"api.example.com/repository/model"
+)
+
+// WithTx の中の操作が共有するトランザクション
+// コミットとロールバックは WithTx が行うため、個々の操作からは何もしない
+type unit struct {
+	tx *sql.Tx
+}
+
+func (u *unit) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	return u.tx.ExecContext(ctx, query, args...)
+}
+
+func (u *unit) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	return u.tx.QueryContext(ctx, query, args...)
+}
+
+func (u *unit) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	return u.tx.QueryRowContext(ctx, query, args...)
+}
+
+func (u *unit) Commit() error {
+	return nil
+}
+
+func (u *unit) Rollback() error {
+	return nil
+}
+
+// 書き込みに使うトランザクション
+// WithTx の中であれば共有のトランザクション
+func (r *repository) begin(ctx context.Context) (Transaction, error) {
+	if r.tx != nil {
+		return r.tx, nil
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	return r.bindTx(tx), nil
+}
+
+// 読み込みに使う接続
+// WithTx の中であれば書き込み前の内容を読まないよう、共有のトランザクション
+func (r *repository) conn() model.DB {
+	if r.tx != nil {
+		return r.tx
+	}
+	return r.bindDB(r.db)
+}
+
+// fn がエラーを返すか panic すればロールバックし、そうでなければコミットする
+// WithTx の中で呼び出した場合は外側のトランザクションに含める
+func (r *repository) WithTx(ctx context.Context, fn func(Repository) error) error {
+	if r.tx != nil {
+		return fn(r)
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("repository.WithTx: %w", err)
+	}
+
+	defer func() {
+		if p := recover(); p != nil {
+			tx.Rollback()
+			panic(p)
+		}
+	}()
+
+	err = fn(&repository{db: r.db, tx: r.bindTx(&unit{tx}), dialect: r.dialect})
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return fmt.Errorf("repository.WithTx: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,149 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	users "api.example.com/pkg/user"
+	"api.example.com/pkg/user/password"
+	"api.example.com/repository/model"
+)
+
+func TestUnit(t *testing.T) {
+	// コミットとロールバックは WithTx が行う
+	u := &unit{}
+	if err := u.Commit(); err != nil {
+		t.Fatalf("want=nil, got=%v.", err)
+	}
+	if err := u.Rollback(); err != nil {
+		t.Fatalf("want=nil, got=%v.", err)
+	}
+}
+
+func TestRepository_WithTx(t *testing.T) {
+	tableLock.Lock()
+	defer tableLock.Unlock()
+
+	db := newDB()
+	defer db.Close()
+	defer db.Exec("delete from users")
+
+	pw, err := password.New("password")
+	if err != nil {
+		panic(err)
+	}
+
+	errTest := errors.New("test error")
+
+	type test struct {
+		name string
+		// トランザクションの中の操作
+		fn func(repo Repository) error
+		// fn が panic すること
+		panics     bool
+		wantErr    error
+		wantExists bool
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.name, func(t *testing.T) {
+			defer db.Exec("delete from users")
+
+			var userName users.Name = users.Name(tt.name)
+			func() {
+				defer func() {
+					if p := recover(); (p != nil) != tt.panics {
+						t.Fatalf("want-panic=%v, panic=%v.", tt.panics, p)
+					}
+				}()
+
+				err := (&repository{db: db}).WithTx(context.Background(), func(repo Repository) error {
+					_, err := repo.UserCreate(context.Background(), users.New(userName, pw))
+					if err != nil {
+						return err
+					}
+
+					// トランザクションの中では作成したユーザーを読み込める
+					_, err = repo.UserReadByName(context.Background(), userName)
+					if err != nil {
+						return err
+					}
+
+					return tt.fn(repo)
+				})
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("want=%v, got=%v.", tt.wantErr, err)
+				}
+			}()
+
+			err := model.NewUserFromName(userName).ReadByName(context.Background(), db)
+			if tt.wantExists != (err == nil) {
+				t.Fatalf("want-exists=%v, error=%v.", tt.wantExists, err)
+			}
+		})
+	}
+
+	tests := []*test{
+		{
+			name: "commit",
+			fn: func(Repository) error {
+				return nil
+			},
+			wantErr:    nil,
+			wantExists: true,
+		},
+		{
+			name: "rollback",
+			fn: func(Repository) error {
+				return errTest
+			},
+			wantErr:    errTest,
+			wantExists: false,
+		},
+		{
+			name: "panic",
+			fn: func(Repository) error {
+				panic("test panic")
+			},
+			panics:     true,
+			wantErr:    nil,
+			wantExists: false,
+		},
+		{
+			name: "nested rollback",
+			fn: func(repo Repository) error {
+				// 内側の WithTx は外側のトランザクションに含まれる
+				return repo.WithTx(context.Background(), func(Repository) error {
+					return errTest
+				})
+			},
+			wantErr:    errTest,
+			wantExists: false,
+		},
+		{
+			name: "user tx",
+			fn: func(repo Repository) error {
+				return repo.UserTx(context.Background(), func(users.Repository) error {
+					return nil
+				})
+			},
+			wantErr:    nil,
+			wantExists: true,
+		},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+
+	t.Run("failed BeginTx", func(t *testing.T) {
+		err := (&repository{db: &mockDB{begin: true, err: errTest}}).WithTx(context.Background(), func(Repository) error {
+			t.Fatal("fn must not be called")
+			return nil
+		})
+		if !errors.Is(err, errTest) {
+			t.Fatalf("want=%v, got=%v.", errTest, err)
+		}
+	})
+}
@@ -3,11 +3,12 @@ package repository
 import (
 	users "api.example.com/pkg/user"
 	"api.example.com/repository/model"
+	"context"
 	"fmt"
 )
 
-func UserCreate(tx Transaction, model model.User) (*users.User, error) {
-	err := model.Create(tx)
+func UserCreate(ctx context.Context, tx Transaction, model model.User) (*users.User, error) {
+	err := model.Create(ctx, tx)
 	if err != nil {
 		tx.Rollback()
 		return nil, fmt.Errorf("repository.UserCreate: %w", err)
@@ -21,8 +22,8 @@ func UserCreate(tx Transaction, model model.User) (*users.User, error) {
 	return model.NewEntity(), nil
 }
 
-func UserRead(db DB, model model.User) (*users.User, error) {
-	err := model.Read(db)
+func UserRead(ctx context.Context, db model.DB, model model.User) (*users.User, error) {
+	err := model.Read(ctx, db)
 	if err != nil {
 		return nil, fmt.Errorf("repository.UserRead: %w", err)
 	}
@@ -30,8 +31,26 @@ func UserRead(db DB, model model.User) (*users.User, error) {
 	return model.NewEntity(), nil
 }
 
-func UserUpdate(tx Transaction, model model.User) (*users.User, error) {
-	err := model.Update(tx)
+func UserReadByName(ctx context.Context, db model.DB, model model.User) (*users.User, error) {
+	err := model.ReadByName(ctx, db)
+	if err != nil {
+		return nil, fmt.Errorf("repository.UserReadByName: %w", err)
+	}
+
+	return model.NewEntity(), nil
+}
+
+func UserList(ctx context.Context, db model.DB, list model.Users) ([]*users.User, error) {
+	err := list.Read(ctx, db)
+	if err != nil {
+		return nil, fmt.Errorf("repository.UserList: %w", err)
+	}
+
+	return list.NewEntity(), nil
+}
+
+func UserUpdate(ctx context.Context, tx Transaction, model model.User) (*users.User, error) {
+	err := model.Update(ctx, tx)
 	if err != nil {
 		tx.Rollback()
 		return nil, fmt.Errorf("repository.UserUpdate: %w", err)
@@ -45,8 +64,8 @@ func UserUpdate(tx Transaction, model model.User) (*users.User, error) {
 	return model.NewEntity(), nil
 }
 
-func UserDelete(tx Transaction, model model.User) error {
-	err := model.Delete(tx)
+func UserDelete(ctx context.Context, tx Transaction, model model.User) error {
+	err := model.Delete(ctx, tx)
 	if err != nil {
 		tx.Rollback()
 		return fmt.Errorf("repository.UserDelete: %w", err)
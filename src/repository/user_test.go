@@ -4,6 +4,7 @@ import (
 	users "api.example.com/pkg/user"
 	"api.example.com/pkg/user/password"
 	"api.example.com/repository/model"
+	"context"
 	"errors"
 	"fmt"
 	"reflect"
@@ -15,31 +16,38 @@ type user struct {
 	entity *users.User
 	err    error
 	// flags
-	create, read, update, delete bool
+	create, read, readByName, update, delete bool
 }
 
-func (u *user) Create(tx model.DB) error {
+func (u *user) Create(ctx context.Context, tx model.DB) error {
 	if u.create {
 		return u.err
 	}
 	return fmt.Errorf("invalid create")
 }
 
-func (u *user) Read(tx model.DB) error {
+func (u *user) Read(ctx context.Context, tx model.DB) error {
 	if u.read {
 		return u.err
 	}
 	return fmt.Errorf("invalid read")
 }
 
-func (u *user) Update(tx model.DB) error {
+func (u *user) ReadByName(ctx context.Context, tx model.DB) error {
+	if u.readByName {
+		return u.err
+	}
+	return fmt.Errorf("invalid read by name")
+}
+
+func (u *user) Update(ctx context.Context, tx model.DB) error {
 	if u.update {
 		return u.err
 	}
 	return fmt.Errorf("invald update")
 }
 
-func (u *user) Delete(tx model.DB) error {
+func (u *user) Delete(ctx context.Context, tx model.DB) error {
 	if u.delete {
 		return u.err
 	}
@@ -61,7 +69,7 @@ func TestUserCreate(t *testing.T) {
 
 	do := func(tt *test) {
 		t.Run(tt.name, func(t *testing.T) {
-			got, err := UserCreate(tt.tx, tt.user)
+			got, err := UserCreate(context.Background(), tt.tx, tt.user)
 			if tt.wantErr != (err != nil) {
 				t.Fatalf("want-error=%v, error=%v.", tt.wantErr, err)
 			}
@@ -132,6 +140,20 @@ func TestUserCreate(t *testing.T) {
 	}
 }
 
+// mock
+type userList struct {
+	entity []*users.User
+	err    error
+}
+
+func (l *userList) Read(ctx context.Context, tx model.DB) error {
+	return l.err
+}
+
+func (l *userList) NewEntity() []*users.User {
+	return l.entity
+}
+
 func TestUserRead(t *testing.T) {
 	type test struct {
 		name    string
@@ -143,7 +165,7 @@ func TestUserRead(t *testing.T) {
 
 	do := func(tt *test) {
 		t.Run(tt.name, func(t *testing.T) {
-			got, err := UserRead(tt.db, tt.user)
+			got, err := UserRead(context.Background(), tt.db, tt.user)
 			if tt.wantErr != (err != nil) {
 				t.Fatalf("want-error=%v, error=%v.", tt.wantErr, err)
 			}
@@ -192,6 +214,108 @@ func TestUserRead(t *testing.T) {
 	}
 }
 
+func TestUserReadByName(t *testing.T) {
+	type test struct {
+		name    string
+		db      DB
+		user    model.User
+		want    *users.User
+		wantErr bool
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := UserReadByName(context.Background(), tt.db, tt.user)
+			if tt.wantErr != (err != nil) {
+				t.Fatalf("want-error=%v, error=%v.", tt.wantErr, err)
+			}
+
+			if !reflect.DeepEqual(tt.want, got) {
+				t.Fatalf("want=%v, got=%v.", tt.want, got)
+			}
+		})
+	}
+
+	tests := []*test{
+		{
+			name: "true",
+			db:   &mockDB{},
+			user: &user{
+				entity: &users.User{
+					ID:        1,
+					Name:      "bob",
+					Password:  password.FromHash([]byte("password")),
+					UpdatedAt: time.Date(2022, 8, 9, 12, 34, 56, 0, time.UTC),
+				},
+				readByName: true,
+			},
+			want: &users.User{
+				ID:        1,
+				Name:      "bob",
+				Password:  password.FromHash([]byte("password")),
+				UpdatedAt: time.Date(2022, 8, 9, 12, 34, 56, 0, time.UTC),
+			},
+			wantErr: false,
+		},
+		{
+			name: "failed read",
+			db:   &mockDB{},
+			user: &user{
+				err:        errors.New("test error"),
+				readByName: true,
+			},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+}
+
+func TestUserList(t *testing.T) {
+	type test struct {
+		name    string
+		list    model.Users
+		want    []*users.User
+		wantErr bool
+	}
+
+	do := func(tt *test) {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := UserList(context.Background(), &mockDB{}, tt.list)
+			if tt.wantErr != (err != nil) {
+				t.Fatalf("want-error=%v, error=%v.", tt.wantErr, err)
+			}
+
+			if !reflect.DeepEqual(tt.want, got) {
+				t.Fatalf("want=%v, got=%v.", tt.want, got)
+			}
+		})
+	}
+
+	tests := []*test{
+		{
+			name: "ok",
+			list: &userList{
+				entity: []*users.User{{ID: 1, Name: "bob"}, {ID: 2, Name: "bobby"}},
+			},
+			want: []*users.User{{ID: 1, Name: "bob"}, {ID: 2, Name: "bobby"}},
+		},
+		{
+			name:    "failed read",
+			list:    &userList{err: errors.New("test error")},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		do(tt)
+	}
+}
+
 func TestUserUpdate(t *testing.T) {
 	type test struct {
 		name    string
@@ -203,7 +327,7 @@ func TestUserUpdate(t *testing.T) {
 
 	do := func(tt *test) {
 		t.Run(tt.name, func(t *testing.T) {
-			got, err := UserUpdate(tt.tx, tt.user)
+			got, err := UserUpdate(context.Background(), tt.tx, tt.user)
 			if tt.wantErr != (err != nil) {
 				t.Fatalf("want-error=%v, error=%v.", tt.wantErr, err)
 			}
@@ -284,7 +408,7 @@ func TestUserDelete(t *testing.T) {
 
 	do := func(tt *test) {
 		t.Run(tt.name, func(t *testing.T) {
-			err := UserDelete(tt.tx, tt.user)
+			err := UserDelete(context.Background(), tt.tx, tt.user)
 			if tt.wantErr != (err != nil) {
 				t.Fatalf("want-error=%v, error=%v.", tt.wantErr, err)
 			}